@@ -0,0 +1,443 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// BackupChunkSize is the size of the chunks a backup file is hashed and downloaded by
+const BackupChunkSize = 4 * 1024 * 1024
+
+// BackupFile is a compressed database of a backup
+type BackupFile struct {
+	Name        string // backup folder of the file: beacon, shard<ID> or btc
+	Size        int64
+	Hash        common.Hash // hash of the chunk hashes
+	ChunkHashes []common.Hash
+}
+
+// BackupManifest is published with every backup. It ties the backup files to the block they were taken at.
+// Block headers do not commit to the state roots, so the manifest is signed with the backup manifest key of
+// the serving node and a preloading node only trusts the manifests signed by the key it is configured with.
+// It then fetches the committee signed block from its peers and finds the block and the state roots in the
+// restored database.
+type BackupManifest struct {
+	ChainID     int // -1 for beacon
+	Epoch       uint64
+	Height      uint64
+	BlockHash   common.Hash
+	BeaconRoots *BeaconRootHash `json:",omitempty"`
+	ShardRoots  *ShardRootHash  `json:",omitempty"`
+	ChunkSize   int64
+	Files       []*BackupFile
+	Sig         []byte `json:",omitempty"` // signature of SigningHash by the backup manifest key
+}
+
+// BackupChainName returns the backup folder of a chain
+func BackupChainName(chainID int) string {
+	if chainID == common.BeaconChainID {
+		return "beacon"
+	}
+	return fmt.Sprintf("shard%v", chainID)
+}
+
+// Hash identifies the manifest, a download is resumed only for the same manifest
+func (manifest *BackupManifest) Hash() common.Hash {
+	data, _ := json.Marshal(manifest)
+	return common.HashH(data)
+}
+
+// SigningHash is the hash of the manifest without its signature
+func (manifest *BackupManifest) SigningHash() common.Hash {
+	unsigned := *manifest
+	unsigned.Sig = nil
+	return unsigned.Hash()
+}
+
+// Sign signs the manifest with a hex encoded secp256k1 private key
+func (manifest *BackupManifest) Sign(privateKey string) error {
+	keyBytes, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return err
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), keyBytes)
+	hash := manifest.SigningHash()
+	sig, err := privKey.Sign(hash[:])
+	if err != nil {
+		return err
+	}
+	manifest.Sig = sig.Serialize()
+	return nil
+}
+
+// VerifySig checks the manifest is signed by a hex encoded secp256k1 public key
+func (manifest *BackupManifest) VerifySig(publicKey string) error {
+	keyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return err
+	}
+	pubKey, err := btcec.ParsePubKey(keyBytes, btcec.S256())
+	if err != nil {
+		return err
+	}
+	sig, err := btcec.ParseDERSignature(manifest.Sig, btcec.S256())
+	if err != nil {
+		return fmt.Errorf("backup manifest of %v is not signed: %v", BackupChainName(manifest.ChainID), err)
+	}
+	hash := manifest.SigningHash()
+	if !sig.Verify(hash[:], pubKey) {
+		return fmt.Errorf("backup manifest of %v is not signed by the trusted key", BackupChainName(manifest.ChainID))
+	}
+	return nil
+}
+
+func (manifest *BackupManifest) GetFile(name string) (*BackupFile, error) {
+	for _, file := range manifest.Files {
+		if file.Name == name {
+			return file, nil
+		}
+	}
+	return nil, fmt.Errorf("backup of %v has no file %v", BackupChainName(manifest.ChainID), name)
+}
+
+// ValidateSanity checks the manifest is well formed before anything is downloaded
+func (manifest *BackupManifest) ValidateSanity(chainID int) error {
+	if manifest.ChainID != chainID {
+		return fmt.Errorf("receive backup manifest of chain %v, expect chain %v", manifest.ChainID, chainID)
+	}
+	if chainID == common.BeaconChainID && manifest.BeaconRoots == nil {
+		return errors.New("beacon backup manifest has no beacon roots")
+	}
+	if chainID != common.BeaconChainID && manifest.ShardRoots == nil {
+		return errors.New("shard backup manifest has no shard roots")
+	}
+	if manifest.ChunkSize <= 0 {
+		return fmt.Errorf("invalid backup chunk size %v", manifest.ChunkSize)
+	}
+	if _, err := manifest.GetFile(BackupChainName(chainID)); err != nil {
+		return err
+	}
+	for _, file := range manifest.Files {
+		if int64(len(file.ChunkHashes)) != (file.Size+manifest.ChunkSize-1)/manifest.ChunkSize {
+			return fmt.Errorf("backup file %v has %v chunk hashes for %v bytes", file.Name, len(file.ChunkHashes), file.Size)
+		}
+		if hashChunkHashes(file.ChunkHashes) != file.Hash {
+			return fmt.Errorf("backup file %v hash mismatch", file.Name)
+		}
+	}
+	return nil
+}
+
+// VerifyChunk checks a downloaded chunk against its hash in the manifest
+func (file *BackupFile) VerifyChunk(index int, data []byte) error {
+	if index < 0 || index >= len(file.ChunkHashes) {
+		return fmt.Errorf("backup file %v has no chunk %v", file.Name, index)
+	}
+	if common.HashH(data) != file.ChunkHashes[index] {
+		return fmt.Errorf("chunk %v of backup file %v hash mismatch", index, file.Name)
+	}
+	return nil
+}
+
+// isBackupName refuses names escaping the backup folder, they come from rpc requests
+func isBackupName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+func hashChunkHashes(hashes []common.Hash) common.Hash {
+	data := []byte{}
+	for _, hash := range hashes {
+		data = append(data, hash[:]...)
+	}
+	return common.HashH(data)
+}
+
+// NewBackupFile hashes a backup file chunk by chunk
+func NewBackupFile(name string, path string, chunkSize int64) (*BackupFile, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	file := &BackupFile{Name: name}
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(fd, buf)
+		if n > 0 {
+			file.ChunkHashes = append(file.ChunkHashes, common.HashH(buf[:n]))
+			file.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	file.Hash = hashChunkHashes(file.ChunkHashes)
+	return file, nil
+}
+
+// writeBackupManifest hashes the backup files of an epoch and writes their manifest in backup/manifest/<chain>,
+// it must be called after the backups of the epoch succeed
+func (blockchain *BlockChain) writeBackupManifest(manifest *BackupManifest, fileNames ...string) error {
+	db := blockchain.GetBeaconChainDatabase()
+	manifest.ChunkSize = BackupChunkSize
+	backupFolder := ""
+	for _, name := range fileNames {
+		epoch, path := db.LatestBackup(fmt.Sprintf("../../backup/%v", name))
+		if uint64(epoch) != manifest.Epoch {
+			return NewBlockChainError(BackupManifestError, fmt.Errorf("latest backup of %v is epoch %v, expect %v", name, epoch, manifest.Epoch))
+		}
+		file, err := NewBackupFile(name, path, manifest.ChunkSize)
+		if err != nil {
+			return NewBlockChainError(BackupManifestError, err)
+		}
+		manifest.Files = append(manifest.Files, file)
+		backupFolder = filepath.Dir(filepath.Dir(path))
+	}
+	if config.Config().BackupManifestKey == "" {
+		Logger.log.Warnf("Backup manifest of %v is not signed, preloading nodes will refuse it", BackupChainName(manifest.ChainID))
+	} else if err := manifest.Sign(config.Config().BackupManifestKey); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	manifestFolder := filepath.Join(backupFolder, "manifest", BackupChainName(manifest.ChainID))
+	if err := os.MkdirAll(manifestFolder, 0700); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(manifestFolder, strconv.FormatUint(manifest.Epoch, 10)), data, 0600); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	//only the manifest of the latest backup is served
+	files, err := ioutil.ReadDir(manifestFolder)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	for _, file := range files {
+		if file.Name() != strconv.FormatUint(manifest.Epoch, 10) {
+			os.Remove(filepath.Join(manifestFolder, file.Name()))
+		}
+	}
+	return nil
+}
+
+// GetLatestBackupManifest returns the manifest of the latest backup of a chain
+func (blockchain *BlockChain) GetLatestBackupManifest(chainName string) (*BackupManifest, error) {
+	if !isBackupName(chainName) {
+		return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("invalid chain name %v", chainName))
+	}
+	epoch, path := blockchain.GetBeaconChainDatabase().LatestBackup(fmt.Sprintf("../../backup/manifest/%v", chainName))
+	if path == "" {
+		return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("no backup manifest of %v", chainName))
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, NewBlockChainError(BackupManifestError, err)
+	}
+	manifest := &BackupManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, NewBlockChainError(BackupManifestError, err)
+	}
+	if manifest.Epoch != uint64(epoch) {
+		return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("manifest %v is of epoch %v", path, manifest.Epoch))
+	}
+	return manifest, nil
+}
+
+// GetBackupChunk reads a chunk of a backup file, the backup must still be the latest of its folder
+func (blockchain *BlockChain) GetBackupChunk(epoch uint64, fileName string, index int) ([]byte, error) {
+	if !isBackupName(fileName) {
+		return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("invalid backup file name %v", fileName))
+	}
+	latestEpoch, path := blockchain.GetBeaconChainDatabase().LatestBackup(fmt.Sprintf("../../backup/%v", fileName))
+	if path == "" || uint64(latestEpoch) != epoch {
+		return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("backup %v of epoch %v is not served", fileName, epoch))
+	}
+	if index < 0 {
+		return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("invalid chunk %v", index))
+	}
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, NewBlockChainError(BackupManifestError, err)
+	}
+	defer fd.Close()
+	buf := make([]byte, BackupChunkSize)
+	n, err := fd.ReadAt(buf, int64(index)*BackupChunkSize)
+	if err != nil && err != io.EOF {
+		return nil, NewBlockChainError(BackupManifestError, err)
+	}
+	return buf[:n], nil
+}
+
+// VerifyBackupDatabase checks a restored backup before it replaces the database of its chain:
+// block is the committee signed block of the signed manifest fetched from peers, it must be stored in the backup
+// with the state roots of the manifest. The beacon committee is read from the signed roots, the shard committee
+// only from the beacon chain of the node.
+// The stored views are reduced to the view of the manifest block so the chain restarts from the verified view.
+func (blockchain *BlockChain) VerifyBackupDatabase(manifest *BackupManifest, block types.BlockInterface, db incdb.Database) error {
+	if *block.Hash() != manifest.BlockHash || block.GetHeight() != manifest.Height {
+		return NewBlockChainError(BackupManifestError, fmt.Errorf("block %v at height %v does not match manifest block %v at height %v",
+			block.Hash().String(), block.GetHeight(), manifest.BlockHash.String(), manifest.Height))
+	}
+	if manifest.ChainID == common.BeaconChainID {
+		return blockchain.verifyBeaconBackupDatabase(manifest, block, db)
+	}
+	return blockchain.verifyShardBackupDatabase(manifest, block, db)
+}
+
+func (blockchain *BlockChain) verifyBeaconBackupDatabase(manifest *BackupManifest, block types.BlockInterface, db incdb.Database) error {
+	roots, err := GetBeaconRootsHashByBlockHash(db, manifest.BlockHash)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	if *roots != *manifest.BeaconRoots {
+		return NewBlockChainError(BackupManifestError, errors.New("beacon roots of the backup do not match the manifest"))
+	}
+	if _, err := rawdbv2.GetBeaconBlockByHash(db, manifest.BlockHash); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	consensusStateDB, err := statedb.NewWithPrefixTrie(roots.ConsensusStateDBRootHash, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	committee := statedb.GetBeaconCommittee(consensusStateDB)
+	if err := blockchain.BeaconChain.ValidateBlockSignatures(block, committee); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+
+	viewsBytes, err := rawdbv2.GetBeaconViews(db)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	view, err := findBackupView(viewsBytes, manifest.BlockHash, func(view json.RawMessage) (bool, error) {
+		v := BeaconRootHash{}
+		if err := json.Unmarshal(view, &v); err != nil {
+			return false, err
+		}
+		return v == *roots, nil
+	})
+	if err != nil {
+		return err
+	}
+	viewsBytes, err = json.Marshal([]json.RawMessage{view})
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	if err := rawdbv2.StoreBeaconViews(db, viewsBytes); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) verifyShardBackupDatabase(manifest *BackupManifest, block types.BlockInterface, db incdb.Database) error {
+	shardID := byte(manifest.ChainID)
+	roots, err := GetShardRootsHashByBlockHash(db, shardID, manifest.BlockHash)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	if *roots != *manifest.ShardRoots {
+		return NewBlockChainError(BackupManifestError, errors.New("shard roots of the backup do not match the manifest"))
+	}
+	if _, err := rawdbv2.GetShardBlockByHash(db, manifest.BlockHash); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	if err := blockchain.VerifyPreloadShardBlock(block); err != nil {
+		return err
+	}
+
+	viewsBytes, err := rawdbv2.GetShardBestState(db, shardID)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	view, err := findBackupView(viewsBytes, manifest.BlockHash, func(view json.RawMessage) (bool, error) {
+		v := struct {
+			ShardID byte
+			ShardRootHash
+		}{}
+		if err := json.Unmarshal(view, &v); err != nil {
+			return false, err
+		}
+		return v.ShardID == shardID && v.ShardRootHash == *roots, nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := rawdbv2.StoreShardBestState(db, shardID, []json.RawMessage{view}); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	return nil
+}
+
+// VerifyPreloadShardBlock checks a shard block is signed by its committee in the beacon chain of the node,
+// so a shard backup can only be preloaded after the beacon chain reached the block
+func (blockchain *BlockChain) VerifyPreloadShardBlock(block types.BlockInterface) error {
+	shardBlock, ok := block.(*types.ShardBlock)
+	if !ok {
+		return NewBlockChainError(BackupManifestError, fmt.Errorf("block %v is not a shard block", block.Hash().String()))
+	}
+	shardID := shardBlock.Header.ShardID
+	committeeFromBlock := shardBlock.Header.CommitteeFromBlock
+	if committeeFromBlock.IsZeroValue() {
+		beaconHeight := shardBlock.Header.BeaconHeight
+		hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), beaconHeight)
+		if err != nil {
+			return NewBlockChainError(BackupManifestError, fmt.Errorf("beacon block %v of shard block %v is not synced: %v", beaconHeight, block.Hash().String(), err))
+		}
+		committeeFromBlock = *hash
+	}
+	committee, err := blockchain.getShardCommitteeFromBeaconHash(committeeFromBlock, shardID)
+	if err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	if err := blockchain.ShardChain[shardID].ValidateBlockSignatures(block, committee); err != nil {
+		return NewBlockChainError(BackupManifestError, err)
+	}
+	return nil
+}
+
+// findBackupView returns the stored view of the manifest block, matchRoots checks the roots of the view
+func findBackupView(viewsBytes []byte, blockHash common.Hash, matchRoots func(json.RawMessage) (bool, error)) (json.RawMessage, error) {
+	views := []json.RawMessage{}
+	if err := json.Unmarshal(viewsBytes, &views); err != nil {
+		return nil, NewBlockChainError(BackupManifestError, err)
+	}
+	for _, view := range views {
+		header := struct {
+			BestBlockHash common.Hash
+		}{}
+		if err := json.Unmarshal(view, &header); err != nil {
+			return nil, NewBlockChainError(BackupManifestError, err)
+		}
+		if header.BestBlockHash != blockHash {
+			continue
+		}
+		ok, err := matchRoots(view)
+		if err != nil {
+			return nil, NewBlockChainError(BackupManifestError, err)
+		}
+		if !ok {
+			return nil, NewBlockChainError(BackupManifestError, errors.New("state roots of the backup view do not match the manifest"))
+		}
+		return view, nil
+	}
+	return nil, NewBlockChainError(BackupManifestError, fmt.Errorf("backup has no view of block %v", blockHash.String()))
+}
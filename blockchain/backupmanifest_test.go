@@ -0,0 +1,87 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupManifest_ValidateSanity(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_backupmanifest")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	data := make([]byte, 2500)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	path := filepath.Join(dir, "shard0")
+	assert.Nil(t, ioutil.WriteFile(path, data, 0600))
+
+	file, err := NewBackupFile("shard0", path, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2500), file.Size)
+	assert.Equal(t, 3, len(file.ChunkHashes))
+	assert.Nil(t, file.VerifyChunk(0, data[:1000]))
+	assert.Nil(t, file.VerifyChunk(2, data[2000:]))
+	assert.NotNil(t, file.VerifyChunk(1, data[:1000]))
+	assert.NotNil(t, file.VerifyChunk(3, data[2000:]))
+
+	manifest := &BackupManifest{
+		ChainID:    0,
+		Epoch:      10,
+		ShardRoots: &ShardRootHash{},
+		ChunkSize:  1000,
+		Files:      []*BackupFile{file},
+	}
+	assert.Nil(t, manifest.ValidateSanity(0))
+	assert.NotNil(t, manifest.ValidateSanity(1))
+	assert.NotNil(t, manifest.ValidateSanity(common.BeaconChainID))
+
+	file.ChunkHashes[1] = common.HashH([]byte{1})
+	assert.NotNil(t, manifest.ValidateSanity(0))
+	file.Hash = hashChunkHashes(file.ChunkHashes)
+	assert.Nil(t, manifest.ValidateSanity(0))
+
+	file.ChunkHashes = file.ChunkHashes[:2]
+	file.Hash = hashChunkHashes(file.ChunkHashes)
+	assert.NotNil(t, manifest.ValidateSanity(0))
+}
+
+func TestBackupManifest_VerifySig(t *testing.T) {
+	privKey, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), common.HashB([]byte("backup manifest key")))
+	_, otherPubKey := btcec.PrivKeyFromBytes(btcec.S256(), common.HashB([]byte("other key")))
+	trustedKey := hex.EncodeToString(pubKey.SerializeCompressed())
+
+	manifest := &BackupManifest{
+		ChainID:    0,
+		Epoch:      10,
+		BlockHash:  common.HashH([]byte("block")),
+		ShardRoots: &ShardRootHash{},
+		ChunkSize:  1000,
+	}
+	assert.NotNil(t, manifest.VerifySig(trustedKey))
+
+	assert.Nil(t, manifest.Sign(hex.EncodeToString(privKey.Serialize())))
+	assert.Nil(t, manifest.VerifySig(trustedKey))
+	assert.NotNil(t, manifest.VerifySig(hex.EncodeToString(otherPubKey.SerializeCompressed())))
+
+	// the roots can not be changed without the key
+	manifest.ShardRoots.ConsensusStateDBRootHash = common.HashH([]byte("forged root"))
+	assert.NotNil(t, manifest.VerifySig(trustedKey))
+}
+
+func TestIsBackupName(t *testing.T) {
+	for _, name := range []string{"beacon", "shard0", "btc"} {
+		assert.True(t, isBackupName(name), name)
+	}
+	for _, name := range []string{"", ".", "..", "../beacon", "manifest/beacon", "/etc"} {
+		assert.False(t, isBackupName(name), name)
+	}
+}
@@ -53,6 +53,7 @@ type BeaconBestState struct {
 	CurrentRandomNumber              int64                `json:"CurrentRandomNumber"`
 	CurrentRandomTimeStamp           int64                `json:"CurrentRandomTimeStamp"` // random timestamp for this epoch
 	IsGetRandomNumber                bool                 `json:"IsGetRandomNumber"`
+	RandomBeaconValue                common.Hash          `json:"RandomBeaconValue"` // value of the last random beacon instruction
 	MaxBeaconCommitteeSize           int                  `json:"MaxBeaconCommitteeSize"`
 	MinBeaconCommitteeSize           int                  `json:"MinBeaconCommitteeSize"`
 	MaxShardCommitteeSize            int                  `json:"MaxShardCommitteeSize"`
@@ -12,8 +12,6 @@ import (
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/trie"
-	"io/ioutil"
-	"os"
 	"reflect"
 	"testing"
 )
@@ -89,11 +87,7 @@ var _ = func() (_ struct{}) {
 
 	incognitoKeys, _ = incognitokey.CommitteeBase58KeyListToStruct(keys)
 	Logger.Init(common.NewBackend(nil).Logger("test", true))
-	dbPath, err := ioutil.TempDir(os.TempDir(), "test")
-	if err != nil {
-		panic(err)
-	}
-	diskBD, _ := incdb.Open("leveldb", dbPath)
+	diskBD, _ := incdb.Open("memdb")
 	warperDBStatedbTest = statedb.NewDatabaseAccessWarper(diskBD)
 	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	return
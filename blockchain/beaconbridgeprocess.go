@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/pkg/errors"
@@ -49,6 +50,9 @@ func (blockchain *BlockChain) processBridgeInstructions(bridgeStateDB *statedb.S
 		case strconv.Itoa(metadata.IssuingSOLRequestMeta):
 			updatingInfoByTokenID, err = blockchain.processIssuingBridgeSolReq(bridgeStateDB, inst, updatingInfoByTokenID, statedb.InsertSOLTxHashIssued, false)
 
+		case strconv.Itoa(metadata.IssuingEVMNetworkRequestMeta):
+			updatingInfoByTokenID, err = blockchain.processIssuingEVMNetworkReq(bridgeStateDB, inst, updatingInfoByTokenID)
+
 		case strconv.Itoa(metadata.IssuingRequestMeta):
 			updatingInfoByTokenID, err = blockchain.processIssuingReq(bridgeStateDB, inst, updatingInfoByTokenID)
 
@@ -67,6 +71,14 @@ func (blockchain *BlockChain) processBridgeInstructions(bridgeStateDB *statedb.S
 		case strconv.Itoa(metadata.BurningSOLConfirmMeta), strconv.Itoa(metadata.BurningSOLConfirmForDepositToSCMeta):
 			updatingInfoByTokenID, err = blockchain.processBurningSOLReq(bridgeStateDB, inst, updatingInfoByTokenID, "")
 
+		default:
+			metaType, errAtoi := strconv.Atoi(inst[0])
+			if errAtoi != nil {
+				continue
+			}
+			if evmNetwork, found := config.Param().GetEVMNetworkByBurningConfirmMeta(metaType); found {
+				updatingInfoByTokenID, err = blockchain.processBurningReq(bridgeStateDB, inst, updatingInfoByTokenID, evmNetwork.Prefix)
+			}
 		}
 		if err != nil {
 			return err
@@ -98,6 +110,23 @@ func (blockchain *BlockChain) processBridgeInstructions(bridgeStateDB *statedb.S
 	return nil
 }
 
+// processIssuingEVMNetworkReq stores the issued marker of an accepted shielding under the network it comes from
+func (blockchain *BlockChain) processIssuingEVMNetworkReq(bridgeStateDB *statedb.StateDB, instruction []string, updatingInfoByTokenID map[common.Hash]metadata.UpdatingInfo) (map[common.Hash]metadata.UpdatingInfo, error) {
+	var networkID uint8
+	if len(instruction) == 4 && instruction[2] == "accepted" {
+		acceptedInst, err := metadata.ParseEVMIssuingInstAcceptedContent(instruction[3])
+		if err != nil {
+			Logger.log.Warn("WARNING: an error occurred while parsing content of accepted evm network issuance instruction: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		networkID = acceptedInst.NetworkID
+	}
+	insertEVMTxHashIssued := func(stateDB *statedb.StateDB, uniqueEVMTx []byte) error {
+		return statedb.InsertEVMNetworkTxHashIssued(stateDB, networkID, uniqueEVMTx)
+	}
+	return blockchain.processIssuingBridgeReq(bridgeStateDB, instruction, updatingInfoByTokenID, insertEVMTxHashIssued, false)
+}
+
 func (blockchain *BlockChain) processIssuingBridgeReq(bridgeStateDB *statedb.StateDB, instruction []string, updatingInfoByTokenID map[common.Hash]metadata.UpdatingInfo, insertEVMTxHashIssued func(*statedb.StateDB, []byte) error, isPRV bool) (map[common.Hash]metadata.UpdatingInfo, error) {
 	if len(instruction) != 4 {
 		return updatingInfoByTokenID, nil // skip the instruction
@@ -247,7 +276,8 @@ func (blockchain *BlockChain) processContractingReq(
 }
 
 func (blockchain *BlockChain) processIssuingBridgeSolReq(bridgeStateDB *statedb.StateDB, instruction []string, updatingInfoByTokenID map[common.Hash]metadata.UpdatingInfo, insertSolTxHashIssued func(*statedb.StateDB, []byte) error, isPRV bool) (map[common.Hash]metadata.UpdatingInfo, error) {
-	if len(instruction) != 4 {
+	// rejected instructions may carry the reject reason as the last element
+	if len(instruction) != 4 && !(len(instruction) == 5 && instruction[2] == "rejected") {
 		return updatingInfoByTokenID, nil // skip the instruction
 	}
 	if instruction[2] == "rejected" {
@@ -256,7 +286,11 @@ func (blockchain *BlockChain) processIssuingBridgeSolReq(bridgeStateDB *statedb.
 			Logger.log.Warn("WARNING: an error occurred while building tx request id in bytes from string: ", err)
 			return updatingInfoByTokenID, nil
 		}
-		err = statedb.TrackBridgeReqWithStatus(bridgeStateDB, *txReqID, common.BridgeRequestRejectedStatus)
+		if len(instruction) == 5 {
+			err = statedb.TrackBridgeReqWithRejectReason(bridgeStateDB, *txReqID, instruction[4])
+		} else {
+			err = statedb.TrackBridgeReqWithStatus(bridgeStateDB, *txReqID, common.BridgeRequestRejectedStatus)
+		}
 		if err != nil {
 			Logger.log.Warn("WARNING: an error occurred while tracking bridge request with rejected status to leveldb: ", err)
 		}
@@ -438,7 +472,7 @@ func (blockchain *BlockChain) updateBridgeIssuanceStatus(bridgeStateDB *statedb.
 		if metaType == metadata.IssuingETHRequestMeta || metaType == metadata.IssuingRequestMeta ||
 			metaType == metadata.IssuingBSCRequestMeta || metaType == metadata.IssuingPRVERC20RequestMeta ||
 			metaType == metadata.IssuingPRVBEP20RequestMeta || metaType == metadata.IssuingPLGRequestMeta ||
-			metaType == metadata.IssuingSOLRequestMeta {
+			metaType == metadata.IssuingSOLRequestMeta || metaType == metadata.IssuingEVMNetworkRequestMeta {
 			reqTxID = *tx.Hash()
 			err = statedb.TrackBridgeReqWithStatus(bridgeStateDB, reqTxID, common.BridgeRequestProcessingStatus)
 			if err != nil {
@@ -447,7 +481,7 @@ func (blockchain *BlockChain) updateBridgeIssuanceStatus(bridgeStateDB *statedb.
 		}
 		if metaType == metadata.IssuingETHResponseMeta || metaType == metadata.IssuingBSCResponseMeta ||
 			metaType == metadata.IssuingPRVERC20ResponseMeta || metaType == metadata.IssuingPRVBEP20ResponseMeta ||
-			metaType == metadata.IssuingPLGResponseMeta {
+			metaType == metadata.IssuingPLGResponseMeta || metaType == metadata.IssuingEVMNetworkResponseMeta {
 			meta := tx.GetMetadata().(*metadata.IssuingEVMResponse)
 			reqTxID = meta.RequestedTxID
 			err = statedb.TrackBridgeReqWithStatus(bridgeStateDB, reqTxID, common.BridgeRequestAcceptedStatus)
@@ -27,7 +27,7 @@ func (blockchain *BlockChain) buildBridgeInstructions(stateDB *statedb.StateDB,
 		if len(inst) < 2 {
 			continue
 		}
-		if inst[0] == instruction.SET_ACTION || inst[0] == instruction.STAKE_ACTION || inst[0] == instruction.SWAP_ACTION || inst[0] == instruction.RANDOM_ACTION || inst[0] == instruction.ASSIGN_ACTION {
+		if inst[0] == instruction.SET_ACTION || inst[0] == instruction.STAKE_ACTION || inst[0] == instruction.SWAP_ACTION || inst[0] == instruction.RANDOM_ACTION || inst[0] == instruction.RANDOM_BEACON_ACTION || inst[0] == instruction.ASSIGN_ACTION {
 			continue
 		}
 
@@ -101,6 +101,11 @@ func (blockchain *BlockChain) buildBridgeInstructions(stateDB *statedb.StateDB,
 			burningConfirm, err = buildBurningSOLConfirmInst(stateDB, metadata.BurningSOLConfirmForDepositToSCMeta, inst, beaconHeight, "")
 			newInst = [][]string{burningConfirm}
 
+		case metadata.BurningEVMNetworkRequestMeta:
+			burningConfirm := []string{}
+			burningConfirm, err = buildBurningEVMNetworkConfirmInst(stateDB, inst, beaconHeight)
+			newInst = [][]string{burningConfirm}
+
 		default:
 			continue
 		}
@@ -173,6 +178,29 @@ func buildBurningConfirmInst(
 	}, nil
 }
 
+// buildBurningEVMNetworkConfirmInst resolves the confirm meta type and the token prefix of the burning request's network
+// from the evm network registry, then builds the burning confirm instruction as for the other evm networks
+func buildBurningEVMNetworkConfirmInst(
+	stateDB *statedb.StateDB,
+	inst []string,
+	height uint64,
+) ([]string, error) {
+	var burningReqAction BurningReqAction
+	err := decodeContent(inst[1], &burningReqAction)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid BurningRequest")
+	}
+	networkID := burningReqAction.Meta.NetworkID
+	evmNetwork, found := config.Param().GetEVMNetwork(networkID)
+	if !found {
+		return nil, errors.Errorf("evm network %v is not registered", networkID)
+	}
+	if !evmNetwork.IsActive(height) {
+		return nil, errors.Errorf("evm network %v is not activated at beacon height %v", networkID, height)
+	}
+	return buildBurningConfirmInst(stateDB, evmNetwork.BurningConfirmMeta, inst, height, evmNetwork.Prefix)
+}
+
 // buildBurningPRVEVMConfirmInst builds on beacon an instruction confirming a tx burning PRV-EVM-token
 func buildBurningPRVEVMConfirmInst(
 	burningMetaType int,
@@ -0,0 +1,154 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// buildInstructionsForEquivocationEvidence verifies both votes of the evidence against the shard committee they were signed with,
+// the offender is swapped out and slashed by the beacon committee state when processing the instruction
+func (blockchain *BlockChain) buildInstructionsForEquivocationEvidence(
+	beaconBestState *BeaconBestState,
+	contentStr string,
+	beaconHeight uint64,
+	equivocatingValidators map[string]bool,
+) ([][]string, error) {
+	if beaconHeight < config.Param().ConsensusParam.EquivocationSlashingHeight {
+		return nil, fmt.Errorf("equivocation slashing is not enabled at beacon height %v", beaconHeight)
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return nil, err
+	}
+	var action metadata.EquivocationEvidenceAction
+	err = json.Unmarshal(contentBytes, &action)
+	if err != nil {
+		return nil, err
+	}
+	evidence := action.Meta
+	if err := evidence.CheckConflict(); err != nil {
+		return nil, err
+	}
+
+	committees, err := blockchain.GetShardCommitteeFromBeaconHash(evidence.VoteA.CommitteeFromBlock, byte(evidence.VoteA.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	var offender *incognitokey.CommitteePublicKey
+	for i := range committees {
+		if committees[i].GetMiningKeyBase58(common.BlsConsensus) == evidence.VoteA.Validator {
+			offender = &committees[i]
+			break
+		}
+	}
+	if offender == nil {
+		return nil, fmt.Errorf("validator %v is not in committee of shard %v from block %v",
+			evidence.VoteA.Validator, evidence.VoteA.ChainID, evidence.VoteA.CommitteeFromBlock.String())
+	}
+	briPublicKey := offender.MiningPubKey[common.BridgeConsensus]
+	if err := verifyEquivocationVote(evidence.VoteA, briPublicKey); err != nil {
+		return nil, err
+	}
+	if err := verifyEquivocationVote(evidence.VoteB, briPublicKey); err != nil {
+		return nil, err
+	}
+
+	publicKey, err := offender.ToBase58()
+	if err != nil {
+		return nil, err
+	}
+	if equivocatingValidators[publicKey] {
+		return nil, fmt.Errorf("equivocation of %v is already proven in this block", publicKey)
+	}
+	_, has, err := statedb.GetStakerInfo(beaconBestState.GetBeaconConsensusStateDB(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, fmt.Errorf("validator %v is not a staker", publicKey)
+	}
+	// the same evidence can not slash the validator again after it stakes back
+	lastEvidence, has, err := statedb.GetEquivocationEvidence(beaconBestState.GetBeaconSlashStateDB(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if has && lastEvidence.ProposeTimeSlot() >= evidence.VoteA.ProposeTimeSlot {
+		return nil, fmt.Errorf("equivocation of %v at timeslot %v is already proven", publicKey, evidence.VoteA.ProposeTimeSlot)
+	}
+	equivocatingValidators[publicKey] = true
+
+	inst := instruction.NewEquivocationInstructionWithValue(
+		publicKey,
+		evidence.VoteA.ChainID,
+		evidence.VoteA.ProposeTimeSlot,
+		[]string{evidence.VoteA.BlockHash, evidence.VoteB.BlockHash},
+		action.TxReqID,
+	)
+	return [][]string{inst.ToString()}, nil
+}
+
+// verifyEquivocationVote checks the vote is confirmed by briPublicKey, the bridge mining key of its validator
+func verifyEquivocationVote(vote metadata.EquivocationVote, briPublicKey []byte) error {
+	voteData := signer.VoteData{
+		PrevBlockHash:      vote.PrevBlockHash,
+		BlockHeight:        vote.BlockHeight,
+		BlockHash:          vote.BlockHash,
+		Validator:          vote.Validator,
+		BLS:                vote.BLS,
+		BRI:                vote.BRI,
+		ProduceTimeSlot:    vote.ProduceTimeSlot,
+		ProposeTimeSlot:    vote.ProposeTimeSlot,
+		CommitteeFromBlock: vote.CommitteeFromBlock,
+		ChainID:            vote.ChainID,
+	}
+	confirmationHash := voteData.ConfirmationHash()
+	ok, err := bridgesig.Verify(briPublicKey, confirmationHash.GetBytes(), vote.Confirmation)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("vote for block %v is not signed by validator %v", vote.BlockHash, vote.Validator)
+	}
+	return nil
+}
+
+// storeEquivocationEvidences records the proven equivocations of the beacon block in the slash state db
+func (blockchain *BlockChain) storeEquivocationEvidences(slashStateDB *statedb.StateDB, beaconHeight uint64, instructions [][]string) error {
+	slashingPercent := config.Param().ConsensusParam.EquivocationSlashingPercent
+	if slashingPercent > 100 {
+		slashingPercent = 100
+	}
+	for _, inst := range instructions {
+		if len(inst) == 0 || inst[0] != instruction.EQUIVOCATION_ACTION {
+			continue
+		}
+		equivocationInstruction, err := instruction.ValidateAndImportEquivocationInstructionFromString(inst)
+		if err != nil {
+			return err
+		}
+		evidence := statedb.NewEquivocationEvidenceStateWithValue(
+			equivocationInstruction.CommitteePublicKey,
+			equivocationInstruction.ChainID,
+			equivocationInstruction.ProposeTimeSlot,
+			equivocationInstruction.BlockHashes,
+			equivocationInstruction.TxReqID,
+			beaconHeight,
+			slashingPercent,
+		)
+		err = statedb.StoreEquivocationEvidence(slashStateDB, evidence)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
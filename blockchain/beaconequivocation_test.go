@@ -0,0 +1,55 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func TestVerifyEquivocationVote(t *testing.T) {
+	config.AbortParam()
+	config.Param().ConsensusParam.ByzantineDetectorHeight = 5
+
+	sk, pk := bridgesig.KeyGen([]byte("validator"))
+	_, otherPk := bridgesig.KeyGen([]byte("other validator"))
+	voteData := signer.VoteData{
+		PrevBlockHash:      common.HashH([]byte("prev")).String(),
+		BlockHeight:        10,
+		BlockHash:          common.HashH([]byte("a")).String(),
+		Validator:          "validator",
+		ProduceTimeSlot:    100,
+		ProposeTimeSlot:    100,
+		CommitteeFromBlock: common.HashH([]byte("committee")),
+		ChainID:            1,
+	}
+	confirmationHash := voteData.ConfirmationHash()
+	confirmation, err := bridgesig.Sign(bridgesig.SKBytes(&sk), confirmationHash.GetBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote := metadata.EquivocationVote{
+		PrevBlockHash:      voteData.PrevBlockHash,
+		BlockHeight:        voteData.BlockHeight,
+		BlockHash:          voteData.BlockHash,
+		Validator:          voteData.Validator,
+		Confirmation:       confirmation,
+		ProduceTimeSlot:    voteData.ProduceTimeSlot,
+		ProposeTimeSlot:    voteData.ProposeTimeSlot,
+		CommitteeFromBlock: voteData.CommitteeFromBlock,
+		ChainID:            voteData.ChainID,
+	}
+	if err := verifyEquivocationVote(vote, bridgesig.PKBytes(&pk)); err != nil {
+		t.Errorf("verifyEquivocationVote() error = %v", err)
+	}
+	if err := verifyEquivocationVote(vote, bridgesig.PKBytes(&otherPk)); err == nil {
+		t.Errorf("verifyEquivocationVote() must fail with another key")
+	}
+	vote.ProposeTimeSlot++
+	if err := verifyEquivocationVote(vote, bridgesig.PKBytes(&pk)); err == nil {
+		t.Errorf("verifyEquivocationVote() must fail when the timeslot is changed")
+	}
+}
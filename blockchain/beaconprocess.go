@@ -23,11 +23,12 @@ import (
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/instruction"
-	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
 	"github.com/incognitochain/incognito-chain/portal"
 	portalprocessv3 "github.com/incognitochain/incognito-chain/portal/portalv3/portalprocess"
 	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/trie"
 	"github.com/incognitochain/incognito-chain/utils"
 )
 
@@ -190,8 +191,15 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *types.BeaconBlock,
 
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, beaconBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconBeststateTopic, newBestState))
+	go blockchain.publishBeaconRequestEvents(beaconBlock)
 	// For masternode: broadcast new committee to highways
 	beaconInsertBlockTimer.UpdateSince(startTimeStoreBeaconBlock)
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.NumOfBlockInsertToChain,
+		grafana.MeasurementValue: float64(1),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         grafana.Beacon,
+	})
 	return nil
 }
 
@@ -331,6 +339,16 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(curView *
 	_, finishSyncInstruction := curView.filterFinishSyncInstruction(beaconBlock.Body.Instructions)
 	instructions = addFinishInstruction(instructions, finishSyncInstruction)
 
+	randomBeaconInstructions := filterRandomBeaconInstruction(beaconBlock.Body.Instructions)
+	if len(randomBeaconInstructions) == 0 {
+		// the producer cannot leave the fallback random value out, the validators build it themselves
+		randomBeaconInstructions = curView.generateRandomBeaconFallbackInstruction(blockchain, beaconBlock.Header.Height)
+	}
+	if err := blockchain.verifyRandomBeaconFallbackForSigning(curView, randomBeaconInstructions, beaconBlock.Header.Height); err != nil {
+		return NewBlockChainError(ProcessRandomInstructionError, err)
+	}
+	instructions = addRandomBeaconInstruction(instructions, randomBeaconInstructions)
+
 	if len(incurredInstructions) != 0 {
 		instructions = append(instructions, incurredInstructions...)
 	}
@@ -547,6 +565,20 @@ func (curView *BeaconBestState) updateBeaconBestState(
 			isFoundRandomInstruction = true
 			Logger.log.Infof("Random number found %d", beaconBestState.CurrentRandomNumber)
 		}
+		if inst[0] == instruction.RANDOM_BEACON_ACTION {
+			randomBeaconInstruction, err := instruction.ValidateAndImportRandomBeaconInstructionFromString(inst)
+			if err != nil {
+				return nil, nil, nil, nil, NewBlockChainError(ProcessRandomInstructionError, err)
+			}
+			if err := blockchain.verifyRandomBeaconInstruction(beaconBestState, randomBeaconInstruction, beaconBlock.Header.Height); err != nil {
+				return nil, nil, nil, nil, NewBlockChainError(ProcessRandomInstructionError, err)
+			}
+			beaconBestState.CurrentRandomNumber = randomBeaconInstruction.RandomNumber()
+			beaconBestState.RandomBeaconValue = randomBeaconInstruction.Value
+			beaconBestState.IsGetRandomNumber = true
+			isFoundRandomInstruction = true
+			Logger.log.Infof("Random beacon found %v, random number %d", randomBeaconInstruction.Value, beaconBestState.CurrentRandomNumber)
+		}
 	}
 
 	if blockchain.IsFirstBeaconHeightInEpoch(beaconBestState.BeaconHeight) && beaconBestState.BeaconHeight != 1 {
@@ -879,6 +911,10 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		}
 		Logger.log.Infof("Store Slashing Committee, %+v", committeeChange.SlashingCommittee)
 	}
+	err = blockchain.storeEquivocationEvidences(newBestState.slashStateDB, beaconBlock.Header.Height, beaconBlock.Body.Instructions)
+	if err != nil {
+		return err
+	}
 	err = blockchain.addShardRewardRequestToBeacon(beaconBlock, newBestState.rewardStateDB)
 	if err != nil {
 		return NewBlockChainError(UpdateDatabaseWithBlockRewardInfoError, err)
@@ -941,18 +977,7 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	}
 
 	// Save result of BurningConfirm instruction to get proof later
-	metas := []string{ // Burning v2: sig on beacon only
-		strconv.Itoa(metadata.BurningConfirmMetaV2),
-		strconv.Itoa(metadata.BurningConfirmForDepositToSCMetaV2),
-		strconv.Itoa(metadata.BurningBSCConfirmMeta),
-		strconv.Itoa(metadata.BurningPRVERC20ConfirmMeta),
-		strconv.Itoa(metadata.BurningPRVBEP20ConfirmMeta),
-		strconv.Itoa(metadata.BurningPBSCConfirmForDepositToSCMeta),
-		strconv.Itoa(metadata.BurningPLGConfirmMeta),
-		strconv.Itoa(metadata.BurningPLGConfirmForDepositToSCMeta),
-		strconv.Itoa(metadata.BurningSOLConfirmMeta),
-		strconv.Itoa(metadata.BurningSOLConfirmForDepositToSCMeta)}
-	if err := blockchain.storeBurningConfirm(newBestState.featureStateDB, beaconBlock.Body.Instructions, beaconBlock.Header.Height, metas); err != nil {
+	if err := blockchain.storeBurningConfirm(newBestState.featureStateDB, beaconBlock.Body.Instructions, beaconBlock.Header.Height, getBeaconBurningConfirmMetas()); err != nil {
 		return NewBlockChainError(StoreBurningConfirmError, err)
 	}
 
@@ -1055,6 +1080,9 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	if err := rawdbv2.StoreBeaconRootsHash(batch, blockHash, bRH); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
+	if err := trie.RetainRoots(blockchain.GetBeaconChainDatabase(), blockHash, beaconStateRoots(blockchain.GetBeaconChainDatabase(), &bRH, beaconBlock)...); err != nil {
+		return NewBlockChainError(StatePruningError, err)
+	}
 
 	if err := rawdbv2.StoreBeaconBlockByHash(batch, blockHash, beaconBlock); err != nil {
 		return NewBlockChainError(StoreBeaconBlockError, err)
@@ -1065,6 +1093,7 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		return NewBlockChainError(StoreBeaconBlockError, err2)
 	}
 
+	pdexHistoryViews := blockchain.pdexHistoryViews(newBestState)
 	finalView := blockchain.BeaconChain.multiView.GetFinalView()
 	blockchain.BeaconChain.multiView.AddView(newBestState)
 	blockchain.beaconViewCache.Add(blockHash, newBestState) // add to cache,in case we need past view to validate shard block tx
@@ -1108,6 +1137,11 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	if err := batch.Write(); err != nil {
 		return NewBlockChainError(StoreBeaconBlockError, err)
 	}
+	blockchain.indexFinalizedPdexv3History(finalizedBlocks, pdexHistoryViews)
+	if err := blockchain.pruneBeaconStates(); err != nil {
+		Logger.log.Error(err)
+	}
+	blockchain.archiveBeaconBlocks()
 
 	beaconStoreBlockTimer.UpdateSince(startTimeProcessStoreBeaconBlock)
 
@@ -1127,7 +1161,16 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 			blockchain.GetBeaconChainDatabase().RemoveBackup(fmt.Sprintf("../../backup/beacon/%d", newBestState.Epoch))
 			return nil
 		}
-
+		err = blockchain.writeBackupManifest(&BackupManifest{
+			ChainID:     common.BeaconChainID,
+			Epoch:       newBestState.Epoch,
+			Height:      newBestState.BeaconHeight,
+			BlockHash:   newBestState.BestBlockHash,
+			BeaconRoots: &bRH,
+		}, "beacon", "btc")
+		if err != nil {
+			Logger.log.Error(err)
+		}
 	}
 	return nil
 }
@@ -95,6 +95,9 @@ func (blockchain *BlockChain) NewBlockBeacon(
 	finishSyncInstructions := copiedCurView.generateFinishSyncInstruction()
 	instructions = addFinishInstruction(instructions, finishSyncInstructions)
 
+	randomBeaconInstructions := copiedCurView.generateRandomBeaconInstruction(blockchain, newBeaconBlock.Header.Height)
+	instructions = addRandomBeaconInstruction(instructions, randomBeaconInstructions)
+
 	newBeaconBlock.Body = types.NewBeaconBody(shardStates, instructions)
 
 	// Process new block with new view
@@ -439,8 +442,9 @@ func (curView *BeaconBestState) GenerateInstruction(
 		}
 	}
 
-	// Random number for Assign Instruction
-	if blockchain.IsGreaterThanRandomTime(newBeaconHeight) && !curView.IsGetRandomNumber {
+	// Random number for Assign Instruction, from RandomBeaconHeight it is set by the random beacon instruction
+	if blockchain.IsGreaterThanRandomTime(newBeaconHeight) && !curView.IsGetRandomNumber &&
+		newBeaconHeight < config.Param().ConsensusParam.RandomBeaconHeight {
 		randomInstructionGenerator := curView.beaconCommitteeState.(committeestate.RandomInstructionsGenerator)
 		randomInstruction, randomNumber := randomInstructionGenerator.GenerateRandomInstructions(&committeestate.BeaconCommitteeStateEnvironment{
 			BeaconHash:    curView.BestBlockHash,
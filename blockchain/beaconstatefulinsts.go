@@ -48,6 +48,7 @@ func collectStatefulActions(
 			metadata.IssuingPRVBEP20RequestMeta,
 			metadata.IssuingPLGRequestMeta,
 			metadata.IssuingSOLRequestMeta,
+			metadata.IssuingEVMNetworkRequestMeta,
 			metadata.PDEContributionMeta,
 			metadata.PDETradeRequestMeta,
 			metadata.PDEWithdrawalRequestMeta,
@@ -61,6 +62,9 @@ func collectStatefulActions(
 			metadata.PortalUnlockOverRateCollateralsMeta,
 			metadata.RelayingBNBHeaderMeta,
 			metadata.RelayingBTCHeaderMeta,
+			metadata.RelayingLTCHeaderMeta,
+			metadata.RelayingDOGEHeaderMeta,
+			metadata.RelayingBCHHeaderMeta,
 			metadata.PortalCustodianWithdrawRequestMeta,
 			metadata.PortalRedeemRequestMeta,
 			metadata.PortalRequestUnlockCollateralMeta,
@@ -84,7 +88,9 @@ func collectStatefulActions(
 			metadataCommon.PortalV4UnshieldingRequestMeta,
 			metadataCommon.PortalV4FeeReplacementRequestMeta,
 			metadataCommon.PortalV4SubmitConfirmedTxMeta,
-			metadataCommon.PortalV4ConvertVaultRequestMeta:
+			metadataCommon.PortalV4ConvertVaultRequestMeta,
+			metadataCommon.PortalV4VaultRotationRequestMeta,
+			metadataCommon.EquivocationEvidenceMeta:
 			statefulInsts = append(statefulInsts, inst)
 
 		default:
@@ -123,7 +129,13 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		Logger.log.Error(err)
 		return utils.EmptyStringMatrix, err
 	}
-	relayingHeaderState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain())
+	relayingHeaderState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(
+		blockchain.GetBNBHeaderChain(),
+		blockchain.GetBTCHeaderChain(),
+		blockchain.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID),
+		blockchain.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.DOGERelayingHeaderChainID),
+		blockchain.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.BCHRelayingHeaderChainID),
+	)
 	if err != nil {
 		Logger.log.Error(err)
 	}
@@ -152,6 +164,9 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	pdeWithdrawalActions := [][]string{}
 	pdeFeeWithdrawalActions := [][]string{}
 
+	// validators already slashed by an equivocation evidence of this block
+	equivocatingValidators := map[string]bool{}
+
 	var keys []int
 	for k := range statefulActionsByShardID {
 		keys = append(keys, int(k))
@@ -280,6 +295,21 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					accumulatedValues.UniqPLGTxsUsed = append(accumulatedValues.UniqPLGTxsUsed, uniqTx)
 				}
 
+			case metadata.IssuingEVMNetworkRequestMeta:
+				var uniqTx []byte
+				newInst, uniqTx, err = blockchain.buildInstructionsForIssuingEVMNetworkReq(
+					beaconBestState,
+					featureStateDB,
+					contentStr,
+					shardID,
+					metaType,
+					accumulatedValues,
+					beaconHeight,
+				)
+				if uniqTx != nil {
+					accumulatedValues.UniqEVMNetworkTxsUsed = append(accumulatedValues.UniqEVMNetworkTxsUsed, uniqTx)
+				}
+
 			case metadata.IssuingSOLRequestMeta:
 				var uniqTx []byte
 				newInst, uniqTx, err = blockchain.buildInstructionsForIssuingSolBridgeReq(
@@ -298,6 +328,13 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					accumulatedValues.UniqSOLTxsUsed = append(accumulatedValues.UniqSOLTxsUsed, uniqTx)
 				}
 
+			case metadata.EquivocationEvidenceMeta:
+				newInst, err = blockchain.buildInstructionsForEquivocationEvidence(beaconBestState, contentStr, beaconHeight, equivocatingValidators)
+				if err != nil {
+					Logger.log.Error(err)
+					continue
+				}
+
 			case metadata.PDEContributionMeta:
 				pdeContributionActions = append(pdeContributionActions, action)
 			case metadata.PDEPRVRequiredContributionRequestMeta:
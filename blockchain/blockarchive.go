@@ -0,0 +1,85 @@
+package blockchain
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// The block archive moves the finalized blocks below the final view of each chain out of the chain
+// database into append-only segment files (see rawdbv2.ArchiveShardBlocks), the height => hash index
+// stays in the database and GetShardBlockByHash/GetBeaconBlockByHash read archived blocks transparently.
+
+// ArchiveShardBlocks moves the blocks finalized below the stored final view of a stopped node's shard
+// database to the block archive registered for it
+func ArchiveShardBlocks(db incdb.Database, shardID byte) (int, error) {
+	views, err := storedShardViews(db, shardID)
+	if err != nil {
+		return 0, NewBlockChainError(BlockArchiveError, err)
+	}
+	final := finalStoredView(views, func(view storedView) uint64 { return view.ShardHeight })
+	moved, err := rawdbv2.ArchiveShardBlocks(db, shardID, final.ShardHeight-1)
+	if err != nil {
+		return moved, NewBlockChainError(BlockArchiveError, err)
+	}
+	return moved, nil
+}
+
+// ArchiveBeaconBlocks moves the blocks finalized below the stored final view of a stopped node's beacon
+// database to the block archive registered for it
+func ArchiveBeaconBlocks(db incdb.Database) (int, error) {
+	views, err := storedBeaconViews(db)
+	if err != nil {
+		return 0, NewBlockChainError(BlockArchiveError, err)
+	}
+	final := finalStoredView(views, func(view storedView) uint64 { return view.BeaconHeight })
+	moved, err := rawdbv2.ArchiveBeaconBlocks(db, final.BeaconHeight-1)
+	if err != nil {
+		return moved, NewBlockChainError(BlockArchiveError, err)
+	}
+	return moved, nil
+}
+
+// archiveShardBlocks archives the shard blocks finalized below the final view
+func (blockchain *BlockChain) archiveShardBlocks(shardID byte) {
+	if !config.Config().BlockArchive {
+		return
+	}
+	db := blockchain.GetShardChainDatabase(shardID)
+	finalHeight := blockchain.ShardChain[shardID].GetFinalView().GetHeight()
+	blockchain.archiveInBackground(int(shardID), func() (int, error) {
+		return rawdbv2.ArchiveShardBlocks(db, shardID, finalHeight-1)
+	})
+}
+
+// archiveBeaconBlocks archives the beacon blocks finalized below the final view
+func (blockchain *BlockChain) archiveBeaconBlocks() {
+	if !config.Config().BlockArchive {
+		return
+	}
+	db := blockchain.GetBeaconChainDatabase()
+	finalHeight := blockchain.BeaconChain.GetFinalView().GetHeight()
+	blockchain.archiveInBackground(common.BeaconChainID, func() (int, error) {
+		return rawdbv2.ArchiveBeaconBlocks(db, finalHeight-1)
+	})
+}
+
+// archiveInBackground runs archive out of the block processing, a call made while the previous run of
+// the chain is in progress is dropped and the next finalized block catches up
+func (blockchain *BlockChain) archiveInBackground(chainID int, archive func() (int, error)) {
+	if _, running := blockchain.archivingBlocks.LoadOrStore(chainID, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer blockchain.archivingBlocks.Delete(chainID)
+		moved, err := archive()
+		if err != nil {
+			Logger.log.Error(NewBlockChainError(BlockArchiveError, err))
+			return
+		}
+		if moved > 0 {
+			Logger.log.Debugf("Archived %v blocks of chain %v", moved, chainID)
+		}
+	}()
+}
@@ -50,11 +50,14 @@ type BlockChain struct {
 	beaconViewCache             *lru.Cache
 	committeeByEpochCache       *lru.Cache
 	committeeByEpochProcessLock sync.Mutex
+	archivingBlocks             sync.Map // chain id => struct{}, set while its finalized blocks are archived
+	pdexHistoryIndexer          pdexHistoryIndexer
 }
 
 // Config is a descriptor which specifies the blockchain instblockchain/beaconstatefulinsts.goance configuration.
 type Config struct {
 	BTCChain      *btcrelaying.BlockChain
+	UTXOChains    map[string]*btcrelaying.BlockChain // relaying header chains of the other UTXO chains by chain ID
 	BNBChainState *bnbrelaying.BNBChainState
 	DataBase      map[int]incdb.Database
 	MemCache      *memcache.MemoryCache
@@ -237,7 +240,7 @@ func (blockchain *BlockChain) InitChainState() error {
 		Logger.log.Infof("Init Shard View shardID %+v, height %+v", shardID, blockchain.ShardChain[shardID].GetFinalViewHeight())
 	}
 
-	return nil
+	return blockchain.initStatePruning()
 }
 
 var whiteListTx map[string]bool
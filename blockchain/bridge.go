@@ -65,6 +65,13 @@ func DecodeInstruction(inst []string) ([]byte, error) {
 		}
 
 	default:
+		if metadata.IsEVMNetworkBurningConfirmMeta(inst[0]) {
+			flatten, err = decodeBurningConfirmInst(inst)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
 		for _, part := range inst {
 			flatten = append(flatten, []byte(part)...)
 		}
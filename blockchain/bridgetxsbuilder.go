@@ -12,6 +12,7 @@ import (
 
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/transaction"
@@ -249,6 +250,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingBridgeReq(
 		TxReqID:         issuingEVMBridgeReqAction.TxReqID,
 		UniqTx:          uniqTx,
 		ExternalTokenID: token,
+		NetworkID:       md.NetworkID,
 	}
 	issuingAcceptedInstBytes, err := json.Marshal(issuingAcceptedInst)
 	if err != nil {
@@ -262,6 +264,46 @@ func (blockchain *BlockChain) buildInstructionsForIssuingBridgeReq(
 	return append(instructions, acceptedInst), uniqTx, nil
 }
 
+// buildInstructionsForIssuingEVMNetworkReq resolves the contract address, the token prefix and the issued markers
+// of the requested network from the evm network registry, then processes the request as any other evm shielding
+func (blockchain *BlockChain) buildInstructionsForIssuingEVMNetworkReq(
+	beaconBestState *BeaconBestState,
+	stateDB *statedb.StateDB,
+	contentStr string,
+	shardID byte,
+	metaType int,
+	ac *metadata.AccumulatedValues,
+	beaconHeight uint64,
+) ([][]string, []byte, error) {
+	issuingEVMBridgeReqAction, err := metadata.ParseEVMIssuingInstContent(contentStr)
+	if err != nil {
+		Logger.log.Warn("WARNING: an issue occured while parsing issuing action content: ", err)
+		return nil, nil, nil
+	}
+	networkID := issuingEVMBridgeReqAction.Meta.NetworkID
+	evmNetwork, found := config.Param().GetEVMNetwork(networkID)
+	if !found || !evmNetwork.IsActive(beaconHeight) {
+		Logger.log.Warnf("WARNING: evm network %v is not registered or not activated at beacon height %v", networkID, beaconHeight)
+		rejectedInst := buildInstruction(metaType, shardID, "rejected", issuingEVMBridgeReqAction.TxReqID.String())
+		return [][]string{rejectedInst}, nil, nil
+	}
+	return blockchain.buildInstructionsForIssuingBridgeReq(
+		beaconBestState,
+		stateDB,
+		contentStr,
+		shardID,
+		metaType,
+		ac,
+		ac.UniqEVMNetworkTxsUsed,
+		evmNetwork.ContractAddressStr,
+		evmNetwork.Prefix,
+		func(stateDB *statedb.StateDB, uniqueEVMTx []byte) (bool, error) {
+			return statedb.IsEVMNetworkTxHashIssued(stateDB, networkID, uniqueEVMTx)
+		},
+		false,
+	)
+}
+
 func (blockchain *BlockChain) buildInstructionsForIssuingSolBridgeReq(
 	beaconBestState *BeaconBestState,
 	stateDB *statedb.StateDB,
@@ -285,6 +327,10 @@ func (blockchain *BlockChain) buildInstructionsForIssuingSolBridgeReq(
 	Logger.log.Infof("[SOL Bridge Shielding Producer] Processing for tx: %s, tokenid: %s", issuingSOLBridgeReqAction.TxReqID.String(), md.IncTokenID.String())
 
 	rejectedInst := buildInstruction(metaType, shardID, "rejected", issuingSOLBridgeReqAction.TxReqID.String())
+	if issuingSOLBridgeReqAction.RejectReason != "" {
+		Logger.log.Warnf("WARNING: rejected shielding tx %s: %s", md.TxSigStr, issuingSOLBridgeReqAction.RejectReason)
+		return append(instructions, append(rejectedInst, issuingSOLBridgeReqAction.RejectReason)), nil, nil
+	}
 
 	uniqTx := []byte(issuingSOLBridgeReqAction.Meta.TxSigStr)
 	Logger.log.Info("[SOL Bridge Shielding Producer] Processing metadata: %+v", issuingSOLBridgeReqAction.Meta)
@@ -485,3 +485,73 @@ func (b *beaconCommitteeStateSlashingBase) processUnstakeInstruction(
 
 	return committeeChange, returnStakingInstruction, nil
 }
+
+// processEquivocationInstruction : swap out the validator proven to sign two blocks in the same timeslot,
+// the first substitute of its shard replaces it and only part of its staking amount is returned
+func (b *beaconCommitteeStateSlashingBase) processEquivocationInstruction(
+	equivocationInstruction *instruction.EquivocationInstruction,
+	env *BeaconCommitteeStateEnvironment,
+	committeeChange *CommitteeChange,
+	returnStakingInstruction *instruction.ReturnStakeInstruction,
+) (*CommitteeChange, *instruction.ReturnStakeInstruction, error) {
+	publicKey := equivocationInstruction.CommitteePublicKey
+	if _, ok := b.autoStake[publicKey]; !ok {
+		Logger.log.Infof("Equivocation of %+v skipped, not a staker anymore", publicKey)
+		return committeeChange, returnStakingInstruction, nil
+	}
+	stakerInfo, has, err := statedb.GetStakerInfo(env.ConsensusStateDB, publicKey)
+	if err != nil {
+		return committeeChange, returnStakingInstruction, err
+	}
+	if !has {
+		Logger.log.Infof("Equivocation of %+v skipped, staker info not found", publicKey)
+		return committeeChange, returnStakingInstruction, nil
+	}
+
+	found := false
+	for shardID, committees := range b.shardCommittee {
+		index := common.IndexOfStr(publicKey, committees)
+		if index == -1 {
+			continue
+		}
+		newCommittees := common.DeepCopyString(committees[:index])
+		newCommittees = append(newCommittees, committees[index+1:]...)
+		committeeChange.AddShardCommitteeRemoved(shardID, []string{publicKey})
+		if len(b.shardSubstitute[shardID]) > 0 {
+			inPublicKey := b.shardSubstitute[shardID][0]
+			b.shardSubstitute[shardID] = b.shardSubstitute[shardID][1:]
+			newCommittees = append(newCommittees, inPublicKey)
+			committeeChange.AddShardSubstituteRemoved(shardID, []string{inPublicKey})
+			committeeChange.AddShardCommitteeAdded(shardID, []string{inPublicKey})
+		}
+		b.shardCommittee[shardID] = newCommittees
+		found = true
+		break
+	}
+	if !found {
+		for shardID, substitutes := range b.shardSubstitute {
+			index := common.IndexOfStr(publicKey, substitutes)
+			if index == -1 {
+				continue
+			}
+			newSubstitutes := common.DeepCopyString(substitutes[:index])
+			b.shardSubstitute[shardID] = append(newSubstitutes, substitutes[index+1:]...)
+			committeeChange.AddShardSubstituteRemoved(shardID, []string{publicKey})
+			found = true
+			break
+		}
+	}
+	if !found {
+		Logger.log.Infof("Equivocation of %+v skipped, not in any shard committee or substitute", publicKey)
+		return committeeChange, returnStakingInstruction, nil
+	}
+
+	slashingPercent := config.Param().ConsensusParam.EquivocationSlashingPercent
+	if slashingPercent > 100 {
+		slashingPercent = 100
+	}
+	returnStakingInstruction.AddNewRequestWithPercent(publicKey, stakerInfo.TxStakingID().String(), 100-slashingPercent)
+	committeeChange = b.deleteStakerInfo(equivocationInstruction.CommitteePublicKeyStruct, publicKey, committeeChange)
+
+	return committeeChange, returnStakingInstruction, nil
+}
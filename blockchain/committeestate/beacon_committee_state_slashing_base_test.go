@@ -1,6 +1,7 @@
 package committeestate
 
 import (
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/instruction"
@@ -440,3 +441,125 @@ func Test_beaconCommitteeStateSlashingBase_processSlashing(t *testing.T) {
 		})
 	}
 }
+
+func Test_beaconCommitteeStateSlashingBase_processEquivocationInstruction(t *testing.T) {
+
+	initTestParams()
+	config.AbortParam()
+	config.Param().ConsensusParam.EquivocationSlashingPercent = 20
+
+	paymentAddress := privacy.GeneratePaymentAddress([]byte{1})
+	sDB, err := statedb.NewWithPrefixTrie(emptyRoot, wrarperDB)
+	assert.Nil(t, err)
+
+	hash, err := common.Hash{}.NewHashFromStr("123")
+	statedb.StoreStakerInfo(
+		sDB,
+		[]incognitokey.CommitteePublicKey{*incKey0, *incKey2, *incKey4},
+		map[string]privacy.PaymentAddress{
+			incKey0.GetIncKeyBase58(): paymentAddress,
+			incKey2.GetIncKeyBase58(): paymentAddress,
+			incKey4.GetIncKeyBase58(): paymentAddress,
+		},
+		map[string]bool{
+			key0: true,
+			key2: true,
+			key4: true,
+		},
+		map[string]common.Hash{
+			key0: *hash,
+			key2: *hash,
+			key4: *hash,
+		},
+	)
+	newState := func() *beaconCommitteeStateSlashingBase {
+		return &beaconCommitteeStateSlashingBase{
+			beaconCommitteeStateBase: beaconCommitteeStateBase{
+				shardCommittee: map[byte][]string{
+					0: []string{key, key0, key2},
+				},
+				shardSubstitute: map[byte][]string{
+					0: []string{key4},
+				},
+				stakingTx: map[string]common.Hash{
+					key0: *hash,
+					key2: *hash,
+					key4: *hash,
+				},
+				rewardReceiver: map[string]privacy.PaymentAddress{
+					incKey0.GetIncKeyBase58(): paymentAddress,
+					incKey2.GetIncKeyBase58(): paymentAddress,
+					incKey4.GetIncKeyBase58(): paymentAddress,
+				},
+				autoStake: map[string]bool{
+					key0: true,
+					key2: true,
+					key4: true,
+				},
+				mu: &sync.RWMutex{},
+			},
+		}
+	}
+	env := &BeaconCommitteeStateEnvironment{
+		ConsensusStateDB: sDB,
+	}
+	returnStaking := func(publicKey string) *instruction.ReturnStakeInstruction {
+		returnStakingInstruction := instruction.NewReturnStakeIns()
+		returnStakingInstruction.AddNewRequestWithPercent(publicKey, hash.String(), 80)
+		return returnStakingInstruction
+	}
+
+	tests := []struct {
+		name                string
+		publicKey           string
+		wantCommittee       []string
+		wantSubstitute      []string
+		wantReturnStaking   *instruction.ReturnStakeInstruction
+		wantCommitteeChange *CommitteeChange
+	}{
+		{
+			name:              "committee replaced by first substitute",
+			publicKey:         key0,
+			wantCommittee:     []string{key, key2, key4},
+			wantSubstitute:    []string{},
+			wantReturnStaking: returnStaking(key0),
+			wantCommitteeChange: NewCommitteeChange().
+				AddShardCommitteeRemoved(0, []string{key0}).
+				AddShardSubstituteRemoved(0, []string{key4}).
+				AddShardCommitteeAdded(0, []string{key4}).
+				AddRemovedStaker(key0),
+		},
+		{
+			name:              "substitute removed",
+			publicKey:         key4,
+			wantCommittee:     []string{key, key0, key2},
+			wantSubstitute:    []string{},
+			wantReturnStaking: returnStaking(key4),
+			wantCommitteeChange: NewCommitteeChange().
+				AddShardSubstituteRemoved(0, []string{key4}).
+				AddRemovedStaker(key4),
+		},
+		{
+			name:                "fixed node skipped",
+			publicKey:           key,
+			wantCommittee:       []string{key, key0, key2},
+			wantSubstitute:      []string{key4},
+			wantReturnStaking:   instruction.NewReturnStakeIns(),
+			wantCommitteeChange: NewCommitteeChange(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newState()
+			equivocationInstruction := instruction.NewEquivocationInstructionWithValue(
+				tt.publicKey, 0, 100, []string{hash.String(), hash.String()}, *hash)
+			committeeChange, returnStakingInstruction, err := b.processEquivocationInstruction(
+				equivocationInstruction, env, NewCommitteeChange(), instruction.NewReturnStakeIns())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantCommittee, b.shardCommittee[0])
+			assert.Equal(t, tt.wantSubstitute, b.shardSubstitute[0])
+			assert.Equal(t, tt.wantReturnStaking, returnStakingInstruction)
+			assert.Equal(t, tt.wantCommitteeChange, committeeChange)
+		})
+	}
+}
@@ -124,6 +124,7 @@ func (b *BeaconCommitteeStateV3) UpdateCommitteeState(env *BeaconCommitteeStateE
 	incurredInstructions := [][]string{}
 	returnStakingInstruction := instruction.NewReturnStakeIns()
 	committeeChange := NewCommitteeChange()
+	equivocationInstructions := []*instruction.EquivocationInstruction{}
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -167,6 +168,14 @@ func (b *BeaconCommitteeStateV3) UpdateCommitteeState(env *BeaconCommitteeStateE
 			committeeChange = b.processAssignWithRandomInstruction(
 				randomInstruction.RandomNumber(), env.numberOfValidator, committeeChange)
 
+		case instruction.RANDOM_BEACON_ACTION:
+			randomBeaconInstruction, err := instruction.ValidateAndImportRandomBeaconInstructionFromString(inst)
+			if err != nil {
+				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
+			}
+			committeeChange = b.processAssignWithRandomInstruction(
+				randomBeaconInstruction.RandomNumber(), env.numberOfValidator, committeeChange)
+
 		case instruction.STOP_AUTO_STAKE_ACTION:
 			stopAutoStakeInstruction, err := instruction.ValidateAndImportStopAutoStakeInstructionFromString(inst)
 			if err != nil {
@@ -202,6 +211,21 @@ func (b *BeaconCommitteeStateV3) UpdateCommitteeState(env *BeaconCommitteeStateE
 			if err != nil {
 				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
 			}
+		case instruction.EQUIVOCATION_ACTION:
+			equivocationInstruction, err := instruction.ValidateAndImportEquivocationInstructionFromString(inst)
+			if err != nil {
+				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
+			}
+			equivocationInstructions = append(equivocationInstructions, equivocationInstruction)
+		}
+	}
+
+	// equivocation is processed after the swap instructions, which are verified against the committees of the previous block
+	for _, equivocationInstruction := range equivocationInstructions {
+		committeeChange, returnStakingInstruction, err = b.processEquivocationInstruction(
+			equivocationInstruction, env, committeeChange, returnStakingInstruction)
+		if err != nil {
+			return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
 		}
 	}
 
@@ -2,8 +2,6 @@ package committeestate
 
 import (
 	"fmt"
-	"io/ioutil"
-	"os"
 	"reflect"
 	"testing"
 
@@ -12,6 +10,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/trie"
 )
@@ -826,11 +825,7 @@ func initTestParams() {
 		panic(err)
 	}
 
-	dbPath, err := ioutil.TempDir(os.TempDir(), "data")
-	if err != nil {
-		panic(err)
-	}
-	diskDB, _ = incdb.Open("leveldb", dbPath)
+	diskDB, _ = incdb.Open("memdb")
 	wrarperDB = statedb.NewDatabaseAccessWarper(diskDB)
 	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("test", true))
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/metrics"
@@ -37,15 +38,18 @@ const (
 	burningAddress2 = "12RxahVABnAVCGP3LGwCn8jkQxgw7z1x14wztHzn455TTVpi1wBq9YGwkRMQg3J4e657AbAnCvYCJSdA9czBUNuCKwGSRQt55Xwz8WA"
 )
 
-var (
-	shardInsertBlockTimer                  = metrics.NewRegisteredTimer("shard/insert", nil)
-	shardVerifyPreprocesingTimer           = metrics.NewRegisteredTimer("shard/verify/preprocessing", nil)
-	shardVerifyPreprocesingForPreSignTimer = metrics.NewRegisteredTimer("shard/verify/preprocessingpresign", nil)
-	shardVerifyWithBestStateTimer          = metrics.NewRegisteredTimer("shard/verify/withbeststate", nil)
-	shardVerifyPostProcessingTimer         = metrics.NewRegisteredTimer("shard/verify/postprocessing", nil)
-	shardStoreBlockTimer                   = metrics.NewRegisteredTimer("shard/storeblock", nil)
-	shardUpdateBestStateTimer              = metrics.NewRegisteredTimer("shard/updatebeststate", nil)
+// shard timers are registered per shard as "shard/<shardID>/<name>" so they can be labeled by chain
+const (
+	shardInsertBlockTimer                  = "insert"
+	shardVerifyPreprocesingTimer           = "verify/preprocessing"
+	shardVerifyPreprocesingForPreSignTimer = "verify/preprocessingpresign"
+	shardVerifyWithBestStateTimer          = "verify/withbeststate"
+	shardVerifyPostProcessingTimer         = "verify/postprocessing"
+	shardStoreBlockTimer                   = "storeblock"
+	shardUpdateBestStateTimer              = "updatebeststate"
+)
 
+var (
 	beaconInsertBlockTimer                  = metrics.NewRegisteredTimer("beacon/insert", nil)
 	beaconVerifyPreprocesingTimer           = metrics.NewRegisteredTimer("beacon/verify/preprocessing", nil)
 	beaconVerifyPreprocesingForPreSignTimer = metrics.NewRegisteredTimer("beacon/verify/preprocessingpresign", nil)
@@ -54,3 +58,7 @@ var (
 	beaconStoreBlockTimer                   = metrics.NewRegisteredTimer("beacon/storeblock", nil)
 	beaconUpdateBestStateTimer              = metrics.NewRegisteredTimer("beacon/updatebeststate", nil)
 )
+
+func shardTimer(shardID byte, name string) metrics.Timer {
+	return metrics.GetOrRegisterTimer(fmt.Sprintf("shard/%d/%s", shardID, name), nil)
+}
@@ -194,6 +194,11 @@ const (
 	ShardBlockAlreadyExist
 	PDEStateDBError
 	UpdateBFTV3StatsError
+	StateSnapshotError
+	BackupManifestError
+	StatePruningError
+	BlockArchiveError
+	PdexHistoryIndexError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -376,6 +381,11 @@ var ErrCodeMessage = map[int]struct {
 	UpgradeBeaconCommitteeStateError:                  {-4000, "Upgrade Beacon Committee State Error"},
 	UpgradeShardCommitteeStateError:                   {-4001, "Upgrade Shard Committee State Error"},
 	UpdateBFTV3StatsError:                             {-4002, "Update BFT V3 Stats Error, This Error Won't effect Store Shard Block"},
+	StateSnapshotError:                                {-4003, "State Snapshot Error"},
+	BackupManifestError:                               {-4004, "Backup Manifest Error"},
+	StatePruningError:                                 {-4005, "State Pruning Error"},
+	BlockArchiveError:                                 {-4006, "Block Archive Error"},
+	PdexHistoryIndexError:                             {-4007, "pDEX History Index Error"},
 }
 
 type BlockChainError struct {
@@ -1,5 +1,7 @@
 package pdex
 
+import "github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+
 type State interface {
 	Version() uint
 	Clone() State
@@ -17,6 +19,7 @@ type StateReader interface {
 	Params() *Params
 	WaitingContributions() []byte
 	PoolPairs() []byte
+	PoolPairSnapshots() map[string]*rawdbv2.Pdexv3PoolPairSnapshot
 	Shares() map[string]uint64
 	TradingFees() map[string]uint64
 	NftIDs() map[string]uint64
@@ -1,6 +1,9 @@
 package pdex
 
-import "github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+import (
+	"github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
 
 type stateBase struct {
 }
@@ -61,6 +64,10 @@ func (s *stateBase) WaitingContributions() []byte {
 	panic("Implement this fucntion")
 }
 
+func (s *stateBase) PoolPairSnapshots() map[string]*rawdbv2.Pdexv3PoolPairSnapshot {
+	panic("Implement this fucntion")
+}
+
 func (s *stateBase) Shares() map[string]uint64 {
 	panic("Implement this fucntion")
 }
@@ -664,6 +664,7 @@ func (sp *stateProcessorV2) addOrder(
 		// skip error checking since concrete type is specified above
 		md, _ := currentOrder.Content.(*metadataPdexv3.AcceptedAddOrder)
 		trackedStatus.OrderID = md.OrderID
+		trackedStatus.ExpiryHeight = md.ExpiryHeight
 
 		pair, exists := pairs[md.PoolPairID]
 		if !exists {
@@ -673,7 +674,7 @@ func (sp *stateProcessorV2) addOrder(
 		// fee for this request is deducted right away, while the fee stored in the order itself
 		// starts from 0 and will accumulate over time
 		newOrder := rawdbv2.NewPdexv3OrderWithValue(md.OrderID, md.NftID, md.Token0Rate, md.Token1Rate,
-			md.Token0Balance, md.Token1Balance, md.TradeDirection, md.Receiver)
+			md.Token0Balance, md.Token1Balance, md.TradeDirection, md.Receiver, md.ExpiryHeight)
 		pair.orderbook.InsertOrder(newOrder)
 		// write changes to state
 		pairs[md.PoolPairID] = pair
@@ -566,6 +566,7 @@ func (sp *stateProducerV2) addOrder(
 	nftIDs map[string]uint64,
 	params *Params,
 	orderCountByNftID map[string]uint,
+	beaconHeight uint64,
 ) ([][]string, map[string]*PoolPairState, error) {
 	result := [][]string{}
 
@@ -588,6 +589,18 @@ TransactionLoop:
 			result = append(result, refundInstructions...)
 			continue TransactionLoop
 		}
+		// order expiry is ignored before its break point, as it was before the feature existed
+		expiryHeight := uint64(0)
+		if beaconHeight >= config.Param().PDexParams.OrderExpiryHeight {
+			expiryHeight = currentOrderReq.ExpiryHeight
+		}
+		// an order cannot be added at or after its expiry height
+		if expiryHeight != 0 && expiryHeight <= beaconHeight {
+			Logger.log.Warnf("AddOrder: order expiry height %d is not after beacon height %d",
+				expiryHeight, beaconHeight)
+			result = append(result, refundInstructions...)
+			continue TransactionLoop
+		}
 		// check that the nftID has not exceeded its order count limit
 		if orderCountByNftID[currentOrderReq.NftID.String()] >= params.MaxOrdersPerNft {
 			Logger.log.Warnf("AddOrder: NftID %s has reached order count limit of %d",
@@ -667,6 +680,7 @@ TransactionLoop:
 			Token1Balance:  token1Balance,
 			TradeDirection: tradeDirection,
 			Receiver:       [2]string{token0RecvStr, token1RecvStr},
+			ExpiryHeight:   expiryHeight,
 		}
 
 		acceptedAction := instruction.NewAction(
@@ -810,20 +824,59 @@ TransactionLoop:
 	return result, pairs, nil
 }
 
+// withdrawAllMatchedOrders() withdraws the balances of orders that cannot be matched any further.
+// numberTxsPerShard holds the auto withdrawals already produced in this block, which count towards limitTxsPerShard
 func (sp *stateProducerV2) withdrawAllMatchedOrders(
-	pairs map[string]*PoolPairState, limitTxsPerShard uint,
+	pairs map[string]*PoolPairState, limitTxsPerShard uint, numberTxsPerShard map[byte]uint,
+) ([][]string, map[string]*PoolPairState, error) {
+	result, pairs := autoWithdrawOrders(pairs, limitTxsPerShard, numberTxsPerShard, func(ord *Order) bool {
+		temp := &v2utils.MatchingOrder{ord}
+		// an order that isn't further matchable is eligible for automatic withdrawal
+		canMatch, err := temp.CanMatch(1 - ord.TradeDirection())
+		return !canMatch && err == nil
+	})
+	Logger.log.Warnf("WithdrawAllMatchedOrder instructions: %v", result)
+	return result, pairs, nil
+}
+
+// withdrawExpiredOrders() withdraws the balances of orders whose expiry height is reached at beaconHeight.
+// It runs before trades so that withdrawn orders are not matched in the same block; expired orders beyond
+// the limit stay in the orderbook and are withdrawn in the next blocks
+func (sp *stateProducerV2) withdrawExpiredOrders(
+	pairs map[string]*PoolPairState, beaconHeight uint64, limitTxsPerShard uint, numberTxsPerShard map[byte]uint,
 ) ([][]string, map[string]*PoolPairState, error) {
+	result, pairs := autoWithdrawOrders(pairs, limitTxsPerShard, numberTxsPerShard, func(ord *Order) bool {
+		return ord.IsExpired(beaconHeight)
+	})
+	Logger.log.Warnf("WithdrawExpiredOrder instructions: %v", result)
+	return result, pairs, nil
+}
+
+// autoWithdrawOrders() refunds the outstanding balances of eligible orders to their stored receivers,
+// producing at most limitTxsPerShard withdrawals per shard. Pairs and orders are visited in ID order
+// so that every node picks the same orders when the limit is reached
+func autoWithdrawOrders(
+	pairs map[string]*PoolPairState, limitTxsPerShard uint, numberTxsPerShard map[byte]uint,
+	isEligible func(ord *Order) bool,
+) ([][]string, map[string]*PoolPairState) {
 	result := [][]string{}
-	numberTxsPerShard := make(map[byte]uint)
-	for pairID, pair := range pairs {
-		for _, ord := range pair.orderbook.orders {
-			temp := &v2utils.MatchingOrder{ord}
-			// check if this order can be matched any further
-			if canMatch, err := temp.CanMatch(1 - ord.TradeDirection()); canMatch || err != nil {
+	pairIDs := make([]string, 0, len(pairs))
+	for pairID := range pairs {
+		pairIDs = append(pairIDs, pairID)
+	}
+	sort.Strings(pairIDs)
+	for _, pairID := range pairIDs {
+		pair := pairs[pairID]
+		orders := make([]*Order, len(pair.orderbook.orders))
+		copy(orders, pair.orderbook.orders)
+		sort.SliceStable(orders, func(i, j int) bool {
+			return orders[i].Id() < orders[j].Id()
+		})
+		for _, ord := range orders {
+			if !isEligible(ord) {
 				continue
 			}
 
-			// an order that isn't further matchable is eligible for automatic withdrawal
 			token0Recv := privacy.OTAReceiver{}
 			token0Recv.FromString(ord.Token0Receiver()) // error ignored (handled when adding this order)
 			token1Recv := privacy.OTAReceiver{}
@@ -876,9 +929,7 @@ func (sp *stateProducerV2) withdrawAllMatchedOrders(
 			result = append(result, outputInstructions...)
 		}
 	}
-
-	Logger.log.Warnf("WithdrawAllMatchedOrder instructions: %v", result)
-	return result, pairs, nil
+	return result, pairs
 }
 
 func (sp *stateProducerV2) withdrawLPFee(
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"sort"
 	"strconv"
@@ -379,6 +380,19 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 	}
 	instructions = append(instructions, withdrawStakingRewardInstructions...)
 
+	// auto withdrawals of expired & fully matched orders share the same limit per shard
+	autoWithdrawTxsPerShard := make(map[byte]uint)
+	if beaconHeight >= config.Param().PDexParams.OrderExpiryHeight {
+		var expiredWithdrawInstructions [][]string
+		expiredWithdrawInstructions, s.poolPairs, err = s.producer.withdrawExpiredOrders(
+			s.poolPairs, beaconHeight, s.params.AutoWithdrawOrderLimitAmount, autoWithdrawTxsPerShard,
+		)
+		if err != nil {
+			return instructions, err
+		}
+		instructions = append(instructions, expiredWithdrawInstructions...)
+	}
+
 	var tradeInstructions [][]string
 	tradeInstructions, s.poolPairs, err = s.producer.trade(
 		tradeTxs,
@@ -392,7 +406,7 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 
 	var matchedWithdrawInstructions [][]string
 	matchedWithdrawInstructions, s.poolPairs, err = s.producer.withdrawAllMatchedOrders(
-		s.poolPairs, s.params.AutoWithdrawOrderLimitAmount,
+		s.poolPairs, s.params.AutoWithdrawOrderLimitAmount, autoWithdrawTxsPerShard,
 	)
 	if err != nil {
 		return instructions, err
@@ -440,6 +454,7 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 		s.nftIDs,
 		s.params,
 		orderCountByNftID,
+		beaconHeight,
 	)
 	if err != nil {
 		return instructions, err
@@ -657,6 +672,32 @@ func (s *stateV2) PoolPairs() []byte {
 	return data
 }
 
+// PoolPairSnapshots returns the reserves and LP fee checkpoints of the pool pairs without cloning
+// their shares and order books
+func (s *stateV2) PoolPairSnapshots() map[string]*rawdbv2.Pdexv3PoolPairSnapshot {
+	res := make(map[string]*rawdbv2.Pdexv3PoolPairSnapshot, len(s.poolPairs))
+	for poolPairID, poolPair := range s.poolPairs {
+		state := poolPair.state
+		snapshot := &rawdbv2.Pdexv3PoolPairSnapshot{
+			PoolPairID:          poolPairID,
+			Token0ID:            state.Token0ID(),
+			Token1ID:            state.Token1ID(),
+			Token0RealAmount:    state.Token0RealAmount(),
+			Token1RealAmount:    state.Token1RealAmount(),
+			Token0VirtualAmount: new(big.Int).Set(state.Token0VirtualAmount()),
+			Token1VirtualAmount: new(big.Int).Set(state.Token1VirtualAmount()),
+			ShareAmount:         state.ShareAmount(),
+			Amplifier:           state.Amplifier(),
+			LpFeesPerShare:      make(map[string]*big.Int, len(poolPair.lpFeesPerShare)),
+		}
+		for tokenID, feePerShare := range poolPair.lpFeesPerShare {
+			snapshot.LpFeesPerShare[tokenID.String()] = new(big.Int).Set(feePerShare)
+		}
+		res[poolPairID] = snapshot
+	}
+	return res
+}
+
 func (s *stateV2) TransformKeyWithNewBeaconHeight(beaconHeight uint64) {}
 
 func (s *stateV2) NftIDs() map[string]uint64 {
@@ -937,6 +937,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 									validOTAReceiver0,
 									validOTAReceiver1,
 								},
+								0,
 							),
 						}},
 						lmLockedShare: map[string]map[uint64]uint64{},
@@ -1059,6 +1060,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 									validOTAReceiver0,
 									validOTAReceiver1,
 								},
+								0,
 							),
 						}},
 						lmLockedShare: map[string]map[uint64]uint64{},
@@ -1889,6 +1891,7 @@ func Test_stateV2_Process(t *testing.T) {
 									validOTAReceiver0,
 									validOTAReceiver1,
 								},
+								0,
 							),
 						}},
 						lmLockedShare: map[string]map[uint64]uint64{},
@@ -2046,6 +2049,7 @@ func Test_stateV2_Process(t *testing.T) {
 									validOTAReceiver0,
 									validOTAReceiver1,
 								},
+								0,
 							),
 							rawdbv2.NewPdexv3OrderWithValue(
 								secondTxHash.String(),
@@ -2055,6 +2059,7 @@ func Test_stateV2_Process(t *testing.T) {
 									validOTAReceiver0,
 									validOTAReceiver1,
 								},
+								0,
 							),
 						}},
 						lmLockedShare: map[string]map[uint64]uint64{},
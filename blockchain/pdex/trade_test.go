@@ -44,8 +44,7 @@ func init() {
 	privacy.LoggerV2.Init(logger)
 	transaction.Logger.Init(logger)
 
-	dbPath, _ := ioutil.TempDir(os.TempDir(), "test_statedb_")
-	d, _ := incdb.Open("leveldb", dbPath)
+	d, _ := incdb.Open("memdb")
 	testWarper = statedb.NewDatabaseAccessWarper(d)
 	testDB, _ = statedb.NewWithPrefixTrie(emptyRoot, testWarper)
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math/big"
 	"os"
 	"path/filepath"
@@ -13,6 +12,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/utils"
 	"github.com/jrick/logrotate/rotator"
@@ -37,11 +37,7 @@ const (
 )
 
 func initDB() {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "data")
-	if err != nil {
-		panic(err)
-	}
-	diskDB, _ = incdb.Open("leveldb", dbPath)
+	diskDB, _ = incdb.Open("memdb")
 	wrarperDB = statedb.NewDatabaseAccessWarper(diskDB)
 }
 
@@ -0,0 +1,164 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain/pdex"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	instruction "github.com/incognitochain/incognito-chain/instruction/pdexv3"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+)
+
+// The pDEX history index is optional (--pdexindexer), for each finalized beacon block it stores into the
+// beacon database a snapshot of the pool pairs changed by the block and a record of the executed trades,
+// see rawdbv2.StorePdexv3History. A snapshot is only written when a pool differs from its last snapshot.
+// Blocks are indexed when they get final, the blocks of a fork losing the race are never indexed.
+
+// pdexHistoryIndexer keeps the last snapshot of each pool pair to skip the unchanged ones
+type pdexHistoryIndexer struct {
+	lock          sync.Mutex
+	lastSnapshots map[string]*rawdbv2.Pdexv3PoolPairSnapshot
+}
+
+// pdexHistoryViews returns by block hash the views from view back to the final view. The multiview drops
+// the views below the final view, so they are collected before a new view may move the final view.
+func (blockchain *BlockChain) pdexHistoryViews(view *BeaconBestState) map[common.Hash]*BeaconBestState {
+	res := map[common.Hash]*BeaconBestState{}
+	if !config.Config().PdexIndexer {
+		return res
+	}
+	finalView := blockchain.BeaconChain.GetFinalView()
+	for view != nil {
+		res[*view.GetHash()] = view
+		if finalView == nil || view.GetHeight() <= finalView.GetHeight() {
+			break
+		}
+		prevView, _ := blockchain.BeaconChain.GetViewByHash(*view.GetPreviousHash()).(*BeaconBestState)
+		view = prevView
+	}
+	return res
+}
+
+// indexFinalizedPdexv3History records the pool pairs and trades of the newly finalized beacon blocks,
+// finalizedBlocks is ordered from the highest block down. The pool pairs of a block without view are not indexed.
+func (blockchain *BlockChain) indexFinalizedPdexv3History(finalizedBlocks []*types.BeaconBlock, views map[common.Hash]*BeaconBestState) {
+	if !config.Config().PdexIndexer {
+		return
+	}
+	for i := len(finalizedBlocks) - 1; i >= 0; i-- {
+		blockchain.indexPdexv3History(views[*finalizedBlocks[i].Hash()], finalizedBlocks[i])
+	}
+}
+
+// indexPdexv3History records the pool pairs and trades of a finalized beacon block
+func (blockchain *BlockChain) indexPdexv3History(beaconBestState *BeaconBestState, beaconBlock *types.BeaconBlock) {
+	if !config.Config().PdexIndexer || beaconBlock.Header.Height < config.Param().PDexParams.Pdexv3BreakPointHeight {
+		return
+	}
+	indexer := &blockchain.pdexHistoryIndexer
+	indexer.lock.Lock()
+	defer indexer.lock.Unlock()
+
+	db := blockchain.GetBeaconChainDatabase()
+	if indexer.lastSnapshots == nil {
+		lastSnapshots, err := rawdbv2.GetLastPdexv3PoolPairSnapshots(db)
+		if err != nil {
+			Logger.log.Error(NewBlockChainError(PdexHistoryIndexError, err))
+			return
+		}
+		indexer.lastSnapshots = lastSnapshots
+	}
+	height, timestamp := beaconBlock.Header.Height, beaconBlock.Header.Timestamp
+	snapshots := []*rawdbv2.Pdexv3PoolPairSnapshot{}
+	if beaconBestState == nil {
+		Logger.log.Warnf("No view of beacon block %v, its pDEX pool pairs are not indexed", height)
+	} else if state, ok := beaconBestState.pdeStates[pdex.AmplifierVersion]; ok && state != nil {
+		snapshots = changedPdexv3PoolPairSnapshots(state.Reader().PoolPairSnapshots(), indexer.lastSnapshots, height, timestamp)
+	}
+	trades := extractPdexv3TradeRecords(beaconBlock.Body.Instructions, height, timestamp)
+	if err := rawdbv2.StorePdexv3History(db, snapshots, trades); err != nil {
+		Logger.log.Error(NewBlockChainError(PdexHistoryIndexError, err))
+		return
+	}
+	for _, snapshot := range snapshots {
+		indexer.lastSnapshots[snapshot.PoolPairID] = snapshot
+	}
+}
+
+// changedPdexv3PoolPairSnapshots returns the snapshots of the pool pairs differing from their last snapshot
+func changedPdexv3PoolPairSnapshots(
+	current, last map[string]*rawdbv2.Pdexv3PoolPairSnapshot, beaconHeight uint64, timestamp int64,
+) []*rawdbv2.Pdexv3PoolPairSnapshot {
+	res := []*rawdbv2.Pdexv3PoolPairSnapshot{}
+	for poolPairID, snapshot := range current {
+		if snapshot.SameState(last[poolPairID]) {
+			continue
+		}
+		snapshot.BeaconHeight = beaconHeight
+		snapshot.Timestamp = timestamp
+		res = append(res, snapshot)
+	}
+	return res
+}
+
+// extractPdexv3TradeRecords parses the accepted trades of a beacon block, one record per pool of the trade path
+func extractPdexv3TradeRecords(instructions [][]string, beaconHeight uint64, timestamp int64) []*rawdbv2.Pdexv3TradeRecord {
+	res := []*rawdbv2.Pdexv3TradeRecord{}
+	for _, inst := range instructions {
+		if len(inst) < 2 || inst[0] != strconv.Itoa(metadataCommon.Pdexv3TradeRequestMeta) ||
+			inst[1] != strconv.Itoa(metadataPdexv3.TradeAcceptedStatus) {
+			continue
+		}
+		action := &instruction.Action{Content: &metadataPdexv3.AcceptedTrade{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			Logger.log.Warnf("Cannot index pDEX trade instruction %v: %v", inst, err)
+			continue
+		}
+		md, _ := action.Content.(*metadataPdexv3.AcceptedTrade)
+		if len(md.PairChanges) != len(md.TradePath) {
+			continue
+		}
+		fees := decodePdexv3TradeFees(inst[4])
+		for index, poolPairID := range md.TradePath {
+			record := &rawdbv2.Pdexv3TradeRecord{
+				TxReqID:      action.RequestTxID(),
+				PoolPairID:   poolPairID,
+				BeaconHeight: beaconHeight,
+				Timestamp:    timestamp,
+				TradePath:    md.TradePath,
+				TokenToBuy:   md.TokenToBuy,
+				Amount:       md.Amount,
+				Token0Change: md.PairChanges[index][0],
+				Token1Change: md.PairChanges[index][1],
+			}
+			if index < len(fees) {
+				record.Fee = fees[index]
+			}
+			if index < len(md.OrderChanges) {
+				record.OrderFills = md.OrderChanges[index]
+			}
+			res = append(res, record)
+		}
+	}
+	return res
+}
+
+// decodePdexv3TradeFees reads the fees earned by each pool of a trade, the token IDs are decoded as strings
+// because common.Hash map keys do not survive a JSON round trip
+func decodePdexv3TradeFees(content string) []map[string]uint64 {
+	temp := struct {
+		Content struct {
+			RewardEarned []map[string]uint64 `json:"RewardEarned"`
+		} `json:"Content"`
+	}{}
+	if err := json.Unmarshal([]byte(content), &temp); err != nil {
+		return nil
+	}
+	return temp.Content.RewardEarned
+}
@@ -0,0 +1,127 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
+	instruction "github.com/incognitochain/incognito-chain/instruction/pdexv3"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPdexv3HistoryIndex(t *testing.T) {
+	db, err := incdb.Open("memdb")
+	assert.Nil(t, err)
+	tradeTxID := common.HashH([]byte("trade"))
+
+	acceptedTrade := instruction.NewAction(&metadataPdexv3.AcceptedTrade{
+		Amount:       95,
+		TradePath:    []string{"pair0", "pair1"},
+		TokenToBuy:   common.PRVCoinID,
+		PairChanges:  [][2]*big.Int{{big.NewInt(100), big.NewInt(-50)}, {big.NewInt(50), big.NewInt(-95)}},
+		RewardEarned: []map[common.Hash]uint64{{common.PRVCoinID: 2}, {}},
+		OrderChanges: []map[string][2]*big.Int{{"order0": {big.NewInt(100), big.NewInt(-50)}}, {}},
+	}, tradeTxID, 0).StringSlice()
+	trades := extractPdexv3TradeRecords([][]string{acceptedTrade, {"1", "2"}}, 10, 1000)
+	assert.Equal(t, 2, len(trades))
+	assert.Equal(t, "pair0", trades[0].PoolPairID)
+	assert.Equal(t, tradeTxID, trades[0].TxReqID)
+	assert.Equal(t, uint64(2), trades[0].Fee[common.PRVIDStr])
+	token0Change, token1Change := trades[0].TotalChanges()
+	assert.Equal(t, int64(200), token0Change.Int64())
+	assert.Equal(t, int64(-100), token1Change.Int64())
+	assert.Equal(t, "pair1", trades[1].PoolPairID)
+
+	// only the pools differing from their last snapshot are stored
+	newSnapshot := func(realAmount uint64, feePerShare int64) *rawdbv2.Pdexv3PoolPairSnapshot {
+		return &rawdbv2.Pdexv3PoolPairSnapshot{
+			PoolPairID:          "pair0",
+			Token0RealAmount:    realAmount,
+			Token1RealAmount:    realAmount,
+			Token0VirtualAmount: new(big.Int).SetUint64(realAmount * 2),
+			Token1VirtualAmount: new(big.Int).SetUint64(realAmount * 2),
+			LpFeesPerShare:      map[string]*big.Int{common.PRVIDStr: big.NewInt(feePerShare)},
+		}
+	}
+	last := map[string]*rawdbv2.Pdexv3PoolPairSnapshot{}
+	snapshots := changedPdexv3PoolPairSnapshots(map[string]*rawdbv2.Pdexv3PoolPairSnapshot{"pair0": newSnapshot(1000, 0)}, last, 10, 1000)
+	assert.Equal(t, 1, len(snapshots))
+	assert.Nil(t, rawdbv2.StorePdexv3History(db, snapshots, trades))
+	last["pair0"] = snapshots[0]
+	snapshots = changedPdexv3PoolPairSnapshots(map[string]*rawdbv2.Pdexv3PoolPairSnapshot{"pair0": newSnapshot(1000, 0)}, last, 11, 1040)
+	assert.Equal(t, 0, len(snapshots))
+	snapshots = changedPdexv3PoolPairSnapshots(map[string]*rawdbv2.Pdexv3PoolPairSnapshot{"pair0": newSnapshot(1000, 5)}, last, 12, 1080)
+	assert.Equal(t, 1, len(snapshots))
+	assert.Nil(t, rawdbv2.StorePdexv3History(db, snapshots, nil))
+
+	snapshot, err := rawdbv2.GetPdexv3PoolPairSnapshot(db, "pair0", 11)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), snapshot.BeaconHeight)
+	snapshot, err = rawdbv2.GetPdexv3PoolPairSnapshot(db, "pair0", 100)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(12), snapshot.BeaconHeight)
+	assert.Equal(t, int64(5), snapshot.LpFeesPerShare[common.PRVIDStr].Int64())
+	snapshot, err = rawdbv2.GetPdexv3PoolPairSnapshot(db, "pair0", 9)
+	assert.Nil(t, err)
+	assert.Nil(t, snapshot)
+
+	records, err := rawdbv2.GetPdexv3TradeRecords(db, "pair1", 900, 1000, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(records))
+	records, err = rawdbv2.GetPdexv3TradeRecords(db, "pair1", 1001, 2000, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(records))
+}
+
+func TestPdexv3HistoryViewsFollowTheBranch(t *testing.T) {
+	config.AbortConfig()
+	config.Config().PdexIndexer = true
+	defer config.AbortConfig()
+	timeSlot := common.TIMESLOT
+	common.TIMESLOT = 10
+	defer func() { common.TIMESLOT = timeSlot }()
+
+	newView := func(height uint64, prev *BeaconBestState, timeSlot int64) *BeaconBestState {
+		block := types.NewBeaconBlock()
+		block.Header.Version = types.MULTI_VIEW_VERSION
+		block.Header.Height = height
+		block.Header.ProposeTime = timeSlot * int64(common.TIMESLOT)
+		block.Header.Timestamp = block.Header.ProposeTime
+		if prev != nil {
+			block.Header.PreviousBlockHash = *prev.GetHash()
+		}
+		return &BeaconBestState{BestBlock: *block}
+	}
+	bc := &BlockChain{BeaconChain: &BeaconChain{multiView: multiview.NewMultiView()}}
+	defer bc.BeaconChain.multiView.Destroy()
+
+	v1 := newView(1, nil, 1)
+	v2 := newView(2, v1, 2)
+	v3 := newView(3, v2, 3)
+	v3b := newView(3, v2, 4)
+	for _, view := range []*BeaconBestState{v1, v2, v3, v3b} {
+		assert.True(t, bc.BeaconChain.multiView.AddView(view))
+	}
+	assert.Equal(t, uint64(2), bc.BeaconChain.GetFinalView().GetHeight())
+
+	// only the views of the branch of the new view are kept, down to the final view
+	v4 := newView(4, v3, 5)
+	views := bc.pdexHistoryViews(v4)
+	assert.Equal(t, 3, len(views))
+	assert.Equal(t, v3, views[*v3.GetHash()])
+	assert.Nil(t, views[*v3b.GetHash()])
+	assert.Equal(t, v2, views[*v2.GetHash()])
+
+	v4b := newView(4, v3b, 6)
+	views = bc.pdexHistoryViews(v4b)
+	assert.Equal(t, 3, len(views))
+	assert.Equal(t, v3b, views[*v3b.GetHash()])
+	assert.Nil(t, views[*v3.GetHash()])
+}
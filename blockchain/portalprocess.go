@@ -57,7 +57,13 @@ func (blockchain *BlockChain) processPortalInstructions(
 	lastPortalV4State := clonedBeaconBestState.portalStateV4
 	lastPortalV3State := clonedBeaconBestState.portalStateV3
 	beaconHeight := block.Header.Height - 1
-	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain())
+	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(
+		blockchain.GetBNBHeaderChain(),
+		blockchain.GetBTCHeaderChain(),
+		blockchain.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID),
+		blockchain.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.DOGERelayingHeaderChainID),
+		blockchain.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.BCHRelayingHeaderChainID),
+	)
 	if err != nil {
 		Logger.log.Error(err)
 		return lastPortalV3State, lastPortalV4State, nil
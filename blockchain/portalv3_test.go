@@ -4,10 +4,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math"
 	"math/big"
-	"os"
 	"strconv"
 	"time"
 
@@ -89,11 +87,7 @@ var supportedCollaterals = []portalv3.PortalCollateral{
 }
 
 func (s *PortalTestSuiteV3) SetupTest() {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "portal_test_statedb_")
-	if err != nil {
-		panic(err)
-	}
-	diskBD, _ := incdb.Open("leveldb", dbPath)
+	diskBD, _ := incdb.Open("memdb")
 	warperDBStatedbTest := statedb.NewDatabaseAccessWarper(diskBD)
 	emptyRoot := common.HexToHash(common.HexEmptyRoot)
 	stateDB, _ := statedb.NewWithPrefixTrie(emptyRoot, warperDBStatedbTest)
@@ -1,10 +1,11 @@
 package blockchain
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math/rand"
 	"os"
 	"strconv"
@@ -16,8 +17,10 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
 
 	"github.com/blockcypher/gobcy"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/incognitochain/incognito-chain/common"
@@ -67,11 +70,7 @@ const PORTALV4_USER_INC_ADDRESS_3 = "12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dS
 const PORTALV4_USER_INC_ADDRESS_4 = "12S4NL3DZ1KoprFRy1k5DdYSXUq81NtxFKdvUTP3PLqQypWzceL5fBBwXooAsX5s23j7cpb1Za37ddmfSaMpEJDPsnJGZuyWTXJSZZ5"
 
 func (s *PortalTestSuiteV4) SetupTest() {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "portal_test_statedb_")
-	if err != nil {
-		panic(err)
-	}
-	diskBD, _ := incdb.Open("leveldb", dbPath)
+	diskBD, _ := incdb.Open("memdb")
 	warperDBStatedbTest := statedb.NewDatabaseAccessWarper(diskBD)
 	emptyRoot := common.HexToHash(common.HexEmptyRoot)
 	stateDB, _ := statedb.NewWithPrefixTrie(emptyRoot, warperDBStatedbTest)
@@ -2112,6 +2111,175 @@ func (s *PortalTestSuiteV4) TestUTXOProcess() {
 	}
 }
 
+/*
+	Vault rotation
+*/
+
+func buildPortalVaultRotationRequestAction(
+	tokenID string,
+	masterPubKeys [][]byte,
+	numRequiredSigs uint,
+	activationHeight uint64,
+	gracePeriod uint64,
+	vaultPrivKeys []*btcec.PrivateKey,
+	txID string,
+	shardID byte,
+) []string {
+	req, _ := metadata.NewPortalVaultRotationRequest(
+		metadataCommon.PortalV4VaultRotationRequestMeta, tokenID, masterPubKeys, numRequiredSigs, activationHeight, gracePeriod, nil)
+	hash := req.RotationHash()
+	for _, privKey := range vaultPrivKeys {
+		sig, _ := privKey.Sign(hash[:])
+		req.VaultSigs = append(req.VaultSigs, sig.Serialize())
+	}
+	actionContent := metadata.PortalVaultRotationRequestAction{
+		Meta:    *req,
+		TxReqID: common.HashH([]byte(txID)),
+		ShardID: shardID,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadataCommon.PortalV4VaultRotationRequestMeta), actionContentBase64Str}
+}
+
+func (s *PortalTestSuiteV4) TestVaultRotation() {
+	fmt.Println("Running TestVaultRotation - beacon height 99 ...")
+	tokenID := portal.TestnetPortalV4BTCID
+	portalTokenProcessor := s.portalParams.PortalTokens[tokenID]
+	pm := portal.NewPortalManager()
+	shardHeights := map[byte]uint64{
+		0: uint64(1003),
+	}
+	shardID := byte(0)
+
+	// current key set
+	oldPrivKeys := []*btcec.PrivateKey{}
+	oldMasterPubKeys := [][]byte{}
+	for _, seed := range []string{"vault-1", "vault-2", "vault-3", "vault-4"} {
+		privKey, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), chainhash.HashB([]byte(seed)))
+		oldPrivKeys = append(oldPrivKeys, privKey)
+		oldMasterPubKeys = append(oldMasterPubKeys, pubKey.SerializeCompressed())
+	}
+	s.portalParams.MasterPubKeys[tokenID] = oldMasterPubKeys
+	_, oldGeneralAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(oldMasterPubKeys, int(s.portalParams.NumRequiredSigs), "")
+	s.Equal(nil, err)
+	s.portalParams.GeneralMultiSigAddresses[tokenID] = oldGeneralAddress
+
+	bc := new(mocks.ChainRetriever)
+	bc.On("GetBTCChainParams").Return(&chaincfg.TestNet3Params)
+	bc.On("GetFinalBeaconHeight").Return(uint64(130))
+	bc.On("GetPortalV4GeneralMultiSigAddress", tokenID, mock.Anything).Return(s.portalParams.GeneralMultiSigAddresses[tokenID])
+
+	// new key set
+	newMasterPubKeys := [][]byte{}
+	for _, seed := range []string{"vault-rotation-1", "vault-rotation-2", "vault-rotation-3"} {
+		_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), chainhash.HashB([]byte(seed)))
+		newMasterPubKeys = append(newMasterPubKeys, pubKey.SerializeCompressed())
+	}
+	_, newGeneralAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(newMasterPubKeys, 2, "")
+	s.Equal(nil, err)
+
+	// vault: UTXOs held by the old key set and one UTXO held by the new key set
+	_, oldUserAddress, _ := portalTokenProcessor.GenerateOTMultisigAddress(
+		s.portalParams.MasterPubKeys[tokenID], int(s.portalParams.NumRequiredSigs), PORTALV4_USER_INC_ADDRESS_1)
+	_, newUserAddress, _ := portalTokenProcessor.GenerateOTMultisigAddress(newMasterPubKeys, 2, PORTALV4_USER_INC_ADDRESS_2)
+	buildUTXOs := func() map[string]map[string]*statedb.UTXO {
+		utxos := map[string]*statedb.UTXO{}
+		for i := 0; i < 7; i++ {
+			key, value := generateUTXOKeyAndValue(tokenID, oldUserAddress, common.HashH([]byte("txHashOld")).String(), uint32(i), uint64(1e6+i*1e5), PORTALV4_USER_INC_ADDRESS_1)
+			utxos[key] = value
+		}
+		key, value := generateUTXOKeyAndValue(tokenID, s.portalParams.GeneralMultiSigAddresses[tokenID], common.HashH([]byte("txHashChange")).String(), 0, 3e5, "")
+		utxos[key] = value
+		key, value = generateUTXOKeyAndValue(tokenID, newUserAddress, common.HashH([]byte("txHashNew")).String(), 0, 4e6, PORTALV4_USER_INC_ADDRESS_2)
+		utxos[key] = value
+		return map[string]map[string]*statedb.UTXO{tokenID: utxos}
+	}
+	s.currentPortalStateForProducer.UTXOs = buildUTXOs()
+	s.currentPortalStateForProcess.UTXOs = buildUTXOs()
+
+	// schedule the rotation
+	beaconHeight := uint64(99)
+	actions := []portalV4InstForProducer{
+		{inst: buildPortalVaultRotationRequestAction(tokenID, newMasterPubKeys, 2, 110, 40, oldPrivKeys[1:3], "rotation0", shardID)},
+		{inst: buildPortalVaultRotationRequestAction(tokenID, newMasterPubKeys, 2, 110, 40, oldPrivKeys[1:], "rotation1", shardID)},
+		{inst: buildPortalVaultRotationRequestAction(tokenID, newMasterPubKeys, 2, 120, 40, oldPrivKeys, "rotation2", shardID)},
+	}
+	newInsts, err := producerPortalInstructionsV4(
+		bc, beaconHeight, shardHeights, actions, &s.currentPortalStateForProducer, s.portalParams, shardID, pm.PortalInstProcessorsV4)
+	s.Equal(nil, err)
+	err = processPortalInstructionsV4(
+		bc, beaconHeight, newInsts, s.sdb, &s.currentPortalStateForProcess, s.portalParams, pm.PortalInstProcessorsV4)
+	s.Equal(nil, err)
+
+	// the rotation needs the signatures of 3 keys of the current key set
+	// and the last rotation is rejected while the accepted one is in progress
+	s.Equal(3, len(newInsts))
+	s.Equal(portalcommonv4.PortalV4RequestRejectedChainStatus, newInsts[0][2])
+	s.Equal("InvalidVaultSigs", newInsts[0][4])
+	s.Equal(portalcommonv4.PortalV4RequestAcceptedChainStatus, newInsts[1][2])
+	s.Equal(portalcommonv4.PortalV4RequestRejectedChainStatus, newInsts[2][2])
+	s.Equal("RotationInProgress", newInsts[2][4])
+	vaultRotation := s.currentPortalStateForProducer.VaultRotations[tokenID]
+	s.Equal(s.portalParams.GeneralMultiSigAddresses[tokenID], vaultRotation.OldGeneralMultiSigAddress)
+	s.Equal(newGeneralAddress, vaultRotation.GeneralMultiSigAddress)
+	s.Equal(uint64(150), vaultRotation.GraceEndHeight)
+	s.Equal(s.currentPortalStateForProcess, s.currentPortalStateForProducer)
+
+	// the old key set holds the vault until the activation
+	s.Equal(s.portalParams.MasterPubKeys[tokenID], s.currentPortalStateForProducer.GetVaultKeySet(tokenID, 109, s.portalParams).MasterPubKeys)
+	s.Equal(newMasterPubKeys, s.currentPortalStateForProducer.GetVaultKeySet(tokenID, 110, s.portalParams).MasterPubKeys)
+
+	// shields to the old addresses are accepted during the grace period only
+	s.Equal(2, len(s.currentPortalStateForProducer.GetShieldingKeySets(tokenID, 135, s.portalParams)))
+	s.Equal(1, len(s.currentPortalStateForProducer.GetShieldingKeySets(tokenID, 150, s.portalParams)))
+
+	// consolidate the retiring UTXOs at the next batching interval
+	beaconHeight = uint64(134)
+	newInsts, err = pm.PortalInstProcessorsV4[metadataCommon.PortalV4VaultConsolidationMeta].BuildNewInsts(
+		bc, "", shardID, &s.currentPortalStateForProducer, beaconHeight, shardHeights, s.portalParams, nil)
+	s.Equal(nil, err)
+	err = processPortalInstructionsV4(
+		bc, beaconHeight, newInsts, s.sdb, &s.currentPortalStateForProcess, s.portalParams, pm.PortalInstProcessorsV4)
+	s.Equal(nil, err)
+
+	// 7 inputs fit into a tx at most
+	s.Equal(2, len(newInsts))
+	newGeneralAddr, _ := btcutil.DecodeAddress(newGeneralAddress, &chaincfg.TestNet3Params)
+	newGeneralScript, _ := txscript.PayToAddrScript(newGeneralAddr)
+	numConsolidatedUTXOs := 0
+	for _, inst := range newInsts {
+		var content metadata.PortalUnshieldRequestBatchContent
+		err = json.Unmarshal([]byte(inst[3]), &content)
+		s.Equal(nil, err)
+		s.Equal(s.portalParams.MasterPubKeys[tokenID], content.MasterPubKeys)
+		s.Equal(s.portalParams.NumRequiredSigs, content.NumRequiredSigs)
+		s.Equal(0, len(content.UnshieldIDs))
+		numConsolidatedUTXOs += len(content.UTXOs)
+
+		rawTxBytes, _ := hex.DecodeString(content.RawExternalTx)
+		rawTx := wire.NewMsgTx(wire.TxVersion)
+		err = rawTx.Deserialize(bytes.NewReader(rawTxBytes))
+		s.Equal(nil, err)
+		totalInput := uint64(0)
+		for _, utxo := range content.UTXOs {
+			totalInput += utxo.GetOutputAmount()
+		}
+		s.Equal(1, len(rawTx.TxOut))
+		s.Equal(newGeneralScript, rawTx.TxOut[0].PkScript)
+		s.Equal(int64(totalInput-1e4), rawTx.TxOut[0].Value)
+	}
+	s.Equal(8, numConsolidatedUTXOs)
+	s.Equal(1, len(s.currentPortalStateForProducer.UTXOs[tokenID]))
+	s.Equal(2, len(s.currentPortalStateForProducer.VaultRotations[tokenID].PendingBatchIDs))
+	s.Equal(s.currentPortalStateForProcess, s.currentPortalStateForProducer)
+
+	// the rotation is not completed until the consolidation txs are confirmed
+	isCompleted, err := s.currentPortalStateForProducer.IsVaultRotationCompleted(tokenID, 151, s.portalParams)
+	s.Equal(nil, err)
+	s.Equal(false, isCompleted)
+}
+
 func TestPortalSuiteV4(t *testing.T) {
 	suite.Run(t, new(PortalTestSuiteV4))
 }
@@ -0,0 +1,182 @@
+package blockchain
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+// From ConsensusParam.RandomBeaconHeight the random number of an epoch is the hash of the aggregated BLS
+// signature over the previous random value and the epoch (consensustypes.RandomBeaconMessage) of a fixed set
+// of more than 2/3 of the beacon committee (consensustypes.RandomBeaconSigners). The votes for a beacon block
+// after the random time carry the BLS share of their validator. All of them sign the same message, so the
+// committing node keeps the shares of every vote of the epoch, including the votes received after their block
+// is committed, and aggregates the shares of that set into the validation data of the first block committed
+// once it has all of them. The producer of the next block turns them into a random beacon instruction.
+// BLS signatures are unique and the signers are fixed, so the value does not depend on who aggregates it.
+// If no random beacon is produced before the second last block of the epoch, that block carries the fallback
+// value (consensustypes.RandomBeaconFallbackValue), which is fixed in advance too. The voters holding an
+// aggregate refuse the fallback, so only the signers withholding their shares can choose between the two values.
+
+// isRandomBeaconHeight returns true if the beacon block at beaconHeight may carry the random beacon instruction
+func (blockchain *BlockChain) isRandomBeaconHeight(beaconHeight uint64) bool {
+	return beaconHeight >= config.Param().ConsensusParam.RandomBeaconHeight &&
+		blockchain.IsGreaterThanRandomTime(beaconHeight) &&
+		blockchain.GetEpochByHeight(beaconHeight-1) == blockchain.GetEpochByHeight(beaconHeight)
+}
+
+// GetRandomBeaconSigningData returns the random beacon message signed by the voters of block,
+// the random beacon instruction of the next block is built from their shares
+func (chain *BeaconChain) GetRandomBeaconSigningData(block types.BlockInterface) (common.Hash, uint64, bool) {
+	nextHeight := block.GetHeight() + 1
+	if !chain.Blockchain.isRandomBeaconHeight(nextHeight) {
+		return common.Hash{}, 0, false
+	}
+	view, ok := chain.GetViewByHash(block.GetPrevHash()).(*BeaconBestState)
+	if !ok || view == nil || view.IsGetRandomNumber {
+		return common.Hash{}, 0, false
+	}
+	return view.RandomBeaconValue, chain.Blockchain.GetEpochByHeight(nextHeight), true
+}
+
+// isRandomBeaconFallbackHeight returns true if the beacon block at beaconHeight carries the fallback random value
+// when the view before it has no random number yet
+func (blockchain *BlockChain) isRandomBeaconFallbackHeight(beaconHeight uint64) bool {
+	return blockchain.isRandomBeaconHeight(beaconHeight) && blockchain.IsLastBeaconHeightInEpoch(beaconHeight+1)
+}
+
+// generateRandomBeaconFallbackInstruction builds the instruction of the fallback random value, nil if the
+// block at beaconHeight does not carry it
+func (curView *BeaconBestState) generateRandomBeaconFallbackInstruction(blockchain *BlockChain, beaconHeight uint64) [][]string {
+	if curView.IsGetRandomNumber || !blockchain.isRandomBeaconFallbackHeight(beaconHeight) {
+		return nil
+	}
+	epoch := blockchain.GetEpochByHeight(beaconHeight)
+	fallbackInstruction := instruction.NewRandomBeaconFallbackInstruction(
+		epoch,
+		consensustypes.RandomBeaconFallbackValue(curView.RandomBeaconValue, epoch),
+	)
+	return [][]string{fallbackInstruction.ToString()}
+}
+
+// knownRandomBeaconInstruction builds the random beacon instruction of the block at beaconHeight from the shares
+// aggregated into the validation data of the best block, nil if it has none or they are invalid
+func (curView *BeaconBestState) knownRandomBeaconInstruction(blockchain *BlockChain, beaconHeight uint64) *instruction.RandomBeaconInstruction {
+	if curView.IsGetRandomNumber || !blockchain.isRandomBeaconHeight(beaconHeight) {
+		return nil
+	}
+	valData, err := consensustypes.DecodeValidationData(curView.BestBlock.ValidationData)
+	if err != nil || len(valData.RandomBeaconSig) == 0 {
+		return nil
+	}
+	randomBeaconInstruction := instruction.NewRandomBeaconInstructionWithValue(
+		blockchain.GetEpochByHeight(beaconHeight),
+		consensustypes.RandomBeaconValue(valData.RandomBeaconSig),
+		valData.RandomBeaconSig,
+		valData.RandomBeaconIdx,
+	)
+	if err := blockchain.verifyRandomBeaconInstruction(curView, randomBeaconInstruction, beaconHeight); err != nil {
+		Logger.log.Infof("Cannot produce random beacon at beacon height %v, %v", beaconHeight, err)
+		return nil
+	}
+	return randomBeaconInstruction
+}
+
+// generateRandomBeaconInstruction builds the random beacon instruction from the shares aggregated into the
+// validation data of the best block, or the fallback one at the second last block of the epoch
+func (curView *BeaconBestState) generateRandomBeaconInstruction(blockchain *BlockChain, beaconHeight uint64) [][]string {
+	randomBeaconInstruction := curView.knownRandomBeaconInstruction(blockchain, beaconHeight)
+	if randomBeaconInstruction == nil {
+		return curView.generateRandomBeaconFallbackInstruction(blockchain, beaconHeight)
+	}
+	return [][]string{randomBeaconInstruction.ToString()}
+}
+
+// verifyRandomBeaconFallbackForSigning refuses to sign a block carrying the fallback random value when the
+// validator holds a valid aggregate for the epoch, so the producer at the fallback height cannot force the
+// fallback by leaving the random beacon out. Only the voters check it: the validation data is not covered by
+// the block hash, a node syncing the block may have another copy of it and must accept the fallback
+func (blockchain *BlockChain) verifyRandomBeaconFallbackForSigning(
+	curView *BeaconBestState, randomBeaconInstructions [][]string, beaconHeight uint64,
+) error {
+	for _, inst := range randomBeaconInstructions {
+		randomBeaconInstruction, err := instruction.ValidateAndImportRandomBeaconInstructionFromString(inst)
+		if err != nil || !randomBeaconInstruction.IsFallback() {
+			continue
+		}
+		if curView.knownRandomBeaconInstruction(blockchain, beaconHeight) != nil {
+			return fmt.Errorf("a random beacon of epoch %v is aggregated, the fallback random value is refused", randomBeaconInstruction.Epoch)
+		}
+	}
+	return nil
+}
+
+// filterRandomBeaconInstruction returns the random beacon instructions of a beacon block, the validators
+// cannot build them from their own validation data
+func filterRandomBeaconInstruction(instructions [][]string) [][]string {
+	res := [][]string{}
+	for _, inst := range instructions {
+		if len(inst) > 0 && inst[0] == instruction.RANDOM_BEACON_ACTION {
+			res = append(res, inst)
+		}
+	}
+	return res
+}
+
+// addRandomBeaconInstruction appends the random beacon instructions
+func addRandomBeaconInstruction(instructions, randomBeaconInstructions [][]string) [][]string {
+	return append(instructions, randomBeaconInstructions...)
+}
+
+// verifyRandomBeaconInstruction checks the random beacon instruction of the beacon block at beaconHeight
+// against the view before the block. The shares were signed with the committee of the voted block, the
+// beacon committee does not change from committee state v2 so it is the committee of curView.
+// The voters also refuse a fallback when they hold an aggregate, see verifyRandomBeaconFallbackForSigning
+func (blockchain *BlockChain) verifyRandomBeaconInstruction(
+	curView *BeaconBestState, randomBeaconInstruction *instruction.RandomBeaconInstruction, beaconHeight uint64,
+) error {
+	if !blockchain.isRandomBeaconHeight(beaconHeight) {
+		return fmt.Errorf("beacon height %v cannot carry a random beacon", beaconHeight)
+	}
+	if curView.IsGetRandomNumber {
+		return fmt.Errorf("random number of epoch %v is already set", curView.Epoch)
+	}
+	if epoch := blockchain.GetEpochByHeight(beaconHeight); randomBeaconInstruction.Epoch != epoch {
+		return fmt.Errorf("expect random beacon of epoch %v, got %v", epoch, randomBeaconInstruction.Epoch)
+	}
+	if randomBeaconInstruction.IsFallback() {
+		if !blockchain.isRandomBeaconFallbackHeight(beaconHeight) {
+			return fmt.Errorf("beacon height %v cannot carry the fallback random value", beaconHeight)
+		}
+		if value := consensustypes.RandomBeaconFallbackValue(curView.RandomBeaconValue, randomBeaconInstruction.Epoch); !value.IsEqual(&randomBeaconInstruction.Value) {
+			return fmt.Errorf("expect fallback random value %v, got %v", value, randomBeaconInstruction.Value)
+		}
+		return nil
+	}
+	if value := consensustypes.RandomBeaconValue(randomBeaconInstruction.AggSig); !value.IsEqual(&randomBeaconInstruction.Value) {
+		return fmt.Errorf("expect random value %v, got %v", value, randomBeaconInstruction.Value)
+	}
+	committee := curView.GetBeaconCommittee()
+	if signers := consensustypes.RandomBeaconSigners(curView.RandomBeaconValue, len(committee)); !reflect.DeepEqual(signers, randomBeaconInstruction.SignersIdx) {
+		return fmt.Errorf("expect random beacon signers %v, got %v", signers, randomBeaconInstruction.SignersIdx)
+	}
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for _, member := range committee {
+		committeeBLSKeys = append(committeeBLSKeys, member.MiningPubKey[common.BlsConsensus])
+	}
+	data := consensustypes.RandomBeaconMessage(curView.RandomBeaconValue, randomBeaconInstruction.Epoch)
+	ok, err := blsmultisig.Verify(randomBeaconInstruction.AggSig, data, randomBeaconInstruction.SignersIdx, committeeBLSKeys)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invalid random beacon aggregated signature")
+	}
+	return nil
+}
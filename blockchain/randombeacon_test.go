@@ -0,0 +1,118 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate/externalmocks"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyRandomBeaconInstruction(t *testing.T) {
+	config.AbortParam()
+	config.Param().EpochParam.NumberOfBlockInEpoch = 100
+	config.Param().EpochParam.NumberOfBlockInEpochV2 = 100
+	config.Param().EpochParam.EpochV2BreakPoint = 1
+	config.Param().EpochParam.RandomTimeV2 = 50
+	config.Param().ConsensusParam.RandomBeaconHeight = 1
+	defer config.AbortParam()
+
+	secretKeys := [][]byte{}
+	committee := []incognitokey.CommitteePublicKey{}
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for _, seed := range []string{"random beacon 0", "random beacon 1", "random beacon 2", "random beacon 3"} {
+		sk, pk := blsmultisig.KeyGen([]byte(seed))
+		secretKeys = append(secretKeys, blsmultisig.SKBytes(sk))
+		committeeBLSKeys = append(committeeBLSKeys, blsmultisig.PKBytes(pk))
+		committee = append(committee, incognitokey.CommitteePublicKey{
+			MiningPubKey: map[string][]byte{common.BlsConsensus: blsmultisig.PKBytes(pk)},
+		})
+	}
+	beaconCommitteeState := &externalmocks.BeaconCommitteeState{}
+	beaconCommitteeState.On("GetBeaconCommittee").Return(committee)
+	prevValue := common.HashH([]byte("previous random value"))
+	curView := &BeaconBestState{
+		beaconCommitteeState: beaconCommitteeState,
+		RandomBeaconValue:    prevValue,
+	}
+	newInstruction := func(epoch uint64, signersIdx []int) *instruction.RandomBeaconInstruction {
+		sigs := [][]byte{}
+		for _, idx := range signersIdx {
+			sig, err := blsmultisig.Sign(consensustypes.RandomBeaconMessage(prevValue, epoch), secretKeys[idx], idx, committeeBLSKeys)
+			assert.Nil(t, err)
+			sigs = append(sigs, sig)
+		}
+		aggSig, err := blsmultisig.Combine(sigs)
+		assert.Nil(t, err)
+		return instruction.NewRandomBeaconInstructionWithValue(epoch, consensustypes.RandomBeaconValue(aggSig), aggSig, signersIdx)
+	}
+
+	bc := &BlockChain{}
+	signers := consensustypes.RandomBeaconSigners(prevValue, len(committee))
+	assert.Equal(t, 3, len(signers))
+	randomBeaconInstruction := newInstruction(2, signers)
+	assert.Nil(t, bc.verifyRandomBeaconInstruction(curView, randomBeaconInstruction, 160))
+	// before the random time of the epoch
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, randomBeaconInstruction, 120))
+	// signed for another epoch
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, newInstruction(3, signers), 160))
+	// not more than 2/3 of the committee
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, newInstruction(2, signers[:2]), 160))
+	// every other set of signers is rejected, the producer cannot choose the aggregated shares
+	for skip := range committee {
+		others := []int{}
+		for idx := range committee {
+			if idx != skip {
+				others = append(others, idx)
+			}
+		}
+		if assert.ObjectsAreEqual(signers, others) {
+			continue
+		}
+		assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, newInstruction(2, others), 160))
+	}
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, newInstruction(2, []int{0, 1, 2, 3}), 160))
+	// value not derived from the signature
+	tampered := *randomBeaconInstruction
+	tampered.Value = common.HashH([]byte("grinded"))
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, &tampered, 160))
+	// signers index not matching the signature
+	tampered = *randomBeaconInstruction
+	tampered.SignersIdx = signers[:2]
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, &tampered, 160))
+
+	// the fallback value is fixed and only carried by the second last block of the epoch
+	fallback := instruction.NewRandomBeaconFallbackInstruction(2, consensustypes.RandomBeaconFallbackValue(prevValue, 2))
+	assert.Nil(t, bc.verifyRandomBeaconInstruction(curView, fallback, 199))
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, fallback, 160))
+	tamperedFallback := instruction.NewRandomBeaconFallbackInstruction(2, common.HashH([]byte("grinded")))
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, tamperedFallback, 199))
+	assert.Nil(t, curView.generateRandomBeaconFallbackInstruction(bc, 160))
+	assert.Equal(t, [][]string{fallback.ToString()}, curView.generateRandomBeaconFallbackInstruction(bc, 199))
+	// the voters holding the aggregate refuse the fallback, the producer builds the random beacon from it
+	assert.Nil(t, bc.verifyRandomBeaconFallbackForSigning(curView, [][]string{fallback.ToString()}, 199))
+	validationData, err := consensustypes.EncodeValidationData(consensustypes.ValidationData{
+		RandomBeaconSig: randomBeaconInstruction.AggSig,
+		RandomBeaconIdx: randomBeaconInstruction.SignersIdx,
+	})
+	assert.Nil(t, err)
+	curView.BestBlock.ValidationData = validationData
+	assert.NotNil(t, bc.verifyRandomBeaconFallbackForSigning(curView, [][]string{fallback.ToString()}, 199))
+	assert.Nil(t, bc.verifyRandomBeaconFallbackForSigning(curView, [][]string{randomBeaconInstruction.ToString()}, 199))
+	assert.Equal(t, [][]string{randomBeaconInstruction.ToString()}, curView.generateRandomBeaconInstruction(bc, 199))
+	curView.BestBlock.ValidationData = ""
+
+	// random number already set in the epoch
+	curView.IsGetRandomNumber = true
+	assert.NotNil(t, bc.verifyRandomBeaconInstruction(curView, randomBeaconInstruction, 160))
+
+	instructions := [][]string{{instruction.SWAP_ACTION}}
+	instructions = addRandomBeaconInstruction(instructions, [][]string{randomBeaconInstruction.ToString()})
+	assert.Equal(t, [][]string{{instruction.SWAP_ACTION}, randomBeaconInstruction.ToString()}, instructions)
+	assert.Equal(t, [][]string{randomBeaconInstruction.ToString()}, filterRandomBeaconInstruction(instructions))
+}
@@ -0,0 +1,403 @@
+package blockchain
+
+import (
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	instruction "github.com/incognitochain/incognito-chain/instruction/pdexv3"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// types of request events
+const (
+	RequestEventTrade             = "trade"
+	RequestEventOrder             = "order"
+	RequestEventAddLiquidity      = "addliquidity"
+	RequestEventWithdrawLiquidity = "withdrawliquidity"
+	RequestEventShield            = "shield"
+	RequestEventUnshield          = "unshield"
+)
+
+// statuses of request events
+const (
+	RequestEventAcceptedStatus  = "accepted"
+	RequestEventRejectedStatus  = "rejected"
+	RequestEventRefundedStatus  = "refunded"
+	RequestEventWaitingStatus   = "waiting"
+	RequestEventMatchedStatus   = "matched"
+	RequestEventWithdrawnStatus = "withdrawn"
+	RequestEventConfirmedStatus = "confirmed" // unshield: burn proof is available
+)
+
+// RequestEvent is a status transition of a pDEX v3 or bridge request produced by a block
+type RequestEvent struct {
+	Type        string   `json:"Type"`
+	Status      string   `json:"Status"`
+	TxReqID     string   `json:"TxReqID"`
+	PoolPairIDs []string `json:"PoolPairIDs,omitempty"`
+	NftID       string   `json:"NftID,omitempty"`
+	OrderID     string   `json:"OrderID,omitempty"`
+	TokenID     string   `json:"TokenID,omitempty"`
+	ChainID     int      `json:"ChainID"` // common.BeaconChainID for a beacon block
+	BlockHeight uint64   `json:"BlockHeight"`
+	BlockHash   string   `json:"BlockHash"`
+}
+
+func getBeaconBurningConfirmMetas() []string {
+	metas := []string{ // Burning v2: sig on beacon only
+		strconv.Itoa(metadata.BurningConfirmMetaV2),
+		strconv.Itoa(metadata.BurningConfirmForDepositToSCMetaV2),
+		strconv.Itoa(metadata.BurningBSCConfirmMeta),
+		strconv.Itoa(metadata.BurningPRVERC20ConfirmMeta),
+		strconv.Itoa(metadata.BurningPRVBEP20ConfirmMeta),
+		strconv.Itoa(metadata.BurningPBSCConfirmForDepositToSCMeta),
+		strconv.Itoa(metadata.BurningPLGConfirmMeta),
+		strconv.Itoa(metadata.BurningPLGConfirmForDepositToSCMeta),
+		strconv.Itoa(metadata.BurningSOLConfirmMeta),
+		strconv.Itoa(metadata.BurningSOLConfirmForDepositToSCMeta)}
+	for _, evmNetwork := range config.Param().EVMNetworks {
+		metas = append(metas, strconv.Itoa(evmNetwork.BurningConfirmMeta))
+	}
+	return metas
+}
+
+func getShardBurningConfirmMetas() []string {
+	return []string{ // Burning v1: sig on both beacon and bridge
+		strconv.Itoa(metadata.BurningConfirmMeta),
+		strconv.Itoa(metadata.BurningConfirmForDepositToSCMeta),
+	}
+}
+
+// publishBeaconRequestEvents publishes the request events of the instructions of a new beacon block
+func (blockchain *BlockChain) publishBeaconRequestEvents(beaconBlock *types.BeaconBlock) {
+	events := extractRequestEvents(beaconBlock.Body.Instructions, getBeaconBurningConfirmMetas(), true)
+	if len(events) == 0 {
+		return
+	}
+	blockHash := beaconBlock.Header.Hash().String()
+	for _, event := range events {
+		event.ChainID = common.BeaconChainID
+		event.BlockHeight = beaconBlock.Header.Height
+		event.BlockHash = blockHash
+	}
+	blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.RequestEventTopic, events))
+}
+
+// publishShardRequestEvents publishes the request events of the instructions of a new shard block
+func (blockchain *BlockChain) publishShardRequestEvents(shardBlock *types.ShardBlock) {
+	events := extractRequestEvents(shardBlock.Body.Instructions, getShardBurningConfirmMetas(), false)
+	if len(events) == 0 {
+		return
+	}
+	blockHash := shardBlock.Header.Hash().String()
+	for _, event := range events {
+		event.ChainID = int(shardBlock.Header.ShardID)
+		event.BlockHeight = shardBlock.Header.Height
+		event.BlockHash = blockHash
+	}
+	blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.RequestEventTopic, events))
+}
+
+// extractRequestEvents parses the instructions of a block into request events,
+// pDEX v3 and shielding requests are only processed by beacon
+func extractRequestEvents(instructions [][]string, burningConfirmMetas []string, isBeacon bool) []*RequestEvent {
+	events := []*RequestEvent{}
+	for _, inst := range instructions {
+		if len(inst) < 2 {
+			continue
+		}
+		for _, meta := range burningConfirmMetas {
+			if inst[0] == meta {
+				events = append(events, extractUnshieldEvent(inst)...)
+			}
+		}
+		if !isBeacon {
+			continue
+		}
+		metaType, err := strconv.Atoi(inst[0])
+		if err != nil {
+			continue
+		}
+		switch metaType {
+		case metadataCommon.Pdexv3TradeRequestMeta:
+			events = append(events, extractTradeEvents(inst)...)
+		case metadataCommon.Pdexv3AddOrderRequestMeta:
+			events = append(events, extractAddOrderEvents(inst)...)
+		case metadataCommon.Pdexv3WithdrawOrderRequestMeta:
+			events = append(events, extractWithdrawOrderEvents(inst)...)
+		case metadataCommon.Pdexv3AddLiquidityRequestMeta:
+			events = append(events, extractAddLiquidityEvents(inst)...)
+		case metadataCommon.Pdexv3WithdrawLiquidityRequestMeta:
+			events = append(events, extractWithdrawLiquidityEvents(inst)...)
+		case metadata.IssuingRequestMeta, metadata.IssuingETHRequestMeta, metadata.IssuingBSCRequestMeta,
+			metadata.IssuingPRVERC20RequestMeta, metadata.IssuingPRVBEP20RequestMeta, metadata.IssuingPLGRequestMeta,
+			metadata.IssuingSOLRequestMeta, metadata.IssuingEVMNetworkRequestMeta:
+			events = append(events, extractShieldEvents(inst, metaType)...)
+		}
+	}
+	return events
+}
+
+func extractTradeEvents(inst []string) []*RequestEvent {
+	switch inst[1] {
+	case strconv.Itoa(metadataPdexv3.TradeAcceptedStatus):
+		action := &instruction.Action{Content: &metadataPdexv3.AcceptedTrade{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		md, _ := action.Content.(*metadataPdexv3.AcceptedTrade)
+		txReqID := action.RequestTxID().String()
+		events := []*RequestEvent{{
+			Type:        RequestEventTrade,
+			Status:      RequestEventAcceptedStatus,
+			TxReqID:     txReqID,
+			PoolPairIDs: md.TradePath,
+			TokenID:     md.TokenToBuy.String(),
+		}}
+		// orders matched by the trade
+		for index, orderChanges := range md.OrderChanges {
+			for orderID := range orderChanges {
+				events = append(events, &RequestEvent{
+					Type:        RequestEventOrder,
+					Status:      RequestEventMatchedStatus,
+					TxReqID:     txReqID,
+					PoolPairIDs: []string{md.TradePath[index]},
+					OrderID:     orderID,
+				})
+			}
+		}
+		return events
+	case strconv.Itoa(metadataPdexv3.TradeRefundedStatus):
+		action := &instruction.Action{Content: &metadataPdexv3.RefundedTrade{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		md, _ := action.Content.(*metadataPdexv3.RefundedTrade)
+		return []*RequestEvent{{
+			Type:    RequestEventTrade,
+			Status:  RequestEventRefundedStatus,
+			TxReqID: action.RequestTxID().String(),
+			TokenID: md.TokenID.String(),
+		}}
+	}
+	return nil
+}
+
+func extractAddOrderEvents(inst []string) []*RequestEvent {
+	switch inst[1] {
+	case strconv.Itoa(metadataPdexv3.OrderAcceptedStatus):
+		action := &instruction.Action{Content: &metadataPdexv3.AcceptedAddOrder{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		md, _ := action.Content.(*metadataPdexv3.AcceptedAddOrder)
+		return []*RequestEvent{{
+			Type:        RequestEventOrder,
+			Status:      RequestEventAcceptedStatus,
+			TxReqID:     action.RequestTxID().String(),
+			PoolPairIDs: []string{md.PoolPairID},
+			NftID:       md.NftID.String(),
+			OrderID:     md.OrderID,
+		}}
+	case strconv.Itoa(metadataPdexv3.OrderRefundedStatus):
+		action := &instruction.Action{Content: &metadataPdexv3.RefundedAddOrder{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		md, _ := action.Content.(*metadataPdexv3.RefundedAddOrder)
+		return []*RequestEvent{{
+			Type:    RequestEventOrder,
+			Status:  RequestEventRefundedStatus,
+			TxReqID: action.RequestTxID().String(),
+			TokenID: md.TokenID.String(),
+		}}
+	}
+	return nil
+}
+
+func extractWithdrawOrderEvents(inst []string) []*RequestEvent {
+	switch inst[1] {
+	case strconv.Itoa(metadataPdexv3.WithdrawOrderAcceptedStatus):
+		action := &instruction.Action{Content: &metadataPdexv3.AcceptedWithdrawOrder{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		md, _ := action.Content.(*metadataPdexv3.AcceptedWithdrawOrder)
+		return []*RequestEvent{{
+			Type:        RequestEventOrder,
+			Status:      RequestEventWithdrawnStatus,
+			TxReqID:     action.RequestTxID().String(),
+			PoolPairIDs: []string{md.PoolPairID},
+			OrderID:     md.OrderID,
+			TokenID:     md.TokenID.String(),
+		}}
+	case strconv.Itoa(metadataPdexv3.WithdrawOrderRejectedStatus):
+		action := &instruction.Action{Content: &metadataPdexv3.RejectedWithdrawOrder{}}
+		if err := action.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		md, _ := action.Content.(*metadataPdexv3.RejectedWithdrawOrder)
+		return []*RequestEvent{{
+			Type:        RequestEventOrder,
+			Status:      RequestEventRejectedStatus,
+			TxReqID:     action.RequestTxID().String(),
+			PoolPairIDs: []string{md.PoolPairID},
+			OrderID:     md.OrderID,
+		}}
+	}
+	return nil
+}
+
+func extractAddLiquidityEvents(inst []string) []*RequestEvent {
+	var status, poolPairID string
+	var contribution statedb.Pdexv3ContributionState
+	switch inst[1] {
+	case common.PDEContributionWaitingChainStatus:
+		waitingInst := instruction.NewWaitingAddLiquidity()
+		if err := waitingInst.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		status, contribution = RequestEventWaitingStatus, waitingInst.Contribution()
+	case common.PDEContributionRefundChainStatus:
+		refundInst := instruction.NewRefundAddLiquidity()
+		if err := refundInst.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		status, contribution = RequestEventRefundedStatus, refundInst.Contribution()
+	case common.PDEContributionMatchedChainStatus:
+		matchInst := instruction.NewMatchAddLiquidity()
+		if err := matchInst.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		status, contribution, poolPairID = RequestEventMatchedStatus, matchInst.Contribution(), matchInst.NewPoolPairID()
+	case common.PDEContributionMatchedNReturnedChainStatus:
+		matchAndReturnInst := instruction.NewMatchAndReturnAddLiquidity()
+		if err := matchAndReturnInst.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		status, contribution = RequestEventMatchedStatus, matchAndReturnInst.Contribution()
+	default:
+		return nil
+	}
+	value := contribution.Value()
+	if poolPairID == "" {
+		poolPairID = value.PoolPairID()
+	}
+	event := &RequestEvent{
+		Type:    RequestEventAddLiquidity,
+		Status:  status,
+		TxReqID: value.TxReqID().String(),
+		NftID:   value.NftID().String(),
+		TokenID: value.TokenID().String(),
+	}
+	if poolPairID != "" {
+		event.PoolPairIDs = []string{poolPairID}
+	}
+	return []*RequestEvent{event}
+}
+
+func extractWithdrawLiquidityEvents(inst []string) []*RequestEvent {
+	switch inst[1] {
+	case common.PDEWithdrawalAcceptedChainStatus:
+		acceptInst := instruction.NewAcceptWithdrawLiquidity()
+		if err := acceptInst.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		return []*RequestEvent{{
+			Type:        RequestEventWithdrawLiquidity,
+			Status:      RequestEventAcceptedStatus,
+			TxReqID:     acceptInst.TxReqID().String(),
+			PoolPairIDs: []string{acceptInst.PoolPairID()},
+			NftID:       acceptInst.NftID().String(),
+			TokenID:     acceptInst.TokenID().String(),
+		}}
+	case common.PDEWithdrawalRejectedChainStatus:
+		rejectInst := instruction.NewRejectWithdrawLiquidity()
+		if err := rejectInst.FromStringSlice(inst); err != nil {
+			return nil
+		}
+		return []*RequestEvent{{
+			Type:    RequestEventWithdrawLiquidity,
+			Status:  RequestEventRejectedStatus,
+			TxReqID: rejectInst.TxReqID().String(),
+		}}
+	}
+	return nil
+}
+
+func extractShieldEvents(inst []string, metaType int) []*RequestEvent {
+	if len(inst) != 4 {
+		return nil
+	}
+	switch inst[2] {
+	case "rejected":
+		txReqID, err := common.Hash{}.NewHashFromStr(inst[3])
+		if err != nil {
+			return nil
+		}
+		return []*RequestEvent{{
+			Type:    RequestEventShield,
+			Status:  RequestEventRejectedStatus,
+			TxReqID: txReqID.String(),
+		}}
+	case "accepted":
+		var txReqID, tokenID common.Hash
+		switch metaType {
+		case metadata.IssuingRequestMeta:
+			acceptedInst, err := metadata.ParseIssuingInstAcceptedContent(inst[3])
+			if err != nil {
+				return nil
+			}
+			txReqID, tokenID = acceptedInst.TxReqID, acceptedInst.IncTokenID
+		case metadata.IssuingSOLRequestMeta:
+			acceptedInst, err := metadata.ParseSOLIssuingInstAcceptedContent(inst[3])
+			if err != nil {
+				return nil
+			}
+			txReqID, tokenID = acceptedInst.TxReqID, acceptedInst.IncTokenID
+		default:
+			acceptedInst, err := metadata.ParseEVMIssuingInstAcceptedContent(inst[3])
+			if err != nil {
+				return nil
+			}
+			txReqID, tokenID = acceptedInst.TxReqID, acceptedInst.IncTokenID
+		}
+		return []*RequestEvent{{
+			Type:    RequestEventShield,
+			Status:  RequestEventAcceptedStatus,
+			TxReqID: txReqID.String(),
+			TokenID: tokenID.String(),
+		}}
+	}
+	return nil
+}
+
+func extractUnshieldEvent(inst []string) []*RequestEvent {
+	if len(inst) < 8 {
+		return nil
+	}
+	txReqID, err := common.Hash{}.NewHashFromStr(inst[5])
+	if err != nil {
+		return nil
+	}
+	event := &RequestEvent{
+		Type:    RequestEventUnshield,
+		Status:  RequestEventConfirmedStatus,
+		TxReqID: txReqID.String(),
+	}
+	incTokenIDBytes, _, err := base58.Base58Check{}.Decode(inst[6])
+	if err == nil {
+		tokenID, err := common.Hash{}.NewHash(incTokenIDBytes)
+		if err == nil {
+			event.TokenID = tokenID.String()
+		}
+	}
+	return []*RequestEvent{event}
+}
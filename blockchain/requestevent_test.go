@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	instruction "github.com/incognitochain/incognito-chain/instruction/pdexv3"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractRequestEvents(t *testing.T) {
+	tradeTxID := common.HashH([]byte("trade"))
+	shieldTxID := common.HashH([]byte("shield"))
+	unshieldTxID := common.HashH([]byte("unshield"))
+
+	acceptedTrade := instruction.NewAction(&metadataPdexv3.AcceptedTrade{
+		Amount:       100,
+		TradePath:    []string{"pair0", "pair1"},
+		TokenToBuy:   common.PRVCoinID,
+		PairChanges:  [][2]*big.Int{{big.NewInt(1), big.NewInt(-1)}, {big.NewInt(1), big.NewInt(-1)}},
+		RewardEarned: []map[common.Hash]uint64{{}, {}},
+		OrderChanges: []map[string][2]*big.Int{{}, {"order1": {big.NewInt(1), big.NewInt(-1)}}},
+	}, tradeTxID, 0).StringSlice()
+	shieldRejected := []string{strconv.Itoa(metadata.IssuingETHRequestMeta), "1", "rejected", shieldTxID.String()}
+	unshieldConfirmed := []string{
+		strconv.Itoa(metadata.BurningConfirmMeta), "1", "", "", "",
+		unshieldTxID.String(), base58.Base58Check{}.Encode(common.PRVCoinID[:], 0x00), "",
+	}
+	instructions := [][]string{acceptedTrade, shieldRejected, unshieldConfirmed}
+
+	// beacon: pDEX and bridge requests, shard burning confirm metas are not included
+	events := extractRequestEvents(instructions, []string{}, true)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, RequestEventTrade, events[0].Type)
+	assert.Equal(t, RequestEventAcceptedStatus, events[0].Status)
+	assert.Equal(t, tradeTxID.String(), events[0].TxReqID)
+	assert.Equal(t, []string{"pair0", "pair1"}, events[0].PoolPairIDs)
+	assert.Equal(t, RequestEventOrder, events[1].Type)
+	assert.Equal(t, RequestEventMatchedStatus, events[1].Status)
+	assert.Equal(t, "order1", events[1].OrderID)
+	assert.Equal(t, []string{"pair1"}, events[1].PoolPairIDs)
+	assert.Equal(t, RequestEventShield, events[2].Type)
+	assert.Equal(t, RequestEventRejectedStatus, events[2].Status)
+	assert.Equal(t, shieldTxID.String(), events[2].TxReqID)
+
+	// shard: only burning confirm instructions
+	events = extractRequestEvents(instructions, getShardBurningConfirmMetas(), false)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, RequestEventUnshield, events[0].Type)
+	assert.Equal(t, RequestEventConfirmedStatus, events[0].Status)
+	assert.Equal(t, unshieldTxID.String(), events[0].TxReqID)
+	assert.Equal(t, common.PRVIDStr, events[0].TokenID)
+}
@@ -145,9 +145,17 @@ func (blockchain *BlockChain) GetBTCChainParams() *chaincfg.Params {
 	return blockchain.GetBTCHeaderChain().GetChainParams()
 }
 
-func (blockchain *BlockChain) GetPortalFeederAddress(beaconHeight uint64) string {
+// GetUTXOHeaderChain returns the relaying header chain of a Bitcoin-derived chain
+func (blockchain *BlockChain) GetUTXOHeaderChain(chainID string) *btcrelaying.BlockChain {
+	if chainID == blockchain.GetBTCChainID() {
+		return blockchain.GetBTCHeaderChain()
+	}
+	return blockchain.GetConfig().UTXOChains[chainID]
+}
+
+func (blockchain *BlockChain) GetPortalFeederAddresses(beaconHeight uint64) []string {
 	portalParams := blockchain.GetPortalParamsV3(beaconHeight)
-	return portalParams.PortalFeederAddress
+	return portalParams.GetFeederAddresses()
 }
 
 // convertDurationTimeToBeaconBlocks returns number of beacon blocks corresponding to duration time
@@ -2,7 +2,6 @@ package blockchain
 
 import (
 	"fmt"
-	"github.com/incognitochain/incognito-chain/config"
 	"strings"
 
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -102,7 +101,8 @@ func (blockchain *BlockChain) ValidateReturnStakingTxFromBeaconInstructions(
 			if err != nil || !isMinted {
 				return errors.Errorf("this is not tx mint for return staking. Error %v", err)
 			}
-			if ok := mintCoin.CheckCoinValid(returnMeta.StakerAddress, returnMeta.SharedRandom, config.Param().StakingAmountShard); !ok {
+			// the amount is compared with the one wanted by beacon instructions below, it can be reduced by slashing
+			if ok := mintCoin.CheckCoinValid(returnMeta.StakerAddress, returnMeta.SharedRandom, mintCoin.GetValue()); !ok {
 				return errors.Errorf("mint data is invalid: Address %v; Amount %v", returnMeta.StakerAddress, mintCoin.GetValue())
 			}
 			if coinID.String() != common.PRVIDStr {
@@ -329,11 +329,17 @@ func (blockchain *BlockChain)  getReturnStakingInfoFromBeaconInstructions(
 						Logger.log.Error(err)
 						continue
 					}
+					// equivocating validators only get back part of their staking amount
+					stakingAmount := txMeta.StakingAmountShard * uint64(returnStakingIns.PercentReturns[i]) / 100
+					if stakingAmount == 0 {
+						Logger.log.Infof("Staking amount of tx %v is fully slashed", txHash.String())
+						continue
+					}
 					res[txHash] = returnStakingInfo{
 						SwapoutPubKey: v,
 						FunderAddress: keyWallet.KeySet.PaymentAddress,
 						StakingTx:     txData,
-						StakingAmount: txMeta.StakingAmountShard,
+						StakingAmount: stakingAmount,
 					}
 				}
 			}
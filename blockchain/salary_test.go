@@ -1,8 +1,6 @@
 package blockchain
 
 import (
-	"io/ioutil"
-	"os"
 	"reflect"
 	"testing"
 	"time"
@@ -16,6 +14,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/instruction"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -33,11 +32,7 @@ var (
 )
 
 var _ = func() (_ struct{}) {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "test_reward")
-	if err != nil {
-		panic(err)
-	}
-	diskDB, _ = incdb.Open("leveldb", dbPath)
+	diskDB, _ = incdb.Open("memdb")
 	wrarperDB = statedb.NewDatabaseAccessWarper(diskDB)
 	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("test", true))
@@ -55,11 +50,7 @@ var _ = func() (_ struct{}) {
 }()
 
 func initStateDB() {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "data")
-	if err != nil {
-		panic(err)
-	}
-	diskDB, _ = incdb.Open("leveldb", dbPath)
+	diskDB, _ = incdb.Open("memdb")
 	wrarperDB = statedb.NewDatabaseAccessWarper(diskDB)
 	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("test", true))
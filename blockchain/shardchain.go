@@ -409,6 +409,11 @@ func (chain *ShardChain) GetPortalParamsV4(beaconHeight uint64) portalv4.PortalP
 	return chain.Blockchain.GetPortalParamsV4(beaconHeight)
 }
 
+// GetRandomBeaconSigningData the random beacon is only signed by the beacon committee
+func (chain *ShardChain) GetRandomBeaconSigningData(block types.BlockInterface) (common.Hash, uint64, bool) {
+	return common.Hash{}, 0, false
+}
+
 //CommitteesV2 get committees by block for shardChain
 // Input block must be ShardBlock
 func (chain *ShardChain) GetCommitteeV2(block types.BlockInterface) ([]incognitokey.CommitteePublicKey, error) {
@@ -24,7 +24,10 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/instruction"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
+	"github.com/incognitochain/incognito-chain/trie"
 )
 
 // VerifyPreSignShardBlock Verify Shard Block Before Signing
@@ -132,7 +135,7 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(
 // InsertShardBlock Insert Shard Block into blockchain
 // this block must have full information (complete block)
 func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, shouldValidate bool) error {
-	//startTimeInsertShardBlock := time.Now()
+	startTimeInsertShardBlock := time.Now()
 	blockHash := shardBlock.Header.Hash()
 	blockHeight := shardBlock.Header.Height
 	shardID := shardBlock.Header.ShardID
@@ -267,12 +270,26 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 	blockchain.removeOldDataAfterProcessingShardBlock(shardBlock, shardID)
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBeststateTopic, newBestState))
+	go blockchain.publishShardRequestEvents(shardBlock)
 	Logger.log.Infof("SHARD %+v | Finish Insert new block %d, with hash %+v 🔗, "+
 		"Found 🔎 %+v transactions, "+
 		"%+v cross shard transactions, "+
 		"%+v instruction",
 		shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash,
 		len(shardBlock.Body.Transactions), len(shardBlock.Body.CrossTransactions), len(shardBlock.Body.Instructions))
+	shardTimer(shardID, shardInsertBlockTimer).UpdateSince(startTimeInsertShardBlock)
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.NumOfBlockInsertToChain,
+		grafana.MeasurementValue: float64(1),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         strconv.Itoa(int(shardID)),
+	})
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.TxInOneBlock,
+		grafana.MeasurementValue: float64(len(shardBlock.Body.Transactions)),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         strconv.Itoa(int(shardID)),
+	})
 	return nil
 }
 
@@ -491,7 +508,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 	if err != nil {
 		return NewBlockChainError(ResponsedTransactionWithMetadataError, err)
 	}
-	shardVerifyPreprocesingTimer.UpdateSince(startTimeVerifyPreProcessingShardBlock)
+	shardTimer(shardBlock.Header.ShardID, shardVerifyPreprocesingTimer).UpdateSince(startTimeVerifyPreProcessingShardBlock)
 	// Get cross shard shardBlock from pool
 	if isPreSign {
 		err := blockchain.verifyPreProcessingShardBlockForSigning(curView, shardBlock, beaconBlocks, txInstructions, shardID, committees)
@@ -666,7 +683,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *S
 			return NewBlockChainError(CrossShardBlockError, fmt.Errorf("Can't not verify all cross shard block from shard %+v", fromShard))
 		}
 	}
-	shardVerifyPreprocesingForPreSignTimer.UpdateSince(startTimeVerifyPreProcessingShardBlockForSigning)
+	shardTimer(shardBlock.Header.ShardID, shardVerifyPreprocesingForPreSignTimer).UpdateSince(startTimeVerifyPreProcessingShardBlockForSigning)
 	return nil
 }
 
@@ -710,7 +727,7 @@ func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(blockchain *
 	if shardBlock.Header.BeaconHeight < shardBestState.BeaconHeight {
 		return NewBlockChainError(ShardBestStateBeaconHeightNotCompatibleError, fmt.Errorf("Shard Block contain invalid beacon height, current beacon height %+v but get %+v ", shardBestState.BeaconHeight, shardBlock.Header.BeaconHeight))
 	}
-	shardVerifyWithBestStateTimer.UpdateSince(startTimeVerifyBestStateWithShardBlock)
+	shardTimer(shardBlock.Header.ShardID, shardVerifyWithBestStateTimer).UpdateSince(startTimeVerifyBestStateWithShardBlock)
 	Logger.log.Debugf("SHARD %+v | Finish VerifyBestStateWithShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash().String())
 	return nil
 }
@@ -805,7 +822,7 @@ func (oldBestState *ShardBestState) updateShardBestState(blockchain *BlockChain,
 		shardBestState.MaxShardCommitteeSize = newMaxCommitteeSize
 	}
 
-	shardUpdateBestStateTimer.UpdateSince(startTimeUpdateShardBestState)
+	shardTimer(shardBlock.Header.ShardID, shardUpdateBestStateTimer).UpdateSince(startTimeUpdateShardBestState)
 	Logger.log.Debugf("SHARD %+v | Finish update Beststate with new Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, *shardBlock.Hash())
 	return shardBestState, hashes, committeeChange, nil
 }
@@ -906,7 +923,7 @@ func (shardBestState *ShardBestState) verifyPostProcessingShardBlock(shardBlock
 	//if hash, isOk := verifyHashFromMapStringString(shardBestState.StakingTx, shardBlock.Header.StakingTxRoot); !isOk {
 	//	return NewBlockChainError(ShardPendingValidatorRootHashError, fmt.Errorf("Expect shard staking root hash to be %+v but get %+v", shardBlock.Header.StakingTxRoot, hash))
 	//}
-	shardVerifyPostProcessingTimer.UpdateSince(startTimeVerifyPostProcessingShardBlock)
+	shardTimer(shardBlock.Header.ShardID, shardVerifyPostProcessingTimer).UpdateSince(startTimeVerifyPostProcessingShardBlock)
 	Logger.log.Debugf("SHARD %+v | Finish VerifyPostProcessing Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash().String())
 	return nil
 }
@@ -946,6 +963,11 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(
 		Logger.log.Errorf("Can not get beacon view state for new block err: %+v, get from beacon hash %v", err, beaconHash.String())
 		return err
 	}
+	for _, tx := range txs {
+		if err := tx_generic.ValidateExpiryHeight(tx, curView.ShardHeight+1, beaconHeight); err != nil {
+			return err
+		}
+	}
 	st = time.Now()
 	err = blockchain.verifyTransactionIndividuallyFromNewBlock(shardID, txs, beaconHeight, beaconHash, curView)
 	Logger.log.Infof("[validatetxs] verifyTransactionIndividuallyFromNewBlock cost %v", time.Since(st))
@@ -1116,11 +1138,7 @@ func (blockchain *BlockChain) processStoreShardBlock(
 		return NewBlockChainError(FetchAndStoreCrossTransactionError, err)
 	}
 	// Save result of BurningConfirm instruction to get proof later
-	metas := []string{ // Burning v1: sig on both beacon and bridge
-		strconv.Itoa(metadata.BurningConfirmMeta),
-		strconv.Itoa(metadata.BurningConfirmForDepositToSCMeta),
-	}
-	err = blockchain.storeBurningConfirm(newShardState.featureStateDB, shardBlock.Body.Instructions, shardBlock.Header.Height, metas)
+	err = blockchain.storeBurningConfirm(newShardState.featureStateDB, shardBlock.Body.Instructions, shardBlock.Header.Height, getShardBurningConfirmMetas())
 	if err != nil {
 		return NewBlockChainError(StoreBurningConfirmError, err)
 	}
@@ -1230,6 +1248,9 @@ func (blockchain *BlockChain) processStoreShardBlock(
 	if err := rawdbv2.StoreShardRootsHash(batchData, shardID, blockHash, sRH); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
+	if err := trie.RetainRoots(blockchain.GetShardChainDatabase(shardID), blockHash, shardStateRoots(&sRH)...); err != nil {
+		return NewBlockChainError(StatePruningError, err)
+	}
 
 	//statedb===========================END
 	if err := rawdbv2.StoreShardBlock(batchData, blockHash, shardBlock); err != nil {
@@ -1292,6 +1313,10 @@ func (blockchain *BlockChain) processStoreShardBlock(
 	if err := batchData.Write(); err != nil {
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
+	if err := blockchain.pruneShardStates(shardID); err != nil {
+		Logger.log.Error(err)
+	}
+	blockchain.archiveShardBlocks(shardID)
 
 	if !config.Config().ForceBackup {
 		return nil
@@ -1308,10 +1333,21 @@ func (blockchain *BlockChain) processStoreShardBlock(
 		err := blockchain.GetShardChainDatabase(newShardState.ShardID).Backup(fmt.Sprintf("../../backup/shard%d/%d", newShardState.ShardID, newShardState.Epoch))
 		if err != nil {
 			blockchain.GetShardChainDatabase(newShardState.ShardID).RemoveBackup(fmt.Sprintf("../../backup/shard%d/%d", newShardState.ShardID, newShardState.Epoch))
+		} else {
+			err = blockchain.writeBackupManifest(&BackupManifest{
+				ChainID:    int(newShardState.ShardID),
+				Epoch:      newShardState.Epoch,
+				Height:     newShardState.ShardHeight,
+				BlockHash:  newShardState.BestBlockHash,
+				ShardRoots: &sRH,
+			}, BackupChainName(int(newShardState.ShardID)))
+			if err != nil {
+				Logger.log.Error(err)
+			}
 		}
 	}
 
-	shardStoreBlockTimer.UpdateSince(startTimeProcessStoreShardBlock)
+	shardTimer(shardBlock.Header.ShardID, shardStoreBlockTimer).UpdateSince(startTimeProcessStoreShardBlock)
 	Logger.log.Infof("SHARD %+v | 🔎 %d transactions in block height %+v \n", shardBlock.Header.ShardID, len(shardBlock.Body.Transactions), blockHeight)
 	return nil
 }
@@ -1458,7 +1494,7 @@ func (blockchain *BlockChain) storeTokenInitInstructions(stateDB *statedb.StateD
 
 			case metadata.IssuingETHRequestMeta, metadata.IssuingBSCRequestMeta,
 				metadata.IssuingPRVERC20RequestMeta, metadata.IssuingPRVBEP20RequestMeta,
-				metadata.IssuingPLGRequestMeta:
+				metadata.IssuingPLGRequestMeta, metadata.IssuingEVMNetworkRequestMeta:
 				if len(l) >= 4 && l[2] == "accepted" {
 					acceptedContent, err := metadata.ParseEVMIssuingInstAcceptedContent(l[3])
 					if err != nil {
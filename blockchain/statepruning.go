@@ -0,0 +1,365 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// State pruning keeps the state tries of the views of each chain and of the finalized blocks up to
+// PruneKeepViews blocks behind the final view. Every stored block retains its state roots, finalized
+// blocks release them once they fall out of that window (see trie.RetainRoots). Beacon blocks also
+// retain the consensus state of the beacon blocks their shard blocks take the committee from, and the
+// beacon states stay until the slowest synced shard has processed them.
+
+// storedView holds the fields of a view saved by BackupBeaconViews or BackupShardViews that pruning needs
+type storedView struct {
+	BestBlockHash common.Hash
+	BeaconHeight  uint64
+	ShardHeight   uint64
+	BestBlock     struct {
+		Header struct {
+			CommitteeFromBlock common.Hash
+		}
+	}
+}
+
+// finalStoredView returns the lowest view, which is the final one
+func finalStoredView(views []storedView, height func(storedView) uint64) storedView {
+	final := views[0]
+	for _, view := range views[1:] {
+		if height(view) < height(final) {
+			final = view
+		}
+	}
+	return final
+}
+
+func storedBeaconViews(db incdb.Database) ([]storedView, error) {
+	data, err := rawdbv2.GetBeaconViews(db)
+	if err != nil {
+		return nil, err
+	}
+	views := []storedView{}
+	if err := json.Unmarshal(data, &views); err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, NewBlockChainError(StatePruningError, errors.New("no beacon view stored"))
+	}
+	return views, nil
+}
+
+func storedShardViews(db incdb.Database, shardID byte) ([]storedView, error) {
+	data, err := rawdbv2.GetShardBestState(db, shardID)
+	if err != nil {
+		return nil, err
+	}
+	views := []storedView{}
+	if err := json.Unmarshal(data, &views); err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, NewBlockChainError(StatePruningError, fmt.Errorf("no view of shard %v stored", shardID))
+	}
+	return views, nil
+}
+
+// keptAbove returns the height of the last block whose state is released when the final view is at height
+func keptAbove(height uint64, keepViews uint64) uint64 {
+	if height <= keepViews {
+		return 0
+	}
+	return height - keepViews
+}
+
+func shardStateRoots(sRH *ShardRootHash) []common.Hash {
+	return []common.Hash{
+		sRH.ConsensusStateDBRootHash,
+		sRH.TransactionStateDBRootHash,
+		sRH.FeatureStateDBRootHash,
+		sRH.RewardStateDBRootHash,
+		sRH.SlashStateDBRootHash,
+	}
+}
+
+// beaconStateRoots returns the state roots of a beacon block together with the consensus roots of the
+// blocks its shard states take their committee from. The committee state of a block this node never
+// stored, below a state snapshot, is skipped.
+func beaconStateRoots(db incdb.Database, bRH *BeaconRootHash, block *types.BeaconBlock) []common.Hash {
+	roots := []common.Hash{
+		bRH.ConsensusStateDBRootHash,
+		bRH.FeatureStateDBRootHash,
+		bRH.RewardStateDBRootHash,
+		bRH.SlashStateDBRootHash,
+	}
+	committeeFromBlocks := make(map[common.Hash]struct{})
+	for _, shardStates := range block.Body.ShardState {
+		for _, shardState := range shardStates {
+			committeeFromBlocks[shardState.CommitteeFromBlock] = struct{}{}
+		}
+	}
+	for hash := range committeeFromBlocks {
+		if hash == (common.Hash{}) {
+			continue
+		}
+		if cfbRH, err := GetBeaconRootsHashByBlockHash(db, hash); err == nil {
+			roots = append(roots, cfbRH.ConsensusStateDBRootHash)
+		}
+	}
+	return roots
+}
+
+func storedBeaconStateRoots(db incdb.Database, hash common.Hash) ([]common.Hash, error) {
+	bRH, err := GetBeaconRootsHashByBlockHash(db, hash)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rawdbv2.GetBeaconBlockByHash(db, hash)
+	if err != nil {
+		return nil, err
+	}
+	block := types.NewBeaconBlock()
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return beaconStateRoots(db, bRH, block), nil
+}
+
+// keepPinnedStateRoots adds to keep the roots of chainID of the state snapshots pinned in db, under their checkpoint
+func keepPinnedStateRoots(db incdb.Database, chainID int, keep map[common.Hash][]common.Hash) error {
+	pinned, err := rawdbv2.GetStateSnapshots(db)
+	if err != nil {
+		return err
+	}
+	for checkpoint, data := range pinned {
+		snapshot := &StateSnapshot{}
+		if err := json.Unmarshal(data, snapshot); err != nil {
+			return err
+		}
+		roots, err := snapshot.StateRoots()
+		if err != nil {
+			return err
+		}
+		for _, root := range roots {
+			if root.ChainID == chainID {
+				keep[checkpoint] = append(keep[checkpoint], root.Root)
+			}
+		}
+	}
+	return nil
+}
+
+// PruneShardState deletes the shard states that are neither in a stored view, among the keepViews
+// finalized blocks before the final view nor in a pinned state snapshot, then enables state pruning on the shard database. The chain
+// must not be running.
+func PruneShardState(db incdb.Database, shardID byte, keepViews uint64) (*trie.PruneStats, error) {
+	views, err := storedShardViews(db, shardID)
+	if err != nil {
+		return nil, NewBlockChainError(StatePruningError, err)
+	}
+	final := finalStoredView(views, func(view storedView) uint64 { return view.ShardHeight })
+	pruneHeight := keptAbove(final.ShardHeight, keepViews)
+
+	keep := make(map[common.Hash][]common.Hash)
+	for height := pruneHeight + 1; height <= final.ShardHeight; height++ {
+		// blocks below a state snapshot are not stored
+		hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+		if err != nil {
+			continue
+		}
+		sRH, err := GetShardRootsHashByBlockHash(db, shardID, *hash)
+		if err != nil {
+			return nil, NewBlockChainError(StatePruningError, err)
+		}
+		keep[*hash] = shardStateRoots(sRH)
+	}
+	for _, view := range views {
+		sRH, err := GetShardRootsHashByBlockHash(db, shardID, view.BestBlockHash)
+		if err != nil {
+			return nil, NewBlockChainError(StatePruningError, err)
+		}
+		keep[view.BestBlockHash] = shardStateRoots(sRH)
+	}
+	if err := keepPinnedStateRoots(db, int(shardID), keep); err != nil {
+		return nil, NewBlockChainError(StatePruningError, err)
+	}
+	stats, err := trie.Prune(db, pruneHeight, keep)
+	if err != nil {
+		return nil, NewBlockChainError(StatePruningError, err)
+	}
+	return stats, nil
+}
+
+// PruneBeaconState deletes the beacon states that are neither in a stored view, among the keepViews
+// finalized blocks before the final view, still to be processed by a synced shard, needed for the
+// committee of a shard view nor in a pinned state snapshot, then enables state pruning on the beacon database. dbs are the databases
+// of every chain, by chain ID. The chain must not be running.
+func PruneBeaconState(dbs map[int]incdb.Database, keepViews uint64) (*trie.PruneStats, error) {
+	db := dbs[common.BeaconChainID]
+	views, err := storedBeaconViews(db)
+	if err != nil {
+		return nil, NewBlockChainError(StatePruningError, err)
+	}
+	final := finalStoredView(views, func(view storedView) uint64 { return view.BeaconHeight })
+	keptFrom := final.BeaconHeight
+	committeeFromBlocks := []common.Hash{}
+	for chainID, shardDB := range dbs {
+		if chainID == common.BeaconChainID {
+			continue
+		}
+		shardViews, err := storedShardViews(shardDB, byte(chainID))
+		if err != nil {
+			continue
+		}
+		shardFinal := finalStoredView(shardViews, func(view storedView) uint64 { return view.ShardHeight })
+		if shardFinal.ShardHeight > 1 && shardFinal.BeaconHeight < keptFrom {
+			keptFrom = shardFinal.BeaconHeight
+		}
+		for _, view := range shardViews {
+			committeeFromBlocks = append(committeeFromBlocks, view.BestBlock.Header.CommitteeFromBlock)
+		}
+	}
+	pruneHeight := keptAbove(keptFrom, keepViews)
+
+	keep := make(map[common.Hash][]common.Hash)
+	for height := pruneHeight + 1; height <= final.BeaconHeight; height++ {
+		hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+		if err != nil {
+			continue
+		}
+		if keep[*hash], err = storedBeaconStateRoots(db, *hash); err != nil {
+			return nil, NewBlockChainError(StatePruningError, err)
+		}
+	}
+	for _, view := range views {
+		if keep[view.BestBlockHash], err = storedBeaconStateRoots(db, view.BestBlockHash); err != nil {
+			return nil, NewBlockChainError(StatePruningError, err)
+		}
+	}
+	// a shard restored from a snapshot may take its committee from a block no kept beacon block refers to
+	for _, hash := range committeeFromBlocks {
+		if _, ok := keep[hash]; ok || hash == (common.Hash{}) {
+			continue
+		}
+		if cfbRH, err := GetBeaconRootsHashByBlockHash(db, hash); err == nil {
+			keep[hash] = []common.Hash{cfbRH.ConsensusStateDBRootHash}
+		}
+	}
+	// pinned shard snapshots also hold the beacon consensus state of their committee
+	for _, chainDB := range dbs {
+		if err := keepPinnedStateRoots(chainDB, common.BeaconChainID, keep); err != nil {
+			return nil, NewBlockChainError(StatePruningError, err)
+		}
+	}
+	stats, err := trie.Prune(db, pruneHeight, keep)
+	if err != nil {
+		return nil, NewBlockChainError(StatePruningError, err)
+	}
+	return stats, nil
+}
+
+// initStatePruning brings the databases in line with the pruning config when the node starts: a full
+// pass on the databases pruning was just enabled for, and the removal of the reference counts when it
+// was disabled.
+func (blockchain *BlockChain) initStatePruning() error {
+	cfg := config.Config()
+	if cfg == nil {
+		return nil
+	}
+	dbs := blockchain.config.DataBase
+	if !cfg.StatePruning {
+		for chainID, db := range dbs {
+			if !trie.IsPruningEnabled(db) {
+				continue
+			}
+			Logger.log.Warnf("State pruning disabled on chain %v, the states already pruned are not restored", chainID)
+			if err := trie.DisablePruning(db); err != nil {
+				return NewBlockChainError(StatePruningError, err)
+			}
+		}
+		return nil
+	}
+	for chainID, db := range dbs {
+		if trie.IsPruningEnabled(db) {
+			continue
+		}
+		Logger.log.Infof("Prune the state of chain %v, keeping %v finalized views", chainID, cfg.PruneKeepViews)
+		var stats *trie.PruneStats
+		var err error
+		if chainID == common.BeaconChainID {
+			stats, err = PruneBeaconState(dbs, cfg.PruneKeepViews)
+		} else if chainID < len(blockchain.ShardChain) {
+			stats, err = PruneShardState(db, byte(chainID), cfg.PruneKeepViews)
+		} else {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		Logger.log.Infof("Pruned the state of chain %v: %v", chainID, stats)
+	}
+	return nil
+}
+
+// suspendStatePruning stops pruning a database that received tries written without reference counts,
+// the next start runs a full pass on it
+func (blockchain *BlockChain) suspendStatePruning(db incdb.Database) error {
+	if !trie.IsPruningEnabled(db) {
+		return nil
+	}
+	Logger.log.Warn("State pruning suspended until the node restarts, state tries were imported")
+	return trie.DisablePruning(db)
+}
+
+// releaseFinalizedStates releases the states of the finalized blocks from the last released one up to height
+func releaseFinalizedStates(db incdb.Database, height uint64, hashByHeight func(uint64) (*common.Hash, error)) error {
+	pruneHeight, ok := trie.PruneHeight(db)
+	if !ok {
+		return nil
+	}
+	for h := pruneHeight + 1; h <= height; h++ {
+		block := common.Hash{}
+		if hash, err := hashByHeight(h); err == nil {
+			block = *hash
+		}
+		if _, err := trie.ReleaseRoots(db, h, block); err != nil {
+			return NewBlockChainError(StatePruningError, err)
+		}
+	}
+	return nil
+}
+
+// pruneShardStates releases the states of the shard blocks falling out of the kept finalized views
+func (blockchain *BlockChain) pruneShardStates(shardID byte) error {
+	db := blockchain.GetShardChainDatabase(shardID)
+	finalHeight := blockchain.ShardChain[shardID].GetFinalView().GetHeight()
+	return releaseFinalizedStates(db, keptAbove(finalHeight, config.Config().PruneKeepViews), func(height uint64) (*common.Hash, error) {
+		return rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+	})
+}
+
+// pruneBeaconStates releases the states of the beacon blocks falling out of the kept finalized views
+// that every synced shard has processed
+func (blockchain *BlockChain) pruneBeaconStates() error {
+	db := blockchain.GetBeaconChainDatabase()
+	keptFrom := blockchain.BeaconChain.GetFinalView().GetHeight()
+	for _, shardChain := range blockchain.ShardChain {
+		if shardChain == nil {
+			continue
+		}
+		if view := shardChain.GetFinalView().(*ShardBestState); view.ShardHeight > 1 && view.BeaconHeight < keptFrom {
+			keptFrom = view.BeaconHeight
+		}
+	}
+	return releaseFinalizedStates(db, keptAbove(keptFrom, config.Config().PruneKeepViews), func(height uint64) (*common.Hash, error) {
+		return rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+	})
+}
@@ -0,0 +1,383 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// MaxStateNodesPerRequest caps the number of trie nodes served for one request
+const MaxStateNodesPerRequest = 384
+
+// StateSnapshot is served to new nodes so they can start from a final view instead of replaying
+// every block since genesis. Block headers do not commit to the state roots, so a snapshot is only
+// trusted through its checkpoint: a node pins it with PinStateSnapshot and its operator publishes the
+// checkpoint, which fast syncing nodes are configured with.
+// Trie nodes of the state roots are downloaded separately and verified against these roots.
+type StateSnapshot struct {
+	ChainID int             // -1 for beacon
+	View    json.RawMessage // final BeaconBestState or ShardBestState
+
+	// Beacon only: blocks from the best block of the view back to the first block of its epoch,
+	// needed to rebuild the missing signature counter
+	Blocks []json.RawMessage `json:",omitempty"`
+
+	// Shard only: beacon roots of the block the shard committee is taken from
+	CommitteeFromBlock      common.Hash     `json:",omitempty"`
+	CommitteeFromBlockRoots *BeaconRootHash `json:",omitempty"`
+}
+
+// StateRoot is a state trie to download into the database of ChainID
+type StateRoot struct {
+	ChainID int
+	Root    common.Hash
+}
+
+// Checkpoint returns the hash committing to the whole snapshot, the view with its state roots, the
+// blocks and the roots of the committee block
+func (snapshot *StateSnapshot) Checkpoint() (common.Hash, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return common.Hash{}, NewBlockChainError(StateSnapshotError, err)
+	}
+	return common.HashH(data), nil
+}
+
+// VerifyStateSnapshot returns an error if the snapshot is not the one of the trusted checkpoint
+func VerifyStateSnapshot(snapshot *StateSnapshot, checkpoint common.Hash) error {
+	hash, err := snapshot.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if hash != checkpoint {
+		return NewBlockChainError(StateSnapshotError, fmt.Errorf("snapshot of chain %v has checkpoint %v, expect trusted checkpoint %v", snapshot.ChainID, hash.String(), checkpoint.String()))
+	}
+	return nil
+}
+
+// StateRoots returns every trie needed to apply the snapshot
+func (snapshot *StateSnapshot) StateRoots() ([]StateRoot, error) {
+	res := []StateRoot{}
+	if snapshot.ChainID == common.BeaconChainID {
+		view := &BeaconBestState{}
+		if err := json.Unmarshal(snapshot.View, view); err != nil {
+			return nil, err
+		}
+		for _, root := range []common.Hash{
+			view.ConsensusStateDBRootHash,
+			view.FeatureStateDBRootHash,
+			view.RewardStateDBRootHash,
+			view.SlashStateDBRootHash,
+		} {
+			res = append(res, StateRoot{ChainID: common.BeaconChainID, Root: root})
+		}
+		return res, nil
+	}
+	view := &ShardBestState{}
+	if err := json.Unmarshal(snapshot.View, view); err != nil {
+		return nil, err
+	}
+	for _, root := range []common.Hash{
+		view.ConsensusStateDBRootHash,
+		view.TransactionStateDBRootHash,
+		view.FeatureStateDBRootHash,
+		view.RewardStateDBRootHash,
+		view.SlashStateDBRootHash,
+	} {
+		res = append(res, StateRoot{ChainID: snapshot.ChainID, Root: root})
+	}
+	if snapshot.CommitteeFromBlockRoots != nil {
+		res = append(res, StateRoot{ChainID: common.BeaconChainID, Root: snapshot.CommitteeFromBlockRoots.ConsensusStateDBRootHash})
+	}
+	return res, nil
+}
+
+// PinStateSnapshot builds a snapshot of the final view of a chain and keeps it, with its state tries,
+// so that it can be served to fast syncing nodes. It returns the checkpoint these nodes are configured with.
+func (blockchain *BlockChain) PinStateSnapshot(chainID int) (common.Hash, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var snapshot *StateSnapshot
+	if chainID == common.BeaconChainID {
+		snapshot, err = blockchain.getBeaconStateSnapshot()
+	} else {
+		snapshot, err = blockchain.getShardStateSnapshot(byte(chainID))
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	checkpoint, err := snapshot.Checkpoint()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	roots, err := snapshot.StateRoots()
+	if err != nil {
+		return common.Hash{}, NewBlockChainError(StateSnapshotError, err)
+	}
+	// keep the tries of the snapshot when the state is pruned, the checkpoint is never released
+	rootsByChain := make(map[int][]common.Hash)
+	for _, root := range roots {
+		rootsByChain[root.ChainID] = append(rootsByChain[root.ChainID], root.Root)
+	}
+	for rootChainID, chainRoots := range rootsByChain {
+		rootDB, err := blockchain.getChainDatabase(rootChainID)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if err := trie.RetainRoots(rootDB, checkpoint, chainRoots...); err != nil {
+			return common.Hash{}, NewBlockChainError(StateSnapshotError, err)
+		}
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return common.Hash{}, NewBlockChainError(StateSnapshotError, err)
+	}
+	if err := rawdbv2.StoreStateSnapshot(db, checkpoint, data); err != nil {
+		return common.Hash{}, NewBlockChainError(StateSnapshotError, err)
+	}
+	Logger.log.Infof("Pin state snapshot of chain %v, checkpoint %v", chainID, checkpoint.String())
+	return checkpoint, nil
+}
+
+// GetStateSnapshot returns the snapshot of a chain pinned under checkpoint
+func (blockchain *BlockChain) GetStateSnapshot(chainID int, checkpoint common.Hash) (*StateSnapshot, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rawdbv2.GetStateSnapshot(db, checkpoint)
+	if err != nil {
+		return nil, NewBlockChainError(StateSnapshotError, fmt.Errorf("no snapshot of chain %v is pinned under checkpoint %v", chainID, checkpoint.String()))
+	}
+	snapshot := &StateSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, NewBlockChainError(StateSnapshotError, err)
+	}
+	return snapshot, nil
+}
+
+func (blockchain *BlockChain) getBeaconStateSnapshot() (*StateSnapshot, error) {
+	view := blockchain.BeaconChain.GetFinalView().(*BeaconBestState)
+	viewBytes, err := json.Marshal(view)
+	if err != nil {
+		return nil, NewBlockChainError(StateSnapshotError, err)
+	}
+	snapshot := &StateSnapshot{
+		ChainID: common.BeaconChainID,
+		View:    viewBytes,
+	}
+	firstHeight := blockchain.GetFirstBeaconHeightInEpoch(view.Epoch)
+	block := &view.BestBlock
+	for {
+		blockBytes, err := json.Marshal(block)
+		if err != nil {
+			return nil, NewBlockChainError(StateSnapshotError, err)
+		}
+		snapshot.Blocks = append(snapshot.Blocks, blockBytes)
+		if block.GetHeight() <= firstHeight || block.GetHeight() == 1 {
+			break
+		}
+		block, _, err = blockchain.GetBeaconBlockByHash(block.GetPrevHash())
+		if err != nil {
+			return nil, NewBlockChainError(StateSnapshotError, err)
+		}
+	}
+	return snapshot, nil
+}
+
+func (blockchain *BlockChain) getShardStateSnapshot(shardID byte) (*StateSnapshot, error) {
+	view := blockchain.ShardChain[shardID].GetFinalView().(*ShardBestState)
+	viewBytes, err := json.Marshal(view)
+	if err != nil {
+		return nil, NewBlockChainError(StateSnapshotError, err)
+	}
+	snapshot := &StateSnapshot{
+		ChainID: int(shardID),
+		View:    viewBytes,
+	}
+	committeeFromBlock := view.BestBlock.Header.CommitteeFromBlock
+	if !committeeFromBlock.IsZeroValue() {
+		roots, err := GetBeaconRootsHashByBlockHash(blockchain.GetBeaconChainDatabase(), committeeFromBlock)
+		if err != nil {
+			return nil, NewBlockChainError(StateSnapshotError, err)
+		}
+		snapshot.CommitteeFromBlock = committeeFromBlock
+		snapshot.CommitteeFromBlockRoots = roots
+	}
+	return snapshot, nil
+}
+
+// GetStateNodes returns the encoded trie nodes stored under the given hashes,
+// unknown nodes are returned as empty slices
+func (blockchain *BlockChain) GetStateNodes(chainID int, hashes []common.Hash) ([][]byte, error) {
+	db, err := blockchain.getChainDatabase(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hashes) > MaxStateNodesPerRequest {
+		hashes = hashes[:MaxStateNodesPerRequest]
+	}
+	res := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		data, err := db.Get(hash[:])
+		if err != nil {
+			continue
+		}
+		res[i] = data
+	}
+	return res, nil
+}
+
+// ApplyStateSnapshot stores the view of the snapshot of the trusted checkpoint and restarts the chain
+// from it, all state tries of the snapshot must already be downloaded
+func (blockchain *BlockChain) ApplyStateSnapshot(snapshot *StateSnapshot, checkpoint common.Hash) error {
+	if err := VerifyStateSnapshot(snapshot, checkpoint); err != nil {
+		return err
+	}
+	if snapshot.ChainID == common.BeaconChainID {
+		if err := blockchain.applyBeaconStateSnapshot(snapshot); err != nil {
+			return err
+		}
+		return blockchain.suspendStatePruning(blockchain.GetBeaconChainDatabase())
+	}
+	if snapshot.ChainID < 0 || snapshot.ChainID >= config.Param().ActiveShards {
+		return NewBlockChainError(StateSnapshotError, fmt.Errorf("invalid chain id %v", snapshot.ChainID))
+	}
+	if err := blockchain.applyShardStateSnapshot(snapshot); err != nil {
+		return err
+	}
+	// the committee state of a shard snapshot is downloaded into the beacon database
+	if err := blockchain.suspendStatePruning(blockchain.GetShardChainDatabase(byte(snapshot.ChainID))); err != nil {
+		return err
+	}
+	return blockchain.suspendStatePruning(blockchain.GetBeaconChainDatabase())
+}
+
+func (blockchain *BlockChain) applyBeaconStateSnapshot(snapshot *StateSnapshot) error {
+	view := &BeaconBestState{}
+	if err := json.Unmarshal(snapshot.View, view); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	blocks := []*types.BeaconBlock{}
+	for i, blockBytes := range snapshot.Blocks {
+		block := types.NewBeaconBlock()
+		if err := json.Unmarshal(blockBytes, block); err != nil {
+			return NewBlockChainError(StateSnapshotError, err)
+		}
+		if i > 0 && blocks[i-1].GetPrevHash() != *block.Hash() {
+			return NewBlockChainError(StateSnapshotError, fmt.Errorf("beacon block %v does not link to block %v", block.GetHeight(), blocks[i-1].GetHeight()))
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 || *blocks[0].Hash() != view.BestBlockHash {
+		return NewBlockChainError(StateSnapshotError, errors.New("best block of beacon snapshot is missing"))
+	}
+	bestBlock := blocks[0]
+
+	db := blockchain.GetBeaconChainDatabase()
+	batch := db.NewBatch()
+	for _, block := range blocks {
+		if err := rawdbv2.StoreBeaconBlockByHash(batch, *block.Hash(), block); err != nil {
+			return NewBlockChainError(StateSnapshotError, err)
+		}
+		if err := rawdbv2.StoreFinalizedBeaconBlockHashByIndex(batch, block.GetHeight(), *block.Hash()); err != nil {
+			return NewBlockChainError(StateSnapshotError, err)
+		}
+	}
+	bRH := BeaconRootHash{
+		ConsensusStateDBRootHash: view.ConsensusStateDBRootHash,
+		FeatureStateDBRootHash:   view.FeatureStateDBRootHash,
+		RewardStateDBRootHash:    view.RewardStateDBRootHash,
+		SlashStateDBRootHash:     view.SlashStateDBRootHash,
+	}
+	if err := rawdbv2.StoreBeaconRootsHash(batch, view.BestBlockHash, bRH); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	viewsBytes, err := json.Marshal([]json.RawMessage{snapshot.View})
+	if err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	if err := rawdbv2.StoreBeaconViews(batch, viewsBytes); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	Logger.log.Infof("Apply beacon state snapshot at height %v, hash %v", bestBlock.GetHeight(), view.BestBlockHash.String())
+	return blockchain.RestoreBeaconViews()
+}
+
+func (blockchain *BlockChain) applyShardStateSnapshot(snapshot *StateSnapshot) error {
+	shardID := byte(snapshot.ChainID)
+	view := &ShardBestState{}
+	if err := json.Unmarshal(snapshot.View, view); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	bestBlock := view.BestBlock
+	if bestBlock == nil || *bestBlock.Hash() != view.BestBlockHash || view.ShardID != shardID {
+		return NewBlockChainError(StateSnapshotError, errors.New("best block of shard snapshot is invalid"))
+	}
+
+	if bestBlock.Header.CommitteeFromBlock != snapshot.CommitteeFromBlock ||
+		(snapshot.CommitteeFromBlock.IsZeroValue() != (snapshot.CommitteeFromBlockRoots == nil)) {
+		return NewBlockChainError(StateSnapshotError, errors.New("committee from block of shard snapshot mismatch"))
+	}
+	if snapshot.CommitteeFromBlockRoots != nil {
+		// the roots come with the trusted snapshot, the ones already stored must be the same
+		roots, err := GetBeaconRootsHashByBlockHash(blockchain.GetBeaconChainDatabase(), snapshot.CommitteeFromBlock)
+		if err != nil {
+			if err := rawdbv2.StoreBeaconRootsHash(blockchain.GetBeaconChainDatabase(), snapshot.CommitteeFromBlock, snapshot.CommitteeFromBlockRoots); err != nil {
+				return NewBlockChainError(StateSnapshotError, err)
+			}
+		} else if *roots != *snapshot.CommitteeFromBlockRoots {
+			return NewBlockChainError(StateSnapshotError, fmt.Errorf("beacon roots of committee block %v differ from the stored ones", snapshot.CommitteeFromBlock.String()))
+		}
+	}
+
+	db := blockchain.GetShardChainDatabase(shardID)
+
+	batch := db.NewBatch()
+	if err := rawdbv2.StoreShardBlock(batch, view.BestBlockHash, bestBlock); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	if err := rawdbv2.StoreFinalizedShardBlockHashByIndex(batch, shardID, bestBlock.GetHeight(), view.BestBlockHash); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	sRH := ShardRootHash{
+		ConsensusStateDBRootHash:   view.ConsensusStateDBRootHash,
+		TransactionStateDBRootHash: view.TransactionStateDBRootHash,
+		FeatureStateDBRootHash:     view.FeatureStateDBRootHash,
+		RewardStateDBRootHash:      view.RewardStateDBRootHash,
+		SlashStateDBRootHash:       view.SlashStateDBRootHash,
+	}
+	if err := rawdbv2.StoreShardRootsHash(batch, shardID, view.BestBlockHash, sRH); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	if err := rawdbv2.StoreShardBestState(batch, shardID, []json.RawMessage{snapshot.View}); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(StateSnapshotError, err)
+	}
+	Logger.log.Infof("Apply shard %v state snapshot at height %v, hash %v", shardID, bestBlock.GetHeight(), view.BestBlockHash.String())
+	return blockchain.RestoreShardViews(shardID)
+}
+
+func (blockchain *BlockChain) getChainDatabase(chainID int) (incdb.Database, error) {
+	if chainID == common.BeaconChainID {
+		return blockchain.GetBeaconChainDatabase(), nil
+	}
+	if chainID < 0 || chainID >= config.Param().ActiveShards {
+		return nil, NewBlockChainError(StateSnapshotError, fmt.Errorf("invalid chain id %v", chainID))
+	}
+	return blockchain.GetShardChainDatabase(byte(chainID)), nil
+}
@@ -0,0 +1,45 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSnapshot_VerifyCheckpoint(t *testing.T) {
+	view, err := json.Marshal(&ShardBestState{
+		ShardID:                  1,
+		ConsensusStateDBRootHash: common.HashH([]byte("consensus")),
+	})
+	assert.Nil(t, err)
+	snapshot := &StateSnapshot{
+		ChainID:                 1,
+		View:                    view,
+		CommitteeFromBlock:      common.HashH([]byte("committee block")),
+		CommitteeFromBlockRoots: &BeaconRootHash{ConsensusStateDBRootHash: common.HashH([]byte("beacon consensus"))},
+	}
+	checkpoint, err := snapshot.Checkpoint()
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyStateSnapshot(snapshot, checkpoint))
+
+	// the snapshot is sent through the network as json, decoding it keeps the checkpoint
+	data, err := json.Marshal(snapshot)
+	assert.Nil(t, err)
+	received := &StateSnapshot{}
+	assert.Nil(t, json.Unmarshal(data, received))
+	assert.Nil(t, VerifyStateSnapshot(received, checkpoint))
+
+	// a peer can not change the roots the state tries are downloaded from
+	received.CommitteeFromBlockRoots.ConsensusStateDBRootHash = common.HashH([]byte("forged"))
+	assert.NotNil(t, VerifyStateSnapshot(received, checkpoint))
+
+	forged := *snapshot
+	forged.View, err = json.Marshal(&ShardBestState{
+		ShardID:                  1,
+		ConsensusStateDBRootHash: common.HashH([]byte("forged")),
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, VerifyStateSnapshot(&forged, checkpoint))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
 	"github.com/incognitochain/incognito-chain/txpool"
 	"github.com/pkg/errors"
 )
@@ -182,6 +183,10 @@ func (v *TxsVerifier) ValidateWithChainState(
 	beaconViewRetriever metadata.BeaconViewRetriever,
 	beaconHeight uint64,
 ) (bool, error) {
+	// the tx must still be valid in the next block of the shard view
+	if err := tx_generic.ValidateExpiryHeight(tx, shardViewRetriever.GetHeight()+1, beaconHeight); err != nil {
+		return false, err
+	}
 	ok := v.checkFees(
 		beaconViewRetriever.GetHeight(),
 		tx,
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -9,9 +11,14 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/pdex"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/blockarchive"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/instruction"
 	"github.com/incognitochain/incognito-chain/peerv2"
 	"github.com/incognitochain/incognito-chain/trie"
 
@@ -19,43 +26,51 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/pebbledb"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/syncker/finishsync"
+	"github.com/incognitochain/incognito-chain/txpool"
 )
 
-func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, error) {
+func makeBlockChain(databaseDir string) (*blockchain.BlockChain, error) {
 	blockchain.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	blockchain.BLogger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	mempool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	trie.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	db, err := incdb.Open("leveldb", filepath.Join(databaseDir))
+	instruction.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	committeestate.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	pdex.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	finishsync.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	txpool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
+	db, err := incdb.OpenMultipleDB("leveldb", databaseDir)
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("Open leveldb at %+v successfully", filepath.Join(databaseDir))
-	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
-	var bcParams *blockchain.Params
-	if testNet {
-		bcParams = &blockchain.ChainTestParam
-	} else {
-		bcParams = &blockchain.ChainMainParam
+	log.Printf("Open leveldb at %+v successfully", databaseDir)
+	if blockarchive.Exists(databaseDir) {
+		if _, err := openBlockArchives(db, databaseDir, false); err != nil {
+			return nil, err
+		}
 	}
+	blockchain.CreateGenesisBlocks()
+	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
 	pb := pubsub.NewPubSubManager()
 	txPool := &mempool.TxPool{}
 	txPool.Init(&mempool.Config{
 		PubSubManager: pb,
 		DataBase:      db,
 		BlockChain:    bc,
-		ChainParams:   bcParams,
 	})
+	poolManager, _ := txpool.NewPoolManager(common.MaxShardNumber, pb, 0)
 	err = bc.Init(&blockchain.Config{
-		ChainParams:     bcParams,
 		DataBase:        db,
 		PubSubManager:   pb,
 		TxPool:          txPool,
 		ConsensusEngine: &consensus.Engine{},
 		Highway:         &peerv2.ConnManager{},
+		PoolManager:     poolManager,
 	})
 	if err != nil {
 		return nil, err
@@ -272,3 +287,144 @@ func restoreBeaconChain(bc *blockchain.BlockChain, filename string) error {
 	log.Println("Restore Beacon Chain Successfully")
 	return nil
 }
+
+// migrateChainDatabase copies the beacon and shard databases of srcDir into new databases of another engine in dstDir,
+// srcDir is left untouched so the node can switch back until the migrated datadir is trusted
+func migrateChainDatabase(srcDir string, srcEngine string, dstDir string, dstEngine string) error {
+	if srcEngine == dstEngine && filepath.Clean(srcDir) == filepath.Clean(dstDir) {
+		return errors.New("source and destination are the same database")
+	}
+	srcDBs, err := incdb.OpenMultipleDB(srcEngine, srcDir)
+	if err != nil {
+		return err
+	}
+	dstDBs, err := incdb.OpenMultipleDB(dstEngine, dstDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for cID := range srcDBs {
+			srcDBs[cID].Close()
+			dstDBs[cID].Close()
+		}
+	}()
+	for cID := -1; cID < common.MaxShardNumber; cID++ {
+		iter := dstDBs[cID].NewIterator()
+		notEmpty := iter.Next()
+		iter.Release()
+		if notEmpty {
+			return fmt.Errorf("destination database of chain %v is not empty", cID)
+		}
+		count, err := incdb.Migrate(srcDBs[cID], dstDBs[cID])
+		if err != nil {
+			return fmt.Errorf("migrate chain %v: %v", cID, err)
+		}
+		if err := dstDBs[cID].Compact(nil, nil); err != nil {
+			return err
+		}
+		log.Printf("Migrated %v keys of chain %v from %v to %v", count, cID, srcEngine, dstEngine)
+	}
+	return nil
+}
+
+// pruneChainState deletes the state tries every chain database no longer needs and enables state
+// pruning on them, the node must be stopped. Start the node with state pruning enabled afterwards,
+// an archive node removes the reference counts and stops pruning.
+func pruneChainState(dataDir string, engine string, keepViews uint64) error {
+	dbs, err := incdb.OpenMultipleDB(engine, dataDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for cID := range dbs {
+			dbs[cID].Close()
+		}
+	}()
+	// the blocks of the stored views may have been moved to the block archive
+	if blockarchive.Exists(dataDir) {
+		closeArchives, err := openBlockArchives(dbs, dataDir, false)
+		if err != nil {
+			return err
+		}
+		defer closeArchives()
+	}
+	stats, err := blockchain.PruneBeaconState(dbs, keepViews)
+	if err != nil {
+		return fmt.Errorf("prune beacon: %v", err)
+	}
+	log.Printf("Pruned beacon state: %v", stats)
+	for cID := 0; cID < common.MaxShardNumber; cID++ {
+		if _, err := rawdbv2.GetShardBestState(dbs[cID], byte(cID)); err != nil {
+			continue
+		}
+		stats, err := blockchain.PruneShardState(dbs[cID], byte(cID), keepViews)
+		if err != nil {
+			return fmt.Errorf("prune shard %v: %v", cID, err)
+		}
+		log.Printf("Pruned shard %v state: %v", cID, stats)
+	}
+	for cID := range dbs {
+		if err := dbs[cID].Compact(nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveChainBlocks moves the finalized blocks of every chain database out of the key-value store into
+// the block archive of dataDir, the node must be stopped. The node keeps reading them from the archive,
+// start it with block archiving enabled to also archive the blocks it finalizes afterwards.
+func archiveChainBlocks(dataDir string, engine string, compress bool) error {
+	dbs, err := incdb.OpenMultipleDB(engine, dataDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for cID := range dbs {
+			dbs[cID].Close()
+		}
+	}()
+	closeArchives, err := openBlockArchives(dbs, dataDir, compress)
+	if err != nil {
+		return err
+	}
+	defer closeArchives()
+	moved, err := blockchain.ArchiveBeaconBlocks(dbs[common.BeaconChainID])
+	if err != nil {
+		return fmt.Errorf("archive beacon: %v", err)
+	}
+	log.Printf("Archived %v beacon blocks", moved)
+	for cID := 0; cID < common.MaxShardNumber; cID++ {
+		if _, err := rawdbv2.GetShardBestState(dbs[cID], byte(cID)); err != nil {
+			continue
+		}
+		moved, err := blockchain.ArchiveShardBlocks(dbs[cID], byte(cID))
+		if err != nil {
+			return fmt.Errorf("archive shard %v: %v", cID, err)
+		}
+		log.Printf("Archived %v blocks of shard %v", moved, cID)
+	}
+	for cID := range dbs {
+		if err := dbs[cID].Compact(nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openBlockArchives opens the block archives of dataDir and registers them on the chain databases,
+// so that archived blocks are read from them. The returned function closes the archives.
+func openBlockArchives(dbs map[int]incdb.Database, dataDir string, compress bool) (func(), error) {
+	archives, err := blockarchive.OpenMultiple(dataDir, compress)
+	if err != nil {
+		return nil, err
+	}
+	for cID, archive := range archives {
+		rawdbv2.SetBlockArchive(dbs[cID], archive)
+	}
+	return func() {
+		for _, archive := range archives {
+			archive.Close()
+		}
+	}, nil
+}
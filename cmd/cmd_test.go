@@ -1,8 +1,14 @@
 package main
 
 import (
-	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCmdLoadParams(t *testing.T) {
@@ -11,3 +17,72 @@ func TestCmdLoadParams(t *testing.T) {
 	assert.NotEqual(t, nil, params)
 	assert.Equal(t, false, params.TestNet)
 }
+
+// makeGenesisDataDir creates a leveldb datadir holding the genesis views of every chain
+func makeGenesisDataDir(t *testing.T) string {
+	// tests run in cmd/, the network params are in the config folder of the project
+	os.Setenv(config.ConfigDirKey, filepath.Join("..", config.DefaultConfigDir))
+	config.LoadConfig()
+	config.LoadParam()
+	dataDir := t.TempDir()
+	bc, err := makeBlockChain(dataDir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	bc.GetBeaconChainDatabase().Close()
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		bc.GetShardChainDatabase(byte(shardID)).Close()
+	}
+	return dataDir
+}
+
+func countKeys(db incdb.Database) int {
+	iter := db.NewIterator()
+	defer iter.Release()
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	return count
+}
+
+func TestMigrateChainDatabase(t *testing.T) {
+	srcDir := makeGenesisDataDir(t)
+	dstDir := t.TempDir()
+	assert.Error(t, migrateChainDatabase(srcDir, "leveldb", srcDir, "leveldb"))
+	assert.NoError(t, migrateChainDatabase(srcDir, "leveldb", dstDir, "pebble"))
+	// the destination is not empty anymore
+	assert.Error(t, migrateChainDatabase(srcDir, "leveldb", dstDir, "pebble"))
+
+	srcDBs, err := incdb.OpenMultipleDB("leveldb", srcDir)
+	assert.NoError(t, err)
+	dstDBs, err := incdb.OpenMultipleDB("pebble", dstDir)
+	assert.NoError(t, err)
+	for cID := range srcDBs {
+		assert.NotZero(t, countKeys(srcDBs[cID]))
+		assert.Equal(t, countKeys(srcDBs[cID]), countKeys(dstDBs[cID]), "chain %v", cID)
+		srcDBs[cID].Close()
+		dstDBs[cID].Close()
+	}
+}
+
+func TestPruneChainState(t *testing.T) {
+	dataDir := makeGenesisDataDir(t)
+	assert.NoError(t, pruneChainState(dataDir, "leveldb", 128))
+	// the pruned datadir still loads
+	bc, err := makeBlockChain(dataDir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(1), bc.GetBeaconBestState().BeaconHeight)
+	}
+}
+
+func TestArchiveChainBlocks(t *testing.T) {
+	dataDir := makeGenesisDataDir(t)
+	assert.NoError(t, archiveChainBlocks(dataDir, "leveldb", true))
+	assert.NoError(t, pruneChainState(dataDir, "leveldb", 128))
+	// the archived blocks are still read back
+	bc, err := makeBlockChain(dataDir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(1), bc.GetBeaconBestState().BeaconHeight)
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"path/filepath"
 
 	"github.com/0xsirrush/color"
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -39,6 +39,11 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	SrcEngine    string `long:"srcengine" description:"Storage engine of the database to migrate, default is 'leveldb'"`
+	DstEngine    string `long:"dstengine" description:"Storage engine of the migrated database, default is 'pebble'"`
+	DBEngine     string `long:"dbengine" description:"Storage engine of the database to prune or archive, default is 'leveldb'"`
+	KeepViews    uint64 `long:"keepviews" description:"Number of finalized views of each chain whose state is kept when pruning, default is 128"`
+	Compress     bool   `long:"compress" description:"Compress the archived blocks with zstd"`
 	// wallet
 	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
 	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
@@ -58,8 +63,12 @@ func newConfigParser(cfg *params, options flags.Options) *flags.Parser {
 
 func loadParams() (*params, error) {
 	cfg := params{
-		DataDir: defaultDataDir,
-		TestNet: false,
+		DataDir:   defaultDataDir,
+		TestNet:   false,
+		SrcEngine: "leveldb",
+		DstEngine: "pebble",
+		DBEngine:  "leveldb",
+		KeepViews: 128,
 	}
 
 	preParser := newConfigParser(&cfg, flags.HelpFlag)
@@ -74,12 +83,15 @@ func loadParams() (*params, error) {
 			return nil, err
 		}
 	}
-	cfg.DataDir = common.CleanAndExpandPath(cfg.DataDir, defaultHomeDir)
+	// the network is selected by env like for the node, --testnet is a shortcut for testnet-1
 	if cfg.TestNet {
-		cfg.DataDir = filepath.Join(cfg.DataDir, blockchain.ChainTestParam.Name)
-	} else {
-		cfg.DataDir = filepath.Join(cfg.DataDir, blockchain.ChainMainParam.Name)
+		os.Setenv(config.NetworkKey, config.TestNetNetwork)
 	}
+	// the chain commands run with the config & params of the node of this network
+	config.LoadConfig()
+	config.LoadParam()
+	cfg.DataDir = common.CleanAndExpandPath(cfg.DataDir, defaultHomeDir)
+	cfg.DataDir = filepath.Join(cfg.DataDir, config.Param().Name)
 
 	return &cfg, nil
 }
@@ -8,6 +8,9 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	migrateDatabase        = "migratedb"
+	pruneState             = "prunestate"
+	archiveBlocks          = "archiveblocks"
 )
 
 var CmdList = []string{
@@ -18,4 +21,7 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	migrateDatabase,
+	pruneState,
+	archiveBlocks,
 }
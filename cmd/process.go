@@ -7,8 +7,8 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 )
 
 func parseToJsonString(data interface{}) ([]byte, error) {
@@ -130,7 +130,7 @@ func processCmd() {
 				log.Println("No Expected Params")
 				return
 			}
-			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			bc, err := makeBlockChain(cfg.ChainDataDir)
 			if err != nil {
 				log.Println("Error create blockchain variable ", err)
 				return
@@ -145,13 +145,7 @@ func processCmd() {
 			if cfg.ShardIDs != "" {
 				// all shard
 				if cfg.ShardIDs == "all" {
-					var numberOfShards int
-					if cfg.TestNet {
-						numberOfShards = blockchain.ChainTestParam.ActiveShards
-					} else {
-						numberOfShards = blockchain.ChainMainParam.ActiveShards
-					}
-					for i := 0; i < numberOfShards; i++ {
+					for i := 0; i < config.Param().ActiveShards; i++ {
 						shardIDs = append(shardIDs, byte(i))
 					}
 				} else {
@@ -193,7 +187,7 @@ func processCmd() {
 				log.Println("No Backup File to Process")
 				return
 			}
-			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			bc, err := makeBlockChain(cfg.ChainDataDir)
 			if err != nil {
 				log.Println("Error create blockchain variable ", err)
 				return
@@ -213,5 +207,38 @@ func processCmd() {
 				}
 			}
 		}
+	case migrateDatabase:
+		{
+			if cfg.ChainDataDir == "" || cfg.OutDataDir == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := migrateChainDatabase(cfg.ChainDataDir, cfg.SrcEngine, cfg.OutDataDir, cfg.DstEngine)
+			if err != nil {
+				log.Printf("Migrate database failed, err %+v", err)
+			}
+		}
+	case pruneState:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := pruneChainState(cfg.ChainDataDir, cfg.DBEngine, cfg.KeepViews)
+			if err != nil {
+				log.Printf("Prune state failed, err %+v", err)
+			}
+		}
+	case archiveBlocks:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := archiveChainBlocks(cfg.ChainDataDir, cfg.DBEngine, cfg.Compress)
+			if err != nil {
+				log.Printf("Archive blocks failed, err %+v", err)
+			}
+		}
 	}
 }
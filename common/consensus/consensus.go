@@ -5,6 +5,7 @@ import (
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -16,7 +17,8 @@ type MiningState struct {
 
 type Validator struct {
 	MiningKey   signatureschemes.MiningKey
-	PrivateSeed string
+	PrivateSeed string // empty when the keys are held by a remote signer
+	Signer      signer.Signer
 	State       MiningState
 }
 
@@ -15,6 +15,15 @@ const (
 	BSCHostKey        = "BSC_HOST"
 	PLGHostKey        = "PLG_HOST"
 	SOLHostKey        = "SOL_HOST"
+	// EVMNetworkHostsKeyFormat is formatted with the network id, value is a comma separated host list
+	EVMNetworkHostsKeyFormat = "EVM_NETWORK_%d_HOSTS"
+)
+
+// metadata types reserved to the burning confirm meta of the evm networks of the registry,
+// the vault contracts read the type of a burn proof as one byte
+const (
+	MinEVMNetworkBurningConfirmMeta = 220
+	MaxEVMNetworkBurningConfirmMeta = 239
 )
 
 // default config
@@ -87,3 +96,9 @@ var (
 	defaultRPCCertFile = "rpc.cert"
 	defaultLogDir      = DefaultLogDirname
 )
+
+// commitment levels accepted for solana shielding txs
+const (
+	SOLCommitmentFinalized = "finalized"
+	SOLCommitmentConfirmed = "confirmed"
+)
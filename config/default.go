@@ -56,18 +56,21 @@ var MainnetParam = &param{
 		AssignOffset: 8,
 	},
 	ConsensusParam: consensusParam{
-		ConsensusV2Epoch:          3071,
-		StakingFlowV2Height:       1207793,
-		EnableSlashingHeight:      1000000000000,
-		AssignRuleV3Height:        1410217,
-		EnableSlashingHeightV2:    1498517,
-		StakingFlowV3Height:       1519263,
-		NotUseBurnedCoins:         1e9,
-		BlockProducingV3Height:    1e9,
-		Lemma2Height:              1e9,
-		ByzantineDetectorHeight:   1e9,
-		Timeslot:                  40,
-		EpochBreakPointSwapNewKey: []uint64{1917},
+		ConsensusV2Epoch:            3071,
+		StakingFlowV2Height:         1207793,
+		EnableSlashingHeight:        1000000000000,
+		AssignRuleV3Height:          1410217,
+		EnableSlashingHeightV2:      1498517,
+		StakingFlowV3Height:         1519263,
+		NotUseBurnedCoins:           1e9,
+		BlockProducingV3Height:      1e9,
+		Lemma2Height:                1e9,
+		ByzantineDetectorHeight:     1e9,
+		Timeslot:                    40,
+		EpochBreakPointSwapNewKey:   []uint64{1917},
+		EquivocationSlashingHeight:  1e9,
+		EquivocationSlashingPercent: 20,
+		RandomBeaconHeight:          1e9,
 	},
 	BeaconHeightBreakPointBurnAddr: 150500,
 	ReplaceStakingTxHeight:         559380,
@@ -138,17 +141,20 @@ var Testnet1Param = &param{
 		AssignOffset: 2,
 	},
 	ConsensusParam: consensusParam{
-		ConsensusV2Epoch:          15290,
-		StakingFlowV2Height:       2051863,
-		EnableSlashingHeight:      2087789,
-		AssignRuleV3Height:        3026651,
-		EnableSlashingHeightV2:    3071502,
-		StakingFlowV3Height:       1e9,
-		BlockProducingV3Height:    1e9,
-		Lemma2Height:              2868685,
-		ByzantineDetectorHeight:   1e9,
-		Timeslot:                  10,
-		EpochBreakPointSwapNewKey: []uint64{1280},
+		ConsensusV2Epoch:            15290,
+		StakingFlowV2Height:         2051863,
+		EnableSlashingHeight:        2087789,
+		AssignRuleV3Height:          3026651,
+		EnableSlashingHeightV2:      3071502,
+		StakingFlowV3Height:         1e9,
+		BlockProducingV3Height:      1e9,
+		Lemma2Height:                2868685,
+		ByzantineDetectorHeight:     1e9,
+		Timeslot:                    10,
+		EpochBreakPointSwapNewKey:   []uint64{1280},
+		EquivocationSlashingHeight:  1e9,
+		EquivocationSlashingPercent: 20,
+		RandomBeaconHeight:          1e9,
 	},
 	BeaconHeightBreakPointBurnAddr: 1,
 	ReplaceStakingTxHeight:         1,
@@ -219,18 +225,21 @@ var Tesnet2Param = &param{
 		AssignOffset: 2,
 	},
 	ConsensusParam: consensusParam{
-		ConsensusV2Epoch:          15290,
-		StakingFlowV2Height:       2051863,
-		EnableSlashingHeight:      2087789,
-		AssignRuleV3Height:        3023215,
-		EnableSlashingHeightV2:    3068072,
-		StakingFlowV3Height:       1e9,
-		NotUseBurnedCoins:         1e9,
-		BlockProducingV3Height:    1e9,
-		Lemma2Height:              1e9,
-		ByzantineDetectorHeight:   1e9,
-		Timeslot:                  10,
-		EpochBreakPointSwapNewKey: []uint64{1280},
+		ConsensusV2Epoch:            15290,
+		StakingFlowV2Height:         2051863,
+		EnableSlashingHeight:        2087789,
+		AssignRuleV3Height:          3023215,
+		EnableSlashingHeightV2:      3068072,
+		StakingFlowV3Height:         1e9,
+		NotUseBurnedCoins:           1e9,
+		BlockProducingV3Height:      1e9,
+		Lemma2Height:                1e9,
+		ByzantineDetectorHeight:     1e9,
+		Timeslot:                    10,
+		EpochBreakPointSwapNewKey:   []uint64{1280},
+		EquivocationSlashingHeight:  1e9,
+		EquivocationSlashingPercent: 20,
+		RandomBeaconHeight:          1e9,
 	},
 	BeaconHeightBreakPointBurnAddr: 1,
 	ReplaceStakingTxHeight:         1,
@@ -301,18 +310,21 @@ var LocalParam = &param{
 		AssignOffset: 2,
 	},
 	ConsensusParam: consensusParam{
-		ConsensusV2Epoch:          15290,
-		StakingFlowV2Height:       2051863,
-		EnableSlashingHeight:      2087789,
-		EnableSlashingHeightV2:    1e9,
-		AssignRuleV3Height:        1e9,
-		StakingFlowV3Height:       1e9,
-		NotUseBurnedCoins:         1e9,
-		BlockProducingV3Height:    1e9,
-		Lemma2Height:              1e9,
-		ByzantineDetectorHeight:   1e9,
-		Timeslot:                  10,
-		EpochBreakPointSwapNewKey: []uint64{1280},
+		ConsensusV2Epoch:            15290,
+		StakingFlowV2Height:         2051863,
+		EnableSlashingHeight:        2087789,
+		EnableSlashingHeightV2:      1e9,
+		AssignRuleV3Height:          1e9,
+		StakingFlowV3Height:         1e9,
+		NotUseBurnedCoins:           1e9,
+		BlockProducingV3Height:      1e9,
+		Lemma2Height:                1e9,
+		ByzantineDetectorHeight:     1e9,
+		Timeslot:                    10,
+		EpochBreakPointSwapNewKey:   []uint64{1280},
+		EquivocationSlashingHeight:  1e9,
+		EquivocationSlashingPercent: 20,
+		RandomBeaconHeight:          1e9,
 	},
 	BeaconHeightBreakPointBurnAddr: 1,
 	ReplaceStakingTxHeight:         1,
@@ -383,18 +395,21 @@ var LocalDCSParam = &param{
 		AssignOffset: 2,
 	},
 	ConsensusParam: consensusParam{
-		ConsensusV2Epoch:          1,
-		StakingFlowV2Height:       1,
-		EnableSlashingHeight:      1,
-		EnableSlashingHeightV2:    1,
-		AssignRuleV3Height:        1,
-		StakingFlowV3Height:       1,
-		NotUseBurnedCoins:         1,
-		Lemma2Height:              50,
-		BlockProducingV3Height:    1e9,
-		ByzantineDetectorHeight:   1e9,
-		Timeslot:                  10,
-		EpochBreakPointSwapNewKey: []uint64{1280},
+		ConsensusV2Epoch:            1,
+		StakingFlowV2Height:         1,
+		EnableSlashingHeight:        1,
+		EnableSlashingHeightV2:      1,
+		AssignRuleV3Height:          1,
+		StakingFlowV3Height:         1,
+		NotUseBurnedCoins:           1,
+		Lemma2Height:                50,
+		BlockProducingV3Height:      1e9,
+		ByzantineDetectorHeight:     1e9,
+		Timeslot:                    10,
+		EpochBreakPointSwapNewKey:   []uint64{1280},
+		EquivocationSlashingHeight:  1e9,
+		EquivocationSlashingPercent: 20,
+		RandomBeaconHeight:          1e9,
 	},
 	BeaconHeightBreakPointBurnAddr: 1,
 	ReplaceStakingTxHeight:         1,
@@ -55,10 +55,12 @@ type param struct {
 	CoinVersion2LowestHeight         uint64             `mapstructure:"coin_v2_lowest_height"`
 	EnableFeatureFlags               map[string]uint64  `mapstructure:"enable_feature_flags" description:"featureFlag: epoch number - since that time, the feature will be enabled; 0 - disabled feature"`
 	BCHeightBreakPointPortalV3       uint64             `mapstructure:"portal_v3_height"`
+	BCHeightBreakPointTxExpiry       uint64             `mapstructure:"tx_expiry_height" description:"beacon height from which txs may carry an expiry height"`
 	TxPoolVersion                    int                `mapstructure:"tx_pool_version"`
 	BSCParam                         bscParam           `mapstructure:"bsc_param"`
 	PLGParam                         plgParam           `mapstructure:"plg_param"`
 	SOLParam                         solParam           `mapstructure:"sol_param"`
+	EVMNetworks                      []evmNetworkParam  `mapstructure:"evm_networks" description:"registry of evm networks bridged by the generic evm shielding/unshielding flow"`
 	PDexParams                       pdexParam          `mapstructure:"pdex_param"`
 	IsEnableBPV3Stats                bool               `mapstructure:"is_enable_bpv3_stats"`
 	IsBackup                         bool
@@ -117,18 +119,21 @@ type swapCommitteeParam struct {
 }
 
 type consensusParam struct {
-	ConsensusV2Epoch          uint64   `mapstructure:"consensus_v2_epoch"`
-	StakingFlowV2Height       uint64   `mapstructure:"staking_flow_v2_height"`
-	AssignRuleV3Height        uint64   `mapstructure:"assign_rule_v3_height"`
-	EnableSlashingHeight      uint64   `mapstructure:"enable_slashing_height"`
-	EnableSlashingHeightV2    uint64   `mapstructure:"enable_slashing_height_v2"`
-	StakingFlowV3Height       uint64   `mapstructure:"staking_flow_v3_height"`
-	NotUseBurnedCoins         uint64   `mapstructure:"force_not_use_burned_coins"`
-	Lemma2Height              uint64   `mapstructure:"lemma2_height"`
-	ByzantineDetectorHeight   uint64   `mapstructure:"byzantine_detector_height"`
-	BlockProducingV3Height    uint64   `mapstructure:"block_producing_v3_height"`
-	Timeslot                  uint64   `mapstructure:"timeslot"`
-	EpochBreakPointSwapNewKey []uint64 `mapstructure:"epoch_break_point_swap_new_key"`
+	ConsensusV2Epoch            uint64   `mapstructure:"consensus_v2_epoch"`
+	StakingFlowV2Height         uint64   `mapstructure:"staking_flow_v2_height"`
+	AssignRuleV3Height          uint64   `mapstructure:"assign_rule_v3_height"`
+	EnableSlashingHeight        uint64   `mapstructure:"enable_slashing_height"`
+	EnableSlashingHeightV2      uint64   `mapstructure:"enable_slashing_height_v2"`
+	StakingFlowV3Height         uint64   `mapstructure:"staking_flow_v3_height"`
+	NotUseBurnedCoins           uint64   `mapstructure:"force_not_use_burned_coins"`
+	Lemma2Height                uint64   `mapstructure:"lemma2_height"`
+	ByzantineDetectorHeight     uint64   `mapstructure:"byzantine_detector_height"`
+	BlockProducingV3Height      uint64   `mapstructure:"block_producing_v3_height"`
+	Timeslot                    uint64   `mapstructure:"timeslot"`
+	EpochBreakPointSwapNewKey   []uint64 `mapstructure:"epoch_break_point_swap_new_key"`
+	EquivocationSlashingHeight  uint64   `mapstructure:"equivocation_slashing_height"`
+	EquivocationSlashingPercent uint     `mapstructure:"equivocation_slashing_percent"`
+	RandomBeaconHeight          uint64   `mapstructure:"random_beacon_height"`
 }
 
 func LoadParam() *param {
@@ -210,6 +215,53 @@ func verifyParam(p *param) error {
 			p.EpochParam.RandomTime, p.EpochParam.NumberOfBlockInEpoch)
 	}
 
+	if commitment := p.SOLParam.GetCommitment(); commitment != SOLCommitmentFinalized && commitment != SOLCommitmentConfirmed {
+		return fmt.Errorf("SOL commitment %+v is not supported", commitment)
+	}
+
+	if err := verifyEVMNetworks(p.EVMNetworks); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func verifyEVMNetworks(evmNetworks []evmNetworkParam) error {
+	networkIDs := map[uint8]bool{}
+	burningConfirmMetas := map[int]bool{}
+	// ETH tokens have no prefix, BSC and PLG ones have built-in prefixes
+	prefixes := map[string]bool{
+		"":               true,
+		common.BSCPrefix: true,
+		common.PLGPrefix: true,
+	}
+	for _, evmNetwork := range evmNetworks {
+		if networkIDs[evmNetwork.NetworkID] {
+			return fmt.Errorf("EVM network id %+v is declared more than once", evmNetwork.NetworkID)
+		}
+		networkIDs[evmNetwork.NetworkID] = true
+		if burningConfirmMetas[evmNetwork.BurningConfirmMeta] {
+			return fmt.Errorf("EVM network %+v reuses burning confirm meta %+v", evmNetwork.NetworkID, evmNetwork.BurningConfirmMeta)
+		}
+		burningConfirmMetas[evmNetwork.BurningConfirmMeta] = true
+		if evmNetwork.BurningConfirmMeta < MinEVMNetworkBurningConfirmMeta || evmNetwork.BurningConfirmMeta > MaxEVMNetworkBurningConfirmMeta {
+			return fmt.Errorf("EVM network %+v burning confirm meta %+v is out of the reserved range [%+v, %+v]", evmNetwork.NetworkID,
+				evmNetwork.BurningConfirmMeta, MinEVMNetworkBurningConfirmMeta, MaxEVMNetworkBurningConfirmMeta)
+		}
+		if prefixes[evmNetwork.Prefix] {
+			return fmt.Errorf("EVM network %+v has an empty or already used prefix %+v", evmNetwork.NetworkID, evmNetwork.Prefix)
+		}
+		prefixes[evmNetwork.Prefix] = true
+		if evmNetwork.ConfirmationBlocks == 0 {
+			return fmt.Errorf("EVM network %+v requires a positive number of confirmation blocks", evmNetwork.NetworkID)
+		}
+		if evmNetwork.ContractAddressStr == "" {
+			return fmt.Errorf("EVM network %+v has no contract address", evmNetwork.NetworkID)
+		}
+		if evmNetwork.MinQuorum > len(evmNetwork.Hosts) {
+			return fmt.Errorf("EVM network %+v requires a quorum of %+v but only has %+v hosts", evmNetwork.NetworkID, evmNetwork.MinQuorum, len(evmNetwork.Hosts))
+		}
+	}
 	return nil
 }
 
@@ -298,11 +350,14 @@ func (p *param) LoadKey(key1 []byte, key2 []byte) {
 }
 
 type bscParam struct {
-	Host string `mapstructure:"host"`
+	Host      string   `mapstructure:"host"`
+	Hosts     []string `mapstructure:"hosts" description:"endpoints queried for quorum verification, default to host"`
+	MinQuorum int      `mapstructure:"min_quorum" description:"number of endpoints that must agree, default to a majority"`
 }
 
 type pdexParam struct {
 	Pdexv3BreakPointHeight uint64 `mapstructure:"pdex_v3_break_point_height"`
+	OrderExpiryHeight      uint64 `mapstructure:"order_expiry_height" description:"beacon height from which pdex v3 orders may carry an expiry height"`
 	ProtocolFundAddress    string `mapstructure:"protocol_fund_address"`
 	AdminAddress           string `mapstructure:"admin_address"`
 	Params                 struct {
@@ -321,25 +376,113 @@ type pdexParam struct {
 func (bschParam *bscParam) GetFromEnv() {
 	if utils.GetEnv(BSCHostKey, utils.EmptyString) != utils.EmptyString {
 		bschParam.Host = utils.GetEnv(BSCHostKey, utils.EmptyString)
+		bschParam.Hosts = strings.Split(bschParam.Host, ",")
 	}
 }
 
+func (bschParam bscParam) GetHosts() []string {
+	return getHosts(bschParam.Hosts, bschParam.Host)
+}
+
 type plgParam struct {
-	Host string `mapstructure:"host"`
+	Host      string   `mapstructure:"host"`
+	Hosts     []string `mapstructure:"hosts" description:"endpoints queried for quorum verification, default to host"`
+	MinQuorum int      `mapstructure:"min_quorum" description:"number of endpoints that must agree, default to a majority"`
 }
 
 func (plgParam *plgParam) GetFromEnv() {
 	if utils.GetEnv(PLGHostKey, utils.EmptyString) != utils.EmptyString {
 		plgParam.Host = utils.GetEnv(PLGHostKey, utils.EmptyString)
+		plgParam.Hosts = strings.Split(plgParam.Host, ",")
 	}
 }
 
+func (plgParam plgParam) GetHosts() []string {
+	return getHosts(plgParam.Hosts, plgParam.Host)
+}
+
 type solParam struct {
-	Host string `mapstructure:"host"`
+	Host         string   `mapstructure:"host"`
+	Hosts        []string `mapstructure:"hosts" description:"endpoints queried for quorum verification, default to host"`
+	MinQuorum    int      `mapstructure:"min_quorum" description:"number of endpoints that must agree, default to a majority"`
+	Commitment   string   `mapstructure:"commitment" description:"commitment level required for shielding txs, finalized or confirmed, default to finalized"`
+	MinSlotDepth uint64   `mapstructure:"min_slot_depth" description:"number of slots a shielding tx must be behind the tip"`
 }
 
 func (solParam *solParam) GetFromEnv() {
 	if utils.GetEnv(SOLHostKey, utils.EmptyString) != utils.EmptyString {
 		solParam.Host = utils.GetEnv(SOLHostKey, utils.EmptyString)
+		solParam.Hosts = strings.Split(solParam.Host, ",")
+	}
+}
+
+func (solParam solParam) GetHosts() []string {
+	return getHosts(solParam.Hosts, solParam.Host)
+}
+
+func (solParam solParam) GetCommitment() string {
+	if solParam.Commitment == utils.EmptyString {
+		return SOLCommitmentFinalized
+	}
+	return solParam.Commitment
+}
+
+// getHosts returns the endpoints used for quorum verification, falling back to the single legacy host
+func getHosts(hosts []string, host string) []string {
+	if len(hosts) > 0 {
+		return hosts
+	}
+	if host != utils.EmptyString {
+		return []string{host}
+	}
+	return []string{}
+}
+
+// evmNetworkParam declares one evm chain bridged by the generic evm shielding/unshielding flow,
+// adding a new chain only requires a new entry here and an activation height
+type evmNetworkParam struct {
+	NetworkID          uint8    `mapstructure:"network_id"`
+	Name               string   `mapstructure:"name"`
+	Prefix             string   `mapstructure:"prefix" description:"prefix of the external token ids of this network"`
+	ContractAddressStr string   `mapstructure:"contract_address" description:"smart contract of the bridge vault on this network"`
+	ConfirmationBlocks uint64   `mapstructure:"confirmation_blocks"`
+	Hosts              []string `mapstructure:"hosts"`
+	BurningConfirmMeta int      `mapstructure:"burning_confirm_meta" description:"metadata type expected by the vault contract in burn proofs"`
+	ActivationHeight   uint64   `mapstructure:"activation_height" description:"beacon height from which the network is accepted"`
+	MinQuorum          int      `mapstructure:"min_quorum" description:"number of hosts that must agree, default to a majority"`
+}
+
+func (evmNetwork *evmNetworkParam) GetFromEnv() {
+	key := fmt.Sprintf(EVMNetworkHostsKeyFormat, evmNetwork.NetworkID)
+	if utils.GetEnv(key, utils.EmptyString) != utils.EmptyString {
+		evmNetwork.Hosts = strings.Split(utils.GetEnv(key, utils.EmptyString), ",")
+	}
+}
+
+// IsActive returns true if the network is enabled at the given beacon height
+func (evmNetwork evmNetworkParam) IsActive(beaconHeight uint64) bool {
+	return evmNetwork.ActivationHeight > 0 && beaconHeight >= evmNetwork.ActivationHeight
+}
+
+// GetEVMNetwork returns a copy of the registered evm network with env overrides applied
+func (p *param) GetEVMNetwork(networkID uint8) (*evmNetworkParam, bool) {
+	for _, evmNetwork := range p.EVMNetworks {
+		if evmNetwork.NetworkID == networkID {
+			res := evmNetwork
+			res.Hosts = append([]string{}, evmNetwork.Hosts...)
+			res.GetFromEnv()
+			return &res, true
+		}
+	}
+	return nil, false
+}
+
+// GetEVMNetworkByBurningConfirmMeta returns the registered evm network whose burn proofs use metaType
+func (p *param) GetEVMNetworkByBurningConfirmMeta(metaType int) (*evmNetworkParam, bool) {
+	for _, evmNetwork := range p.EVMNetworks {
+		if evmNetwork.BurningConfirmMeta == metaType {
+			return p.GetEVMNetwork(evmNetwork.NetworkID)
+		}
 	}
+	return nil, false
 }
@@ -1,6 +1,10 @@
 package config
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
 
 func Test_verifyParam(t *testing.T) {
 	type args struct {
@@ -236,3 +240,101 @@ func Test_verifyParam(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifyEVMNetworks(t *testing.T) {
+	tests := []struct {
+		name        string
+		evmNetworks []evmNetworkParam
+		wantErr     bool
+	}{
+		{
+			name: "duplicate network id",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+				{NetworkID: 1, Prefix: "N2", ConfirmationBlocks: 15, ContractAddressStr: "0x2", BurningConfirmMeta: 221},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate burning confirm meta",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+				{NetworkID: 2, Prefix: "N2", ConfirmationBlocks: 15, ContractAddressStr: "0x2", BurningConfirmMeta: 220},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing contract address",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, BurningConfirmMeta: 220},
+			},
+			wantErr: true,
+		},
+		{
+			name: "quorum larger than hosts",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220, Hosts: []string{"a", "b"}, MinQuorum: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty prefix",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+			},
+			wantErr: true,
+		},
+		{
+			name: "built-in prefix",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: common.BSCPrefix, ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate prefix",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+				{NetworkID: 2, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x2", BurningConfirmMeta: 221},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero confirmation blocks",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+			},
+			wantErr: true,
+		},
+		{
+			name: "burning confirm meta below the reserved range",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 72},
+			},
+			wantErr: true,
+		},
+		{
+			name: "burning confirm meta above the reserved range",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 400},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pass all",
+			evmNetworks: []evmNetworkParam{
+				{NetworkID: 1, Prefix: "N1", ConfirmationBlocks: 15, ContractAddressStr: "0x1", BurningConfirmMeta: 220},
+				{NetworkID: 2, Prefix: "N2", ConfirmationBlocks: 15, ContractAddressStr: "0x2", BurningConfirmMeta: 221},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyEVMNetworks(tt.evmNetworks); (err != nil) != tt.wantErr {
+				t.Errorf("verifyEVMNetworks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package blsbft
 
 import (
+	"fmt"
+
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -25,8 +28,8 @@ type Actor interface {
 	IsStarted() bool
 	// ProcessBFTMsg - process incoming BFT message
 	ProcessBFTMsg(msg *wire.MessageBFT)
-	// LoadUserKey - load user mining key
-	LoadUserKeys(miningKey []signatureschemes2.MiningKey)
+	// LoadUserKeys - load the signers of user mining keys
+	LoadUserKeys(signers []signer.Signer)
 	// ValidateData - validate data with this consensus signature scheme
 	ValidateData(data []byte, sig string, publicKey string) error
 	// SignData - sign data with this consensus signature scheme
@@ -51,3 +54,29 @@ func NewActorWithValue(
 	}
 	return res
 }
+
+// newUserKeySet keeps only the public part of the user mining keys, signatures are requested from their signers
+func newUserKeySet(signers []signer.Signer) ([]signatureschemes2.MiningKey, map[string]signer.Signer) {
+	userKeySet := []signatureschemes2.MiningKey{}
+	userSigners := make(map[string]signer.Signer)
+	for _, userSigner := range signers {
+		publicKey := userSigner.GetPublicKey()
+		userKeySet = append(userKeySet, signatureschemes2.MiningKey{
+			PubKey: map[string][]byte{
+				common.BlsConsensus:    publicKey.MiningPubKey[common.BlsConsensus],
+				common.BridgeConsensus: publicKey.MiningPubKey[common.BridgeConsensus],
+			},
+		})
+		userSigners[publicKey.GetMiningKeyBase58(common.BlsConsensus)] = userSigner
+	}
+	return userKeySet, userSigners
+}
+
+func getUserSigner(userSigners map[string]signer.Signer, userKey *signatureschemes2.MiningKey) (signer.Signer, error) {
+	userPk := userKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	userSigner, ok := userSigners[userPk]
+	if !ok {
+		return nil, NewConsensusError(LoadKeyError, fmt.Errorf("no signer for mining key %v", userPk))
+	}
+	return userSigner, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -32,6 +33,7 @@ type actorV1 struct {
 	peerID   string
 
 	userKeySet       []signatureschemes2.MiningKey
+	userSigners      map[string]signer.Signer
 	bftMessageCh     chan wire.MessageBFT
 	proposeMessageCh chan BFTPropose
 	voteMessageCh    chan BFTVote
@@ -129,8 +131,8 @@ func (actorV1 *actorV1) preValidateVote(blockHash []byte, Vote *vote, candidate
 	return err
 }
 
-func (actorV1 *actorV1) LoadUserKeys(miningKey []signatureschemes2.MiningKey) {
-	actorV1.userKeySet = miningKey
+func (actorV1 *actorV1) LoadUserKeys(signers []signer.Signer) {
+	actorV1.userKeySet, actorV1.userSigners = newUserKeySet(signers)
 	return
 }
 
@@ -143,7 +145,11 @@ func (actorV1 *actorV1) GetUserPublicKey() *incognitokey.CommitteePublicKey {
 }
 
 func (actorV1 *actorV1) SignData(data []byte) (string, error) {
-	result, err := actorV1.userKeySet[0].BriSignData(data) //, 0, []blsmultisig.PublicKey{e.UserKeySet.PubKey[common.BlsConsensus]})
+	userSigner, err := getUserSigner(actorV1.userSigners, &actorV1.userKeySet[0])
+	if err != nil {
+		return "", err
+	}
+	result, err := userSigner.BriSignData(data)
 	if err != nil {
 		return "", NewConsensusError(SignDataError, err)
 	}
@@ -430,8 +436,23 @@ func (actorV1 *actorV1) enterProposePhase(keyset *signatureschemes2.MiningKey) {
 	if actorV1.chain.CurrentHeight()+1 != block.GetHeight() {
 		return
 	}
+	userSigner, err := getUserSigner(actorV1.userSigners, keyset)
+	if err != nil {
+		actorV1.logger.Error(err)
+		return
+	}
 	var validationData consensustypes.ValidationData
-	validationData.ProducerBLSSig, _ = keyset.BriSignData(block.Hash().GetBytes())
+	validationData.ProducerBLSSig, err = userSigner.SignPropose(&signer.ProposeRequest{
+		ChainID:         actorV1.chainID,
+		BlockHeight:     block.GetHeight(),
+		BlockHash:       *block.Hash(),
+		Round:           block.GetRound(),
+		ProposeTimeSlot: common.CalculateTimeSlot(block.GetProposeTime()),
+	})
+	if err != nil {
+		actorV1.logger.Error("can't sign block", err)
+		return
+	}
 	validationDataString, err := consensustypes.EncodeValidationData(validationData)
 	if err != nil {
 		actorV1.logger.Errorf("Encode validation data failed %+v", err)
@@ -1,6 +1,7 @@
 package blsbft
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
@@ -35,6 +37,7 @@ type actorV2 struct {
 	peerID   string
 
 	userKeySet       []signatureschemes2.MiningKey
+	userSigners      map[string]signer.Signer
 	bftMessageCh     chan wire.MessageBFT
 	proposeMessageCh chan BFTPropose
 	voteMessageCh    chan BFTVote
@@ -55,6 +58,10 @@ type actorV2 struct {
 
 	nextBlockFinalityProof map[string]map[int64]string
 
+	// verified random beacon shares of randomBeaconMessage by committee index, see recordRandomBeaconShares
+	randomBeaconMessage []byte
+	randomBeaconShares  map[int][]byte
+
 	ruleDirector *ActorV2RuleDirector
 	blockVersion int
 }
@@ -488,8 +495,8 @@ func (a *actorV2) ProcessBFTMsg(msgBFT *wire.MessageBFT) {
 	}
 }
 
-func (a *actorV2) LoadUserKeys(miningKey []signatureschemes2.MiningKey) {
-	a.userKeySet = miningKey
+func (a *actorV2) LoadUserKeys(signers []signer.Signer) {
+	a.userKeySet, a.userSigners = newUserKeySet(signers)
 	return
 }
 
@@ -509,8 +516,11 @@ func (a actorV2) ValidateData(data []byte, sig string, publicKey string) error {
 }
 
 func (a *actorV2) SignData(data []byte) (string, error) {
-	//, 0, []blsmultisig.PublicKey{e.UserKeySet.PubKey[common.BlsConsensus]})
-	result, err := a.userKeySet[0].BriSignData(data)
+	userSigner, err := getUserSigner(a.userSigners, &a.userKeySet[0])
+	if err != nil {
+		return "", err
+	}
+	result, err := userSigner.BriSignData(data)
 	if err != nil {
 		return "", NewConsensusError(SignDataError, err)
 	}
@@ -666,13 +676,17 @@ func (a *actorV2) run() error {
 								createdBlk.GetHeight(), createdBlk.Hash().String(), finalityProof.ReProposeHashSignature)
 						}
 
-						env := NewSendProposeBlockEnvironment(
-							finalityProof,
-							isEnoughLemma2Proof,
-							userProposeKey,
-							a.node.GetSelfPeerID().String(),
-						)
-						bftProposeMessage, err := a.ruleDirector.builder.ProposeMessageRule().CreateProposeBFTMessage(env, createdBlk)
+						var bftProposeMessage *BFTPropose
+						userSigner, err := getUserSigner(a.userSigners, &userProposeKey)
+						if err == nil {
+							env := NewSendProposeBlockEnvironment(
+								finalityProof,
+								isEnoughLemma2Proof,
+								userSigner,
+								a.node.GetSelfPeerID().String(),
+							)
+							bftProposeMessage, err = a.ruleDirector.builder.ProposeMessageRule().CreateProposeBFTMessage(env, createdBlk)
+						}
 						if err != nil {
 							a.logger.Error("Create BFT Propose Message Failed", err)
 						} else {
@@ -800,6 +814,11 @@ func (a *actorV2) processWithEnoughVotesBeaconChain(
 		a.logger.Error(err)
 		return err
 	}
+	validationData, err = a.addRandomBeaconSig(v.block, v.SigningCommittees, validationData, v.Votes)
+	if err != nil {
+		a.logger.Error(err)
+		return err
+	}
 	v.block.(blockValidation).AddValidationField(validationData)
 
 	if err := a.ruleDirector.builder.InsertBlockRule().InsertBlock(v.block); err != nil {
@@ -972,6 +991,7 @@ func (a *actorV2) proposeBlock(
 	block, err = a.addValidationData(userMiningKey, block)
 	if err != nil {
 		a.logger.Errorf("Add validation data for new block failed", err)
+		return nil, err
 	}
 
 	return block, nil
@@ -1049,13 +1069,26 @@ func (a *actorV2) proposeShardBlock(
 func (a *actorV2) addValidationData(userMiningKey signatureschemes2.MiningKey, block types.BlockInterface) (types.BlockInterface, error) {
 
 	var validationData consensustypes.ValidationData
+	userSigner, err := getUserSigner(a.userSigners, &userMiningKey)
+	if err != nil {
+		return block, err
+	}
 	portalParam := a.chain.GetPortalParamsV4(0)
-	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(userMiningKey.PriKey[common.BridgeConsensus], block.GetInstructions(), portalParam)
+	portalSigs, err := userSigner.SignPortalExternalTxs(block.GetInstructions(), portalParam)
 	if err != nil {
 		return block, NewConsensusError(UnExpectedError, err)
 	}
 	validationData.PortalSig = portalSigs
-	validationData.ProducerBLSSig, _ = userMiningKey.BriSignData(block.Hash().GetBytes())
+	validationData.ProducerBLSSig, err = userSigner.SignPropose(&signer.ProposeRequest{
+		ChainID:         a.chainID,
+		BlockHeight:     block.GetHeight(),
+		BlockHash:       *block.Hash(),
+		Round:           block.GetRound(),
+		ProposeTimeSlot: common.CalculateTimeSlot(block.GetProposeTime()),
+	})
+	if err != nil {
+		return block, NewConsensusError(SignDataError, err)
+	}
 	validationDataString, _ := consensustypes.EncodeValidationData(validationData)
 	block.(blockValidation).AddValidationField(validationDataString)
 
@@ -1119,10 +1152,19 @@ func (a *actorV2) sendVote(
 	portalParamV4 portalv4.PortalParams,
 ) error {
 
+	userSigner, err := getUserSigner(a.userSigners, userKey)
+	if err != nil {
+		return err
+	}
+	var randomBeacon *signer.RandomBeaconRequest
+	if prevValue, epoch, ok := a.chain.GetRandomBeaconSigningData(block); ok {
+		randomBeacon = &signer.RandomBeaconRequest{PrevValue: prevValue, Epoch: epoch}
+	}
 	env := NewVoteMessageEnvironment(
-		userKey,
+		userSigner,
 		signingCommittees,
 		portalParamV4,
+		randomBeacon,
 	)
 	vote, err := a.ruleDirector.builder.VoteRule().CreateVote(env, block)
 	if err != nil {
@@ -1367,6 +1409,9 @@ func (a *actorV2) processVoteMessage(voteMsg BFTVote) error {
 				a.logger.Infof("%v Receive vote (%d) for block %v from unknown validator %v", a.chainKey, len(a.receiveBlockByHash[voteMsg.BlockHash].Votes), voteMsg.BlockHash, voteMsg.Validator)
 			}
 			proposeBlockInfo.HasNewVote = true
+			if proposeBlockInfo.block != nil && len(voteMsg.RandomBeaconSig) != 0 {
+				a.recordRandomBeaconShares(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, map[string]*BFTVote{voteMsg.Validator: &voteMsg})
+			}
 		}
 
 		if !proposeBlockInfo.ProposerSendVote {
@@ -1569,6 +1614,78 @@ func (a *actorV2) combineVotes(votes map[string]*BFTVote, committees []string) (
 	return
 }
 
+// recordRandomBeaconShares keeps the verified random beacon shares of the votes for block. All the blocks of an
+// epoch after the random time sign the same message, so the shares of the votes for a block, also the ones
+// received after it is committed, complete the random beacon of the next blocks. The shares are not covered
+// by the vote confirmation so each one is verified first
+func (a *actorV2) recordRandomBeaconShares(
+	block types.BlockInterface,
+	committees []incognitokey.CommitteePublicKey,
+	votes map[string]*BFTVote,
+) {
+	prevValue, epoch, ok := a.chain.GetRandomBeaconSigningData(block)
+	if !ok {
+		return
+	}
+	data := consensustypes.RandomBeaconMessage(prevValue, epoch)
+	if !bytes.Equal(data, a.randomBeaconMessage) {
+		a.randomBeaconMessage = data
+		a.randomBeaconShares = make(map[int][]byte)
+	}
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for _, member := range committees {
+		committeeBLSKeys = append(committeeBLSKeys, member.MiningPubKey[common.BlsConsensus])
+	}
+	for validator, vote := range votes {
+		if len(vote.RandomBeaconSig) == 0 {
+			continue
+		}
+		idx, _ := a.getValidatorIndex(committees, validator)
+		if _, ok := a.randomBeaconShares[idx]; idx < 0 || ok {
+			continue
+		}
+		if ok, err := blsmultisig.Verify(vote.RandomBeaconSig, data, []int{idx}, committeeBLSKeys); !ok || err != nil {
+			a.logger.Errorf("Invalid random beacon signature from %v, %v", vote.Validator, err)
+			continue
+		}
+		a.randomBeaconShares[idx] = vote.RandomBeaconSig
+	}
+}
+
+// addRandomBeaconSig aggregates into the validation data the random beacon shares of the canonical signers
+// (consensustypes.RandomBeaconSigners), only once all of them are received for the epoch
+func (a *actorV2) addRandomBeaconSig(
+	block types.BlockInterface,
+	committees []incognitokey.CommitteePublicKey,
+	tempValidationData string,
+	votes map[string]*BFTVote,
+) (string, error) {
+	prevValue, _, ok := a.chain.GetRandomBeaconSigningData(block)
+	if !ok {
+		return tempValidationData, nil
+	}
+	a.recordRandomBeaconShares(block, committees, votes)
+	valData, err := consensustypes.DecodeValidationData(tempValidationData)
+	if err != nil {
+		return "", err
+	}
+	signersIdx := consensustypes.RandomBeaconSigners(prevValue, len(committees))
+	sigs := [][]byte{}
+	for _, idx := range signersIdx {
+		sig, ok := a.randomBeaconShares[idx]
+		if !ok {
+			return tempValidationData, nil
+		}
+		sigs = append(sigs, sig)
+	}
+	valData.RandomBeaconSig, err = blsmultisig.Combine(sigs)
+	if err != nil {
+		return "", NewConsensusError(CombineSignatureError, err)
+	}
+	valData.RandomBeaconIdx = signersIdx
+	return consensustypes.EncodeValidationData(*valData)
+}
+
 func (a *actorV2) makeBFTProposeMsg(
 	proposeCtn *BFTPropose,
 	chainKey string,
@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"reflect"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ var ByzantineDetectorObject *ByzantineDetector
 var defaultBlackListTTL = 1 * time.Second
 var defaultHeightTTL = uint64(100)
 var defaultTimeSlotTTL = int64(100)
+var defaultEvidenceTimeSlotTTL = int64(10000)
 
 type VoteMessageHandler func(bftVote *BFTVote) error
 
@@ -41,6 +43,7 @@ type ByzantineDetector struct {
 	voteInTimeSlot               map[string]map[int64]*BFTVote                  // validator => timeslot => vote
 	validRecentVote              map[string]*BFTVote
 	smallestBlockProduceTimeSlot map[string]map[uint64]*BFTVote // validator => height => timeslot
+	evidences                    map[string][2]*BFTVote         // validator => two votes for different blocks in one timeslot
 	logger                       common.Logger
 	mu                           *sync.RWMutex
 }
@@ -68,6 +71,7 @@ func NewByzantineDetector(logger common.Logger) *ByzantineDetector {
 		voteInTimeSlot:               make(map[string]map[int64]*BFTVote),
 		smallestBlockProduceTimeSlot: make(map[string]map[uint64]*BFTVote),
 		validRecentVote:              make(map[string]*BFTVote),
+		evidences:                    make(map[string][2]*BFTVote),
 		mu:                           new(sync.RWMutex),
 	}
 }
@@ -137,6 +141,10 @@ func (b *ByzantineDetector) Validate(bestViewHeight uint64, vote *BFTVote) error
 		}
 	}
 
+	if errors.Is(err, ErrDuplicateVoteInOneTimeSlot) {
+		b.addEquivocationEvidence(vote)
+	}
+
 	b.addNewVote(rawdb_consensus.GetConsensusDatabase(), vote, err)
 
 	if config.Param().ConsensusParam.ByzantineDetectorHeight < bestViewHeight {
@@ -166,6 +174,12 @@ func (b *ByzantineDetector) UpdateState(finalHeight uint64, finalTimeSlot int64)
 			}
 		}
 	}
+
+	for validator, votes := range b.evidences {
+		if votes[0].ProposeTimeSlot+defaultEvidenceTimeSlotTTL < finalTimeSlot {
+			delete(b.evidences, validator)
+		}
+	}
 }
 
 func (b *ByzantineDetector) Loop() {
@@ -242,9 +256,9 @@ func (b ByzantineDetector) voteMoreThanOneTimesInATimeSlot(newVote *BFTVote) err
 			return nil
 		}
 		if !reflect.DeepEqual(vote, newVote) {
-			return fmt.Errorf("error name: %+v,"+
+			return fmt.Errorf("error name: %w,"+
 				"first bftvote: %+v, latter bftvote: %+v",
-				ErrVoteForHigherTimeSlot, vote, newVote)
+				ErrDuplicateVoteInOneTimeSlot, vote, newVote)
 		}
 	}
 
@@ -344,3 +358,50 @@ func (b *ByzantineDetector) addNewVote(database incdb.Database, newVote *BFTVote
 
 	b.validRecentVote[newVote.Validator] = newVote
 }
+
+// addEquivocationEvidence keeps the two signed votes for different blocks of a shard in one timeslot,
+// votes for the same block with different data are not provable on chain
+func (b *ByzantineDetector) addEquivocationEvidence(newVote *BFTVote) {
+	if newVote.ChainID == common.BeaconChainID {
+		return
+	}
+	vote, ok := b.voteInTimeSlot[newVote.Validator][newVote.ProposeTimeSlot]
+	if !ok || vote.BlockHash == newVote.BlockHash || vote.CommitteeFromBlock != newVote.CommitteeFromBlock {
+		return
+	}
+	if b.evidences == nil {
+		b.evidences = make(map[string][2]*BFTVote)
+	}
+	b.evidences[newVote.Validator] = [2]*BFTVote{vote, newVote}
+}
+
+// GetEquivocationEvidences returns the evidences detected locally by validator,
+// they can be submitted on chain to slash the validator
+func (b *ByzantineDetector) GetEquivocationEvidences() map[string]*metadata.EquivocationEvidence {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	res := make(map[string]*metadata.EquivocationEvidence)
+	for validator, votes := range b.evidences {
+		res[validator] = metadata.NewEquivocationEvidence(votes[0].toEquivocationVote(), votes[1].toEquivocationVote())
+	}
+
+	return res
+}
+
+func (v *BFTVote) toEquivocationVote() metadata.EquivocationVote {
+	return metadata.EquivocationVote{
+		PrevBlockHash:      v.PrevBlockHash,
+		BlockHeight:        v.BlockHeight,
+		BlockHash:          v.BlockHash,
+		Validator:          v.Validator,
+		BLS:                v.BLS,
+		BRI:                v.BRI,
+		Confirmation:       v.Confirmation,
+		ProduceTimeSlot:    v.ProduceTimeSlot,
+		ProposeTimeSlot:    v.ProposeTimeSlot,
+		CommitteeFromBlock: v.CommitteeFromBlock,
+		ChainID:            v.ChainID,
+	}
+}
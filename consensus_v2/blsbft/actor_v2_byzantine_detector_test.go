@@ -4,6 +4,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -659,3 +660,73 @@ func TestByzantineDetector_voteForSmallerBlockHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestByzantineDetector_addEquivocationEvidence(t *testing.T) {
+	firstVote := &BFTVote{
+		Validator:       blsKeys[0],
+		BlockHash:       "a",
+		BlockHeight:     10,
+		ProposeTimeSlot: 163394559,
+		ChainID:         0,
+	}
+	tests := []struct {
+		name    string
+		newVote *BFTVote
+		want    bool
+	}{
+		{
+			name: "vote for another block in the same timeslot",
+			newVote: &BFTVote{
+				Validator:       blsKeys[0],
+				BlockHash:       "b",
+				BlockHeight:     10,
+				ProposeTimeSlot: 163394559,
+				ChainID:         0,
+			},
+			want: true,
+		},
+		{
+			name: "vote for the same block",
+			newVote: &BFTVote{
+				Validator:       blsKeys[0],
+				BlockHash:       "a",
+				BlockHeight:     10,
+				ProduceTimeSlot: 1,
+				ProposeTimeSlot: 163394559,
+				ChainID:         0,
+			},
+			want: false,
+		},
+		{
+			name: "vote in another timeslot",
+			newVote: &BFTVote{
+				Validator:       blsKeys[0],
+				BlockHash:       "b",
+				BlockHeight:     10,
+				ProposeTimeSlot: 163394560,
+				ChainID:         0,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &ByzantineDetector{
+				voteInTimeSlot: map[string]map[int64]*BFTVote{
+					blsKeys[0]: {
+						firstVote.ProposeTimeSlot: firstVote,
+					},
+				},
+				mu: new(sync.RWMutex),
+			}
+			b.addEquivocationEvidence(tt.newVote)
+			evidence, ok := b.GetEquivocationEvidences()[blsKeys[0]]
+			if ok != tt.want {
+				t.Fatalf("addEquivocationEvidence() got evidence = %v, want %v", ok, tt.want)
+			}
+			if ok && (evidence.VoteA.BlockHash != "a" || evidence.VoteB.BlockHash != "b") {
+				t.Errorf("addEquivocationEvidence() got votes for %v and %v", evidence.VoteA.BlockHash, evidence.VoteB.BlockHash)
+			}
+		})
+	}
+}
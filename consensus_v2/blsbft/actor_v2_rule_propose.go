@@ -7,6 +7,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -27,12 +28,12 @@ func NewProposeMessageEnvironment(block types.BlockInterface, previousBlock type
 type SendProposeBlockEnvironment struct {
 	finalityProof    *FinalityProof
 	isValidRePropose bool
-	userProposeKey   signatureschemes2.MiningKey
+	userSigner       signer.Signer
 	peerID           string
 }
 
-func NewSendProposeBlockEnvironment(finalityProof *FinalityProof, isValidRePropose bool, userProposeKey signatureschemes2.MiningKey, peerID string) *SendProposeBlockEnvironment {
-	return &SendProposeBlockEnvironment{finalityProof: finalityProof, isValidRePropose: isValidRePropose, userProposeKey: userProposeKey, peerID: peerID}
+func NewSendProposeBlockEnvironment(finalityProof *FinalityProof, isValidRePropose bool, userSigner signer.Signer, peerID string) *SendProposeBlockEnvironment {
+	return &SendProposeBlockEnvironment{finalityProof: finalityProof, isValidRePropose: isValidRePropose, userSigner: userSigner, peerID: peerID}
 }
 
 type IProposeMessageRule interface {
@@ -386,8 +387,7 @@ func GetProposerByTimeSlot(ts int64, committeeLen int) int {
 
 func (p ProposeRuleLemma2) CreateProposeBFTMessage(env *SendProposeBlockEnvironment, block types.BlockInterface) (*BFTPropose, error) {
 
-	reProposeHashSignature, err := createReProposeHashSignature(
-		env.userProposeKey.PriKey[common.BridgeConsensus], block)
+	reProposeHashSignature, err := createReProposeHashSignature(env.userSigner, block)
 
 	if err != nil {
 		return nil, err
@@ -4,22 +4,32 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
-	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
-	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/portal/portalv4"
-	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 )
 
 type VoteMessageEnvironment struct {
-	userKey           *signatureschemes2.MiningKey
+	userSigner        signer.Signer
 	signingCommittees []incognitokey.CommitteePublicKey
 	portalParamV4     portalv4.PortalParams
+	// randomBeacon is the random beacon share to sign with the vote, nil if the vote does not carry one
+	randomBeacon *signer.RandomBeaconRequest
 }
 
-func NewVoteMessageEnvironment(userKey *signatureschemes2.MiningKey, signingCommittees []incognitokey.CommitteePublicKey, portalParamV4 portalv4.PortalParams) *VoteMessageEnvironment {
-	return &VoteMessageEnvironment{userKey: userKey, signingCommittees: signingCommittees, portalParamV4: portalParamV4}
+func NewVoteMessageEnvironment(
+	userSigner signer.Signer,
+	signingCommittees []incognitokey.CommitteePublicKey,
+	portalParamV4 portalv4.PortalParams,
+	randomBeacon *signer.RandomBeaconRequest,
+) *VoteMessageEnvironment {
+	return &VoteMessageEnvironment{
+		userSigner:        userSigner,
+		signingCommittees: signingCommittees,
+		portalParamV4:     portalParamV4,
+		randomBeacon:      randomBeacon,
+	}
 }
 
 type IVoteRule interface {
@@ -97,7 +107,7 @@ func (v VoteRule) ValidateVote(proposeBlockInfo *ProposeBlockInfo) *ProposeBlock
 
 func (v VoteRule) CreateVote(env *VoteMessageEnvironment, block types.BlockInterface) (*BFTVote, error) {
 
-	vote, err := createVote(env.userKey, block, env.signingCommittees, env.portalParamV4)
+	vote, err := createVote(env.userSigner, block, env.signingCommittees, env.portalParamV4, env.randomBeacon)
 	if err != nil {
 		v.logger.Error(err)
 		return nil, err
@@ -107,15 +117,16 @@ func (v VoteRule) CreateVote(env *VoteMessageEnvironment, block types.BlockInter
 }
 
 func createVote(
-	userKey *signatureschemes2.MiningKey,
+	userSigner signer.Signer,
 	block types.BlockInterface,
 	committees []incognitokey.CommitteePublicKey,
 	portalParamsV4 portalv4.PortalParams,
+	randomBeacon *signer.RandomBeaconRequest,
 ) (*BFTVote, error) {
 	var vote = new(BFTVote)
-	bytelist := []blsmultisig.PublicKey{}
+	bytelist := [][]byte{}
 	selfIdx := 0
-	userBLSPk := userKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	userBLSPk := userSigner.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
 	for i, v := range committees {
 		if v.GetMiningKeyBase58(common.BlsConsensus) == userBLSPk {
 			selfIdx = i
@@ -123,27 +134,6 @@ func createVote(
 		bytelist = append(bytelist, v.MiningPubKey[common.BlsConsensus])
 	}
 
-	blsSig, err := userKey.BLSSignData(block.Hash().GetBytes(), selfIdx, bytelist)
-	if err != nil {
-		return nil, NewConsensusError(UnExpectedError, err)
-	}
-	bridgeSig := []byte{}
-	if metadata.HasBridgeInstructions(block.GetInstructions()) {
-		bridgeSig, err = userKey.BriSignData(block.Hash().GetBytes())
-		if err != nil {
-			return nil, NewConsensusError(UnExpectedError, err)
-		}
-	}
-
-	// check and sign on unshielding external tx for Portal v4
-	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(userKey.PriKey[common.BridgeConsensus], block.GetInstructions(), portalParamsV4)
-	if err != nil {
-		return nil, NewConsensusError(UnExpectedError, err)
-	}
-
-	vote.BLS = blsSig
-	vote.BRI = bridgeSig
-	vote.PortalSigs = portalSigs
 	vote.BlockHash = block.Hash().String()
 	vote.Validator = userBLSPk
 	vote.ProduceTimeSlot = common.CalculateTimeSlot(block.GetProduceTime())
@@ -152,10 +142,37 @@ func createVote(
 	vote.BlockHeight = block.GetHeight()
 	vote.CommitteeFromBlock = block.CommitteeFromBlock()
 	vote.ChainID = block.GetShardID()
-	err = vote.signVote(userKey)
+	sig, err := userSigner.SignVote(&signer.VoteRequest{
+		Vote:      vote.confirmationData(),
+		Round:     block.GetRound(),
+		SelfIdx:   selfIdx,
+		Committee: bytelist,
+		BridgeSig: metadata.HasBridgeInstructions(block.GetInstructions()),
+	})
 	if err != nil {
 		return nil, NewConsensusError(UnExpectedError, err)
 	}
+
+	// check and sign on unshielding external tx for Portal v4
+	portalSigs, err := userSigner.SignPortalExternalTxs(block.GetInstructions(), portalParamsV4)
+	if err != nil {
+		return nil, NewConsensusError(UnExpectedError, err)
+	}
+
+	if randomBeacon != nil {
+		req := *randomBeacon
+		req.SelfIdx = selfIdx
+		req.Committee = bytelist
+		vote.RandomBeaconSig, err = userSigner.SignRandomBeacon(&req)
+		if err != nil {
+			return nil, NewConsensusError(UnExpectedError, err)
+		}
+	}
+
+	vote.BLS = sig.BLS
+	vote.BRI = sig.BRI
+	vote.Confirmation = sig.Confirmation
+	vote.PortalSigs = portalSigs
 	return vote, nil
 }
 
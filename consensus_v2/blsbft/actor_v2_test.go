@@ -2,8 +2,14 @@ package blsbft
 
 import (
 	"encoding/json"
+	"testing"
+
+	mocksTypes "github.com/incognitochain/incognito-chain/blockchain/types/mocks"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft/mocks"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -524,3 +530,75 @@ var _ = func() (_ struct{}) {
 //		})
 //	}
 //}
+
+func TestActorV2_addRandomBeaconSig(t *testing.T) {
+	prevValue := common.HashH([]byte("previous random beacon"))
+	epoch := uint64(5)
+	data := consensustypes.RandomBeaconMessage(prevValue, epoch)
+
+	secretKeys := []blsmultisig.SecretKey{}
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	committees := []incognitokey.CommitteePublicKey{}
+	for i := 0; i < 4; i++ {
+		sk, pk := blsmultisig.KeyGen([]byte{byte(i)})
+		secretKeys = append(secretKeys, blsmultisig.SKBytes(sk))
+		committeeBLSKeys = append(committeeBLSKeys, blsmultisig.PKBytes(pk))
+		committees = append(committees, incognitokey.CommitteePublicKey{
+			MiningPubKey: map[string][]byte{common.BlsConsensus: blsmultisig.PKBytes(pk)},
+		})
+	}
+	votes := map[string]*BFTVote{}
+	for i := range committees {
+		sig, err := blsmultisig.Sign(data, secretKeys[i], i, committeeBLSKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		validator := committees[i].GetMiningKeyBase58(common.BlsConsensus)
+		votes[validator] = &BFTVote{Validator: validator, RandomBeaconSig: sig}
+	}
+
+	block1 := &mocksTypes.BlockInterface{}
+	block2 := &mocksTypes.BlockInterface{}
+	chain := &mocks.Chain{}
+	chain.On("GetRandomBeaconSigningData", block1).Return(prevValue, epoch, true)
+	chain.On("GetRandomBeaconSigningData", block2).Return(prevValue, epoch, true)
+	a := &actorV2{chain: chain, logger: logger}
+	emptyValidationData, _ := consensustypes.EncodeValidationData(consensustypes.ValidationData{})
+
+	// block1 is committed before the last signer votes
+	signers := consensustypes.RandomBeaconSigners(prevValue, len(committees))
+	lateVoter := committees[signers[len(signers)-1]].GetMiningKeyBase58(common.BlsConsensus)
+	earlyVotes := map[string]*BFTVote{}
+	for validator, vote := range votes {
+		if validator != lateVoter {
+			earlyVotes[validator] = vote
+		}
+	}
+	validationData, err := a.addRandomBeaconSig(block1, committees, emptyValidationData, earlyVotes)
+	if err != nil || validationData != emptyValidationData {
+		t.Fatalf("expect no random beacon without all the signers, got %v, %v", validationData, err)
+	}
+
+	// the late vote for block1 completes the random beacon of block2, which gets no vote from the signer
+	a.recordRandomBeaconShares(block1, committees, map[string]*BFTVote{lateVoter: votes[lateVoter]})
+	validationData, err = a.addRandomBeaconSig(block2, committees, emptyValidationData, earlyVotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valData, err := consensustypes.DecodeValidationData(validationData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := blsmultisig.Verify(valData.RandomBeaconSig, data, valData.RandomBeaconIdx, committeeBLSKeys); !ok || err != nil {
+		t.Fatalf("expect a valid aggregated random beacon, got %v", err)
+	}
+
+	// a forged share is not kept
+	a.randomBeaconShares = nil
+	a.randomBeaconMessage = nil
+	forged := map[string]*BFTVote{lateVoter: {Validator: lateVoter, RandomBeaconSig: votes[committees[signers[0]].GetMiningKeyBase58(common.BlsConsensus)].RandomBeaconSig}}
+	a.recordRandomBeaconShares(block1, committees, forged)
+	if len(a.randomBeaconShares) != 0 {
+		t.Fatalf("expect the forged share to be dropped")
+	}
+}
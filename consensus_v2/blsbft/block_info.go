@@ -10,6 +10,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -308,7 +309,8 @@ type ReProposeBlockInfo struct {
 	RootHash          common.Hash
 }
 
-func createReProposeHashSignature(privateKey []byte, block types.BlockInterface) (string, error) {
+// createReProposeHashSignature signs the re-propose hash of block with the bridge key of the proposer signer
+func createReProposeHashSignature(userSigner signer.Signer, block types.BlockInterface) (string, error) {
 
 	reProposeBlockInfo := newReProposeBlockInfo(
 		block.GetPrevHash(),
@@ -319,7 +321,17 @@ func createReProposeHashSignature(privateKey []byte, block types.BlockInterface)
 		block.GetAggregateRootHash(),
 	)
 
-	return reProposeBlockInfo.Sign(privateKey)
+	sig, err := userSigner.SignRePropose(&signer.ReProposeRequest{
+		ChainID:     block.GetShardID(),
+		BlockHeight: block.GetHeight(),
+		BlockHash:   *block.Hash(),
+		RePropose:   signer.ReProposeData(*reProposeBlockInfo),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base58.Base58Check{}.Encode(sig, common.Base58Version), nil
 }
 
 func verifyReProposeHashSignature(
@@ -366,8 +378,7 @@ func newReProposeBlockInfo(previousBlockHash common.Hash, producer string, produ
 }
 
 func (r ReProposeBlockInfo) Hash() common.Hash {
-	data, _ := json.Marshal(&r)
-	return common.HashH(data)
+	return signer.ReProposeData(r).Hash()
 }
 
 func (r ReProposeBlockInfo) Sign(privateKey []byte) (string, error) {
@@ -83,6 +83,9 @@ type Chain interface {
 	GetPortalParamsV4(beaconHeight uint64) portalv4.PortalParams
 	GetBlockByHash(hash common.Hash) (types.BlockInterface, error)
 	StoreFinalityProof(block types.BlockInterface, finalityProof interface{}, reProposeSig interface{}) error
+	// GetRandomBeaconSigningData returns the previous random value and the epoch the voters of block sign
+	// for the random beacon, false if the votes of block do not carry a random beacon signature
+	GetRandomBeaconSigningData(block types.BlockInterface) (common.Hash, uint64, bool)
 }
 
 type CommitteeChainHandler interface {
@@ -2,11 +2,10 @@ package blsbft
 
 import (
 	"encoding/json"
-	"github.com/incognitochain/incognito-chain/config"
 	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 
 	"github.com/incognitochain/incognito-chain/common"
-	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 )
 
 const (
@@ -38,6 +37,8 @@ type BFTVote struct {
 	ChainID            int
 	// Portal v4
 	PortalSigs []*portalprocessv4.PortalSig
+	// RandomBeaconSig is the BLS share of the voter for the random beacon, see Chain.GetRandomBeaconSigningData
+	RandomBeaconSig []byte `json:",omitempty"`
 }
 
 type BFTRequestBlock struct {
@@ -54,55 +55,25 @@ func (s *BFTVote) isEmptyDataForByzantineDetector() bool {
 	return false
 }
 
-func (s *BFTVote) signVote(key *signatureschemes2.MiningKey) error {
-
-	data := []byte{}
-
-	if s.BlockHeight < config.Param().ConsensusParam.ByzantineDetectorHeight {
-		data = append(data, s.BlockHash...)
-		data = append(data, s.BLS...)
-		data = append(data, s.BRI...)
-	} else {
-		data = append(data, s.BlockHash...)
-		data = append(data, s.BLS...)
-		data = append(data, s.BRI...)
-		data = append(data, common.Uint64ToBytes(s.BlockHeight)...)
-		data = append(data, common.Int64ToBytes(s.ProduceTimeSlot)...)
-		data = append(data, common.Int64ToBytes(s.ProposeTimeSlot)...)
-		data = append(data, []byte(s.Validator)...)
-		data = append(data, []byte(s.PrevBlockHash)...)
-		data = append(data, s.CommitteeFromBlock[:]...)
-		data = append(data, common.Int64ToBytes(int64(s.ChainID))...)
+// confirmationData returns the fields of the vote covered by its confirmation
+func (s *BFTVote) confirmationData() signer.VoteData {
+	return signer.VoteData{
+		PrevBlockHash:      s.PrevBlockHash,
+		BlockHeight:        s.BlockHeight,
+		BlockHash:          s.BlockHash,
+		Validator:          s.Validator,
+		BLS:                s.BLS,
+		BRI:                s.BRI,
+		ProduceTimeSlot:    s.ProduceTimeSlot,
+		ProposeTimeSlot:    s.ProposeTimeSlot,
+		CommitteeFromBlock: s.CommitteeFromBlock,
+		ChainID:            s.ChainID,
 	}
-
-	data = common.HashB(data)
-	var err error
-	s.Confirmation, err = key.BriSignData(data)
-	return err
 }
 
 func (s *BFTVote) validateVoteOwner(ownerPk []byte) error {
-
-	data := []byte{}
-
-	if s.BlockHeight < config.Param().ConsensusParam.ByzantineDetectorHeight {
-		data = append(data, s.BlockHash...)
-		data = append(data, s.BLS...)
-		data = append(data, s.BRI...)
-	} else {
-		data = append(data, s.BlockHash...)
-		data = append(data, s.BLS...)
-		data = append(data, s.BRI...)
-		data = append(data, common.Uint64ToBytes(s.BlockHeight)...)
-		data = append(data, common.Int64ToBytes(s.ProduceTimeSlot)...)
-		data = append(data, common.Int64ToBytes(s.ProposeTimeSlot)...)
-		data = append(data, []byte(s.Validator)...)
-		data = append(data, []byte(s.PrevBlockHash)...)
-		data = append(data, s.CommitteeFromBlock[:]...)
-		data = append(data, common.Int64ToBytes(int64(s.ChainID))...)
-	}
-
-	dataHash := common.HashH(data)
+	voteData := s.confirmationData()
+	dataHash := voteData.ConfirmationHash()
 	err := validateSingleBriSig(&dataHash, s.Confirmation, ownerPk)
 	return err
 }
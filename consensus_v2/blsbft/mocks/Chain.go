@@ -464,6 +464,36 @@ func (_m *Chain) GetPubKeyCommitteeIndex(_a0 string) int {
 	return r0
 }
 
+// GetRandomBeaconSigningData provides a mock function with given fields: block
+func (_m *Chain) GetRandomBeaconSigningData(block types.BlockInterface) (common.Hash, uint64, bool) {
+	ret := _m.Called(block)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(types.BlockInterface) common.Hash); ok {
+		r0 = rf(block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(types.BlockInterface) uint64); ok {
+		r1 = rf(block)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 bool
+	if rf, ok := ret.Get(2).(func(types.BlockInterface) bool); ok {
+		r2 = rf(block)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
+
 // GetShardID provides a mock function with given fields:
 func (_m *Chain) GetShardID() int {
 	ret := _m.Called()
@@ -13,6 +13,9 @@ type ValidationData struct {
 	AggSig         []byte
 	BridgeSig      [][]byte
 	PortalSig      []*portalprocessv4.PortalSig
+	// random beacon signatures of the RandomBeaconSigners aggregated, see RandomBeaconMessage
+	RandomBeaconSig []byte `json:",omitempty"`
+	RandomBeaconIdx []int  `json:",omitempty"`
 }
 
 func DecodeValidationData(data string) (*ValidationData, error) {
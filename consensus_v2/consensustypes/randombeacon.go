@@ -0,0 +1,56 @@
+package consensustypes
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// randomBeaconDomain separates the random beacon messages from the block hashes signed by the same BLS keys
+const randomBeaconDomain = "incognito-random-beacon"
+
+// randomBeaconFallbackDomain separates the fallback random values from the random beacon messages
+const randomBeaconFallbackDomain = "incognito-random-beacon-fallback"
+
+// RandomBeaconMessage returns the data BLS signed by the beacon committee to produce the random value of an epoch,
+// it only depends on the previous random value and the epoch so the block producer cannot choose it
+func RandomBeaconMessage(prevValue common.Hash, epoch uint64) []byte {
+	data := []byte(randomBeaconDomain)
+	data = append(data, prevValue[:]...)
+	data = append(data, common.Uint64ToBytes(epoch)...)
+	return common.HashB(data)
+}
+
+// RandomBeaconSigners returns the sorted committee indexes whose signatures make the random beacon following
+// prevValue: the smallest set over 2/3 of a committee of committeeSize members, taken in committee order from
+// an offset given by prevValue. BLS signatures are unique and the set is fixed, so the aggregated signature
+// is the same whoever aggregates it
+func RandomBeaconSigners(prevValue common.Hash, committeeSize int) []int {
+	if committeeSize <= 0 {
+		return nil
+	}
+	start := int(binary.BigEndian.Uint64(prevValue[:8]) % uint64(committeeSize))
+	signers := make([]int, 0, committeeSize*2/3+1)
+	for i := 0; i < committeeSize*2/3+1; i++ {
+		signers = append(signers, (start+i)%committeeSize)
+	}
+	sort.Ints(signers)
+	return signers
+}
+
+// RandomBeaconValue returns the random value carried by the aggregated BLS signature of the RandomBeaconSigners
+// over a RandomBeaconMessage
+func RandomBeaconValue(aggSig []byte) common.Hash {
+	return common.HashH(aggSig)
+}
+
+// RandomBeaconFallbackValue returns the random value of an epoch whose random beacon is not produced in time.
+// It is known in advance, withholding signatures or the random beacon instruction only chooses between it and
+// the random beacon
+func RandomBeaconFallbackValue(prevValue common.Hash, epoch uint64) common.Hash {
+	data := []byte(randomBeaconFallbackDomain)
+	data = append(data, prevValue[:]...)
+	data = append(data, common.Uint64ToBytes(epoch)...)
+	return common.HashH(data)
+}
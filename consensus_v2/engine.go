@@ -13,6 +13,7 @@ import (
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
 	"github.com/incognitochain/incognito-chain/pubsub"
@@ -156,11 +157,11 @@ func (engine *Engine) WatchCommitteeChange() {
 			engine.bftProcess[chainID].SetBlockVersion(engine.version[chainID])
 		}
 
-		validatorMiningKey := []signatureschemes2.MiningKey{}
+		validatorSigners := []signer.Signer{}
 		for _, validator := range validators {
-			validatorMiningKey = append(validatorMiningKey, validator.MiningKey)
+			validatorSigners = append(validatorSigners, validator.Signer)
 		}
-		engine.bftProcess[chainID].LoadUserKeys(validatorMiningKey)
+		engine.bftProcess[chainID].LoadUserKeys(validatorSigners)
 		engine.bftProcess[chainID].Start()
 		engine.NotifyNewRole(chainID, common.CommitteeRole)
 		miningProc = engine.bftProcess[chainID]
@@ -228,6 +229,8 @@ func (engine *Engine) Start() error {
 		engine.loadKeysFromPrivateKey()
 	} else if engine.config.Node.GetMiningKeys() != "" {
 		engine.loadKeysFromMiningKey()
+	} else if engine.config.Node.GetRemoteSigner() != "" {
+		engine.loadKeysFromRemoteSigner()
 	}
 	engine.IsEnabled = 1
 	return nil
@@ -243,7 +246,7 @@ func (engine *Engine) loadKeysFromPrivateKey() {
 		panic(err)
 	}
 	engine.validators = []*consensus.Validator{
-		&consensus.Validator{PrivateSeed: privateSeed, MiningKey: *miningKey},
+		&consensus.Validator{PrivateSeed: privateSeed, MiningKey: *miningKey, Signer: signer.NewLocalSigner(miningKey)},
 	}
 }
 
@@ -256,13 +259,39 @@ func (engine *Engine) loadKeysFromMiningKey() {
 			panic(err)
 		}
 		engine.validators = append(engine.validators, &consensus.Validator{
-			PrivateSeed: key, MiningKey: *miningKey,
+			PrivateSeed: key, MiningKey: *miningKey, Signer: signer.NewLocalSigner(miningKey),
 		})
 	}
 	// @NOTICE: hack code, only allow one key
 	engine.validators = engine.validators[:1] //allow only 1 key
+	engine.setMonitorPubKeys()
+}
 
-	//set monitor pubkey
+//loadKeysFromRemoteSigner only keeps the public mining keys, signatures are requested from the signer process
+func (engine *Engine) loadKeysFromRemoteSigner() {
+	signers, err := signer.NewRemoteSigners(engine.config.Node.GetRemoteSigner(), engine.config.Node.GetRemoteSignerTLS())
+	if err != nil {
+		panic(NewConsensusError(LoadKeyError, err))
+	}
+	engine.validators = []*consensus.Validator{}
+	for _, remoteSigner := range signers {
+		publicKey := remoteSigner.GetPublicKey()
+		engine.validators = append(engine.validators, &consensus.Validator{
+			MiningKey: signatureschemes2.MiningKey{
+				PubKey: map[string][]byte{
+					common.BlsConsensus:    publicKey.MiningPubKey[common.BlsConsensus],
+					common.BridgeConsensus: publicKey.MiningPubKey[common.BridgeConsensus],
+				},
+			},
+			Signer: remoteSigner,
+		})
+	}
+	// @NOTICE: hack code, only allow one key
+	engine.validators = engine.validators[:1] //allow only 1 key
+	engine.setMonitorPubKeys()
+}
+
+func (engine *Engine) setMonitorPubKeys() {
 	pubkeys := []string{}
 	for _, val := range engine.validators {
 		pubkeys = append(pubkeys, val.MiningKey.GetPublicKey().GetMiningKeyBase58("bls"))
@@ -290,7 +319,11 @@ func (engine *Engine) OnBFTMsg(msg *wire.MessageBFT) {
 func (engine *Engine) GetAllValidatorKeyState() map[string]consensus.MiningState {
 	result := make(map[string]consensus.MiningState)
 	for _, validator := range engine.validators {
-		result[validator.PrivateSeed] = validator.State
+		key := validator.PrivateSeed
+		if key == "" {
+			key = validator.MiningKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+		}
+		result[key] = validator.State
 	}
 	return result
 }
@@ -312,7 +345,12 @@ func (engine *Engine) getBlockVersion(chainID int) int {
 		chainEpoch = engine.config.Blockchain.ShardChain[chainID].GetEpoch()
 		chainHeight = engine.config.Blockchain.ShardChain[chainID].GetBestView().GetBeaconHeight()
 	}
+	return GetBlockVersion(chainEpoch, chainHeight)
+}
 
+// GetBlockVersion returns the version of the blocks produced on top of a view of chainEpoch and chainHeight,
+// the beacon height for a shard view
+func GetBlockVersion(chainEpoch, chainHeight uint64) int {
 	if chainHeight >= config.Param().ConsensusParam.BlockProducingV3Height {
 		return types.BLOCK_PRODUCINGV3_VERSION
 	}
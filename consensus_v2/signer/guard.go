@@ -0,0 +1,158 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrDoubleSign = errors.New("refuse to double sign")
+
+// SignRecord - the last block signed by a validator on a chain
+type SignRecord struct {
+	Height    uint64
+	Round     int
+	TimeSlot  int64
+	BlockHash string
+}
+
+// DoubleSignGuard refuses to sign a block conflicting with the last signed block of the same validator and chain:
+// an older timeslot, a lower height or another block in the same timeslot.
+// The last signed blocks are persisted before the signature is returned, so a restart can not double sign either.
+type DoubleSignGuard struct {
+	lock     sync.Mutex
+	path     string
+	Votes    map[string]*SignRecord
+	Proposes map[string]*SignRecord
+	// RePropose - the last re-propose hash signed by a proposer, at most one per proposer timeslot
+	RePropose map[string]*SignRecord
+}
+
+// NewDoubleSignGuard loads the last signed blocks from path, an empty path keeps them in memory only
+func NewDoubleSignGuard(path string) (*DoubleSignGuard, error) {
+	guard := &DoubleSignGuard{
+		path:      path,
+		Votes:     make(map[string]*SignRecord),
+		Proposes:  make(map[string]*SignRecord),
+		RePropose: make(map[string]*SignRecord),
+	}
+	if path == "" {
+		return guard, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return guard, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, guard); err != nil {
+		return nil, fmt.Errorf("invalid double sign guard file %v, %v", path, err)
+	}
+	if guard.Votes == nil {
+		guard.Votes = make(map[string]*SignRecord)
+	}
+	if guard.Proposes == nil {
+		guard.Proposes = make(map[string]*SignRecord)
+	}
+	if guard.RePropose == nil {
+		guard.RePropose = make(map[string]*SignRecord)
+	}
+	return guard, nil
+}
+
+func guardKey(validator string, chainID int) string {
+	return fmt.Sprintf("%v-%v", validator, chainID)
+}
+
+// CheckVote records the vote if it does not conflict with the last vote of the validator
+func (guard *DoubleSignGuard) CheckVote(validator string, req *VoteRequest) error {
+	return guard.check(guard.Votes, guardKey(validator, req.Vote.ChainID), &SignRecord{
+		Height:    req.Vote.BlockHeight,
+		Round:     req.Round,
+		TimeSlot:  req.Vote.ProposeTimeSlot,
+		BlockHash: req.Vote.BlockHash,
+	})
+}
+
+// CheckPropose records the proposal if it does not conflict with the last proposal of the validator
+func (guard *DoubleSignGuard) CheckPropose(validator string, req *ProposeRequest) error {
+	return guard.check(guard.Proposes, guardKey(validator, req.ChainID), &SignRecord{
+		Height:    req.BlockHeight,
+		Round:     req.Round,
+		TimeSlot:  req.ProposeTimeSlot,
+		BlockHash: req.BlockHash.String(),
+	})
+}
+
+// CheckRePropose records the re-proposal if it does not conflict with the last re-proposal of the validator
+func (guard *DoubleSignGuard) CheckRePropose(validator string, req *ReProposeRequest) error {
+	return guard.check(guard.RePropose, guardKey(validator, req.ChainID), &SignRecord{
+		Height:    req.BlockHeight,
+		TimeSlot:  req.RePropose.ProposerTimeSlot,
+		BlockHash: req.BlockHash.String(),
+	})
+}
+
+func (guard *DoubleSignGuard) check(records map[string]*SignRecord, key string, record *SignRecord) error {
+	guard.lock.Lock()
+	defer guard.lock.Unlock()
+
+	last, ok := records[key]
+	if ok {
+		if record.TimeSlot == last.TimeSlot && record.BlockHash == last.BlockHash {
+			// signing the same block again
+			return nil
+		}
+		if record.TimeSlot <= last.TimeSlot {
+			return fmt.Errorf("%w, block %v at timeslot %v, last signed block %v at timeslot %v",
+				ErrDoubleSign, record.BlockHash, record.TimeSlot, last.BlockHash, last.TimeSlot)
+		}
+		if record.Height < last.Height {
+			return fmt.Errorf("%w, block %v at height %v, last signed block %v at height %v",
+				ErrDoubleSign, record.BlockHash, record.Height, last.BlockHash, last.Height)
+		}
+	}
+	records[key] = record
+	if err := guard.persist(); err != nil {
+		if ok {
+			records[key] = last
+		} else {
+			delete(records, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// persist writes the records to a temporary file then renames it, the file is never left half written
+func (guard *DoubleSignGuard) persist() error {
+	if guard.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(guard)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(filepath.Dir(guard.path), filepath.Base(guard.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), guard.path)
+}
@@ -0,0 +1,70 @@
+package signer
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDoubleSignGuard_CheckVote(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_guard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "guard.json")
+	guard, err := NewDoubleSignGuard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote := func(height uint64, timeSlot int64, blockHash string) *VoteRequest {
+		return &VoteRequest{Vote: VoteData{ChainID: 1, BlockHeight: height, ProposeTimeSlot: timeSlot, BlockHash: blockHash}}
+	}
+
+	tests := []struct {
+		name    string
+		req     *VoteRequest
+		wantErr bool
+	}{
+		{"first vote", vote(10, 100, "a"), false},
+		{"same vote again", vote(10, 100, "a"), false},
+		{"another block in the same timeslot", vote(10, 100, "b"), true},
+		{"older timeslot", vote(11, 99, "c"), true},
+		{"lower height", vote(9, 101, "d"), true},
+		{"next timeslot", vote(10, 101, "e"), false},
+		{"next height", vote(11, 102, "f"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckVote("validator", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckVote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrDoubleSign) {
+				t.Errorf("CheckVote() error = %v, want ErrDoubleSign", err)
+			}
+		})
+	}
+
+	// another chain or validator is not guarded by these votes
+	if err := guard.CheckVote("validator", &VoteRequest{Vote: VoteData{ChainID: 2, BlockHeight: 1, ProposeTimeSlot: 1, BlockHash: "g"}}); err != nil {
+		t.Errorf("vote on another chain error = %v", err)
+	}
+	if err := guard.CheckVote("other", vote(1, 1, "h")); err != nil {
+		t.Errorf("vote of another validator error = %v", err)
+	}
+
+	// the last votes survive a restart
+	reloaded, err := NewDoubleSignGuard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.CheckVote("validator", vote(11, 102, "i")); !errors.Is(err, ErrDoubleSign) {
+		t.Errorf("vote after restart error = %v, want ErrDoubleSign", err)
+	}
+	if err := reloaded.CheckVote("validator", vote(11, 102, "f")); err != nil {
+		t.Errorf("same vote after restart error = %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+// +build windows plan9
+
+package signer
+
+import (
+	"net"
+	"os"
+)
+
+// listenUnix restricts the socket to its owner once created, there is no umask on this platform
+func listenUnix(addr string) (net.Listener, error) {
+	listener, err := net.Listen("unix", addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(addr, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
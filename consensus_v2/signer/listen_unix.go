@@ -0,0 +1,15 @@
+// +build !windows,!plan9
+
+package signer
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenUnix creates the socket with the 0600 mode, no other user can connect between its creation and a chmod
+func listenUnix(addr string) (net.Listener, error) {
+	umask := syscall.Umask(0177)
+	defer syscall.Umask(umask)
+	return net.Listen("unix", addr)
+}
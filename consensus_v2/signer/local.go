@@ -0,0 +1,118 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
+)
+
+// LocalSigner signs with mining keys held in process memory
+type LocalSigner struct {
+	miningKey *signatureschemes.MiningKey
+	guard     *DoubleSignGuard
+}
+
+func NewLocalSigner(miningKey *signatureschemes.MiningKey) *LocalSigner {
+	return &LocalSigner{miningKey: miningKey}
+}
+
+// NewGuardedLocalSigner returns a local signer refusing to sign conflicting votes and proposals
+func NewGuardedLocalSigner(miningKey *signatureschemes.MiningKey, guard *DoubleSignGuard) *LocalSigner {
+	return &LocalSigner{miningKey: miningKey, guard: guard}
+}
+
+func (s *LocalSigner) GetPublicKey() *incognitokey.CommitteePublicKey {
+	return s.miningKey.GetPublicKey()
+}
+
+func (s *LocalSigner) SignVote(req *VoteRequest) (*VoteSignature, error) {
+	if s.guard != nil {
+		if err := s.guard.CheckVote(s.validator(), req); err != nil {
+			return nil, err
+		}
+	}
+	blockHash, err := common.Hash{}.NewHashFromStr(req.Vote.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	committee := make([]blsmultisig.PublicKey, len(req.Committee))
+	for i, pk := range req.Committee {
+		committee[i] = pk
+	}
+	vote := req.Vote
+	vote.BLS, err = s.miningKey.BLSSignData(blockHash.GetBytes(), req.SelfIdx, committee)
+	if err != nil {
+		return nil, err
+	}
+	vote.BRI = []byte{}
+	if req.BridgeSig {
+		vote.BRI, err = s.miningKey.BriSignData(blockHash.GetBytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+	confirmationHash := vote.ConfirmationHash()
+	confirmation, err := s.miningKey.BriSignData(confirmationHash.GetBytes())
+	if err != nil {
+		return nil, err
+	}
+	return &VoteSignature{
+		BLS:          vote.BLS,
+		BRI:          vote.BRI,
+		Confirmation: confirmation,
+	}, nil
+}
+
+func (s *LocalSigner) SignPropose(req *ProposeRequest) ([]byte, error) {
+	if s.guard != nil {
+		if err := s.guard.CheckPropose(s.validator(), req); err != nil {
+			return nil, err
+		}
+	}
+	return s.miningKey.BriSignData(req.BlockHash.GetBytes())
+}
+
+// SignRePropose refuses to sign the re-propose hash of a block proposed by another validator
+func (s *LocalSigner) SignRePropose(req *ReProposeRequest) ([]byte, error) {
+	proposer := incognitokey.CommitteePublicKey{}
+	if err := proposer.FromString(req.RePropose.Proposer); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(proposer.MiningPubKey[common.BridgeConsensus], s.miningKey.PubKey[common.BridgeConsensus]) {
+		return nil, errors.New("refuse to sign the re-propose hash of another proposer")
+	}
+	if s.guard != nil {
+		if err := s.guard.CheckRePropose(s.validator(), req); err != nil {
+			return nil, err
+		}
+	}
+	hash := req.RePropose.Hash()
+	return s.miningKey.BriSignData(hash.GetBytes())
+}
+
+func (s *LocalSigner) SignPortalExternalTxs(insts [][]string, portalParam portalv4.PortalParams) ([]*portalprocessv4.PortalSig, error) {
+	return portalprocessv4.CheckAndSignPortalUnshieldExternalTx(s.miningKey.PriKey[common.BridgeConsensus], insts, portalParam)
+}
+
+func (s *LocalSigner) SignRandomBeacon(req *RandomBeaconRequest) ([]byte, error) {
+	committee := make([]blsmultisig.PublicKey, len(req.Committee))
+	for i, pk := range req.Committee {
+		committee[i] = pk
+	}
+	return s.miningKey.BLSSignData(consensustypes.RandomBeaconMessage(req.PrevValue, req.Epoch), req.SelfIdx, committee)
+}
+
+func (s *LocalSigner) BriSignData(data []byte) ([]byte, error) {
+	return s.miningKey.BriSignData(data)
+}
+
+func (s *LocalSigner) validator() string {
+	return s.miningKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+}
@@ -0,0 +1,160 @@
+package signer
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
+)
+
+// RemoteSigner asks a signer process (see remotesigner) for the signatures of one validator
+type RemoteSigner struct {
+	conn      *connection
+	publicKey *incognitokey.CommitteePublicKey
+	validator string
+}
+
+// connection redials the signer process after it restarts
+type connection struct {
+	lock      sync.Mutex
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	client    *rpc.Client
+}
+
+func (conn *connection) dial() (*rpc.Client, error) {
+	if conn.tlsConfig == nil {
+		return rpc.Dial(conn.network, conn.address)
+	}
+	tlsConn, err := tls.Dial(conn.network, conn.address, conn.tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return rpc.NewClient(tlsConn), nil
+}
+
+func (conn *connection) call(method string, args interface{}, reply interface{}) error {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+	if conn.client == nil {
+		client, err := conn.dial()
+		if err != nil {
+			return err
+		}
+		conn.client = client
+	}
+	err := conn.client.Call(serviceName+"."+method, args, reply)
+	if err == rpc.ErrShutdown {
+		conn.client.Close()
+		conn.client = nil
+	}
+	return err
+}
+
+// NewRemoteSigners connects to the signer process at address and returns a signer for each key it holds,
+// the node and the signer authenticate each other with tlsFiles over tcp
+func NewRemoteSigners(address string, tlsFiles TLSFiles) ([]*RemoteSigner, error) {
+	network, addr, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	conn := &connection{network: network, address: addr}
+	if network == "tcp" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		conn.tlsConfig, err = tlsFiles.clientConfig(host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keys := []string{}
+	if err := conn.call("GetPublicKeys", struct{}{}, &keys); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("signer does not hold any mining key")
+	}
+	signers := []*RemoteSigner{}
+	for _, key := range keys {
+		publicKey := new(incognitokey.CommitteePublicKey)
+		if err := publicKey.FromBase58(key); err != nil {
+			return nil, err
+		}
+		signers = append(signers, &RemoteSigner{
+			conn:      conn,
+			publicKey: publicKey,
+			validator: publicKey.GetMiningKeyBase58(common.BlsConsensus),
+		})
+	}
+	return signers, nil
+}
+
+func (s *RemoteSigner) GetPublicKey() *incognitokey.CommitteePublicKey {
+	return s.publicKey
+}
+
+func (s *RemoteSigner) SignVote(req *VoteRequest) (*VoteSignature, error) {
+	sig := new(VoteSignature)
+	if err := s.conn.call("SignVote", SignVoteArgs{PublicKey: s.validator, Request: *req}, sig); err != nil {
+		return nil, err
+	}
+	// gob does not tell an empty slice from nil
+	if sig.BRI == nil {
+		sig.BRI = []byte{}
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) SignPropose(req *ProposeRequest) ([]byte, error) {
+	sig := []byte{}
+	if err := s.conn.call("SignPropose", SignProposeArgs{PublicKey: s.validator, Request: *req}, &sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) SignRePropose(req *ReProposeRequest) ([]byte, error) {
+	sig := []byte{}
+	if err := s.conn.call("SignRePropose", SignReProposeArgs{PublicKey: s.validator, Request: *req}, &sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignPortalExternalTxs only asks the signer when the block has portal external txs,
+// the signer signs them with its own portal params so portalParam is not sent
+func (s *RemoteSigner) SignPortalExternalTxs(insts [][]string, portalParam portalv4.PortalParams) ([]*portalprocessv4.PortalSig, error) {
+	if !portalprocessv4.HasPortalUnshieldExternalTx(insts) {
+		return nil, nil
+	}
+	sigs := []*portalprocessv4.PortalSig{}
+	if err := s.conn.call("SignPortalExternalTxs", SignPortalExternalTxsArgs{PublicKey: s.validator, Insts: insts}, &sigs); err != nil {
+		return nil, err
+	}
+	return sigs, nil
+}
+
+func (s *RemoteSigner) SignRandomBeacon(req *RandomBeaconRequest) ([]byte, error) {
+	sig := []byte{}
+	if err := s.conn.call("SignRandomBeacon", SignRandomBeaconArgs{PublicKey: s.validator, Request: *req}, &sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) BriSignData(data []byte) ([]byte, error) {
+	sig := []byte{}
+	if err := s.conn.call("BriSignData", SignDataArgs{PublicKey: s.validator, Data: data}, &sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
@@ -0,0 +1,228 @@
+package signer
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
+)
+
+const serviceName = "Signer"
+
+// SignVoteArgs - args of Signer.SignVote, PublicKey is the BLS mining key (base58) of the validator
+type SignVoteArgs struct {
+	PublicKey string
+	Request   VoteRequest
+}
+
+// SignProposeArgs - args of Signer.SignPropose
+type SignProposeArgs struct {
+	PublicKey string
+	Request   ProposeRequest
+}
+
+// SignReProposeArgs - args of Signer.SignRePropose
+type SignReProposeArgs struct {
+	PublicKey string
+	Request   ReProposeRequest
+}
+
+// SignRandomBeaconArgs - args of Signer.SignRandomBeacon
+type SignRandomBeaconArgs struct {
+	PublicKey string
+	Request   RandomBeaconRequest
+}
+
+// SignPortalExternalTxsArgs - args of Signer.SignPortalExternalTxs, the signer uses its own portal params
+type SignPortalExternalTxsArgs struct {
+	PublicKey string
+	Insts     [][]string
+}
+
+// SignDataArgs - args of Signer.BriSignData
+type SignDataArgs struct {
+	PublicKey string
+	Data      []byte
+}
+
+// Server serves the signatures of the mining keys it holds to validators over net/rpc,
+// every vote and proposal goes through the double sign guard
+type Server struct {
+	signers     map[string]*LocalSigner
+	portalParam portalv4.PortalParams
+	rpc         *rpc.Server
+}
+
+// NewServer - portalParam are the portal v4 params of the network the portal external txs are signed for,
+// a validator cannot make the signer sign for other multisig keys or tokens
+func NewServer(miningKeys []*signatureschemes.MiningKey, guard *DoubleSignGuard, portalParam portalv4.PortalParams) (*Server, error) {
+	server := &Server{
+		signers:     make(map[string]*LocalSigner),
+		portalParam: portalParam,
+		rpc:         rpc.NewServer(),
+	}
+	for _, miningKey := range miningKeys {
+		localSigner := NewGuardedLocalSigner(miningKey, guard)
+		server.signers[localSigner.validator()] = localSigner
+	}
+	if err := server.rpc.RegisterName(serviceName, &handler{server: server}); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// Serve accepts connections until the listener is closed
+func (server *Server) Serve(listener net.Listener) {
+	server.rpc.Accept(listener)
+}
+
+func (server *Server) getSigner(publicKey string) (*LocalSigner, error) {
+	localSigner, ok := server.signers[publicKey]
+	if !ok {
+		return nil, fmt.Errorf("signer does not hold key %v", publicKey)
+	}
+	return localSigner, nil
+}
+
+type handler struct {
+	server *Server
+}
+
+// GetPublicKeys returns the committee public keys (base58) of the held mining keys
+func (h *handler) GetPublicKeys(args struct{}, reply *[]string) error {
+	keys := []string{}
+	for _, localSigner := range h.server.signers {
+		key, err := localSigner.GetPublicKey().ToBase58()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	*reply = keys
+	return nil
+}
+
+func (h *handler) SignVote(args SignVoteArgs, reply *VoteSignature) error {
+	localSigner, err := h.server.getSigner(args.PublicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := localSigner.SignVote(&args.Request)
+	if err != nil {
+		return err
+	}
+	*reply = *sig
+	return nil
+}
+
+func (h *handler) SignPropose(args SignProposeArgs, reply *[]byte) error {
+	localSigner, err := h.server.getSigner(args.PublicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := localSigner.SignPropose(&args.Request)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+func (h *handler) SignRePropose(args SignReProposeArgs, reply *[]byte) error {
+	localSigner, err := h.server.getSigner(args.PublicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := localSigner.SignRePropose(&args.Request)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+func (h *handler) SignRandomBeacon(args SignRandomBeaconArgs, reply *[]byte) error {
+	localSigner, err := h.server.getSigner(args.PublicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := localSigner.SignRandomBeacon(&args.Request)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+func (h *handler) SignPortalExternalTxs(args SignPortalExternalTxsArgs, reply *[]*portalprocessv4.PortalSig) error {
+	localSigner, err := h.server.getSigner(args.PublicKey)
+	if err != nil {
+		return err
+	}
+	sigs, err := localSigner.SignPortalExternalTxs(args.Insts, h.server.portalParam)
+	if err != nil {
+		return err
+	}
+	*reply = sigs
+	return nil
+}
+
+// BriSignData refuses hash sized data, block hashes and vote confirmations must go through the guard
+func (h *handler) BriSignData(args SignDataArgs, reply *[]byte) error {
+	localSigner, err := h.server.getSigner(args.PublicKey)
+	if err != nil {
+		return err
+	}
+	if len(args.Data) == common.HashSize {
+		return errors.New("refuse to sign hash sized data, use SignVote or SignPropose")
+	}
+	sig, err := localSigner.BriSignData(args.Data)
+	if err != nil {
+		return err
+	}
+	*reply = sig
+	return nil
+}
+
+// ParseAddress splits a signer address "unix:///path/to/socket" or "tcp://host:port" into network and address
+func ParseAddress(address string) (string, string, error) {
+	for _, network := range []string{"unix", "tcp"} {
+		prefix := network + "://"
+		if strings.HasPrefix(address, prefix) {
+			return network, strings.TrimPrefix(address, prefix), nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid signer address %v, expect unix:///path/to/socket or tcp://host:port", address)
+}
+
+// Listen listens on a signer address. Only the owner of the signer process can connect to a unix socket,
+// a stale one is removed first. A tcp listener only accepts the validators authenticated by tlsFiles
+func Listen(address string, tlsFiles TLSFiles) (net.Listener, error) {
+	network, addr, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" {
+		if err := os.Remove(addr); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return listenUnix(addr)
+	}
+	tlsConfig, err := tlsFiles.serverConfig()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewListener(listener, tlsConfig), nil
+}
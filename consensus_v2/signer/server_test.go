@@ -0,0 +1,343 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/incognitochain/incognito-chain/portal"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portaltokensv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
+)
+
+func newTestMiningKey(seed []byte) *signatureschemes.MiningKey {
+	blsPriKey, blsPubKey := blsmultisig.KeyGen(seed)
+	bridgePriKey, bridgePubKey := bridgesig.KeyGen(seed)
+	return &signatureschemes.MiningKey{
+		PriKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.SKBytes(blsPriKey),
+			common.BridgeConsensus: bridgesig.SKBytes(&bridgePriKey),
+		},
+		PubKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.PKBytes(blsPubKey),
+			common.BridgeConsensus: bridgesig.PKBytes(&bridgePubKey),
+		},
+	}
+}
+
+func newTestPortalParam(t *testing.T) portalv4.PortalParams {
+	portalParams, err := portal.GetNetworkPortalParams(config.LocalNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return portalParams.GetPortalParamsV4(0)
+}
+
+// newTestPortalInst returns an unshield batching instruction spending one vault UTXO of the token
+func newTestPortalInst(t *testing.T, portalParam portalv4.PortalParams, tokenID string) []string {
+	processor := portalParam.PortalTokens[tokenID]
+	_, address, err := processor.GenerateOTMultisigAddress(portalParam.MasterPubKeys[tokenID], int(portalParam.NumRequiredSigs), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utxo := statedb.NewUTXOWithValue(address, "2e9a0e3e4a4ed5a2d4dd81c7a0b2cc4b1bf34e7c5a5e9b0b11e8c2dd6e62b0a1", 1, 100000000, "")
+	outputs := []*portaltokensv4.OutputTx{{ReceiverAddress: address, Amount: processor.ConvertExternalToIncAmount(100000000)}}
+	hexRawTx, _, err := processor.CreateRawExternalTx([]*statedb.UTXO{utxo}, outputs, 10000, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(metadata.PortalUnshieldRequestBatchContent{
+		RawExternalTx: hexRawTx,
+		TokenID:       tokenID,
+		UTXOs:         []*statedb.UTXO{utxo},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []string{strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta), "-1", "accepted", string(content)}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config.AbortParam()
+	miningKey := newTestMiningKey([]byte("remote signer test seed"))
+	portalParam := newTestPortalParam(t)
+	server, err := NewServer([]*signatureschemes.MiningKey{miningKey}, nil, portalParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(dir, "signer.sock")
+	address := "unix://" + socket
+	listener, err := Listen(address, TLSFiles{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go server.Serve(listener)
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("got socket mode %v, want 0600", info.Mode().Perm())
+	}
+
+	signers, err := NewRemoteSigners(address, TLSFiles{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 {
+		t.Fatalf("got %v signers, want 1", len(signers))
+	}
+	remoteSigner := signers[0]
+	validator := miningKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	if remoteSigner.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus) != validator {
+		t.Fatal("remote signer does not hold the mining key")
+	}
+
+	blockHash := common.HashH([]byte("block"))
+	req := &VoteRequest{
+		Vote: VoteData{
+			BlockHash:       blockHash.String(),
+			BlockHeight:     10,
+			Validator:       validator,
+			ProposeTimeSlot: 100,
+		},
+		Committee: [][]byte{miningKey.PubKey[common.BlsConsensus]},
+	}
+	remoteSig, err := remoteSigner.SignVote(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localSig, err := NewLocalSigner(miningKey).SignVote(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(remoteSig.BLS) != string(localSig.BLS) {
+		t.Error("remote BLS signature differs from local one")
+	}
+	voteData := req.Vote
+	voteData.BLS = remoteSig.BLS
+	voteData.BRI = remoteSig.BRI
+	confirmationHash := voteData.ConfirmationHash()
+	ok, err := bridgesig.Verify(miningKey.PubKey[common.BridgeConsensus], confirmationHash.GetBytes(), remoteSig.Confirmation)
+	if err != nil || !ok {
+		t.Errorf("invalid vote confirmation, %v", err)
+	}
+
+	randomBeaconReq := &RandomBeaconRequest{
+		PrevValue: common.HashH([]byte("prev")),
+		Epoch:     3,
+		Committee: req.Committee,
+	}
+	randomBeaconSig, err := remoteSigner.SignRandomBeacon(randomBeaconReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err = blsmultisig.Verify(randomBeaconSig, consensustypes.RandomBeaconMessage(randomBeaconReq.PrevValue, 3), []int{0}, []blsmultisig.PublicKey{req.Committee[0]})
+	if err != nil || !ok {
+		t.Errorf("invalid random beacon signature, %v", err)
+	}
+
+	proposer, err := miningKey.GetPublicKey().ToBase58()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reProposeReq := &ReProposeRequest{
+		BlockHeight: 10,
+		BlockHash:   blockHash,
+		RePropose: ReProposeData{
+			PreviousBlockHash: common.HashH([]byte("prev block")),
+			Proposer:          proposer,
+			ProposerTimeSlot:  100,
+		},
+	}
+	reProposeSig, err := remoteSigner.SignRePropose(reProposeReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reProposeHash := reProposeReq.RePropose.Hash()
+	ok, err = bridgesig.Verify(miningKey.PubKey[common.BridgeConsensus], reProposeHash.GetBytes(), reProposeSig)
+	if err != nil || !ok {
+		t.Errorf("invalid re-propose hash signature, %v", err)
+	}
+	otherProposer, err := newTestMiningKey([]byte("other proposer seed")).GetPublicKey().ToBase58()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reProposeReq.RePropose.Proposer = otherProposer
+	if _, err := remoteSigner.SignRePropose(reProposeReq); err == nil {
+		t.Error("remote signer signs the re-propose hash of another proposer")
+	}
+
+	// the signer signs the portal external txs with its own portal params
+	insts := [][]string{newTestPortalInst(t, portalParam, portal.LocalPortalV4BTCID)}
+	remotePortalSigs, err := remoteSigner.SignPortalExternalTxs(insts, portalv4.PortalParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	localPortalSigs, err := NewLocalSigner(miningKey).SignPortalExternalTxs(insts, portalParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remotePortalSigs) != 1 || !reflect.DeepEqual(remotePortalSigs, localPortalSigs) {
+		t.Error("remote portal signatures differ from local ones")
+	}
+	if sigs, err := remoteSigner.SignPortalExternalTxs([][]string{{"1"}}, portalParam); err != nil || sigs != nil {
+		t.Errorf("got portal signatures %v %v without portal external tx", sigs, err)
+	}
+
+	// data of hash size could forge a confirmation or a producer signature
+	if _, err := remoteSigner.BriSignData(blockHash.GetBytes()); err == nil {
+		t.Error("remote signer signs hash sized data")
+	}
+	if _, err := remoteSigner.BriSignData([]byte("peer id")); err != nil {
+		t.Error(err)
+	}
+}
+
+// writeTestCert writes a PEM certificate and key for 127.0.0.1 signed by parent, a self signed CA when parent is nil
+func writeTestCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := ioutil.WriteFile(filepath.Join(dir, name+".crt"), certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, name+".key"), keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestRemoteSignerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_signer_tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config.AbortParam()
+	ca, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "signer", ca, caKey)
+	writeTestCert(t, dir, "node", ca, caKey)
+	otherCA, otherCAKey := writeTestCert(t, dir, "other-ca", nil, nil)
+	writeTestCert(t, dir, "other-node", otherCA, otherCAKey)
+	tlsFiles := func(name, caName string) TLSFiles {
+		return TLSFiles{
+			Cert: filepath.Join(dir, name+".crt"),
+			Key:  filepath.Join(dir, name+".key"),
+			CA:   filepath.Join(dir, caName+".crt"),
+		}
+	}
+
+	miningKey := newTestMiningKey([]byte("remote signer tls test seed"))
+	server, err := NewServer([]*signatureschemes.MiningKey{miningKey}, nil, newTestPortalParam(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a tcp signer is never served without authentication
+	if _, err := Listen("tcp://127.0.0.1:0", TLSFiles{}); err != ErrTLSRequired {
+		t.Fatalf("got %v, want %v", err, ErrTLSRequired)
+	}
+	listener, err := Listen("tcp://127.0.0.1:0", tlsFiles("signer", "ca"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go server.Serve(listener)
+	address := "tcp://" + listener.Addr().String()
+
+	if _, err := NewRemoteSigners(address, TLSFiles{}); err != ErrTLSRequired {
+		t.Errorf("got %v, want %v", err, ErrTLSRequired)
+	}
+	// a node whose certificate is not signed by the CA of the signer
+	if _, err := NewRemoteSigners(address, tlsFiles("other-node", "ca")); err == nil {
+		t.Error("signer serves a node of another CA")
+	}
+	// a signer whose certificate is not signed by the CA of the node
+	if _, err := NewRemoteSigners(address, tlsFiles("node", "other-ca")); err == nil {
+		t.Error("node trusts a signer of another CA")
+	}
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []string{}
+	if err := client.Call(serviceName+".GetPublicKeys", struct{}{}, &keys); err == nil {
+		t.Error("signer serves a plain tcp connection")
+	}
+	client.Close()
+
+	signers, err := NewRemoteSigners(address, tlsFiles("node", "ca"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 {
+		t.Fatalf("got %v signers, want 1", len(signers))
+	}
+	data := []byte("peer id")
+	sig, err := signers[0].BriSignData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := bridgesig.Verify(miningKey.PubKey[common.BridgeConsensus], data, sig)
+	if err != nil || !ok {
+		t.Errorf("invalid bridge signature, %v", err)
+	}
+}
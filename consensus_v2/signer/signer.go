@@ -0,0 +1,122 @@
+package signer
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
+)
+
+// Signer signs consensus messages with the mining keys (BLS and bridge) of one validator,
+// the blsbft actors only know the public keys and ask the signer for every signature
+type Signer interface {
+	// GetPublicKey - public mining keys of the validator
+	GetPublicKey() *incognitokey.CommitteePublicKey
+	// SignVote - BLS sign the voted block (and bridge sign it when it has bridge instructions), then confirm the vote
+	SignVote(req *VoteRequest) (*VoteSignature, error)
+	// SignPropose - bridge sign the hash of a block proposed by the validator
+	SignPropose(req *ProposeRequest) ([]byte, error)
+	// SignRePropose - bridge sign the re-propose hash of a block proposed by the validator
+	SignRePropose(req *ReProposeRequest) ([]byte, error)
+	// SignPortalExternalTxs - part sign the external txs of the portal v4 instructions of a block
+	SignPortalExternalTxs(insts [][]string, portalParam portalv4.PortalParams) ([]*portalprocessv4.PortalSig, error)
+	// SignRandomBeacon - BLS sign the random beacon message of an epoch
+	SignRandomBeacon(req *RandomBeaconRequest) ([]byte, error)
+	// BriSignData - bridge sign arbitrary data, e.g. to authenticate the node to its peers
+	BriSignData(data []byte) ([]byte, error)
+}
+
+// VoteData - the fields of a vote covered by its confirmation (blsbft.BFTVote)
+type VoteData struct {
+	PrevBlockHash      string
+	BlockHeight        uint64
+	BlockHash          string
+	Validator          string
+	BLS                []byte
+	BRI                []byte
+	ProduceTimeSlot    int64
+	ProposeTimeSlot    int64
+	CommitteeFromBlock common.Hash
+	ChainID            int
+}
+
+// ConfirmationHash returns the hash signed by the bridge key to confirm the vote,
+// votes below ByzantineDetectorHeight do not confirm their height and timeslots
+func (v *VoteData) ConfirmationHash() common.Hash {
+	data := []byte{}
+	data = append(data, v.BlockHash...)
+	data = append(data, v.BLS...)
+	data = append(data, v.BRI...)
+	if v.BlockHeight >= config.Param().ConsensusParam.ByzantineDetectorHeight {
+		data = append(data, common.Uint64ToBytes(v.BlockHeight)...)
+		data = append(data, common.Int64ToBytes(v.ProduceTimeSlot)...)
+		data = append(data, common.Int64ToBytes(v.ProposeTimeSlot)...)
+		data = append(data, []byte(v.Validator)...)
+		data = append(data, []byte(v.PrevBlockHash)...)
+		data = append(data, v.CommitteeFromBlock[:]...)
+		data = append(data, common.Int64ToBytes(int64(v.ChainID))...)
+	}
+	return common.HashH(data)
+}
+
+// VoteRequest - the vote to sign, SelfIdx is the index of the validator in the BLS public keys of the signing committee
+type VoteRequest struct {
+	Vote      VoteData
+	Round     int
+	SelfIdx   int
+	Committee [][]byte
+	BridgeSig bool
+}
+
+// VoteSignature - the signatures of a vote
+type VoteSignature struct {
+	BLS          []byte
+	BRI          []byte
+	Confirmation []byte
+}
+
+// ProposeRequest - the block to sign as producer or proposer
+type ProposeRequest struct {
+	ChainID         int
+	BlockHeight     uint64
+	BlockHash       common.Hash
+	Round           int
+	ProposeTimeSlot int64
+}
+
+// ReProposeData - the fields of a block covered by the re-propose hash signature (blsbft.ReProposeBlockInfo)
+type ReProposeData struct {
+	PreviousBlockHash common.Hash
+	Producer          string
+	ProducerTimeSlot  int64
+	Proposer          string
+	ProposerTimeSlot  int64
+	RootHash          common.Hash
+}
+
+// Hash returns the re-propose hash signed by the bridge key of the proposer
+func (r ReProposeData) Hash() common.Hash {
+	data, _ := json.Marshal(&r)
+	return common.HashH(data)
+}
+
+// ReProposeRequest - the block to sign as proposer, the signer hashes the re-propose data itself
+// so a request cannot make it sign a block hash or a vote confirmation
+type ReProposeRequest struct {
+	ChainID     int
+	BlockHeight uint64
+	BlockHash   common.Hash
+	RePropose   ReProposeData
+}
+
+// RandomBeaconRequest - the random beacon share to sign, the signer builds the message itself
+// (consensustypes.RandomBeaconMessage) so a request cannot make it sign a block hash
+type RandomBeaconRequest struct {
+	PrevValue common.Hash
+	Epoch     uint64
+	SelfIdx   int
+	Committee [][]byte
+}
@@ -0,0 +1,66 @@
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+var ErrTLSRequired = errors.New("a tcp signer address requires a tls cert, key and ca")
+
+// TLSFiles - PEM files authenticating a signer connection over tcp: the certificate and key of this end
+// and the CA certificate the other end must be signed by
+type TLSFiles struct {
+	Cert string
+	Key  string
+	CA   string
+}
+
+func (files TLSFiles) load() (tls.Certificate, *x509.CertPool, error) {
+	if files.Cert == "" || files.Key == "" || files.CA == "" {
+		return tls.Certificate{}, nil, ErrTLSRequired
+	}
+	cert, err := tls.LoadX509KeyPair(files.Cert, files.Key)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	caPEM, err := ioutil.ReadFile(files.CA)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificate found in %v", files.CA)
+	}
+	return cert, pool, nil
+}
+
+// serverConfig only accepts the clients presenting a certificate signed by the CA
+func (files TLSFiles) serverConfig() (*tls.Config, error) {
+	cert, pool, err := files.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// clientConfig only accepts a signer presenting a certificate for serverName signed by the CA
+func (files TLSFiles) clientConfig(serverName string) (*tls.Config, error) {
+	cert, pool, err := files.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -1,86 +1,533 @@
-package main
+// +build go1.25
+
+package simulation
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
 )
 
+const simulationEpoch = uint64(1)
+
+// Chain is the shard chain of a simulated node, it implements blsbft.Chain with empty blocks on top of a real
+// multiview.MultiView. Each block moves the beacon check point by one so the block version follows the shard height
 type Chain struct {
-	multiview *multiview.MultiView
-	chainID   int
-	chainName string
+	shardID   int
+	committee []incognitokey.CommitteePublicKey
+	multiView *multiview.MultiView
+	// blocks keeps every inserted block, including the ones pruned from the multiview.
+	// A node and its twin share them and run their actors concurrently, blocksLock guards them
+	blocks     map[common.Hash]*types.ShardBlock
+	blocksLock *sync.RWMutex
+	db         incdb.Database
+	ready      bool
+	// txRoot is put in the header of the created blocks, a twin chain uses another one to create conflicting blocks
+	txRoot common.Hash
+	// broadcast sends a block inserted with InsertAndBroadcastBlock to the other nodes
+	broadcast func(block *types.ShardBlock, previousValidationData string)
 }
 
-func NewChain(chainID int, chainName string, committee []incognitokey.CommitteePublicKey) *Chain {
-	c := new(Chain)
-	c.chainID = chainID
-	c.chainName = chainName
-	c.multiview = multiview.NewMultiView()
-	state := &State{
-		NewBlock(1, 1, "Genesis", common.Hash{}),
-		committee,
+// NewChain creates a shard chain starting from genesis, destroy must be called once it is not used anymore
+func NewChain(
+	shardID int,
+	committee []incognitokey.CommitteePublicKey,
+	genesis *types.ShardBlock,
+	db incdb.Database,
+) *Chain {
+	c := &Chain{
+		shardID:    shardID,
+		committee:  committee,
+		multiView:  multiview.NewMultiView(),
+		blocks:     make(map[common.Hash]*types.ShardBlock),
+		blocksLock: new(sync.RWMutex),
+		db:         db,
+		ready:      true,
 	}
-	c.multiview.AddView(state)
+	genesis = copyBlock(genesis)
+	c.blocks[*genesis.Hash()] = genesis
+	c.multiView.AddView(NewView(genesis, committee))
 	return c
 }
 
+// destroy stops the goroutine of the multiview, the blocks and the final and best views can still be read
+func (c *Chain) destroy() {
+	c.multiView.Destroy()
+}
+
+// NewGenesisBlock returns the first block of the simulated shard, proposed at genesisTime
+func NewGenesisBlock(shardID int, genesisTime int64) *types.ShardBlock {
+	block := types.NewShardBlock()
+	block.Header = types.ShardHeader{
+		ShardID:       byte(shardID),
+		Version:       consensus_v2.GetBlockVersion(simulationEpoch, 0),
+		Height:        1,
+		Epoch:         simulationEpoch,
+		BeaconHeight:  1,
+		ConsensusType: common.BlsConsensus,
+		Timestamp:     genesisTime,
+		ProposeTime:   genesisTime,
+	}
+	return block
+}
+
+// twin returns a chain sharing the views of c, the blocks it creates conflict with the ones of c
+func (c *Chain) twin(broadcast func(*types.ShardBlock, string)) *Chain {
+	t := *c
+	t.txRoot = common.HashH([]byte("twin"))
+	t.broadcast = broadcast
+	return &t
+}
+
+func (c *Chain) BestViewCommitteeFromBlock() common.Hash {
+	return c.GetBestView().GetBlock().CommitteeFromBlock()
+}
+
+func (c *Chain) GetMultiView() *multiview.MultiView {
+	return c.multiView
+}
+
 func (c *Chain) GetFinalView() multiview.View {
-	return c.multiview.GetFinalView()
+	return c.multiView.GetFinalView()
 }
 
 func (c *Chain) GetBestView() multiview.View {
-	return c.multiview.GetBestView()
+	return c.multiView.GetBestView()
+}
+
+func (c *Chain) GetEpoch() uint64 {
+	return simulationEpoch
 }
 
 func (c *Chain) GetChainName() string {
-	return c.chainName
+	return common.GetShardChainKey(byte(c.shardID))
+}
+
+func (c *Chain) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+func (c *Chain) GetLastBlockTimeStamp() int64 {
+	return c.GetBestView().GetBlock().GetProduceTime()
+}
+
+func (c *Chain) GetMinBlkInterval() time.Duration {
+	return time.Duration(common.TIMESLOT) * time.Second
+}
+
+func (c *Chain) GetMaxBlkCreateTime() time.Duration {
+	return time.Duration(common.TIMESLOT) * time.Second / 2
+}
+
+func (c *Chain) IsReady() bool {
+	return c.ready
+}
+
+func (c *Chain) SetReady(ready bool) {
+	c.ready = ready
+}
+
+func (c *Chain) GetActiveShardNumber() int {
+	return 1
+}
+
+func (c *Chain) CurrentHeight() uint64 {
+	return c.GetBestViewHeight()
+}
+
+func (c *Chain) GetCommitteeSize() int {
+	return len(c.committee)
 }
 
-func (s *Chain) IsReady() bool {
-	return true
+func (c *Chain) IsBeaconChain() bool {
+	return false
 }
 
-func (s *Chain) UnmarshalBlock(blockString []byte) (common.BlockInterface, error) {
-	blk := &blockchain.ShardBlock{}
-	json.Unmarshal(blockString, blk)
-	return blk, nil
+func (c *Chain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return c.committee
 }
 
-func (c *Chain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
-	newBlock := NewBlock(c.GetBestView().GetHeight()+1, time.Now().Unix(), proposer, *c.GetBestView().GetHash())
+func (c *Chain) GetPendingCommittee() []incognitokey.CommitteePublicKey {
+	return []incognitokey.CommitteePublicKey{}
+}
+
+func (c *Chain) GetPubKeyCommitteeIndex(pubKey string) int {
+	for i, v := range c.committee {
+		if key, _ := v.ToBase58(); key == pubKey {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Chain) GetLastProposerIndex() int {
+	return c.GetPubKeyCommitteeIndex(c.GetBestView().GetBlock().GetProposer())
+}
+
+func (c *Chain) UnmarshalBlock(blockString []byte) (types.BlockInterface, error) {
+	var shardBlk types.ShardBlock
+	err := json.Unmarshal(blockString, &shardBlk)
+	if err != nil {
+		return nil, err
+	}
+	return &shardBlk, nil
+}
+
+// CreateNewBlock creates an empty block on top of the best view, the header follows ShardChain.CreateNewBlock
+func (c *Chain) CreateNewBlock(
+	version int,
+	proposer string,
+	round int,
+	startTime int64,
+	committees []incognitokey.CommitteePublicKey,
+	hash common.Hash,
+) (types.BlockInterface, error) {
+	bestView := c.GetBestView()
+	previousBlock, ok := bestView.GetBlock().(*types.ShardBlock)
+	if !ok {
+		return nil, errors.New("best view is not a shard view")
+	}
+	newBlock := types.NewShardBlock()
+	newBlock.Header = types.ShardHeader{
+		Producer:           proposer,
+		ProducerPubKeyStr:  proposer,
+		ShardID:            byte(c.shardID),
+		Version:            version,
+		PreviousBlockHash:  *bestView.GetHash(),
+		Height:             bestView.GetHeight() + 1,
+		Round:              round,
+		Epoch:              simulationEpoch,
+		BeaconHeight:       previousBlock.Header.BeaconHeight + 1,
+		BeaconHash:         common.HashH([]byte(fmt.Sprintf("simulation beacon %d", previousBlock.Header.BeaconHeight+1))),
+		ConsensusType:      common.BlsConsensus,
+		Timestamp:          startTime,
+		TxRoot:             c.txRoot,
+		CommitteeRoot:      c.committeeRoot(),
+		CommitteeFromBlock: hash,
+	}
+	if version >= types.MULTI_VIEW_VERSION {
+		newBlock.Header.Proposer = proposer
+		newBlock.Header.ProposeTime = startTime
+	}
+
+	if version >= types.LEMMA2_VERSION {
+		previousProposeTimeSlot := common.CalculateTimeSlot(previousBlock.GetProposeTime())
+		currentTimeSlot := common.CalculateTimeSlot(newBlock.Header.ProposeTime)
+
+		if newBlock.Header.Timestamp == newBlock.Header.ProposeTime &&
+			newBlock.Header.Producer == newBlock.Header.Proposer &&
+			previousProposeTimeSlot+1 == currentTimeSlot {
+			newBlock.Header.FinalityHeight = newBlock.Header.Height - 1
+		} else {
+			newBlock.Header.FinalityHeight = 0
+		}
+	}
 	return newBlock, nil
 }
 
-func (c *Chain) CreateNewBlockFromOldBlock(oldBlock common.BlockInterface, proposer string, startTime int64) (common.BlockInterface, error) {
-	//TODO: must using the old block data, and timestamp
-	oldBlock.(*blockchain.ShardBlock).Header.Proposer = proposer
-	oldBlock.(*blockchain.ShardBlock).Header.ProposeTime = time.Now().Unix()
-	// newBlock := NewBlock(c.GetBestView().GetHeight()+1, oldBlock.GetProduceTime(), proposer, *c.GetBestView().GetHash())
-	return oldBlock, nil
+func (c *Chain) CreateNewBlockFromOldBlock(
+	oldBlock types.BlockInterface,
+	proposer string,
+	startTime int64,
+	isValidRePropose bool,
+) (types.BlockInterface, error) {
+	b, _ := json.Marshal(oldBlock)
+	newBlock := new(types.ShardBlock)
+	json.Unmarshal(b, &newBlock)
+
+	newBlock.Header.Proposer = proposer
+	newBlock.Header.ProposeTime = startTime
+	if newBlock.Header.Version >= types.LEMMA2_VERSION {
+		if isValidRePropose {
+			newBlock.Header.FinalityHeight = newBlock.Header.Height - 1
+		} else {
+			newBlock.Header.FinalityHeight = 0
+		}
+	}
+	return newBlock, nil
+}
+
+// InsertBlock adds the view of block, the block must extend a view of the multiview
+func (c *Chain) InsertBlock(block types.BlockInterface, shouldValidate bool) error {
+	shardBlock, ok := block.(*types.ShardBlock)
+	if !ok {
+		return fmt.Errorf("block %v is not a shard block", block.Hash().String())
+	}
+	if c.hasBlock(*block.Hash()) {
+		return nil
+	}
+	if shouldValidate {
+		if err := c.ValidateBlockSignatures(block, c.committee); err != nil {
+			return err
+		}
+	}
+	if c.multiView.GetViewByHash(block.GetPrevHash()) == nil {
+		return fmt.Errorf("previous view %v of block %v height %v not found",
+			block.GetPrevHash().String(), block.Hash().String(), block.GetHeight())
+	}
+	shardBlock = copyBlock(shardBlock)
+	c.blocksLock.Lock()
+	c.blocks[*shardBlock.Hash()] = shardBlock
+	c.blocksLock.Unlock()
+	if !c.multiView.AddView(NewView(shardBlock, c.committee)) {
+		return fmt.Errorf("can not add view %v height %v", shardBlock.Hash().String(), shardBlock.GetHeight())
+	}
+	return nil
 }
 
-func (s *Chain) InsertAndBroadcastBlock(block common.BlockInterface) error {
-	state := &State{
-		block,
-		s.multiview.GetBestView().GetCommittee(),
+func (c *Chain) InsertAndBroadcastBlock(block types.BlockInterface) error {
+	if err := c.InsertBlock(block, false); err != nil {
+		return err
+	}
+	if c.broadcast != nil {
+		c.broadcast(copyBlock(block.(*types.ShardBlock)), "")
 	}
-	s.multiview.AddView(state)
 	return nil
 }
 
-func (s *Chain) ValidatePreSignBlock(block common.BlockInterface) error {
+func (c *Chain) InsertWithPrevValidationData(block types.BlockInterface, previousValidationData string) error {
+	if err := c.InsertBlock(block, false); err != nil {
+		return err
+	}
+	return c.ReplacePreviousValidationData(block.GetPrevHash(), previousValidationData)
+}
+
+func (c *Chain) InsertAndBroadcastBlockWithPrevValidationData(block types.BlockInterface, previousValidationData string) error {
+	if err := c.InsertWithPrevValidationData(block, previousValidationData); err != nil {
+		return err
+	}
+	if c.broadcast != nil {
+		c.broadcast(copyBlock(block.(*types.ShardBlock)), previousValidationData)
+	}
 	return nil
 }
 
-func (s *Chain) GetShardID() int {
-	return s.chainID
+// ValidateBlockSignatures checks the producer signature and the aggregated signature of the signing committee
+func (c *Chain) ValidateBlockSignatures(block types.BlockInterface, committees []incognitokey.CommitteePublicKey) error {
+	if err := blsbft.ValidateProducerSigV2(block); err != nil {
+		return err
+	}
+	_, proposerIndex := c.GetProposerByTimeSlotFromCommitteeList(
+		common.CalculateTimeSlot(block.GetProposeTime()),
+		committees,
+	)
+	signingCommittees := c.GetSigningCommittees(proposerIndex, committees, block.GetVersion())
+	return blsbft.ValidateCommitteeSig(block, signingCommittees)
+}
+
+// ValidatePreSignBlock checks what a shard node checks before voting, without transactions to verify
+func (c *Chain) ValidatePreSignBlock(
+	block types.BlockInterface,
+	signingCommittees, committees []incognitokey.CommitteePublicKey,
+) error {
+	shardBlock, ok := block.(*types.ShardBlock)
+	if !ok {
+		return fmt.Errorf("block %v is not a shard block", block.Hash().String())
+	}
+	view := c.GetViewByHash(block.GetPrevHash())
+	if view == nil {
+		return fmt.Errorf("previous view %v not found", block.GetPrevHash().String())
+	}
+	if block.GetHeight() != view.GetHeight()+1 {
+		return fmt.Errorf("expect block height %v, got %v", view.GetHeight()+1, block.GetHeight())
+	}
+	if shardBlock.Header.BeaconHeight != view.GetBeaconHeight()+1 {
+		return fmt.Errorf("expect beacon height %v, got %v", view.GetBeaconHeight()+1, shardBlock.Header.BeaconHeight)
+	}
+	if version := consensus_v2.GetBlockVersion(simulationEpoch, view.GetBeaconHeight()); block.GetVersion() != version {
+		return fmt.Errorf("expect block version %v, got %v", version, block.GetVersion())
+	}
+	proposer, _ := c.GetProposerByTimeSlotFromCommitteeList(
+		common.CalculateTimeSlot(block.GetProposeTime()),
+		committees,
+	)
+	if proposerBase58, _ := proposer.ToBase58(); proposerBase58 != block.GetProposer() {
+		return fmt.Errorf("expect proposer %v, got %v", proposerBase58, block.GetProposer())
+	}
+	return blsbft.ValidateProducerSigV2(block)
+}
+
+// committeeRoot is the committee root of the blocks after genesis, the committee never changes
+func (c *Chain) committeeRoot() common.Hash {
+	committees, _ := incognitokey.CommitteeKeyListToString(c.committee)
+	root, _ := common.GenerateHashFromStringArray(committees)
+	return root
+}
+
+func (c *Chain) GetShardID() int {
+	return c.shardID
+}
+
+func (c *Chain) GetChainDatabase() incdb.Database {
+	return c.db
+}
+
+func (c *Chain) GetBestViewHeight() uint64 {
+	return c.GetBestView().GetHeight()
+}
+
+func (c *Chain) GetFinalViewHeight() uint64 {
+	return c.GetFinalView().GetHeight()
+}
+
+func (c *Chain) GetBestViewHash() string {
+	return c.GetBestView().GetHash().String()
+}
+
+func (c *Chain) GetFinalViewHash() string {
+	return c.GetFinalView().GetHash().String()
+}
+
+func (c *Chain) GetViewByHash(hash common.Hash) multiview.View {
+	view := c.multiView.GetViewByHash(hash)
+	if view == nil {
+		return nil
+	}
+	return view
+}
+
+func (c *Chain) CommitteeEngineVersion() int {
+	return c.GetBestView().CommitteeStateVersion()
+}
+
+func (c *Chain) GetProposerByTimeSlotFromCommitteeList(
+	ts int64,
+	committees []incognitokey.CommitteePublicKey,
+) (incognitokey.CommitteePublicKey, int) {
+	return blsbft.GetProposerByTimeSlotFromCommitteeList(ts, committees, c.GetBestView().GetProposerLength())
+}
+
+func (c *Chain) ReplacePreviousValidationData(previousBlockHash common.Hash, newValidationData string) error {
+	c.blocksLock.Lock()
+	defer c.blocksLock.Unlock()
+	block, ok := c.blocks[previousBlockHash]
+	if !ok {
+		return fmt.Errorf("previous block %v not found", previousBlockHash.String())
+	}
+	block.AddValidationField(newValidationData)
+	return nil
+}
+
+func (c *Chain) GetSigningCommittees(
+	proposerIndex int,
+	committees []incognitokey.CommitteePublicKey,
+	blockVersion int,
+) []incognitokey.CommitteePublicKey {
+	res := []incognitokey.CommitteePublicKey{}
+	if blockVersion >= types.BLOCK_PRODUCINGV3_VERSION {
+		res = blockchain.FilterSigningCommitteeV3(committees, proposerIndex)
+	} else {
+		res = append(res, committees...)
+	}
+	return res
+}
+
+func (c *Chain) GetPortalParamsV4(beaconHeight uint64) portalv4.PortalParams {
+	return portalv4.PortalParams{}
+}
+
+func (c *Chain) GetBlockByHash(hash common.Hash) (types.BlockInterface, error) {
+	c.blocksLock.RLock()
+	defer c.blocksLock.RUnlock()
+	block, ok := c.blocks[hash]
+	if !ok {
+		return nil, fmt.Errorf("block %v not found", hash.String())
+	}
+	return block, nil
+}
+
+func (c *Chain) StoreFinalityProof(block types.BlockInterface, finalityProof interface{}, reProposeSig interface{}) error {
+	return nil
+}
+
+// GetRandomBeaconSigningData the random beacon is only signed by the beacon committee
+func (c *Chain) GetRandomBeaconSigningData(block types.BlockInterface) (common.Hash, uint64, bool) {
+	return common.Hash{}, 0, false
+}
+
+// getFinalizedChain returns the block hash of each height up to the final view
+func (c *Chain) getFinalizedChain() map[uint64]common.Hash {
+	c.blocksLock.RLock()
+	defer c.blocksLock.RUnlock()
+	res := make(map[uint64]common.Hash)
+	for hash := *c.GetFinalView().GetHash(); ; {
+		block, ok := c.blocks[hash]
+		if !ok {
+			return res
+		}
+		res[block.GetHeight()] = hash
+		if block.GetHeight() == 1 {
+			return res
+		}
+		hash = block.GetPrevHash()
+	}
+}
+
+// getBlocksFrom returns the blocks from the first one unknown by known to the best view, nil if the chains do not connect
+func (c *Chain) getBlocksFrom(known *Chain) []*types.ShardBlock {
+	c.blocksLock.RLock()
+	defer c.blocksLock.RUnlock()
+	res := []*types.ShardBlock{}
+	for hash := *c.GetBestView().GetHash(); known.multiView.GetViewByHash(hash) == nil; {
+		block, ok := c.blocks[hash]
+		if !ok || block.GetHeight() <= known.GetFinalViewHeight() {
+			return nil
+		}
+		res = append([]*types.ShardBlock{copyBlock(block)}, res...)
+		hash = block.GetPrevHash()
+	}
+	return res
+}
+
+func (c *Chain) hasBlock(hash common.Hash) bool {
+	c.blocksLock.RLock()
+	defer c.blocksLock.RUnlock()
+	_, ok := c.blocks[hash]
+	return ok
+}
+
+// CommitteeChain is the beacon chain seen by the shard actor, its final view holds the fixed shard committee
+type CommitteeChain struct {
+	committee []incognitokey.CommitteePublicKey
+	finalView *View
+}
+
+func NewCommitteeChain(committee []incognitokey.CommitteePublicKey, genesisTime int64) *CommitteeChain {
+	block := types.NewBeaconBlock()
+	block.Header.Height = 1
+	block.Header.Timestamp = genesisTime
+	return &CommitteeChain{
+		committee: committee,
+		finalView: NewView(block, committee),
+	}
+}
+
+func (c *CommitteeChain) CommitteesFromViewHashForShard(committeeHash common.Hash, shardID byte) ([]incognitokey.CommitteePublicKey, error) {
+	if !committeeHash.IsEqual(c.finalView.GetHash()) {
+		return nil, fmt.Errorf("committee view %v not found", committeeHash.String())
+	}
+	return c.committee, nil
+}
+
+func (c *CommitteeChain) FinalView() multiview.View {
+	return c.finalView
 }
 
-func (c Chain) GetViewByHash(hash common.Hash) multiview.View {
-	return c.multiview.GetViewByHash(hash)
+func copyBlock(block *types.ShardBlock) *types.ShardBlock {
+	b, _ := json.Marshal(block)
+	newBlock := new(types.ShardBlock)
+	json.Unmarshal(b, newBlock)
+	return newBlock
 }
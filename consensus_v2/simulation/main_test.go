@@ -1,18 +1,18 @@
-package main
+// +build go1.25
+
+package simulation
 
 import (
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
+	"testing/synctest"
 	"time"
-
-	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/consensus_v2"
-	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
-var startTimeSlot uint64
-
 type TimeSlotScenerio struct {
 	ProposingScenerio []int                     // offset from position of current timeslot proposer
 	VotingScenerios   map[string][]int          // offset from position of current timeslot proposer
@@ -76,7 +76,7 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"0": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 1, FinalTimeslot: 0, ViewCount: 3},
 				},
 			},
 			4: {
@@ -97,12 +97,12 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"0": {BestHeight: 4, BestTimeslot: 6, FinalHeight: 1, FinalTimeslot: 0, ViewCount: 4},
 				},
 			},
 			7: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"all": {BestHeight: 4, BestTimeslot: 7, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"all": {BestHeight: 5, BestTimeslot: 7, FinalHeight: 4, FinalTimeslot: 6, ViewCount: 2},
 				},
 			},
 		},
@@ -127,7 +127,7 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {0, 1, 3, 4, 5, 6, 7},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"2": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"2": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 1, FinalTimeslot: 0, ViewCount: 3},
 				},
 			},
 			4: {
@@ -136,19 +136,19 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"1": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"0": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"2": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"3": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"4": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"5": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"6": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"7": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"1": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"0": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"2": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"3": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"4": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"5": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"6": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"7": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
 				},
 			},
 			5: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"all": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"all": {BestHeight: 5, BestTimeslot: 5, FinalHeight: 4, FinalTimeslot: 4, ViewCount: 2},
 				},
 			},
 		},
@@ -172,7 +172,7 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {0, 1, 2, 4, 5, 6, 7},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"3": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"3": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 1, FinalTimeslot: 0, ViewCount: 3},
 				},
 			},
 			4: {
@@ -181,14 +181,14 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {2},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"1": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"0": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"2": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"3": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"4": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"5": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"6": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"7": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"1": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"0": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"2": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"3": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"4": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"5": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"6": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"7": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
 				},
 			},
 			5: {
@@ -203,26 +203,26 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3, 4, 5, 6, 7},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 4, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
-					"1": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"3": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"2": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"4": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"5": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"6": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"7": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
+					"0": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"1": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"3": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"2": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"4": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"5": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"6": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"7": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
 				},
 			},
 			7: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"1": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"3": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"2": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"4": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"5": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"6": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"7": {BestHeight: 4, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"0": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"1": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"3": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"2": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"4": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"5": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"6": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"7": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
 				},
 			},
 		},
@@ -246,19 +246,19 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3, 4, 5, 6, 7},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"0": {BestHeight: 3, BestTimeslot: 3, FinalHeight: 1, FinalTimeslot: 0, ViewCount: 3},
 				},
 			},
 			4: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"1": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"3": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"2": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"4": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"5": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"6": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"7": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
+					"0": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"1": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"3": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"2": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"4": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"5": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"6": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
+					"7": {BestHeight: 4, BestTimeslot: 4, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 2},
 				},
 			},
 			5: {
@@ -273,20 +273,20 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3, 4, 5, 6, 7},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 4, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"5": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
+					"0": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
+					"5": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 3, FinalTimeslot: 3, ViewCount: 3},
 				},
 			},
 			7: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"1": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"2": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"3": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"4": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"5": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"6": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"7": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 3},
+					"0": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"1": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"2": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"3": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"4": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"5": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"6": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
+					"7": {BestHeight: 6, BestTimeslot: 7, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 2},
 				},
 			},
 			8: {
@@ -301,14 +301,14 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {0, 1, 3, 4, 5, 6, 7},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"1": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"2": {BestHeight: 5, BestTimeslot: 9, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
-					"3": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"4": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"5": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 3},
-					"6": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
-					"7": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
+					"0": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"1": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"2": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"3": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"4": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"5": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"6": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
+					"7": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 3},
 				},
 			},
 			10: {
@@ -319,7 +319,7 @@ func Test_Main4Committee_Case1(t *testing.T) {
 			},
 			11: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"all": {BestHeight: 6, BestTimeslot: 11, FinalHeight: 5, FinalTimeslot: 9, ViewCount: 2},
+					"all": {BestHeight: 8, BestTimeslot: 11, FinalHeight: 5, FinalTimeslot: 6, ViewCount: 4},
 				},
 			},
 		},
@@ -349,7 +349,7 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 3, BestTimeslot: 4, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"0": {BestHeight: 3, BestTimeslot: 4, FinalHeight: 1, FinalTimeslot: 0, ViewCount: 3},
 				},
 			},
 			5: {
@@ -358,25 +358,25 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0":  {BestHeight: 3, BestTimeslot: 3, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"12": {BestHeight: 3, BestTimeslot: 4, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
+					"0":  {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 4, ViewCount: 2},
+					"12": {BestHeight: 4, BestTimeslot: 5, FinalHeight: 3, FinalTimeslot: 4, ViewCount: 2},
 				},
 			},
 			6: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"1":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"2":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"3":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"4":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"5":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"6":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"7":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"8":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"9":  {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"10": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 2},
-					"11": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
-					"12": {BestHeight: 3, BestTimeslot: 2, FinalHeight: 2, FinalTimeslot: 1, ViewCount: 3},
+					"0":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"1":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"2":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"3":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"4":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"5":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"6":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"7":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"8":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"9":  {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"10": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"11": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
+					"12": {BestHeight: 5, BestTimeslot: 6, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 2},
 				},
 			},
 			7: {
@@ -391,7 +391,7 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {0, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"2": {BestHeight: 4, BestTimeslot: 8, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
+					"2": {BestHeight: 6, BestTimeslot: 8, FinalHeight: 4, FinalTimeslot: 5, ViewCount: 3},
 				},
 			},
 			9: {
@@ -400,24 +400,24 @@ func Test_Main4Committee_Case1(t *testing.T) {
 					"all": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
 				},
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0": {BestHeight: 4, BestTimeslot: 7, FinalHeight: 3, FinalTimeslot: 2, ViewCount: 2},
+					"0": {BestHeight: 7, BestTimeslot: 9, FinalHeight: 6, FinalTimeslot: 8, ViewCount: 2},
 				},
 			},
 			10: {
 				ExpectedOutput: map[string]TimeSlotOutput{
-					"0":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"1":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"2":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"3":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"4":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"5":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"6":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"7":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"8":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"9":  {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"10": {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"11": {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
-					"12": {BestHeight: 5, BestTimeslot: 10, FinalHeight: 4, FinalTimeslot: 8, ViewCount: 2},
+					"0":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"1":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"2":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"3":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"4":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"5":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"6":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"7":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"8":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"9":  {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"10": {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"11": {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
+					"12": {BestHeight: 8, BestTimeslot: 10, FinalHeight: 7, FinalTimeslot: 9, ViewCount: 2},
 				},
 			},
 		},
@@ -425,188 +425,104 @@ func Test_Main4Committee_Case1(t *testing.T) {
 	RunSimulation(&testScn4, t)
 
 }
-func RunSimulation(testScn *testScenerio, t *testing.T) {
-	simulation = nil
-	committeePkStruct := []incognitokey.CommitteePublicKey{}
-	committeePkBytes := [][]byte{}
-	for _, v := range testScn.Committee {
-		p, _ := consensus_v2.LoadUserKeyFromIncPrivateKey(v)
-		m, _ := consensus_v2.GetMiningKeyFromPrivateSeed(p)
-		pk := m.GetPublicKey()
-		committeePkStruct = append(committeePkStruct, *pk)
-		committeePkBytes = append(committeePkBytes, pk.MiningPubKey["bls"])
-	}
-	nodeList := []*Node{}
-
-	for i, v := range testScn.Committee {
-		p, _ := consensus_v2.LoadUserKeyFromIncPrivateKey(v)
-		m, _ := consensus_v2.GetMiningKeyFromPrivateSeed(p)
-		ni := NewNode(committeePkStruct, m, i, testScn.Name)
-		nodeList = append(nodeList, ni)
-	}
-	var startNode = func() {
-		for i, v := range nodeList {
-			v.nodeList = nodeList
-			fmt.Println("node start", i)
-			go v.Start()
-		}
-	}
 
-	GetSimulation().nodeList = nodeList
-	startTimeSlot = uint64(common.CalculateTimeSlot(time.Now().Unix()))
-	GetSimulation().setStartTimeSlot(uint64(startTimeSlot))
-	var setTimeSlot = func(s int) uint64 {
-		return startTimeSlot + uint64(s) - 1
+// RunSimulation runs the committee of testScn on the fake clock of a synctest bubble, the propose and vote
+// scenarios of a timeslot drop the messages sent to the listed offsets from the proposer of the timeslot.
+// The consensus rules activated after blsbft v2 are disabled, as when these scenarios were written. The expected
+// outputs follow the multiview finality of consensus v2, a view is final once its child is proposed in the next
+// timeslot, and the nodes sync the blocks they miss at the beginning of each timeslot
+func RunSimulation(testScn *testScenerio, t *testing.T) {
+	scenario := &Scenario{
+		Name:                    testScn.Name,
+		Nodes:                   len(testScn.Committee),
+		Keys:                    testScn.Committee,
+		TimeSlots:               int64(testScn.TimeSlots),
+		Lemma2Height:            math.MaxUint64,
+		BlockProducingV3Height:  math.MaxUint64,
+		ByzantineDetectorHeight: math.MaxUint64,
 	}
-	var setProposeCommunication = func(timeslot uint64, scenario []int) {
-		if GetSimulation().scenario.proposeComm[timeslot] == nil {
-			GetSimulation().scenario.proposeComm[timeslot] = []int{}
+	for i := 1; i <= testScn.TimeSlots; i++ {
+		scenerio, ok := testScn.TimeSlotScenerios[i]
+		if !ok {
+			continue
 		}
-		GetSimulation().scenario.proposeComm[timeslot] = scenario
-	}
-	var setVoteCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.voteComm[timeslot] == nil {
-			GetSimulation().scenario.voteComm[timeslot] = make(map[string][]int)
+		if len(scenerio.ProposingScenerio) > 0 {
+			scenario.Network = append(scenario.Network, NetworkRule{
+				From:            int64(i),
+				To:              int64(i),
+				Type:            ProposeMessage,
+				ReceiverOffsets: scenerio.ProposingScenerio,
+				Drop:            true,
+			})
 		}
-		GetSimulation().scenario.voteComm[timeslot][fmt.Sprintf("%d", nodeID)] = scenario
-	}
-
-	var timeslot uint64
-	for i := 1; i <= testScn.TimeSlots; i++ {
-		timeslot = setTimeSlot(i)
-		slotProducerIdx := GetIndexOfBytes(nodeList[0].chain.GetBestView().GetProposerByTimeSlot(int64(timeslot), 2).MiningPubKey["bls"], committeePkBytes)
-		if scenerio, ok := testScn.TimeSlotScenerios[i]; ok {
-			pComm := make([]int, len(testScn.Committee))
-			for a := range pComm {
-				pComm[a] = 1
+		for sender, offsets := range scenerio.VotingScenerios {
+			rule := NetworkRule{
+				From:            int64(i),
+				To:              int64(i),
+				Type:            VoteMessage,
+				ReceiverOffsets: offsets,
+				Drop:            true,
 			}
-
-			for _, p := range scenerio.ProposingScenerio {
-				pComm[(slotProducerIdx+p)%len(testScn.Committee)] = 0
-			}
-			setProposeCommunication(timeslot, pComm)
-			if len(scenerio.VotingScenerios) > 0 {
-				if vaComm, ok := scenerio.VotingScenerios["all"]; ok {
-					vComm := make([]int, len(testScn.Committee))
-					for a := range vComm {
-						vComm[a] = 1
-					}
-					for _, p := range vaComm {
-						vComm[(slotProducerIdx+p)%len(testScn.Committee)] = 0
-					}
-					for n := 0; n < len(testScn.Committee); n++ {
-						setVoteCommunication(timeslot, n, vComm)
-					}
-				} else {
-					for n := 0; n < len(testScn.Committee); n++ {
-						if nComm, ok := scenerio.VotingScenerios[strconv.Itoa(n)]; ok {
-							vComm := make([]int, len(testScn.Committee))
-							for a := range vComm {
-								vComm[a] = 1
-							}
-							for _, p := range nComm {
-								vComm[(slotProducerIdx+p)%len(testScn.Committee)] = 0
-							}
-							setVoteCommunication(timeslot, n, vComm)
-						}
-					}
+			if sender != "all" {
+				nodeID, err := strconv.Atoi(sender)
+				if err != nil {
+					t.Fatal(err)
 				}
+				rule.Senders = []int{nodeID}
 			}
+			scenario.Network = append(scenario.Network, rule)
 		}
 	}
 
-	GetSimulation().setMaxTimeSlot(timeslot)
-	startNode()
-	lastTimeSlot := uint64(0)
-	for {
-		curTimeSlotTime := uint64(common.CalculateTimeSlot(time.Now().Unix()))
-		curTimeSlot := (curTimeSlotTime - startTimeSlot) + 1
-		if lastTimeSlot != curTimeSlot {
-			time.AfterFunc(time.Millisecond*1500, func() {
-				slotProducerIdx := GetIndexOfBytes(nodeList[0].chain.GetBestView().GetProposerByTimeSlot(int64(curTimeSlotTime), 2).MiningPubKey["bls"], committeePkBytes)
-				fmt.Println("==========================")
-				fmt.Printf("Timeslot is: %v\n", curTimeSlot)
-				fmt.Printf("Proposer is: %d\n", slotProducerIdx)
-				expectedOutput := true
-				if _, ok := testScn.TimeSlotScenerios[int(curTimeSlot)]; ok {
-					if len(testScn.TimeSlotScenerios[int(curTimeSlot)].ExpectedOutput) > 0 {
-						if output, ok := testScn.TimeSlotScenerios[int(curTimeSlot)].ExpectedOutput["all"]; ok {
-							for i := 0; i < len(testScn.Committee); i++ {
-								if nodeList[i].chain.GetBestView().GetHeight() != output.BestHeight {
-									expectedOutput = false
-									fmt.Println("BestHeight not match!")
-									break
-								}
-								if uint64(common.CalculateTimeSlot(nodeList[i].chain.GetBestView().GetBlock().GetProduceTime()))-startTimeSlot+1 != output.BestTimeslot {
-									expectedOutput = false
-									fmt.Println("BestTimeslot not match!")
-									break
-								}
-								if nodeList[i].chain.GetFinalView().GetHeight() != output.FinalHeight {
-									expectedOutput = false
-									fmt.Println("FinalHeight not match!")
-									break
-								}
-								if uint64(common.CalculateTimeSlot(nodeList[i].chain.GetFinalView().GetBlock().GetProduceTime()))-startTimeSlot+1 != output.FinalTimeslot {
-									expectedOutput = false
-									fmt.Println("FinalHeight not match!")
-									break
-								}
-								if len(nodeList[i].chain.multiview.GetAllViewsWithBFS()) != output.ViewCount {
-									expectedOutput = false
-									fmt.Println("Viewcount not match!")
-									break
-								}
-							}
-						} else {
-							for nodeID, output := range testScn.TimeSlotScenerios[int(curTimeSlot)].ExpectedOutput {
-								nodeIDOffset, _ := strconv.Atoi(nodeID)
-								if nodeList[(slotProducerIdx+nodeIDOffset)%len(testScn.Committee)].chain.GetBestView().GetHeight() != output.BestHeight {
-									expectedOutput = false
-									fmt.Println("BestHeight not match!")
-									break
-								}
-								if uint64(common.CalculateTimeSlot(nodeList[(slotProducerIdx+nodeIDOffset)%len(testScn.Committee)].chain.GetBestView().GetBlock().GetProduceTime()))-startTimeSlot+1 != output.BestTimeslot {
-									expectedOutput = false
-									fmt.Println("BestTimeslot not match!")
-									break
-								}
-								if nodeList[(slotProducerIdx+nodeIDOffset)%len(testScn.Committee)].chain.GetFinalView().GetHeight() != output.FinalHeight {
-									expectedOutput = false
-									fmt.Println("FinalHeight not match!")
-									break
-								}
-								if uint64(common.CalculateTimeSlot(nodeList[(slotProducerIdx+nodeIDOffset)%len(testScn.Committee)].chain.GetFinalView().GetBlock().GetProduceTime()))-startTimeSlot+1 != output.FinalTimeslot {
-									expectedOutput = false
-									fmt.Println("FinalHeight not match!")
-									break
-								}
-								if len(nodeList[(slotProducerIdx+nodeIDOffset)%len(testScn.Committee)].chain.multiview.GetAllViewsWithBFS()) != output.ViewCount {
-									expectedOutput = false
-									fmt.Println("Viewcount not match!")
-									break
-								}
-							}
-						}
+	logFile, err := os.Create(filepath.Join(os.TempDir(), fmt.Sprintf("%s.log", testScn.Name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logFile.Close()
+	t.Run(testScn.Name, func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			sim, err := NewSimulation(scenario, logFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 1; i <= testScn.TimeSlots; i++ {
+				sim.RunTo(int64(i), 1500*time.Millisecond)
+				scenerio, ok := testScn.TimeSlotScenerios[i]
+				if !ok {
+					continue
+				}
+				if output, ok := scenerio.ExpectedOutput["all"]; ok {
+					for _, n := range sim.Nodes() {
+						checkTimeSlotOutput(t, sim, n, i, output)
 					}
+					continue
 				}
-				fmt.Printf("ExpectedOutput met: %v\n", expectedOutput)
-
-				for i := 0; i < len(testScn.Committee); i++ {
-					fmt.Printf("Node %v: \n -Best view height: %d:%d\n -Final view height: %d:%d\n -View count: %v\n", i, nodeList[i].chain.GetBestView().GetHeight(), uint64(common.CalculateTimeSlot(nodeList[i].chain.GetBestView().GetBlock().GetProduceTime()))-startTimeSlot+1, nodeList[i].chain.GetFinalView().GetHeight(), uint64(common.CalculateTimeSlot(nodeList[i].chain.GetFinalView().GetBlock().GetProduceTime()))-startTimeSlot+1, len(nodeList[i].chain.multiview.GetAllViewsWithBFS()))
+				slotProducerIdx := sim.ProposerIndex(int64(i))
+				for nodeID, output := range scenerio.ExpectedOutput {
+					nodeIDOffset, _ := strconv.Atoi(nodeID)
+					checkTimeSlotOutput(t, sim, sim.Nodes()[(slotProducerIdx+nodeIDOffset)%len(testScn.Committee)], i, output)
 				}
-				fmt.Println("==========================")
-			})
-		}
-		lastTimeSlot = curTimeSlot
-		time.Sleep(1 * time.Millisecond)
-		if curTimeSlot == uint64(testScn.TimeSlots) {
-			for i, v := range nodeList {
-				fmt.Println("node stop", i)
-				go v.Stop()
 			}
-			return
-		}
+			sim.Stop()
+			if err := sim.Verify(); err != nil {
+				t.Error(err)
+			}
+		})
+	})
+}
+
+func checkTimeSlotOutput(t *testing.T, sim *Simulation, n *Node, timeSlot int, output TimeSlotOutput) {
+	bestView := n.chain.GetBestView()
+	finalView := n.chain.GetFinalView()
+	res := TimeSlotOutput{
+		BestHeight:    bestView.GetHeight(),
+		BestTimeslot:  uint64(sim.TimeSlot(bestView.GetBlock().GetProduceTime())),
+		FinalHeight:   finalView.GetHeight(),
+		FinalTimeslot: uint64(sim.TimeSlot(finalView.GetBlock().GetProduceTime())),
+		ViewCount:     len(n.chain.multiView.GetAllViewsWithBFS()),
+	}
+	if res != output {
+		t.Errorf("timeslot %v node %v: got %+v, expect %+v", timeSlot, n.ID, res, output)
 	}
 }
 
@@ -0,0 +1,217 @@
+// +build go1.25
+
+package simulation
+
+import (
+	"sort"
+	"sync"
+	"testing/synctest"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// delivery is a message in flight on the virtual network
+type delivery struct {
+	at  time.Time
+	seq uint64
+	// from is the sender, to the id of the receiver
+	from *Node
+	to   int
+
+	msg                    *wire.MessageBFT
+	block                  *types.ShardBlock
+	previousValidationData string
+	// sync asks the receiver to sync the blocks of the sender
+	sync bool
+	// loopback is a message of the sender handed back to it, as the pubsub of a node does
+	loopback bool
+}
+
+// Network delivers the messages of the nodes in virtual time following the rules of the scenario.
+// The actors send from their own goroutine, the queue is ordered by arrival time then sender so that
+// a run does not depend on the scheduling of the goroutines
+type Network struct {
+	sim   *Simulation
+	lock  sync.Mutex
+	queue []*delivery
+	seq   uint64
+}
+
+func newNetwork(sim *Simulation) *Network {
+	return &Network{sim: sim}
+}
+
+// route returns the latency of a message of kind sent now from a node to another, false if the message is dropped
+func (net *Network) route(kind string, from, to int) (int64, bool) {
+	timeSlot := net.sim.currentTimeSlot()
+	delay := net.sim.latency()
+	for _, rule := range net.sim.scenario.Network {
+		if timeSlot < rule.From || (rule.To != 0 && timeSlot > rule.To) {
+			continue
+		}
+		if rule.Type != "" && rule.Type != kind {
+			continue
+		}
+		proposer := net.sim.ProposerIndex(timeSlot)
+		if !net.matchNode(rule.Senders, rule.SenderOffsets, proposer, from) ||
+			!net.matchNode(rule.Receivers, rule.ReceiverOffsets, proposer, to) {
+			continue
+		}
+		if rule.Drop || isPartitioned(rule.Partition, from, to) {
+			return 0, false
+		}
+		delay += rule.Delay
+	}
+	return delay, true
+}
+
+func (net *Network) send(kind string, d *delivery) {
+	delay, ok := int64(0), true
+	if !d.loopback {
+		delay, ok = net.route(kind, d.from.ID, d.to)
+	}
+	if !ok {
+		return
+	}
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.seq++
+	d.at = time.Now().Add(time.Duration(delay) * time.Millisecond)
+	d.seq = net.seq
+	net.queue = append(net.queue, d)
+}
+
+func (net *Network) broadcastMessage(from *Node, msg *wire.MessageBFT) {
+	kind := VoteMessage
+	if msg.Type == blsbft.MSG_PROPOSE {
+		kind = ProposeMessage
+	}
+	// a twin only acts in the timeslot of its equivocation, its actor may still run at the beginning of the next one
+	if from.isTwin && net.sim.currentTimeSlot() != from.equivocation {
+		return
+	}
+	net.send(kind, &delivery{from: from, to: from.ID, msg: msg, loopback: true})
+	for _, to := range net.sim.nodes {
+		if to.ID == from.ID {
+			continue
+		}
+		if kind == ProposeMessage && !net.sim.proposeReaches(from, to.ID) {
+			continue
+		}
+		net.send(kind, &delivery{from: from, to: to.ID, msg: msg})
+	}
+}
+
+func (net *Network) broadcastBlock(from *Node, block *types.ShardBlock, previousValidationData string) {
+	for _, to := range net.sim.nodes {
+		if to.ID == from.ID {
+			continue
+		}
+		net.send(BlockMessage, &delivery{
+			from:                   from,
+			to:                     to.ID,
+			block:                  copyBlock(block),
+			previousValidationData: previousValidationData,
+		})
+	}
+}
+
+// requestBlocks makes the node with peerID send its missing blocks to n
+func (net *Network) requestBlocks(n *Node, peerID string) {
+	for _, peer := range net.sim.nodes {
+		if peer.peerID.String() == peerID && peer.ID != n.ID {
+			net.send(RequestMessage, &delivery{from: peer, to: n.ID, sync: true})
+			return
+		}
+	}
+}
+
+// pop removes the first message arriving until now, nil if there is none
+func (net *Network) pop(now time.Time) *delivery {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	if len(net.queue) == 0 {
+		return nil
+	}
+	sort.SliceStable(net.queue, func(i, j int) bool {
+		a, b := net.queue[i], net.queue[j]
+		if !a.at.Equal(b.at) {
+			return a.at.Before(b.at)
+		}
+		if a.from.ID != b.from.ID {
+			return a.from.ID < b.from.ID
+		}
+		return a.seq < b.seq
+	})
+	d := net.queue[0]
+	if d.at.After(now) {
+		return nil
+	}
+	net.queue = net.queue[1:]
+	return d
+}
+
+// deliverUntil hands in order the messages arriving until now to the receivers, including the ones sent meanwhile.
+// The actors handle each message before the next one is delivered
+func (net *Network) deliverUntil(now time.Time) {
+	for d := net.pop(now); d != nil; d = net.pop(now) {
+		receivers := []*Node{net.sim.nodes[d.to]}
+		if d.loopback {
+			receivers = []*Node{d.from}
+		} else if twin, ok := net.sim.twins[d.to]; ok && d.msg != nil {
+			receivers = append(receivers, twin)
+		}
+		for _, n := range receivers {
+			if !n.running {
+				continue
+			}
+			switch {
+			case d.msg != nil:
+				n.receiveMessage(d.msg)
+			case d.block != nil:
+				n.receiveBlock(d.from, d.block, d.previousValidationData)
+			case d.sync:
+				n.syncFrom(d.from)
+			}
+		}
+		synctest.Wait()
+	}
+}
+
+// matchNode returns true if the node id is in nodes or at one of the offsets from the proposer, true if both are empty
+func (net *Network) matchNode(nodes, offsets []int, proposer, id int) bool {
+	if len(nodes) == 0 && len(offsets) == 0 {
+		return true
+	}
+	for _, offset := range offsets {
+		if (proposer+offset)%len(net.sim.nodes) == id {
+			return true
+		}
+	}
+	return containsNode(nodes, id)
+}
+
+func containsNode(nodes []int, id int) bool {
+	for _, n := range nodes {
+		if n == id {
+			return true
+		}
+	}
+	return false
+}
+
+func isPartitioned(partition [][]int, from, to int) bool {
+	fromGroup, toGroup := -1, -1
+	for i, group := range partition {
+		if containsNode(group, from) {
+			fromGroup = i
+		}
+		if containsNode(group, to) {
+			toGroup = i
+		}
+	}
+	return fromGroup != -1 && toGroup != -1 && fromGroup != toGroup
+}
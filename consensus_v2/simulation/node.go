@@ -1,182 +1,209 @@
-package main
+// +build go1.25
+
+package simulation
 
 import (
 	"fmt"
-	"os"
-	"strings"
-	"sync"
 	"time"
 
-	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
-
+	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/consensus_v2/blsbftv2"
-	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
+	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/wire"
-	libp2p "github.com/libp2p/go-libp2p-peer"
+	peer "github.com/libp2p/go-libp2p-peer"
 )
 
-var mapNodeID = make(map[string]string)
-var mapLock = sync.Mutex{}
-
+// Node is a committee member running a blsbft actor, it implements blsbft.NodeInterface over the virtual network
+// of the simulation
 type Node struct {
-	id              string
-	consensusEngine *blsbftv2.BLSBFT_V2
-	chain           *Chain
-	nodeList        []*Node
+	ID        int
+	sim       *Simulation
+	peerID    peer.ID
+	miningKey *signatureschemes.MiningKey
+	chain     *Chain
+	logger    common.Logger
+	actor     blsbft.Actor
+	// readyAt is the time the actor loop starts after its first sleep, the messages received before are dropped
+	readyAt time.Time
+	running bool
+	// down is set while a restart of the scenario keeps the node stopped
+	down bool
+	// isTwin is set for the byzantine copy of a node created for an equivocation in timeslot equivocation
+	isTwin       bool
+	equivocation int64
 }
 
-type logWriter struct {
-	NodeID string
-	fd     *os.File
+func newNode(sim *Simulation, id int, miningKey *signatureschemes.MiningKey, genesis *types.ShardBlock) (*Node, error) {
+	db, err := incdb.Open("memdb")
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{
+		ID:        id,
+		sim:       sim,
+		peerID:    peer.ID(fmt.Sprintf("simulation-node-%d", id)),
+		miningKey: miningKey,
+		logger:    sim.newLogger(fmt.Sprintf("Node %d", id)),
+	}
+	n.chain = NewChain(0, sim.committee, genesis, db)
+	n.chain.broadcast = n.pushBlock
+	return n, nil
 }
 
-func (s logWriter) Write(p []byte) (n int, err error) {
-	s.fd.Write(p)
-	return len(p), nil
+// newTwin creates a node signing with the keys of n, it shares the views of n but proposes other blocks
+func (n *Node) newTwin(equivocation int64) (*Node, error) {
+	twin := &Node{
+		ID:           n.ID,
+		sim:          n.sim,
+		peerID:       n.peerID,
+		miningKey:    n.miningKey,
+		logger:       n.sim.newLogger(fmt.Sprintf("Node %d twin", n.ID)),
+		isTwin:       true,
+		equivocation: equivocation,
+	}
+	twin.chain = n.chain.twin(twin.pushBlock)
+	return twin, nil
 }
 
-func NewNode(committeePkStruct []incognitokey.CommitteePublicKey, miningKey *signatureschemes.MiningKey, index int, testname string) *Node {
-	name := fmt.Sprintf("%s_log%d", testname, index)
-	fd, err := os.OpenFile(fmt.Sprintf("%s.log", name), os.O_CREATE|os.O_WRONLY, 0666)
-	if err != nil {
-		panic(err)
+// newMiningKey derives the consensus keys of a node from seed
+func newMiningKey(seed []byte) *signatureschemes.MiningKey {
+	blsPriKey, blsPubKey := blsmultisig.KeyGen(seed)
+	bridgePriKey, bridgePubKey := bridgesig.KeyGen(seed)
+	return &signatureschemes.MiningKey{
+		PriKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.SKBytes(blsPriKey),
+			common.BridgeConsensus: bridgesig.SKBytes(&bridgePriKey),
+		},
+		PubKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.PKBytes(blsPubKey),
+			common.BridgeConsensus: bridgesig.PKBytes(&bridgePubKey),
+		},
 	}
-	fd.Truncate(0)
-	backendLog := common.NewBackend(logWriter{
-		NodeID: name,
-		fd:     fd,
-	})
-	consensusLogger := backendLog.Logger("Consensus", false)
-	consensusLogger.SetLevel(1)
-	chainViewLogger := backendLog.Logger("ShardView", false)
-	chainViewLogger.SetLevel(1)
-
-	node := Node{id: fmt.Sprintf("%d", index)}
-	node.chain = NewChain(0, "shard0", committeePkStruct)
-
-	node.consensusEngine = &blsbftv2.BLSBFT_V2{
-		Chain:    node.chain,
-		Node:     &node,
-		ChainKey: "shard",
-		PeerID:   name,
-		Logger:   consensusLogger,
+}
+
+// start creates the actor as the consensus engine of a node does, it loads its state from the consensus database
+func (n *Node) start() {
+	version := n.blockVersion()
+	n.actor = blsbft.NewActorWithValue(
+		n.chain,
+		n.sim.committeeChain,
+		version,
+		n.chain.GetShardID(),
+		version,
+		n.chain.GetChainName(),
+		n,
+		n.logger,
+	)
+	n.actor.LoadUserKeys([]signer.Signer{signer.NewLocalSigner(n.miningKey)})
+	n.actor.Start()
+	n.readyAt = time.Now().Add(time.Duration(common.TIMESLOT-1) * time.Second)
+	n.running = true
+}
+
+func (n *Node) stop() {
+	n.actor.Destroy()
+	n.running = false
+}
+
+func (n *Node) blockVersion() int {
+	return consensus_v2.GetBlockVersion(simulationEpoch, n.chain.GetBestView().GetBeaconHeight())
+}
+
+// updateBlockVersion sets the block version of the best view to the actor, as the consensus engine does
+func (n *Node) updateBlockVersion() {
+	n.actor.SetBlockVersion(n.blockVersion())
+}
+
+// receiveMessage hands a BFT message delivered by the network to the actor, it returns once the actor loop got it
+func (n *Node) receiveMessage(msg *wire.MessageBFT) {
+	if time.Now().Before(n.readyAt) {
+		return
 	}
-	node.consensusEngine.LoadUserKeys([]signatureschemes.MiningKey{*miningKey})
-	return &node
-}
-
-func (s *Node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
-	time.AfterFunc(time.Millisecond*100, func() {
-		if msg.(*wire.MessageBFT).Type == "propose" {
-			timeSlot := uint64(msg.(*wire.MessageBFT).TimeSlot)
-			if timeSlot > GetSimulation().maxTimeSlot {
-				os.Exit(0)
-			}
-			pComm := GetSimulation().scenario.proposeComm
-			if senderComm, ok := pComm[timeSlot]; ok {
-				for i, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					if senderComm[i] == 0 {
-						fmt.Printf("Don'trrrrrr send propose from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-						// c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-					} else {
-						fmt.Printf("Send propose from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-						c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-					}
-				}
-			} else {
-				for _, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					fmt.Printf("Send* propose from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-					c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-				}
-			}
-			return
+	n.actor.ProcessBFTMsg(msg)
+}
+
+// receiveBlock inserts a block broadcast by from, missing previous blocks are synced from the sender
+func (n *Node) receiveBlock(from *Node, block *types.ShardBlock, previousValidationData string) {
+	if n.chain.GetViewByHash(block.GetPrevHash()) == nil {
+		n.syncFrom(from)
+		return
+	}
+	if err := n.chain.InsertBlock(block, true); err != nil {
+		n.logger.Debug(err)
+		return
+	}
+	if previousValidationData != "" {
+		if err := n.chain.ReplacePreviousValidationData(block.GetPrevHash(), previousValidationData); err != nil {
+			n.logger.Debug(err)
 		}
+	}
+}
 
-		if msg.(*wire.MessageBFT).Type == "vote" {
-			vComm := GetSimulation().scenario.voteComm
-			timeSlot := uint64(msg.(*wire.MessageBFT).TimeSlot)
-			if timeSlot > GetSimulation().maxTimeSlot {
-				os.Exit(0)
-			}
-			if comm, ok := vComm[timeSlot]; ok {
-				for i, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					if senderComm, ok := comm[s.id]; ok {
-						if senderComm[i] == 0 {
-							fmt.Printf("Don't send vote from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-							// c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-						} else {
-							fmt.Printf("Send vote from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-							c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-						}
-					} else {
-						fmt.Printf("Send vote from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-						c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-					}
-
-				}
-			} else {
-				for _, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					fmt.Printf("Send* vote from %v to %v at timeslot %v\n", s.id, c.id, timeSlot-GetSimulation().startTimeSlot+1)
-					c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT))
-				}
-			}
+// syncFrom inserts the blocks of the best chain of peer this node does not have
+func (n *Node) syncFrom(peer *Node) {
+	for _, block := range peer.chain.getBlocksFrom(n.chain) {
+		if err := n.chain.InsertBlock(block, true); err != nil {
+			n.logger.Debug(err)
 			return
 		}
-	})
+	}
+}
 
-	return nil
+func (n *Node) pushBlock(block *types.ShardBlock, previousValidationData string) {
+	n.sim.network.broadcastBlock(n, block, previousValidationData)
 }
 
-func (s *Node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) (err error) {
-	str := []string{}
-	for _, h := range hashes {
-		pH, _ := common.Hash{}.NewHash(h)
-		str = append(str, pH.String())
+func (n *Node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
+	msgBFT, ok := msg.(*wire.MessageBFT)
+	if !ok {
+		return fmt.Errorf("unexpected message %v", msg.MessageType())
 	}
-	for _, c := range s.nodeList {
-		if s.id == c.id {
-			continue
-		}
-		if c.id == mapNodeID[peerID] {
-			for _, h := range hashes {
-				pH, _ := common.Hash{}.NewHash(h)
-				v := c.chain.multiview.GetViewByHash(*pH)
-				s.chain.multiview.AddView(v)
-			}
-		}
+	n.sim.network.broadcastMessage(n, msgBFT)
+	return nil
+}
+
+func (n *Node) PushBlockToAll(block types.BlockInterface, previousValidationData string, isBeacon bool) error {
+	shardBlock, ok := block.(*types.ShardBlock)
+	if !ok {
+		return fmt.Errorf("block %v is not a shard block", block.Hash().String())
 	}
-	fmt.Printf("Node %v RequestMissingViewViaStream from %v hash %v \n", s.id, mapNodeID[peerID], strings.Join(str, ","))
+	n.pushBlock(copyBlock(shardBlock), previousValidationData)
 	return nil
 }
 
-func (s *Node) GetSelfPeerID() libp2p.ID {
-	return libp2p.ID(s.id)
+func (n *Node) IsEnableMining() bool {
+	return true
+}
+
+func (n *Node) GetMiningKeys() string {
+	return ""
+}
+
+func (n *Node) GetPrivateKey() string {
+	return ""
+}
+
+func (n *Node) GetUserMiningState() (role string, chainID int) {
+	return common.CommitteeRole, n.chain.GetShardID()
+}
+
+// RequestMissingViewViaStream syncs the blocks of the peer, the reply goes through the virtual network
+func (n *Node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	n.sim.network.requestBlocks(n, peerID)
+	return nil
 }
 
-func (s *Node) Start() {
-	fmt.Printf("Node %s log is %s, peerID: %v \n", s.id, fmt.Sprintf("log%s.log", s.id), libp2p.ID(s.id).String())
-	mapLock.Lock()
-	defer mapLock.Unlock()
-	mapNodeID[libp2p.ID(s.id).String()] = s.id
-	s.consensusEngine.Start()
+func (n *Node) GetSelfPeerID() peer.ID {
+	return n.peerID
 }
 
-func (s *Node) Stop() {
-	mapLock.Lock()
-	defer mapLock.Unlock()
-	delete(mapNodeID, libp2p.ID(s.id).String())
-	s.consensusEngine.Stop()
+// Chain returns the shard chain of the node
+func (n *Node) Chain() *Chain {
+	return n.chain
 }
@@ -0,0 +1,109 @@
+// +build go1.25
+
+package simulation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// Message types a network rule applies to, an empty type matches all of them.
+// BlockMessage covers the blocks a node broadcasts and the sync of the nodes at the beginning of each timeslot,
+// RequestMessage the blocks an actor requests when it gets a propose on top of an unknown block
+const (
+	ProposeMessage = "propose"
+	VoteMessage    = "vote"
+	BlockMessage   = "block"
+	RequestMessage = "request"
+)
+
+// Scenario scripts a simulation, timeslots are counted from 1, the first timeslot after genesis
+type Scenario struct {
+	Name string `json:"name"`
+	// Nodes is the size of the shard committee, every member runs a node
+	Nodes int `json:"nodes"`
+	// Keys are incognito private keys the mining keys of the first nodes are derived from,
+	// the other nodes get keys derived from their index
+	Keys []string `json:"keys"`
+	// TimeSlots is the number of simulated timeslots
+	TimeSlots int64 `json:"timeslots"`
+	// Latency is the delay of a message in milliseconds when no rule applies, 100 if not set
+	Latency int64 `json:"latency"`
+	// The consensus heights are shard heights, the simulated beacon chain moves by one block with each shard block.
+	// A zero height enables the rule from genesis
+	Lemma2Height            uint64 `json:"lemma2_height"`
+	BlockProducingV3Height  uint64 `json:"block_producing_v3_height"`
+	ByzantineDetectorHeight uint64 `json:"byzantine_detector_height"`
+
+	Network       []NetworkRule  `json:"network"`
+	Equivocations []Equivocation `json:"equivocations"`
+	Restarts      []Restart      `json:"restarts"`
+	Expected      Expected       `json:"expected"`
+}
+
+// NetworkRule changes the delivery of the messages sent from timeslot From to timeslot To inclusive,
+// a zero To keeps the rule until the end
+type NetworkRule struct {
+	From int64  `json:"from"`
+	To   int64  `json:"to"`
+	Type string `json:"type"`
+	// Senders and Receivers restrict the rule to these nodes, all nodes if empty
+	Senders   []int `json:"senders"`
+	Receivers []int `json:"receivers"`
+	// SenderOffsets and ReceiverOffsets add the nodes at these offsets from the proposer of the timeslot
+	SenderOffsets   []int `json:"sender_offsets"`
+	ReceiverOffsets []int `json:"receiver_offsets"`
+	// Delay is added to the latency of the message in milliseconds
+	Delay int64 `json:"delay"`
+	Drop  bool  `json:"drop"`
+	// Partition drops the messages between nodes of different groups, a node in no group reaches every node
+	Partition [][]int `json:"partition"`
+}
+
+// Equivocation makes Node propose a second block in TimeSlot, the node must be the proposer of the timeslot.
+// A twin of the node sharing its keys sends the conflicting block to Receivers, the other nodes get the honest one
+type Equivocation struct {
+	Node      int   `json:"node"`
+	TimeSlot  int64 `json:"timeslot"`
+	Receivers []int `json:"receivers"`
+}
+
+// Restart stops Node at the beginning of timeslot Stop and starts it again at the beginning of timeslot Start,
+// the node keeps its chain and consensus database
+type Restart struct {
+	Node  int   `json:"node"`
+	Stop  int64 `json:"stop"`
+	Start int64 `json:"start"`
+}
+
+// Expected is checked on the running nodes at the end of the simulation,
+// the final chains of all nodes must always agree. The nodes share the byzantine detector of the process
+type Expected struct {
+	// MinFinalHeight is the lowest final view height every node must reach
+	MinFinalHeight uint64 `json:"min_final_height"`
+	// MaxFinalHeight bounds the final view height when set, for a chain expected to stall
+	MaxFinalHeight uint64 `json:"max_final_height"`
+	// SameBestView requires the nodes to choose the same best view
+	SameBestView bool `json:"same_best_view"`
+	// BestBlockVersion is the version of the best block of every node when set
+	BestBlockVersion int `json:"best_block_version"`
+	// Evidences lists the validators the byzantine detector must hold an equivocation evidence against
+	Evidences []int `json:"evidences"`
+	// NoEvidence requires that no node detects an equivocation
+	NoEvidence bool `json:"no_evidence"`
+	// BlackListed lists the validators the byzantine detector must black list
+	BlackListed []int `json:"black_listed"`
+}
+
+// LoadScenario reads a scenario from a json file
+func LoadScenario(file string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	scenario := new(Scenario)
+	if err := json.Unmarshal(data, scenario); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
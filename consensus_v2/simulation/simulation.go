@@ -1,56 +1,368 @@
-package main
+// +build go1.25
+
+// Package simulation runs the blsbft actors of a shard committee in one process, over a virtual network
+// scripted by a Scenario, to check finality, fork choice and byzantine detection without a real network.
+// The actors run their own loop and timers as in a node, a simulation must run in a testing/synctest bubble
+// so that they run on its fake clock: the timeslots of a scenario pass instantly and always the same way.
+// testing/synctest needs Go 1.25, older toolchains skip the package.
+package simulation
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"io/ioutil"
+	"testing/synctest"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+const (
+	simulationTimeSlot = uint64(10) // seconds
+	// step is the virtual time between two deliveries of the network, the delays of the messages are rounded up to it
+	step           = 100 * time.Millisecond
+	defaultLatency = int64(100)
 )
 
+// Simulation runs the nodes of a scenario.
+// The nodes share the consensus database and the byzantine detector of the process, as the actors of a node do
 type Simulation struct {
-	nodeList      []*Node
-	fd            os.File
-	maxTimeSlot   uint64
-	startTimeSlot uint64
-	scenario      Scenario
-	expected      Expected
+	scenario       *Scenario
+	nodes          []*Node
+	twins          map[int]*Node
+	committee      []incognitokey.CommitteePublicKey
+	committeeChain *CommitteeChain
+	network        *Network
+	logBackend     *common.Backend
+	genesisTime    int64
+	// now is the virtual time the simulation reached, lastTimeSlot the last timeslot it began
+	now          time.Time
+	lastTimeSlot int64
 }
 
-type Scenario struct {
-	proposeComm map[uint64][]int // timeSlot -> sender -> receiver
-	voteComm    map[uint64]map[string][]int
-	sync        map[string][]string
+// NewSimulation creates and starts the nodes of scenario from a shared genesis block, the consensus params of the
+// process are replaced by the scenario ones. It must be called in a synctest bubble and Stop must be called before
+// the bubble ends. Timeslot 1 is the one the nodes start in. The actors log to logWriter, nothing is logged if it is nil
+func NewSimulation(scenario *Scenario, logWriter io.Writer) (*Simulation, error) {
+	miningKeys, err := loadMiningKeys(scenario)
+	if err != nil {
+		return nil, err
+	}
+	if len(miningKeys) < 1 {
+		return nil, errors.New("scenario without node")
+	}
+	setConsensusParams(scenario)
+
+	now := time.Now()
+	s := &Simulation{
+		scenario:    scenario,
+		twins:       make(map[int]*Node),
+		genesisTime: now.Unix() - int64(simulationTimeSlot),
+		now:         now,
+	}
+	if logWriter != nil {
+		s.logBackend = common.NewBackend(logWriter)
+	}
+	s.network = newNetwork(s)
+	db, err := incdb.Open("memdb")
+	if err != nil {
+		return nil, err
+	}
+	rawdb_consensus.SetConsensusDatabase(db)
+	blsbft.ByzantineDetectorObject = blsbft.NewByzantineDetector(s.newLogger("Byzantine Detector"))
+
+	for _, miningKey := range miningKeys {
+		s.committee = append(s.committee, *miningKey.GetPublicKey())
+	}
+	for _, e := range scenario.Equivocations {
+		if proposer := s.ProposerIndex(e.TimeSlot); proposer != e.Node {
+			return nil, fmt.Errorf("node %v equivocates in timeslot %v, the proposer is node %v", e.Node, e.TimeSlot, proposer)
+		}
+	}
+	s.committeeChain = NewCommitteeChain(s.committee, s.genesisTime)
+	genesis := NewGenesisBlock(0, s.genesisTime)
+	for i, miningKey := range miningKeys {
+		n, err := newNode(s, i, miningKey, genesis)
+		if err != nil {
+			return nil, err
+		}
+		s.nodes = append(s.nodes, n)
+	}
+	for _, n := range s.nodes {
+		n.start()
+	}
+	s.beginTimeSlot()
+	return s, nil
 }
 
-type Expected struct {
+// loadMiningKeys derives the mining keys of the nodes from the private keys of the scenario,
+// or from the node index when it has none
+func loadMiningKeys(scenario *Scenario) ([]*signatureschemes.MiningKey, error) {
+	miningKeys := []*signatureschemes.MiningKey{}
+	for _, privateKey := range scenario.Keys {
+		privateSeed, err := consensus_v2.LoadUserKeyFromIncPrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		miningKey, err := consensus_v2.GetMiningKeyFromPrivateSeed(privateSeed)
+		if err != nil {
+			return nil, err
+		}
+		miningKeys = append(miningKeys, miningKey)
+	}
+	for i := len(miningKeys); i < scenario.Nodes; i++ {
+		miningKeys = append(miningKeys, newMiningKey(common.HashB([]byte(fmt.Sprintf("simulation node %d", i)))))
+	}
+	return miningKeys, nil
 }
 
-var simulation *Simulation
+func setConsensusParams(scenario *Scenario) {
+	config.AbortParam()
+	param := config.Param()
+	param.ActiveShards = 1
+	param.EpochParam.NumberOfBlockInEpoch = 350
+	param.ConsensusParam.Timeslot = simulationTimeSlot
+	param.ConsensusParam.ConsensusV2Epoch = simulationEpoch
+	param.ConsensusParam.StakingFlowV2Height = 1
+	param.ConsensusParam.StakingFlowV3Height = 1
+	param.ConsensusParam.Lemma2Height = scenario.Lemma2Height
+	param.ConsensusParam.BlockProducingV3Height = scenario.BlockProducingV3Height
+	param.ConsensusParam.ByzantineDetectorHeight = scenario.ByzantineDetectorHeight
+	common.TIMESLOT = simulationTimeSlot
+}
 
-func InitSimulation(scenario []map[string]Scenario, expected []map[string]Expected) *Simulation {
-	return simulation
+// Run simulates the timeslots of the scenario then stops the nodes
+func (s *Simulation) Run() {
+	s.RunTo(s.scenario.TimeSlots+1, 0)
+	s.Stop()
 }
 
-func GetSimulation() *Simulation {
-	if simulation == nil {
-		simulation = new(Simulation)
-		simulation.scenario = Scenario{
-			proposeComm: make(map[uint64][]int),
-			voteComm:    make(map[uint64]map[string][]int),
-			sync:        make(map[string][]string),
+// RunTo lets the actors run until offset after the beginning of timeSlot, the network delivers the messages
+// every step and the nodes sync their chain at the beginning of each timeslot
+func (s *Simulation) RunTo(timeSlot int64, offset time.Duration) {
+	end := s.timeSlotBegin(timeSlot).Add(offset)
+	for s.now.Before(end) {
+		time.Sleep(step)
+		s.now = s.now.Add(step)
+		// let the actors handle the ticks of their timers first
+		synctest.Wait()
+		if s.currentTimeSlot() != s.lastTimeSlot {
+			s.beginTimeSlot()
 		}
+		s.network.deliverUntil(s.now)
 	}
-	return simulation
 }
 
-func (s *Simulation) setMaxTimeSlot(max uint64) {
-	fmt.Println("Set Max Time Slot", max)
-	s.maxTimeSlot = max
+// Stop destroys the actors of the running nodes and the multiviews of the chains, the bubble of the simulation
+// can not end while their goroutines run. The chains can still be verified
+func (s *Simulation) Stop() {
+	for _, n := range s.endpoints() {
+		if n.running {
+			n.stop()
+		}
+	}
+	for _, n := range s.nodes {
+		n.chain.destroy()
+	}
 }
-func (s *Simulation) setStartTimeSlot(start uint64) {
-	fmt.Println("Set Start Time Slot", start)
-	s.startTimeSlot = start
+
+// beginTimeSlot applies the restarts and equivocations of the new timeslot, then the running nodes sync their chain
+// with the peers they can reach and update their block version, as the syncker and the engine of a node do
+func (s *Simulation) beginTimeSlot() {
+	timeSlot := s.currentTimeSlot()
+	s.lastTimeSlot = timeSlot
+	for _, r := range s.scenario.Restarts {
+		n := s.nodes[r.Node]
+		if r.Stop == timeSlot && n.running {
+			n.stop()
+			n.down = true
+		}
+		if r.Start == timeSlot && !n.running {
+			n.start()
+			n.down = false
+		}
+	}
+
+	// the actor of a twin starts one timeslot before its equivocation, an actor waits a timeslot before it runs
+	for id, twin := range s.twins {
+		if twin.equivocation < timeSlot {
+			twin.stop()
+			delete(s.twins, id)
+		}
+	}
+	for _, e := range s.scenario.Equivocations {
+		startTimeSlot := e.TimeSlot - 1
+		if startTimeSlot < 1 {
+			startTimeSlot = 1
+		}
+		if startTimeSlot != timeSlot || !s.nodes[e.Node].running {
+			continue
+		}
+		twin, err := s.nodes[e.Node].newTwin(e.TimeSlot)
+		if err != nil {
+			panic(err)
+		}
+		twin.start()
+		s.twins[e.Node] = twin
+	}
+
+	for _, n := range s.nodes {
+		if !n.running {
+			continue
+		}
+		for _, peer := range s.nodes {
+			if peer.ID == n.ID || !peer.running {
+				continue
+			}
+			if _, ok := s.network.route(BlockMessage, peer.ID, n.ID); ok {
+				n.syncFrom(peer)
+			}
+		}
+	}
+	for _, n := range s.endpoints() {
+		if n.running {
+			n.updateBlockVersion()
+		}
+	}
 }
 
-func (s *Simulation) setLogfile(fd os.File) {
-	s.fd = fd
+// proposeReaches returns false for the propose messages of an equivocating node the receiver must not get:
+// the receivers of the equivocation only get the block of the twin, the other nodes the honest block
+func (s *Simulation) proposeReaches(from *Node, to int) bool {
+	for _, e := range s.scenario.Equivocations {
+		if e.Node == from.ID && e.TimeSlot == s.currentTimeSlot() {
+			return containsNode(e.Receivers, to) == from.isTwin
+		}
+	}
+	return !from.isTwin
+}
+
+// currentTimeSlot returns the timeslot of the virtual time, counted from 1 after genesis
+func (s *Simulation) currentTimeSlot() int64 {
+	return common.CalculateTimeSlot(time.Now().Unix()) - common.CalculateTimeSlot(s.genesisTime)
+}
+
+// TimeSlot returns the timeslot of a block, counted from 1 after genesis
+func (s *Simulation) TimeSlot(unixTime int64) int64 {
+	return common.CalculateTimeSlot(unixTime) - common.CalculateTimeSlot(s.genesisTime)
+}
+
+func (s *Simulation) timeSlotBegin(timeSlot int64) time.Time {
+	return time.Unix(s.genesisTime+timeSlot*int64(simulationTimeSlot), 0)
+}
+
+// ProposerIndex returns the index in the committee of the proposer of timeSlot
+func (s *Simulation) ProposerIndex(timeSlot int64) int {
+	_, index := blsbft.GetProposerByTimeSlotFromCommitteeList(
+		common.CalculateTimeSlot(s.genesisTime)+timeSlot,
+		s.committee,
+		len(s.committee),
+	)
+	return index
+}
+
+func (s *Simulation) latency() int64 {
+	if s.scenario.Latency != 0 {
+		return s.scenario.Latency
+	}
+	return defaultLatency
+}
+
+func (s *Simulation) endpoints() []*Node {
+	res := append([]*Node{}, s.nodes...)
+	for _, n := range s.nodes {
+		if twin, ok := s.twins[n.ID]; ok {
+			res = append(res, twin)
+		}
+	}
+	return res
+}
+
+func (s *Simulation) newLogger(tag string) common.Logger {
+	if s.logBackend == nil {
+		return common.NewBackend(ioutil.Discard).Logger(tag, true)
+	}
+	return s.logBackend.Logger(tag, false)
+}
 
+// Nodes returns the nodes of the simulation, indexed as in the scenario
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Committee returns the shard committee, the member i runs the node i
+func (s *Simulation) Committee() []incognitokey.CommitteePublicKey {
+	return s.committee
+}
+
+// Verify checks that the final chains of all nodes agree, then the expectations of the scenario on the running nodes
+func (s *Simulation) Verify() error {
+	finalized := make(map[uint64]common.Hash)
+	for _, n := range s.nodes {
+		for height, hash := range n.chain.getFinalizedChain() {
+			if other, ok := finalized[height]; ok && !other.IsEqual(&hash) {
+				return fmt.Errorf("node %v finalized block %v at height %v, another node finalized %v",
+					n.ID, hash.String(), height, other.String())
+			}
+			finalized[height] = hash
+		}
+	}
+
+	expected := s.scenario.Expected
+	bestViewHash := ""
+	for _, n := range s.nodes {
+		if n.down {
+			continue
+		}
+		finalHeight := n.chain.GetFinalViewHeight()
+		if finalHeight < expected.MinFinalHeight {
+			return fmt.Errorf("node %v final height %v, expect at least %v", n.ID, finalHeight, expected.MinFinalHeight)
+		}
+		if expected.MaxFinalHeight != 0 && finalHeight > expected.MaxFinalHeight {
+			return fmt.Errorf("node %v final height %v, expect at most %v", n.ID, finalHeight, expected.MaxFinalHeight)
+		}
+		bestView := n.chain.GetBestView()
+		if expected.BestBlockVersion != 0 && bestView.GetBlock().GetVersion() != expected.BestBlockVersion {
+			return fmt.Errorf("node %v best block version %v, expect %v",
+				n.ID, bestView.GetBlock().GetVersion(), expected.BestBlockVersion)
+		}
+		if expected.SameBestView {
+			if bestViewHash == "" {
+				bestViewHash = bestView.GetHash().String()
+			} else if bestViewHash != bestView.GetHash().String() {
+				return fmt.Errorf("node %v best view %v height %v, another node chose %v",
+					n.ID, bestView.GetHash().String(), bestView.GetHeight(), bestViewHash)
+			}
+		}
+	}
+
+	evidences := blsbft.ByzantineDetectorObject.GetEquivocationEvidences()
+	if expected.NoEvidence && len(evidences) != 0 {
+		return fmt.Errorf("%v equivocations detected", len(evidences))
+	}
+	for _, validator := range expected.Evidences {
+		evidence, ok := evidences[s.committee[validator].GetMiningKeyBase58(common.BlsConsensus)]
+		if !ok {
+			return fmt.Errorf("no equivocation evidence against validator %v", validator)
+		}
+		if err := evidence.CheckConflict(); err != nil {
+			return fmt.Errorf("evidence against validator %v, %v", validator, err)
+		}
+	}
+	blackList, _ := blsbft.ByzantineDetectorObject.GetByzantineDetectorInfo()["BlackList"].(map[string]*rawdb_consensus.BlackListValidator)
+	for _, validator := range expected.BlackListed {
+		if _, ok := blackList[s.committee[validator].GetMiningKeyBase58(common.BlsConsensus)]; !ok {
+			return fmt.Errorf("validator %v is not black listed", validator)
+		}
+	}
+	return nil
 }
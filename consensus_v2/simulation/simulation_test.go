@@ -0,0 +1,47 @@
+// +build go1.25
+
+package simulation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/synctest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runScenario runs the timeslots of scenario on the fake clock of a synctest bubble
+func runScenario(t *testing.T, scenario *Scenario) *Simulation {
+	logFile, err := os.Create(filepath.Join(os.TempDir(), "simulation.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logFile.Close()
+	var sim *Simulation
+	synctest.Test(t, func(t *testing.T) {
+		sim, err = NewSimulation(scenario, logFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sim.Run()
+	})
+	return sim
+}
+
+func TestScenarioFiles(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		scenario, err := LoadScenario(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(scenario.Name, func(t *testing.T) {
+			sim := runScenario(t, scenario)
+			assert.NoError(t, sim.Verify())
+		})
+	}
+}
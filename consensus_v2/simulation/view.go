@@ -1,38 +1,70 @@
-package main
+// +build go1.25
+
+package simulation
 
 import (
-	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
-type State struct {
-	block     common.BlockInterface
+// View is the state of a simulated chain after a block, the committee never changes
+type View struct {
+	block     types.BlockInterface
+	prevHash  common.Hash
 	committee []incognitokey.CommitteePublicKey
 }
 
-func (s *State) GetHash() *common.Hash {
-	return s.block.Hash()
+func NewView(block types.BlockInterface, committee []incognitokey.CommitteePublicKey) *View {
+	return &View{
+		block:     block,
+		prevHash:  block.GetPrevHash(),
+		committee: committee,
+	}
+}
+
+func (v *View) GetHash() *common.Hash {
+	return v.block.Hash()
+}
+
+func (v *View) GetPreviousHash() *common.Hash {
+	return &v.prevHash
+}
+
+func (v *View) GetHeight() uint64 {
+	return v.block.GetHeight()
+}
+
+func (v *View) GetCommittee() []incognitokey.CommitteePublicKey {
+	return v.committee
+}
+
+func (v *View) GetPreviousBlockCommittee(db incdb.Database) ([]incognitokey.CommitteePublicKey, error) {
+	return v.committee, nil
 }
 
-func (s *State) GetPreviousHash() *common.Hash {
-	hash := s.block.GetPrevHash()
-	return &hash
+func (v *View) CommitteeStateVersion() int {
+	return 0
 }
 
-func (s *State) GetHeight() uint64 {
-	return s.block.GetHeight()
+func (v *View) GetBlock() types.BlockInterface {
+	return v.block
 }
 
-func (s *State) GetCommittee() []incognitokey.CommitteePublicKey {
-	return s.committee
+// GetBeaconHeight returns the beacon check point of a shard view, the height of a beacon view
+func (v *View) GetBeaconHeight() uint64 {
+	if shardBlock, ok := v.block.(*types.ShardBlock); ok {
+		return shardBlock.Header.BeaconHeight
+	}
+	return v.block.GetHeight()
 }
 
-func (s *State) GetProposerByTimeSlot(ts int64, version int) (incognitokey.CommitteePublicKey, int) {
-	id := blockchain.GetProposerByTimeSlot(ts, len(s.committee))
-	return s.committee[id], id
+func (v *View) GetProposerByTimeSlot(ts int64, version int) (incognitokey.CommitteePublicKey, int) {
+	return blsbft.GetProposerByTimeSlotFromCommitteeList(ts, v.committee, v.GetProposerLength())
 }
 
-func (s *State) GetBlock() common.BlockInterface {
-	return s.block
+func (v *View) GetProposerLength() int {
+	return len(v.committee)
 }
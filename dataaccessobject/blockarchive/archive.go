@@ -0,0 +1,320 @@
+package blockarchive
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DirName is the directory of the chain database path holding the archives of its chains
+	DirName = "archive"
+
+	segmentSuffix = ".seg"
+	// DefaultSegmentSize is the size after which a new segment file is started
+	DefaultSegmentSize = 256 << 20
+
+	headerSize = 9 // payload length (4) - flag (1) - crc32 of payload (4)
+
+	flagRaw  = 0
+	flagZstd = 1
+)
+
+var (
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+)
+
+func init() {
+	encoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	decoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(runtime.NumCPU()))
+}
+
+// Archive stores blocks in append-only segment files, every record is
+// [payload length][flag][crc32][payload] where the flag tells whether the payload is zstd compressed.
+// Records are addressed by their Location, the caller keeps the index.
+type Archive struct {
+	dir         string
+	compress    bool
+	segmentSize int64
+
+	lock    sync.RWMutex
+	current *os.File
+	segment uint32
+	size    int64
+	readers map[uint32]*os.File
+}
+
+// Open opens (or creates) the archive in dir. A record the process was writing when it stopped is
+// truncated from the last segment.
+func Open(dir string, compress bool) (*Archive, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, errors.Wrapf(err, "create archive dir %v", dir)
+	}
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	a := &Archive{
+		dir:         dir,
+		compress:    compress,
+		segmentSize: DefaultSegmentSize,
+		readers:     make(map[uint32]*os.File),
+	}
+	if len(segments) > 0 {
+		a.segment = segments[len(segments)-1]
+	}
+	if err := a.openSegment(a.segment, true); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// OpenMultiple opens one archive per chain in the archive directory of the chain database path dbPath
+func OpenMultiple(dbPath string, compress bool) (map[int]*Archive, error) {
+	m := make(map[int]*Archive)
+	for i := -1; i < common.MaxShardNumber; i++ {
+		newPath := chainDir(dbPath, i)
+		a, err := Open(newPath, compress)
+		if err != nil {
+			for _, opened := range m {
+				opened.Close()
+			}
+			return nil, err
+		}
+		m[i] = a
+	}
+	return m, nil
+}
+
+// Exists tells whether blocks of the chain database path dbPath were already archived
+func Exists(dbPath string) bool {
+	segments, err := listSegments(chainDir(dbPath, common.BeaconChainID))
+	return err == nil && len(segments) > 0
+}
+
+func chainDir(dbPath string, chainID int) string {
+	if chainID == common.BeaconChainID {
+		return path.Join(dbPath, DirName, common.BeaconChainDatabaseDirectory)
+	}
+	return path.Join(dbPath, DirName, common.ShardChainDatabaseDirectory+strconv.Itoa(chainID))
+}
+
+func listSegments(dir string) ([]uint32, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "list archive dir %v", dir)
+	}
+	segments := []uint32{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), segmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(f.Name(), segmentSuffix), 10, 32)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, uint32(id))
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+func (a *Archive) segmentPath(segment uint32) string {
+	return filepath.Join(a.dir, fmt.Sprintf("%06d%v", segment, segmentSuffix))
+}
+
+// openSegment makes segment the one records are appended to, recover drops a trailing partial record
+func (a *Archive) openSegment(segment uint32, recover bool) error {
+	f, err := os.OpenFile(a.segmentPath(segment), os.O_RDWR|os.O_CREATE, 0640)
+	if err != nil {
+		return errors.Wrapf(err, "open segment %v", segment)
+	}
+	size := int64(0)
+	if recover {
+		if size, err = validSize(f); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Truncate(size); err != nil {
+			f.Close()
+			return errors.Wrapf(err, "truncate segment %v", segment)
+		}
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "seek segment %v", segment)
+	}
+	a.current, a.segment, a.size = f, segment, size
+	return nil
+}
+
+// validSize returns the length of the records of f that are complete and match their checksum
+func validSize(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	offset := int64(0)
+	header := make([]byte, headerSize)
+	for offset+headerSize <= info.Size() {
+		if _, err := f.ReadAt(header, offset); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		length := int64(binary.BigEndian.Uint32(header[0:4]))
+		if offset+headerSize+length > info.Size() {
+			break
+		}
+		payload := make([]byte, length)
+		if _, err := f.ReadAt(payload, offset+headerSize); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[5:9]) {
+			break
+		}
+		offset += headerSize + length
+	}
+	return offset, nil
+}
+
+// Append writes data at the end of the archive and returns its location. The record is only
+// durable after Sync.
+func (a *Archive) Append(data []byte) (Location, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.current == nil {
+		return Location{}, errors.New("archive is closed")
+	}
+	if a.size > 0 && a.size >= a.segmentSize {
+		if err := a.current.Sync(); err != nil {
+			return Location{}, errors.WithStack(err)
+		}
+		if err := a.current.Close(); err != nil {
+			return Location{}, errors.WithStack(err)
+		}
+		if err := a.openSegment(a.segment+1, false); err != nil {
+			return Location{}, err
+		}
+	}
+	flag := byte(flagRaw)
+	payload := data
+	if a.compress {
+		flag = flagZstd
+		payload = encoder.EncodeAll(data, nil)
+	}
+	record := make([]byte, headerSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	record[4] = flag
+	binary.BigEndian.PutUint32(record[5:9], crc32.ChecksumIEEE(payload))
+	copy(record[headerSize:], payload)
+	if _, err := a.current.Write(record); err != nil {
+		// drop what was written so that the next record starts at a record boundary
+		a.current.Truncate(a.size)
+		a.current.Seek(a.size, io.SeekStart)
+		return Location{}, errors.Wrapf(err, "append to segment %v", a.segment)
+	}
+	loc := Location{Segment: a.segment, Offset: uint64(a.size), Size: uint32(len(record))}
+	a.size += int64(len(record))
+	return loc, nil
+}
+
+// Sync flushes the appended records to disk
+func (a *Archive) Sync() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.current == nil {
+		return errors.New("archive is closed")
+	}
+	return errors.WithStack(a.current.Sync())
+}
+
+// Read returns the data stored at loc
+func (a *Archive) Read(loc Location) ([]byte, error) {
+	if loc.Size < headerSize {
+		return nil, errors.Errorf("invalid location %v", loc)
+	}
+	f, err := a.reader(loc.Segment)
+	if err != nil {
+		return nil, err
+	}
+	record := make([]byte, loc.Size)
+	if _, err := f.ReadAt(record, int64(loc.Offset)); err != nil {
+		return nil, errors.Wrapf(err, "read %v", loc)
+	}
+	payload := record[headerSize:]
+	if uint32(len(payload)) != binary.BigEndian.Uint32(record[0:4]) || crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(record[5:9]) {
+		return nil, errors.Errorf("corrupted record at %v", loc)
+	}
+	switch record[4] {
+	case flagRaw:
+		return payload, nil
+	case flagZstd:
+		data, err := decoder.DecodeAll(payload, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decompress %v", loc)
+		}
+		return data, nil
+	default:
+		return nil, errors.Errorf("unknown record flag %v at %v", record[4], loc)
+	}
+}
+
+func (a *Archive) reader(segment uint32) (*os.File, error) {
+	a.lock.RLock()
+	f, ok := a.readers[segment]
+	closed := a.current == nil
+	a.lock.RUnlock()
+	if closed {
+		return nil, errors.New("archive is closed")
+	}
+	if ok {
+		return f, nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if f, ok := a.readers[segment]; ok {
+		return f, nil
+	}
+	f, err := os.Open(a.segmentPath(segment))
+	if err != nil {
+		return nil, errors.Wrapf(err, "open segment %v", segment)
+	}
+	a.readers[segment] = f
+	return f, nil
+}
+
+// Close syncs and closes the segment files
+func (a *Archive) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.current == nil {
+		return nil
+	}
+	for segment, f := range a.readers {
+		f.Close()
+		delete(a.readers, segment)
+	}
+	err := a.current.Sync()
+	if closeErr := a.current.Close(); err == nil {
+		err = closeErr
+	}
+	a.current = nil
+	return errors.WithStack(err)
+}
@@ -0,0 +1,84 @@
+package blockarchive
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func block(i int) []byte {
+	return bytes.Repeat([]byte(fmt.Sprintf(`{"Height":%v}`, i)), i%50+1)
+}
+
+func TestArchive_AppendRead(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_archive_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, compress := range []bool{false, true} {
+		a, err := Open(dir, compress)
+		assert.Nil(t, err)
+		a.segmentSize = 4096
+		locs := []Location{}
+		for i := 0; i < 200; i++ {
+			loc, err := a.Append(block(i))
+			assert.Nil(t, err)
+			locs = append(locs, loc)
+		}
+		assert.Nil(t, a.Sync())
+		assert.True(t, locs[len(locs)-1].Segment > 0, "segments are rolled over")
+		for i, loc := range locs {
+			decoded, err := DecodeLocation(loc.Bytes())
+			assert.Nil(t, err)
+			data, err := a.Read(decoded)
+			assert.Nil(t, err)
+			assert.Equal(t, block(i), data)
+		}
+		assert.Nil(t, a.Close())
+	}
+
+	// records written with and without compression stay readable
+	a, err := Open(dir, false)
+	assert.Nil(t, err)
+	defer a.Close()
+	loc, err := a.Append(block(7))
+	assert.Nil(t, err)
+	data, err := a.Read(loc)
+	assert.Nil(t, err)
+	assert.Equal(t, block(7), data)
+	_, err = a.Read(Location{Segment: loc.Segment, Offset: loc.Offset + 1, Size: loc.Size})
+	assert.NotNil(t, err)
+}
+
+func TestArchive_RecoverPartialRecord(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_archive_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	a, err := Open(dir, true)
+	assert.Nil(t, err)
+	first, err := a.Append(block(1))
+	assert.Nil(t, err)
+	second, err := a.Append(block(2))
+	assert.Nil(t, err)
+	assert.Nil(t, a.Close())
+
+	// the process stopped in the middle of the second record
+	assert.Nil(t, os.Truncate(a.segmentPath(second.Segment), int64(second.Offset)+int64(second.Size)/2))
+	a, err = Open(dir, true)
+	assert.Nil(t, err)
+	defer a.Close()
+	data, err := a.Read(first)
+	assert.Nil(t, err)
+	assert.Equal(t, block(1), data)
+	third, err := a.Append(block(3))
+	assert.Nil(t, err)
+	assert.Equal(t, second.Offset, third.Offset)
+	data, err = a.Read(third)
+	assert.Nil(t, err)
+	assert.Equal(t, block(3), data)
+}
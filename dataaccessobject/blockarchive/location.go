@@ -0,0 +1,42 @@
+package blockarchive
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const locationSize = 16
+
+// Location addresses a record of the archive
+type Location struct {
+	Segment uint32
+	Offset  uint64
+	Size    uint32
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("segment %v offset %v size %v", l.Segment, l.Offset, l.Size)
+}
+
+// Bytes encodes the location as segment (4) - offset (8) - size (4)
+func (l Location) Bytes() []byte {
+	b := make([]byte, locationSize)
+	binary.BigEndian.PutUint32(b[0:4], l.Segment)
+	binary.BigEndian.PutUint64(b[4:12], l.Offset)
+	binary.BigEndian.PutUint32(b[12:16], l.Size)
+	return b
+}
+
+// DecodeLocation is the reverse of Location.Bytes
+func DecodeLocation(b []byte) (Location, error) {
+	if len(b) != locationSize {
+		return Location{}, errors.Errorf("invalid location length %v", len(b))
+	}
+	return Location{
+		Segment: binary.BigEndian.Uint32(b[0:4]),
+		Offset:  binary.BigEndian.Uint64(b[4:12]),
+		Size:    binary.BigEndian.Uint32(b[12:16]),
+	}, nil
+}
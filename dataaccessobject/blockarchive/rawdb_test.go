@@ -0,0 +1,78 @@
+package blockarchive_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/blockarchive"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb/memdb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBlock struct {
+	Height uint64
+}
+
+// TestArchive_ShardBlocks moves finalized blocks out of the database and reads them back through rawdbv2
+func TestArchive_ShardBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_archive_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db := memdb.New("")
+	hashes := []common.Hash{}
+	for h := uint64(1); h <= 1200; h++ {
+		hash := common.HashH([]byte(fmt.Sprint(h)))
+		hashes = append(hashes, hash)
+		assert.Nil(t, rawdbv2.StoreShardBlock(db, hash, testBlock{Height: h}))
+		if h != 700 {
+			assert.Nil(t, rawdbv2.StoreFinalizedShardBlockHashByIndex(db, 1, h, hash))
+		}
+	}
+
+	moved, err := rawdbv2.ArchiveShardBlocks(db, 1, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, moved, "nothing is archived without an archive")
+
+	archive, err := blockarchive.Open(dir, true)
+	assert.Nil(t, err)
+	rawdbv2.SetBlockArchive(db, archive)
+	defer rawdbv2.SetBlockArchive(db, nil)
+	moved, err = rawdbv2.ArchiveShardBlocks(db, 1, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 999, moved, "heights without a finalized hash are skipped")
+	assert.Equal(t, uint64(1000), rawdbv2.GetLastArchivedShardBlockHeight(db, 1))
+	moved, err = rawdbv2.ArchiveShardBlocks(db, 1, 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, moved)
+
+	has, err := db.Has(rawdbv2.GetShardHashToBlockKey(hashes[0]))
+	assert.Nil(t, err)
+	assert.False(t, has)
+	for _, i := range []int{0, 499, 500, 998, 1199} {
+		has, err := rawdbv2.HasShardBlock(db, hashes[i])
+		assert.Nil(t, err)
+		assert.True(t, has)
+		data, err := rawdbv2.GetShardBlockByHash(db, hashes[i])
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf(`{"Height":%v}`, i+1), string(data))
+		hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, 1, uint64(i+1))
+		assert.Nil(t, err)
+		assert.Equal(t, hashes[i], *hash)
+	}
+	_, err = rawdbv2.GetShardBlockByHash(db, common.HashH([]byte("unknown")))
+	assert.NotNil(t, err)
+
+	// blocks cannot be read once the archive is gone
+	assert.Nil(t, archive.Close())
+	rawdbv2.SetBlockArchive(db, nil)
+	_, err = rawdbv2.GetShardBlockByHash(db, hashes[0])
+	assert.NotNil(t, err)
+	has, err = rawdbv2.HasShardBlock(db, hashes[0])
+	assert.Nil(t, err)
+	assert.True(t, has)
+}
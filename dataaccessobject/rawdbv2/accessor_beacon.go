@@ -52,6 +52,11 @@ func HasBeaconBlock(db incdb.KeyValueReader, hash common.Hash) (bool, error) {
 	} else if ok {
 		return true, nil
 	}
+	if ok, err := hasArchivedBlock(db, hash); err != nil {
+		return false, NewRawdbError(HasBeaconBlockError, err)
+	} else if ok {
+		return true, nil
+	}
 	return false, nil
 }
 
@@ -60,10 +65,17 @@ func GetBeaconBlockByHash(db incdb.KeyValueReader, hash common.Hash) ([]byte, er
 	if ok, err := db.Has(keyHash); err != nil {
 		return []byte{}, NewRawdbError(GetBeaconBlockByHashError, fmt.Errorf("has key %+v failed", keyHash))
 	} else if !ok {
+		// finalized blocks may have been moved to the block archive
+		if block, archived, err := getArchivedBlock(db, hash); archived {
+			return block, err
+		}
 		return []byte{}, NewRawdbError(GetBeaconBlockByHashError, fmt.Errorf("block %+v not exist", hash))
 	}
 	block, err := db.Get(keyHash)
 	if err != nil {
+		if block, archived, err := getArchivedBlock(db, hash); archived {
+			return block, err
+		}
 		return nil, NewRawdbError(GetBeaconBlockByHashError, err)
 	}
 	ret := make([]byte, len(block))
@@ -0,0 +1,144 @@
+package rawdbv2
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/blockarchive"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// archiveBatchSize is the number of blocks moved to the archive by one database batch
+const archiveBatchSize = 500
+
+// blockArchives maps a chain database to the archive holding its finalized blocks
+var blockArchives sync.Map
+
+// SetBlockArchive registers the archive the finalized blocks of db are moved to, nil unregisters it
+func SetBlockArchive(db incdb.Database, archive *blockarchive.Archive) {
+	if archive == nil {
+		blockArchives.Delete(db)
+		return
+	}
+	blockArchives.Store(db, archive)
+}
+
+// GetBlockArchive returns the archive registered for db, nil if there is none
+func GetBlockArchive(db incdb.KeyValueReader) *blockarchive.Archive {
+	if archive, ok := blockArchives.Load(db); ok {
+		return archive.(*blockarchive.Archive)
+	}
+	return nil
+}
+
+func hasArchivedBlock(db incdb.KeyValueReader, hash common.Hash) (bool, error) {
+	return db.Has(GetArchivedBlockKey(hash))
+}
+
+// getArchivedBlock reads a block that was moved out of the database, ok is false if it was not archived
+func getArchivedBlock(db incdb.KeyValueReader, hash common.Hash) ([]byte, bool, error) {
+	val, err := db.Get(GetArchivedBlockKey(hash))
+	if err != nil {
+		return nil, false, nil
+	}
+	loc, err := blockarchive.DecodeLocation(val)
+	if err != nil {
+		return nil, true, NewRawdbError(GetArchivedBlockError, err)
+	}
+	archive := GetBlockArchive(db)
+	if archive == nil {
+		return nil, true, NewRawdbError(GetArchivedBlockError, fmt.Errorf("block %+v is archived but the block archive is not opened", hash))
+	}
+	block, err := archive.Read(loc)
+	if err != nil {
+		return nil, true, NewRawdbError(GetArchivedBlockError, err)
+	}
+	return block, true, nil
+}
+
+// GetLastArchivedShardBlockHeight returns the height up to which the finalized blocks of the shard are archived
+func GetLastArchivedShardBlockHeight(db incdb.KeyValueReader, sid byte) uint64 {
+	return getLastArchivedHeight(db, GetLastArchivedShardBlockKey(sid))
+}
+
+// GetLastArchivedBeaconBlockHeight returns the height up to which the finalized beacon blocks are archived
+func GetLastArchivedBeaconBlockHeight(db incdb.KeyValueReader) uint64 {
+	return getLastArchivedHeight(db, GetLastArchivedBeaconBlockKey())
+}
+
+func getLastArchivedHeight(db incdb.KeyValueReader, key []byte) uint64 {
+	val, err := db.Get(key)
+	if err != nil {
+		return 0
+	}
+	height, err := common.BytesToUint64(val)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// ArchiveShardBlocks moves the finalized shard blocks up to toHeight from db to its block archive,
+// the height => hash index stays in db. It returns the number of moved blocks.
+func ArchiveShardBlocks(db incdb.Database, sid byte, toHeight uint64) (int, error) {
+	return archiveBlocks(db, GetLastArchivedShardBlockKey(sid), toHeight, GetShardHashToBlockKey, func(height uint64) (*common.Hash, error) {
+		return GetFinalizedShardBlockHashByIndex(db, sid, height)
+	})
+}
+
+// ArchiveBeaconBlocks moves the finalized beacon blocks up to toHeight from db to its block archive,
+// the height => hash index stays in db. It returns the number of moved blocks.
+func ArchiveBeaconBlocks(db incdb.Database, toHeight uint64) (int, error) {
+	return archiveBlocks(db, GetLastArchivedBeaconBlockKey(), toHeight, GetBeaconHashToBlockKey, func(height uint64) (*common.Hash, error) {
+		return GetFinalizedBeaconBlockHashByIndex(db, height)
+	})
+}
+
+// archiveBlocks appends the blocks to the archive before a batch replaces them by their location,
+// a crash in between only leaves unreferenced records in the archive
+func archiveBlocks(db incdb.Database, heightKey []byte, toHeight uint64, blockKey func(common.Hash) []byte, hashByIndex func(uint64) (*common.Hash, error)) (int, error) {
+	archive := GetBlockArchive(db)
+	if archive == nil {
+		return 0, nil
+	}
+	moved := 0
+	height := getLastArchivedHeight(db, heightKey) + 1
+	for height <= toHeight {
+		batch := db.NewBatch()
+		pending := 0
+		for n := 0; n < archiveBatchSize && height <= toHeight; n, height = n+1, height+1 {
+			// fast synced nodes do not have the blocks below their first view
+			hash, err := hashByIndex(height)
+			if err != nil {
+				continue
+			}
+			block, err := db.Get(blockKey(*hash))
+			if err != nil {
+				continue
+			}
+			loc, err := archive.Append(block)
+			if err != nil {
+				return moved, NewRawdbError(ArchiveBlockError, err)
+			}
+			if err := batch.Put(GetArchivedBlockKey(*hash), loc.Bytes()); err != nil {
+				return moved, NewRawdbError(ArchiveBlockError, err)
+			}
+			if err := batch.Delete(blockKey(*hash)); err != nil {
+				return moved, NewRawdbError(ArchiveBlockError, err)
+			}
+			pending++
+		}
+		if err := archive.Sync(); err != nil {
+			return moved, NewRawdbError(ArchiveBlockError, err)
+		}
+		if err := batch.Put(heightKey, common.Uint64ToBytes(height-1)); err != nil {
+			return moved, NewRawdbError(ArchiveBlockError, err)
+		}
+		if err := batch.Write(); err != nil {
+			return moved, NewRawdbError(ArchiveBlockError, err)
+		}
+		moved += pending
+	}
+	return moved, nil
+}
@@ -308,6 +308,7 @@ type Pdexv3Order struct {
 	token1Balance  uint64
 	tradeDirection byte
 	receiver       [2]string
+	expiryHeight   uint64
 }
 
 func (o *Pdexv3Order) Id() string             { return o.id }
@@ -320,6 +321,14 @@ func (o *Pdexv3Order) TradeDirection() byte   { return o.tradeDirection }
 func (o *Pdexv3Order) Token0Receiver() string { return o.receiver[0] }
 func (o *Pdexv3Order) Token1Receiver() string { return o.receiver[1] }
 
+// ExpiryHeight() is the beacon height from which the order is automatically withdrawn, 0 means it never expires
+func (o *Pdexv3Order) ExpiryHeight() uint64 { return o.expiryHeight }
+
+// IsExpired() returns true if the order has an expiry height that is reached at beaconHeight
+func (o *Pdexv3Order) IsExpired(beaconHeight uint64) bool {
+	return o.expiryHeight != 0 && beaconHeight >= o.expiryHeight
+}
+
 // SetToken0Balance() changes the token0 balance of this order. Only balances can be updated,
 // while rates, id & trade direction cannot
 func (o *Pdexv3Order) SetToken0Balance(b uint64) { o.token0Balance = b }
@@ -330,6 +339,7 @@ func NewPdexv3OrderWithValue(
 	token0Rate, token1Rate, token0Balance, token1Balance uint64,
 	tradeDirection byte,
 	receiver [2]string,
+	expiryHeight uint64,
 ) *Pdexv3Order {
 	return &Pdexv3Order{
 		id:             id,
@@ -340,6 +350,7 @@ func NewPdexv3OrderWithValue(
 		token1Balance:  token1Balance,
 		tradeDirection: tradeDirection,
 		receiver:       receiver,
+		expiryHeight:   expiryHeight,
 	}
 }
 
@@ -353,6 +364,7 @@ func (o *Pdexv3Order) MarshalJSON() ([]byte, error) {
 		Token1Balance  uint64      `json:"Token1Balance"`
 		TradeDirection byte        `json:"TradeDirection"`
 		Receiver       [2]string   `json:"Receiver"`
+		ExpiryHeight   uint64      `json:"ExpiryHeight,omitempty"`
 	}{
 		Id:             o.id,
 		NftID:          o.nftID,
@@ -362,6 +374,7 @@ func (o *Pdexv3Order) MarshalJSON() ([]byte, error) {
 		Token1Balance:  o.token1Balance,
 		TradeDirection: o.tradeDirection,
 		Receiver:       o.receiver,
+		ExpiryHeight:   o.expiryHeight,
 	})
 	if err != nil {
 		return []byte{}, err
@@ -379,6 +392,7 @@ func (o *Pdexv3Order) UnmarshalJSON(data []byte) error {
 		Token1Balance  uint64      `json:"Token1Balance"`
 		TradeDirection byte        `json:"TradeDirection"`
 		Receiver       [2]string   `json:"Receiver"`
+		ExpiryHeight   uint64      `json:"ExpiryHeight,omitempty"`
 	}
 	err := json.Unmarshal(data, &temp)
 	if err != nil {
@@ -394,11 +408,12 @@ func (o *Pdexv3Order) UnmarshalJSON(data []byte) error {
 		token1Balance:  temp.Token1Balance,
 		tradeDirection: temp.TradeDirection,
 		receiver:       temp.Receiver,
+		expiryHeight:   temp.ExpiryHeight,
 	}
 	return nil
 }
 
 func (o *Pdexv3Order) Clone() *Pdexv3Order {
 	return NewPdexv3OrderWithValue(o.id, o.nftID, o.token0Rate, o.token1Rate,
-		o.token0Balance, o.token1Balance, o.tradeDirection, o.receiver)
+		o.token0Balance, o.token1Balance, o.tradeDirection, o.receiver, o.expiryHeight)
 }
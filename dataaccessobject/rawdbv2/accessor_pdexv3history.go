@@ -0,0 +1,199 @@
+package rawdbv2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// Pdexv3PoolPairSnapshot is the state of a pool pair at a beacon height it changed at,
+// token maps are keyed by token ID string to survive the JSON round trip
+type Pdexv3PoolPairSnapshot struct {
+	PoolPairID          string              `json:"PoolPairID"`
+	BeaconHeight        uint64              `json:"BeaconHeight"`
+	Timestamp           int64               `json:"Timestamp"`
+	Token0ID            common.Hash         `json:"Token0ID"`
+	Token1ID            common.Hash         `json:"Token1ID"`
+	Token0RealAmount    uint64              `json:"Token0RealAmount"`
+	Token1RealAmount    uint64              `json:"Token1RealAmount"`
+	Token0VirtualAmount *big.Int            `json:"Token0VirtualAmount"`
+	Token1VirtualAmount *big.Int            `json:"Token1VirtualAmount"`
+	ShareAmount         uint64              `json:"ShareAmount"`
+	Amplifier           uint                `json:"Amplifier"`
+	LpFeesPerShare      map[string]*big.Int `json:"LpFeesPerShare"`
+}
+
+// SameState returns true if both snapshots hold the same reserves, share and LP fee checkpoints
+func (snapshot *Pdexv3PoolPairSnapshot) SameState(other *Pdexv3PoolPairSnapshot) bool {
+	if other == nil ||
+		snapshot.Token0RealAmount != other.Token0RealAmount ||
+		snapshot.Token1RealAmount != other.Token1RealAmount ||
+		snapshot.ShareAmount != other.ShareAmount ||
+		snapshot.Amplifier != other.Amplifier ||
+		!equalBigInt(snapshot.Token0VirtualAmount, other.Token0VirtualAmount) ||
+		!equalBigInt(snapshot.Token1VirtualAmount, other.Token1VirtualAmount) ||
+		len(snapshot.LpFeesPerShare) != len(other.LpFeesPerShare) {
+		return false
+	}
+	for tokenID, feePerShare := range snapshot.LpFeesPerShare {
+		if !equalBigInt(feePerShare, other.LpFeesPerShare[tokenID]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalBigInt(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// Pdexv3TradeRecord is a trade executed against a pool pair, a trade routed through several pools
+// is recorded once for each pool of its path with the changes of that pool
+type Pdexv3TradeRecord struct {
+	TxReqID      common.Hash            `json:"TxReqID"`
+	PoolPairID   string                 `json:"PoolPairID"`
+	BeaconHeight uint64                 `json:"BeaconHeight"`
+	Timestamp    int64                  `json:"Timestamp"`
+	TradePath    []string               `json:"TradePath"`
+	TokenToBuy   common.Hash            `json:"TokenToBuy"`
+	Amount       uint64                 `json:"Amount"`
+	Fee          map[string]uint64      `json:"Fee"`
+	Token0Change *big.Int               `json:"Token0Change"`
+	Token1Change *big.Int               `json:"Token1Change"`
+	OrderFills   map[string][2]*big.Int `json:"OrderFills"`
+}
+
+// TotalChanges returns the token changes of the trade on the pool reserves and the matched orders
+func (record *Pdexv3TradeRecord) TotalChanges() (*big.Int, *big.Int) {
+	token0Change, token1Change := big.NewInt(0), big.NewInt(0)
+	if record.Token0Change != nil {
+		token0Change.Add(token0Change, record.Token0Change)
+	}
+	if record.Token1Change != nil {
+		token1Change.Add(token1Change, record.Token1Change)
+	}
+	for _, changes := range record.OrderFills {
+		if changes[0] != nil {
+			token0Change.Add(token0Change, changes[0])
+		}
+		if changes[1] != nil {
+			token1Change.Add(token1Change, changes[1])
+		}
+	}
+	return token0Change, token1Change
+}
+
+// StorePdexv3History writes the pool snapshots and trades indexed from one beacon block in a single batch
+func StorePdexv3History(db incdb.Database, snapshots []*Pdexv3PoolPairSnapshot, trades []*Pdexv3TradeRecord) error {
+	if len(snapshots) == 0 && len(trades) == 0 {
+		return nil
+	}
+	batch := db.NewBatch()
+	for _, snapshot := range snapshots {
+		value, err := json.Marshal(snapshot)
+		if err != nil {
+			return NewRawdbError(StorePdexv3HistoryError, err)
+		}
+		if err := batch.Put(GetPdexv3PoolPairSnapshotKey(snapshot.PoolPairID, snapshot.BeaconHeight), value); err != nil {
+			return NewRawdbError(StorePdexv3HistoryError, err)
+		}
+	}
+	for _, trade := range trades {
+		value, err := json.Marshal(trade)
+		if err != nil {
+			return NewRawdbError(StorePdexv3HistoryError, err)
+		}
+		if err := batch.Put(GetPdexv3TradeRecordKey(trade.PoolPairID, trade.Timestamp, trade.TxReqID), value); err != nil {
+			return NewRawdbError(StorePdexv3HistoryError, err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return NewRawdbError(StorePdexv3HistoryError, err)
+	}
+	return nil
+}
+
+// GetPdexv3PoolPairSnapshot returns the last snapshot of a pool pair taken at or before beaconHeight,
+// nil if the pool pair was not indexed yet at that height
+func GetPdexv3PoolPairSnapshot(db incdb.Database, poolPairID string, beaconHeight uint64) (*Pdexv3PoolPairSnapshot, error) {
+	prefix := GetPdexv3PoolPairSnapshotPrefix(poolPairID)
+	iterator := db.NewIteratorWithPrefix(prefix)
+	defer iterator.Release()
+	var value []byte
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(prefix):]) > beaconHeight {
+			break
+		}
+		value = append(value[:0], iterator.Value()...)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetPdexv3HistoryError, err)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	snapshot := &Pdexv3PoolPairSnapshot{}
+	if err := json.Unmarshal(value, snapshot); err != nil {
+		return nil, NewRawdbError(GetPdexv3HistoryError, err)
+	}
+	return snapshot, nil
+}
+
+// GetLastPdexv3PoolPairSnapshots returns the last snapshot of every indexed pool pair
+func GetLastPdexv3PoolPairSnapshots(db incdb.Database) (map[string]*Pdexv3PoolPairSnapshot, error) {
+	iterator := db.NewIteratorWithPrefix(pdexv3PoolPairSnapshotPrefix)
+	defer iterator.Release()
+	result := make(map[string]*Pdexv3PoolPairSnapshot)
+	for iterator.Next() {
+		snapshot := &Pdexv3PoolPairSnapshot{}
+		if err := json.Unmarshal(iterator.Value(), snapshot); err != nil {
+			return nil, NewRawdbError(GetPdexv3HistoryError, err)
+		}
+		result[snapshot.PoolPairID] = snapshot
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetPdexv3HistoryError, err)
+	}
+	return result, nil
+}
+
+// GetPdexv3TradeRecords returns the trades of a pool pair with a timestamp in [fromTime, toTime] in
+// time order, at most limit records when limit is not 0
+func GetPdexv3TradeRecords(db incdb.Database, poolPairID string, fromTime, toTime int64, limit uint) ([]*Pdexv3TradeRecord, error) {
+	prefix := GetPdexv3TradeRecordPrefix(poolPairID)
+	iterator := db.NewIteratorWithStart(getPdexv3TradeRecordTimeKey(poolPairID, fromTime))
+	defer iterator.Release()
+	result := []*Pdexv3TradeRecord{}
+	for iterator.Next() {
+		key := iterator.Key()
+		if !bytes.HasPrefix(key, prefix) || len(key) != len(prefix)+8+common.HashSize {
+			break
+		}
+		if int64(binary.BigEndian.Uint64(key[len(prefix):])) > toTime {
+			break
+		}
+		record := &Pdexv3TradeRecord{}
+		if err := json.Unmarshal(iterator.Value(), record); err != nil {
+			return nil, NewRawdbError(GetPdexv3HistoryError, err)
+		}
+		result = append(result, record)
+		if limit != 0 && uint(len(result)) >= limit {
+			break
+		}
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetPdexv3HistoryError, err)
+	}
+	return result, nil
+}
@@ -51,6 +51,11 @@ func HasShardBlock(db incdb.KeyValueReader, hash common.Hash) (bool, error) {
 	} else if ok {
 		return true, nil
 	}
+	if ok, err := hasArchivedBlock(db, hash); err != nil {
+		return false, NewRawdbError(HasShardBlockError, err)
+	} else if ok {
+		return true, nil
+	}
 	return false, nil
 }
 
@@ -59,10 +64,17 @@ func GetShardBlockByHash(db incdb.KeyValueReader, hash common.Hash) ([]byte, err
 	if ok, err := db.Has(keyHash); err != nil {
 		return []byte{}, NewRawdbError(GetShardBlockByHashError, fmt.Errorf("has key %+v failed", keyHash))
 	} else if !ok {
+		// finalized blocks may have been moved to the block archive
+		if block, archived, err := getArchivedBlock(db, hash); archived {
+			return block, err
+		}
 		return []byte{}, NewRawdbError(GetShardBlockByHashError, fmt.Errorf("block %+v not exist", hash))
 	}
 	block, err := db.Get(keyHash)
 	if err != nil {
+		if block, archived, err := getArchivedBlock(db, hash); archived {
+			return block, err
+		}
 		return nil, NewRawdbError(GetShardBlockByHashError, err)
 	}
 	ret := make([]byte, len(block))
@@ -0,0 +1,42 @@
+package rawdbv2
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// StoreStateSnapshot stores an encoded state snapshot under its checkpoint
+func StoreStateSnapshot(db incdb.KeyValueWriter, checkpoint common.Hash, data []byte) error {
+	if err := db.Put(GetStateSnapshotKey(checkpoint), data); err != nil {
+		return NewRawdbError(StoreStateSnapshotError, err)
+	}
+	return nil
+}
+
+// GetStateSnapshot returns the encoded state snapshot stored under checkpoint
+func GetStateSnapshot(db incdb.KeyValueReader, checkpoint common.Hash) ([]byte, error) {
+	data, err := db.Get(GetStateSnapshotKey(checkpoint))
+	if err != nil {
+		return nil, NewRawdbError(GetStateSnapshotError, err)
+	}
+	return data, nil
+}
+
+// GetStateSnapshots returns every encoded state snapshot stored in db, by checkpoint
+func GetStateSnapshots(db incdb.Database) (map[common.Hash][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(stateSnapshotPrefix)
+	defer iterator.Release()
+	result := make(map[common.Hash][]byte)
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(stateSnapshotPrefix)+common.HashSize {
+			continue
+		}
+		checkpoint := common.BytesToHash(key[len(stateSnapshotPrefix):])
+		result[checkpoint] = append([]byte{}, iterator.Value()...)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetStateSnapshotError, err)
+	}
+	return result, nil
+}
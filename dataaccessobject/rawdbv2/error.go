@@ -77,6 +77,10 @@ const (
 	CleanUpPreviousShardBestStateError
 	RestoreCrossShardNextHeightsError
 	StoreShardPreCommitteeError
+	ArchiveBlockError
+	GetArchivedBlockError
+	StoreStateSnapshotError
+	GetStateSnapshotError
 	// tx
 	StoreTransactionIndexError
 	GetTransactionByHashError
@@ -103,6 +107,10 @@ const (
 	GetTxByCoinIndexError
 	StoreTxBySerialNumberError
 	GetTxBySerialNumberError
+
+	// pDEX v3 history
+	StorePdexv3HistoryError
+	GetPdexv3HistoryError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -142,6 +150,10 @@ var ErrCodeMessage = map[int]struct {
 	DeleteShardBlockByViewError:    {-2015, "Delete Shard Block By View"},
 	FinalizedShardBlockError:       {-2016, "Finalized Shard Block Error "},
 	GetFinalizedShardBlockError:    {-2017, "Get Finalized Shard Block Error"},
+	ArchiveBlockError:              {-2018, "Archive Block Error"},
+	GetArchivedBlockError:          {-2019, "Get Archived Block Error"},
+	StoreStateSnapshotError:        {-2020, "Store State Snapshot Error"},
+	GetStateSnapshotError:          {-2021, "Get State Snapshot Error"},
 
 	StoreTransactionIndexError:   {-3000, "Store Transaction Index Error"},
 	GetTransactionByHashError:    {-3001, "Get Transaction By Hash Error"},
@@ -196,6 +208,9 @@ var ErrCodeMessage = map[int]struct {
 	DeleteOTAKeyError:         {-6005, "Delete OTA keys error"},
 	StoreCoinHashError:        {-6006, "Store coin hash error"},
 	GetCoinHashError:          {-6007, "Get coin hash error"},
+
+	StorePdexv3HistoryError: {-7001, "Store pDEX v3 history error"},
+	GetPdexv3HistoryError:   {-7002, "Get pDEX v3 history error"},
 }
 
 type RawdbError struct {
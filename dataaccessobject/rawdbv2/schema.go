@@ -1,6 +1,8 @@
 package rawdbv2
 
 import (
+	"encoding/binary"
+
 	"github.com/incognitochain/incognito-chain/common"
 )
 
@@ -35,6 +37,12 @@ var (
 	shardSlashRootHashPrefix           = []byte("s-sl" + string(splitter))
 	shardFeatureRootHashPrefix         = []byte("s-fe" + string(splitter))
 	previousBestStatePrefix            = []byte("previous-best-state" + string(splitter))
+	archivedBlockPrefix                = []byte("a-b-l" + string(splitter))
+	lastArchivedShardBlockPrefix       = []byte("a-s-h" + string(splitter))
+	lastArchivedBeaconBlockKey         = []byte("a-b-h" + string(splitter))
+	pdexv3PoolPairSnapshotPrefix       = []byte("p3-p-s" + string(splitter))
+	pdexv3TradeRecordPrefix            = []byte("p3-t-r" + string(splitter))
+	stateSnapshotPrefix                = []byte("s-s-n" + string(splitter))
 	splitter                           = []byte("-[-]-")
 
 	// output coins by OTA key storage (optional)
@@ -141,6 +149,66 @@ func GetBeaconBlockHashToIndexKey(hash common.Hash) []byte {
 	return append(temp, hash[:]...)
 }
 
+// ============================= Block archive =======================================
+func GetArchivedBlockKey(hash common.Hash) []byte {
+	temp := make([]byte, 0, len(archivedBlockPrefix))
+	temp = append(temp, archivedBlockPrefix...)
+	return append(temp, hash[:]...)
+}
+
+func GetLastArchivedShardBlockKey(shardID byte) []byte {
+	temp := make([]byte, 0, len(lastArchivedShardBlockPrefix))
+	temp = append(temp, lastArchivedShardBlockPrefix...)
+	return append(temp, shardID)
+}
+
+func GetLastArchivedBeaconBlockKey() []byte {
+	temp := make([]byte, 0, len(lastArchivedBeaconBlockKey))
+	return append(temp, lastArchivedBeaconBlockKey...)
+}
+
+// ============================= State snapshot =======================================
+func GetStateSnapshotKey(checkpoint common.Hash) []byte {
+	temp := make([]byte, 0, len(stateSnapshotPrefix))
+	temp = append(temp, stateSnapshotPrefix...)
+	return append(temp, checkpoint[:]...)
+}
+
+// ============================= pDEX v3 history =======================================
+func GetPdexv3PoolPairSnapshotPrefix(poolPairID string) []byte {
+	temp := make([]byte, 0, len(pdexv3PoolPairSnapshotPrefix))
+	temp = append(temp, pdexv3PoolPairSnapshotPrefix...)
+	temp = append(temp, []byte(poolPairID)...)
+	return append(temp, splitter...)
+}
+
+// GetPdexv3PoolPairSnapshotKey orders the snapshots of a pool pair by beacon height
+func GetPdexv3PoolPairSnapshotKey(poolPairID string, beaconHeight uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, beaconHeight)
+	return append(GetPdexv3PoolPairSnapshotPrefix(poolPairID), buf...)
+}
+
+func GetPdexv3TradeRecordPrefix(poolPairID string) []byte {
+	temp := make([]byte, 0, len(pdexv3TradeRecordPrefix))
+	temp = append(temp, pdexv3TradeRecordPrefix...)
+	temp = append(temp, []byte(poolPairID)...)
+	return append(temp, splitter...)
+}
+
+// GetPdexv3TradeRecordKey orders the trades of a pool pair by block timestamp
+func GetPdexv3TradeRecordKey(poolPairID string, timestamp int64, txReqID common.Hash) []byte {
+	return append(getPdexv3TradeRecordTimeKey(poolPairID, timestamp), txReqID[:]...)
+}
+
+func getPdexv3TradeRecordTimeKey(poolPairID string, timestamp int64) []byte {
+	buf := make([]byte, 8)
+	if timestamp > 0 {
+		binary.BigEndian.PutUint64(buf, uint64(timestamp))
+	}
+	return append(GetPdexv3TradeRecordPrefix(poolPairID), buf...)
+}
+
 func GetBeaconViewsKey() []byte {
 	temp := make([]byte, 0, len(beaconViewsPrefix))
 	temp = append(temp, beaconViewsPrefix...)
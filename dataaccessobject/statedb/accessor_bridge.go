@@ -85,6 +85,31 @@ func IsPLGTxHashIssued(stateDB *StateDB, uniquePLGTx []byte) (bool, error) {
 	return true, nil
 }
 
+func InsertEVMNetworkTxHashIssued(stateDB *StateDB, networkID uint8, uniqueEVMTx []byte) error {
+	key := GenerateBridgeEVMNetworkTxObjectKey(networkID, uniqueEVMTx)
+	value := NewBridgeEVMNetworkTxStateWithValue(networkID, uniqueEVMTx)
+	err := stateDB.SetStateObject(BridgeEVMNetworkTxObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(BridgeInsertEVMNetworkTxHashIssuedError, err)
+	}
+	return nil
+}
+
+func IsEVMNetworkTxHashIssued(stateDB *StateDB, networkID uint8, uniqueEVMTx []byte) (bool, error) {
+	key := GenerateBridgeEVMNetworkTxObjectKey(networkID, uniqueEVMTx)
+	evmTxState, has, err := stateDB.getBridgeEVMNetworkTxState(key)
+	if err != nil {
+		return false, NewStatedbError(IsEVMNetworkTxHashIssuedError, err)
+	}
+	if !has {
+		return false, nil
+	}
+	if evmTxState.NetworkID() != networkID || bytes.Compare(evmTxState.UniqueEVMTx(), uniqueEVMTx) != 0 {
+		panic("same key wrong value")
+	}
+	return true, nil
+}
+
 func InsertSOLTxHashIssued(stateDB *StateDB, uniqueSOLTx []byte) error {
 	key := GenerateBridgeSOLTxObjectKey(uniqueSOLTx)
 	value := NewBridgeSOLTxStateWithValue(uniqueSOLTx)
@@ -306,6 +331,31 @@ func GetBridgeReqWithStatus(stateDB *StateDB, txReqID common.Hash) (byte, error)
 	return bridgeStatusState.Status(), nil
 }
 
+// TrackBridgeReqWithRejectReason tracks a rejected bridge request along with the reason it was rejected
+func TrackBridgeReqWithRejectReason(stateDB *StateDB, txReqID common.Hash, rejectReason string) error {
+	key := GenerateBridgeStatusObjectKey(txReqID)
+	value := NewBridgeStatusStateWithValue(txReqID, common.BridgeRequestRejectedStatus)
+	value.SetRejectReason(rejectReason)
+	err := stateDB.SetStateObject(BridgeStatusObjectType, key, value)
+	if err != nil {
+		return NewStatedbError(TrackBridgeReqWithStatusError, err)
+	}
+	return nil
+}
+
+// GetBridgeReqRejectReason returns the reason recorded for a rejected bridge request, empty if there is none
+func GetBridgeReqRejectReason(stateDB *StateDB, txReqID common.Hash) (string, error) {
+	key := GenerateBridgeStatusObjectKey(txReqID)
+	bridgeStatusState, has, err := stateDB.getBridgeStatusState(key)
+	if err != nil {
+		return "", NewStatedbError(GetBridgeReqWithStatusError, err)
+	}
+	if !has {
+		return "", nil
+	}
+	return bridgeStatusState.RejectReason(), nil
+}
+
 func IsBridgeToken(stateDB *StateDB, tokenID common.Hash) (
 	isBridgeTokens bool,
 	err error,
@@ -0,0 +1,52 @@
+package statedb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStateDB_TrackBridgeReqWithRejectReason(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejectedTxReqID := common.Hash{1}
+	acceptedTxReqID := common.Hash{2}
+	reason := "sol tx failed on chain: map[InstructionError:[0 map[Custom:1]]]"
+
+	if err := TrackBridgeReqWithRejectReason(sDB, rejectedTxReqID, reason); err != nil {
+		t.Fatal(err)
+	}
+	if err := TrackBridgeReqWithStatus(sDB, acceptedTxReqID, common.BridgeRequestAcceptedStatus); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sDB.Database().TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+	tempStateDB, err := NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := GetBridgeReqWithStatus(tempStateDB, rejectedTxReqID)
+	if err != nil || status != common.BridgeRequestRejectedStatus {
+		t.Fatalf("GetBridgeReqWithStatus() = %v, %v, want rejected", status, err)
+	}
+	gotReason, err := GetBridgeReqRejectReason(tempStateDB, rejectedTxReqID)
+	if err != nil || gotReason != reason {
+		t.Fatalf("GetBridgeReqRejectReason() = %v, %v, want %v", gotReason, err, reason)
+	}
+	gotReason, err = GetBridgeReqRejectReason(tempStateDB, acceptedTxReqID)
+	if err != nil || gotReason != "" {
+		t.Fatalf("GetBridgeReqRejectReason() = %v, %v, want empty reason", gotReason, err)
+	}
+	gotReason, err = GetBridgeReqRejectReason(tempStateDB, common.Hash{3})
+	if err != nil || gotReason != "" {
+		t.Fatalf("GetBridgeReqRejectReason() = %v, %v, want empty reason", gotReason, err)
+	}
+}
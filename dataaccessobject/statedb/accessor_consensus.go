@@ -705,6 +705,17 @@ func GetSlashingCommittee(stateDB *StateDB, epoch uint64) map[byte][]string {
 	return stateDB.getAllSlashingCommittee(epoch)
 }
 
+// StoreEquivocationEvidence keeps the last proven equivocation of each validator
+func StoreEquivocationEvidence(stateDB *StateDB, evidence *EquivocationEvidenceState) error {
+	key := GenerateEquivocationEvidenceObjectKey(evidence.CommitteePublicKey())
+	return stateDB.SetStateObject(EquivocationEvidenceObjectType, key, evidence)
+}
+
+func GetEquivocationEvidence(stateDB *StateDB, committeePublicKey string) (*EquivocationEvidenceState, bool, error) {
+	key := GenerateEquivocationEvidenceObjectKey(committeePublicKey)
+	return stateDB.getEquivocationEvidence(key)
+}
+
 func DeleteSyncingValidators(stateDB *StateDB, syncingValidators map[byte][]incognitokey.CommitteePublicKey) error {
 	for shardID, singleChainSyncingValidators := range syncingValidators {
 		err := deleteSyncingValidators(stateDB, shardID, SyncingValidators, singleChainSyncingValidators)
@@ -336,6 +336,37 @@ func TestStoreSlashingCommittee(t *testing.T) {
 	}
 }
 
+func TestStoreEquivocationEvidence(t *testing.T) {
+	sDB, _ := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	evidence := NewEquivocationEvidenceStateWithValue(
+		committeePublicKeys[0], 1, 100,
+		[]string{common.HashH([]byte{1}).String(), common.HashH([]byte{2}).String()},
+		common.HashH([]byte{3}), 10, 20,
+	)
+	if err := StoreEquivocationEvidence(sDB, evidence); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sDB.Database().TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+
+	got, has, err := GetEquivocationEvidence(sDB, committeePublicKeys[0])
+	if err != nil || !has {
+		t.Fatalf("GetEquivocationEvidence() has = %v, error = %v", has, err)
+	}
+	if !reflect.DeepEqual(got, evidence) {
+		t.Fatalf("want %+v, got %+v", evidence, got)
+	}
+	_, has, err = GetEquivocationEvidence(sDB, committeePublicKeys[1])
+	if err != nil || has {
+		t.Fatalf("GetEquivocationEvidence() has = %v, error = %v", has, err)
+	}
+}
+
 func TestStoreOneShardSubstitutesValidatorV3(t *testing.T) {
 	number := 10
 	limit := 20
@@ -282,3 +282,51 @@ func GetPortalConvertVaultRequestStatus(stateDB *StateDB, txID string) ([]byte,
 
 	return data, nil
 }
+
+// ================= Portal v4 Vault Rotation =================
+// Store and get the status of the vault rotation request by txID
+func StorePortalVaultRotationRequestStatus(stateDB *StateDB, txID string, statusContent []byte) error {
+	statusType := PortalVaultRotationRequestStatusPrefix()
+	statusSuffix := []byte(txID)
+	err := StorePortalV4Status(stateDB, statusType, statusSuffix, statusContent)
+	if err != nil {
+		return NewStatedbError(StorePortalVaultRotationRequestStatusError, err)
+	}
+
+	return nil
+}
+
+func GetPortalVaultRotationRequestStatus(stateDB *StateDB, txID string) ([]byte, error) {
+	statusType := PortalVaultRotationRequestStatusPrefix()
+	statusSuffix := []byte(txID)
+	data, err := GetPortalV4Status(stateDB, statusType, statusSuffix)
+	if err != nil {
+		return []byte{}, NewStatedbError(GetPortalVaultRotationRequestStatusError, err)
+	}
+
+	return data, nil
+}
+
+// Store and get the latest vault rotation of a portal token by tokenID
+func StorePortalVaultRotationStatus(stateDB *StateDB, tokenID string, statusContent []byte) error {
+	statusType := PortalVaultRotationStatusPrefix()
+	statusSuffix := []byte(tokenID)
+	err := StorePortalV4Status(stateDB, statusType, statusSuffix, statusContent)
+	if err != nil {
+		return NewStatedbError(StorePortalVaultRotationStatusError, err)
+	}
+
+	return nil
+}
+
+// GetPortalVaultRotationStatus returns empty data without error if the vault of tokenID has never been rotated
+func GetPortalVaultRotationStatus(stateDB *StateDB, tokenID string) ([]byte, error) {
+	statusType := PortalVaultRotationStatusPrefix()
+	statusSuffix := []byte(tokenID)
+	data, err := GetPortalV4Status(stateDB, statusType, statusSuffix)
+	if err != nil && err.(*StatedbError).GetErrorCode() != ErrCodeMessage[GetPortalStatusNotFoundError].Code {
+		return []byte{}, NewStatedbError(GetPortalVaultRotationStatusError, err)
+	}
+
+	return data, nil
+}
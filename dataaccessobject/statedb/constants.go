@@ -80,6 +80,11 @@ const (
 	// Solana bridge
 	BridgeSOLTxObjectType = 71
 
+	// generic evm network bridge
+	BridgeEVMNetworkTxObjectType = 72
+
+	EquivocationEvidenceObjectType = 73
+
 	// pDex v3
 	Pdexv3StatusObjectType                    = 48
 	Pdexv3ParamsObjectType                    = 49
@@ -37,6 +37,7 @@ const (
 	ErrInvalidBridgeBSCTxStateType            = "invalid bridge bsc tx state type"
 	ErrInvalidBridgePRVEVMStateType           = "invalid bridge prv evm tx state type"
 	ErrInvalidBridgePLGTxStateType            = "invalid bridge polygon tx state type"
+	ErrInvalidBridgeEVMNetworkTxStateType     = "invalid bridge evm network tx state type"
 	ErrInvalidBridgeSOLTxStateType            = "invalid bridge solana tx state type"
 	//A
 	ErrInvalidFinalExchangeRatesStateType  = "invalid final exchange rates state type"
@@ -52,6 +53,7 @@ const (
 	ErrInvalidPDETradingFeeStateType             = "invalid pde trading fee state type"
 	ErrInvalidUnlockOverRateCollateralsStateType = "invalid unlock over rate collaterals state type"
 	ErrInvalidSlasingCommitteeStateType          = "invalid slashing committee state type"
+	ErrInvalidEquivocationEvidenceStateType      = "invalid equivocation evidence state type"
 	ErrInvalidPortalV4StatusStateType            = "invalid portal v4 status state type"
 	ErrInvalidPortalExternalTxStateType          = "invalid portal external tx state type"
 	ErrInvalidPortalConfirmProofStateType        = "invalid portal confirm proof state type"
@@ -265,6 +267,10 @@ const (
 	StorePortalSubmitConfirmedTxRequestStatusError
 	StorePortalV4ConvertVaultRequestStatusError
 	GetPortalV4ConvertVaultRequestStatusError
+	StorePortalVaultRotationRequestStatusError
+	GetPortalVaultRotationRequestStatusError
+	StorePortalVaultRotationStatusError
+	GetPortalVaultRotationStatusError
 
 	// bsc bridge
 	BridgeInsertBSCTxHashIssuedError
@@ -293,6 +299,10 @@ const (
 	// Solana bridge
 	BridgeInsertSOLTxHashIssuedError
 	IsSOLTxHashIssuedError
+
+	// generic evm network bridge
+	BridgeInsertEVMNetworkTxHashIssuedError
+	IsEVMNetworkTxHashIssuedError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -456,6 +466,10 @@ var ErrCodeMessage = map[int]struct {
 	StorePortalSubmitConfirmedTxRequestStatusError:           {-15020, "Store portal submit confirmed tx request status error"},
 	StorePortalV4StatusError:                                 {-15021, "Store portal v4 status error"},
 	GetPortalV4StatusError:                                   {-15022, "Get portal v4 status error"},
+	StorePortalVaultRotationRequestStatusError:               {-15023, "Store portal vault rotation request status error"},
+	GetPortalVaultRotationRequestStatusError:                 {-15024, "Get portal vault rotation request status error"},
+	StorePortalVaultRotationStatusError:                      {-15025, "Store portal vault rotation status error"},
+	GetPortalVaultRotationStatusError:                        {-15026, "Get portal vault rotation status error"},
 
 	// bsc bridge
 	BridgeInsertBSCTxHashIssuedError: {-15100, "Bridge Insert BSC Tx Hash Issued Error"},
@@ -472,6 +486,10 @@ var ErrCodeMessage = map[int]struct {
 	// solana bridge
 	BridgeInsertSOLTxHashIssuedError: {-15108, "Bridge Insert Solana Tx Hash Issued Error"},
 	IsSOLTxHashIssuedError:           {-15109, "Is Solana Tx Hash Issued Error"},
+
+	// generic evm network bridge
+	BridgeInsertEVMNetworkTxHashIssuedError: {-15110, "Bridge Insert EVM Network Tx Hash Issued Error"},
+	IsEVMNetworkTxHashIssuedError:           {-15111, "Is EVM Network Tx Hash Issued Error"},
 }
 
 type StatedbError struct {
@@ -0,0 +1,21 @@
+package statedb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// GetProof returns the raw value stored under an object key along with the Merkle proof of it
+// against the root the state db was opened at, a nil value with a proof proves the key is absent.
+// The state db must not hold uncommitted changes. Proofs are verified with the stateproof package.
+func (stateDB *StateDB) GetProof(key common.Hash) ([]byte, [][]byte, error) {
+	value, err := stateDB.trie.TryGet(key[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := trie.ProofList{}
+	if err := stateDB.trie.Prove(key[:], 0, &proof); err != nil {
+		return nil, nil, err
+	}
+	return value, proof, nil
+}
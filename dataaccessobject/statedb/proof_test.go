@@ -0,0 +1,84 @@
+package statedb
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/stateproof"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateDB_GetProof(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	assert.Nil(t, err)
+	txReqIDs := []common.Hash{}
+	for i := 0; i < 100; i++ {
+		txReqID := common.HashH([]byte{byte(i)})
+		assert.Nil(t, TrackBridgeReqWithStatus(sDB, txReqID, common.BridgeRequestAcceptedStatus))
+		txReqIDs = append(txReqIDs, txReqID)
+	}
+	rootHash, err := sDB.Commit(true)
+	assert.Nil(t, err)
+	assert.Nil(t, sDB.Database().TrieDB().Commit(rootHash, false))
+	tempStateDB, err := NewWithPrefixTrie(rootHash, wrarperDB)
+	assert.Nil(t, err)
+
+	// existing object
+	key := GenerateBridgeStatusObjectKey(txReqIDs[7])
+	value, proof, err := tempStateDB.GetProof(key)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, value)
+	provenValue, err := stateproof.Verify(rootHash, key, proof)
+	assert.Nil(t, err)
+	assert.Equal(t, value, provenValue)
+
+	// the proof is bound to the root and the key
+	_, err = stateproof.Verify(common.Hash{1}, key, proof)
+	assert.NotNil(t, err)
+	otherKey := GenerateBridgeStatusObjectKey(txReqIDs[8])
+	provenValue, err = stateproof.Verify(rootHash, otherKey, proof)
+	assert.True(t, err != nil || provenValue == nil)
+
+	// tampered nodes are rejected
+	tampered := [][]byte{}
+	for _, node := range proof {
+		tampered = append(tampered, common.CopyBytes(node))
+	}
+	tampered[len(tampered)-1][len(tampered[len(tampered)-1])-1] ^= 0xff
+	_, err = stateproof.Verify(rootHash, key, tampered)
+	assert.NotNil(t, err)
+
+	// a getstateproof result is only verified against the trusted root
+	stateProof := struct {
+		Root  string
+		Key   string
+		Value string
+		Proof []string
+	}{Root: rootHash.String(), Key: key.String(), Value: hex.EncodeToString(value)}
+	for _, node := range proof {
+		stateProof.Proof = append(stateProof.Proof, hex.EncodeToString(node))
+	}
+	stateProofJSON, err := json.Marshal(stateProof)
+	assert.Nil(t, err)
+	provenHex, err := stateproof.VerifyJSON(rootHash.String(), string(stateProofJSON))
+	assert.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(value), provenHex)
+	_, err = stateproof.VerifyJSON(common.Hash{1}.String(), string(stateProofJSON))
+	assert.NotNil(t, err)
+	stateProof.Value = hex.EncodeToString([]byte("forged"))
+	stateProofJSON, err = json.Marshal(stateProof)
+	assert.Nil(t, err)
+	_, err = stateproof.VerifyJSON(rootHash.String(), string(stateProofJSON))
+	assert.NotNil(t, err)
+
+	// absent object
+	absentKey := GenerateBridgeStatusObjectKey(common.Hash{1})
+	value, proof, err = tempStateDB.GetProof(absentKey)
+	assert.Nil(t, err)
+	assert.Empty(t, value)
+	provenValue, err = stateproof.Verify(rootHash, absentKey, proof)
+	assert.Nil(t, err)
+	assert.Nil(t, provenValue)
+}
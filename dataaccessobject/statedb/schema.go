@@ -21,6 +21,7 @@ var (
 	currentBeaconCandidatePrefix       = []byte("cur-bea-cand-")
 	committeeRewardPrefix              = []byte("committee-reward-")
 	slashingCommitteePrefix            = []byte("slashing-committee-")
+	equivocationEvidencePrefix         = []byte("equivocation-evidence-")
 	rewardRequestPrefix                = []byte("reward-request-")
 	blackListProducerPrefix            = []byte("black-list-")
 	serialNumberPrefix                 = []byte("serial-number-")
@@ -48,6 +49,7 @@ var (
 	bridgeBSCTxPrefix                  = []byte("bri-bsc-tx-")
 	bridgePLGTxPrefix                  = []byte("bri-plg-tx-")
 	bridgeSOLTxPrefix                  = []byte("bri-sol-tx-")
+	bridgeEVMNetworkTxPrefix           = []byte("bri-evm-net-tx-")
 	bridgePRVEVMPrefix                 = []byte("bri-prv-evm-tx-")
 	bridgeCentralizedTokenInfoPrefix   = []byte("bri-cen-token-info-")
 	bridgeDecentralizedTokenInfoPrefix = []byte("bri-de-token-info-")
@@ -154,6 +156,8 @@ var (
 	portalUnshielFeeReplacementBatchStatusPrefix = []byte("unshieldrequestbatchfeereplacementprocessed-")
 	portalUnshielSubmitConfirmedTxStatusPrefix   = []byte("unshieldrequestsubmitconfirmedtx-")
 	portalConvertVaultRequestPrefix              = []byte("portalconvertvaultrequest-")
+	portalVaultRotationRequestStatusPrefix       = []byte("portalvaultrotationrequest-")
+	portalVaultRotationStatusPrefix              = []byte("portalvaultrotation-")
 )
 
 func GetCommitteePrefixWithRole(role int, shardID int) []byte {
@@ -224,6 +228,11 @@ func GetSlashingCommitteePrefix(epoch uint64) []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetEquivocationEvidencePrefix() []byte {
+	h := common.HashH(equivocationEvidencePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetCommitteeRewardPrefix() []byte {
 	h := common.HashH(committeeRewardPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -355,6 +364,11 @@ func GetBridgeSOLTxPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetBridgeEVMNetworkTxPrefix() []byte {
+	h := common.HashH(bridgeEVMNetworkTxPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetBridgeTokenInfoPrefix(isCentralized bool) []byte {
 	if isCentralized {
 		h := common.HashH(bridgeCentralizedTokenInfoPrefix)
@@ -800,6 +814,14 @@ func PortaConvertVaultRequestStatusPrefix() []byte {
 	return portalConvertVaultRequestPrefix
 }
 
+func PortalVaultRotationRequestStatusPrefix() []byte {
+	return portalVaultRotationRequestStatusPrefix
+}
+
+func PortalVaultRotationStatusPrefix() []byte {
+	return portalVaultRotationStatusPrefix
+}
+
 // Portal v4 prefix hash of the key
 
 func GetPortalV4StatusPrefix(statusType []byte) []byte {
@@ -884,6 +906,13 @@ var _ = func() (_ struct{}) {
 		panic("black-list-" + " same prefix " + v)
 	}
 	m[string(tempBlackListProducer)] = "black-list-"
+	// equivocation evidence
+	tempEquivocationEvidence := GetEquivocationEvidencePrefix()
+	prefixs = append(prefixs, tempEquivocationEvidence)
+	if v, ok := m[string(tempEquivocationEvidence)]; ok {
+		panic("equivocation-evidence-" + " same prefix " + v)
+	}
+	m[string(tempEquivocationEvidence)] = "equivocation-evidence-"
 	for i, v1 := range prefixs {
 		for j, v2 := range prefixs {
 			if i == j {
@@ -424,6 +424,21 @@ func (stateDB *StateDB) getStakerInfo(key common.Hash) (*StakerInfo, bool, error
 	return NewStakerInfo(), false, nil
 }
 
+func (stateDB *StateDB) getEquivocationEvidence(key common.Hash) (*EquivocationEvidenceState, bool, error) {
+	evidenceObject, err := stateDB.getStateObject(EquivocationEvidenceObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if evidenceObject != nil {
+		res, ok := evidenceObject.GetValue().(*EquivocationEvidenceState)
+		if !ok {
+			err = fmt.Errorf("Can not parse equivocation evidence")
+		}
+		return res, true, err
+	}
+	return NewEquivocationEvidenceState(), false, nil
+}
+
 func (stateDB *StateDB) getStakerObject(key common.Hash) (*StateObject, bool, error) {
 	stakerObject, err := stateDB.getStateObject(StakerObjectType, key)
 	if err != nil {
@@ -2498,6 +2513,18 @@ func (stateDB *StateDB) getBridgePLGTxState(key common.Hash) (*BridgePLGTxState,
 	return NewBridgePLGTxState(), false, nil
 }
 
+// ================================= EVM network bridge OBJECT =======================================
+func (stateDB *StateDB) getBridgeEVMNetworkTxState(key common.Hash) (*BridgeEVMNetworkTxState, bool, error) {
+	evmTxState, err := stateDB.getStateObject(BridgeEVMNetworkTxObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if evmTxState != nil {
+		return evmTxState.GetValue().(*BridgeEVMNetworkTxState), true, nil
+	}
+	return NewBridgeEVMNetworkTxState(), false, nil
+}
+
 // ================================= Solana bridge OBJECT =======================================
 func (stateDB *StateDB) getBridgeSOLTxState(key common.Hash) (*BridgeSOLTxState, bool, error) {
 	solTxState, err := stateDB.getStateObject(BridgeSOLTxObjectType, key)
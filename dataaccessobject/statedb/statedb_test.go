@@ -2,15 +2,14 @@ package statedb
 
 import (
 	"bytes"
-	"io/ioutil"
 	"math/rand"
-	"os"
 	"reflect"
 	"testing"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
 	"github.com/incognitochain/incognito-chain/trie"
 )
 
@@ -40,11 +39,7 @@ var (
 	limit1      = 1
 )
 var _ = func() (_ struct{}) {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
-	if err != nil {
-		panic(err)
-	}
-	diskBD, _ := incdb.Open("leveldb", dbPath)
+	diskBD, _ := incdb.Open("memdb")
 	warperDBStatedbTest = NewDatabaseAccessWarper(diskBD)
 	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	return
@@ -117,6 +117,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newProcessUnshieldRequestBatchObjectWithValue(db, hash, value)
 	case SlashingCommitteeObjectType:
 		return newSlashingCommitteeObjectWithValue(db, hash, value)
+	case EquivocationEvidenceObjectType:
+		return newEquivocationEvidenceObjectWithValue(db, hash, value)
 	case Pdexv3StatusObjectType:
 		return newPdexv3StatusObjectWithValue(db, hash, value)
 	case Pdexv3ParamsObjectType:
@@ -163,6 +165,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newBridgePLGTxObjectWithValue(db, hash, value)
 	case BridgeSOLTxObjectType:
 		return newBridgeSOLTxObjectWithValue(db, hash, value)
+	case BridgeEVMNetworkTxObjectType:
+		return newBridgeEVMNetworkTxObjectWithValue(db, hash, value)
 	default:
 		panic("state object type not exist")
 	}
@@ -260,6 +264,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newProcessUnshieldRequestBatchObject(db, hash)
 	case SlashingCommitteeObjectType:
 		return newSlashingCommitteeObject(db, hash)
+	case EquivocationEvidenceObjectType:
+		return newEquivocationEvidenceObject(db, hash)
 	case Pdexv3StatusObjectType:
 		return newPdexv3StatusObject(db, hash)
 	case Pdexv3ParamsObjectType:
@@ -306,6 +312,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newBridgePLGTxObject(db, hash)
 	case BridgeSOLTxObjectType:
 		return newBridgeSOLTxObject(db, hash)
+	case BridgeEVMNetworkTxObjectType:
+		return newBridgeEVMNetworkTxObject(db, hash)
 	default:
 		panic("state object type not exist")
 	}
@@ -0,0 +1,202 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+type BridgeEVMNetworkTxState struct {
+	networkID   uint8
+	uniqueEVMTx []byte
+}
+
+func (evmTx BridgeEVMNetworkTxState) NetworkID() uint8 {
+	return evmTx.networkID
+}
+
+func (evmTx *BridgeEVMNetworkTxState) SetNetworkID(networkID uint8) {
+	evmTx.networkID = networkID
+}
+
+func (evmTx BridgeEVMNetworkTxState) UniqueEVMTx() []byte {
+	return evmTx.uniqueEVMTx
+}
+
+func (evmTx *BridgeEVMNetworkTxState) SetUniqueEVMTx(uniqueEVMTx []byte) {
+	evmTx.uniqueEVMTx = uniqueEVMTx
+}
+
+func NewBridgeEVMNetworkTxState() *BridgeEVMNetworkTxState {
+	return &BridgeEVMNetworkTxState{}
+}
+
+func NewBridgeEVMNetworkTxStateWithValue(networkID uint8, uniqueEVMTx []byte) *BridgeEVMNetworkTxState {
+	return &BridgeEVMNetworkTxState{networkID: networkID, uniqueEVMTx: uniqueEVMTx}
+}
+
+func (evmTx BridgeEVMNetworkTxState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		NetworkID   uint8
+		UniqueEVMTx []byte
+	}{
+		NetworkID:   evmTx.networkID,
+		UniqueEVMTx: evmTx.uniqueEVMTx,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (evmTx *BridgeEVMNetworkTxState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		NetworkID   uint8
+		UniqueEVMTx []byte
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	evmTx.networkID = temp.NetworkID
+	evmTx.uniqueEVMTx = temp.UniqueEVMTx
+	return nil
+}
+
+type BridgeEVMNetworkTxObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                 int
+	bridgeEVMNetworkTxHash  common.Hash
+	BridgeEVMNetworkTxState *BridgeEVMNetworkTxState
+	objectType              int
+	deleted                 bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newBridgeEVMNetworkTxObject(db *StateDB, hash common.Hash) *BridgeEVMNetworkTxObject {
+	return &BridgeEVMNetworkTxObject{
+		version:                 defaultVersion,
+		db:                      db,
+		bridgeEVMNetworkTxHash:  hash,
+		BridgeEVMNetworkTxState: NewBridgeEVMNetworkTxState(),
+		objectType:              BridgeEVMNetworkTxObjectType,
+		deleted:                 false,
+	}
+}
+
+func newBridgeEVMNetworkTxObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*BridgeEVMNetworkTxObject, error) {
+	var newBridgeEVMNetworkTxState = NewBridgeEVMNetworkTxState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newBridgeEVMNetworkTxState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newBridgeEVMNetworkTxState, ok = data.(*BridgeEVMNetworkTxState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidBridgeEVMNetworkTxStateType, reflect.TypeOf(data))
+		}
+	}
+	return &BridgeEVMNetworkTxObject{
+		version:                 defaultVersion,
+		bridgeEVMNetworkTxHash:  key,
+		BridgeEVMNetworkTxState: newBridgeEVMNetworkTxState,
+		db:                      db,
+		objectType:              BridgeEVMNetworkTxObjectType,
+		deleted:                 false,
+	}, nil
+}
+
+func GenerateBridgeEVMNetworkTxObjectKey(networkID uint8, uniqueEVMTx []byte) common.Hash {
+	prefixHash := GetBridgeEVMNetworkTxPrefix()
+	valueHash := common.HashH(append([]byte{networkID}, uniqueEVMTx...))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (evmTx BridgeEVMNetworkTxObject) GetVersion() int {
+	return evmTx.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (evmTx *BridgeEVMNetworkTxObject) SetError(err error) {
+	if evmTx.dbErr == nil {
+		evmTx.dbErr = err
+	}
+}
+
+func (evmTx BridgeEVMNetworkTxObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return evmTx.trie
+}
+
+func (evmTx *BridgeEVMNetworkTxObject) SetValue(data interface{}) error {
+	var newBridgeEVMNetworkTxState = NewBridgeEVMNetworkTxState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newBridgeEVMNetworkTxState)
+		if err != nil {
+			return err
+		}
+	} else {
+		newBridgeEVMNetworkTxState, ok = data.(*BridgeEVMNetworkTxState)
+		if !ok {
+			return fmt.Errorf("%+v, got type %+v", ErrInvalidBridgeEVMNetworkTxStateType, reflect.TypeOf(data))
+		}
+	}
+	evmTx.BridgeEVMNetworkTxState = newBridgeEVMNetworkTxState
+	return nil
+}
+
+func (evmTx BridgeEVMNetworkTxObject) GetValue() interface{} {
+	return evmTx.BridgeEVMNetworkTxState
+}
+
+func (evmTx BridgeEVMNetworkTxObject) GetValueBytes() []byte {
+	data := evmTx.GetValue()
+	value, err := json.Marshal(data)
+	if err != nil {
+		panic("failed to marshal bridge evm network tx state")
+	}
+	return value
+}
+
+func (evmTx BridgeEVMNetworkTxObject) GetHash() common.Hash {
+	return evmTx.bridgeEVMNetworkTxHash
+}
+
+func (evmTx BridgeEVMNetworkTxObject) GetType() int {
+	return evmTx.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (evmTx *BridgeEVMNetworkTxObject) MarkDelete() {
+	evmTx.deleted = true
+}
+
+func (evmTx *BridgeEVMNetworkTxObject) Reset() bool {
+	evmTx.BridgeEVMNetworkTxState = NewBridgeEVMNetworkTxState()
+	return true
+}
+
+func (evmTx BridgeEVMNetworkTxObject) IsDeleted() bool {
+	return evmTx.deleted
+}
+
+// value is either default or nil
+func (evmTx BridgeEVMNetworkTxObject) IsEmpty() bool {
+	temp := NewBridgeEVMNetworkTxState()
+	return reflect.DeepEqual(temp, evmTx.BridgeEVMNetworkTxState) || evmTx.BridgeEVMNetworkTxState == nil
+}
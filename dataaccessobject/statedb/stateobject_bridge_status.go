@@ -9,8 +9,9 @@ import (
 )
 
 type BridgeStatusState struct {
-	txReqID common.Hash
-	status  byte
+	txReqID      common.Hash
+	status       byte
+	rejectReason string
 }
 
 func (s BridgeStatusState) TxReqID() common.Hash {
@@ -29,13 +30,23 @@ func (s *BridgeStatusState) SetStatus(status byte) {
 	s.status = status
 }
 
+func (s BridgeStatusState) RejectReason() string {
+	return s.rejectReason
+}
+
+func (s *BridgeStatusState) SetRejectReason(rejectReason string) {
+	s.rejectReason = rejectReason
+}
+
 func (s BridgeStatusState) MarshalJSON() ([]byte, error) {
 	data, err := json.Marshal(struct {
-		TxReqID common.Hash
-		Status  byte
+		TxReqID      common.Hash
+		Status       byte
+		RejectReason string `json:"RejectReason,omitempty"`
 	}{
-		TxReqID: s.txReqID,
-		Status:  s.status,
+		TxReqID:      s.txReqID,
+		Status:       s.status,
+		RejectReason: s.rejectReason,
 	})
 	if err != nil {
 		return []byte{}, err
@@ -45,8 +56,9 @@ func (s BridgeStatusState) MarshalJSON() ([]byte, error) {
 
 func (s *BridgeStatusState) UnmarshalJSON(data []byte) error {
 	temp := struct {
-		TxReqID common.Hash
-		Status  byte
+		TxReqID      common.Hash
+		Status       byte
+		RejectReason string `json:"RejectReason,omitempty"`
 	}{}
 	err := json.Unmarshal(data, &temp)
 	if err != nil {
@@ -54,6 +66,7 @@ func (s *BridgeStatusState) UnmarshalJSON(data []byte) error {
 	}
 	s.txReqID = temp.TxReqID
 	s.status = temp.Status
+	s.rejectReason = temp.RejectReason
 	return nil
 }
 
@@ -0,0 +1,247 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// EquivocationEvidenceState records the last proven equivocation of a validator
+type EquivocationEvidenceState struct {
+	committeePublicKey string
+	chainID            int
+	proposeTimeSlot    int64
+	blockHashes        []string
+	txReqID            common.Hash
+	beaconHeight       uint64
+	slashedPercent     uint
+}
+
+func NewEquivocationEvidenceState() *EquivocationEvidenceState {
+	return &EquivocationEvidenceState{}
+}
+
+func NewEquivocationEvidenceStateWithValue(
+	committeePublicKey string,
+	chainID int,
+	proposeTimeSlot int64,
+	blockHashes []string,
+	txReqID common.Hash,
+	beaconHeight uint64,
+	slashedPercent uint,
+) *EquivocationEvidenceState {
+	return &EquivocationEvidenceState{
+		committeePublicKey: committeePublicKey,
+		chainID:            chainID,
+		proposeTimeSlot:    proposeTimeSlot,
+		blockHashes:        blockHashes,
+		txReqID:            txReqID,
+		beaconHeight:       beaconHeight,
+		slashedPercent:     slashedPercent,
+	}
+}
+
+func (s *EquivocationEvidenceState) CommitteePublicKey() string {
+	return s.committeePublicKey
+}
+
+func (s *EquivocationEvidenceState) ChainID() int {
+	return s.chainID
+}
+
+func (s *EquivocationEvidenceState) ProposeTimeSlot() int64 {
+	return s.proposeTimeSlot
+}
+
+func (s *EquivocationEvidenceState) BlockHashes() []string {
+	return s.blockHashes
+}
+
+func (s *EquivocationEvidenceState) TxReqID() common.Hash {
+	return s.txReqID
+}
+
+func (s *EquivocationEvidenceState) BeaconHeight() uint64 {
+	return s.beaconHeight
+}
+
+func (s *EquivocationEvidenceState) SlashedPercent() uint {
+	return s.slashedPercent
+}
+
+func (s EquivocationEvidenceState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		ChainID            int
+		ProposeTimeSlot    int64
+		BlockHashes        []string
+		TxReqID            common.Hash
+		BeaconHeight       uint64
+		SlashedPercent     uint
+	}{
+		CommitteePublicKey: s.committeePublicKey,
+		ChainID:            s.chainID,
+		ProposeTimeSlot:    s.proposeTimeSlot,
+		BlockHashes:        s.blockHashes,
+		TxReqID:            s.txReqID,
+		BeaconHeight:       s.beaconHeight,
+		SlashedPercent:     s.slashedPercent,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *EquivocationEvidenceState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		ChainID            int
+		ProposeTimeSlot    int64
+		BlockHashes        []string
+		TxReqID            common.Hash
+		BeaconHeight       uint64
+		SlashedPercent     uint
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.committeePublicKey = temp.CommitteePublicKey
+	s.chainID = temp.ChainID
+	s.proposeTimeSlot = temp.ProposeTimeSlot
+	s.blockHashes = temp.BlockHashes
+	s.txReqID = temp.TxReqID
+	s.beaconHeight = temp.BeaconHeight
+	s.slashedPercent = temp.SlashedPercent
+	return nil
+}
+
+type EquivocationEvidenceObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                       int
+	equivocationEvidenceObjectKey common.Hash
+	equivocationEvidenceState     *EquivocationEvidenceState
+	objectType                    int
+	deleted                       bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newEquivocationEvidenceObject(db *StateDB, hash common.Hash) *EquivocationEvidenceObject {
+	return &EquivocationEvidenceObject{
+		version:                       defaultVersion,
+		db:                            db,
+		equivocationEvidenceObjectKey: hash,
+		equivocationEvidenceState:     NewEquivocationEvidenceState(),
+		objectType:                    EquivocationEvidenceObjectType,
+		deleted:                       false,
+	}
+}
+
+func newEquivocationEvidenceObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*EquivocationEvidenceObject, error) {
+	var newEquivocationEvidenceState = NewEquivocationEvidenceState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newEquivocationEvidenceState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newEquivocationEvidenceState, ok = data.(*EquivocationEvidenceState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidEquivocationEvidenceStateType, reflect.TypeOf(data))
+		}
+	}
+	return &EquivocationEvidenceObject{
+		version:                       defaultVersion,
+		equivocationEvidenceObjectKey: key,
+		equivocationEvidenceState:     newEquivocationEvidenceState,
+		db:                            db,
+		objectType:                    EquivocationEvidenceObjectType,
+		deleted:                       false,
+	}, nil
+}
+
+func GenerateEquivocationEvidenceObjectKey(committeePublicKey string) common.Hash {
+	prefixHash := GetEquivocationEvidencePrefix()
+	valueHash := common.HashH([]byte(committeePublicKey))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (c EquivocationEvidenceObject) GetVersion() int {
+	return c.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (c *EquivocationEvidenceObject) SetError(err error) {
+	if c.dbErr == nil {
+		c.dbErr = err
+	}
+}
+
+func (c EquivocationEvidenceObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return c.trie
+}
+
+func (c *EquivocationEvidenceObject) SetValue(data interface{}) error {
+	newEquivocationEvidenceState, ok := data.(*EquivocationEvidenceState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidEquivocationEvidenceStateType, reflect.TypeOf(data))
+	}
+	c.equivocationEvidenceState = newEquivocationEvidenceState
+	return nil
+}
+
+func (c EquivocationEvidenceObject) GetValue() interface{} {
+	return c.equivocationEvidenceState
+}
+
+func (c EquivocationEvidenceObject) GetValueBytes() []byte {
+	data := c.GetValue()
+	value, err := json.Marshal(data)
+	if err != nil {
+		panic("failed to marshal equivocation evidence state")
+	}
+	return value
+}
+
+func (c EquivocationEvidenceObject) GetHash() common.Hash {
+	return c.equivocationEvidenceObjectKey
+}
+
+func (c EquivocationEvidenceObject) GetType() int {
+	return c.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (c *EquivocationEvidenceObject) MarkDelete() {
+	c.deleted = true
+}
+
+// reset equivocation evidence into default value
+func (c *EquivocationEvidenceObject) Reset() bool {
+	c.equivocationEvidenceState = NewEquivocationEvidenceState()
+	return true
+}
+
+func (c EquivocationEvidenceObject) IsDeleted() bool {
+	return c.deleted
+}
+
+// value is either default or nil
+func (c EquivocationEvidenceObject) IsEmpty() bool {
+	temp := NewEquivocationEvidenceState()
+	return reflect.DeepEqual(temp, c.equivocationEvidenceState) || c.equivocationEvidenceState == nil
+}
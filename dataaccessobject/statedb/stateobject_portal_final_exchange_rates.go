@@ -9,6 +9,15 @@ import (
 
 type FinalExchangeRatesDetail struct {
 	Amount uint64
+	// exchange rate oracle, only set while the oracle is enabled
+	Submissions map[string]ExchangeRateSubmission `json:",omitempty"` // feeder address => last submission within the window
+	IsStale     bool                              `json:",omitempty"` // too few feeders reported within the window, Amount is the last fresh rate
+}
+
+// ExchangeRateSubmission is a rate pushed by a feeder at a beacon height
+type ExchangeRateSubmission struct {
+	Rate         uint64
+	BeaconHeight uint64
 }
 
 type FinalExchangeRatesState struct {
@@ -23,6 +32,14 @@ func (f *FinalExchangeRatesState) SetRates(rates map[string]FinalExchangeRatesDe
 	f.rates = rates
 }
 
+// IsStale returns true if the exchange rate oracle marked the rate of tokenID stale
+func (f *FinalExchangeRatesState) IsStale(tokenID string) bool {
+	if f == nil {
+		return false
+	}
+	return f.rates[tokenID].IsStale
+}
+
 func NewFinalExchangeRatesState() *FinalExchangeRatesState {
 	return &FinalExchangeRatesState{}
 }
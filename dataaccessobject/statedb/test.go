@@ -4,12 +4,11 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
 	"github.com/incognitochain/incognito-chain/privacy/key"
 	"github.com/incognitochain/incognito-chain/trie"
 	"github.com/incognitochain/incognito-chain/wallet"
-	"io/ioutil"
 	"math/rand"
-	"os"
 	"strconv"
 )
 
@@ -19,11 +18,7 @@ var (
 )
 
 var _ = func() (_ struct{}) {
-	dbPath, err := ioutil.TempDir(os.TempDir(), "test_reward")
-	if err != nil {
-		panic(err)
-	}
-	diskDB, _ = incdb.Open("leveldb", dbPath)
+	diskDB, _ = incdb.Open("memdb")
 	wrarperDB = NewDatabaseAccessWarper(diskDB)
 	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("test", true))
@@ -0,0 +1,70 @@
+// Package stateproof verifies the state proofs of the getstateproof RPC, it only depends on the trie
+// so wallets can build it for mobile (wasm/gomobile) and wasm.
+//
+// The verification is node trust only. No block header and no signature of the beacon committee
+// commits to the state db roots: the root of a proof is the one the serving node computed after the
+// block. A proof only shows the value stored under that root, a node lying about the root can prove
+// any value. The caller must get the root from a node it trusts, e.g. its own node, or from the view
+// of a state snapshot whose fast sync checkpoint it trusts (see blockchain.StateSnapshot).
+package stateproof
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/pkg/errors"
+)
+
+// Verify checks a proof of a state object key against a state root
+// and returns the proven value, nil if the key is absent
+func Verify(root common.Hash, key common.Hash, proof [][]byte) ([]byte, error) {
+	value, _, err := trie.VerifyProof(root, key[:], trie.NewProofSet(proof))
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// VerifyJSON checks a getstateproof RPC result against a trusted root and returns the hex encoded
+// value of the object, or an empty string if the proof shows its absence
+func VerifyJSON(trustedRoot string, stateProof string) (string, error) {
+	params := struct {
+		Root  string
+		Key   string
+		Value string
+		Proof []string
+	}{}
+	err := json.Unmarshal([]byte(stateProof), &params)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid state proof")
+	}
+	root, err := common.Hash{}.NewHashFromStr(trustedRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid trusted root")
+	}
+	if params.Root != root.String() {
+		return "", fmt.Errorf("proof root %v is not the trusted root %v", params.Root, root.String())
+	}
+	key, err := common.Hash{}.NewHashFromStr(params.Key)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid key")
+	}
+	proof := make([][]byte, len(params.Proof))
+	for i, node := range params.Proof {
+		proof[i], err = hex.DecodeString(node)
+		if err != nil {
+			return "", errors.Wrap(err, "Invalid proof node")
+		}
+	}
+	value, err := Verify(*root, *key, proof)
+	if err != nil {
+		return "", err
+	}
+	if hex.EncodeToString(value) != params.Value {
+		return "", errors.New("proven value differs from the value of the state proof")
+	}
+	return params.Value, nil
+}
@@ -0,0 +1,59 @@
+package incdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LatestBackup returns the highest epoch of the backups in backupFolder and its file, backups are named by epoch.
+func LatestBackup(backupFolder string) (int, string) {
+	files, err := ioutil.ReadDir(backupFolder)
+	if err != nil || len(files) == 0 {
+		return 0, ""
+	}
+	latestBackupEpoch := 0
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".tmp") {
+			continue
+		}
+		epoch, err := strconv.Atoi(file.Name())
+		if err != nil {
+			return 0, ""
+		}
+		if epoch > latestBackupEpoch {
+			latestBackupEpoch = epoch
+		}
+	}
+	return latestBackupEpoch, fmt.Sprintf("%v/%v", backupFolder, latestBackupEpoch)
+}
+
+// RemoveStaleBackups removes the backups next to backupFile, except the ones of its epoch and the epoch before.
+func RemoveStaleBackups(backupFile string) error {
+	latestEpoch, err := strconv.Atoi(filepath.Base(backupFile))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	folder := filepath.Dir(backupFile)
+	files, err := ioutil.ReadDir(folder)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, file := range files {
+		epoch, err := strconv.Atoi(file.Name())
+		if err != nil {
+			continue
+		}
+		if epoch != latestEpoch && epoch != latestEpoch-1 {
+			if err := os.Remove(filepath.Join(folder, file.Name())); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
@@ -58,7 +58,9 @@ type Database interface {
 	RemoveBackup(string)
 	Backup(backupFolder string) error
 	LatestBackup(backupFolder string) (int, string)
-	PreloadBackup(backupFile string) error
+	// PreloadBackup replaces the database with a backup, verify is run on the restored backup before
+	// it replaces the current database and the database is left untouched when it fails
+	PreloadBackup(backupFile string, verify func(Database) error) error
 	ReOpen() error
 	Clear() error
 }
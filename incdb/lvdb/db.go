@@ -2,12 +2,8 @@ package lvdb
 
 import (
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -251,12 +247,33 @@ func (db *db) Batch(data []incdb.BatchData) leveldb.Batch {
 	return *batch
 }
 
-func (db *db) PreloadBackup(backupFile string) error {
-	err := uncompress(backupFile, db.dbPath+"_")
+func (db *db) PreloadBackup(backupFile string, verify func(incdb.Database) error) error {
+	var err error
+	if incdb.IsSnapshotFile(backupFile) {
+		err = restoreSnapshot(backupFile, db.dbPath+"_")
+	} else {
+		// backups taken before snapshots are compressed database folders
+		err = uncompress(backupFile, db.dbPath+"_")
+	}
 	if err != nil {
+		os.RemoveAll(db.dbPath + "_")
 		return err
 	}
 
+	if verify != nil {
+		restored, err := open(db.dbPath + "_")
+		if err != nil {
+			os.RemoveAll(db.dbPath + "_")
+			return err
+		}
+		err = verify(restored)
+		restored.Close()
+		if err != nil {
+			os.RemoveAll(db.dbPath + "_")
+			return err
+		}
+	}
+
 	fmt.Println("remove ", db.dbPath)
 	err = os.RemoveAll(db.dbPath)
 	if err != nil {
@@ -271,30 +288,7 @@ func (db *db) PreloadBackup(backupFile string) error {
 }
 
 func (db *db) LatestBackup(path string) (int, string) {
-	backupFolder := filepath.Join(db.dbPath, path)
-	//fmt.Println("backupFolder", backupFolder)
-	files, err := ioutil.ReadDir(backupFolder)
-	if err != nil {
-		return 0, ""
-	}
-	//fmt.Println("files", files)
-	if len(files) == 0 {
-		return 0, ""
-	}
-	latestBackupEpoch := 0
-	//Get max epoch
-	for _, file := range files {
-		//fmt.Println("file", file.Name())
-		epoch, err := strconv.Atoi(file.Name())
-		if err != nil {
-			return 0, ""
-		}
-		if epoch > latestBackupEpoch {
-			latestBackupEpoch = epoch
-		}
-	}
-
-	return latestBackupEpoch, fmt.Sprintf("%v/%v", backupFolder, latestBackupEpoch)
+	return incdb.LatestBackup(filepath.Join(db.dbPath, path))
 }
 
 func (db *db) RemoveBackup(backupFile string) {
@@ -302,35 +296,16 @@ func (db *db) RemoveBackup(backupFile string) {
 	os.Remove(backupFile)
 }
 
+// Backup writes a snapshot of the database into backupFile, relative to the database directory.
+// The snapshot is read through a leveldb iterator, so the database stays open.
 func (db *db) Backup(backupFile string) error {
-	db.lock.Lock()
-	defer db.lock.Unlock()
-
 	backupFile = filepath.Join(db.dbPath, backupFile)
 	fmt.Println("backupFile", backupFile)
 
-	if err := os.MkdirAll(filepath.Dir(backupFile), 0700); err != nil {
-		panic(err)
-	}
-
-	if err := db.Close(); err != nil {
-		return err
-	}
-
-	err := common.CompressDatabase(db.dbPath, backupFile)
-	if err != nil {
+	if err := incdb.WriteSnapshotFile(db, backupFile); err != nil {
 		return err
 	}
-
-	if err := db.ReOpen(); err != nil {
-		panic(err)
-	}
-
-	if err := removeUnusedBackupDatabase(backupFile); err != nil {
-		panic(err)
-	}
-
-	return nil
+	return incdb.RemoveStaleBackups(backupFile)
 }
 
 func (db *db) Clear() error {
@@ -347,55 +322,20 @@ func (db *db) Clear() error {
 	return nil
 }
 
-//removeUnusedBackupDatabase ...
-// for remove unused databases in backup folder
-func removeUnusedBackupDatabase(filePath string) error {
-	strs := strings.Split(filePath, "/")
-
-	//Get latest epoch
-	latestEpoch, err := strconv.Atoi(strs[len(strs)-1])
-	if err != nil {
+// restoreSnapshot imports a snapshot into a new database at desPath
+func restoreSnapshot(srcPath, desPath string) error {
+	if err := os.RemoveAll(desPath); err != nil {
 		return err
 	}
-
-	//Get path directory of this file
-	path := filePath
-	for i := len(path) - 1; i > -1; i-- {
-		if path[i] != '/' {
-			path = path[:len(path)-1]
-		} else {
-			log.Println(1)
-			break
-		}
-	}
-
-	//Get needed epoch to download
-	files, err := ioutil.ReadDir(path)
+	restored, err := open(desPath)
 	if err != nil {
 		return err
 	}
-	if len(files) == 0 {
-		return err
+	err = incdb.ReadSnapshotFile(restored, srcPath)
+	if cerr := restored.Close(); err == nil {
+		err = cerr
 	}
-
-	//Get file name and compare with latest epoch
-	for _, file := range files {
-
-		epoch, err := strconv.Atoi(file.Name())
-		if err != nil {
-			return err
-		}
-
-		if epoch != latestEpoch && epoch != latestEpoch-1 {
-			name := path + "/" + file.Name()
-			err = os.Remove(name)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
+	return err
 }
 
 //Uncompress file from zip file
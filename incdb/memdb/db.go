@@ -0,0 +1,316 @@
+package memdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned by Get when the key does not exist
+var ErrNotFound = errors.New("memdb: not found")
+
+// db is an in-memory key-value store, its content is lost on exit. The optional path is
+// only used to resolve backup files, so tests can run without touching disk.
+type db struct {
+	path string
+	lock sync.RWMutex
+	kv   map[string][]byte
+}
+
+func init() {
+	driver := incdb.Driver{
+		DbType: "memdb",
+		Open:   openDriver,
+	}
+	if err := incdb.RegisterDriver(driver); err != nil {
+		panic("failed to register db driver")
+	}
+}
+
+func openDriver(args ...interface{}) (incdb.Database, error) {
+	if len(args) > 1 {
+		return nil, errors.New("invalid arguments")
+	}
+	path := ""
+	if len(args) == 1 {
+		var ok bool
+		if path, ok = args[0].(string); !ok {
+			return nil, errors.New("expected db path")
+		}
+	}
+	return New(path), nil
+}
+
+// New returns an empty in-memory database
+func New(path string) incdb.Database {
+	return newDB(path)
+}
+
+func newDB(path string) *db {
+	return &db{path: path, kv: make(map[string][]byte)}
+}
+
+func (db *db) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	_, ok := db.kv[string(key)]
+	return ok, nil
+}
+
+func (db *db) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	value, ok := db.kv[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyBytes(value), nil
+}
+
+func (db *db) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.kv[string(key)] = copyBytes(value)
+	return nil
+}
+
+func (db *db) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	delete(db.kv, string(key))
+	return nil
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (db *db) NewBatch() incdb.Batch {
+	return &batch{db: db}
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the memory database.
+func (db *db) NewIterator() incdb.Iterator {
+	return db.newIterator(nil, nil)
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (db *db) NewIteratorWithStart(start []byte) incdb.Iterator {
+	return db.newIterator(start, nil)
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (db *db) NewIteratorWithPrefix(prefix []byte) incdb.Iterator {
+	return db.newIterator(nil, prefix)
+}
+
+// newIterator takes a sorted copy of the matching pairs, later writes are not seen by the iterator
+func (db *db) newIterator(start, prefix []byte) incdb.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	keys := []string{}
+	for key := range db.kv {
+		if !bytes.HasPrefix([]byte(key), prefix) || bytes.Compare([]byte(key), start) < 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = db.kv[key]
+	}
+	return &iterator{keys: keys, values: values, index: -1}
+}
+
+// Stat returns a particular internal stat of the database.
+func (db *db) Stat(property string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	switch property {
+	case "memdb.keycount":
+		return fmt.Sprint(len(db.kv)), nil
+	case "memdb.size":
+		size := 0
+		for key, value := range db.kv {
+			size += len(key) + len(value)
+		}
+		return fmt.Sprint(size), nil
+	}
+	return "", errors.Errorf("unknown property %v", property)
+}
+
+// Compact is a no-op, there is nothing to compact in memory.
+func (db *db) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+// Close keeps the content, so that the database can be reopened like a disk one.
+func (db *db) Close() error {
+	return nil
+}
+
+func (db *db) ReOpen() error {
+	return nil
+}
+
+func (db *db) Clear() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.kv = make(map[string][]byte)
+	return nil
+}
+
+func (db *db) Backup(backupFile string) error {
+	backupFile = filepath.Join(db.path, backupFile)
+	if err := incdb.WriteSnapshotFile(db, backupFile); err != nil {
+		return err
+	}
+	return incdb.RemoveStaleBackups(backupFile)
+}
+
+func (db *db) PreloadBackup(backupFile string, verify func(incdb.Database) error) error {
+	restored := newDB(db.path)
+	if err := incdb.ReadSnapshotFile(restored, backupFile); err != nil {
+		return err
+	}
+	if verify != nil {
+		if err := verify(restored); err != nil {
+			return err
+		}
+	}
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.kv = restored.kv
+	return nil
+}
+
+func (db *db) LatestBackup(path string) (int, string) {
+	return incdb.LatestBackup(filepath.Join(db.path, path))
+}
+
+func (db *db) RemoveBackup(backupFile string) {
+	os.Remove(filepath.Join(db.path, backupFile))
+}
+
+func copyBytes(b []byte) []byte {
+	return append([]byte{}, b...)
+}
+
+// batch is a write-only memory batch that commits changes to its host database
+// when Write is called. A batch cannot be used concurrently.
+type batch struct {
+	db   *db
+	ops  []op
+	size int
+}
+
+type op struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *batch) Put(key, value []byte) error {
+	b.ops = append(b.ops, op{key: copyBytes(key), value: copyBytes(value)})
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *batch) Delete(key []byte) error {
+	b.ops = append(b.ops, op{key: copyBytes(key), delete: true})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to the memory database.
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+	for _, o := range b.ops {
+		if o.delete {
+			delete(b.db.kv, string(o.key))
+		} else {
+			b.db.kv[string(o.key)] = o.value
+		}
+	}
+	return nil
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.ops = b.ops[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w incdb.KeyValueWriter) error {
+	for _, o := range b.ops {
+		var err error
+		if o.delete {
+			err = w.Delete(o.key)
+		} else {
+			err = w.Put(o.key, o.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterator walks a sorted copy of the database content.
+type iterator struct {
+	keys   []string
+	values [][]byte
+	index  int
+}
+
+func (it *iterator) Next() bool {
+	if it.index < len(it.keys) {
+		it.index++
+	}
+	return it.index < len(it.keys)
+}
+
+func (it *iterator) Last() bool {
+	it.index = len(it.keys) - 1
+	return it.index >= 0
+}
+
+func (it *iterator) Error() error {
+	return nil
+}
+
+func (it *iterator) Key() []byte {
+	if it.index < 0 || it.index >= len(it.keys) {
+		return nil
+	}
+	return []byte(it.keys[it.index])
+}
+
+func (it *iterator) Value() []byte {
+	if it.index < 0 || it.index >= len(it.keys) {
+		return nil
+	}
+	return it.values[it.index]
+}
+
+func (it *iterator) Release() {
+	it.keys, it.values = nil, nil
+}
@@ -0,0 +1,107 @@
+package memdb_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDb_Base(t *testing.T) {
+	db, err := incdb.Open("memdb")
+	assert.Nil(t, err)
+
+	assert.Nil(t, db.Put([]byte("a"), []byte{1}))
+	result, err := db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1}, result)
+	has, err := db.Has([]byte("a"))
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	assert.Nil(t, db.Delete([]byte("a")))
+	assert.Nil(t, db.Delete([]byte("b")))
+	has, err = db.Has([]byte("a"))
+	assert.Nil(t, err)
+	assert.False(t, has)
+	_, err = db.Get([]byte("a"))
+	assert.NotNil(t, err)
+}
+
+func TestDb_BatchAndIterator(t *testing.T) {
+	db, err := incdb.Open("memdb")
+	assert.Nil(t, err)
+
+	batch := db.NewBatch()
+	for _, key := range []string{"b2", "a1", "b1", "c1"} {
+		assert.Nil(t, batch.Put([]byte(key), []byte("v"+key)))
+	}
+	assert.Nil(t, batch.Delete([]byte("c1")))
+	has, _ := db.Has([]byte("a1"))
+	assert.False(t, has, "batch must not be visible before Write")
+	assert.Nil(t, batch.Write())
+
+	keys := func(iter incdb.Iterator) []string {
+		defer iter.Release()
+		res := []string{}
+		for iter.Next() {
+			res = append(res, string(iter.Key()))
+		}
+		return res
+	}
+	assert.Equal(t, []string{"a1", "b1", "b2"}, keys(db.NewIterator()))
+	assert.Equal(t, []string{"b1", "b2"}, keys(db.NewIteratorWithPrefix([]byte("b"))))
+	assert.Equal(t, []string{"b2"}, keys(db.NewIteratorWithStart([]byte("b11"))))
+
+	iter := db.NewIterator()
+	assert.True(t, iter.Last())
+	assert.Equal(t, []byte("vb2"), iter.Value())
+	iter.Release()
+	iter.Release()
+
+	replayed, _ := incdb.Open("memdb")
+	assert.Nil(t, batch.Replay(replayed))
+	assert.Equal(t, []string{"a1", "b1", "b2"}, keys(replayed.NewIterator()))
+}
+
+func TestDb_BackupAndPreload(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_memdb_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "data", "beacon")
+
+	db, err := incdb.Open("memdb", dbPath)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Put([]byte("epoch"), []byte{1}))
+	assert.Nil(t, db.Backup("../backup/1"))
+	assert.Nil(t, db.Put([]byte("epoch"), []byte{2}))
+	assert.Nil(t, db.Backup("../backup/2"))
+	assert.Nil(t, db.Put([]byte("epoch"), []byte{3}))
+	assert.Nil(t, db.Backup("../backup/3"))
+	_, err = os.Stat(filepath.Join(dir, "data", "backup", "1"))
+	assert.True(t, os.IsNotExist(err), "stale backup must be removed")
+
+	epoch, file := db.LatestBackup("../backup")
+	assert.Equal(t, 3, epoch)
+
+	other, _ := incdb.Open("memdb", dbPath)
+	assert.NotNil(t, other.PreloadBackup(filepath.Join(dir, "data", "backup", "2"), func(incdb.Database) error {
+		return os.ErrInvalid
+	}))
+	has, _ := other.Has([]byte("epoch"))
+	assert.False(t, has, "a failed verify must leave the database untouched")
+
+	assert.Nil(t, other.PreloadBackup(file, func(restored incdb.Database) error {
+		v, err := restored.Get([]byte("epoch"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{3}, v)
+		return nil
+	}))
+	v, err := other.Get([]byte("epoch"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{3}, v)
+}
@@ -0,0 +1,419 @@
+package pebbledb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/pkg/errors"
+)
+
+type db struct {
+	fn     string // filename for reporting
+	dbPath string
+	pdb    *pebble.DB
+	lock   sync.RWMutex
+}
+
+func init() {
+	driver := incdb.Driver{
+		DbType: "pebble",
+		Open:   openDriver,
+	}
+	if err := incdb.RegisterDriver(driver); err != nil {
+		panic("failed to register db driver")
+	}
+}
+
+func openDriver(args ...interface{}) (incdb.Database, error) {
+	if len(args) != 1 {
+		return nil, errors.New("invalid arguments")
+	}
+	dbPath, ok := args[0].(string)
+	if !ok {
+		return nil, errors.New("expected db path")
+	}
+	return open(dbPath)
+}
+
+func openPebble(dbPath string) (*pebble.DB, error) {
+	handles := 256
+	opts := &pebble.Options{
+		MaxOpenFiles: handles,
+		// Archive nodes mostly append blocks, a larger L0 and base level cut the number of rewrites
+		L0CompactionThreshold: 4,
+		L0StopWritesThreshold: 24,
+		LBaseMaxBytes:         64 << 20,
+		MemTableSize:          8 << 20,
+		Levels:                make([]pebble.LevelOptions, 7),
+	}
+	for i := range opts.Levels {
+		opts.Levels[i].FilterPolicy = bloom.FilterPolicy(10)
+		opts.Levels[i].TargetFileSize = 4 << 20 << uint(i)
+	}
+	pdb, err := pebble.Open(dbPath, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "pebble.Open %s", dbPath)
+	}
+	return pdb, nil
+}
+
+func open(dbPath string) (*db, error) {
+	pdb, err := openPebble(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return &db{fn: dbPath, pdb: pdb, dbPath: dbPath}, nil
+}
+
+func (db *db) GetPath() string {
+	return db.fn
+}
+
+func (db *db) Close() error {
+	return errors.Wrap(db.pdb.Close(), "db.pdb.Close")
+}
+
+func (db *db) ReOpen() error {
+	pdb, err := openPebble(db.dbPath)
+	if err != nil {
+		return err
+	}
+	db.pdb = pdb
+	return nil
+}
+
+func (db *db) Has(key []byte) (bool, error) {
+	_, closer, err := db.pdb.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+func (db *db) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	value, closer, err := db.pdb.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	// the value is only valid until the closer is closed
+	ret := append([]byte{}, value...)
+	closer.Close()
+	return ret, nil
+}
+
+func (db *db) Put(key, value []byte) error {
+	return db.pdb.Set(key, value, pebble.NoSync)
+}
+
+func (db *db) Delete(key []byte) error {
+	return db.pdb.Delete(key, pebble.NoSync)
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (db *db) NewBatch() incdb.Batch {
+	return &batch{
+		db: db.pdb,
+		b:  db.pdb.NewBatch(),
+	}
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the pebble database.
+func (db *db) NewIterator() incdb.Iterator {
+	return &iterator{iter: db.pdb.NewIter(nil)}
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (db *db) NewIteratorWithStart(start []byte) incdb.Iterator {
+	return &iterator{iter: db.pdb.NewIter(&pebble.IterOptions{LowerBound: start})}
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (db *db) NewIteratorWithPrefix(prefix []byte) incdb.Iterator {
+	return &iterator{iter: db.pdb.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixLimit(prefix),
+	})}
+}
+
+// prefixLimit returns the smallest key greater than every key starting with prefix, or nil if there is none
+func prefixLimit(prefix []byte) []byte {
+	limit := append([]byte{}, prefix...)
+	for i := len(limit) - 1; i >= 0; i-- {
+		limit[i]++
+		if limit[i] != 0 {
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+// Stat returns a particular internal stat of the database, only "pebble.metrics" is supported.
+func (db *db) Stat(property string) (string, error) {
+	if property != "pebble.metrics" {
+		return "", errors.Errorf("unknown property %v", property)
+	}
+	return db.pdb.Metrics().String(), nil
+}
+
+// Compact flattens the underlying data store for the given key range. In essence,
+// deleted and overwritten versions are discarded, and the data is rearranged to
+// reduce the cost of operations needed to access them.
+//
+// A nil start is treated as a key before all keys in the data store; a nil limit
+// is treated as a key after all keys in the data store. If both is nil then it
+// will compact entire data store.
+func (db *db) Compact(start []byte, limit []byte) error {
+	if start == nil {
+		start = []byte{}
+	}
+	if limit == nil {
+		iter := db.pdb.NewIter(nil)
+		if !iter.Last() {
+			return iter.Close()
+		}
+		limit = append(append([]byte{}, iter.Key()...), 0)
+		if err := iter.Close(); err != nil {
+			return err
+		}
+	}
+	return db.pdb.Compact(start, limit)
+}
+
+// Path returns the path to the database directory.
+func (db *db) Path() string {
+	return db.fn
+}
+
+// Backup writes a snapshot of the database into backupFile, relative to the database directory.
+func (db *db) Backup(backupFile string) error {
+	backupFile = filepath.Join(db.dbPath, backupFile)
+	fmt.Println("backupFile", backupFile)
+	// pebble iterators read from a consistent snapshot, reads and writes go on while it is exported
+	if err := incdb.WriteSnapshotFile(db, backupFile); err != nil {
+		return err
+	}
+	return incdb.RemoveStaleBackups(backupFile)
+}
+
+func (db *db) PreloadBackup(backupFile string, verify func(incdb.Database) error) error {
+	if !incdb.IsSnapshotFile(backupFile) {
+		return errors.Errorf("%v is not a snapshot, only the leveldb driver restores legacy backups", backupFile)
+	}
+	if err := os.RemoveAll(db.dbPath + "_"); err != nil {
+		return err
+	}
+	restored, err := open(db.dbPath + "_")
+	if err != nil {
+		return err
+	}
+	err = incdb.ReadSnapshotFile(restored, backupFile)
+	if err == nil && verify != nil {
+		err = verify(restored)
+	}
+	if cerr := restored.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.RemoveAll(db.dbPath + "_")
+		return err
+	}
+
+	fmt.Println("remove ", db.dbPath)
+	if err := os.RemoveAll(db.dbPath); err != nil {
+		return err
+	}
+	fmt.Println("rename ", db.dbPath)
+	return os.Rename(db.dbPath+"_", db.dbPath)
+}
+
+func (db *db) LatestBackup(path string) (int, string) {
+	return incdb.LatestBackup(filepath.Join(db.dbPath, path))
+}
+
+func (db *db) RemoveBackup(backupFile string) {
+	os.Remove(filepath.Join(db.dbPath, backupFile))
+}
+
+// Clear deletes every key of the database.
+func (db *db) Clear() error {
+	iter := db.pdb.NewIter(nil)
+	if !iter.Last() {
+		return iter.Close()
+	}
+	limit := append(append([]byte{}, iter.Key()...), 0)
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	return db.pdb.DeleteRange([]byte{}, limit, pebble.Sync)
+}
+
+// batch is a write-only pebble batch that commits changes to its host database
+// when Write is called. A batch cannot be used concurrently.
+type batch struct {
+	db      *pebble.DB
+	b       *pebble.Batch
+	size    int
+	applied bool
+}
+
+// reuse copies an applied batch into a fresh one, pebble batches cannot be applied twice
+// while leveldb ones keep their content after Write until Reset
+func (b *batch) reuse() error {
+	if !b.applied {
+		return nil
+	}
+	nb := b.db.NewBatch()
+	reader := b.b.Reader()
+	for {
+		kind, key, value, ok := reader.Next()
+		if !ok {
+			break
+		}
+		var err error
+		switch kind {
+		case pebble.InternalKeyKindSet:
+			err = nb.Set(key, value, nil)
+		case pebble.InternalKeyKindDelete:
+			err = nb.Delete(key, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	b.b, b.applied = nb, false
+	return nil
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *batch) Put(key, value []byte) error {
+	if err := b.reuse(); err != nil {
+		return err
+	}
+	b.size += len(value)
+	return b.b.Set(key, value, nil)
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *batch) Delete(key []byte) error {
+	if err := b.reuse(); err != nil {
+		return err
+	}
+	b.size++
+	return b.b.Delete(key, nil)
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	if err := b.reuse(); err != nil {
+		return err
+	}
+	if err := b.db.Apply(b.b, pebble.NoSync); err != nil {
+		return err
+	}
+	b.applied = true
+	return nil
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.b.Reset()
+	b.size = 0
+	b.applied = false
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w incdb.KeyValueWriter) error {
+	reader := b.b.Reader()
+	for {
+		kind, key, value, ok := reader.Next()
+		if !ok {
+			return nil
+		}
+		var err error
+		switch kind {
+		case pebble.InternalKeyKindSet:
+			err = w.Put(key, value)
+		case pebble.InternalKeyKindDelete:
+			err = w.Delete(key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// iterator adapts a pebble iterator, which must be positioned before use, to incdb.Iterator.
+type iterator struct {
+	iter    *pebble.Iterator
+	started bool
+}
+
+// Next moves the iterator to the next key/value pair, the first call moves it to the first pair.
+func (it *iterator) Next() bool {
+	if it.iter == nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		return it.iter.First()
+	}
+	return it.iter.Next()
+}
+
+func (it *iterator) Last() bool {
+	if it.iter == nil {
+		return false
+	}
+	it.started = true
+	return it.iter.Last()
+}
+
+func (it *iterator) Error() error {
+	if it.iter == nil {
+		return nil
+	}
+	return it.iter.Error()
+}
+
+func (it *iterator) Key() []byte {
+	if it.iter == nil || !it.iter.Valid() {
+		return nil
+	}
+	return it.iter.Key()
+}
+
+func (it *iterator) Value() []byte {
+	if it.iter == nil || !it.iter.Valid() {
+		return nil
+	}
+	return it.iter.Value()
+}
+
+// Release closes the pebble iterator, it can be called multiple times.
+func (it *iterator) Release() {
+	if it.iter != nil {
+		it.iter.Close()
+		it.iter = nil
+	}
+}
@@ -0,0 +1,195 @@
+package pebbledb_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/pebbledb"
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestDB(t *testing.T) (incdb.Database, string) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_pebble_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	db, err := incdb.Open("pebble", filepath.Join(dir, "data", "beacon"))
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dir, err)
+	}
+	return db, dir
+}
+
+func keys(iter incdb.Iterator) []string {
+	defer iter.Release()
+	res := []string{}
+	for iter.Next() {
+		res = append(res, string(iter.Key()))
+	}
+	return res
+}
+
+func TestDb_Base(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	assert.Nil(t, db.Put([]byte("a"), []byte{1}))
+	result, err := db.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1}, result)
+	has, err := db.Has([]byte("a"))
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	assert.Nil(t, db.Delete([]byte("a")))
+	has, err = db.Has([]byte("a"))
+	assert.Nil(t, err)
+	assert.False(t, has)
+	_, err = db.Get([]byte("a"))
+	assert.NotNil(t, err)
+	_, err = db.Stat("pebble.metrics")
+	assert.Nil(t, err)
+}
+
+func TestDb_BatchAndIterator(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	batch := db.NewBatch()
+	for _, key := range []string{"b2", "a1", "b1", "c1", "b\xff"} {
+		assert.Nil(t, batch.Put([]byte(key), []byte("v"+key)))
+	}
+	assert.Nil(t, batch.Delete([]byte("c1")))
+	assert.Nil(t, batch.Write())
+	// a written batch keeps its content until Reset, like a leveldb one
+	assert.Nil(t, batch.Put([]byte("d1"), []byte("vd1")))
+	assert.Nil(t, batch.Write())
+	batch.Reset()
+
+	assert.Equal(t, []string{"a1", "b1", "b2", "b\xff", "d1"}, keys(db.NewIterator()))
+	assert.Equal(t, []string{"b1", "b2", "b\xff"}, keys(db.NewIteratorWithPrefix([]byte("b"))))
+	assert.Equal(t, []string{"b2", "b\xff", "d1"}, keys(db.NewIteratorWithStart([]byte("b11"))))
+
+	iter := db.NewIterator()
+	assert.True(t, iter.Last())
+	assert.Equal(t, []byte("vd1"), iter.Value())
+	iter.Release()
+	iter.Release()
+
+	assert.Nil(t, db.Compact(nil, nil))
+	assert.Nil(t, db.Clear())
+	assert.Equal(t, []string{}, keys(db.NewIterator()))
+}
+
+func TestDb_BackupAndPreload(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, db.Put([]byte("epoch"), []byte{1}))
+	assert.Nil(t, db.Backup("../backup/1"))
+	assert.Nil(t, db.Put([]byte("epoch"), []byte{2}))
+	assert.Nil(t, db.Backup("../backup/2"))
+	assert.Nil(t, db.Put([]byte("other"), []byte{2}))
+	epoch, file := db.LatestBackup("../backup")
+	assert.Equal(t, 2, epoch)
+
+	// same order as the syncker: close, preload, reopen
+	assert.Nil(t, db.Close())
+	assert.NotNil(t, db.PreloadBackup(file, func(incdb.Database) error { return os.ErrInvalid }))
+	assert.Nil(t, db.ReOpen())
+	has, _ := db.Has([]byte("other"))
+	assert.True(t, has, "a failed verify must leave the database untouched")
+
+	assert.Nil(t, db.Close())
+	assert.Nil(t, db.PreloadBackup(file, func(restored incdb.Database) error {
+		v, err := restored.Get([]byte("epoch"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{2}, v)
+		return nil
+	}))
+	assert.Nil(t, db.ReOpen())
+	defer db.Close()
+	has, _ = db.Has([]byte("other"))
+	assert.False(t, has)
+	v, err := db.Get([]byte("epoch"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{2}, v)
+}
+
+func fillDB(t *testing.T, db incdb.Database, n int) {
+	batch := db.NewBatch()
+	for i := 0; i < n; i++ {
+		assert.Nil(t, batch.Put([]byte(fmt.Sprintf("key-%06d", i)), bytes.Repeat([]byte{byte(i)}, i%300)))
+	}
+	assert.Nil(t, batch.Write())
+}
+
+func assertSameContent(t *testing.T, expected, actual incdb.Database) {
+	it1, it2 := expected.NewIterator(), actual.NewIterator()
+	defer it1.Release()
+	defer it2.Release()
+	for it1.Next() {
+		assert.True(t, it2.Next())
+		assert.Equal(t, it1.Key(), it2.Key())
+		assert.Equal(t, it1.Value(), it2.Value())
+	}
+	assert.False(t, it2.Next())
+}
+
+// TestDb_MigrateFromLeveldb moves a leveldb database to pebble and back
+func TestDb_MigrateFromLeveldb(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_migrate_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	lv, err := incdb.Open("leveldb", filepath.Join(dir, "leveldb"))
+	assert.Nil(t, err)
+	defer lv.Close()
+	fillDB(t, lv, 5000)
+
+	pb, err := incdb.Open("pebble", filepath.Join(dir, "pebble"))
+	assert.Nil(t, err)
+	defer pb.Close()
+	count, err := incdb.Migrate(lv, pb)
+	assert.Nil(t, err)
+	assert.Equal(t, 5000, count)
+	assertSameContent(t, lv, pb)
+
+	back, err := incdb.Open("leveldb", filepath.Join(dir, "back"))
+	assert.Nil(t, err)
+	defer back.Close()
+	_, err = incdb.Migrate(pb, back)
+	assert.Nil(t, err)
+	assertSameContent(t, lv, back)
+}
+
+// TestDb_PreloadLeveldbBackup makes sure a leveldb backup is a snapshot that other engines restore
+func TestDb_PreloadLeveldbBackup(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "test_backup_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	lv, err := incdb.Open("leveldb", filepath.Join(dir, "data", "beacon"))
+	assert.Nil(t, err)
+	defer lv.Close()
+	fillDB(t, lv, 100)
+	assert.Nil(t, lv.Backup("../../backup/beacon/5"))
+	_, file := lv.LatestBackup("../../backup/beacon")
+	assert.True(t, incdb.IsSnapshotFile(file))
+
+	pb, err := incdb.Open("pebble", filepath.Join(dir, "pebble"))
+	assert.Nil(t, err)
+	assert.Nil(t, pb.Close())
+	assert.Nil(t, pb.PreloadBackup(file, nil))
+	assert.Nil(t, pb.ReOpen())
+	defer pb.Close()
+	assertSameContent(t, lv, pb)
+}
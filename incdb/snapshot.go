@@ -0,0 +1,234 @@
+package incdb
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// A snapshot is the engine-neutral dump of a database, used for backups and to
+// migrate a database from one driver to another. It is gzip compressed:
+//
+//	"INCSNAP1"
+//	0x01 | uvarint(len(key)) | key | uvarint(len(value)) | value   (one per pair, in key order)
+//	0x00 | uint64(count) | sha256(everything before the digest)
+const (
+	snapshotMagic     = "INCSNAP1"
+	snapshotRecord    = byte(0x01)
+	snapshotTrailer   = byte(0x00)
+	maxSnapshotItemSz = 1 << 30
+)
+
+// ExportSnapshot writes every key/value pair of db to w and returns the number of pairs written.
+func ExportSnapshot(db Iteratee, w io.Writer) (int, error) {
+	zw := gzip.NewWriter(w)
+	h := sha256.New()
+	out := bufio.NewWriter(io.MultiWriter(zw, h))
+	if _, err := out.WriteString(snapshotMagic); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	iter := db.NewIterator()
+	defer iter.Release()
+	count := 0
+	buf := make([]byte, binary.MaxVarintLen64)
+	for iter.Next() {
+		out.WriteByte(snapshotRecord)
+		for _, item := range [][]byte{iter.Key(), iter.Value()} {
+			n := binary.PutUvarint(buf, uint64(len(item)))
+			out.Write(buf[:n])
+			if _, err := out.Write(item); err != nil {
+				return count, errors.WithStack(err)
+			}
+		}
+		count++
+	}
+	if err := iter.Error(); err != nil {
+		return count, errors.WithStack(err)
+	}
+
+	out.WriteByte(snapshotTrailer)
+	binary.BigEndian.PutUint64(buf[:8], uint64(count))
+	out.Write(buf[:8])
+	if err := out.Flush(); err != nil {
+		return count, errors.WithStack(err)
+	}
+	if _, err := zw.Write(h.Sum(nil)); err != nil {
+		return count, errors.WithStack(err)
+	}
+	return count, errors.WithStack(zw.Close())
+}
+
+// ImportSnapshot writes the pairs of a snapshot read from r into db and returns the number of pairs written.
+// Pairs are flushed in batches before the trailer is checked, so db should be a fresh database that is
+// dropped when an error is returned.
+func ImportSnapshot(db Batcher, r io.Reader) (int, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, errors.Wrap(err, "not a snapshot")
+	}
+	defer zr.Close()
+	in := &hashReader{r: bufio.NewReader(zr), h: sha256.New()}
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(in, magic); err != nil || string(magic) != snapshotMagic {
+		return 0, errors.New("not a snapshot")
+	}
+
+	batch := db.NewBatch()
+	count := 0
+	for {
+		kind, err := in.ReadByte()
+		if err != nil {
+			return count, errors.Wrap(err, "truncated snapshot")
+		}
+		switch kind {
+		case snapshotRecord:
+			key, err := in.readItem()
+			if err != nil {
+				return count, err
+			}
+			value, err := in.readItem()
+			if err != nil {
+				return count, err
+			}
+			if err := batch.Put(key, value); err != nil {
+				return count, err
+			}
+			count++
+			if batch.ValueSize() >= IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return count, err
+				}
+				batch.Reset()
+			}
+		case snapshotTrailer:
+			buf := make([]byte, 8)
+			if _, err := io.ReadFull(in, buf); err != nil {
+				return count, errors.Wrap(err, "truncated snapshot")
+			}
+			sum := in.h.Sum(nil)
+			digest := make([]byte, len(sum))
+			if _, err := io.ReadFull(in.r, digest); err != nil {
+				return count, errors.Wrap(err, "truncated snapshot")
+			}
+			if !bytes.Equal(sum, digest) {
+				return count, errors.New("snapshot checksum mismatch")
+			}
+			if n := binary.BigEndian.Uint64(buf); n != uint64(count) {
+				return count, errors.Errorf("snapshot has %v pairs, trailer says %v", count, n)
+			}
+			return count, batch.Write()
+		default:
+			return count, errors.Errorf("unknown snapshot record %v", kind)
+		}
+	}
+}
+
+// hashReader hashes everything read through it
+type hashReader struct {
+	r *bufio.Reader
+	h hash.Hash
+}
+
+func (hr *hashReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n])
+	return n, err
+}
+
+func (hr *hashReader) ReadByte() (byte, error) {
+	b, err := hr.r.ReadByte()
+	if err == nil {
+		hr.h.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (hr *hashReader) readItem() ([]byte, error) {
+	n, err := binary.ReadUvarint(hr)
+	if err != nil {
+		return nil, errors.Wrap(err, "truncated snapshot")
+	}
+	if n > maxSnapshotItemSz {
+		return nil, errors.Errorf("snapshot item of %v bytes is too large", n)
+	}
+	item := make([]byte, n)
+	if _, err := io.ReadFull(hr, item); err != nil {
+		return nil, errors.Wrap(err, "truncated snapshot")
+	}
+	return item, nil
+}
+
+// WriteSnapshotFile exports db into file. The snapshot is written next to file and renamed once complete,
+// so a crash never leaves a truncated backup behind.
+func WriteSnapshotFile(db Iteratee, file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.Create(file + ".tmp")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = ExportSnapshot(db, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file + ".tmp")
+		return err
+	}
+	return errors.WithStack(os.Rename(file+".tmp", file))
+}
+
+// ReadSnapshotFile imports the snapshot file into db, see ImportSnapshot.
+func ReadSnapshotFile(db Batcher, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	_, err = ImportSnapshot(db, f)
+	return err
+}
+
+// IsSnapshotFile reports whether file holds a snapshot. Backups taken before snapshots existed are
+// compressed leveldb folders, which only the leveldb driver can restore.
+func IsSnapshotFile(file string) bool {
+	f, err := os.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return false
+	}
+	defer zr.Close()
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(zr, magic); err != nil {
+		return false
+	}
+	return string(magic) == snapshotMagic
+}
+
+// Migrate copies every key/value pair of src into dst through the snapshot format and returns the number
+// of pairs copied.
+func Migrate(src Iteratee, dst Batcher) (int, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := ExportSnapshot(src, pw)
+		pw.CloseWithError(err)
+	}()
+	count, err := ImportSnapshot(dst, pr)
+	pr.CloseWithError(err)
+	return count, err
+}
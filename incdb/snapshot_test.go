@@ -0,0 +1,55 @@
+package incdb_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func fillDB(t *testing.T, db incdb.Database, n int) {
+	batch := db.NewBatch()
+	for i := 0; i < n; i++ {
+		assert.Nil(t, batch.Put([]byte(fmt.Sprintf("key-%06d", i)), bytes.Repeat([]byte{byte(i)}, i%300)))
+	}
+	assert.Nil(t, batch.Write())
+}
+
+func assertSameContent(t *testing.T, expected, actual incdb.Database) {
+	it1, it2 := expected.NewIterator(), actual.NewIterator()
+	defer it1.Release()
+	defer it2.Release()
+	for it1.Next() {
+		assert.True(t, it2.Next())
+		assert.Equal(t, it1.Key(), it2.Key())
+		assert.Equal(t, it1.Value(), it2.Value())
+	}
+	assert.False(t, it2.Next())
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	src, _ := incdb.Open("memdb")
+	fillDB(t, src, 2000)
+
+	buf := new(bytes.Buffer)
+	count, err := incdb.ExportSnapshot(src, buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, count)
+	raw := buf.Bytes()
+
+	dst, _ := incdb.Open("memdb")
+	count, err = incdb.ImportSnapshot(dst, bytes.NewReader(raw))
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, count)
+	assertSameContent(t, src, dst)
+
+	truncated, _ := incdb.Open("memdb")
+	_, err = incdb.ImportSnapshot(truncated, bytes.NewReader(raw[:len(raw)/2]))
+	assert.NotNil(t, err)
+
+	_, err = incdb.ImportSnapshot(truncated, bytes.NewReader([]byte("not a snapshot")))
+	assert.NotNil(t, err)
+}
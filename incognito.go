@@ -11,7 +11,9 @@ import (
 	"runtime/debug"
 	"strconv"
 
+	"github.com/incognitochain/incognito-chain/dataaccessobject/blockarchive"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
@@ -25,6 +27,8 @@ import (
 	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/memdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/pebbledb"
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -54,6 +58,44 @@ func getBTCRelayingChain(btcRelayingChainID, btcDataFolderName string) (*btcrela
 	)
 }
 
+// getUTXORelayingChain opens the relaying header chain of a Bitcoin-derived chain besides Bitcoin
+// @@Note: need to update the genesis block heights before deploying
+func getUTXORelayingChain(relayingChainID, dataFolderName string) (*btcrelaying.BlockChain, error) {
+	relayingChainParams := map[string]*chaincfg.Params{
+		portal.TestnetLTCChainID:   btcrelaying.LTCTestNet4Params,
+		portal.Testnet2LTCChainID:  btcrelaying.LTCTestNet4Params,
+		portal.MainnetLTCChainID:   btcrelaying.LTCMainNetParams,
+		portal.TestnetDOGEChainID:  btcrelaying.DOGETestNetParams,
+		portal.Testnet2DOGEChainID: btcrelaying.DOGETestNetParams,
+		portal.MainnetDOGEChainID:  btcrelaying.DOGEMainNetParams,
+		portal.TestnetBCHChainID:   btcrelaying.BCHTestNet3Params,
+		portal.Testnet2BCHChainID:  btcrelaying.BCHTestNet3Params,
+		portal.MainnetBCHChainID:   btcrelaying.BCHMainNetParams,
+	}
+	relayingChainRules := map[string]btcrelaying.ChainRules{
+		portal.TestnetLTCChainID:   btcrelaying.LTCChainRules,
+		portal.Testnet2LTCChainID:  btcrelaying.LTCChainRules,
+		portal.MainnetLTCChainID:   btcrelaying.LTCChainRules,
+		portal.TestnetDOGEChainID:  btcrelaying.DOGEChainRules,
+		portal.Testnet2DOGEChainID: btcrelaying.DOGEChainRules,
+		portal.MainnetDOGEChainID:  btcrelaying.DOGEChainRules,
+		portal.TestnetBCHChainID:   btcrelaying.BCHTestNet3ChainRules,
+		portal.Testnet2BCHChainID:  btcrelaying.BCHTestNet3ChainRules,
+		portal.MainnetBCHChainID:   btcrelaying.BCHMainNetChainRules,
+	}
+	params, ok := relayingChainParams[relayingChainID]
+	if !ok {
+		return nil, fmt.Errorf("unsupported relaying chain %v", relayingChainID)
+	}
+	rules := relayingChainRules[relayingChainID]
+	return btcrelaying.GetChainV2WithRules(
+		filepath.Join(config.Config().DataDir, dataFolderName),
+		params,
+		int32(0),
+		&rules,
+	)
+}
+
 func getBNBRelayingChainState(bnbRelayingChainID string) (*bnbrelaying.BNBChainState, error) {
 	bnbChainState := new(bnbrelaying.BNBChainState)
 	err := bnbChainState.LoadBNBChainState(
@@ -108,13 +150,27 @@ func mainMaster(serverChan chan<- *Server) error {
 	if interruptRequested(interrupt) {
 		return nil
 	}
-	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
+	db, err := incdb.OpenMultipleDB(cfg.DatabaseEngine, filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
-		Logger.log.Error("could not open connection to leveldb")
+		Logger.log.Errorf("could not open connection to %v", cfg.DatabaseEngine)
 		Logger.log.Error(err)
 		panic(err)
 	}
+	// Open the block archives, they stay readable once blocks were archived even if archiving got disabled
+	dbPath := filepath.Join(cfg.DataDir, cfg.DatabaseDir)
+	if cfg.BlockArchive || blockarchive.Exists(dbPath) {
+		archives, err := blockarchive.OpenMultiple(dbPath, cfg.BlockArchiveCompress)
+		if err != nil {
+			Logger.log.Error("could not open block archive")
+			Logger.log.Error(err)
+			panic(err)
+		}
+		for cID, archive := range archives {
+			rawdbv2.SetBlockArchive(db[cID], archive)
+			defer archive.Close()
+		}
+	}
 	// Create db for mempool and use it
 	consensusDB, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "consensus"))
 	if err != nil {
@@ -175,6 +231,29 @@ func mainMaster(serverChan chan<- *Server) error {
 		db.Close()
 	}()
 
+	// Create ltc, doge and bch relaying chains
+	relayingParam := portal.GetPortalParams().RelayingParam
+	utxoChains := map[string]*btcrelaying.BlockChain{}
+	for chainID, dataFolderName := range map[string]string{
+		relayingParam.LTCRelayingHeaderChainID:  relayingParam.LTCDataFolderName,
+		relayingParam.DOGERelayingHeaderChainID: relayingParam.DOGEDataFolderName,
+		relayingParam.BCHRelayingHeaderChainID:  relayingParam.BCHDataFolderName,
+	} {
+		utxoChain, err := getUTXORelayingChain(chainID, dataFolderName)
+		if err != nil {
+			Logger.log.Errorf("could not get or create %v relaying chain", chainID)
+			Logger.log.Error(err)
+			panic(err)
+		}
+		utxoChains[chainID] = utxoChain
+	}
+	defer func() {
+		Logger.log.Warn("Gracefully shutting down the ltc, doge and bch databases...")
+		for _, utxoChain := range utxoChains {
+			utxoChain.GetDB().Close()
+		}
+	}()
+
 	// Create bnbrelaying chain state
 	bnbChainState, err := getBNBRelayingChainState(portal.GetPortalParams().RelayingParam.BNBRelayingHeaderChainID)
 	if err != nil {
@@ -196,7 +275,7 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Create server and start it.
 	server := Server{}
 	server.wallet = walletObj
-	err = server.NewServer(cfg.Listener, db, dbmp, outcoinDb, cfg.NumIndexerWorkers, cfg.IndexerAccessTokens, version, btcChain, bnbChainState, interrupt)
+	err = server.NewServer(cfg.Listener, db, dbmp, outcoinDb, cfg.NumIndexerWorkers, cfg.IndexerAccessTokens, version, btcChain, utxoChains, bnbChainState, interrupt)
 	if err != nil {
 		Logger.log.Errorf("Unable to start server on %+v", cfg.Listener)
 		Logger.log.Error(err)
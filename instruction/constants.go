@@ -4,6 +4,7 @@ const (
 	SWAP_SHARD_ACTION              = "swapshard"
 	SWAP_ACTION                    = "swap"
 	RANDOM_ACTION                  = "random"
+	RANDOM_BEACON_ACTION           = "randombeacon"
 	STAKE_ACTION                   = "stake"
 	ASSIGN_ACTION                  = "assign"
 	ASSIGN_SYNC_ACTION             = "assignsync"
@@ -19,6 +20,7 @@ const (
 	FINISH_SYNC_ACTION             = "finishsync"
 	ACCEPT_BLOCK_REWARD_V3_ACTION  = "acceptblockrewardv3"
 	SHARD_RECEIVE_REWARD_V3_ACTION = "shardreceiverewardv3"
+	EQUIVOCATION_ACTION            = "equivocation"
 
 	SHARD_RECEIVE_REWARD_V1_ACTION = 43
 	ACCEPT_BLOCK_REWARD_V1_ACTION  = 37
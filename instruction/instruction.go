@@ -30,6 +30,7 @@ type CommitteeStateInstruction struct {
 
 func IsConsensusInstruction(action string) bool {
 	return action == RANDOM_ACTION ||
+		action == RANDOM_BEACON_ACTION ||
 		action == SWAP_ACTION ||
 		action == STAKE_ACTION ||
 		action == ASSIGN_ACTION ||
@@ -42,7 +43,8 @@ func IsConsensusInstruction(action string) bool {
 		action == FINISH_SYNC_ACTION ||
 		action == SHARD_INST ||
 		action == BEACON_INST ||
-		action == RETURN_ACTION
+		action == RETURN_ACTION ||
+		action == EQUIVOCATION_ACTION
 }
 
 // the order of instruction must always be maintain
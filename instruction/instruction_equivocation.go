@@ -0,0 +1,106 @@
+package instruction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+//EquivocationInstruction : validator proven to sign two blocks in the same timeslot
+// format: "equivocation", "key", "chainID", "proposeTimeSlot", "blockHashA,blockHashB", "txReqID"
+type EquivocationInstruction struct {
+	CommitteePublicKey       string
+	CommitteePublicKeyStruct incognitokey.CommitteePublicKey
+	ChainID                  int
+	ProposeTimeSlot          int64
+	BlockHashes              []string
+	TxReqID                  common.Hash
+}
+
+//NewEquivocationInstructionWithValue : Constructor with value
+func NewEquivocationInstructionWithValue(
+	committeePublicKey string,
+	chainID int,
+	proposeTimeSlot int64,
+	blockHashes []string,
+	txReqID common.Hash,
+) *EquivocationInstruction {
+	equivocationInstruction := &EquivocationInstruction{
+		CommitteePublicKey: committeePublicKey,
+		ChainID:            chainID,
+		ProposeTimeSlot:    proposeTimeSlot,
+		BlockHashes:        blockHashes,
+		TxReqID:            txReqID,
+	}
+	equivocationInstruction.CommitteePublicKeyStruct.FromString(committeePublicKey)
+	return equivocationInstruction
+}
+
+//GetType : Get type of equivocation instruction
+func (equivocationIns *EquivocationInstruction) GetType() string {
+	return EQUIVOCATION_ACTION
+}
+
+//ToString : Convert class to string
+func (equivocationIns *EquivocationInstruction) ToString() []string {
+	return []string{
+		EQUIVOCATION_ACTION,
+		equivocationIns.CommitteePublicKey,
+		strconv.Itoa(equivocationIns.ChainID),
+		strconv.FormatInt(equivocationIns.ProposeTimeSlot, 10),
+		strings.Join(equivocationIns.BlockHashes, SPLITTER),
+		equivocationIns.TxReqID.String(),
+	}
+}
+
+//ValidateAndImportEquivocationInstructionFromString : Validate and import equivocation instruction from string
+func ValidateAndImportEquivocationInstructionFromString(instruction []string) (*EquivocationInstruction, error) {
+	if err := ValidateEquivocationInstructionSanity(instruction); err != nil {
+		return nil, err
+	}
+	return ImportEquivocationInstructionFromString(instruction), nil
+}
+
+//ImportEquivocationInstructionFromString : Import equivocation instruction from string, instruction must be validated
+func ImportEquivocationInstructionFromString(instruction []string) *EquivocationInstruction {
+	chainID, _ := strconv.Atoi(instruction[2])
+	proposeTimeSlot, _ := strconv.ParseInt(instruction[3], 10, 64)
+	txReqID, _ := common.Hash{}.NewHashFromStr(instruction[5])
+	return NewEquivocationInstructionWithValue(
+		instruction[1],
+		chainID,
+		proposeTimeSlot,
+		strings.Split(instruction[4], SPLITTER),
+		*txReqID,
+	)
+}
+
+//ValidateEquivocationInstructionSanity : Validate equivocation instruction data type
+func ValidateEquivocationInstructionSanity(instruction []string) error {
+	if len(instruction) != 6 {
+		return fmt.Errorf("invalid length, %+v", instruction)
+	}
+	if instruction[0] != EQUIVOCATION_ACTION {
+		return fmt.Errorf("invalid equivocation action, %+v", instruction)
+	}
+	committeePublicKey := incognitokey.CommitteePublicKey{}
+	if err := committeePublicKey.FromString(instruction[1]); err != nil {
+		return fmt.Errorf("invalid committee public key %+v, %+v", instruction[1], err)
+	}
+	if _, err := strconv.Atoi(instruction[2]); err != nil {
+		return fmt.Errorf("invalid chain id %+v, %+v", instruction[2], err)
+	}
+	if _, err := strconv.ParseInt(instruction[3], 10, 64); err != nil {
+		return fmt.Errorf("invalid propose timeslot %+v, %+v", instruction[3], err)
+	}
+	if len(strings.Split(instruction[4], SPLITTER)) != 2 {
+		return fmt.Errorf("invalid block hashes, %+v", instruction[4])
+	}
+	if _, err := (common.Hash{}).NewHashFromStr(instruction[5]); err != nil {
+		return fmt.Errorf("invalid tx request id %+v, %+v", instruction[5], err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package instruction
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestValidateAndImportEquivocationInstructionFromString(t *testing.T) {
+	initPublicKey()
+	txReqID := common.HashH([]byte("tx"))
+	blockHashA := common.HashH([]byte("a")).String()
+	blockHashB := common.HashH([]byte("b")).String()
+	tests := []struct {
+		name        string
+		instruction []string
+		want        *EquivocationInstruction
+		wantErr     bool
+	}{
+		{
+			name:        "Invalid Length",
+			instruction: []string{EQUIVOCATION_ACTION, key1, "0", "100", blockHashA + SPLITTER + blockHashB},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid Action",
+			instruction: []string{UNSTAKE_ACTION, key1, "0", "100", blockHashA + SPLITTER + blockHashB, txReqID.String()},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid Public Key",
+			instruction: []string{EQUIVOCATION_ACTION, "key1", "0", "100", blockHashA + SPLITTER + blockHashB, txReqID.String()},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid Timeslot",
+			instruction: []string{EQUIVOCATION_ACTION, key1, "0", "abc", blockHashA + SPLITTER + blockHashB, txReqID.String()},
+			wantErr:     true,
+		},
+		{
+			name:        "Only One Block Hash",
+			instruction: []string{EQUIVOCATION_ACTION, key1, "0", "100", blockHashA, txReqID.String()},
+			wantErr:     true,
+		},
+		{
+			name:        "Valid Input",
+			instruction: []string{EQUIVOCATION_ACTION, key1, "1", "100", blockHashA + SPLITTER + blockHashB, txReqID.String()},
+			want: &EquivocationInstruction{
+				CommitteePublicKey:       key1,
+				CommitteePublicKeyStruct: *incKey1,
+				ChainID:                  1,
+				ProposeTimeSlot:          100,
+				BlockHashes:              []string{blockHashA, blockHashB},
+				TxReqID:                  txReqID,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateAndImportEquivocationInstructionFromString(tt.instruction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAndImportEquivocationInstructionFromString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateAndImportEquivocationInstructionFromString() = %v, want %v", got, tt.want)
+			}
+			if got != nil && !reflect.DeepEqual(got.ToString(), tt.instruction) {
+				t.Errorf("ToString() = %v, want %v", got.ToString(), tt.instruction)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package instruction
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+)
+
+//RandomBeaconInstruction : random value of an epoch, hash of the beacon committee aggregated BLS signature
+// over the previous random value and the epoch. A fallback random value has no signature
+// format: "randombeacon", "epoch", "value", "aggSig", "signerIdx1,signerIdx2"
+type RandomBeaconInstruction struct {
+	Epoch      uint64
+	Value      common.Hash
+	AggSig     []byte
+	SignersIdx []int
+}
+
+//NewRandomBeaconInstructionWithValue : Constructor with value
+func NewRandomBeaconInstructionWithValue(epoch uint64, value common.Hash, aggSig []byte, signersIdx []int) *RandomBeaconInstruction {
+	return &RandomBeaconInstruction{
+		Epoch:      epoch,
+		Value:      value,
+		AggSig:     aggSig,
+		SignersIdx: signersIdx,
+	}
+}
+
+//NewRandomBeaconFallbackInstruction : Constructor of the instruction of a fallback random value
+func NewRandomBeaconFallbackInstruction(epoch uint64, value common.Hash) *RandomBeaconInstruction {
+	return &RandomBeaconInstruction{
+		Epoch:      epoch,
+		Value:      value,
+		AggSig:     []byte{},
+		SignersIdx: []int{},
+	}
+}
+
+//IsFallback : the random value is the fallback one, not derived from a signature
+func (r *RandomBeaconInstruction) IsFallback() bool {
+	return len(r.AggSig) == 0
+}
+
+//GetType : Get type of random beacon instruction
+func (r *RandomBeaconInstruction) GetType() string {
+	return RANDOM_BEACON_ACTION
+}
+
+//RandomNumber : random number consumed by the assign and swap rules
+func (r *RandomBeaconInstruction) RandomNumber() int64 {
+	return int64(binary.BigEndian.Uint64(r.Value[:8]))
+}
+
+//ToString : Convert class to string
+func (r *RandomBeaconInstruction) ToString() []string {
+	signersIdx := []string{}
+	for _, idx := range r.SignersIdx {
+		signersIdx = append(signersIdx, strconv.Itoa(idx))
+	}
+	aggSig := ""
+	if !r.IsFallback() {
+		aggSig = base58.Base58Check{}.Encode(r.AggSig, common.ZeroByte)
+	}
+	return []string{
+		RANDOM_BEACON_ACTION,
+		strconv.FormatUint(r.Epoch, 10),
+		r.Value.String(),
+		aggSig,
+		strings.Join(signersIdx, SPLITTER),
+	}
+}
+
+//ValidateAndImportRandomBeaconInstructionFromString : Validate and import random beacon instruction from string
+func ValidateAndImportRandomBeaconInstructionFromString(instruction []string) (*RandomBeaconInstruction, error) {
+	if err := ValidateRandomBeaconInstructionSanity(instruction); err != nil {
+		return nil, err
+	}
+	return ImportRandomBeaconInstructionFromString(instruction), nil
+}
+
+//ImportRandomBeaconInstructionFromString : Import random beacon instruction from string, instruction must be validated
+func ImportRandomBeaconInstructionFromString(instruction []string) *RandomBeaconInstruction {
+	epoch, _ := strconv.ParseUint(instruction[1], 10, 64)
+	value, _ := common.Hash{}.NewHashFromStr(instruction[2])
+	if instruction[3] == "" {
+		return NewRandomBeaconFallbackInstruction(epoch, *value)
+	}
+	aggSig, _, _ := base58.Base58Check{}.Decode(instruction[3])
+	signersIdx := []int{}
+	for _, idx := range strings.Split(instruction[4], SPLITTER) {
+		temp, _ := strconv.Atoi(idx)
+		signersIdx = append(signersIdx, temp)
+	}
+	return NewRandomBeaconInstructionWithValue(epoch, *value, aggSig, signersIdx)
+}
+
+//ValidateRandomBeaconInstructionSanity : Validate random beacon instruction data type
+func ValidateRandomBeaconInstructionSanity(instruction []string) error {
+	if len(instruction) != 5 {
+		return fmt.Errorf("invalid length, %+v", instruction)
+	}
+	if instruction[0] != RANDOM_BEACON_ACTION {
+		return fmt.Errorf("invalid random beacon action, %+v", instruction)
+	}
+	if _, err := strconv.ParseUint(instruction[1], 10, 64); err != nil {
+		return fmt.Errorf("invalid epoch %+v, %+v", instruction[1], err)
+	}
+	if _, err := (common.Hash{}).NewHashFromStr(instruction[2]); err != nil || len(instruction[2]) != common.HashSize*2 {
+		return fmt.Errorf("invalid random value %+v, %+v", instruction[2], err)
+	}
+	// a fallback random value has neither signature nor signers
+	if instruction[3] == "" && instruction[4] == "" {
+		return nil
+	}
+	if aggSig, _, err := (base58.Base58Check{}).Decode(instruction[3]); err != nil || len(aggSig) == 0 {
+		return fmt.Errorf("invalid aggregated signature %+v, %+v", instruction[3], err)
+	}
+	prevIdx := -1
+	for _, idx := range strings.Split(instruction[4], SPLITTER) {
+		temp, err := strconv.Atoi(idx)
+		if err != nil || temp <= prevIdx {
+			return fmt.Errorf("invalid signers index, %+v", instruction[4])
+		}
+		prevIdx = temp
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package instruction
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestValidateRandomBeaconInstructionSanity(t *testing.T) {
+	valid := NewRandomBeaconInstructionWithValue(3, common.HashH([]byte("value")), []byte{1, 2, 3}, []int{0, 2, 3}).ToString()
+	tests := []struct {
+		name        string
+		instruction []string
+		wantErr     bool
+	}{
+		{
+			name:        "Invalid length",
+			instruction: valid[:4],
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid action",
+			instruction: []string{RANDOM_ACTION, valid[1], valid[2], valid[3], valid[4]},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid epoch",
+			instruction: []string{RANDOM_BEACON_ACTION, "-1", valid[2], valid[3], valid[4]},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid value",
+			instruction: []string{RANDOM_BEACON_ACTION, valid[1], "abc", valid[3], valid[4]},
+			wantErr:     true,
+		},
+		{
+			name:        "Empty aggregated signature",
+			instruction: []string{RANDOM_BEACON_ACTION, valid[1], valid[2], "", valid[4]},
+			wantErr:     true,
+		},
+		{
+			name:        "Fallback with signers",
+			instruction: []string{RANDOM_BEACON_ACTION, valid[1], valid[2], "", "0"},
+			wantErr:     true,
+		},
+		{
+			name:        "Valid fallback",
+			instruction: NewRandomBeaconFallbackInstruction(3, common.HashH([]byte("value"))).ToString(),
+			wantErr:     false,
+		},
+		{
+			name:        "Unsorted signers index",
+			instruction: []string{RANDOM_BEACON_ACTION, valid[1], valid[2], valid[3], "0,3,2"},
+			wantErr:     true,
+		},
+		{
+			name:        "Valid Input",
+			instruction: valid,
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRandomBeaconInstructionSanity(tt.instruction); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRandomBeaconInstructionSanity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportRandomBeaconInstructionFromString(t *testing.T) {
+	want := NewRandomBeaconInstructionWithValue(3, common.HashH([]byte("value")), []byte{1, 2, 3}, []int{0, 2, 3})
+	got, err := ValidateAndImportRandomBeaconInstructionFromString(want.ToString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportRandomBeaconInstructionFromString() = %v, want %v", got, want)
+	}
+	if got.RandomNumber() != want.RandomNumber() {
+		t.Errorf("RandomNumber() = %v, want %v", got.RandomNumber(), want.RandomNumber())
+	}
+
+	fallback := NewRandomBeaconFallbackInstruction(3, common.HashH([]byte("value")))
+	got, err = ValidateAndImportRandomBeaconInstructionFromString(fallback.ToString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, fallback) || !got.IsFallback() {
+		t.Errorf("ImportRandomBeaconInstructionFromString() = %v, want %v", got, fallback)
+	}
+}
@@ -116,6 +116,12 @@ func (rsI *ReturnStakeInstruction) AddNewRequest(publicKey string, stakingTx str
 	rsI.PercentReturns = append(rsI.PercentReturns, 100)
 }
 
+// AddNewRequestWithPercent only returns percentReturn percent of the staking amount, the rest is slashed
+func (rsI *ReturnStakeInstruction) AddNewRequestWithPercent(publicKey string, stakingTx string, percentReturn uint) {
+	rsI.AddNewRequest(publicKey, stakingTx)
+	rsI.PercentReturns[len(rsI.PercentReturns)-1] = percentReturn
+}
+
 func ValidateAndImportReturnStakingInstructionFromString(instruction []string) (*ReturnStakeInstruction, error) {
 	if err := ValidateReturnStakingInstructionSanity(instruction); err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/incognitochain/incognito-chain/syncker/finishsync"
+	"github.com/incognitochain/incognito-chain/syncker/statesync"
 
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
@@ -40,6 +41,7 @@ import (
 	// privacy "github.com/incognitochain/incognito-chain/privacy/errorhandler"
 	relaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcRelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/incognitochain/incognito-chain/relaying/btcrelayer"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 	"github.com/incognitochain/incognito-chain/syncker"
@@ -80,6 +82,7 @@ var (
 	wrapperLogger          = backendLog.Logger("Wrapper log", false)
 	daov2Logger            = backendLog.Logger("DAO log", false)
 	btcRelayingLogger      = backendLog.Logger("BTC relaying log", false)
+	btcRelayerLogger       = backendLog.Logger("BTC relayer log", false)
 	synckerLogger          = backendLog.Logger("Syncker log ", false)
 	privacyV1Logger        = backendLog.Logger("Privacy V1 log ", false)
 	privacyV2Logger        = backendLog.Logger("Privacy V2 log ", false)
@@ -87,6 +90,7 @@ var (
 	committeeStateLogger   = backendLog.Logger("Committee State log ", false)
 	pdexLogger             = backendLog.Logger("Pdex log ", false)
 	finishSyncLogger       = backendLog.Logger("Finish Sync log ", false)
+	stateSyncLogger        = backendLog.Logger("State Sync log ", false)
 
 	portalLogger          = backendLog.Logger("Portal log ", false)
 	portalRelayingLogger  = backendLog.Logger("Portal relaying log ", false)
@@ -139,8 +143,10 @@ func init() {
 	wrapper.Logger.Init(wrapperLogger)
 	dataaccessobject.Logger.Init(daov2Logger)
 	btcRelaying.Logger.Init(btcRelayingLogger)
+	btcrelayer.Logger.Init(btcRelayerLogger)
 	syncker.Logger.Init(synckerLogger)
 	finishsync.Logger.Init(finishSyncLogger)
+	statesync.Logger.Init(stateSyncLogger)
 	privacy.LoggerV1.Init(privacyV1Logger)
 	privacy.LoggerV2.Init(privacyV2Logger)
 	instruction.Logger.Init(instructionLogger)
@@ -185,10 +191,12 @@ var subsystemLoggers = map[string]common.Logger{
 	"PEERV2":            peerv2Logger,
 	"DAO":               daov2Logger,
 	"BTCRELAYING":       btcRelayingLogger,
+	"BTCRELAYER":        btcRelayerLogger,
 	"SYNCKER":           synckerLogger,
 	"INST":              instructionLogger,
 	"COMS":              committeeStateLogger,
 	"FINS":              finishSyncLogger,
+	"STSY":              stateSyncLogger,
 	"PORTAL":            portalLogger,
 	"PORTALRELAYING":    portalRelayingLogger,
 	"PORTALV3COMMON":    portalV3CommonLogger,
@@ -22,6 +22,7 @@ import (
 	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
 )
 
 // default value
@@ -609,6 +610,12 @@ func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beac
 		}
 		return NewMempoolTxError(RejectSanityTx, fmt.Errorf("transaction's sansity %v is error %v", txHash.String(), err))
 	}
+	// Condition 1.1: the tx must not expire before the next block of the shard
+	if shardView != nil {
+		if err := tx_generic.ValidateExpiryHeight(tx, shardView.ShardHeight+1, shardView.BeaconHeight); err != nil {
+			return NewMempoolTxError(RejectSanityTx, fmt.Errorf("transaction %v is error %v", txHash.String(), err))
+		}
+	}
 
 	// Condition 2: Don't accept the transaction if it already exists in the pool.
 	isTxInPool := tp.isTxInPool(txHash)
@@ -23,6 +23,7 @@ type BurningRequest struct {
 	TokenID       common.Hash
 	TokenName     string
 	RemoteAddress string
+	NetworkID     uint8 `json:"NetworkID,omitempty"` // only used by BurningEVMNetworkRequestMeta
 	MetadataBase
 }
 
@@ -95,6 +96,16 @@ func (bReq BurningRequest) ValidateSanityData(chainRetriever ChainRetriever, sha
 		return false, false, fmt.Errorf("metadata type %d is not supported", bReq.Type)
 	}
 
+	if bReq.Type == BurningEVMNetworkRequestMeta {
+		evmNetwork, found := config.Param().GetEVMNetwork(bReq.NetworkID)
+		if !found {
+			return false, false, fmt.Errorf("evm network %v is not registered", bReq.NetworkID)
+		}
+		if !evmNetwork.IsActive(beaconHeight) {
+			return false, false, fmt.Errorf("evm network %v is not activated at beacon height %v", bReq.NetworkID, beaconHeight)
+		}
+	}
+
 	if (bReq.Type == BurningPRVERC20RequestMeta || bReq.Type == BurningPRVBEP20RequestMeta) && bReq.TokenID.String() != common.PRVIDStr {
 		return false, false, fmt.Errorf("metadata type %d does not support for incTokenID %v", bReq.Type, bReq.TokenID.String())
 	} else if (bReq.Type != BurningPRVERC20RequestMeta && bReq.Type != BurningPRVBEP20RequestMeta) && bReq.TokenID.String() == common.PRVIDStr {
@@ -109,7 +120,8 @@ func (bReq BurningRequest) ValidateMetadataByItself() bool {
 		bReq.Type == BurningForDepositToSCRequestMetaV2 || bReq.Type == BurningPBSCRequestMeta ||
 		bReq.Type == BurningPRVERC20RequestMeta || bReq.Type == BurningPRVBEP20RequestMeta ||
 		bReq.Type == BurningPBSCForDepositToSCRequestMeta ||
-		bReq.Type == BurningPLGRequestMeta || bReq.Type == BurningPLGForDepositToSCRequestMeta
+		bReq.Type == BurningPLGRequestMeta || bReq.Type == BurningPLGForDepositToSCRequestMeta ||
+		bReq.Type == BurningEVMNetworkRequestMeta
 }
 
 func (bReq BurningRequest) Hash() *common.Hash {
@@ -119,6 +131,9 @@ func (bReq BurningRequest) Hash() *common.Hash {
 	record += strconv.FormatUint(bReq.BurningAmount, 10)
 	record += bReq.TokenName
 	record += bReq.RemoteAddress
+	if bReq.Type == BurningEVMNetworkRequestMeta {
+		record += strconv.Itoa(int(bReq.NetworkID))
+	}
 	// final hash
 	hash := common.HashH([]byte(record))
 	return &hash
@@ -131,6 +146,9 @@ func (bReq BurningRequest) HashWithoutSig() *common.Hash {
 	record += strconv.FormatUint(bReq.BurningAmount, 10)
 	record += bReq.TokenName
 	record += bReq.RemoteAddress
+	if bReq.Type == BurningEVMNetworkRequestMeta {
+		record += strconv.Itoa(int(bReq.NetworkID))
+	}
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -12,9 +12,11 @@ type AccumulatedValues struct {
 	UniqPRVEVMTxsUsed [][]byte
 	UniqPLGTxsUsed    [][]byte
 	UniqSOLTxsUsed    [][]byte
-	DBridgeTokenPair  map[string][]byte
-	CBridgeTokens     []*common.Hash
-	InitTokens        []*common.Hash
+	// UniqEVMNetworkTxsUsed is shared by all generic evm networks, the issued markers in statedb are kept per network
+	UniqEVMNetworkTxsUsed [][]byte
+	DBridgeTokenPair      map[string][]byte
+	CBridgeTokens         []*common.Hash
+	InitTokens            []*common.Hash
 }
 
 func (ac AccumulatedValues) CanProcessTokenPair(
@@ -108,8 +108,11 @@ const (
 	PortalResetPortalDBMeta = 199
 
 	// relaying
-	RelayingBNBHeaderMeta = 200
-	RelayingBTCHeaderMeta = 201
+	RelayingBNBHeaderMeta  = 200
+	RelayingBTCHeaderMeta  = 201
+	RelayingLTCHeaderMeta  = 343
+	RelayingDOGEHeaderMeta = 344
+	RelayingBCHHeaderMeta  = 345
 
 	PortalTopUpWaitingPortingRequestMeta  = 202
 	PortalTopUpWaitingPortingResponseMeta = 203
@@ -138,6 +141,8 @@ const (
 	PortalV4FeeReplacementRequestMeta = 265
 	PortalV4SubmitConfirmedTxMeta     = 266
 	PortalV4ConvertVaultRequestMeta   = 267
+	PortalV4VaultRotationRequestMeta  = 268
+	PortalV4VaultConsolidationMeta    = 269
 
 	// erc20/bep20 for prv token
 	IssuingPRVERC20RequestMeta  = 270
@@ -203,6 +208,16 @@ const (
 	// pSOL ( Solana )
 	BurningSOLForDepositToSCRequestMeta = 338
 	BurningSOLConfirmForDepositToSCMeta = 158
+
+	// generic evm networks, resolved from config.Param().EVMNetworks by network id
+	// the burning confirm meta type of each network is declared in the registry, within
+	// [config.MinEVMNetworkBurningConfirmMeta, config.MaxEVMNetworkBurningConfirmMeta] which no type above may use
+	IssuingEVMNetworkRequestMeta  = 339
+	IssuingEVMNetworkResponseMeta = 340
+	BurningEVMNetworkRequestMeta  = 341
+
+	// consensus
+	EquivocationEvidenceMeta = 342
 )
 
 var minerCreatedMetaTypes = []int{
@@ -215,6 +230,7 @@ var minerCreatedMetaTypes = []int{
 	IssuingPRVBEP20ResponseMeta,
 	IssuingPLGResponseMeta,
 	IssuingSOLResponseMeta,
+	IssuingEVMNetworkResponseMeta,
 	ReturnStakingMeta,
 	WithDrawRewardResponseMeta,
 	PDETradeResponseMeta,
@@ -330,6 +346,9 @@ var portalMetaTypesV3 = []int{
 var portalRelayingMetaTypes = []int{
 	RelayingBNBHeaderMeta,
 	RelayingBTCHeaderMeta,
+	RelayingLTCHeaderMeta,
+	RelayingDOGEHeaderMeta,
+	RelayingBCHHeaderMeta,
 }
 
 var bridgeMetas = []string{
@@ -357,6 +376,8 @@ var portalV4MetaTypes = []int{
 	PortalV4FeeReplacementRequestMeta,
 	PortalV4SubmitConfirmedTxMeta,
 	PortalV4ConvertVaultRequestMeta,
+	PortalV4VaultRotationRequestMeta,
+	PortalV4VaultConsolidationMeta,
 }
 
 // NOTE: add new records when add new feature flags
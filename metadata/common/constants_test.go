@@ -0,0 +1,40 @@
+package common
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/config"
+)
+
+// TestMetaTypesOutOfEVMNetworkRange checks no metadata type declared in constants.go uses the range
+// reserved to the burning confirm meta of the evm networks of the registry
+func TestMetaTypesOutOfEVMNetworkRange(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "constants.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		valueSpec, ok := node.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, value := range valueSpec.Values {
+			lit, ok := value.(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT {
+				continue
+			}
+			metaType, err := strconv.Atoi(lit.Value)
+			if err != nil {
+				continue
+			}
+			if metaType >= config.MinEVMNetworkBurningConfirmMeta && metaType <= config.MaxEVMNetworkBurningConfirmMeta {
+				t.Errorf("%v = %v is in the range reserved to evm networks", valueSpec.Names[i].Name, metaType)
+			}
+		}
+		return true
+	})
+}
@@ -107,9 +107,13 @@ const (
 	PortalV4FeeReplacementRequestMetaError
 	PortalV4SubmitConfirmedTxRequestMetaError
 	PortalV4ConvertVaultRequestMetaError
+	PortalV4VaultRotationRequestMetaError
 
 	// relaying header
 	RelayingHeaderMetaError
+
+	// equivocation evidence
+	EquivocationEvidenceMetaError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -211,9 +215,13 @@ var ErrCodeMessage = map[int]struct {
 	PortalV4FeeReplacementRequestMetaError:         {-10003, "Portal batch unshield request metadata error"},
 	PortalV4SubmitConfirmedTxRequestMetaError:      {-10004, "Portal submit external confirmed tx metadata error"},
 	PortalV4ConvertVaultRequestMetaError:           {-10005, "Portal convert vault tx metadata error"},
+	PortalV4VaultRotationRequestMetaError:          {-10006, "Portal vault rotation request metadata error"},
 
 	// relaying header
 	RelayingHeaderMetaError: {-11005, " relaying header metadata error"},
+
+	// equivocation evidence
+	EquivocationEvidenceMetaError: {-12001, "Equivocation evidence metadata error"},
 }
 
 type MetadataTxError struct {
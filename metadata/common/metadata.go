@@ -69,11 +69,12 @@ type ChainRetriever interface {
 	GetBTCChainID() string
 	GetBTCHeaderChain() *btcrelaying.BlockChain
 	GetBTCChainParams() *chaincfg.Params
+	GetUTXOHeaderChain(chainID string) *btcrelaying.BlockChain
 	GetShardStakingTx(shardID byte, beaconHeight uint64) (map[string]string, error)
 	IsAfterNewZKPCheckPoint(beaconHeight uint64) bool
 	IsAfterPrivacyV2CheckPoint(beaconHeight uint64) bool
 	IsAfterPdexv3CheckPoint(beaconHeight uint64) bool
-	GetPortalFeederAddress(beaconHeight uint64) string
+	GetPortalFeederAddresses(beaconHeight uint64) []string
 	IsSupportedTokenCollateralV3(beaconHeight uint64, externalTokenID string) bool
 	GetPortalETHContractAddrStr(beaconHeight uint64) string
 	GetLatestBNBBlkHeight() (int64, error)
@@ -152,6 +153,8 @@ type Transaction interface {
 	SetType(string)
 	GetLockTime() int64
 	SetLockTime(int64)
+	GetExpiryHeight() uint64
+	SetExpiryHeight(uint64)
 	GetSenderAddrLastByte() byte
 	SetGetSenderAddrLastByte(byte)
 	GetTxFee() uint64
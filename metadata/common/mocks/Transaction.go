@@ -62,6 +62,20 @@ func (_m *Transaction) CheckTxVersion(_a0 int8) bool {
 	return r0
 }
 
+// GetExpiryHeight provides a mock function with given fields:
+func (_m *Transaction) GetExpiryHeight() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // GetInfo provides a mock function with given fields:
 func (_m *Transaction) GetInfo() []byte {
 	ret := _m.Called()
@@ -633,6 +647,11 @@ func (_m *Transaction) LoadData(db *statedb.StateDB) error {
 	return r0
 }
 
+// SetExpiryHeight provides a mock function with given fields: _a0
+func (_m *Transaction) SetExpiryHeight(_a0 uint64) {
+	_m.Called(_a0)
+}
+
 // SetGetSenderAddrLastByte provides a mock function with given fields: _a0
 func (_m *Transaction) SetGetSenderAddrLastByte(_a0 byte) {
 	_m.Called(_a0)
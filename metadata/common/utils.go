@@ -7,6 +7,7 @@ import (
 
 	ec "github.com/ethereum/go-ethereum/common"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/privacy/coin"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -28,10 +29,23 @@ func HasBridgeInstructions(instructions [][]string) bool {
 				return true
 			}
 		}
+		if len(inst) > 0 && IsEVMNetworkBurningConfirmMeta(inst[0]) {
+			return true
+		}
 	}
 	return false
 }
 
+// IsEVMNetworkBurningConfirmMeta checks whether instMetaType is the burning confirm meta type of a registered evm network
+func IsEVMNetworkBurningConfirmMeta(instMetaType string) bool {
+	metaType, err := strconv.Atoi(instMetaType)
+	if err != nil || config.Param() == nil {
+		return false
+	}
+	_, found := config.Param().GetEVMNetworkByBurningConfirmMeta(metaType)
+	return found
+}
+
 type MetaInfo struct {
 	HasInput   bool
 	HasOutput  bool
@@ -80,6 +94,7 @@ func buildMetaInfo() {
 		IssuingPRVBEP20ResponseMeta,
 		IssuingPLGResponseMeta,
 		IssuingSOLResponseMeta,
+		IssuingEVMNetworkResponseMeta,
 		IssuingResponseMeta,
 		InitTokenResponseMeta,
 
@@ -152,6 +167,7 @@ func buildMetaInfo() {
 		BurningPLGForDepositToSCRequestMeta,
 		BurningSOLRequestMeta,
 		BurningSOLForDepositToSCRequestMeta,
+		BurningEVMNetworkRequestMeta,
 	}
 	metaListNInfo = append(metaListNInfo, ListAndInfo{
 		list: listTpNormal,
@@ -237,6 +253,9 @@ func buildMetaInfo() {
 		PortalUnlockOverRateCollateralsMeta,
 		RelayingBNBHeaderMeta,
 		RelayingBTCHeaderMeta,
+		RelayingLTCHeaderMeta,
+		RelayingDOGEHeaderMeta,
+		RelayingBCHHeaderMeta,
 		PortalTopUpWaitingPortingRequestMeta,
 
 		IssuingRequestMeta,
@@ -248,6 +267,7 @@ func buildMetaInfo() {
 		InitTokenRequestMeta,
 		IssuingPLGRequestMeta,
 		IssuingSOLRequestMeta,
+		IssuingEVMNetworkRequestMeta,
 		BurningPRVERC20RequestMeta,
 		BurningPRVBEP20RequestMeta,
 
@@ -272,6 +292,9 @@ func buildMetaInfo() {
 		PortalV4FeeReplacementRequestMeta,
 		PortalV4SubmitConfirmedTxMeta,
 		PortalV4ConvertVaultRequestMeta,
+		PortalV4VaultRotationRequestMeta,
+
+		EquivocationEvidenceMeta,
 	}
 	metaListNInfo = append(metaListNInfo, ListAndInfo{
 		list: listNNormal,
@@ -376,12 +399,14 @@ func buildMetaInfo() {
 		IssuingBSCRequestMeta,
 		IssuingPLGRequestMeta,
 		IssuingSOLRequestMeta,
+		IssuingEVMNetworkRequestMeta,
 		IssuingETHResponseMeta,
 		IssuingBSCResponseMeta,
 		IssuingPRVERC20ResponseMeta,
 		IssuingPRVBEP20ResponseMeta,
 		IssuingPLGResponseMeta,
 		IssuingSOLResponseMeta,
+		IssuingEVMNetworkResponseMeta,
 		PDEWithdrawalRequestMeta,
 		PDEWithdrawalResponseMeta,
 		PDEPRVRequiredContributionRequestMeta,
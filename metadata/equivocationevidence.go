@@ -0,0 +1,145 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+)
+
+// EquivocationVote - the signed fields of a consensus vote (blsbft.BFTVote)
+type EquivocationVote struct {
+	PrevBlockHash      string
+	BlockHeight        uint64
+	BlockHash          string
+	Validator          string
+	BLS                []byte
+	BRI                []byte
+	Confirmation       []byte
+	ProduceTimeSlot    int64
+	ProposeTimeSlot    int64
+	CommitteeFromBlock common.Hash
+	ChainID            int
+}
+
+// EquivocationEvidence - two conflicting votes signed by the same validator
+// metadata - create normal tx with this metadata, anyone holding the votes can submit it
+type EquivocationEvidence struct {
+	MetadataBase
+	VoteA EquivocationVote
+	VoteB EquivocationVote
+}
+
+// EquivocationEvidenceAction - shard validator creates instruction that contain this action content
+type EquivocationEvidenceAction struct {
+	Meta    EquivocationEvidence
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// NewEquivocationEvidence orders the votes by block hash so the same pair always builds the same evidence
+func NewEquivocationEvidence(voteA, voteB EquivocationVote) *EquivocationEvidence {
+	if voteA.BlockHash > voteB.BlockHash {
+		voteA, voteB = voteB, voteA
+	}
+	return &EquivocationEvidence{
+		MetadataBase: MetadataBase{
+			Type: EquivocationEvidenceMeta,
+		},
+		VoteA: voteA,
+		VoteB: voteB,
+	}
+}
+
+// CheckConflict only accepts two votes for different blocks of a shard in the same timeslot.
+// The other rules of the byzantine detector depend on the order votes were received and can not be proven on chain.
+// Beacon votes are rejected: the committee state only slashes shard validators, the beacon committee is not slashed.
+// The vote confirmations are verified by beacon, see signer.VoteData.ConfirmationHash.
+func (e EquivocationEvidence) CheckConflict() error {
+	if e.VoteA.Validator == "" || e.VoteA.Validator != e.VoteB.Validator {
+		return errors.New("votes must be signed by the same validator")
+	}
+	if e.VoteA.ChainID == common.BeaconChainID || e.VoteB.ChainID == common.BeaconChainID {
+		return errors.New("equivocation of beacon validators is not slashed")
+	}
+	if e.VoteA.ChainID != e.VoteB.ChainID || e.VoteA.ChainID < 0 || e.VoteA.ChainID >= config.Param().ActiveShards {
+		return errors.New("votes must be for the same shard")
+	}
+	if e.VoteA.CommitteeFromBlock != e.VoteB.CommitteeFromBlock {
+		return errors.New("votes must be signed with the same committee")
+	}
+	if e.VoteA.ProposeTimeSlot != e.VoteB.ProposeTimeSlot {
+		return errors.New("votes must be in the same timeslot")
+	}
+	if e.VoteA.BlockHash == e.VoteB.BlockHash {
+		return errors.New("votes must be for different blocks")
+	}
+	minHeight := config.Param().ConsensusParam.ByzantineDetectorHeight
+	if e.VoteA.BlockHeight < minHeight || e.VoteB.BlockHeight < minHeight {
+		return fmt.Errorf("votes below height %v do not sign their timeslot", minHeight)
+	}
+	if len(e.VoteA.Confirmation) == 0 || len(e.VoteB.Confirmation) == 0 {
+		return errors.New("votes must be signed")
+	}
+	return nil
+}
+
+func (e EquivocationEvidence) ValidateTxWithBlockChain(
+	tx Transaction,
+	chainRetriever ChainRetriever,
+	shardViewRetriever ShardViewRetriever,
+	beaconViewRetriever BeaconViewRetriever,
+	shardID byte,
+	db *statedb.StateDB,
+) (bool, error) {
+	// signatures are verified by beacon against the committee of the votes
+	return true, nil
+}
+
+func (e EquivocationEvidence) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < config.Param().ConsensusParam.EquivocationSlashingHeight {
+		return false, false, NewMetadataTxError(metadataCommon.EquivocationEvidenceMetaError, errors.New("equivocation slashing is not enabled"))
+	}
+	if tx.GetType() != common.TxNormalType {
+		return false, false, NewMetadataTxError(metadataCommon.EquivocationEvidenceMetaError, errors.New("tx equivocation evidence must be TxNormalType"))
+	}
+	if err := e.CheckConflict(); err != nil {
+		return false, false, NewMetadataTxError(metadataCommon.EquivocationEvidenceMetaError, err)
+	}
+	return true, true, nil
+}
+
+func (e EquivocationEvidence) ValidateMetadataByItself() bool {
+	return e.Type == EquivocationEvidenceMeta
+}
+
+func (e EquivocationEvidence) Hash() *common.Hash {
+	rawBytes, _ := json.Marshal(&e)
+	hash := common.HashH(rawBytes)
+	return &hash
+}
+
+func (e *EquivocationEvidence) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+	actionContent := EquivocationEvidenceAction{
+		Meta:    *e,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(e.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (e *EquivocationEvidence) CalculateSize() uint64 {
+	return calculateSize(e)
+}
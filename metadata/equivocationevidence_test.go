@@ -0,0 +1,63 @@
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func TestEquivocationEvidence(t *testing.T) {
+	config.AbortParam()
+	config.Param().ActiveShards = 2
+	config.Param().ConsensusParam.ByzantineDetectorHeight = 5
+
+	newVote := func(blockHash string) metadata.EquivocationVote {
+		return metadata.EquivocationVote{
+			PrevBlockHash:      common.HashH([]byte("prev")).String(),
+			BlockHeight:        10,
+			BlockHash:          blockHash,
+			Validator:          "validator",
+			ProduceTimeSlot:    100,
+			ProposeTimeSlot:    100,
+			CommitteeFromBlock: common.HashH([]byte("committee")),
+			ChainID:            1,
+			Confirmation:       []byte(blockHash),
+		}
+	}
+	voteA := newVote(common.HashH([]byte("a")).String())
+	voteB := newVote(common.HashH([]byte("b")).String())
+
+	evidence := metadata.NewEquivocationEvidence(voteB, voteA)
+	if evidence.VoteA.BlockHash > evidence.VoteB.BlockHash {
+		t.Errorf("votes must be ordered by block hash")
+	}
+	if err := evidence.CheckConflict(); err != nil {
+		t.Errorf("CheckConflict() error = %v", err)
+	}
+	if *evidence.Hash() != *metadata.NewEquivocationEvidence(voteA, voteB).Hash() {
+		t.Errorf("evidence hash must not depend on the order of votes")
+	}
+
+	tampered := voteB
+	tampered.ProposeTimeSlot = 101
+	tests := []struct {
+		name  string
+		voteA metadata.EquivocationVote
+		voteB metadata.EquivocationVote
+	}{
+		{"same block", voteA, voteA},
+		{"different timeslots", voteA, tampered},
+		{"below byzantine detector height", func() metadata.EquivocationVote { v := voteA; v.BlockHeight = 1; return v }(), voteB},
+		{"beacon votes", func() metadata.EquivocationVote { v := voteA; v.ChainID = -1; return v }(), func() metadata.EquivocationVote { v := voteB; v.ChainID = -1; return v }()},
+		{"different validators", voteA, func() metadata.EquivocationVote { v := voteB; v.Validator = "other"; return v }()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := metadata.NewEquivocationEvidence(tt.voteA, tt.voteB).CheckConflict(); err == nil {
+				t.Errorf("CheckConflict() must fail")
+			}
+		})
+	}
+}
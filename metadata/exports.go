@@ -57,6 +57,7 @@ var ShouldIncludeBeaconViewByPdexv3Tx = metadataCommon.ShouldIncludeBeaconViewBy
 var ConvertPrivacyTokenToNativeToken = metadataCommon.ConvertPrivacyTokenToNativeToken
 var ConvertNativeTokenToPrivacyToken = metadataCommon.ConvertNativeTokenToPrivacyToken
 var HasBridgeInstructions = metadataCommon.HasBridgeInstructions
+var IsEVMNetworkBurningConfirmMeta = metadataCommon.IsEVMNetworkBurningConfirmMeta
 var HasPortalInstructions = metadataCommon.HasPortalInstructions
 
 var calculateSize = metadataCommon.CalculateSize
@@ -161,6 +162,9 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta                 = metadataCommon.RelayingBNBHeaderMeta
 	RelayingBTCHeaderMeta                 = metadataCommon.RelayingBTCHeaderMeta
+	RelayingLTCHeaderMeta                 = metadataCommon.RelayingLTCHeaderMeta
+	RelayingDOGEHeaderMeta                = metadataCommon.RelayingDOGEHeaderMeta
+	RelayingBCHHeaderMeta                 = metadataCommon.RelayingBCHHeaderMeta
 	PortalTopUpWaitingPortingRequestMeta  = metadataCommon.PortalTopUpWaitingPortingRequestMeta
 	PortalTopUpWaitingPortingResponseMeta = metadataCommon.PortalTopUpWaitingPortingResponseMeta
 	// incognito mode for smart contract
@@ -210,6 +214,12 @@ const (
 
 	BurningSOLForDepositToSCRequestMeta = metadataCommon.BurningSOLForDepositToSCRequestMeta
 	BurningSOLConfirmForDepositToSCMeta = metadataCommon.BurningSOLConfirmForDepositToSCMeta
+
+	IssuingEVMNetworkRequestMeta  = metadataCommon.IssuingEVMNetworkRequestMeta
+	IssuingEVMNetworkResponseMeta = metadataCommon.IssuingEVMNetworkResponseMeta
+	BurningEVMNetworkRequestMeta  = metadataCommon.BurningEVMNetworkRequestMeta
+
+	EquivocationEvidenceMeta = metadataCommon.EquivocationEvidenceMeta
 )
 
 // export error codes
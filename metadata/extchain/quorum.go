@@ -0,0 +1,188 @@
+package extchain
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/pkg/errors"
+)
+
+// QueryFunc queries one endpoint and returns the result along with a digest,
+// results from different endpoints are considered equal iff their digests are equal
+type QueryFunc func(host string) (result interface{}, digest string, err error)
+
+// HeightFunc queries the most recent block height known by one endpoint
+type HeightFunc func(host string) (*big.Int, error)
+
+// Verifier reads data of an external chain and only returns what enough endpoints agree on
+type Verifier interface {
+	Query(method string, query QueryFunc) (interface{}, error)
+	QueryHeight(method string, query HeightFunc) (*big.Int, error)
+}
+
+// QuorumVerifier queries all configured endpoints of a network concurrently
+// and requires at least minQuorum of them to return the same result
+type QuorumVerifier struct {
+	network   string
+	hosts     []string
+	minQuorum int
+}
+
+type endpointResult struct {
+	host   string
+	result interface{}
+	digest string
+	height *big.Int
+	err    error
+}
+
+// NewQuorumVerifier builds a verifier over the distinct non-empty hosts,
+// minQuorum <= 0 means a simple majority of the hosts
+func NewQuorumVerifier(network string, hosts []string, minQuorum int) (*QuorumVerifier, error) {
+	distinctHosts := []string{}
+	seen := map[string]bool{}
+	for _, host := range hosts {
+		host = strings.TrimSpace(host)
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		distinctHosts = append(distinctHosts, host)
+	}
+	if len(distinctHosts) == 0 {
+		return nil, errors.Errorf("no endpoint configured for network %s", network)
+	}
+	if minQuorum <= 0 {
+		minQuorum = len(distinctHosts)/2 + 1
+	}
+	if minQuorum > len(distinctHosts) {
+		return nil, errors.Errorf("quorum %v of network %s exceeds the number of endpoints %v", minQuorum, network, len(distinctHosts))
+	}
+	return &QuorumVerifier{
+		network:   network,
+		hosts:     distinctHosts,
+		minQuorum: minQuorum,
+	}, nil
+}
+
+func (v QuorumVerifier) Network() string {
+	return v.network
+}
+
+func (v QuorumVerifier) Hosts() []string {
+	return v.hosts
+}
+
+func (v QuorumVerifier) MinQuorum() int {
+	return v.minQuorum
+}
+
+// Query returns the result of the largest group of endpoints agreeing on the same digest,
+// an error is returned if that group is smaller than the quorum
+func (v QuorumVerifier) Query(method string, query QueryFunc) (interface{}, error) {
+	results := v.queryAll(func(host string) endpointResult {
+		result, digest, err := query(host)
+		if err == nil && result == nil {
+			err = errors.New("empty result")
+		}
+		return endpointResult{host: host, result: result, digest: digest, err: err}
+	})
+
+	groups := map[string][]endpointResult{}
+	digests := []string{}
+	numErrors := 0
+	for _, res := range results {
+		if res.err != nil {
+			numErrors++
+			continue
+		}
+		if _, ok := groups[res.digest]; !ok {
+			digests = append(digests, res.digest)
+		}
+		groups[res.digest] = append(groups[res.digest], res)
+	}
+	sort.SliceStable(digests, func(i, j int) bool {
+		return len(groups[digests[i]]) > len(groups[digests[j]])
+	})
+
+	if len(digests) > 1 || numErrors > 0 {
+		v.reportDisagreement(method, results)
+	}
+	if len(digests) == 0 || len(groups[digests[0]]) < v.minQuorum ||
+		(len(digests) > 1 && len(groups[digests[1]]) == len(groups[digests[0]])) {
+		v.counter("noquorum").Inc(1)
+		return nil, errors.Errorf("%s on network %s: no quorum, need %v of %v endpoints to agree", method, v.network, v.minQuorum, len(v.hosts))
+	}
+	return groups[digests[0]][0].result, nil
+}
+
+// QueryHeight returns the highest block height that at least minQuorum endpoints have reached,
+// so a lagging or lying minority can neither speed up nor block confirmations
+func (v QuorumVerifier) QueryHeight(method string, query HeightFunc) (*big.Int, error) {
+	results := v.queryAll(func(host string) endpointResult {
+		height, err := query(host)
+		if err == nil && height == nil {
+			err = errors.New("empty result")
+		}
+		return endpointResult{host: host, height: height, err: err}
+	})
+
+	heights := []*big.Int{}
+	for _, res := range results {
+		if res.err == nil {
+			heights = append(heights, res.height)
+		}
+	}
+	if len(heights) < len(results) {
+		v.reportDisagreement(method, results)
+	}
+	if len(heights) < v.minQuorum {
+		v.counter("noquorum").Inc(1)
+		return nil, errors.Errorf("%s on network %s: no quorum, only %v of %v endpoints responded", method, v.network, len(heights), len(v.hosts))
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i].Cmp(heights[j]) > 0
+	})
+	return new(big.Int).Set(heights[v.minQuorum-1]), nil
+}
+
+func (v QuorumVerifier) queryAll(query func(host string) endpointResult) []endpointResult {
+	results := make([]endpointResult, len(v.hosts))
+	wg := sync.WaitGroup{}
+	for i, host := range v.hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			results[i] = query(host)
+		}(i, host)
+	}
+	wg.Wait()
+	return results
+}
+
+func (v QuorumVerifier) reportDisagreement(method string, results []endpointResult) {
+	details := []string{}
+	for _, res := range results {
+		if res.err != nil {
+			v.counter("endpointerror").Inc(1)
+			details = append(details, fmt.Sprintf("%s: error %v", res.host, res.err))
+		} else if res.height != nil {
+			details = append(details, fmt.Sprintf("%s: %v", res.host, res.height))
+		} else {
+			details = append(details, fmt.Sprintf("%s: %s", res.host, res.digest))
+		}
+	}
+	v.counter("disagreement").Inc(1)
+	if metadataCommon.Logger.Log != nil {
+		metadataCommon.Logger.Log.Warnf("WARNING: endpoints of network %s disagree on %s: %s", v.network, method, strings.Join(details, "; "))
+	}
+}
+
+func (v QuorumVerifier) counter(name string) metrics.Counter {
+	return metrics.GetOrRegisterCounter(fmt.Sprintf("extchain/%s/%s", v.network, name), nil)
+}
@@ -0,0 +1,220 @@
+package extchain
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	metadataCommon.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type fakeRes struct {
+	rpccaller.RPCBaseRes
+	Result string `json:"result"`
+}
+
+// newFakeNode starts a json-rpc server answering every method with the given values
+func newFakeNode(t *testing.T, results map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string `json:"method"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		res := fakeRes{Result: results[req.Method]}
+		res.Id = 1
+		if _, ok := results[req.Method]; !ok {
+			res.RPCError = &rpccaller.RPCError{Code: -32601, Message: "method not found"}
+		}
+		json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func queryBlockHash(host string) (interface{}, string, error) {
+	var res fakeRes
+	err := rpccaller.NewRPCClient().RPCCall("", host, "", "eth_getBlockByHash", []interface{}{}, &res)
+	if err != nil {
+		return nil, "", err
+	}
+	if res.RPCError != nil {
+		return nil, "", errors.New(res.RPCError.Message)
+	}
+	return res.Result, res.Result, nil
+}
+
+func queryBlockNumber(host string) (*big.Int, error) {
+	var res fakeRes
+	err := rpccaller.NewRPCClient().RPCCall("", host, "", "eth_blockNumber", []interface{}{}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.RPCError != nil {
+		return nil, errors.New(res.RPCError.Message)
+	}
+	height, ok := new(big.Int).SetString(res.Result, 10)
+	if !ok {
+		return nil, errors.New("invalid height")
+	}
+	return height, nil
+}
+
+func TestNewQuorumVerifier(t *testing.T) {
+	v, err := NewQuorumVerifier("test", []string{"a", "b", "a", " ", "c"}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, v.Hosts())
+	assert.Equal(t, 2, v.MinQuorum())
+
+	_, err = NewQuorumVerifier("test", []string{"a", "a"}, 2)
+	assert.NotNil(t, err)
+
+	_, err = NewQuorumVerifier("test", []string{}, 1)
+	assert.NotNil(t, err)
+}
+
+func TestQuorumVerifier_Query(t *testing.T) {
+	honest1 := newFakeNode(t, map[string]string{"eth_getBlockByHash": "0xabc"})
+	honest2 := newFakeNode(t, map[string]string{"eth_getBlockByHash": "0xabc"})
+	liar := newFakeNode(t, map[string]string{"eth_getBlockByHash": "0xdef"})
+	broken := newFakeNode(t, map[string]string{})
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	tests := []struct {
+		name      string
+		hosts     []string
+		minQuorum int
+		want      interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "single honest endpoint",
+			hosts:     []string{honest1.URL},
+			minQuorum: 1,
+			want:      "0xabc",
+		},
+		{
+			name:      "honest majority outvotes a liar",
+			hosts:     []string{honest1.URL, liar.URL, honest2.URL},
+			minQuorum: 2,
+			want:      "0xabc",
+		},
+		{
+			name:      "failing endpoints are tolerated within quorum",
+			hosts:     []string{honest1.URL, broken.URL, down.URL, honest2.URL},
+			minQuorum: 2,
+			want:      "0xabc",
+		},
+		{
+			name:      "liar prevents unanimity",
+			hosts:     []string{honest1.URL, liar.URL, honest2.URL},
+			minQuorum: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "tie is rejected",
+			hosts:     []string{honest1.URL, liar.URL},
+			minQuorum: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "not enough responding endpoints",
+			hosts:     []string{honest1.URL, broken.URL, down.URL},
+			minQuorum: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewQuorumVerifier("test", tt.hosts, tt.minQuorum)
+			assert.Nil(t, err)
+			got, err := v.Query("eth_getBlockByHash", queryBlockHash)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuorumVerifier_QueryHeight(t *testing.T) {
+	ahead := newFakeNode(t, map[string]string{"eth_blockNumber": "1000"})
+	synced1 := newFakeNode(t, map[string]string{"eth_blockNumber": "105"})
+	synced2 := newFakeNode(t, map[string]string{"eth_blockNumber": "100"})
+	lagging := newFakeNode(t, map[string]string{"eth_blockNumber": "50"})
+	broken := newFakeNode(t, map[string]string{})
+
+	tests := []struct {
+		name      string
+		hosts     []string
+		minQuorum int
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name:      "a single node ahead can not speed up confirmations",
+			hosts:     []string{ahead.URL, synced1.URL, synced2.URL},
+			minQuorum: 2,
+			want:      105,
+		},
+		{
+			name:      "a lagging node can not block confirmations",
+			hosts:     []string{synced1.URL, synced2.URL, lagging.URL},
+			minQuorum: 2,
+			want:      100,
+		},
+		{
+			name:      "unanimity takes the lowest height",
+			hosts:     []string{ahead.URL, synced1.URL, lagging.URL},
+			minQuorum: 3,
+			want:      50,
+		},
+		{
+			name:      "not enough responding endpoints",
+			hosts:     []string{synced1.URL, broken.URL},
+			minQuorum: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewQuorumVerifier("test", tt.hosts, tt.minQuorum)
+			assert.Nil(t, err)
+			got, err := v.QueryHeight("eth_blockNumber", queryBlockNumber)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.Int64())
+		})
+	}
+}
+
+func TestQuorumVerifier_ReportsDisagreement(t *testing.T) {
+	honest := newFakeNode(t, map[string]string{"eth_getBlockByHash": "0xabc"})
+	liar := newFakeNode(t, map[string]string{"eth_getBlockByHash": "0xdef"})
+
+	v, err := NewQuorumVerifier("reporttest", []string{honest.URL, liar.URL, honest.URL + "/"}, 2)
+	assert.Nil(t, err)
+	before := metrics.GetOrRegisterCounter("extchain/reporttest/disagreement", nil).Count()
+	_, err = v.Query("eth_getBlockByHash", queryBlockHash)
+	assert.Nil(t, err)
+	after := metrics.GetOrRegisterCounter("extchain/reporttest/disagreement", nil).Count()
+	assert.Equal(t, before+1, after)
+}
@@ -0,0 +1,11 @@
+package metadata
+
+import (
+	"github.com/incognitochain/incognito-chain/metadata/extchain"
+)
+
+// NewExtChainVerifier builds the verifier used to read external chains when validating shielding requests,
+// replace it to plug in another verification strategy
+var NewExtChainVerifier = func(network string, hosts []string, minQuorum int) (extchain.Verifier, error) {
+	return extchain.NewQuorumVerifier(network, hosts, minQuorum)
+}
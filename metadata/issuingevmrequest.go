@@ -27,6 +27,7 @@ type IssuingEVMRequest struct {
 	TxIndex    uint
 	ProofStrs  []string
 	IncTokenID common.Hash
+	NetworkID  uint8 `json:"NetworkID,omitempty"` // only used by IssuingEVMNetworkRequestMeta
 	MetadataBase
 }
 
@@ -44,6 +45,7 @@ type IssuingEVMAcceptedInst struct {
 	TxReqID         common.Hash `json:"txReqId"`
 	UniqTx          []byte      `json:"uniqETHTx"` // don't update the jsontag to make it compatible with the old shielding eth tx
 	ExternalTokenID []byte      `json:"externalTokenId"`
+	NetworkID       uint8       `json:"networkId,omitempty"`
 }
 
 type GetEVMHeaderByHashRes struct {
@@ -137,6 +139,13 @@ func NewIssuingEVMRequestFromMap(
 		*incTokenID,
 		metatype,
 	)
+	if metatype == IssuingEVMNetworkRequestMeta {
+		networkID, ok := data["NetworkID"].(float64)
+		if !ok {
+			return nil, NewMetadataTxError(IssuingEvmRequestNewIssuingEVMRequestFromMapError, errors.Errorf("NetworkID incorrect"))
+		}
+		req.NetworkID = uint8(networkID)
+	}
 	return req, nil
 }
 
@@ -153,13 +162,26 @@ func (iReq IssuingEVMRequest) ValidateSanityData(chainRetriever ChainRetriever,
 		return false, false, NewMetadataTxError(IssuingEvmRequestValidateSanityDataError, errors.New("Invalid token id"))
 	}
 
+	if iReq.Type == IssuingEVMNetworkRequestMeta {
+		evmNetwork, found := config.Param().GetEVMNetwork(iReq.NetworkID)
+		if !found {
+			return false, false, NewMetadataTxError(IssuingEvmRequestValidateSanityDataError, errors.Errorf("EVM network %v is not registered", iReq.NetworkID))
+		}
+		if !evmNetwork.IsActive(beaconHeight) {
+			return false, false, NewMetadataTxError(IssuingEvmRequestValidateSanityDataError, errors.Errorf("EVM network %v is not activated at beacon height %v", iReq.NetworkID, beaconHeight))
+		}
+		if iReq.IncTokenID.String() == common.PRVIDStr {
+			return false, false, NewMetadataTxError(IssuingEvmRequestValidateSanityDataError, errors.New("Invalid token id"))
+		}
+	}
+
 	return true, true, nil
 }
 
 func (iReq IssuingEVMRequest) ValidateMetadataByItself() bool {
 	if iReq.Type != IssuingETHRequestMeta && iReq.Type != IssuingBSCRequestMeta &&
 		iReq.Type != IssuingPRVERC20RequestMeta && iReq.Type != IssuingPRVBEP20RequestMeta &&
-		iReq.Type != IssuingPLGRequestMeta {
+		iReq.Type != IssuingPLGRequestMeta && iReq.Type != IssuingEVMNetworkRequestMeta {
 		return false
 	}
 	evmReceipt, err := iReq.verifyProofAndParseReceipt()
@@ -183,6 +205,9 @@ func (iReq IssuingEVMRequest) Hash() *common.Hash {
 	}
 	record += iReq.MetadataBase.Hash().String()
 	record += iReq.IncTokenID.String()
+	if iReq.Type == IssuingEVMNetworkRequestMeta {
+		record += strconv.Itoa(int(iReq.NetworkID))
+	}
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -218,40 +243,72 @@ func (iReq *IssuingEVMRequest) CalculateSize() uint64 {
 }
 
 func (iReq *IssuingEVMRequest) verifyProofAndParseReceipt() (*types.Receipt, error) {
-	var protocol, host, port string
-	if iReq.Type == IssuingBSCRequestMeta || iReq.Type == IssuingPRVBEP20RequestMeta {
+	var protocol, port, network string
+	var hosts []string
+	var minQuorum int
+	minEVMConfirmationBlocks := EVMConfirmationBlocks
+	if iReq.Type == IssuingEVMNetworkRequestMeta {
+		evmNetwork, found := config.Param().GetEVMNetwork(iReq.NetworkID)
+		if !found || len(evmNetwork.Hosts) == 0 {
+			return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, errors.Errorf("EVM network %v is not registered or has no host", iReq.NetworkID))
+		}
+		network = fmt.Sprintf("evm%v", iReq.NetworkID)
+		hosts = evmNetwork.Hosts
+		minQuorum = evmNetwork.MinQuorum
+		minEVMConfirmationBlocks = int(evmNetwork.ConfirmationBlocks)
+	} else if iReq.Type == IssuingBSCRequestMeta || iReq.Type == IssuingPRVBEP20RequestMeta {
 		evmParam := config.Param().BSCParam
 		evmParam.GetFromEnv()
-		host = evmParam.Host
+		network = "bsc"
+		hosts = evmParam.GetHosts()
+		minQuorum = evmParam.MinQuorum
 	} else if iReq.Type == IssuingETHRequestMeta || iReq.Type == IssuingPRVERC20RequestMeta {
 		evmParam := config.Config().GethParam
 		evmParam.GetFromEnv()
 		protocol = evmParam.Protocol
-		host = evmParam.Host
 		port = evmParam.Port
+		network = "eth"
+		hosts = evmParam.GetHosts()
+		minQuorum = evmParam.MinQuorum
 	} else if iReq.Type == IssuingPLGRequestMeta {
 		evmParam := config.Param().PLGParam
 		evmParam.GetFromEnv()
-		host = evmParam.Host
+		network = "plg"
+		hosts = evmParam.GetHosts()
+		minQuorum = evmParam.MinQuorum
 	} else {
 		return nil, errors.New("[verifyProofAndParseReceipt] invalid metatype")
 	}
-	evmHeader, err := GetEVMHeader(iReq.BlockHash, protocol, host, port)
+	verifier, err := NewExtChainVerifier(network, hosts, minQuorum)
+	if err != nil {
+		return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, err)
+	}
+
+	// the header commits to the receipt root, so agreeing on its hash is enough to trust the receipt proof
+	res, err := verifier.Query("eth_getBlockByHash", func(host string) (interface{}, string, error) {
+		header, err := GetEVMHeader(iReq.BlockHash, protocol, host, port)
+		if err != nil || header == nil {
+			return nil, "", err
+		}
+		return header, header.Hash().String(), nil
+	})
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, err)
 	}
-	if evmHeader == nil {
+	evmHeader, ok := res.(*types.Header)
+	if !ok || evmHeader == nil {
 		Logger.log.Warn("WARNING: Could not find out the EVM block header with the hash: ", iReq.BlockHash)
 		return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, errors.Errorf("WARNING: Could not find out the EVM block header with the hash: %s", iReq.BlockHash.String()))
 	}
 
-	mostRecentBlkNum, err := GetMostRecentEVMBlockHeight(protocol, host, port)
+	mostRecentBlkNum, err := verifier.QueryHeight("eth_blockNumber", func(host string) (*big.Int, error) {
+		return GetMostRecentEVMBlockHeight(protocol, host, port)
+	})
 	if err != nil {
 		Logger.log.Warn("WARNING: Could not find the most recent block height on Ethereum")
 		return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, err)
 	}
 
-	minEVMConfirmationBlocks := EVMConfirmationBlocks
 	if iReq.Type == IssuingPLGRequestMeta {
 		minEVMConfirmationBlocks = PLGConfirmationBlocks
 	}
@@ -283,7 +340,8 @@ func (iReq *IssuingEVMRequest) verifyProofAndParseReceipt() (*types.Receipt, err
 		return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, err)
 	}
 
-	if iReq.Type == IssuingETHRequestMeta || iReq.Type == IssuingPRVERC20RequestMeta || iReq.Type == IssuingPLGRequestMeta {
+	if iReq.Type == IssuingETHRequestMeta || iReq.Type == IssuingPRVERC20RequestMeta || iReq.Type == IssuingPLGRequestMeta ||
+		iReq.Type == IssuingEVMNetworkRequestMeta {
 		if len(val) == 0 {
 			return nil, NewMetadataTxError(IssuingEvmRequestVerifyProofAndParseReceipt, errors.New("the encoded receipt is empty"))
 		}
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	bin "github.com/gagliardetto/binary"
@@ -30,6 +31,7 @@ type IssuingSOLReqAction struct {
 	Amount              uint64            `json:"Amount"`
 	ReceivingIncAddrStr string            `json:"ReceivingIncAddr"`
 	ExternalTokenID     []byte            `json:"ExternalTokenIDStr"`
+	RejectReason        string            `json:"RejectReason,omitempty"`
 }
 
 type IssuingSOLAcceptedInst struct {
@@ -166,6 +168,7 @@ func (iReq *IssuingSOLRequest) BuildReqActions(tx Transaction, chainRetriever Ch
 		Amount:              shieldInfo.Amount,
 		ReceivingIncAddrStr: shieldInfo.ReceivingIncAddrStr,
 		ExternalTokenID:     shieldInfo.ExternalTokenID,
+		RejectReason:        shieldInfo.RejectReason,
 	}
 	Logger.log.Infof("BuildReqActions with Meta: %+v", actionContent.Meta)
 	actionContentBytes, err := json.Marshal(actionContent)
@@ -185,26 +188,66 @@ func (iReq *IssuingSOLRequest) CalculateSize() uint64 {
 func (iReq *IssuingSOLRequest) verifyAndParseSolTxSig() (*ShieldInfo, error) {
 	solParam := config.Param().SOLParam
 	solParam.GetFromEnv()
-	host := solParam.Host
-	solClient := rpc.New(host)
+	verifier, err := NewExtChainVerifier("sol", solParam.GetHosts(), solParam.MinQuorum)
+	if err != nil {
+		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, err)
+	}
 
 	// get sol transaction by txSig
 	txSig, err := solana.SignatureFromBase58(iReq.TxSigStr)
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Invalid tx signature with error %v", err))
 	}
-	res, err := solClient.GetTransaction(
-		context.TODO(),
-		txSig,
-		&rpc.GetTransactionOpts{
-			Encoding: solana.EncodingBase64,
-		},
-	)
+	commitment := rpc.CommitmentType(solParam.GetCommitment())
+	res, err := verifier.Query("getTransaction", func(host string) (interface{}, string, error) {
+		res, err := rpc.New(host).GetTransaction(
+			context.TODO(),
+			txSig,
+			&rpc.GetTransactionOpts{
+				Encoding:   solana.EncodingBase64,
+				Commitment: commitment,
+			},
+		)
+		if err != nil || res == nil || res.Transaction == nil {
+			return nil, "", err
+		}
+		solTx := &solTxResult{
+			slot:    res.Slot,
+			txBytes: res.Transaction.GetBinary(),
+		}
+		if res.Meta != nil && res.Meta.Err != nil {
+			solTx.failure = fmt.Sprintf("%v", res.Meta.Err)
+		}
+		return solTx, fmt.Sprintf("%v-%s-%s", solTx.slot, solTx.failure, base64.StdEncoding.EncodeToString(solTx.txBytes)), nil
+	})
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Can not get sol tx signature with error %v", err))
 	}
+	solTx := res.(*solTxResult)
 
-	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(res.Transaction.GetBinary()))
+	// check the tx is deep enough behind the tip at the required commitment
+	currentSlot, err := verifier.QueryHeight("getSlot", func(host string) (*big.Int, error) {
+		slot, err := rpc.New(host).GetSlot(context.TODO(), commitment)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(slot), nil
+	})
+	if err != nil {
+		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Can not get the current slot with error %v", err))
+	}
+	if currentSlot.Uint64() < solTx.slot+solParam.MinSlotDepth {
+		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("It needs %v slots behind the tip, the tx slot is %v but the current slot is %v", solParam.MinSlotDepth, solTx.slot, currentSlot))
+	}
+
+	// a failed tx never moves funds, it is kept on chain only to record the rejection
+	if solTx.failure != "" {
+		return &ShieldInfo{
+			RejectReason: fmt.Sprintf("sol tx failed on chain: %s", solTx.failure),
+		}, nil
+	}
+
+	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(solTx.txBytes))
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Can not decode sol tx signature with error %v", err))
 	}
@@ -267,19 +310,24 @@ func (iReq *IssuingSOLRequest) verifyAndParseSolTxSig() (*ShieldInfo, error) {
 
 	// get solana's token id from writable account (shield maker account)
 	writableAccPk := accs[0].PublicKey
-	writableAccInfo, err := solClient.GetAccountInfoWithOpts(context.TODO(), writableAccPk, &rpc.GetAccountInfoOpts{Encoding: "jsonParsed"})
-	if err != nil {
-		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Can not get writable account info with error %v", err))
-	}
-
-	infoJson := writableAccInfo.Value.Data.GetRawJSON()
-	accData := SolAccountData{}
-	err = json.Unmarshal(infoJson[:], &accData)
+	mint, err := verifier.Query("getAccountInfo", func(host string) (interface{}, string, error) {
+		writableAccInfo, err := rpc.New(host).GetAccountInfoWithOpts(context.TODO(), writableAccPk, &rpc.GetAccountInfoOpts{Encoding: "jsonParsed"})
+		if err != nil {
+			return nil, "", fmt.Errorf("Can not get writable account info with error %v", err)
+		}
+		infoJson := writableAccInfo.Value.Data.GetRawJSON()
+		accData := SolAccountData{}
+		err = json.Unmarshal(infoJson[:], &accData)
+		if err != nil {
+			return nil, "", fmt.Errorf("Can not unmarshal writable account data with error %v", err)
+		}
+		return accData.Parsed.Info.Mint, accData.Parsed.Info.Mint, nil
+	})
 	if err != nil {
-		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Can not unmarshal writable account data with error %v", err))
+		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, err)
 	}
 
-	externalTokenIDStr := accData.Parsed.Info.Mint
+	externalTokenIDStr := mint.(string)
 	externalTokenIDBytes, err := solana.PublicKeyFromBase58(externalTokenIDStr)
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingSolReqVerifyAndParseTxError, fmt.Errorf("Can not unmarshal writable account data with error %v", err))
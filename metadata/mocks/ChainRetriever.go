@@ -229,15 +229,17 @@ func (_m *ChainRetriever) GetPortalETHContractAddrStr(beaconHeight uint64) strin
 	return r0
 }
 
-// GetPortalFeederAddress provides a mock function with given fields: beaconHeight
-func (_m *ChainRetriever) GetPortalFeederAddress(beaconHeight uint64) string {
+// GetPortalFeederAddresses provides a mock function with given fields: beaconHeight
+func (_m *ChainRetriever) GetPortalFeederAddresses(beaconHeight uint64) []string {
 	ret := _m.Called(beaconHeight)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(uint64) string); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(uint64) []string); ok {
 		r0 = rf(beaconHeight)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	return r0
@@ -375,6 +377,22 @@ func (_m *ChainRetriever) GetTransactionByHash(_a0 common.Hash) (byte, common.Ha
 	return r0, r1, r2, r3, r4, r5
 }
 
+// GetUTXOHeaderChain provides a mock function with given fields: chainID
+func (_m *ChainRetriever) GetUTXOHeaderChain(chainID string) *btcrelaying.BlockChain {
+	ret := _m.Called(chainID)
+
+	var r0 *btcrelaying.BlockChain
+	if rf, ok := ret.Get(0).(func(string) *btcrelaying.BlockChain); ok {
+		r0 = rf(chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*btcrelaying.BlockChain)
+		}
+	}
+
+	return r0
+}
+
 // IsAfterNewZKPCheckPoint provides a mock function with given fields: beaconHeight
 func (_m *ChainRetriever) IsAfterNewZKPCheckPoint(beaconHeight uint64) bool {
 	ret := _m.Called(beaconHeight)
@@ -83,6 +83,12 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &IssuingSOLResponse{}
 	case BurningSOLRequestMeta, BurningSOLForDepositToSCRequestMeta:
 		md = &BurningSOLRequest{}
+	case IssuingEVMNetworkRequestMeta:
+		md = &IssuingEVMRequest{}
+	case IssuingEVMNetworkResponseMeta:
+		md = &IssuingEVMResponse{}
+	case BurningEVMNetworkRequestMeta:
+		md = &BurningRequest{}
 	case ShardStakingMeta:
 		md = &StakingMetadata{}
 	case BeaconStakingMeta:
@@ -97,6 +103,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &UnStakingMetadata{}
 	case StopAutoStakingMeta:
 		md = &StopAutoStakingMetadata{}
+	case EquivocationEvidenceMeta:
+		md = &EquivocationEvidence{}
 	case PDEContributionMeta:
 		md = &PDEContribution{}
 	case PDEPRVRequiredContributionRequestMeta:
@@ -143,6 +151,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &RelayingHeader{}
 	case RelayingBTCHeaderMeta:
 		md = &RelayingHeader{}
+	case RelayingLTCHeaderMeta, RelayingDOGEHeaderMeta, RelayingBCHHeaderMeta:
+		md = &RelayingHeader{}
 	case PortalCustodianWithdrawRequestMeta:
 		md = &PortalCustodianWithdrawRequest{}
 	case PortalCustodianWithdrawResponseMeta:
@@ -207,6 +217,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PortalSubmitConfirmedTxRequest{}
 	case metadataCommon.PortalV4ConvertVaultRequestMeta:
 		md = &PortalConvertVaultRequest{}
+	case metadataCommon.PortalV4VaultRotationRequestMeta:
+		md = &PortalVaultRotationRequest{}
 	case metadataCommon.Pdexv3ModifyParamsMeta:
 		md = &metadataPdexv3.ParamsModifyingRequest{}
 	case metadataCommon.Pdexv3AddLiquidityRequestMeta:
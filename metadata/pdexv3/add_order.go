@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
 	"github.com/incognitochain/incognito-chain/privacy"
@@ -18,6 +19,7 @@ type AddOrderRequest struct {
 	MinAcceptableAmount uint64                              `json:"MinAcceptableAmount"`
 	Receiver            map[common.Hash]privacy.OTAReceiver `json:"Receiver"`
 	NftID               common.Hash                         `json:"NftID"`
+	ExpiryHeight        uint64                              `json:"ExpiryHeight,omitempty"` // optional, beacon height from which the order is automatically withdrawn
 	metadataCommon.MetadataBase
 }
 
@@ -108,6 +110,14 @@ func (req AddOrderRequest) ValidateSanityData(chainRetriever metadataCommon.Chai
 		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.PDEInvalidMetadataValueError,
 			fmt.Errorf("SellAmount cannot be 0"))
 	}
+	if req.ExpiryHeight != 0 && beaconHeight < config.Param().PDexParams.OrderExpiryHeight {
+		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.PDEInvalidMetadataValueError,
+			fmt.Errorf("ExpiryHeight is only enabled from beacon height %d", config.Param().PDexParams.OrderExpiryHeight))
+	}
+	if req.ExpiryHeight != 0 && req.ExpiryHeight <= beaconHeight {
+		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.PDEInvalidMetadataValueError,
+			fmt.Errorf("ExpiryHeight %d must be greater than current beacon height %d", req.ExpiryHeight, beaconHeight))
+	}
 
 	// Type vs burned token id + amount check
 	switch tx.GetType() {
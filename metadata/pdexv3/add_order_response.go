@@ -14,8 +14,9 @@ import (
 // AddOrderStatus containns the info tracked by feature statedb, which is then displayed in RPC status queries.
 // For refunded `add order` requests, all fields except Status are ignored
 type AddOrderStatus struct {
-	Status  int    `json:"Status"`
-	OrderID string `json:"OrderID"`
+	Status       int    `json:"Status"`
+	OrderID      string `json:"OrderID"`
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
 }
 
 // AddOrderResponse is the metadata inside response tx for `add order` (applicable for refunded case only)
@@ -36,6 +37,7 @@ type AcceptedAddOrder struct {
 	Token1Balance  uint64      `json:"Token1Balance"`
 	TradeDirection byte        `json:"TradeDirection"`
 	Receiver       [2]string   `json:"Receiver"`
+	ExpiryHeight   uint64      `json:"ExpiryHeight,omitempty"`
 }
 
 func (md AcceptedAddOrder) GetType() int {
@@ -72,13 +72,20 @@ func (portalExchangeRates PortalExchangeRates) ValidateTxWithBlockChain(
 }
 
 func (portalExchangeRates PortalExchangeRates) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
-	feederAddress := chainRetriever.GetPortalFeederAddress(beaconHeight)
-	isEqual, err := wallet.ComparePaymentAddresses(portalExchangeRates.SenderAddress, feederAddress)
-	if err != nil {
-		return false, false, fmt.Errorf("cannot compare payment address %v and %v: %v", portalExchangeRates.SenderAddress, feederAddress, err)
+	feederAddresses := chainRetriever.GetPortalFeederAddresses(beaconHeight)
+	isFeeder := false
+	for _, feederAddress := range feederAddresses {
+		isEqual, err := wallet.ComparePaymentAddresses(portalExchangeRates.SenderAddress, feederAddress)
+		if err != nil {
+			return false, false, fmt.Errorf("cannot compare payment address %v and %v: %v", portalExchangeRates.SenderAddress, feederAddress, err)
+		}
+		if isEqual {
+			isFeeder = true
+			break
+		}
 	}
-	if !isEqual {
-		return false, false, fmt.Errorf("sender address and feeder address mismatch (%v != %v)\n", portalExchangeRates.SenderAddress, feederAddress)
+	if !isFeeder {
+		return false, false, fmt.Errorf("sender address %v is not a feeder address %v\n", portalExchangeRates.SenderAddress, feederAddresses)
 	}
 
 	keyWallet, err := wallet.Base58CheckDeserialize(portalExchangeRates.SenderAddress)
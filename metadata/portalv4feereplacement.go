@@ -33,6 +33,10 @@ type PortalReplacementFeeRequestContent struct {
 	UTXOs         []*statedb.UTXO
 	TxReqID       common.Hash
 	ShardID       byte
+
+	// same as the key set of the replaced batch
+	MasterPubKeys   [][]byte `json:",omitempty"`
+	NumRequiredSigs uint     `json:",omitempty"`
 }
 
 type PortalReplacementFeeRequestStatus struct {
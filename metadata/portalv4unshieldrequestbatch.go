@@ -10,6 +10,10 @@ type PortalUnshieldRequestBatchContent struct {
 	UTXOs         []*statedb.UTXO
 	NetworkFee    uint
 	BeaconHeight  uint64
+
+	// the key set holding the UTXOs, only set once the vault of the token has been rotated
+	MasterPubKeys   [][]byte `json:",omitempty"`
+	NumRequiredSigs uint     `json:",omitempty"`
 }
 
 type ExternalFeeInfo struct {
@@ -26,4 +30,7 @@ type PortalUnshieldRequestBatchStatus struct {
 	NetworkFees   map[uint64]ExternalFeeInfo
 	BeaconHeight  uint64
 	Status        byte
+
+	MasterPubKeys   [][]byte `json:",omitempty"`
+	NumRequiredSigs uint     `json:",omitempty"`
 }
@@ -0,0 +1,282 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// the multisig redeem script of the vault can not have more than 15 public keys
+const maxVaultMasterPubKeys = 15
+
+// shields to the old addresses are accepted for about a week at most after the activation
+const maxVaultRotationGracePeriod = 60000
+
+// PortalVaultRotationRequest schedules moving the vault of a portal token to a new master key set,
+// it is authorized by the signatures of RotationHash from the required number of the current master keys
+type PortalVaultRotationRequest struct {
+	MetadataBaseWithSignature
+	TokenID          string
+	MasterPubKeys    [][]byte
+	NumRequiredSigs  uint
+	ActivationHeight uint64   // beacon height from which the new key set holds the vault
+	GracePeriod      uint64   // number of beacon blocks shields to the old addresses are still accepted after the activation
+	VaultSigs        [][]byte // DER signatures of RotationHash by the current master keys of the vault
+}
+
+type PortalVaultRotationRequestAction struct {
+	Meta    PortalVaultRotationRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type PortalVaultRotationRequestContent struct {
+	TokenID                   string
+	OldMasterPubKeys          [][]byte
+	OldNumRequiredSigs        uint
+	OldGeneralMultiSigAddress string
+	MasterPubKeys             [][]byte
+	NumRequiredSigs           uint
+	GeneralMultiSigAddress    string
+	ActivationHeight          uint64
+	GraceEndHeight            uint64
+	TxReqID                   common.Hash
+	ShardID                   byte
+}
+
+type PortalVaultRotationRequestStatus struct {
+	Status           byte
+	ErrorMsg         string
+	TokenID          string
+	MasterPubKeys    [][]byte
+	NumRequiredSigs  uint
+	ActivationHeight uint64
+	GraceEndHeight   uint64
+	TxReqID          common.Hash
+}
+
+// PortalVaultRotationStatus tracks the latest vault rotation of a portal token
+type PortalVaultRotationStatus struct {
+	TokenID                   string
+	TxReqID                   common.Hash
+	OldMasterPubKeys          [][]byte
+	OldNumRequiredSigs        uint
+	OldGeneralMultiSigAddress string
+	MasterPubKeys             [][]byte
+	NumRequiredSigs           uint
+	GeneralMultiSigAddress    string
+	ActivationHeight          uint64
+	GraceEndHeight            uint64
+	PendingBatchIDs           []string // consolidation batches waiting for the confirmation of their external txs
+	CompletedBatchIDs         []string
+	MigratedAmount            uint64 // in external unit
+}
+
+func NewPortalVaultRotationRequest(
+	metaType int, tokenID string, masterPubKeys [][]byte, numRequiredSigs uint,
+	activationHeight uint64, gracePeriod uint64, vaultSigs [][]byte,
+) (*PortalVaultRotationRequest, error) {
+	vaultRotationReq := &PortalVaultRotationRequest{
+		TokenID:          tokenID,
+		MasterPubKeys:    masterPubKeys,
+		NumRequiredSigs:  numRequiredSigs,
+		ActivationHeight: activationHeight,
+		GracePeriod:      gracePeriod,
+		VaultSigs:        vaultSigs,
+	}
+	vaultRotationReq.MetadataBase = MetadataBase{
+		Type: metaType,
+	}
+
+	return vaultRotationReq, nil
+}
+
+func (rotationReq PortalVaultRotationRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	chainRetriever ChainRetriever,
+	shardViewRetriever ShardViewRetriever,
+	beaconViewRetriever BeaconViewRetriever,
+	shardID byte,
+	db *statedb.StateDB,
+) (bool, error) {
+	return true, nil
+}
+
+func (rotationReq PortalVaultRotationRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// the vault rotation is requested by the same key as the replacement of unshield fees,
+	// the signatures of the current vault key set are verified by the beacon against its portal state
+	keyWallet, err := wallet.Base58CheckDeserialize(chainRetriever.GetPortalReplacementAddress(beaconHeight))
+	if err != nil {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError, errors.New("Requester incognito address is invalid"))
+	}
+	incAddr := keyWallet.KeySet.PaymentAddress
+	if len(incAddr.Pk) == 0 {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError, errors.New("Requester incognito address is invalid"))
+	}
+
+	if ok, err := rotationReq.MetadataBaseWithSignature.VerifyMetadataSignature(incAddr.Pk, tx); err != nil || !ok {
+		return false, false, errors.New("Sender is unauthorized")
+	}
+
+	// check tx type and version
+	if tx.GetType() != common.TxNormalType {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError, errors.New("Tx vault rotation request must be TxNormalType"))
+	}
+
+	if tx.GetVersion() != 2 {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+			errors.New("Tx vault rotation request must be version 2"))
+	}
+
+	// validate tokenID
+	isPortalToken, err := chainRetriever.IsPortalToken(beaconHeight, rotationReq.TokenID, common.PortalVersion4)
+	if !isPortalToken || err != nil {
+		return false, false, errors.New("TokenID is not supported currently on Portal v4")
+	}
+
+	// validate the new key set
+	if len(rotationReq.MasterPubKeys) == 0 || len(rotationReq.MasterPubKeys) > maxVaultMasterPubKeys {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+			errors.New("Number of master public keys is invalid"))
+	}
+	if rotationReq.NumRequiredSigs == 0 || rotationReq.NumRequiredSigs > uint(len(rotationReq.MasterPubKeys)) {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+			errors.New("Number of required signatures is invalid"))
+	}
+	for _, pubKey := range rotationReq.MasterPubKeys {
+		if len(pubKey) != btcec.PubKeyBytesLenCompressed {
+			return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+				errors.New("Master public keys must be compressed"))
+		}
+		if _, err := btcec.ParsePubKey(pubKey, btcec.S256()); err != nil {
+			return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError, err)
+		}
+	}
+
+	if rotationReq.ActivationHeight <= beaconHeight {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+			errors.New("Activation height must be greater than the current beacon height"))
+	}
+	if rotationReq.GracePeriod > maxVaultRotationGracePeriod || rotationReq.ActivationHeight+rotationReq.GracePeriod < rotationReq.ActivationHeight {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+			errors.New("Grace period is invalid"))
+	}
+
+	if len(rotationReq.VaultSigs) == 0 || len(rotationReq.VaultSigs) > maxVaultMasterPubKeys {
+		return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError,
+			errors.New("Number of vault signatures is invalid"))
+	}
+	for _, sig := range rotationReq.VaultSigs {
+		if _, err := btcec.ParseDERSignature(sig, btcec.S256()); err != nil {
+			return false, false, NewMetadataTxError(metadataCommon.PortalV4VaultRotationRequestMetaError, err)
+		}
+	}
+
+	return true, true, nil
+}
+
+func (rotationReq PortalVaultRotationRequest) ValidateMetadataByItself() bool {
+	return rotationReq.Type == metadataCommon.PortalV4VaultRotationRequestMeta
+}
+
+// RotationHash is the message signed by the current master keys of the vault to authorize the rotation
+func (rotationReq PortalVaultRotationRequest) RotationHash() common.Hash {
+	return common.HashH([]byte(strconv.Itoa(metadataCommon.PortalV4VaultRotationRequestMeta) + rotationReq.hashRecord()))
+}
+
+// VerifyVaultSigs returns an error unless VaultSigs holds valid signatures of RotationHash
+// from at least numRequiredSigs distinct keys of masterPubKeys
+func (rotationReq PortalVaultRotationRequest) VerifyVaultSigs(masterPubKeys [][]byte, numRequiredSigs uint) error {
+	if numRequiredSigs == 0 {
+		return errors.New("the vault key set requires no signature")
+	}
+	hash := rotationReq.RotationHash()
+	signed := map[int]bool{}
+	for _, sigBytes := range rotationReq.VaultSigs {
+		sig, err := btcec.ParseDERSignature(sigBytes, btcec.S256())
+		if err != nil {
+			return err
+		}
+		for i, pubKeyBytes := range masterPubKeys {
+			if signed[i] {
+				continue
+			}
+			pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+			if err != nil {
+				continue
+			}
+			if sig.Verify(hash[:], pubKey) {
+				signed[i] = true
+				break
+			}
+		}
+	}
+	if uint(len(signed)) < numRequiredSigs {
+		return fmt.Errorf("got %v valid vault signatures, %v required", len(signed), numRequiredSigs)
+	}
+	return nil
+}
+
+func (rotationReq PortalVaultRotationRequest) hashRecord() string {
+	record := rotationReq.TokenID
+	for _, pubKey := range rotationReq.MasterPubKeys {
+		record += string(pubKey)
+	}
+	record += strconv.FormatUint(uint64(rotationReq.NumRequiredSigs), 10)
+	record += strconv.FormatUint(rotationReq.ActivationHeight, 10)
+	record += strconv.FormatUint(rotationReq.GracePeriod, 10)
+	return record
+}
+
+func (rotationReq PortalVaultRotationRequest) Hash() *common.Hash {
+	record := rotationReq.MetadataBase.Hash().String()
+	record += rotationReq.hashRecord()
+	for _, sig := range rotationReq.VaultSigs {
+		record += string(sig)
+	}
+
+	if rotationReq.Sig != nil && len(rotationReq.Sig) != 0 {
+		record += string(rotationReq.Sig)
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (rotationReq PortalVaultRotationRequest) HashWithoutSig() *common.Hash {
+	record := rotationReq.MetadataBaseWithSignature.Hash().String()
+	record += rotationReq.hashRecord()
+	for _, sig := range rotationReq.VaultSigs {
+		record += string(sig)
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (rotationReq *PortalVaultRotationRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+	actionContent := PortalVaultRotationRequestAction{
+		Meta:    *rotationReq,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(metadataCommon.PortalV4VaultRotationRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (rotationReq *PortalVaultRotationRequest) CalculateSize() uint64 {
+	return calculateSize(rotationReq)
+}
@@ -103,7 +103,8 @@ func (rh RelayingHeader) ValidateSanityData(chainRetriever ChainRetriever, shard
 }
 
 func (rh RelayingHeader) ValidateMetadataByItself() bool {
-	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta
+	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta ||
+		rh.Type == RelayingLTCHeaderMeta || rh.Type == RelayingDOGEHeaderMeta || rh.Type == RelayingBCHHeaderMeta
 }
 
 func (rh RelayingHeader) Hash() *common.Hash {
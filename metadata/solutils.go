@@ -25,6 +25,13 @@ type ShieldInfo struct {
 	Amount              uint64 `json:"amount"`
 	ReceivingIncAddrStr string `json:"receiverAddrStr"`
 	ExternalTokenID     []byte `json:"externalTokenIDStr"`
+	RejectReason        string `json:"rejectReason,omitempty"` // set when the shielding tx failed on chain
+}
+
+type solTxResult struct {
+	slot    uint64
+	txBytes []byte
+	failure string
 }
 
 const SolPubKeyLen = 32
@@ -0,0 +1,116 @@
+package prometheus
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
+)
+
+// tags with unbounded values are not turned into labels, their measurements are aggregated instead
+var droppedTags = map[string]bool{
+	grafana.BlockHeightTag:     true,
+	grafana.TxHashTag:          true,
+	grafana.NodeIDTag:          true,
+	grafana.ExternalAddressTag: true,
+}
+
+type seriesKey struct {
+	measurement string
+	label       label
+}
+
+type series struct {
+	count uint64
+	sum   float64
+	last  float64
+}
+
+// MetricTool implements grafana.MetricTool by keeping the measurements in memory until they are scraped.
+// Each measurement is exported as a summary of the received values and a gauge of the last one.
+type MetricTool struct {
+	lock            sync.Mutex
+	externalAddress string
+	series          map[seriesKey]*series
+}
+
+func NewMetricTool(externalAddress string) *MetricTool {
+	return &MetricTool{
+		externalAddress: externalAddress,
+		series:          map[seriesKey]*series{},
+	}
+}
+
+func (tool *MetricTool) GetExternalAddress() string {
+	return tool.externalAddress
+}
+
+func (tool *MetricTool) SendTimeSeriesMetricData(params map[string]interface{}) {
+	measurement, ok := params[grafana.Measurement].(string)
+	if !ok {
+		return
+	}
+	value, ok := params[grafana.MeasurementValue].(float64)
+	if !ok {
+		return
+	}
+	key := seriesKey{measurement: measurement}
+	tag, _ := params[grafana.Tag].(string)
+	tagValue, _ := params[grafana.TagValue].(string)
+	switch {
+	case tag == "" || droppedTags[tag]:
+	case tag == grafana.ShardIDTag:
+		key.label = label{ChainLabel, chainLabel(tagValue)}
+	default:
+		key.label = label{strings.TrimPrefix(MetricName(tag), Namespace+"_"), tagValue}
+	}
+
+	tool.lock.Lock()
+	defer tool.lock.Unlock()
+	s, ok := tool.series[key]
+	if !ok {
+		s = &series{}
+		tool.series[key] = s
+	}
+	s.count++
+	s.sum += value
+	s.last = value
+}
+
+// SendTimeSeriesMetricDataWithTime records the measurement at scrape time, Prometheus does not accept past samples
+func (tool *MetricTool) SendTimeSeriesMetricDataWithTime(params map[string]interface{}) {
+	tool.SendTimeSeriesMetricData(params)
+}
+
+func (tool *MetricTool) collect(c *collection) {
+	tool.lock.Lock()
+	defer tool.lock.Unlock()
+	keys := make([]seriesKey, 0, len(tool.series))
+	for key := range tool.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].measurement != keys[j].measurement {
+			return keys[i].measurement < keys[j].measurement
+		}
+		return keys[i].label.value < keys[j].label.value
+	})
+	for _, key := range keys {
+		s := tool.series[key]
+		name := MetricName(key.measurement)
+		labels := []label{}
+		if key.label.name != "" {
+			labels = append(labels, key.label)
+		}
+		c.add(name, "summary", "_sum", s.sum, labels...)
+		c.add(name, "summary", "_count", float64(s.count), labels...)
+		c.add(name+"_last", "gauge", "", s.last, labels...)
+	}
+}
+
+// chainLabel accepts the shard tag values "beacon", "<shardID>", "shardid-<shardID>" and chain keys "shard-<shardID>"
+func chainLabel(tagValue string) string {
+	tagValue = strings.TrimPrefix(tagValue, grafana.ShardIDTag+"-")
+	return strings.TrimPrefix(tagValue, grafana.Shard+"-")
+}
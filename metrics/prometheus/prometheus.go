@@ -0,0 +1,179 @@
+// Package prometheus exposes the metrics registry and the grafana measurements
+// in the Prometheus text exposition format, so they can be scraped instead of pushed.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+const (
+	Namespace   = "incognito"
+	ChainLabel  = "chain"
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+var quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	suffix string
+	labels []label
+	value  float64
+}
+
+type family struct {
+	name    string
+	typ     string
+	samples []sample
+}
+
+// collection groups samples by metric family, Prometheus requires one TYPE line per family
+type collection struct {
+	families map[string]*family
+}
+
+func newCollection() *collection {
+	return &collection{families: map[string]*family{}}
+}
+
+func (c *collection) add(name, typ, suffix string, value float64, labels ...label) {
+	f, ok := c.families[name]
+	if !ok {
+		f = &family{name: name, typ: typ}
+		c.families[name] = f
+	}
+	f.samples = append(f.samples, sample{suffix: suffix, labels: labels, value: value})
+}
+
+func (c *collection) write(w *bufio.Writer) error {
+	names := make([]string, 0, len(c.families))
+	for name := range c.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := c.families[name]
+		fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+		for _, s := range f.samples {
+			w.WriteString(f.name)
+			w.WriteString(s.suffix)
+			if len(s.labels) > 0 {
+				w.WriteByte('{')
+				for i, l := range s.labels {
+					if i > 0 {
+						w.WriteByte(',')
+					}
+					fmt.Fprintf(w, "%s=%q", l.name, l.value)
+				}
+				w.WriteByte('}')
+			}
+			w.WriteByte(' ')
+			w.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+			w.WriteByte('\n')
+		}
+	}
+	return w.Flush()
+}
+
+// addRegistry converts every metric of the registry, durations of timers are exported in seconds
+func (c *collection) addRegistry(r metrics.Registry) {
+	r.Each(func(rawName string, i interface{}) {
+		name, chain := splitChain(rawName)
+		name = MetricName(name)
+		labels := []label{}
+		if chain != "" {
+			labels = append(labels, label{ChainLabel, chain})
+		}
+		switch metric := i.(type) {
+		case metrics.Counter:
+			c.add(name, "counter", "", float64(metric.Count()), labels...)
+		case metrics.Gauge:
+			c.add(name, "gauge", "", float64(metric.Value()), labels...)
+		case metrics.GaugeFloat64:
+			c.add(name, "gauge", "", metric.Value(), labels...)
+		case metrics.Meter:
+			m := metric.Snapshot()
+			c.add(name+"_total", "counter", "", float64(m.Count()), labels...)
+		case metrics.Histogram:
+			h := metric.Snapshot()
+			c.addSummary(name, h.Percentiles(quantiles), float64(h.Sum()), h.Count(), 1, labels)
+		case metrics.Timer:
+			t := metric.Snapshot()
+			c.addSummary(name+"_seconds", t.Percentiles(quantiles), float64(t.Sum()), t.Count(), float64(time.Second), labels)
+		}
+	})
+}
+
+func (c *collection) addSummary(name string, values []float64, sum float64, count int64, unit float64, labels []label) {
+	for i, q := range quantiles {
+		qLabels := append(append([]label{}, labels...), label{"quantile", strconv.FormatFloat(q, 'g', -1, 64)})
+		c.add(name, "summary", "", values[i]/unit, qLabels...)
+	}
+	c.add(name, "summary", "_sum", sum/unit, labels...)
+	c.add(name, "summary", "_count", float64(count), labels...)
+}
+
+// splitChain extracts the chain of metrics registered as "beacon/<name>" or "shard/<shardID>/<name>"
+func splitChain(name string) (string, string) {
+	parts := strings.SplitN(name, "/", 3)
+	switch {
+	case len(parts) >= 2 && parts[0] == "beacon":
+		return strings.Join(parts[1:], "/"), "beacon"
+	case len(parts) == 3 && parts[0] == "shard":
+		if _, err := strconv.Atoi(parts[1]); err == nil {
+			return parts[2], parts[1]
+		}
+	}
+	return name, ""
+}
+
+// MetricName converts a registry or measurement name to a valid Prometheus name in the incognito namespace,
+// e.g. "verify/preprocessing" to "incognito_verify_preprocessing" and "TxPoolEntered" to "incognito_tx_pool_entered"
+func MetricName(name string) string {
+	var sb strings.Builder
+	sb.WriteString(Namespace)
+	sb.WriteByte('_')
+	prevLower := false
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if prevLower {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(r - 'A' + 'a')
+			prevLower = false
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			sb.WriteRune(r)
+			prevLower = true
+		default:
+			sb.WriteByte('_')
+			prevLower = false
+		}
+	}
+	return sb.String()
+}
+
+// Handler serves the metrics of r and the measurements recorded by tool, which may be nil
+func Handler(r metrics.Registry, tool *MetricTool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c := newCollection()
+		c.addRegistry(r)
+		if tool != nil {
+			tool.collect(c)
+		}
+		w.Header().Set("Content-Type", ContentType)
+		c.write(bufio.NewWriter(w))
+	})
+}
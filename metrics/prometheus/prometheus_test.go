@@ -0,0 +1,97 @@
+package prometheus
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
+)
+
+func TestMetricName(t *testing.T) {
+	tests := map[string]string{
+		"verify/preprocessing":     "incognito_verify_preprocessing",
+		"TxPoolEntered":            "incognito_tx_pool_entered",
+		"extchain/eth/quorum-fail": "incognito_extchain_eth_quorum_fail",
+	}
+	for name, want := range tests {
+		if got := MetricName(name); got != want {
+			t.Errorf("MetricName(%v) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSplitChain(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  string
+		chain string
+	}{
+		{"beacon/verify/preprocessing", "verify/preprocessing", "beacon"},
+		{"shard/3/insert", "insert", "3"},
+		{"shard/verify/preprocessing", "shard/verify/preprocessing", ""},
+		{"extchain/eth/success", "extchain/eth/success", ""},
+	}
+	for _, tt := range tests {
+		name, chain := splitChain(tt.name)
+		if name != tt.want || chain != tt.chain {
+			t.Errorf("splitChain(%v) = %v, %v, want %v, %v", tt.name, name, chain, tt.want, tt.chain)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := metrics.NewRegistry()
+	metrics.NewRegisteredCounter("extchain/eth/success", r).Inc(2)
+	metrics.NewRegisteredGauge("shard/1/poolsize", r).Update(7)
+	metrics.NewRegisteredTimer("beacon/insert", r).Update(2 * time.Second)
+	metrics.NewRegisteredTimer("shard/0/insert", r).Update(time.Second)
+
+	tool := NewMetricTool("")
+	tool.SendTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.HandleAllMessage,
+		grafana.MeasurementValue: float64(1),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         "shardid-2",
+	})
+	tool.SendTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.HandleAllMessage,
+		grafana.MeasurementValue: float64(3),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         "shardid-2",
+	})
+	tool.SendTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.TxEnterNetSyncSuccess,
+		grafana.MeasurementValue: float64(1),
+		grafana.Tag:              grafana.TxHashTag,
+		grafana.TagValue:         "abc",
+	})
+
+	rec := httptest.NewRecorder()
+	Handler(r, tool).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+	out := string(body)
+
+	for _, line := range []string{
+		"# TYPE incognito_extchain_eth_success counter",
+		"incognito_extchain_eth_success 2",
+		"incognito_poolsize{chain=\"1\"} 7",
+		"# TYPE incognito_insert_seconds summary",
+		"incognito_insert_seconds_sum{chain=\"beacon\"} 2",
+		"incognito_insert_seconds_count{chain=\"0\"} 1",
+		"incognito_handle_all_message_sum{chain=\"2\"} 4",
+		"incognito_handle_all_message_count{chain=\"2\"} 2",
+		"incognito_handle_all_message_last{chain=\"2\"} 3",
+		"incognito_tx_enter_net_sync_success_count 1",
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("missing %q in\n%v", line, out)
+		}
+	}
+	if strings.Count(out, "# TYPE incognito_insert_seconds summary") != 1 {
+		t.Errorf("family incognito_insert_seconds must be declared once")
+	}
+}
@@ -28,6 +28,7 @@ type MultiView struct {
 	viewByHash     map[common.Hash]View //viewByPrevHash map[common.Hash][]View
 	viewByPrevHash map[common.Hash][]View
 	actionCh       chan func()
+	destroyCh      chan struct{}
 
 	//state
 	finalView View
@@ -39,12 +40,16 @@ func NewMultiView() *MultiView {
 		viewByHash:     make(map[common.Hash]View),
 		viewByPrevHash: make(map[common.Hash][]View),
 		actionCh:       make(chan func()),
+		destroyCh:      make(chan struct{}),
 	}
 
 	go func() {
 		ticker := time.NewTicker(time.Second * 10)
 		for {
 			select {
+			case <-s.destroyCh:
+				ticker.Stop()
+				return
 			case f := <-s.actionCh:
 				f()
 			case <-ticker.C:
@@ -57,6 +62,11 @@ func NewMultiView() *MultiView {
 	return s
 }
 
+// Destroy stops the goroutine serving the multiview, the views can not be added or looked up anymore
+func (multiView *MultiView) Destroy() {
+	multiView.destroyCh <- struct{}{}
+}
+
 func (multiView *MultiView) Reset() {
 	multiView.viewByHash = make(map[common.Hash]View)
 	multiView.viewByPrevHash = make(map[common.Hash][]View)
@@ -9,6 +9,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
 
 	"github.com/incognitochain/incognito-chain/syncker"
 
@@ -157,16 +158,10 @@ out:
 		case msgChan := <-netSync.cMessage:
 			{
 				go func(msgC interface{}) {
-					// go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
-					// 	metrics.Measurement:      metrics.HandleAllMessage,
-					// 	metrics.MeasurementValue: float64(1),
-					// 	metrics.Tag:              metrics.ShardIDTag,
-					// 	metrics.TagValue:         fmt.Sprintf("shardid-%+v", netSync.config.RoleInCommittees)})
-					// go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
-					// 	metrics.Measurement:      metrics.HandleAllMessageSize,
-					// 	metrics.MeasurementValue: float64(reflect.TypeOf(msgC).Size()),
-					// 	metrics.Tag:              metrics.ShardIDTag,
-					// 	metrics.TagValue:         fmt.Sprintf("shardid-%+v", netSync.config.RoleInCommittees)})
+					grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+						grafana.Measurement:      grafana.HandleAllMessage,
+						grafana.MeasurementValue: float64(1),
+					})
 					switch msg := msgC.(type) {
 					case *wire.MessageTx, *wire.MessageTxPrivacyToken:
 						{
@@ -395,25 +390,25 @@ func (netSync *NetSync) handleMessageTxPrivacyToken(msg *wire.MessageTxPrivacyTo
 }
 
 func (netSync *NetSync) handleMessageBFTMsg(msg *wire.MessageBFT) {
-	// go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
-	// 	metrics.Measurement:      metrics.HandleMessageBFTMsg,
-	// 	metrics.MeasurementValue: float64(1),
-	// 	metrics.Tag:              metrics.ShardIDTag,
-	// 	metrics.TagValue:         fmt.Sprintf("shardid-%+v", netSync.config.RoleInCommittees),
-	// })
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.HandleMessageBFTMsg,
+		grafana.MeasurementValue: float64(1),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         msg.ChainKey,
+	})
 	Logger.log.Info("Handling new message BFTMsg")
-	// startTime := time.Now()
+	startTime := time.Now()
 	if err := msg.VerifyMsgSanity(); err != nil {
 		Logger.log.Error(err)
 		return
 	}
 	netSync.config.Consensus.OnBFTMsg(msg)
-	// go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
-	// 	metrics.Measurement:      metrics.HandleMessageBFTMsgTime,
-	// 	metrics.MeasurementValue: float64(time.Since(startTime).Seconds()),
-	// 	metrics.Tag:              metrics.ShardIDTag,
-	// 	metrics.TagValue:         fmt.Sprintf("shardid-%+v", netSync.config.RoleInCommittees),
-	// })
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.HandleMessageBFTMsgTime,
+		grafana.MeasurementValue: float64(time.Since(startTime).Seconds()),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         msg.ChainKey,
+	})
 }
 
 func (netSync *NetSync) handleCacheBlock(blockHash string) bool {
@@ -15,6 +15,9 @@ import (
 func NewBlockProvider(p *p2pgrpc.GRPCProtocol, bg BlockGetter) *BlockProvider {
 	bp := &BlockProvider{BlockGetter: bg}
 	proto.RegisterHighwayServiceServer(p.GetGRPCServer(), bp)
+	if sg, ok := bg.(StateGetter); ok {
+		proto.RegisterStateSyncServiceServer(p.GetGRPCServer(), &StateProvider{StateGetter: sg})
+	}
 	go p.Serve() // NOTE: must serve after registering all services
 	return bp
 }
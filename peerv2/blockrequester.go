@@ -331,6 +331,67 @@ func (c *BlockRequester) GetBlockBeaconByHash(
 	return res, nil
 }
 
+func (c *BlockRequester) GetStateSnapshot(
+	ctx context.Context,
+	chainID int32,
+	checkpoint common.Hash,
+) ([]byte, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester still not ready")
+	}
+	uuid := genUUID()
+	Logger.Infof("[statesync] Requesting state snapshot of chain %v checkpoint %v, uuid = %s", chainID, checkpoint.String(), uuid)
+	client := proto.NewStateSyncServiceClient(c.conn)
+	reply, err := client.GetStateSnapshot(
+		ctx,
+		&proto.GetStateSnapshotRequest{
+			ChainID:    chainID,
+			UUID:       uuid,
+			Checkpoint: checkpoint.GetBytes(),
+		},
+		grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize),
+	)
+	if err != nil {
+		Logger.Errorf("Request state snapshot of chain %v return error %v, uuid = %s", chainID, err, uuid)
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
+func (c *BlockRequester) GetStateNodes(
+	ctx context.Context,
+	chainID int32,
+	hashes []common.Hash,
+) ([][]byte, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester still not ready")
+	}
+	hashBytes := [][]byte{}
+	for _, hash := range hashes {
+		hashBytes = append(hashBytes, hash.GetBytes())
+	}
+	uuid := genUUID()
+	client := proto.NewStateSyncServiceClient(c.conn)
+	reply, err := client.GetStateNodes(
+		ctx,
+		&proto.GetStateNodesRequest{
+			ChainID: chainID,
+			Hashes:  hashBytes,
+			UUID:    uuid,
+		},
+		grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize),
+	)
+	if err != nil {
+		Logger.Errorf("Request %v state nodes of chain %v return error %v, uuid = %s", len(hashes), chainID, err, uuid)
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
 type syncBlkInfo struct {
 	bySpecHeights bool
 	byHash        bool
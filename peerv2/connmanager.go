@@ -57,6 +57,7 @@ func NewConnManager(
 		hwLocker:   &sync.RWMutex{},
 		newHighway: make(chan *rpcclient.HighwayAddr, 100),
 		reqPickHW:  make(chan interface{}, 100),
+		transport:  TransportHighway,
 		notifiee:   &network.NotifyBundle{},
 		rttService: nil,
 	}
@@ -219,9 +220,23 @@ type ConnManager struct {
 	newHighway chan *rpcclient.HighwayAddr
 	reqPickHW  chan interface{}
 
+	transport      string // TransportHighway, TransportMesh or TransportAuto
+	bootstrapPeers []string
+	mesh           *meshDiscovery
+
 	stop chan int
 }
 
+// SetTransport selects how the node reaches the network, must be called before StartV2.
+// bootstrapPeers are the libp2p addresses used to join the mesh.
+func (cm *ConnManager) SetTransport(mode string, bootstrapPeers []string) {
+	if mode == "" {
+		mode = TransportHighway
+	}
+	cm.transport = mode
+	cm.bootstrapPeers = bootstrapPeers
+}
+
 func (cm *ConnManager) PutMessage(msg *pubsub.Message) {
 	cm.messages <- msg
 }
@@ -496,6 +511,25 @@ func (conn *ConnManager) RequestShardBlocksByHashViaStream(ctx context.Context,
 	return conn.requestBlocksByHashViaStream(ctx, peerID, req)
 }
 
+func (conn *ConnManager) RequestStateSnapshot(ctx context.Context, chainID int, checkpoint common.Hash) (*blockchain.StateSnapshot, error) {
+	data, err := conn.Requester.GetStateSnapshot(ctx, int32(chainID), checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &blockchain.StateSnapshot{}
+	if err := wrapper.DeCom(data, snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.ChainID != chainID {
+		return nil, errors.Errorf("receive state snapshot of chain %v, expect chain %v", snapshot.ChainID, chainID)
+	}
+	return snapshot, nil
+}
+
+func (conn *ConnManager) RequestStateNodes(ctx context.Context, chainID int, hashes []common.Hash) ([][]byte, error) {
+	return conn.Requester.GetStateNodes(ctx, int32(chainID), hashes)
+}
+
 func (conn *ConnManager) requestBlocksViaStream(ctx context.Context, peerID string, req *proto.BlockByHeightRequest) (blockCh chan types.BlockInterface, err error) {
 	Logger.Infof("[stream] Request Block type %v from peer %v from cID %v, [%v %v] ", req.Type, peerID, req.GetFrom(), req.Heights[0], req.Heights[len(req.Heights)-1])
 	blockCh = make(chan types.BlockInterface, blockchain.DefaultMaxBlkReqPerPeer)
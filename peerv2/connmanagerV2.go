@@ -15,26 +15,41 @@ import (
 func (cm *ConnManager) StartV2(bg BlockGetter) {
 	// Pubsub
 	var err error
-	cm.ps, err = pubsub.NewFloodSub(
-		context.Background(),
-		cm.LocalHost.Host,
+	opts := []pubsub.Option{
 		pubsub.WithMaxMessageSize(common.MaxPSMsgSize),
 		pubsub.WithPeerOutboundQueueSize(1024),
 		pubsub.WithValidateQueueSize(1024),
-	)
-
+	}
+	if cm.transport == TransportHighway {
+		cm.ps, err = pubsub.NewFloodSub(context.Background(), cm.LocalHost.Host, opts...)
+	} else {
+		// gossipsub also speaks floodsub, so highways keep working in auto mode
+		cm.ps, err = pubsub.NewGossipSub(context.Background(), cm.LocalHost.Host, opts...)
+	}
 	if err != nil {
 		panic(err)
 	}
+	if cm.transport != TransportHighway {
+		cm.mesh, err = newMeshDiscovery(cm.LocalHost.Host, cm.bootstrapPeers, meshNamespace(cm.LocalHost.Version))
+		if err != nil {
+			panic(err)
+		}
+	}
 	cm.messages = make(chan *pubsub.Message, 1000)
 
-	go cm.keeper.Start(cm.LocalHost, cm.rttService, cm.discoverer, cm.DiscoverPeersAddress)
+	if cm.transport != TransportMesh {
+		go cm.keeper.Start(cm.LocalHost, cm.rttService, cm.discoverer, cm.DiscoverPeersAddress)
+	}
 
 	// NOTE: must Connect after creating FloodSub
 	cm.Requester = NewRequesterV2(cm.LocalHost.GRPC)
-	cm.Subscriber = NewSubManager(cm.info, cm.ps, cm.Requester, cm.messages, cm.disp)
-
-	go cm.manageHighwayConnection()
+	if cm.transport == TransportMesh {
+		cm.Subscriber = NewSubManager(cm.info, cm.ps, &meshRegisterer{}, cm.messages, cm.disp)
+		go cm.manageMeshConnection()
+	} else {
+		cm.Subscriber = NewSubManager(cm.info, cm.ps, cm.Requester, cm.messages, cm.disp)
+		go cm.manageHighwayConnection()
+	}
 
 	cm.Provider = NewBlockProvider(cm.LocalHost.GRPC, bg)
 	go cm.keepConnectionAlive()
@@ -192,6 +207,7 @@ func (cm *ConnManager) manageHighwayConnection() {
 			time.Sleep(10 * time.Minute)
 		}
 	}(cm)
+	noHWSince := time.Now() // zero while connected to a highway
 	for {
 		select {
 		case <-cm.reqPickHW:
@@ -200,6 +216,22 @@ func (cm *ConnManager) manageHighwayConnection() {
 			if err != nil {
 				Logger.Error(err)
 			}
+			cm.hwLocker.RLock()
+			hasHW := err == nil || cm.currentHW != nil
+			cm.hwLocker.RUnlock()
+			if hasHW {
+				noHWSince = time.Time{}
+			} else if noHWSince.IsZero() {
+				noHWSince = time.Now()
+			}
+			if cm.shouldFallbackToMesh(hasHW, noHWSince) {
+				Logger.Warnf("No highway answered since %v, switching to mesh transport", noHWSince)
+				if sub, ok := cm.Subscriber.(*SubManager); ok {
+					sub.SetRegisterer(&meshRegisterer{})
+				}
+				go cm.manageMeshConnection()
+				return
+			}
 			Logger.Info("[debugGRPC] Pick HW Done")
 		case newHW := <-cm.newHighway:
 			Logger.Info("[debugGRPC] Received newHW %v", newHW)
@@ -219,6 +251,7 @@ func (cm *ConnManager) manageHighwayConnection() {
 			cm.hwLocker.Lock()
 			cm.currentHW = newHW
 			cm.hwLocker.Unlock()
+			noHWSince = time.Time{}
 			Logger.Info("[debugGRPC] Force subscribe %v", newHW)
 			err := cm.Subscriber.Subscribe(true)
 			if err != nil {
@@ -235,6 +268,14 @@ func (cm *ConnManager) manageHighwayConnection() {
 	}
 }
 
+// shouldFallbackToMesh reports whether a node in auto mode should give up highways
+func (cm *ConnManager) shouldFallbackToMesh(hasHW bool, noHWSince time.Time) bool {
+	if cm.transport != TransportAuto || hasHW || noHWSince.IsZero() {
+		return false
+	}
+	return time.Since(noHWSince) > HighwayFallbackTimeout
+}
+
 // manageRoleSubscription: polling current role periodically and subscribe to relevant topics
 func (cm *ConnManager) keepConnectionAlive() {
 	forced := false // only subscribe when role changed or last forced subscribe failed
@@ -32,4 +32,9 @@ var (
 
 	IgnoreRPCDuration = 60 * time.Minute  // Ignore an address after a failed RPC
 	IgnoreHWDuration  = 360 * time.Minute // Ignore a highway when cannot connect
+
+	MeshMinPeers           = 8                // Look for more mesh peers below this number of connections
+	MeshDiscoveryTimestep  = 30 * time.Second // Check number of mesh peers
+	MeshFindPeersTimeout   = 20 * time.Second // Timeout for a DHT lookup of mesh peers
+	HighwayFallbackTimeout = 2 * time.Minute  // Switch to mesh after no highway answered for this long (auto mode)
 )
@@ -0,0 +1,327 @@
+package peerv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	p2pgrpc "github.com/incognitochain/go-libp2p-grpc"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peerv2/proto"
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/pkg/errors"
+)
+
+// Transport modes of ConnManager
+const (
+	TransportHighway = "highway" // gossip and block requests go through one highway
+	TransportMesh    = "mesh"    // gossip directly between peers found through the DHT and bootstrap peers
+	TransportAuto    = "auto"    // use highways, fall back to mesh when no highway answers
+)
+
+const (
+	meshTopicSuffix = "mesh"
+	meshDHTPrefix   = "/incognito"
+)
+
+// IsValidTransport reports whether mode is one of the supported transport modes
+func IsValidTransport(mode string) bool {
+	return mode == TransportHighway || mode == TransportMesh || mode == TransportAuto
+}
+
+// meshTopic returns the gossipsub topic of a message on a chain, shaped <msg>-<chainID>-mesh so that GetCommitteeIDOfTopic can parse it
+func meshTopic(msg string, cID byte) string {
+	return fmt.Sprintf("%s-%d-%s", msg, cID, meshTopicSuffix)
+}
+
+func meshNamespace(version string) string {
+	return "incognito/mesh/" + version
+}
+
+func allShardIDs() []byte {
+	res := []byte{}
+	for i := 0; i < common.MaxShardNumber; i++ {
+		res = append(res, byte(i))
+	}
+	return res
+}
+
+func containsChain(cIDs []byte, cID byte) bool {
+	for _, c := range cIDs {
+		if c == cID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMessage(msgs []string, msg string) bool {
+	for _, m := range msgs {
+		if m == msg {
+			return true
+		}
+	}
+	return false
+}
+
+// meshSubscribedChains returns the chains whose topic of msg a node listening on committeeIDs must subscribe to
+func meshSubscribedChains(msg string, messages []string, committeeIDs []byte) []byte {
+	switch msg {
+	case wire.CmdBlockBeacon:
+		return []byte{HighwayBeaconID}
+	case wire.CmdPeerState:
+		res := append([]byte{}, committeeIDs...)
+		if !containsChain(res, HighwayBeaconID) {
+			res = append([]byte{HighwayBeaconID}, res...)
+		}
+		if containsChain(committeeIDs, HighwayBeaconID) && containsMessage(messages, wire.CmdBlockShard) {
+			res = append(res, allShardIDs()...)
+		}
+		return res
+	case wire.CmdBlockShard:
+		if containsChain(committeeIDs, HighwayBeaconID) {
+			return allShardIDs()
+		}
+	}
+	return committeeIDs
+}
+
+// meshPublishChains returns the chains a message is sent to besides the subscribed ones, those topics are published to without subscribing
+func meshPublishChains(msg string) []byte {
+	switch msg {
+	case wire.CmdCrossShard, wire.CmdTx, wire.CmdPrivacyCustomToken:
+		return allShardIDs()
+	case wire.CmdMsgFinishSync:
+		return []byte{common.BeaconChainSyncID}
+	}
+	return nil
+}
+
+// meshRegisterer derives topics locally instead of registering to a highway, every node of a chain uses the same topic
+type meshRegisterer struct{}
+
+func (r *meshRegisterer) Register(
+	ctx context.Context,
+	pubkey string,
+	messages []string,
+	committeeIDs []byte,
+	selfID peer.ID,
+	role string,
+) ([]*proto.MessageTopicPair, *proto.UserRole, error) {
+	pairs := []*proto.MessageTopicPair{}
+	for _, msg := range messages {
+		pair := &proto.MessageTopicPair{Message: msg}
+		subscribed := map[byte]bool{}
+		for _, cID := range meshSubscribedChains(msg, messages, committeeIDs) {
+			if subscribed[cID] {
+				continue
+			}
+			subscribed[cID] = true
+			pair.Topic = append(pair.Topic, meshTopic(msg, cID))
+			pair.Act = append(pair.Act, proto.MessageTopicPair_PUBSUB)
+		}
+		for _, cID := range meshPublishChains(msg) {
+			if subscribed[cID] {
+				continue
+			}
+			subscribed[cID] = true
+			pair.Topic = append(pair.Topic, meshTopic(msg, cID))
+			pair.Act = append(pair.Act, proto.MessageTopicPair_PUB)
+		}
+		pairs = append(pairs, pair)
+	}
+
+	userRole := &proto.UserRole{Role: role, Shard: -1}
+	if len(committeeIDs) == 1 {
+		userRole.Shard = int32(committeeIDs[0])
+	}
+	return pairs, userRole, nil
+}
+
+func (r *meshRegisterer) Target() string {
+	return meshTopicSuffix
+}
+
+func (r *meshRegisterer) UpdateTarget(peer.ID) {}
+
+// meshDiscovery keeps a node connected to enough peers of the mesh, using the bootstrap peers and a Kademlia DHT
+type meshDiscovery struct {
+	host      host.Host
+	bootstrap []peer.AddrInfo
+	namespace string
+
+	dht     *dht.IpfsDHT
+	routing *discovery.RoutingDiscovery
+}
+
+// newMeshDiscovery creates the DHT of the mesh, it must be called before connecting to any peer of the mesh
+func newMeshDiscovery(h host.Host, bootstrapAddrs []string, namespace string) (*meshDiscovery, error) {
+	bootstrap := []peer.AddrInfo{}
+	for _, addr := range bootstrapAddrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		addrInfo, err := getAddressInfo(addr)
+		if err != nil {
+			Logger.Errorf("Ignore bootstrap peer %v, err %v", addr, err)
+			continue
+		}
+		if addrInfo.ID == h.ID() {
+			continue
+		}
+		bootstrap = append(bootstrap, *addrInfo)
+	}
+	kad, err := dht.New(
+		context.Background(),
+		h,
+		dht.Mode(dht.ModeServer),
+		dht.ProtocolPrefix(meshDHTPrefix),
+		dht.BootstrapPeers(bootstrap...),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &meshDiscovery{
+		host:      h,
+		bootstrap: bootstrap,
+		namespace: namespace,
+		dht:       kad,
+		routing:   discovery.NewRoutingDiscovery(kad),
+	}, nil
+}
+
+// start connects to the bootstrap peers, fills the DHT routing table and advertises this node under the mesh namespace
+func (md *meshDiscovery) start(ctx context.Context) error {
+	md.connectBootstrap(ctx)
+	if err := md.dht.Bootstrap(ctx); err != nil {
+		return errors.WithStack(err)
+	}
+	discovery.Advertise(ctx, md.routing, md.namespace)
+	return nil
+}
+
+func (md *meshDiscovery) connectBootstrap(ctx context.Context) int {
+	connected := 0
+	for _, addrInfo := range md.bootstrap {
+		if md.host.Network().Connectedness(addrInfo.ID) == network.Connected {
+			connected++
+			continue
+		}
+		dialCtx, cancel := context.WithTimeout(ctx, DialTimeout)
+		err := md.host.Connect(dialCtx, addrInfo)
+		cancel()
+		if err != nil {
+			Logger.Warnf("Could not connect to bootstrap peer %v: %v", addrInfo.ID.Pretty(), err)
+			continue
+		}
+		connected++
+	}
+	return connected
+}
+
+// discover connects to peers advertising the mesh namespace until this node has MeshMinPeers peers
+func (md *meshDiscovery) discover(ctx context.Context) {
+	if len(md.host.Network().Peers()) >= MeshMinPeers {
+		return
+	}
+	md.connectBootstrap(ctx)
+	findCtx, cancel := context.WithTimeout(ctx, MeshFindPeersTimeout)
+	defer cancel()
+	peers, err := md.routing.FindPeers(findCtx, md.namespace)
+	if err != nil {
+		Logger.Warnf("Find mesh peers failed: %v", err)
+		return
+	}
+	for addrInfo := range peers {
+		if addrInfo.ID == md.host.ID() || len(addrInfo.Addrs) == 0 {
+			continue
+		}
+		if md.host.Network().Connectedness(addrInfo.ID) == network.Connected {
+			continue
+		}
+		dialCtx, cancel := context.WithTimeout(ctx, DialTimeout)
+		err := md.host.Connect(dialCtx, addrInfo)
+		cancel()
+		if err != nil {
+			Logger.Debugf("Could not connect to mesh peer %v: %v", addrInfo.ID.Pretty(), err)
+			continue
+		}
+		Logger.Infof("Connected to mesh peer %v", addrInfo.ID.Pretty())
+		if len(md.host.Network().Peers()) >= MeshMinPeers {
+			return
+		}
+	}
+}
+
+// providers returns the connected peers serving blocks over gRPC
+func (md *meshDiscovery) providers() []peer.ID {
+	res := []peer.ID{}
+	for _, pid := range md.host.Network().Peers() {
+		protocols, err := md.host.Peerstore().SupportsProtocols(pid, string(p2pgrpc.Protocol))
+		if err != nil || len(protocols) == 0 {
+			continue
+		}
+		res = append(res, pid)
+	}
+	return res
+}
+
+// manageMeshConnection keeps the node in the mesh: discovers peers, subscribes to the chains' topics
+// and points the BlockRequester at a connected peer, whose BlockProvider serves blocks over libp2p
+func (cm *ConnManager) manageMeshConnection() {
+	Logger.Infof("manageMeshConnection")
+	ctx := context.Background()
+	if err := cm.mesh.start(ctx); err != nil {
+		Logger.Errorf("Start mesh DHT failed, only bootstrap peers are used: %+v", err)
+	}
+	go cm.mesh.discover(ctx)
+
+	forced := true // force the first subscribe since topics change from highway to mesh
+	subsTimestep := time.NewTicker(CheckSubsTimestep)
+	defer subsTimestep.Stop()
+	discoverTimestep := time.NewTicker(MeshDiscoveryTimestep)
+	defer discoverTimestep.Stop()
+	for {
+		select {
+		case <-subsTimestep.C:
+			if err := cm.Subscriber.Subscribe(forced); err != nil {
+				Logger.Errorf("Subscribe mesh topics failed: forced = %v err = %+v", forced, err)
+			} else {
+				forced = false
+			}
+			if !cm.Requester.IsReady() {
+				if err := cm.pickMeshProvider(); err != nil {
+					Logger.Warn(err)
+				}
+			}
+		case <-discoverTimestep.C:
+			cm.mesh.discover(ctx)
+		case <-cm.reqPickHW:
+			if err := cm.pickMeshProvider(); err != nil {
+				Logger.Warn(err)
+			}
+		case <-cm.stop:
+			Logger.Info("Stop managing mesh connection")
+			return
+		}
+	}
+}
+
+// pickMeshProvider connects the BlockRequester to a random connected peer serving blocks
+func (cm *ConnManager) pickMeshProvider() error {
+	id := common.RandInt()
+	providers := cm.mesh.providers()
+	if len(providers) == 0 {
+		return errors.New("no mesh peer serves blocks yet")
+	}
+	pid := providers[id%len(providers)]
+	Logger.Infof("Pick mesh peer %v as block provider, id %v", pid.Pretty(), id)
+	return cm.Requester.ConnectNewHW(&peer.AddrInfo{ID: pid}, id)
+}
@@ -0,0 +1,227 @@
+package peerv2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pubsub "github.com/incognitochain/go-libp2p-pubsub"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peerv2/proto"
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMeshHost(t *testing.T) (*Host, string) {
+	h := NewHost("test", "127.0.0.1", 0, "")
+	addrs := h.Host.Addrs()
+	assert.NotEmpty(t, addrs)
+	return h, fmt.Sprintf("%s/p2p/%s", addrs[0], h.Host.ID().Pretty())
+}
+
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestMeshRegisterer(t *testing.T) {
+	defer func(n int) { common.MaxShardNumber = n }(common.MaxShardNumber)
+	common.MaxShardNumber = 2
+
+	topicsOf := func(pairs []*proto.MessageTopicPair, msg string) map[string]proto.MessageTopicPair_Action {
+		res := map[string]proto.MessageTopicPair_Action{}
+		for _, p := range pairs {
+			if p.Message != msg {
+				continue
+			}
+			for i, topic := range p.Topic {
+				res[topic] = p.Act[i]
+			}
+		}
+		return res
+	}
+
+	r := &meshRegisterer{}
+	msgs := getMessagesForLayer(common.ShardRole, common.CommitteeRole, []byte{0})
+	pairs, role, err := r.Register(context.Background(), "", msgs, []byte{0}, peer.ID(""), common.CommitteeRole)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), role.Shard)
+	assert.Equal(t, map[string]proto.MessageTopicPair_Action{
+		"blockbeacon-255-mesh": proto.MessageTopicPair_PUBSUB,
+	}, topicsOf(pairs, wire.CmdBlockBeacon))
+	assert.Equal(t, map[string]proto.MessageTopicPair_Action{
+		"bft-0-mesh": proto.MessageTopicPair_PUBSUB,
+	}, topicsOf(pairs, wire.CmdBFT))
+	assert.Equal(t, map[string]proto.MessageTopicPair_Action{
+		"crossshard-0-mesh": proto.MessageTopicPair_PUBSUB,
+		"crossshard-1-mesh": proto.MessageTopicPair_PUB,
+	}, topicsOf(pairs, wire.CmdCrossShard))
+	assert.Equal(t, map[string]proto.MessageTopicPair_Action{
+		"peerstate-255-mesh": proto.MessageTopicPair_PUBSUB,
+		"peerstate-0-mesh":   proto.MessageTopicPair_PUBSUB,
+	}, topicsOf(pairs, wire.CmdPeerState))
+	assert.Equal(t, map[string]proto.MessageTopicPair_Action{
+		"finishsync-0-mesh":   proto.MessageTopicPair_PUBSUB,
+		"finishsync-255-mesh": proto.MessageTopicPair_PUB,
+	}, topicsOf(pairs, wire.CmdMsgFinishSync))
+	assert.Equal(t, 1, GetCommitteeIDOfTopic(meshTopic(wire.CmdCrossShard, 1)))
+
+	msgs = getMessagesForLayer(common.BeaconRole, common.CommitteeRole, []byte{HighwayBeaconID})
+	pairs, _, err = r.Register(context.Background(), "", msgs, []byte{HighwayBeaconID}, peer.ID(""), common.CommitteeRole)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]proto.MessageTopicPair_Action{
+		"blockshard-0-mesh": proto.MessageTopicPair_PUBSUB,
+		"blockshard-1-mesh": proto.MessageTopicPair_PUBSUB,
+	}, topicsOf(pairs, wire.CmdBlockShard))
+	assert.Equal(t, 3, len(topicsOf(pairs, wire.CmdPeerState)))
+}
+
+// TestMeshDiscovery makes sure two nodes knowing only the same bootstrap peer find each other through the DHT
+func TestMeshDiscovery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	boot, bootAddr := newTestMeshHost(t)
+	h1, _ := newTestMeshHost(t)
+	h2, _ := newTestMeshHost(t)
+	defer boot.Host.Close()
+	defer h1.Host.Close()
+	defer h2.Host.Close()
+
+	ns := meshNamespace("test")
+	mds := []*meshDiscovery{}
+	for i, h := range []*Host{boot, h1, h2} {
+		bootstrap := []string{bootAddr}
+		if i == 0 {
+			bootstrap = nil
+		}
+		md, err := newMeshDiscovery(h.Host, bootstrap, ns)
+		assert.Nil(t, err)
+		mds = append(mds, md)
+	}
+	for _, md := range mds {
+		assert.Nil(t, md.start(ctx))
+	}
+	assert.Equal(t, network.Connected, h1.Host.Network().Connectedness(boot.Host.ID()))
+
+	found := waitFor(20*time.Second, func() bool {
+		mds[1].discover(ctx)
+		return h1.Host.Network().Connectedness(h2.Host.ID()) == network.Connected
+	})
+	assert.True(t, found, "peer not discovered through the DHT")
+}
+
+// TestMeshGossip makes sure a message published on a shard topic reaches a node that is only connected through another peer
+func TestMeshGossip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	boot, bootAddr := newTestMeshHost(t)
+	h1, _ := newTestMeshHost(t)
+	h2, _ := newTestMeshHost(t)
+	defer boot.Host.Close()
+	defer h1.Host.Close()
+	defer h2.Host.Close()
+
+	topic := meshTopic(wire.CmdBFT, 0)
+	pss := []*pubsub.PubSub{}
+	subs := []*pubsub.Subscription{}
+	for _, h := range []*Host{boot, h1, h2} {
+		ps, err := pubsub.NewGossipSub(ctx, h.Host)
+		assert.Nil(t, err)
+		s, err := ps.Subscribe(topic)
+		assert.Nil(t, err)
+		pss = append(pss, ps)
+		subs = append(subs, s)
+	}
+
+	// NOTE: must connect after creating the pubsub
+	for _, h := range []*Host{h1, h2} {
+		md, err := newMeshDiscovery(h.Host, []string{bootAddr}, meshNamespace("test"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, md.connectBootstrap(ctx))
+	}
+	assert.NotEqual(t, network.Connected, h1.Host.Network().Connectedness(h2.Host.ID()))
+	time.Sleep(2 * time.Second) // let the gossipsub mesh form
+
+	assert.Nil(t, pss[1].Publish(topic, []byte("hello")))
+	recvCtx, recvCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer recvCancel()
+	msg, err := subs[2].Next(recvCtx)
+	assert.Nil(t, err)
+	if err == nil {
+		assert.Equal(t, []byte("hello"), msg.Data)
+	}
+}
+
+type testBlockGetter struct {
+	beaconBlocks map[common.Hash]*types.BeaconBlock
+}
+
+func (bg *testBlockGetter) StreamBlockByHeight(fromPool bool, req *proto.BlockByHeightRequest) chan interface{} {
+	return nil
+}
+
+func (bg *testBlockGetter) StreamBlockByHash(fromPool bool, req *proto.BlockByHashRequest) chan interface{} {
+	return nil
+}
+
+func (bg *testBlockGetter) GetShardBlockByHeight(height uint64, shardID byte) (map[common.Hash]*types.ShardBlock, error) {
+	return nil, errors.New("not found")
+}
+
+func (bg *testBlockGetter) GetShardBlockByHash(hash common.Hash) (*types.ShardBlock, uint64, error) {
+	return nil, 0, errors.New("not found")
+}
+
+func (bg *testBlockGetter) GetBeaconBlockByHeight(height uint64) ([]*types.BeaconBlock, error) {
+	return nil, errors.New("not found")
+}
+
+func (bg *testBlockGetter) GetBeaconBlockByHash(hash common.Hash) (*types.BeaconBlock, uint64, error) {
+	blk, ok := bg.beaconBlocks[hash]
+	if !ok {
+		return nil, 0, errors.New("not found")
+	}
+	return blk, blk.GetHeight(), nil
+}
+
+// TestMeshBlockProvider makes sure a node gets blocks from a connected peer's BlockProvider, without any highway
+func TestMeshBlockProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider, providerAddr := newTestMeshHost(t)
+	h, _ := newTestMeshHost(t)
+	defer provider.Host.Close()
+	defer h.Host.Close()
+
+	blk := types.NewBeaconBlock()
+	blk.Header.Height = 10
+	NewBlockProvider(provider.GRPC, &testBlockGetter{beaconBlocks: map[common.Hash]*types.BeaconBlock{*blk.Hash(): blk}})
+
+	cm := &ConnManager{
+		LocalHost:      h,
+		Requester:      NewRequesterV2(h.GRPC),
+		bootstrapPeers: []string{providerAddr},
+	}
+	var err error
+	cm.mesh, err = newMeshDiscovery(h.Host, cm.bootstrapPeers, meshNamespace("test"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cm.mesh.connectBootstrap(ctx))
+	assert.True(t, waitFor(5*time.Second, func() bool { return len(cm.mesh.providers()) == 1 }))
+	assert.Nil(t, cm.pickMeshProvider())
+	assert.True(t, cm.Requester.IsReady())
+
+	data, err := cm.Requester.GetBlockBeaconByHash([]common.Hash{*blk.Hash()})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(data))
+}
@@ -43,3 +43,19 @@ func (_m *ConsensusData) GetOneValidatorForEachConsensusProcess() map[int]*conse
 
 	return r0
 }
+
+// GetSyncingValidators provides a mock function with given fields:
+func (_m *ConsensusData) GetSyncingValidators() []*consensus.Validator {
+	ret := _m.Called()
+
+	var r0 []*consensus.Validator
+	if rf, ok := ret.Get(0).(func() []*consensus.Validator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*consensus.Validator)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,212 @@
+package proto
+
+import (
+	context "context"
+
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// State sync messages are served next to HighwayService over the same gRPC server,
+// they are decoded through the struct tags so no descriptor needs to be registered.
+
+type GetStateSnapshotRequest struct {
+	ChainID    int32  `protobuf:"varint,1,opt,name=ChainID,proto3" json:"ChainID,omitempty"`
+	UUID       string `protobuf:"bytes,2,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Checkpoint []byte `protobuf:"bytes,3,opt,name=Checkpoint,proto3" json:"Checkpoint,omitempty"`
+}
+
+func (m *GetStateSnapshotRequest) Reset()         { *m = GetStateSnapshotRequest{} }
+func (m *GetStateSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateSnapshotRequest) ProtoMessage()    {}
+
+func (m *GetStateSnapshotRequest) GetChainID() int32 {
+	if m != nil {
+		return m.ChainID
+	}
+	return 0
+}
+
+func (m *GetStateSnapshotRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *GetStateSnapshotRequest) GetCheckpoint() []byte {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+type GetStateSnapshotResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (m *GetStateSnapshotResponse) Reset()         { *m = GetStateSnapshotResponse{} }
+func (m *GetStateSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateSnapshotResponse) ProtoMessage()    {}
+
+func (m *GetStateSnapshotResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetStateNodesRequest struct {
+	ChainID int32    `protobuf:"varint,1,opt,name=ChainID,proto3" json:"ChainID,omitempty"`
+	Hashes  [][]byte `protobuf:"bytes,2,rep,name=Hashes,proto3" json:"Hashes,omitempty"`
+	UUID    string   `protobuf:"bytes,3,opt,name=UUID,proto3" json:"UUID,omitempty"`
+}
+
+func (m *GetStateNodesRequest) Reset()         { *m = GetStateNodesRequest{} }
+func (m *GetStateNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateNodesRequest) ProtoMessage()    {}
+
+func (m *GetStateNodesRequest) GetChainID() int32 {
+	if m != nil {
+		return m.ChainID
+	}
+	return 0
+}
+
+func (m *GetStateNodesRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *GetStateNodesRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+type GetStateNodesResponse struct {
+	// Data[i] is the encoded trie node of Hashes[i], empty if the node is unknown
+	Data [][]byte `protobuf:"bytes,1,rep,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (m *GetStateNodesResponse) Reset()         { *m = GetStateNodesResponse{} }
+func (m *GetStateNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateNodesResponse) ProtoMessage()    {}
+
+func (m *GetStateNodesResponse) GetData() [][]byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// StateSyncServiceClient is the client API for StateSyncService service.
+type StateSyncServiceClient interface {
+	GetStateSnapshot(ctx context.Context, in *GetStateSnapshotRequest, opts ...grpc.CallOption) (*GetStateSnapshotResponse, error)
+	GetStateNodes(ctx context.Context, in *GetStateNodesRequest, opts ...grpc.CallOption) (*GetStateNodesResponse, error)
+}
+
+type stateSyncServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStateSyncServiceClient(cc *grpc.ClientConn) StateSyncServiceClient {
+	return &stateSyncServiceClient{cc}
+}
+
+func (c *stateSyncServiceClient) GetStateSnapshot(ctx context.Context, in *GetStateSnapshotRequest, opts ...grpc.CallOption) (*GetStateSnapshotResponse, error) {
+	out := new(GetStateSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/StateSyncService/GetStateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateSyncServiceClient) GetStateNodes(ctx context.Context, in *GetStateNodesRequest, opts ...grpc.CallOption) (*GetStateNodesResponse, error) {
+	out := new(GetStateNodesResponse)
+	err := c.cc.Invoke(ctx, "/StateSyncService/GetStateNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateSyncServiceServer is the server API for StateSyncService service.
+type StateSyncServiceServer interface {
+	GetStateSnapshot(context.Context, *GetStateSnapshotRequest) (*GetStateSnapshotResponse, error)
+	GetStateNodes(context.Context, *GetStateNodesRequest) (*GetStateNodesResponse, error)
+}
+
+// UnimplementedStateSyncServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedStateSyncServiceServer struct {
+}
+
+func (*UnimplementedStateSyncServiceServer) GetStateSnapshot(ctx context.Context, req *GetStateSnapshotRequest) (*GetStateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateSnapshot not implemented")
+}
+func (*UnimplementedStateSyncServiceServer) GetStateNodes(ctx context.Context, req *GetStateNodesRequest) (*GetStateNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateNodes not implemented")
+}
+
+func RegisterStateSyncServiceServer(s *grpc.Server, srv StateSyncServiceServer) {
+	s.RegisterService(&_StateSyncService_serviceDesc, srv)
+}
+
+func _StateSyncService_GetStateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateSyncServiceServer).GetStateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/StateSyncService/GetStateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateSyncServiceServer).GetStateSnapshot(ctx, req.(*GetStateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateSyncService_GetStateNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateSyncServiceServer).GetStateNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/StateSyncService/GetStateNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateSyncServiceServer).GetStateNodes(ctx, req.(*GetStateNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _StateSyncService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "StateSyncService",
+	HandlerType: (*StateSyncServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStateSnapshot",
+			Handler:    _StateSyncService_GetStateSnapshot_Handler,
+		},
+		{
+			MethodName: "GetStateNodes",
+			Handler:    _StateSyncService_GetStateNodes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "statesync.proto",
+}
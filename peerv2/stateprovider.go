@@ -0,0 +1,49 @@
+package peerv2
+
+import (
+	"context"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peerv2/proto"
+	"github.com/incognitochain/incognito-chain/peerv2/wrapper"
+)
+
+// StateGetter serves pinned state snapshots and trie nodes for fast sync
+type StateGetter interface {
+	GetStateSnapshot(chainID int, checkpoint common.Hash) (*blockchain.StateSnapshot, error)
+	GetStateNodes(chainID int, hashes []common.Hash) ([][]byte, error)
+}
+
+type StateProvider struct {
+	proto.UnimplementedStateSyncServiceServer
+	StateGetter StateGetter
+}
+
+func (sp *StateProvider) GetStateSnapshot(ctx context.Context, req *proto.GetStateSnapshotRequest) (*proto.GetStateSnapshotResponse, error) {
+	checkpoint := common.BytesToHash(req.GetCheckpoint())
+	Logger.Infof("[statesync] Receive GetStateSnapshot chain %v checkpoint %v request, uuid = %s", req.GetChainID(), checkpoint.String(), req.GetUUID())
+	snapshot, err := sp.StateGetter.GetStateSnapshot(int(req.GetChainID()), checkpoint)
+	if err != nil {
+		Logger.Errorf("[statesync] Cannot get state snapshot of chain %v: %v, uuid = %s", req.GetChainID(), err, req.GetUUID())
+		return nil, err
+	}
+	data, err := wrapper.EnCom(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetStateSnapshotResponse{Data: data}, nil
+}
+
+func (sp *StateProvider) GetStateNodes(ctx context.Context, req *proto.GetStateNodesRequest) (*proto.GetStateNodesResponse, error) {
+	hashes := []common.Hash{}
+	for _, hashBytes := range req.GetHashes() {
+		hashes = append(hashes, common.BytesToHash(hashBytes))
+	}
+	Logger.Debugf("[statesync] Receive GetStateNodes chain %v request %v nodes, uuid = %s", req.GetChainID(), len(hashes), req.GetUUID())
+	data, err := sp.StateGetter.GetStateNodes(int(req.GetChainID()), hashes)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetStateNodesResponse{Data: data}, nil
+}
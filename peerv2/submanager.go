@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/incognitochain/incognito-chain/common/consensus"
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -27,8 +28,9 @@ type SubManager struct {
 	info
 	messages chan *pubsub.Message // channel to put subscribed messages to
 
-	registerer Registerer
-	subscriber Subscriber
+	registerer     Registerer
+	registererLock sync.RWMutex // the highway loop switches to mesh while keepConnectionAlive subscribes
+	subscriber     Subscriber
 
 	topics msgToTopics
 	subs   msgToTopics // mapping from message to topic's subscription
@@ -83,6 +85,19 @@ func (sub *SubManager) SetSyncMode(s string) {
 	sub.syncMode = s
 }
 
+// SetRegisterer changes where topics are registered, the next Subscribe should be forced
+func (sub *SubManager) SetRegisterer(r Registerer) {
+	sub.registererLock.Lock()
+	defer sub.registererLock.Unlock()
+	sub.registerer = r
+}
+
+func (sub *SubManager) getRegisterer() Registerer {
+	sub.registererLock.RLock()
+	defer sub.registererLock.RUnlock()
+	return sub.registerer
+}
+
 // Subscribe registers to proxy and save the list of new topics if needed
 func (sub *SubManager) Subscribe(forced bool) error {
 	rolehash := ""
@@ -148,7 +163,7 @@ func (sub *SubManager) Subscribe(forced bool) error {
 
 		// Registering mining
 		for chainID, validator := range newRole {
-			if validator.PrivateSeed != "" {
+			if validator.Signer != nil {
 				topics, _, err := sub.registerToProxy(
 					validator.MiningKey.GetPublicKeyBase58(),
 					validator.State.Layer,
@@ -357,7 +372,7 @@ func (sub *SubManager) registerToProxy(
 	Logger.Infof("Registering: pubkey: %v", pubkey)
 	Logger.Infof("Registering: wantedMessages: %v", messagesWanted)
 
-	pairs, topicRole, err := sub.registerer.Register(
+	pairs, topicRole, err := sub.getRegisterer().Register(
 		context.Background(),
 		pubkey,
 		messagesWanted,
@@ -153,7 +153,7 @@ func TestGetMessage(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			msgs := getMessagesForLayer(tc.layer, tc.shardID)
+			msgs := getMessagesForLayer(tc.layer, "", tc.shardID)
 			compareMsgs(t, tc.out, msgs)
 		})
 	}
@@ -318,3 +318,25 @@ func TestRegisterToProxy(t *testing.T) {
 		}
 	}
 }
+
+func TestSetRegistererWhileSubscribing(t *testing.T) {
+	newRegisterer := func() *mocks.Registerer {
+		registerer := &mocks.Registerer{}
+		var pairs []*proto.MessageTopicPair
+		registerer.On("Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pairs, &proto.UserRole{}, nil)
+		return registerer
+	}
+	sub := &SubManager{registerer: newRegisterer()}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			sub.SetRegisterer(newRegisterer())
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, _, err := sub.registerToProxy("", common.ShardRole, common.CommitteeRole, []byte{0})
+		assert.Nil(t, err)
+	}
+	<-done
+}
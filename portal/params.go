@@ -1,6 +1,7 @@
 package portal
 
 import (
+	"fmt"
 	"sort"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	portaltokensv3 "github.com/incognitochain/incognito-chain/portal/portalv3/portaltokens"
 	"github.com/incognitochain/incognito-chain/portal/portalv4"
 	portaltokensv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
 type PortalParams struct {
@@ -106,6 +108,22 @@ func SetupParam() {
 	}
 }
 
+// GetNetworkPortalParams returns the portal params of a network as named by config.Config().Network(),
+// for the processes which do not load the node config (e.g. the remote signer)
+func GetNetworkPortalParams(network string) (PortalParams, error) {
+	switch network {
+	case config.LocalNetwork, config.LocalDCSNetwork:
+		return localPortalParam, nil
+	case config.TestNetNetwork + "-" + config.TestNetVersion1:
+		return testnet1PortalParams, nil
+	case config.TestNetNetwork + "-" + config.TestNetVersion2:
+		return testnet2PortalParams, nil
+	case config.MainnetNetwork:
+		return mainnetPortalParam, nil
+	}
+	return PortalParams{}, fmt.Errorf("unknown network %v", network)
+}
+
 var localPortalParam = PortalParams{
 	PortalParamsV3: map[uint64]portalv3.PortalParams{
 		0: {
@@ -130,12 +148,18 @@ var localPortalParam = PortalParams{
 		},
 	},
 	RelayingParam: portalrelaying.RelayingParams{
-		BNBRelayingHeaderChainID: TestnetBNBChainID,
-		BTCRelayingHeaderChainID: TestnetBTCChainID,
-		BTCDataFolderName:        TestnetBTCDataFolderName,
-		BNBFullNodeProtocol:      TestnetBNBFullNodeProtocol,
-		BNBFullNodeHost:          TestnetBNBFullNodeHost,
-		BNBFullNodePort:          TestnetBNBFullNodePort,
+		BNBRelayingHeaderChainID:  TestnetBNBChainID,
+		BTCRelayingHeaderChainID:  TestnetBTCChainID,
+		BTCDataFolderName:         TestnetBTCDataFolderName,
+		LTCRelayingHeaderChainID:  TestnetLTCChainID,
+		LTCDataFolderName:         TestnetLTCDataFolderName,
+		DOGERelayingHeaderChainID: TestnetDOGEChainID,
+		DOGEDataFolderName:        TestnetDOGEDataFolderName,
+		BCHRelayingHeaderChainID:  TestnetBCHChainID,
+		BCHDataFolderName:         TestnetBCHDataFolderName,
+		BNBFullNodeProtocol:       TestnetBNBFullNodeProtocol,
+		BNBFullNodeHost:           TestnetBNBFullNodeHost,
+		BNBFullNodePort:           TestnetBNBFullNodePort,
 	},
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
 		0: {
@@ -151,37 +175,79 @@ var localPortalParam = PortalParams{
 					[]byte{0x2, 0xa8, 0x58, 0xf6, 0x9e, 0xea, 0x70, 0xd8, 0x1a, 0xd1, 0xd2, 0x1f, 0x8f, 0xbe, 0x79, 0xfb, 0xa2, 0xa7, 0xe8, 0x50, 0x8c, 0x2a, 0x71, 0x58, 0xf6, 0x1c, 0x2f, 0xa0, 0x17, 0x60, 0x89, 0x6e, 0x39},
 					[]byte{0x3, 0xa2, 0x52, 0x1b, 0x31, 0x41, 0x16, 0xe7, 0x5b, 0x7f, 0x4b, 0xed, 0xb5, 0x22, 0x20, 0x1f, 0xcb, 0x43, 0x79, 0x70, 0x49, 0x8d, 0x40, 0xa5, 0xb1, 0x72, 0xbd, 0xae, 0x3a, 0xa, 0x64, 0x27, 0xa5},
 				},
+				LocalPortalV4LTCID: [][]byte{
+					[]byte{0x2, 0x72, 0xdc, 0x74, 0xff, 0x9b, 0xf, 0x93, 0x82, 0x2, 0xda, 0xa3, 0x94, 0x44, 0x53, 0x21, 0xae, 0x82, 0x66, 0x67, 0xe, 0xb4, 0x36, 0x71, 0xb4, 0x25, 0x24, 0x6f, 0x72, 0x6e, 0x42, 0x61, 0xc},
+					[]byte{0x3, 0x60, 0xd8, 0x58, 0x94, 0x9a, 0xad, 0x2b, 0x5f, 0xb2, 0x62, 0xda, 0xb1, 0xcc, 0x6c, 0x56, 0x6e, 0x3e, 0x4b, 0x9d, 0xff, 0x5d, 0x4, 0x98, 0xcb, 0xf3, 0xf9, 0x50, 0x65, 0xc6, 0x4f, 0x9a, 0x9c},
+					[]byte{0x2, 0xa8, 0x58, 0xf6, 0x9e, 0xea, 0x70, 0xd8, 0x1a, 0xd1, 0xd2, 0x1f, 0x8f, 0xbe, 0x79, 0xfb, 0xa2, 0xa7, 0xe8, 0x50, 0x8c, 0x2a, 0x71, 0x58, 0xf6, 0x1c, 0x2f, 0xa0, 0x17, 0x60, 0x89, 0x6e, 0x39},
+					[]byte{0x3, 0xa2, 0x52, 0x1b, 0x31, 0x41, 0x16, 0xe7, 0x5b, 0x7f, 0x4b, 0xed, 0xb5, 0x22, 0x20, 0x1f, 0xcb, 0x43, 0x79, 0x70, 0x49, 0x8d, 0x40, 0xa5, 0xb1, 0x72, 0xbd, 0xae, 0x3a, 0xa, 0x64, 0x27, 0xa5},
+				},
+				LocalPortalV4DOGEID: [][]byte{
+					[]byte{0x2, 0x72, 0xdc, 0x74, 0xff, 0x9b, 0xf, 0x93, 0x82, 0x2, 0xda, 0xa3, 0x94, 0x44, 0x53, 0x21, 0xae, 0x82, 0x66, 0x67, 0xe, 0xb4, 0x36, 0x71, 0xb4, 0x25, 0x24, 0x6f, 0x72, 0x6e, 0x42, 0x61, 0xc},
+					[]byte{0x3, 0x60, 0xd8, 0x58, 0x94, 0x9a, 0xad, 0x2b, 0x5f, 0xb2, 0x62, 0xda, 0xb1, 0xcc, 0x6c, 0x56, 0x6e, 0x3e, 0x4b, 0x9d, 0xff, 0x5d, 0x4, 0x98, 0xcb, 0xf3, 0xf9, 0x50, 0x65, 0xc6, 0x4f, 0x9a, 0x9c},
+					[]byte{0x2, 0xa8, 0x58, 0xf6, 0x9e, 0xea, 0x70, 0xd8, 0x1a, 0xd1, 0xd2, 0x1f, 0x8f, 0xbe, 0x79, 0xfb, 0xa2, 0xa7, 0xe8, 0x50, 0x8c, 0x2a, 0x71, 0x58, 0xf6, 0x1c, 0x2f, 0xa0, 0x17, 0x60, 0x89, 0x6e, 0x39},
+					[]byte{0x3, 0xa2, 0x52, 0x1b, 0x31, 0x41, 0x16, 0xe7, 0x5b, 0x7f, 0x4b, 0xed, 0xb5, 0x22, 0x20, 0x1f, 0xcb, 0x43, 0x79, 0x70, 0x49, 0x8d, 0x40, 0xa5, 0xb1, 0x72, 0xbd, 0xae, 0x3a, 0xa, 0x64, 0x27, 0xa5},
+				},
+				LocalPortalV4BCHID: [][]byte{
+					[]byte{0x2, 0x72, 0xdc, 0x74, 0xff, 0x9b, 0xf, 0x93, 0x82, 0x2, 0xda, 0xa3, 0x94, 0x44, 0x53, 0x21, 0xae, 0x82, 0x66, 0x67, 0xe, 0xb4, 0x36, 0x71, 0xb4, 0x25, 0x24, 0x6f, 0x72, 0x6e, 0x42, 0x61, 0xc},
+					[]byte{0x3, 0x60, 0xd8, 0x58, 0x94, 0x9a, 0xad, 0x2b, 0x5f, 0xb2, 0x62, 0xda, 0xb1, 0xcc, 0x6c, 0x56, 0x6e, 0x3e, 0x4b, 0x9d, 0xff, 0x5d, 0x4, 0x98, 0xcb, 0xf3, 0xf9, 0x50, 0x65, 0xc6, 0x4f, 0x9a, 0x9c},
+					[]byte{0x2, 0xa8, 0x58, 0xf6, 0x9e, 0xea, 0x70, 0xd8, 0x1a, 0xd1, 0xd2, 0x1f, 0x8f, 0xbe, 0x79, 0xfb, 0xa2, 0xa7, 0xe8, 0x50, 0x8c, 0x2a, 0x71, 0x58, 0xf6, 0x1c, 0x2f, 0xa0, 0x17, 0x60, 0x89, 0x6e, 0x39},
+					[]byte{0x3, 0xa2, 0x52, 0x1b, 0x31, 0x41, 0x16, 0xe7, 0x5b, 0x7f, 0x4b, 0xed, 0xb5, 0x22, 0x20, 0x1f, 0xcb, 0x43, 0x79, 0x70, 0x49, 0x8d, 0x40, 0xa5, 0xb1, 0x72, 0xbd, 0xae, 0x3a, 0xa, 0x64, 0x27, 0xa5},
+				},
 			},
 			NumRequiredSigs: 3,
 			GeneralMultiSigAddresses: map[string]string{
 				// LocalPortalV4BTCID: "tb1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0scjyajz",
-				LocalPortalV4BTCID: "tb1q5dhtz6x2lzkjpw7asp9sa4adqvtkddu4jutdlndrnzdq4jau5yzsmyxftl",
+				LocalPortalV4BTCID:  "tb1q5dhtz6x2lzkjpw7asp9sa4adqvtkddu4jutdlndrnzdq4jau5yzsmyxftl",
+				LocalPortalV4LTCID:  "tltc1q5dhtz6x2lzkjpw7asp9sa4adqvtkddu4jutdlndrnzdq4jau5yzsy86g5q",
+				LocalPortalV4DOGEID: "2NB9GqCAywyEPnkYfdYTQd4c76bUBy26hb6",
+				LocalPortalV4BCHID:  "bchtest:prz9yfztsqq479wt7sfz6esw9tpmaguajyawacugfr",
 			},
 			PortalTokens: initPortalTokensV4ForLocal(),
 			DefaultFeeUnshields: map[string]uint64{
-				LocalPortalV4BTCID: 50000, // 50000 nano pbtc = 5000 satoshi
+				LocalPortalV4BTCID:  50000,      // 50000 nano pbtc = 5000 satoshi
+				LocalPortalV4LTCID:  100000,     // 100000 nano pltc = 10000 litoshi
+				LocalPortalV4DOGEID: 1000000000, // 1e9 nano pdoge = 1 DOGE
+				LocalPortalV4BCHID:  10000,      // 10000 nano pbch = 1000 satoshi
 			},
 			MinShieldAmts: map[string]uint64{
-				LocalPortalV4BTCID: 5000, // 5000 nano pbtc = 500 satoshi
+				LocalPortalV4BTCID:  5000,        // 5000 nano pbtc = 500 satoshi
+				LocalPortalV4LTCID:  100000,      // 100000 nano pltc = 10000 litoshi
+				LocalPortalV4DOGEID: 10000000000, // 1e10 nano pdoge = 10 DOGE
+				LocalPortalV4BCHID:  10000,       // 10000 nano pbch = 1000 satoshi
 			},
 			MinUnshieldAmts: map[string]uint64{
-				LocalPortalV4BTCID: 500000, // 500000 nano pbtc = 50000 satoshi
+				LocalPortalV4BTCID:  500000,       // 500000 nano pbtc = 50000 satoshi
+				LocalPortalV4LTCID:  1000000,      // 1000000 nano pltc = 100000 litoshi
+				LocalPortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				LocalPortalV4BCHID:  500000,       // 500000 nano pbch = 50000 satoshi
 			},
 			DustValueThreshold: map[string]uint64{
-				LocalPortalV4BTCID: 10000000, // 1000000 nano pbtc = 0.01 BTC
+				LocalPortalV4BTCID:  10000000,      // 1000000 nano pbtc = 0.01 BTC
+				LocalPortalV4LTCID:  100000000,     // 1e8 nano pltc = 0.1 LTC
+				LocalPortalV4DOGEID: 1000000000000, // 1e12 nano pdoge = 1000 DOGE
+				LocalPortalV4BCHID:  10000000,      // 1e7 nano pbch = 0.01 BCH
 			},
 			MinUTXOsInVault: map[string]uint64{
-				LocalPortalV4BTCID: 50,
+				LocalPortalV4BTCID:  50,
+				LocalPortalV4LTCID:  50,
+				LocalPortalV4DOGEID: 50,
+				LocalPortalV4BCHID:  50,
 			},
 			BatchNumBlks:                15, // ~ 2.5 mins
 			PortalReplacementAddress:    "12svfkP6w5UDJDSCwqH978PvqiqBxKmUnA9em9yAYWYJVRv7wuXY1qhhYpPAm4BDz2mLbFrRmdK3yRhnTqJCZXKHUmoi7NV83HCH2YFpctHNaDdkSiQshsjw2UFUuwdEvcidgaKmF3VJpY5f8RdN",
 			MaxFeePercentageForEachStep: 20, // ~ 20% from previous fee
 			TimeSpaceForFeeReplacement:  5 * time.Minute,
 			MaxUnshieldFees: map[string]uint64{
-				LocalPortalV4BTCID: 1000000, // 1000000 nano pbtc = 100000 satoshi
+				LocalPortalV4BTCID:  1000000,      // 1000000 nano pbtc = 100000 satoshi
+				LocalPortalV4LTCID:  10000000,     // 1e7 nano pltc = 0.01 LTC
+				LocalPortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				LocalPortalV4BCHID:  1000000,      // 1e6 nano pbch = 0.001 BCH
 			},
 			PortalV4TokenIDs: []string{
 				LocalPortalV4BTCID,
+				LocalPortalV4LTCID,
+				LocalPortalV4DOGEID,
+				LocalPortalV4BCHID,
 			},
 		},
 	},
@@ -211,12 +277,18 @@ var testnet1PortalParams = PortalParams{
 		},
 	},
 	RelayingParam: portalrelaying.RelayingParams{
-		BNBRelayingHeaderChainID: TestnetBNBChainID,
-		BTCRelayingHeaderChainID: TestnetBTCChainID,
-		BTCDataFolderName:        TestnetBTCDataFolderName,
-		BNBFullNodeProtocol:      TestnetBNBFullNodeProtocol,
-		BNBFullNodeHost:          TestnetBNBFullNodeHost,
-		BNBFullNodePort:          TestnetBNBFullNodePort,
+		BNBRelayingHeaderChainID:  TestnetBNBChainID,
+		BTCRelayingHeaderChainID:  TestnetBTCChainID,
+		BTCDataFolderName:         TestnetBTCDataFolderName,
+		LTCRelayingHeaderChainID:  TestnetLTCChainID,
+		LTCDataFolderName:         TestnetLTCDataFolderName,
+		DOGERelayingHeaderChainID: TestnetDOGEChainID,
+		DOGEDataFolderName:        TestnetDOGEDataFolderName,
+		BCHRelayingHeaderChainID:  TestnetBCHChainID,
+		BCHDataFolderName:         TestnetBCHDataFolderName,
+		BNBFullNodeProtocol:       TestnetBNBFullNodeProtocol,
+		BNBFullNodeHost:           TestnetBNBFullNodeHost,
+		BNBFullNodePort:           TestnetBNBFullNodePort,
 	},
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
 		0: {
@@ -231,36 +303,90 @@ var testnet1PortalParams = PortalParams{
 					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
 						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
 				},
+				TestnetPortalV4LTCID: [][]byte{
+					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+				},
+				TestnetPortalV4DOGEID: [][]byte{
+					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+				},
+				TestnetPortalV4BCHID: [][]byte{
+					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+				},
 			},
 			NumRequiredSigs: 3,
 			GeneralMultiSigAddresses: map[string]string{
-				TestnetPortalV4BTCID: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				TestnetPortalV4BTCID:  "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				TestnetPortalV4LTCID:  "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+				TestnetPortalV4DOGEID: "2N9EsqpirWrpQtjWYhR6g1MMoHTgLuTqGU5",
+				TestnetPortalV4BCHID:  "bchtest:pzhhzvsnamsa5eyh0ejnusp5y7daelj5egg0z34hgl",
 			},
 			PortalTokens: initPortalTokensV4ForTestNet(),
 			DefaultFeeUnshields: map[string]uint64{
-				TestnetPortalV4BTCID: 50000, // nano pbtc
+				TestnetPortalV4BTCID:  50000,      // nano pbtc
+				TestnetPortalV4LTCID:  100000,     // 100000 nano pltc = 10000 litoshi
+				TestnetPortalV4DOGEID: 1000000000, // 1e9 nano pdoge = 1 DOGE
+				TestnetPortalV4BCHID:  10000,      // 10000 nano pbch = 1000 satoshi
 			},
 			MinShieldAmts: map[string]uint64{
-				TestnetPortalV4BTCID: 100000, // nano pbtc
+				TestnetPortalV4BTCID:  100000,      // nano pbtc
+				TestnetPortalV4LTCID:  100000,      // 100000 nano pltc = 10000 litoshi
+				TestnetPortalV4DOGEID: 10000000000, // 1e10 nano pdoge = 10 DOGE
+				TestnetPortalV4BCHID:  10000,       // 10000 nano pbch = 1000 satoshi
 			},
 			MinUnshieldAmts: map[string]uint64{
-				TestnetPortalV4BTCID: 100000, // nano pbtc
+				TestnetPortalV4BTCID:  100000,       // nano pbtc
+				TestnetPortalV4LTCID:  1000000,      // 1000000 nano pltc = 100000 litoshi
+				TestnetPortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				TestnetPortalV4BCHID:  500000,       // 500000 nano pbch = 50000 satoshi
 			},
 			DustValueThreshold: map[string]uint64{
-				TestnetPortalV4BTCID: 10000000, // nano pbtc
+				TestnetPortalV4BTCID:  10000000,      // nano pbtc
+				TestnetPortalV4LTCID:  100000000,     // 1e8 nano pltc = 0.1 LTC
+				TestnetPortalV4DOGEID: 1000000000000, // 1e12 nano pdoge = 1000 DOGE
+				TestnetPortalV4BCHID:  10000000,      // 1e7 nano pbch = 0.01 BCH
 			},
 			MinUTXOsInVault: map[string]uint64{
-				TestnetPortalV4BTCID: 50,
+				TestnetPortalV4BTCID:  50,
+				TestnetPortalV4LTCID:  50,
+				TestnetPortalV4DOGEID: 50,
+				TestnetPortalV4BCHID:  50,
 			},
 			BatchNumBlks:                20,
 			PortalReplacementAddress:    "12sv8WUvkvFfD5SW3aaXDSPs8yx2SxPdbv6a2LAU6FJb2kBKqmLcCuQ6ZQst4fg7THBTBtERaqMpJ7KBgsnRYobmysFEM2pbMwLE2kGzwyxgSijnZT7VQGeuUxBryC1Z6ebd8EWqDUkxwpW7Gqt8",
 			MaxFeePercentageForEachStep: 10, // ~ 10% from previous fee
 			TimeSpaceForFeeReplacement:  5 * time.Minute,
 			MaxUnshieldFees: map[string]uint64{
-				TestnetPortalV4BTCID: 100000, // pbtc
+				TestnetPortalV4BTCID:  100000,       // pbtc
+				TestnetPortalV4LTCID:  10000000,     // 1e7 nano pltc = 0.01 LTC
+				TestnetPortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				TestnetPortalV4BCHID:  1000000,      // 1e6 nano pbch = 0.001 BCH
 			},
 			PortalV4TokenIDs: []string{
 				TestnetPortalV4BTCID,
+				TestnetPortalV4LTCID,
+				TestnetPortalV4DOGEID,
+				TestnetPortalV4BCHID,
 			},
 		},
 	},
@@ -290,12 +416,18 @@ var testnet2PortalParams = PortalParams{
 		},
 	},
 	RelayingParam: portalrelaying.RelayingParams{
-		BNBRelayingHeaderChainID: Testnet2BNBChainID,
-		BTCRelayingHeaderChainID: Testnet2BTCChainID,
-		BTCDataFolderName:        Testnet2BTCDataFolderName,
-		BNBFullNodeProtocol:      Testnet2BNBFullNodeProtocol,
-		BNBFullNodeHost:          Testnet2BNBFullNodeHost,
-		BNBFullNodePort:          Testnet2BNBFullNodePort,
+		BNBRelayingHeaderChainID:  Testnet2BNBChainID,
+		BTCRelayingHeaderChainID:  Testnet2BTCChainID,
+		BTCDataFolderName:         Testnet2BTCDataFolderName,
+		LTCRelayingHeaderChainID:  Testnet2LTCChainID,
+		LTCDataFolderName:         Testnet2LTCDataFolderName,
+		DOGERelayingHeaderChainID: Testnet2DOGEChainID,
+		DOGEDataFolderName:        Testnet2DOGEDataFolderName,
+		BCHRelayingHeaderChainID:  Testnet2BCHChainID,
+		BCHDataFolderName:         Testnet2BCHDataFolderName,
+		BNBFullNodeProtocol:       Testnet2BNBFullNodeProtocol,
+		BNBFullNodeHost:           Testnet2BNBFullNodeHost,
+		BNBFullNodePort:           Testnet2BNBFullNodePort,
 	},
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
 		0: {
@@ -310,36 +442,90 @@ var testnet2PortalParams = PortalParams{
 					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
 						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
 				},
+				Testnet2PortalV4LTCID: [][]byte{
+					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+				},
+				Testnet2PortalV4DOGEID: [][]byte{
+					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+				},
+				Testnet2PortalV4BCHID: [][]byte{
+					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+				},
 			},
 			NumRequiredSigs: 3,
 			GeneralMultiSigAddresses: map[string]string{
-				Testnet2PortalV4BTCID: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				Testnet2PortalV4BTCID:  "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				Testnet2PortalV4LTCID:  "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+				Testnet2PortalV4DOGEID: "2N9EsqpirWrpQtjWYhR6g1MMoHTgLuTqGU5",
+				Testnet2PortalV4BCHID:  "bchtest:pzhhzvsnamsa5eyh0ejnusp5y7daelj5egg0z34hgl",
 			},
 			PortalTokens: initPortalTokensV4ForTestNet2(),
 			DefaultFeeUnshields: map[string]uint64{
-				Testnet2PortalV4BTCID: 50000, // nano pbtc
+				Testnet2PortalV4BTCID:  50000,      // nano pbtc
+				Testnet2PortalV4LTCID:  100000,     // 100000 nano pltc = 10000 litoshi
+				Testnet2PortalV4DOGEID: 1000000000, // 1e9 nano pdoge = 1 DOGE
+				Testnet2PortalV4BCHID:  10000,      // 10000 nano pbch = 1000 satoshi
 			},
 			MinShieldAmts: map[string]uint64{
-				Testnet2PortalV4BTCID: 100000, // nano pbtc
+				Testnet2PortalV4BTCID:  100000,      // nano pbtc
+				Testnet2PortalV4LTCID:  100000,      // 100000 nano pltc = 10000 litoshi
+				Testnet2PortalV4DOGEID: 10000000000, // 1e10 nano pdoge = 10 DOGE
+				Testnet2PortalV4BCHID:  10000,       // 10000 nano pbch = 1000 satoshi
 			},
 			MinUnshieldAmts: map[string]uint64{
-				Testnet2PortalV4BTCID: 100000, // nano pbtc
+				Testnet2PortalV4BTCID:  100000,       // nano pbtc
+				Testnet2PortalV4LTCID:  1000000,      // 1000000 nano pltc = 100000 litoshi
+				Testnet2PortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				Testnet2PortalV4BCHID:  500000,       // 500000 nano pbch = 50000 satoshi
 			},
 			DustValueThreshold: map[string]uint64{
-				Testnet2PortalV4BTCID: 10000000, // nano pbtc
+				Testnet2PortalV4BTCID:  10000000,      // nano pbtc
+				Testnet2PortalV4LTCID:  100000000,     // 1e8 nano pltc = 0.1 LTC
+				Testnet2PortalV4DOGEID: 1000000000000, // 1e12 nano pdoge = 1000 DOGE
+				Testnet2PortalV4BCHID:  10000000,      // 1e7 nano pbch = 0.01 BCH
 			},
 			MinUTXOsInVault: map[string]uint64{
-				Testnet2PortalV4BTCID: 50,
+				Testnet2PortalV4BTCID:  50,
+				Testnet2PortalV4LTCID:  50,
+				Testnet2PortalV4DOGEID: 50,
+				Testnet2PortalV4BCHID:  50,
 			},
 			BatchNumBlks:                20, //
 			PortalReplacementAddress:    "12sv8WUvkvFfD5SW3aaXDSPs8yx2SxPdbv6a2LAU6FJb2kBKqmLcCuQ6ZQst4fg7THBTBtERaqMpJ7KBgsnRYobmysFEM2pbMwLE2kGzwyxgSijnZT7VQGeuUxBryC1Z6ebd8EWqDUkxwpW7Gqt8",
 			MaxFeePercentageForEachStep: 10, // ~ 10% from previous fee
 			TimeSpaceForFeeReplacement:  5 * time.Minute,
 			MaxUnshieldFees: map[string]uint64{
-				Testnet2PortalV4BTCID: 100000, // pbtc
+				Testnet2PortalV4BTCID:  100000,       // pbtc
+				Testnet2PortalV4LTCID:  10000000,     // 1e7 nano pltc = 0.01 LTC
+				Testnet2PortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				Testnet2PortalV4BCHID:  1000000,      // 1e6 nano pbch = 0.001 BCH
 			},
 			PortalV4TokenIDs: []string{
 				Testnet2PortalV4BTCID,
+				Testnet2PortalV4LTCID,
+				Testnet2PortalV4DOGEID,
+				Testnet2PortalV4BCHID,
 			},
 		},
 	},
@@ -370,12 +556,18 @@ var mainnetPortalParam = PortalParams{
 		},
 	},
 	RelayingParam: portalrelaying.RelayingParams{
-		BNBRelayingHeaderChainID: MainnetBNBChainID,
-		BTCRelayingHeaderChainID: MainnetBTCChainID,
-		BTCDataFolderName:        MainnetBTCDataFolderName,
-		BNBFullNodeProtocol:      MainnetBNBFullNodeProtocol,
-		BNBFullNodeHost:          MainnetBNBFullNodeHost,
-		BNBFullNodePort:          MainnetBNBFullNodePort,
+		BNBRelayingHeaderChainID:  MainnetBNBChainID,
+		BTCRelayingHeaderChainID:  MainnetBTCChainID,
+		BTCDataFolderName:         MainnetBTCDataFolderName,
+		LTCRelayingHeaderChainID:  MainnetLTCChainID,
+		LTCDataFolderName:         MainnetLTCDataFolderName,
+		DOGERelayingHeaderChainID: MainnetDOGEChainID,
+		DOGEDataFolderName:        MainnetDOGEDataFolderName,
+		BCHRelayingHeaderChainID:  MainnetBCHChainID,
+		BCHDataFolderName:         MainnetBCHDataFolderName,
+		BNBFullNodeProtocol:       MainnetBNBFullNodeProtocol,
+		BNBFullNodeHost:           MainnetBNBFullNodeHost,
+		BNBFullNodePort:           MainnetBNBFullNodePort,
 	},
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
 		0: {
@@ -396,36 +588,108 @@ var mainnetPortalParam = PortalParams{
 					[]byte{0x2, 0x65, 0x96, 0x49, 0xab, 0xd4, 0xe5, 0x97, 0x7d, 0x5b, 0x67, 0x4c, 0x6d, 0xa1, 0xf, 0x9,
 						0x28, 0xa0, 0x8c, 0x67, 0x8d, 0x7f, 0x50, 0xcc, 0x10, 0xf0, 0xfe, 0xe5, 0x68, 0xa8, 0x57, 0x63, 0xd8},
 				},
+				MainnetPortalV4LTCID: [][]byte{
+					[]byte{0x2, 0x39, 0x42, 0x3d, 0xad, 0x93, 0x8f, 0xcb, 0xe5, 0xb5, 0xef, 0x7b, 0x7b, 0x9a, 0xf, 0x28,
+						0x4, 0x19, 0x53, 0x66, 0x7f, 0xee, 0x72, 0xe4, 0x81, 0xf9, 0xe6, 0xb, 0x81, 0x41, 0xd7, 0x3a, 0x36},
+					[]byte{0x2, 0x8d, 0xc, 0xd7, 0x83, 0x9d, 0x5e, 0xc5, 0x7b, 0x77, 0x1a, 0xf1, 0x2, 0xb8, 0x72, 0xd0,
+						0x4f, 0x34, 0xb4, 0xeb, 0x17, 0xac, 0xa1, 0x9f, 0xdf, 0xa, 0x64, 0xbf, 0xd, 0x36, 0x76, 0x66, 0x87},
+					[]byte{0x3, 0x78, 0x52, 0x33, 0xe3, 0x8, 0x3a, 0xd8, 0x58, 0x77, 0x76, 0x29, 0xa0, 0x17, 0xb6, 0xdd,
+						0x16, 0x43, 0x18, 0x8b, 0xb4, 0xa3, 0xaf, 0x45, 0xf0, 0xb5, 0x91, 0x8c, 0x84, 0xf2, 0x73, 0x56, 0x44},
+					[]byte{0x3, 0x61, 0x9d, 0xc9, 0xfb, 0x6d, 0x8, 0x2a, 0x5c, 0x98, 0x45, 0xbc, 0xbf, 0x86, 0xfb, 0x47,
+						0x4, 0xbe, 0x67, 0x46, 0xa, 0x59, 0xc4, 0xbc, 0x1d, 0xec, 0xc0, 0xe8, 0xe4, 0x3e, 0x1d, 0x6d, 0x0},
+					[]byte{0x2, 0xe4, 0x1d, 0x40, 0xe6, 0xf3, 0x80, 0xad, 0x51, 0xca, 0x17, 0x87, 0xfe, 0xc8, 0x23, 0x8d,
+						0xa4, 0xc2, 0x88, 0xfc, 0xfb, 0x6f, 0x2b, 0xcc, 0xd9, 0xa6, 0x1c, 0x2, 0xe5, 0x4a, 0x31, 0x34, 0x39},
+					[]byte{0x2, 0xf0, 0xc, 0xe3, 0xec, 0x4, 0xdb, 0x75, 0x59, 0x99, 0x70, 0xc6, 0xfd, 0xc5, 0x2, 0x2f,
+						0xad, 0x6b, 0x8d, 0x18, 0x86, 0x71, 0x44, 0xcf, 0xe6, 0x93, 0x92, 0xbb, 0xd1, 0x60, 0xc1, 0x1b, 0x5c},
+					[]byte{0x2, 0x65, 0x96, 0x49, 0xab, 0xd4, 0xe5, 0x97, 0x7d, 0x5b, 0x67, 0x4c, 0x6d, 0xa1, 0xf, 0x9,
+						0x28, 0xa0, 0x8c, 0x67, 0x8d, 0x7f, 0x50, 0xcc, 0x10, 0xf0, 0xfe, 0xe5, 0x68, 0xa8, 0x57, 0x63, 0xd8},
+				},
+				MainnetPortalV4DOGEID: [][]byte{
+					[]byte{0x2, 0x39, 0x42, 0x3d, 0xad, 0x93, 0x8f, 0xcb, 0xe5, 0xb5, 0xef, 0x7b, 0x7b, 0x9a, 0xf, 0x28,
+						0x4, 0x19, 0x53, 0x66, 0x7f, 0xee, 0x72, 0xe4, 0x81, 0xf9, 0xe6, 0xb, 0x81, 0x41, 0xd7, 0x3a, 0x36},
+					[]byte{0x2, 0x8d, 0xc, 0xd7, 0x83, 0x9d, 0x5e, 0xc5, 0x7b, 0x77, 0x1a, 0xf1, 0x2, 0xb8, 0x72, 0xd0,
+						0x4f, 0x34, 0xb4, 0xeb, 0x17, 0xac, 0xa1, 0x9f, 0xdf, 0xa, 0x64, 0xbf, 0xd, 0x36, 0x76, 0x66, 0x87},
+					[]byte{0x3, 0x78, 0x52, 0x33, 0xe3, 0x8, 0x3a, 0xd8, 0x58, 0x77, 0x76, 0x29, 0xa0, 0x17, 0xb6, 0xdd,
+						0x16, 0x43, 0x18, 0x8b, 0xb4, 0xa3, 0xaf, 0x45, 0xf0, 0xb5, 0x91, 0x8c, 0x84, 0xf2, 0x73, 0x56, 0x44},
+					[]byte{0x3, 0x61, 0x9d, 0xc9, 0xfb, 0x6d, 0x8, 0x2a, 0x5c, 0x98, 0x45, 0xbc, 0xbf, 0x86, 0xfb, 0x47,
+						0x4, 0xbe, 0x67, 0x46, 0xa, 0x59, 0xc4, 0xbc, 0x1d, 0xec, 0xc0, 0xe8, 0xe4, 0x3e, 0x1d, 0x6d, 0x0},
+					[]byte{0x2, 0xe4, 0x1d, 0x40, 0xe6, 0xf3, 0x80, 0xad, 0x51, 0xca, 0x17, 0x87, 0xfe, 0xc8, 0x23, 0x8d,
+						0xa4, 0xc2, 0x88, 0xfc, 0xfb, 0x6f, 0x2b, 0xcc, 0xd9, 0xa6, 0x1c, 0x2, 0xe5, 0x4a, 0x31, 0x34, 0x39},
+					[]byte{0x2, 0xf0, 0xc, 0xe3, 0xec, 0x4, 0xdb, 0x75, 0x59, 0x99, 0x70, 0xc6, 0xfd, 0xc5, 0x2, 0x2f,
+						0xad, 0x6b, 0x8d, 0x18, 0x86, 0x71, 0x44, 0xcf, 0xe6, 0x93, 0x92, 0xbb, 0xd1, 0x60, 0xc1, 0x1b, 0x5c},
+					[]byte{0x2, 0x65, 0x96, 0x49, 0xab, 0xd4, 0xe5, 0x97, 0x7d, 0x5b, 0x67, 0x4c, 0x6d, 0xa1, 0xf, 0x9,
+						0x28, 0xa0, 0x8c, 0x67, 0x8d, 0x7f, 0x50, 0xcc, 0x10, 0xf0, 0xfe, 0xe5, 0x68, 0xa8, 0x57, 0x63, 0xd8},
+				},
+				MainnetPortalV4BCHID: [][]byte{
+					[]byte{0x2, 0x39, 0x42, 0x3d, 0xad, 0x93, 0x8f, 0xcb, 0xe5, 0xb5, 0xef, 0x7b, 0x7b, 0x9a, 0xf, 0x28,
+						0x4, 0x19, 0x53, 0x66, 0x7f, 0xee, 0x72, 0xe4, 0x81, 0xf9, 0xe6, 0xb, 0x81, 0x41, 0xd7, 0x3a, 0x36},
+					[]byte{0x2, 0x8d, 0xc, 0xd7, 0x83, 0x9d, 0x5e, 0xc5, 0x7b, 0x77, 0x1a, 0xf1, 0x2, 0xb8, 0x72, 0xd0,
+						0x4f, 0x34, 0xb4, 0xeb, 0x17, 0xac, 0xa1, 0x9f, 0xdf, 0xa, 0x64, 0xbf, 0xd, 0x36, 0x76, 0x66, 0x87},
+					[]byte{0x3, 0x78, 0x52, 0x33, 0xe3, 0x8, 0x3a, 0xd8, 0x58, 0x77, 0x76, 0x29, 0xa0, 0x17, 0xb6, 0xdd,
+						0x16, 0x43, 0x18, 0x8b, 0xb4, 0xa3, 0xaf, 0x45, 0xf0, 0xb5, 0x91, 0x8c, 0x84, 0xf2, 0x73, 0x56, 0x44},
+					[]byte{0x3, 0x61, 0x9d, 0xc9, 0xfb, 0x6d, 0x8, 0x2a, 0x5c, 0x98, 0x45, 0xbc, 0xbf, 0x86, 0xfb, 0x47,
+						0x4, 0xbe, 0x67, 0x46, 0xa, 0x59, 0xc4, 0xbc, 0x1d, 0xec, 0xc0, 0xe8, 0xe4, 0x3e, 0x1d, 0x6d, 0x0},
+					[]byte{0x2, 0xe4, 0x1d, 0x40, 0xe6, 0xf3, 0x80, 0xad, 0x51, 0xca, 0x17, 0x87, 0xfe, 0xc8, 0x23, 0x8d,
+						0xa4, 0xc2, 0x88, 0xfc, 0xfb, 0x6f, 0x2b, 0xcc, 0xd9, 0xa6, 0x1c, 0x2, 0xe5, 0x4a, 0x31, 0x34, 0x39},
+					[]byte{0x2, 0xf0, 0xc, 0xe3, 0xec, 0x4, 0xdb, 0x75, 0x59, 0x99, 0x70, 0xc6, 0xfd, 0xc5, 0x2, 0x2f,
+						0xad, 0x6b, 0x8d, 0x18, 0x86, 0x71, 0x44, 0xcf, 0xe6, 0x93, 0x92, 0xbb, 0xd1, 0x60, 0xc1, 0x1b, 0x5c},
+					[]byte{0x2, 0x65, 0x96, 0x49, 0xab, 0xd4, 0xe5, 0x97, 0x7d, 0x5b, 0x67, 0x4c, 0x6d, 0xa1, 0xf, 0x9,
+						0x28, 0xa0, 0x8c, 0x67, 0x8d, 0x7f, 0x50, 0xcc, 0x10, 0xf0, 0xfe, 0xe5, 0x68, 0xa8, 0x57, 0x63, 0xd8},
+				},
 			},
 			NumRequiredSigs: 5,
 			GeneralMultiSigAddresses: map[string]string{
-				MainnetPortalV4BTCID: "bc1qmx3s84mu3wuv69dlmrtlqpuduaejxqchd6rcfm9nhhujm5hhe7hqar8l93",
+				MainnetPortalV4BTCID:  "bc1qmx3s84mu3wuv69dlmrtlqpuduaejxqchd6rcfm9nhhujm5hhe7hqar8l93",
+				MainnetPortalV4LTCID:  "ltc1qmx3s84mu3wuv69dlmrtlqpuduaejxqchd6rcfm9nhhujm5hhe7hq78f0l5",
+				MainnetPortalV4DOGEID: "A5F1mQPTtTmopepYwEeff43FaGmNfrYvjL",
+				MainnetPortalV4BCHID:  "bitcoincash:pzx838ljua00y2tydyqe6q39ph7ypg0wdvjdfph7dn",
 			},
 			PortalTokens: initPortalTokensV4ForMainNet(),
 			DefaultFeeUnshields: map[string]uint64{
-				MainnetPortalV4BTCID: 30000, // nano pbtc
+				MainnetPortalV4BTCID:  30000,      // nano pbtc
+				MainnetPortalV4LTCID:  100000,     // 100000 nano pltc = 10000 litoshi
+				MainnetPortalV4DOGEID: 1000000000, // 1e9 nano pdoge = 1 DOGE
+				MainnetPortalV4BCHID:  10000,      // 10000 nano pbch = 1000 satoshi
 			},
 			MinShieldAmts: map[string]uint64{
-				MainnetPortalV4BTCID: 100000, // nano pbtc
+				MainnetPortalV4BTCID:  100000,      // nano pbtc
+				MainnetPortalV4LTCID:  100000,      // 100000 nano pltc = 10000 litoshi
+				MainnetPortalV4DOGEID: 10000000000, // 1e10 nano pdoge = 10 DOGE
+				MainnetPortalV4BCHID:  10000,       // 10000 nano pbch = 1000 satoshi
 			},
 			MinUnshieldAmts: map[string]uint64{
-				MainnetPortalV4BTCID: 1000000, // nano pbtc
+				MainnetPortalV4BTCID:  1000000,      // nano pbtc
+				MainnetPortalV4LTCID:  1000000,      // 1000000 nano pltc = 100000 litoshi
+				MainnetPortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				MainnetPortalV4BCHID:  500000,       // 500000 nano pbch = 50000 satoshi
 			},
 			DustValueThreshold: map[string]uint64{
-				MainnetPortalV4BTCID: 10000000, // nano pbtc ~ 0.01 BTC
+				MainnetPortalV4BTCID:  10000000,      // nano pbtc ~ 0.01 BTC
+				MainnetPortalV4LTCID:  100000000,     // 1e8 nano pltc = 0.1 LTC
+				MainnetPortalV4DOGEID: 1000000000000, // 1e12 nano pdoge = 1000 DOGE
+				MainnetPortalV4BCHID:  10000000,      // 1e7 nano pbch = 0.01 BCH
 			},
 			MinUTXOsInVault: map[string]uint64{
-				MainnetPortalV4BTCID: 500,
+				MainnetPortalV4BTCID:  500,
+				MainnetPortalV4LTCID:  500,
+				MainnetPortalV4DOGEID: 500,
+				MainnetPortalV4BCHID:  500,
 			},
 			BatchNumBlks:                45, // ~ 30 mins
 			PortalReplacementAddress:    "12sgiLdxrrmWx1qyoqxemoKdjAvUko8txG8isq3woUK73ocB4dtjaFzZVmCYQYcchzNEkptAzCK3tZF55xQvx4gcT82KzXCkMXFMbdP1A3kkhQ3NhxKpqufayLbBJ2v7MCdfkS8wvfrLXdhAXAMG",
 			MaxFeePercentageForEachStep: 25, // ~ 25% from previous fee
 			TimeSpaceForFeeReplacement:  5 * time.Minute,
 			MaxUnshieldFees: map[string]uint64{
-				MainnetPortalV4BTCID: 5000000, // pbtc
+				MainnetPortalV4BTCID:  5000000,      // pbtc
+				MainnetPortalV4LTCID:  10000000,     // 1e7 nano pltc = 0.01 LTC
+				MainnetPortalV4DOGEID: 100000000000, // 1e11 nano pdoge = 100 DOGE
+				MainnetPortalV4BCHID:  1000000,      // 1e6 nano pbch = 0.001 BCH
 			},
 			PortalV4TokenIDs: []string{
 				MainnetPortalV4BTCID,
+				MainnetPortalV4LTCID,
+				MainnetPortalV4DOGEID,
+				MainnetPortalV4BCHID,
 			},
 		},
 	},
@@ -520,6 +784,51 @@ func initPortalTokensV4ForLocal() map[string]portaltokensv4.PortalTokenProcessor
 			ChainParam:    &chaincfg.TestNet3Params,
 			PortalTokenID: LocalPortalV4BTCID,
 		},
+		LocalPortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   192,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   51200,
+					ExternalDustLimit:   5460,
+				},
+				ChainParam:    btcrelaying.LTCTestNet4Params,
+				PortalTokenID: LocalPortalV4LTCID,
+			},
+		},
+		LocalPortalV4DOGEID: portaltokensv4.PortalDOGETokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetDOGEChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   100000000,
+				},
+				ChainParam:    btcrelaying.DOGETestNetParams,
+				PortalTokenID: LocalPortalV4DOGEID,
+			},
+		},
+		LocalPortalV4BCHID: portaltokensv4.PortalBCHTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetBCHChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   546,
+				},
+				ChainParam:    btcrelaying.BCHTestNet3Params,
+				PortalTokenID: LocalPortalV4BCHID,
+			},
+		},
 	}
 }
 
@@ -537,6 +846,51 @@ func initPortalTokensV4ForTestNet() map[string]portaltokensv4.PortalTokenProcess
 			ChainParam:    &chaincfg.TestNet3Params,
 			PortalTokenID: TestnetPortalV4BTCID,
 		},
+		TestnetPortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   192,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   51200,
+					ExternalDustLimit:   5460,
+				},
+				ChainParam:    btcrelaying.LTCTestNet4Params,
+				PortalTokenID: TestnetPortalV4LTCID,
+			},
+		},
+		TestnetPortalV4DOGEID: portaltokensv4.PortalDOGETokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetDOGEChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   100000000,
+				},
+				ChainParam:    btcrelaying.DOGETestNetParams,
+				PortalTokenID: TestnetPortalV4DOGEID,
+			},
+		},
+		TestnetPortalV4BCHID: portaltokensv4.PortalBCHTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetBCHChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   546,
+				},
+				ChainParam:    btcrelaying.BCHTestNet3Params,
+				PortalTokenID: TestnetPortalV4BCHID,
+			},
+		},
 	}
 }
 
@@ -554,6 +908,51 @@ func initPortalTokensV4ForTestNet2() map[string]portaltokensv4.PortalTokenProces
 			ChainParam:    &chaincfg.TestNet3Params,
 			PortalTokenID: Testnet2PortalV4BTCID,
 		},
+		Testnet2PortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             Testnet2LTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   192,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   51200,
+					ExternalDustLimit:   5460,
+				},
+				ChainParam:    btcrelaying.LTCTestNet4Params,
+				PortalTokenID: Testnet2PortalV4LTCID,
+			},
+		},
+		Testnet2PortalV4DOGEID: portaltokensv4.PortalDOGETokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             Testnet2DOGEChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   100000000,
+				},
+				ChainParam:    btcrelaying.DOGETestNetParams,
+				PortalTokenID: Testnet2PortalV4DOGEID,
+			},
+		},
+		Testnet2PortalV4BCHID: portaltokensv4.PortalBCHTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             Testnet2BCHChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   546,
+				},
+				ChainParam:    btcrelaying.BCHTestNet3Params,
+				PortalTokenID: Testnet2PortalV4BCHID,
+			},
+		},
 	}
 }
 
@@ -571,6 +970,51 @@ func initPortalTokensV4ForMainNet() map[string]portaltokensv4.PortalTokenProcess
 			ChainParam:    &chaincfg.MainNetParams,
 			PortalTokenID: MainnetPortalV4BTCID,
 		},
+		MainnetPortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             MainnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   192,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   51200,
+					ExternalDustLimit:   5460,
+				},
+				ChainParam:    btcrelaying.LTCMainNetParams,
+				PortalTokenID: MainnetPortalV4LTCID,
+			},
+		},
+		MainnetPortalV4DOGEID: portaltokensv4.PortalDOGETokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             MainnetDOGEChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   100000000,
+				},
+				ChainParam:    btcrelaying.DOGEMainNetParams,
+				PortalTokenID: MainnetPortalV4DOGEID,
+			},
+		},
+		MainnetPortalV4BCHID: portaltokensv4.PortalBCHTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             MainnetBCHChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   400,
+					ExternalOutputSize:  34,
+					ExternalTxMaxSize:   100000,
+					ExternalDustLimit:   546,
+				},
+				ChainParam:    btcrelaying.BCHMainNetParams,
+				PortalTokenID: MainnetPortalV4BCHID,
+			},
+		},
 	}
 }
 
@@ -608,9 +1052,47 @@ const (
 	MainnetBNBFullNodePort     = "443"
 	MainnetPortalFeeder        = "12RwJVcDx4SM4PvjwwPrCRPZMMRT9g6QrnQUHD54EbtDb6AQbe26ciV6JXKyt4WRuFQVqLKqUUbb7VbWxR5V6KaG9HyFbKf6CrRxhSm"
 
+	// relaying header chain of utxo chains
+	// @@Note: need to update before deploying
+	TestnetLTCChainID         = "Litecoin-Testnet"
+	TestnetLTCDataFolderName  = "ltcrelayingv1"
+	TestnetDOGEChainID        = "Dogecoin-Testnet"
+	TestnetDOGEDataFolderName = "dogerelayingv1"
+	TestnetBCHChainID         = "BitcoinCash-Testnet"
+	TestnetBCHDataFolderName  = "bchrelayingv1"
+
+	Testnet2LTCChainID         = "Litecoin-Testnet-2"
+	Testnet2LTCDataFolderName  = "ltcrelayingv1"
+	Testnet2DOGEChainID        = "Dogecoin-Testnet-2"
+	Testnet2DOGEDataFolderName = "dogerelayingv1"
+	Testnet2BCHChainID         = "BitcoinCash-Testnet-2"
+	Testnet2BCHDataFolderName  = "bchrelayingv1"
+
+	MainnetLTCChainID         = "Litecoin-Mainnet"
+	MainnetLTCDataFolderName  = "ltcrelayingv1"
+	MainnetDOGEChainID        = "Dogecoin-Mainnet"
+	MainnetDOGEDataFolderName = "dogerelayingv1"
+	MainnetBCHChainID         = "BitcoinCash-Mainnet"
+	MainnetBCHDataFolderName  = "bchrelayingv1"
+
 	// portal token v4
 	LocalPortalV4BTCID    = "ef5947f70ead81a76a53c7c8b7317dd5245510c665d3a13921dc9a581188728b"
 	TestnetPortalV4BTCID  = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
 	Testnet2PortalV4BTCID = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
 	MainnetPortalV4BTCID  = "b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"
+
+	LocalPortalV4LTCID    = "c1fd8911916c928187de5b3a0da1d31276412d221c56c04f5c36091e8f78cd7d"
+	TestnetPortalV4LTCID  = "7542c4c35c7333b64f6e20c246780ec84c615b7d021c6cdad8bfbda70d3668de"
+	Testnet2PortalV4LTCID = "e2d8987d93db4ce4bf009d8394e061153c254c0283b6e75030c2339b4054ce33"
+	MainnetPortalV4LTCID  = "9487b8513c19671470367ef8092e938eafd44d9671ecfa5de91f1f19d0564896"
+
+	LocalPortalV4DOGEID    = "23afe5a52b9397d237a71d4e068f59b66d96206fa1e88a015b767e04e494b17e"
+	TestnetPortalV4DOGEID  = "441653a02b85f6651790dedd270b6743783024b1884ffbbd97768cc2d37fec13"
+	Testnet2PortalV4DOGEID = "eefda6c30765df37ea7bcd676f7cc488d79b49af9afe294f63c59b286fc4cfaf"
+	MainnetPortalV4DOGEID  = "a80a6737b6cb83d069da3e3ea531f8540d33477eee6d2205542aeef74629b916"
+
+	LocalPortalV4BCHID    = "9eb0a4e4267a9c8b7e8a5c738c69765080d98b1389e437dabf1df5e0e722facf"
+	TestnetPortalV4BCHID  = "3251cc187673c97e9c4dc782fcdca3eb09b468e24951323fa4e269dcc9c98e53"
+	Testnet2PortalV4BCHID = "c8c5a1ae58613521de5c91881410df0d8dc94311b3fad46a0873ecde1b28289f"
+	MainnetPortalV4BCHID  = "0af84782d4f31bbb3897bd95d41e6281284062c39fc8eeb2d73b857eef3006ec"
 )
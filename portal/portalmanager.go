@@ -26,9 +26,28 @@ func NewPortalManager() *PortalManager {
 		},
 	}
 
+	rltcChain := &portalrelaying.RelayingLTCChain{
+		RelayingChain: &portalrelaying.RelayingChain{
+			Actions: [][]string{},
+		},
+	}
+	rdogeChain := &portalrelaying.RelayingDOGEChain{
+		RelayingChain: &portalrelaying.RelayingChain{
+			Actions: [][]string{},
+		},
+	}
+	rbchChain := &portalrelaying.RelayingBCHChain{
+		RelayingChain: &portalrelaying.RelayingChain{
+			Actions: [][]string{},
+		},
+	}
+
 	relayingChainProcessor := map[int]portalrelaying.RelayingProcessor{
-		metadata.RelayingBNBHeaderMeta: rbnbChain,
-		metadata.RelayingBTCHeaderMeta: rbtcChain,
+		metadata.RelayingBNBHeaderMeta:  rbnbChain,
+		metadata.RelayingBTCHeaderMeta:  rbtcChain,
+		metadata.RelayingLTCHeaderMeta:  rltcChain,
+		metadata.RelayingDOGEHeaderMeta: rdogeChain,
+		metadata.RelayingBCHHeaderMeta:  rbchChain,
 	}
 
 	portalInstProcessorV3 := map[int]portalprocessv3.PortalInstructionProcessorV3{
@@ -182,6 +201,16 @@ func NewPortalManager() *PortalManager {
 				Actions: map[byte][][]string{},
 			},
 		},
+		metadataCommon.PortalV4VaultRotationRequestMeta: &portalprocessv4.PortalVaultRotationRequestProcessor{
+			PortalInstProcessorV4: &portalprocessv4.PortalInstProcessorV4{
+				Actions: map[byte][][]string{},
+			},
+		},
+		metadataCommon.PortalV4VaultConsolidationMeta: &portalprocessv4.PortalVaultConsolidationProcessor{
+			PortalInstProcessorV4: &portalprocessv4.PortalInstProcessorV4{
+				Actions: map[byte][][]string{},
+			},
+		},
 	}
 
 	return &PortalManager{
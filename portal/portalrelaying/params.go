@@ -1,10 +1,16 @@
 package portalrelaying
 
 type RelayingParams struct {
-	BNBRelayingHeaderChainID string
-	BTCRelayingHeaderChainID string
-	BTCDataFolderName        string
-	BNBFullNodeProtocol      string
-	BNBFullNodeHost          string
-	BNBFullNodePort          string
-}
\ No newline at end of file
+	BNBRelayingHeaderChainID  string
+	BTCRelayingHeaderChainID  string
+	BTCDataFolderName         string
+	LTCRelayingHeaderChainID  string
+	LTCDataFolderName         string
+	DOGERelayingHeaderChainID string
+	DOGEDataFolderName        string
+	BCHRelayingHeaderChainID  string
+	BCHDataFolderName         string
+	BNBFullNodeProtocol       string
+	BNBFullNodeHost           string
+	BNBFullNodePort           string
+}
@@ -37,6 +37,15 @@ type RelayingBNBChain struct {
 type RelayingBTCChain struct {
 	*RelayingChain
 }
+type RelayingLTCChain struct {
+	*RelayingChain
+}
+type RelayingDOGEChain struct {
+	*RelayingChain
+}
+type RelayingBCHChain struct {
+	*RelayingChain
+}
 
 func (rChain *RelayingChain) GetActions() [][]string {
 	return rChain.Actions
@@ -150,4 +159,45 @@ func (rbtcChain *RelayingBTCChain) BuildRelayingInst(
 		RelayingHeaderConsideringChainStatus,
 	)
 	return [][]string{inst}
-}
\ No newline at end of file
+}
+
+// buildConsideringRelayingInst builds the instruction of a header which is validated when the beacon processes it
+func (rChain *RelayingChain) buildConsideringRelayingInst(relayingHeaderAction metadata.RelayingHeaderAction) [][]string {
+	inst := rChain.BuildHeaderRelayingInst(
+		relayingHeaderAction.Meta.IncogAddressStr,
+		relayingHeaderAction.Meta.Header,
+		relayingHeaderAction.Meta.BlockHeight,
+		relayingHeaderAction.Meta.Type,
+		relayingHeaderAction.ShardID,
+		relayingHeaderAction.TxReqID,
+		RelayingHeaderConsideringChainStatus,
+	)
+	return [][]string{inst}
+}
+
+func (rltcChain *RelayingLTCChain) BuildRelayingInst(
+	bc metadata.ChainRetriever,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+) [][]string {
+	Logger.log.Info("[LTC Relaying] - Processing buildRelayingInst...")
+	return rltcChain.buildConsideringRelayingInst(relayingHeaderAction)
+}
+
+func (rdogeChain *RelayingDOGEChain) BuildRelayingInst(
+	bc metadata.ChainRetriever,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+) [][]string {
+	Logger.log.Info("[DOGE Relaying] - Processing buildRelayingInst...")
+	return rdogeChain.buildConsideringRelayingInst(relayingHeaderAction)
+}
+
+func (rbchChain *RelayingBCHChain) BuildRelayingInst(
+	bc metadata.ChainRetriever,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+) [][]string {
+	Logger.log.Info("[BCH Relaying] - Processing buildRelayingInst...")
+	return rbchChain.buildConsideringRelayingInst(relayingHeaderAction)
+}
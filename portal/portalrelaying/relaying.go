@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sort"
 	"strconv"
 
@@ -17,10 +18,13 @@ import (
 )
 
 // RelayingHeaderChainState is state of relaying header chains
-// include btc and bnb header chain
+// include btc, bnb, ltc, doge and bch header chain
 type RelayingHeaderChainState struct {
-	BNBHeaderChain *bnbrelaying.BNBChainState
-	BTCHeaderChain *btcrelaying.BlockChain
+	BNBHeaderChain  *bnbrelaying.BNBChainState
+	BTCHeaderChain  *btcrelaying.BlockChain
+	LTCHeaderChain  *btcrelaying.BlockChain
+	DOGEHeaderChain *btcrelaying.BlockChain
+	BCHHeaderChain  *btcrelaying.BlockChain
 }
 
 /*
@@ -124,6 +128,12 @@ func ProcessRelayingInstructions(instructions [][]string, relayingState *Relayin
 		//	err = blockchain.processRelayingBNBHeaderInst(inst, relayingState)
 		case strconv.Itoa(metadata.RelayingBTCHeaderMeta):
 			err = ProcessRelayingBTCHeaderInst(inst, relayingState)
+		case strconv.Itoa(metadata.RelayingLTCHeaderMeta):
+			err = processRelayingUTXOHeaderInst(inst, relayingState.LTCHeaderChain, "LTC")
+		case strconv.Itoa(metadata.RelayingDOGEHeaderMeta):
+			err = processRelayingUTXOHeaderInst(inst, relayingState.DOGEHeaderChain, "DOGE")
+		case strconv.Itoa(metadata.RelayingBCHHeaderMeta):
+			err = processRelayingUTXOHeaderInst(inst, relayingState.BCHHeaderChain, "BCH")
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -176,3 +186,53 @@ func ProcessRelayingBTCHeaderInst(
 	Logger.log.Infof("ProcessBlock (%s) success with result: isMainChain: %v, isOrphan: %v", block.Hash(), isMainChain, isOrphan)
 	return nil
 }
+
+// processRelayingUTXOHeaderInst appends a header to the relaying header chain of a Bitcoin-derived chain.
+// Headers of merged mined chains come with their auxpow in an AuxPowRelayingBlock.
+func processRelayingUTXOHeaderInst(
+	instruction []string,
+	headerChain *btcrelaying.BlockChain,
+	chainName string,
+) error {
+	Logger.log.Infof("[%v Relaying] - Processing processRelayingUTXOHeaderInst...", chainName)
+	if headerChain == nil {
+		return fmt.Errorf("[processRelayingUTXOHeaderInst] %v Header chain instance should not be nil", chainName)
+	}
+
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+
+	var relayingHeaderContent metadata.RelayingHeaderContent
+	err := json.Unmarshal([]byte(instruction[3]), &relayingHeaderContent)
+	if err != nil {
+		return err
+	}
+
+	headerBytes, err := base64.StdEncoding.DecodeString(relayingHeaderContent.Header)
+	if err != nil {
+		return err
+	}
+	var relayingBlk btcrelaying.AuxPowRelayingBlock
+	err = json.Unmarshal(headerBytes, &relayingBlk)
+	if err != nil {
+		return err
+	}
+	block := btcutil.NewBlock(&relayingBlk.MsgBlock)
+	var isMainChain, isOrphan bool
+	if relayingBlk.AuxPow != "" {
+		auxPow, err := btcrelaying.ParseAuxPow(relayingBlk.AuxPow)
+		if err != nil {
+			return err
+		}
+		isMainChain, isOrphan, err = headerChain.ProcessAuxPowBlockV2(block, auxPow, btcrelaying.BFNone)
+	} else {
+		isMainChain, isOrphan, err = headerChain.ProcessBlockV2(block, btcrelaying.BFNone)
+	}
+	if err != nil {
+		Logger.log.Errorf("ProcessBlock fail with error: %v", err)
+		return err
+	}
+	Logger.log.Infof("ProcessBlock (%s) success with result: isMainChain: %v, isOrphan: %v", block.Hash(), isMainChain, isOrphan)
+	return nil
+}
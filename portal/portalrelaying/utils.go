@@ -8,10 +8,19 @@ import (
 	bnbTypes "github.com/tendermint/tendermint/types"
 )
 
-func InitRelayingHeaderChainStateFromDB(bnbChain *bnbrelaying.BNBChainState, btcChain *btcrelaying.BlockChain) (*RelayingHeaderChainState, error) {
+func InitRelayingHeaderChainStateFromDB(
+	bnbChain *bnbrelaying.BNBChainState,
+	btcChain *btcrelaying.BlockChain,
+	ltcChain *btcrelaying.BlockChain,
+	dogeChain *btcrelaying.BlockChain,
+	bchChain *btcrelaying.BlockChain,
+) (*RelayingHeaderChainState, error) {
 	return &RelayingHeaderChainState{
-		BNBHeaderChain: bnbChain,
-		BTCHeaderChain: btcChain,
+		BNBHeaderChain:  bnbChain,
+		BTCHeaderChain:  btcChain,
+		LTCHeaderChain:  ltcChain,
+		DOGEHeaderChain: dogeChain,
+		BCHHeaderChain:  bchChain,
 	}, nil
 }
 
@@ -33,6 +33,28 @@ type PortalParams struct {
 	PortalFeederAddress          string
 	PortalETHContractAddressStr  string // smart contract of ETH for portal
 	MinUnlockOverRateCollaterals uint64
+	ExchangeRateOracle           ExchangeRateOracleParams
+}
+
+// ExchangeRateOracleParams configures the aggregation of the exchange rates pushed by a set of feeders,
+// the oracle is disabled (PortalFeederAddress is the only feeder) while FeederAddresses is empty
+type ExchangeRateOracleParams struct {
+	FeederAddresses     []string // payment addresses allowed to push exchange rates
+	WindowBlocks        uint64   // number of beacon blocks a submission is taken into account
+	MinFeeders          int      // minimum number of feeders reporting a token within the window, the rate is stale below
+	MaxDeviationPercent uint64   // a submission deviating more from the final rate is rejected
+}
+
+func (p PortalParams) IsExchangeRateOracleEnabled() bool {
+	return len(p.ExchangeRateOracle.FeederAddresses) > 0
+}
+
+// GetFeederAddresses returns the payment addresses allowed to push exchange rates
+func (p PortalParams) GetFeederAddresses() []string {
+	if p.IsExchangeRateOracleEnabled() {
+		return p.ExchangeRateOracle.FeederAddresses
+	}
+	return []string{p.PortalFeederAddress}
 }
 
 func (p PortalParams) GetSupportedCollateralTokenIDs() []string {
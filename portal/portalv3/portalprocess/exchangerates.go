@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/portal/portalv3"
 	pCommon "github.com/incognitochain/incognito-chain/portal/portalv3/common"
+	"math/big"
 	"sort"
 	"strconv"
 )
@@ -56,17 +57,34 @@ func (p *PortalExchangeRateProcessor) BuildNewInsts(
 		return [][]string{}, nil
 	}
 
+	// drop the rates deviating too much from the final rates when the oracle is enabled
+	rates := actionData.Meta.Rates
+	if portalParams.IsExchangeRateOracleEnabled() {
+		rates = filterOutlierExchangeRates(rates, currentPortalState.FinalExchangeRatesState, portalParams.ExchangeRateOracle)
+	}
+
 	// create new instruction for request pushing the exchange rates
 	metaType := actionData.Meta.Type
 	portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
 		SenderAddress: actionData.Meta.SenderAddress,
-		Rates:         actionData.Meta.Rates,
+		Rates:         rates,
 		TxReqID:       actionData.TxReqID,
 		LockTime:      actionData.LockTime,
 	}
 
 	portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
 
+	if len(rates) == 0 {
+		Logger.log.Warnf("WARNING: all exchange rates of request %v deviate from the final exchange rates", actionData.TxReqID.String())
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			pCommon.PortalRequestRejectedChainStatus,
+			string(portalExchangeRatesContentBytes),
+		}
+		return [][]string{inst}, nil
+	}
+
 	inst := []string{
 		strconv.Itoa(metaType),
 		strconv.Itoa(int(shardID)),
@@ -80,7 +98,7 @@ func (p *PortalExchangeRateProcessor) BuildNewInsts(
 	}
 	exchangeRateRequests[actionData.TxReqID.String()] = metadata.NewExchangeRatesRequestStatus(
 		actionData.Meta.SenderAddress,
-		actionData.Meta.Rates,
+		rates,
 	)
 	currentPortalState.ExchangeRatesRequests = exchangeRateRequests
 
@@ -136,7 +154,6 @@ func (p *PortalExchangeRateProcessor) ProcessInsts(
 	return nil
 }
 
-
 func PickExchangesRatesFinal(currentPortalState *CurrentPortalState) {
 	// sort exchange rate requests by rate
 	sumRates := map[string][]uint64{}
@@ -165,4 +182,109 @@ func PickExchangesRatesFinal(currentPortalState *CurrentPortalState) {
 		}
 	}
 	currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(updateFinalExchangeRates)
-}
\ No newline at end of file
+}
+
+// filterOutlierExchangeRates returns the rates deviating at most MaxDeviationPercent from the final rates,
+// a token without final rate or with a stale one accepts any rate so that the oracle can recover
+func filterOutlierExchangeRates(
+	rates []*metadata.ExchangeRateInfo,
+	finalExchangeRates *statedb.FinalExchangeRatesState,
+	oracleParams portalv3.ExchangeRateOracleParams,
+) []*metadata.ExchangeRateInfo {
+	finalRates := map[string]statedb.FinalExchangeRatesDetail{}
+	if finalExchangeRates != nil {
+		finalRates = finalExchangeRates.Rates()
+	}
+
+	res := []*metadata.ExchangeRateInfo{}
+	for _, rate := range rates {
+		finalRate, ok := finalRates[rate.PTokenID]
+		if !ok || finalRate.Amount == 0 || finalRate.IsStale {
+			res = append(res, rate)
+			continue
+		}
+		deviation := new(big.Int).Sub(new(big.Int).SetUint64(rate.Rate), new(big.Int).SetUint64(finalRate.Amount))
+		deviation.Abs(deviation).Mul(deviation, big.NewInt(100))
+		maxDeviation := new(big.Int).Mul(new(big.Int).SetUint64(finalRate.Amount), new(big.Int).SetUint64(oracleParams.MaxDeviationPercent))
+		if deviation.Cmp(maxDeviation) > 0 {
+			Logger.log.Warnf("WARNING: exchange rate %v of token %v deviates more than %v%% from the final rate %v",
+				rate.Rate, rate.PTokenID, oracleParams.MaxDeviationPercent, finalRate.Amount)
+			continue
+		}
+		res = append(res, rate)
+	}
+	return res
+}
+
+// AggregateExchangeRatesByOracle records the exchange rates accepted at beaconHeight as the last submissions
+// of their feeders and picks the final rate of each token as the median of the submissions within the window.
+// A token reported by less than MinFeeders feeders within the window is marked stale and keeps its last rate.
+func AggregateExchangeRatesByOracle(
+	currentPortalState *CurrentPortalState,
+	beaconHeight uint64,
+	oracleParams portalv3.ExchangeRateOracleParams,
+) {
+	// copy the final rates so that the state of the previous block is not updated
+	updateFinalExchangeRates := map[string]statedb.FinalExchangeRatesDetail{}
+	if currentPortalState.FinalExchangeRatesState != nil {
+		for tokenID, detail := range currentPortalState.FinalExchangeRatesState.Rates() {
+			submissions := make(map[string]statedb.ExchangeRateSubmission, len(detail.Submissions))
+			for feeder, submission := range detail.Submissions {
+				submissions[feeder] = submission
+			}
+			detail.Submissions = submissions
+			updateFinalExchangeRates[tokenID] = detail
+		}
+	}
+
+	// a feeder pushing several requests in a block keeps the one with the greatest tx id
+	reqIDs := make([]string, 0, len(currentPortalState.ExchangeRatesRequests))
+	for reqID := range currentPortalState.ExchangeRatesRequests {
+		reqIDs = append(reqIDs, reqID)
+	}
+	sort.Strings(reqIDs)
+	for _, reqID := range reqIDs {
+		req := currentPortalState.ExchangeRatesRequests[reqID]
+		for _, rate := range req.Rates {
+			detail := updateFinalExchangeRates[rate.PTokenID]
+			if detail.Submissions == nil {
+				detail.Submissions = map[string]statedb.ExchangeRateSubmission{}
+			}
+			detail.Submissions[req.SenderAddress] = statedb.ExchangeRateSubmission{
+				Rate:         rate.Rate,
+				BeaconHeight: beaconHeight,
+			}
+			updateFinalExchangeRates[rate.PTokenID] = detail
+		}
+	}
+
+	windowBlocks := oracleParams.WindowBlocks
+	if windowBlocks == 0 {
+		windowBlocks = 1
+	}
+	for tokenID, detail := range updateFinalExchangeRates {
+		rates := []uint64{}
+		for feeder, submission := range detail.Submissions {
+			if submission.BeaconHeight+windowBlocks <= beaconHeight {
+				delete(detail.Submissions, feeder)
+				continue
+			}
+			rates = append(rates, submission.Rate)
+		}
+		if len(detail.Submissions) == 0 {
+			detail.Submissions = nil
+		}
+
+		if len(rates) == 0 || len(rates) < oracleParams.MinFeeders {
+			detail.IsStale = true
+		} else {
+			sort.Slice(rates, func(i, j int) bool {
+				return rates[i] < rates[j]
+			})
+			detail.Amount = calcMedian(rates)
+			detail.IsStale = false
+		}
+		updateFinalExchangeRates[tokenID] = detail
+	}
+	currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(updateFinalExchangeRates)
+}
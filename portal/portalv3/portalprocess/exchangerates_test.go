@@ -0,0 +1,88 @@
+package portalprocess
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/portal/portalv3"
+	pCommon "github.com/incognitochain/incognito-chain/portal/portalv3/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func TestAggregateExchangeRatesByOracle(t *testing.T) {
+	oracleParams := portalv3.ExchangeRateOracleParams{
+		FeederAddresses:     []string{"feeder1", "feeder2", "feeder3"},
+		WindowBlocks:        10,
+		MinFeeders:          2,
+		MaxDeviationPercent: 20,
+	}
+	currentPortalState := &CurrentPortalState{
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+			common.PRVIDStr: {Amount: 1000000},
+		}),
+		ExchangeRatesRequests: map[string]*metadata.ExchangeRatesRequestStatus{
+			"tx1": metadata.NewExchangeRatesRequestStatus("feeder1", []*metadata.ExchangeRateInfo{
+				{PTokenID: common.PRVIDStr, Rate: 1100000},
+				{PTokenID: pCommon.PortalBTCIDStr, Rate: 10000000000},
+			}),
+			"tx2": metadata.NewExchangeRatesRequestStatus("feeder2", []*metadata.ExchangeRateInfo{
+				{PTokenID: common.PRVIDStr, Rate: 1300000},
+			}),
+		},
+	}
+
+	// PRV has 2 feeders, BTC only 1
+	AggregateExchangeRatesByOracle(currentPortalState, 100, oracleParams)
+	rates := currentPortalState.FinalExchangeRatesState.Rates()
+	assert.Equal(t, uint64(1200000), rates[common.PRVIDStr].Amount)
+	assert.False(t, rates[common.PRVIDStr].IsStale)
+	assert.True(t, rates[pCommon.PortalBTCIDStr].IsStale)
+	assert.Equal(t, 1, len(rates[pCommon.PortalBTCIDStr].Submissions))
+
+	// feeder3 reports BTC within the window
+	currentPortalState.ExchangeRatesRequests = map[string]*metadata.ExchangeRatesRequestStatus{
+		"tx3": metadata.NewExchangeRatesRequestStatus("feeder3", []*metadata.ExchangeRateInfo{
+			{PTokenID: pCommon.PortalBTCIDStr, Rate: 12000000000},
+		}),
+	}
+	AggregateExchangeRatesByOracle(currentPortalState, 105, oracleParams)
+	rates = currentPortalState.FinalExchangeRatesState.Rates()
+	assert.Equal(t, uint64(11000000000), rates[pCommon.PortalBTCIDStr].Amount)
+	assert.False(t, rates[pCommon.PortalBTCIDStr].IsStale)
+	assert.Equal(t, uint64(1200000), rates[common.PRVIDStr].Amount)
+
+	// submissions of height 100 leave the window, PRV keeps its last rate but is stale
+	currentPortalState.ExchangeRatesRequests = nil
+	AggregateExchangeRatesByOracle(currentPortalState, 110, oracleParams)
+	rates = currentPortalState.FinalExchangeRatesState.Rates()
+	assert.Equal(t, uint64(1200000), rates[common.PRVIDStr].Amount)
+	assert.True(t, rates[common.PRVIDStr].IsStale)
+	assert.Nil(t, rates[common.PRVIDStr].Submissions)
+	assert.True(t, rates[pCommon.PortalBTCIDStr].IsStale)
+	assert.True(t, isLiquidationPausedByStaleRate(currentPortalState.FinalExchangeRatesState, portalv3.PortalParams{}, pCommon.PortalBNBIDStr))
+}
+
+func TestFilterOutlierExchangeRates(t *testing.T) {
+	oracleParams := portalv3.ExchangeRateOracleParams{MaxDeviationPercent: 20}
+	finalExchangeRates := statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+		common.PRVIDStr:        {Amount: 1000000},
+		pCommon.PortalBTCIDStr: {Amount: 10000000000, IsStale: true},
+	})
+	rates := []*metadata.ExchangeRateInfo{
+		{PTokenID: common.PRVIDStr, Rate: 1300000},
+		{PTokenID: pCommon.PortalBTCIDStr, Rate: 50000000000},
+		{PTokenID: pCommon.PortalBNBIDStr, Rate: 40000000},
+	}
+	res := filterOutlierExchangeRates(rates, finalExchangeRates, oracleParams)
+	assert.Equal(t, rates[1:], res)
+
+	rates[0].Rate = 800000
+	res = filterOutlierExchangeRates(rates, finalExchangeRates, oracleParams)
+	assert.Equal(t, rates, res)
+}
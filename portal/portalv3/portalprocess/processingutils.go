@@ -276,7 +276,11 @@ func ProcessPortalInstsV3(
 	}
 
 	// pick the final exchangeRates
-	PickExchangesRatesFinal(currentPortalState)
+	if portalParams.IsExchangeRateOracleEnabled() {
+		AggregateExchangeRatesByOracle(currentPortalState, beaconHeight, portalParams.ExchangeRateOracle)
+	} else {
+		PickExchangesRatesFinal(currentPortalState)
+	}
 
 	// update info of bridge portal token
 	for _, updatingInfo := range updatingInfoByTokenID {
@@ -1243,6 +1243,22 @@ func GetTotalLockedCollateralInEpoch(featureStateDB *statedb.StateDB, lastState
 	return currentPortalState.LockedCollateralForRewards.GetTotalLockedCollateralForRewards(), nil
 }
 
+// isLiquidationPausedByStaleRate returns true if the rate of tokenID or of a collateral token is stale,
+// liquidating custodians by a stale rate could be unfair so it waits for the oracle to recover
+func isLiquidationPausedByStaleRate(
+	finalExchange *statedb.FinalExchangeRatesState,
+	portalParams portalv3.PortalParams,
+	tokenID string) bool {
+	tokenIDs := append([]string{tokenID, common.PRVIDStr}, portalParams.GetSupportedCollateralTokenIDs()...)
+	for _, id := range tokenIDs {
+		if finalExchange.IsStale(id) {
+			Logger.log.Warnf("WARNING: pause liquidation of portal token %v, the exchange rate of %v is stale", tokenID, id)
+			return true
+		}
+	}
+	return false
+}
+
 func calAndCheckTPRatio(
 	portalState *CurrentPortalState,
 	custodianState *statedb.CustodianState,
@@ -1276,6 +1292,9 @@ func calAndCheckTPRatio(
 	}
 	sort.Strings(tpListKeys)
 	for _, tokenID := range tpListKeys {
+		if isLiquidationPausedByStaleRate(finalExchange, portalParams, tokenID) {
+			continue
+		}
 		amountPubToken := holdingPubToken[tokenID]
 		amountPRV, ok := lockedAmount[tokenID]
 		if !ok {
@@ -2082,6 +2101,9 @@ func calAndCheckLiquidationRatioV3(
 	}
 	sort.Strings(tokenIDs)
 	for _, tokenID := range tokenIDs {
+		if isLiquidationPausedByStaleRate(finalExchange, portalParams, tokenID) {
+			continue
+		}
 		amountPubTokenInUSDT := totalHoldPubTokenInUSDT[tokenID]
 		amountLockedCollateralInUSDT := lockedAmount[tokenID]
 
@@ -178,17 +178,24 @@ func (p *PortalConvertVaultRequestProcessor) BuildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	// generate expected multisig address from master pubkeys and user payment address
-	_, expectedReceivedMultisigAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(
-		portalParams.MasterPubKeys[meta.TokenID], int(portalParams.NumRequiredSigs), portalcommonv4.PortalConvertVaultChainCode)
-	if err != nil {
-		Logger.log.Error("Converting Request: Could not generate multisig address - Error: %v", err)
-		return [][]string{rejectInst}, nil
+	// verify converting proof against the multisig addresses generated from the accepted key sets
+	var isValid bool
+	var listUTXO []*statedb.UTXO
+	for _, keySet := range currentPortalState.GetShieldingKeySets(meta.TokenID, beaconHeight+1, portalParams) {
+		var expectedReceivedMultisigAddress string
+		_, expectedReceivedMultisigAddress, err = portalTokenProcessor.GenerateOTMultisigAddress(
+			keySet.MasterPubKeys, int(keySet.NumRequiredSigs), portalcommonv4.PortalConvertVaultChainCode)
+		if err != nil {
+			Logger.log.Error("Converting Request: Could not generate multisig address - Error: %v", err)
+			return [][]string{rejectInst}, nil
+		}
+
+		isValid, listUTXO, err = portalTokenProcessor.ParseAndVerifyShieldProof(
+			meta.ConvertProof, bc, expectedReceivedMultisigAddress, portalcommonv4.PortalConvertVaultChainCode, 0)
+		if isValid && err == nil {
+			break
+		}
 	}
-
-	// verify shielding proof
-	isValid, listUTXO, err := portalTokenProcessor.ParseAndVerifyShieldProof(
-		meta.ConvertProof, bc, expectedReceivedMultisigAddress, portalcommonv4.PortalConvertVaultChainCode, 0)
 	if !isValid || err != nil {
 		Logger.log.Error("Converting Request: Parse proof and verify converting proof failed - Error: %v", err)
 		return [][]string{rejectInst}, nil
@@ -26,11 +26,14 @@ func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, port
 	var tokenID string
 	var hexRawExternalTx string
 	var utxos []*statedb.UTXO
+	var masterPubKeys [][]byte
+	var numRequiredSigs uint
 
 	for _, inst := range insts {
 		metaType := inst[0]
 		switch metaType {
-		case strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta):
+		case strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta),
+			strconv.Itoa(metadataCommon.PortalV4VaultConsolidationMeta):
 			{
 				// unmarshal instructions content
 				var actionData metadata.PortalUnshieldRequestBatchContent
@@ -41,6 +44,8 @@ func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, port
 				tokenID = actionData.TokenID
 				hexRawExternalTx = actionData.RawExternalTx
 				utxos = actionData.UTXOs
+				masterPubKeys = actionData.MasterPubKeys
+				numRequiredSigs = actionData.NumRequiredSigs
 			}
 		case strconv.Itoa(metadataCommon.PortalV4FeeReplacementRequestMeta):
 			{
@@ -57,6 +62,8 @@ func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, port
 				tokenID = actionData.TokenID
 				hexRawExternalTx = actionData.ExternalRawTx
 				utxos = actionData.UTXOs
+				masterPubKeys = actionData.MasterPubKeys
+				numRequiredSigs = actionData.NumRequiredSigs
 			}
 		// other cases
 		default:
@@ -72,7 +79,12 @@ func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, port
 		if portalTokenProcessor == nil {
 			return nil, fmt.Errorf("[checkAndSignPortalV4] portalTokenProcessor is nil")
 		}
-		sigs, txHash, err := portalTokenProcessor.PartSignOnRawExternalTx(seedKey, portalParam.MasterPubKeys[tokenID], int(portalParam.NumRequiredSigs), rawTxBytes, utxos)
+		// the instruction carries the key set holding the UTXOs once the vault of the token has been rotated
+		if len(masterPubKeys) == 0 {
+			masterPubKeys = portalParam.MasterPubKeys[tokenID]
+			numRequiredSigs = portalParam.NumRequiredSigs
+		}
+		sigs, txHash, err := portalTokenProcessor.PartSignOnRawExternalTx(seedKey, masterPubKeys, int(numRequiredSigs), rawTxBytes, utxos)
 		if err != nil {
 			return nil, fmt.Errorf("[checkAndSignPortalV4] Error when signing raw tx bytes: %v", err)
 		}
@@ -85,3 +97,22 @@ func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, port
 
 	return pSigs, nil
 }
+
+// HasPortalUnshieldExternalTx checks whether beacons need to sign on external txs of the instructions
+func HasPortalUnshieldExternalTx(insts [][]string) bool {
+	for _, inst := range insts {
+		if len(inst) == 0 {
+			continue
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta),
+			strconv.Itoa(metadataCommon.PortalV4VaultConsolidationMeta):
+			return true
+		case strconv.Itoa(metadataCommon.PortalV4FeeReplacementRequestMeta):
+			if len(inst) > 2 && inst[2] != portalcommonv4.PortalV4RequestRejectedChainStatus {
+				return true
+			}
+		}
+	}
+	return false
+}
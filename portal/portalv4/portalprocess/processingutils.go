@@ -142,6 +142,12 @@ func hasPortalV4Instruction(instructions [][]string) bool {
 		case strconv.Itoa(metadataCommon.PortalV4ConvertVaultRequestMeta):
 			hasPortalV4Instruction = true
 			break
+		case strconv.Itoa(metadataCommon.PortalV4VaultRotationRequestMeta):
+			hasPortalV4Instruction = true
+			break
+		case strconv.Itoa(metadataCommon.PortalV4VaultConsolidationMeta):
+			hasPortalV4Instruction = true
+			break
 		}
 	}
 	return hasPortalV4Instruction
@@ -219,6 +225,13 @@ func handleBatchingUnshieldRequests(
 	currentPortalState *CurrentPortalStateV4,
 	portalParams portalv4.PortalParams,
 	ppv4 map[int]PortalInstructionProcessorV4) ([][]string, error) {
-	return ppv4[metadataCommon.PortalV4UnshieldBatchingMeta].BuildNewInsts(
+	// consolidate the retiring UTXOs of rotated vaults before they are filtered out of the unshield batches
+	consolidationInsts, err := ppv4[metadataCommon.PortalV4VaultConsolidationMeta].BuildNewInsts(
+		bc, "", 0, currentPortalState, beaconHeight, shardHeights, portalParams, nil)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	batchUnshieldInsts, err := ppv4[metadataCommon.PortalV4UnshieldBatchingMeta].BuildNewInsts(
 		bc, "", 0, currentPortalState, beaconHeight, shardHeights, portalParams, nil)
+	return append(consolidationInsts, batchUnshieldInsts...), err
 }
@@ -198,17 +198,24 @@ func (p *PortalShieldingRequestProcessor) BuildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	// generate expected multisig address from master pubkeys and user payment address
-	_, expectedReceivedMultisigAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(
-		portalParams.MasterPubKeys[meta.TokenID], int(portalParams.NumRequiredSigs), meta.IncogAddressStr)
-	if err != nil {
-		Logger.log.Error("Shielding Request: Could not generate multisig address - Error: %v", err)
-		return [][]string{rejectInst}, nil
-	}
+	// verify shielding proof against the multisig addresses generated from the accepted key sets and user payment address
+	var isValid bool
+	var listUTXO []*statedb.UTXO
+	for _, keySet := range currentPortalState.GetShieldingKeySets(meta.TokenID, beaconHeight+1, portalParams) {
+		var expectedReceivedMultisigAddress string
+		_, expectedReceivedMultisigAddress, err = portalTokenProcessor.GenerateOTMultisigAddress(
+			keySet.MasterPubKeys, int(keySet.NumRequiredSigs), meta.IncogAddressStr)
+		if err != nil {
+			Logger.log.Error("Shielding Request: Could not generate multisig address - Error: %v", err)
+			return [][]string{rejectInst}, nil
+		}
 
-	// verify shielding proof
-	isValid, listUTXO, err := portalTokenProcessor.ParseAndVerifyShieldProof(
-		meta.ShieldingProof, bc, expectedReceivedMultisigAddress, meta.IncogAddressStr, portalParams.MinShieldAmts[meta.TokenID])
+		isValid, listUTXO, err = portalTokenProcessor.ParseAndVerifyShieldProof(
+			meta.ShieldingProof, bc, expectedReceivedMultisigAddress, meta.IncogAddressStr, portalParams.MinShieldAmts[meta.TokenID])
+		if isValid && err == nil {
+			break
+		}
+	}
 	if !isValid || err != nil {
 		Logger.log.Error("Shielding Request: Parse proof and verify shielding proof failed - Error: %v", err)
 		return [][]string{rejectInst}, nil
@@ -51,6 +51,7 @@ func buildUnshieldBatchingInst(
 	utxos []*statedb.UTXO,
 	networkFee uint,
 	beaconHeight uint64,
+	keySet *VaultKeySet,
 	metaType int,
 	status string,
 ) []string {
@@ -63,6 +64,10 @@ func buildUnshieldBatchingInst(
 		NetworkFee:    networkFee,
 		BeaconHeight:  beaconHeight,
 	}
+	if keySet != nil {
+		unshieldBatchContent.MasterPubKeys = keySet.MasterPubKeys
+		unshieldBatchContent.NumRequiredSigs = keySet.NumRequiredSigs
+	}
 	unshieldBatchContentBytes, _ := json.Marshal(unshieldBatchContent)
 	return []string{
 		strconv.Itoa(metaType),
@@ -133,6 +138,30 @@ func (p *PortalUnshieldBatchingProcessor) BuildNewInsts(
 
 		// choose waiting unshield IDs to process with current UTXOs
 		utxos := currentPortalStateV4.UTXOs[tokenID]
+		tokenBC := bc
+		var keySet *VaultKeySet
+		if currentPortalStateV4.getVaultRotation(tokenID) != nil {
+			vaultKeySet := currentPortalStateV4.GetVaultKeySet(tokenID, beaconHeight+1, portalParams)
+			keySet = &vaultKeySet
+			tokenBC = newVaultChainRetriever(bc, tokenID, vaultKeySet.GeneralMultiSigAddress)
+		}
+		if currentPortalStateV4.isActivatedVaultRotation(tokenID, beaconHeight+1) {
+			// the retiring UTXOs are spent by vault consolidation txs only
+			retiringUTXOs, err := currentPortalStateV4.GetRetiringUTXOs(tokenID, portalTokenProcessor)
+			if err != nil {
+				Logger.log.Errorf("[BatchUnshieldRequest]: Error when getting retiring UTXOs: %v - TokenID %v\n", err, tokenID)
+				continue
+			}
+			if len(retiringUTXOs) > 0 {
+				heldUTXOs := map[string]*statedb.UTXO{}
+				for key, utxo := range utxos {
+					if _, isRetiring := retiringUTXOs[key]; !isRetiring {
+						heldUTXOs[key] = utxo
+					}
+				}
+				utxos = heldUTXOs
+			}
+		}
 		dustAmount := portalTokenProcessor.ConvertIncToExternalAmount(portalParams.DustValueThreshold[tokenID])
 		batchTxs, err := portalTokenProcessor.MatchUTXOsAndUnshieldIDsNew(utxos, wReqForProcess, dustAmount,
 			portalParams.MinUTXOsInVault[tokenID])
@@ -160,7 +189,7 @@ func (p *PortalUnshieldBatchingProcessor) BuildNewInsts(
 
 			// create raw tx
 			hexRawExtTxStr, _, err := portalTokenProcessor.CreateRawExternalTx(
-				bcTx.UTXOs, outputTxs, feeUnshield, bc, beaconHeight)
+				bcTx.UTXOs, outputTxs, feeUnshield, tokenBC, beaconHeight)
 			if err != nil {
 				Logger.log.Errorf("[BatchUnshieldRequest]: Error when creating raw external tx %v", err)
 				continue
@@ -176,7 +205,7 @@ func (p *PortalUnshieldBatchingProcessor) BuildNewInsts(
 			// build new instruction with new raw external tx
 			newInst := buildUnshieldBatchingInst(
 				batchID, hexRawExtTxStr, tokenID, bcTx.UnshieldIDs, chosenUTXOs, uint(feeUnshield), beaconHeight+1,
-				metadataCommon.PortalV4UnshieldBatchingMeta, portalcommonv4.PortalV4RequestAcceptedChainStatus)
+				keySet, metadataCommon.PortalV4UnshieldBatchingMeta, portalcommonv4.PortalV4RequestAcceptedChainStatus)
 			newInsts = append(newInsts, newInst)
 		}
 	}
@@ -211,48 +240,64 @@ func (p *PortalUnshieldBatchingProcessor) ProcessInsts(
 	// get portal token procesor
 	reqStatus := instructions[2]
 	if reqStatus == portalcommonv4.PortalV4RequestAcceptedChainStatus {
-		batchUnshieldStatus := metadata.PortalUnshieldRequestBatchStatus{
-			BatchID:       actionData.BatchID,
-			TokenID:       actionData.TokenID,
-			UnshieldIDs:   actionData.UnshieldIDs,
-			UTXOs:         actionData.UTXOs,
-			RawExternalTx: actionData.RawExternalTx,
-			NetworkFees: map[uint64]metadata.ExternalFeeInfo{
-				beaconHeight + 1: {
-					NetworkFee:    actionData.NetworkFee,
-					RBFReqIncTxID: "",
-				},
-			},
-			BeaconHeight: beaconHeight + 1,
-			Status:       portalcommonv4.PortalBatchUnshieldProcessingStatus,
-		}
-
-		batchUnshieldStatusBytes, _ := json.Marshal(batchUnshieldStatus)
-		// store status of batch unshield by batchID
-		err = statedb.StorePortalBatchUnshieldRequestStatus(
-			stateDB,
-			actionData.BatchID,
-			batchUnshieldStatusBytes)
+		err = processUnshieldBatchingContent(stateDB, beaconHeight, actionData, currentPortalStateV4)
 		if err != nil {
-			Logger.log.Errorf("[ProcessBatchUnshieldRequest] Error when storing status of batch unshield requests: %v\n", err)
+			Logger.log.Errorf("[ProcessBatchUnshieldRequest] %v\n", err)
 			return nil
 		}
+	}
 
-		// add new processed batch unshield request to batch unshield list
-		// remove waiting unshield request from waiting list
-		currentPortalStateV4.UpdatePortalStateAfterProcessBatchUnshieldRequest(
-			actionData.BatchID, actionData.UTXOs, beaconHeight+1, actionData.NetworkFee, actionData.UnshieldIDs, actionData.TokenID)
+	return nil
+}
 
-		for _, unshieldID := range actionData.UnshieldIDs {
-			// update status of unshield request that processed
-			err := UpdateNewStatusUnshieldRequest(unshieldID, portalcommonv4.PortalUnshieldReqProcessedStatus, "", 0, stateDB)
-			if err != nil {
-				Logger.log.Errorf("[ProcessBatchUnshieldRequest] Error when updating status of unshielding request with unshieldID %v: %v\n", unshieldID, err)
-				return nil
-			}
-		}
+// processUnshieldBatchingContent stores the status of the new batch and moves its UTXOs and unshield requests to the batch
+func processUnshieldBatchingContent(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	actionData metadata.PortalUnshieldRequestBatchContent,
+	currentPortalStateV4 *CurrentPortalStateV4,
+) error {
+	batchUnshieldStatus := metadata.PortalUnshieldRequestBatchStatus{
+		BatchID:       actionData.BatchID,
+		TokenID:       actionData.TokenID,
+		UnshieldIDs:   actionData.UnshieldIDs,
+		UTXOs:         actionData.UTXOs,
+		RawExternalTx: actionData.RawExternalTx,
+		NetworkFees: map[uint64]metadata.ExternalFeeInfo{
+			beaconHeight + 1: {
+				NetworkFee:    actionData.NetworkFee,
+				RBFReqIncTxID: "",
+			},
+		},
+		BeaconHeight: beaconHeight + 1,
+		Status:       portalcommonv4.PortalBatchUnshieldProcessingStatus,
+
+		MasterPubKeys:   actionData.MasterPubKeys,
+		NumRequiredSigs: actionData.NumRequiredSigs,
+	}
+
+	batchUnshieldStatusBytes, _ := json.Marshal(batchUnshieldStatus)
+	// store status of batch unshield by batchID
+	err := statedb.StorePortalBatchUnshieldRequestStatus(
+		stateDB,
+		actionData.BatchID,
+		batchUnshieldStatusBytes)
+	if err != nil {
+		return fmt.Errorf("Error when storing status of batch unshield requests: %v", err)
 	}
 
+	// add new processed batch unshield request to batch unshield list
+	// remove waiting unshield request from waiting list
+	currentPortalStateV4.UpdatePortalStateAfterProcessBatchUnshieldRequest(
+		actionData.BatchID, actionData.UTXOs, beaconHeight+1, actionData.NetworkFee, actionData.UnshieldIDs, actionData.TokenID)
+
+	for _, unshieldID := range actionData.UnshieldIDs {
+		// update status of unshield request that processed
+		err := UpdateNewStatusUnshieldRequest(unshieldID, portalcommonv4.PortalUnshieldReqProcessedStatus, "", 0, stateDB)
+		if err != nil {
+			return fmt.Errorf("Error when updating status of unshielding request with unshieldID %v: %v", unshieldID, err)
+		}
+	}
 	return nil
 }
 
@@ -325,6 +370,8 @@ func (p *PortalFeeReplacementRequestProcessor) PrepareDataForBlockProducer(
 
 	optionalData := make(map[string]interface{})
 	optionalData["outputs"] = outputs
+	optionalData["masterPubKeys"] = processedUnshieldRequestBatch.MasterPubKeys
+	optionalData["numRequiredSigs"] = processedUnshieldRequestBatch.NumRequiredSigs
 	return optionalData, nil
 }
 
@@ -337,6 +384,7 @@ func buildReplacementFeeRequestInst(
 	shardID byte,
 	externalRawTx string,
 	utxos []*statedb.UTXO,
+	keySet *VaultKeySet,
 	txReqID common.Hash,
 	status string,
 ) []string {
@@ -348,6 +396,10 @@ func buildReplacementFeeRequestInst(
 		ExternalRawTx: externalRawTx,
 		UTXOs:         utxos,
 	}
+	if keySet != nil {
+		replacementRequestContent.MasterPubKeys = keySet.MasterPubKeys
+		replacementRequestContent.NumRequiredSigs = keySet.NumRequiredSigs
+	}
 	replacementRequestContentBytes, _ := json.Marshal(replacementRequestContent)
 	return []string{
 		strconv.Itoa(metaType),
@@ -394,6 +446,7 @@ func (p *PortalFeeReplacementRequestProcessor) BuildNewInsts(
 		actionData.ShardID,
 		"",
 		nil,
+		nil,
 		actionData.TxReqID,
 		portalcommonv4.PortalV4RequestRejectedChainStatus,
 	)
@@ -439,8 +492,27 @@ func (p *PortalFeeReplacementRequestProcessor) BuildNewInsts(
 		Logger.log.Errorf("[ReplaceFeeRequest]: UTXOs of unshield batchID - %v is empty: ", meta.BatchID)
 		return [][]string{rejectInst}, nil
 	}
+	// the replaced tx is signed by the key set holding the UTXOs of the batch
+	outputs := optionalData["outputs"].([]*portaltokens.OutputTx)
+	var keySet *VaultKeySet
+	masterPubKeys, _ := optionalData["masterPubKeys"].([][]byte)
+	numRequiredSigs, _ := optionalData["numRequiredSigs"].(uint)
+	if len(masterPubKeys) > 0 {
+		keySet = &VaultKeySet{
+			MasterPubKeys:   masterPubKeys,
+			NumRequiredSigs: numRequiredSigs,
+		}
+	}
+	tokenBC := bc
+	if vaultRotation := currentPortalV4State.getVaultRotation(tokenIDStr); vaultRotation != nil {
+		if currentPortalV4State.isPendingVaultConsolidation(tokenIDStr, meta.BatchID) {
+			outputs = getVaultConsolidationOutputs(vaultRotation, unshieldBatch.GetUTXOs(), portalTokenProcessor)
+		}
+		vaultKeySet := currentPortalV4State.GetVaultKeySet(tokenIDStr, beaconHeight+1, portalParams)
+		tokenBC = newVaultChainRetriever(bc, tokenIDStr, vaultKeySet.GeneralMultiSigAddress)
+	}
 	hexRawExtTxStr, _, err := portalTokenProcessor.CreateRawExternalTx(
-		unshieldBatch.GetUTXOs(), optionalData["outputs"].([]*portaltokens.OutputTx), uint64(meta.Fee), bc, beaconHeight)
+		unshieldBatch.GetUTXOs(), outputs, uint64(meta.Fee), tokenBC, beaconHeight)
 	if err != nil {
 		Logger.log.Errorf("[ReplaceFeeRequest]: an error occured create new raw transaction portal replacement fee: %+v", err)
 		return nil, fmt.Errorf("[ReplaceFeeRequest]: an error occured create new raw transaction portal replacement fee: %+v", err)
@@ -455,6 +527,7 @@ func (p *PortalFeeReplacementRequestProcessor) BuildNewInsts(
 		actionData.ShardID,
 		hexRawExtTxStr,
 		unshieldBatch.GetUTXOs(),
+		keySet,
 		actionData.TxReqID,
 		portalcommonv4.PortalV4RequestAcceptedChainStatus,
 	)
@@ -708,18 +781,34 @@ func (p *PortalSubmitConfirmedTxProcessor) BuildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	expectedReceivedMultisigAddress := portalParams.GeneralMultiSigAddresses[tokenIDStr]
+	expectedReceivedMultisigAddress := currentPortalV4State.GetVaultKeySet(tokenIDStr, beaconHeight+1, portalParams).GeneralMultiSigAddress
 	outputs := optionalData["outputs"].([]*portaltokens.OutputTx)
 	if len(unshieldBatch.GetUTXOs()) == 0 {
 		Logger.log.Errorf("[SubmitConfirmedRequest]: UTXOs of unshield batchID - %v is empty: ", meta.BatchID)
 		return [][]string{rejectInst}, nil
 	}
+	isPendingVaultConsolidation := currentPortalV4State.isPendingVaultConsolidation(tokenIDStr, batchIDStr)
+	if isPendingVaultConsolidation {
+		outputs = getVaultConsolidationOutputs(currentPortalV4State.getVaultRotation(tokenIDStr), unshieldBatch.GetUTXOs(), portalTokenProcessor)
+	}
 	isValid, listUTXO, externalTxID, externalFee, err := portalTokenProcessor.ParseAndVerifyUnshieldProof(
 		meta.UnshieldProof, bc, expectedReceivedMultisigAddress, "", outputs, unshieldBatch.GetUTXOs())
 	if !isValid || err != nil {
 		Logger.log.Errorf("[SubmitConfirmedRequest]: unshield Proof is invalid with Proof - %v, Error - %+v", meta.UnshieldProof, err)
 		return [][]string{rejectInst}, nil
 	}
+	// the change of the batches created before the activation of the vault rotation is paid to the old general address
+	vaultRotation := currentPortalV4State.getVaultRotation(tokenIDStr)
+	if currentPortalV4State.isActivatedVaultRotation(tokenIDStr, beaconHeight+1) &&
+		vaultRotation.OldGeneralMultiSigAddress != expectedReceivedMultisigAddress {
+		_, oldListUTXO, _, _, err := portalTokenProcessor.ParseAndVerifyUnshieldProof(
+			meta.UnshieldProof, bc, vaultRotation.OldGeneralMultiSigAddress, "", outputs, unshieldBatch.GetUTXOs())
+		if err != nil {
+			Logger.log.Errorf("[SubmitConfirmedRequest]: unshield Proof is invalid with Proof - %v, Error - %+v", meta.UnshieldProof, err)
+			return [][]string{rejectInst}, nil
+		}
+		listUTXO = append(listUTXO, oldListUTXO...)
+	}
 
 	// build accept instruction
 	newInst := buildSubmitConfirmedTxInst(
@@ -735,6 +824,9 @@ func (p *PortalSubmitConfirmedTxProcessor) BuildNewInsts(
 	)
 
 	// remove unshield being processed and update status
+	if isPendingVaultConsolidation {
+		currentPortalV4State.completeVaultConsolidation(tokenIDStr, batchIDStr, unshieldBatch.GetUTXOs())
+	}
 	currentPortalV4State.RemoveBatchProcessedUnshieldRequest(tokenIDStr, keyUnshieldBatchHash)
 	if len(listUTXO) > 0 {
 		currentPortalV4State.AddUTXOs(listUTXO, tokenIDStr)
@@ -776,7 +868,11 @@ func (p *PortalSubmitConfirmedTxProcessor) ProcessInsts(
 		// update unshield batch
 		keyUnshieldBatchHash := statedb.GenerateProcessedUnshieldRequestBatchObjectKey(actionData.TokenID, actionData.BatchID)
 		keyUnshieldBatch := keyUnshieldBatchHash.String()
-		unshieldRequests := currentPortalV4State.ProcessedUnshieldRequests[actionData.TokenID][keyUnshieldBatch].GetUnshieldRequests()
+		unshieldBatch := currentPortalV4State.ProcessedUnshieldRequests[actionData.TokenID][keyUnshieldBatch]
+		unshieldRequests := unshieldBatch.GetUnshieldRequests()
+		if currentPortalV4State.isPendingVaultConsolidation(actionData.TokenID, actionData.BatchID) {
+			currentPortalV4State.completeVaultConsolidation(actionData.TokenID, actionData.BatchID, unshieldBatch.GetUTXOs())
+		}
 		currentPortalV4State.RemoveBatchProcessedUnshieldRequest(actionData.TokenID, keyUnshieldBatchHash)
 		if len(actionData.UTXOs) > 0 {
 			currentPortalV4State.AddUTXOs(actionData.UTXOs, actionData.TokenID)
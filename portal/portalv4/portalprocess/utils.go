@@ -18,6 +18,7 @@ type CurrentPortalStateV4 struct {
 	ShieldingExternalTx       map[string]map[string]*statedb.ShieldingRequest              // tokenID : hash(tokenID || proofHash) : value
 	WaitingUnshieldRequests   map[string]map[string]*statedb.WaitingUnshieldRequest        // tokenID : hash(tokenID || unshieldID) : value
 	ProcessedUnshieldRequests map[string]map[string]*statedb.ProcessedUnshieldRequestBatch // tokenID : hash(tokenID || batchID) : value
+	VaultRotations            map[string]*metadata.PortalVaultRotationStatus               // tokenID : latest vault rotation
 
 	DeletedUTXOKeyHashes                 []common.Hash
 	DeletedWaitingUnshieldReqKeyHashes   []common.Hash
@@ -79,11 +80,30 @@ func InitCurrentPortalStateV4FromDB(
 		}
 	}
 
+	// load the latest vault rotations
+	vaultRotations := map[string]*metadata.PortalVaultRotationStatus{}
+	for _, tokenID := range portalParamV4.PortalV4TokenIDs {
+		vaultRotationBytes, err := statedb.GetPortalVaultRotationStatus(stateDB, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		if len(vaultRotationBytes) == 0 {
+			continue
+		}
+		var vaultRotation metadata.PortalVaultRotationStatus
+		err = json.Unmarshal(vaultRotationBytes, &vaultRotation)
+		if err != nil {
+			return nil, err
+		}
+		vaultRotations[tokenID] = &vaultRotation
+	}
+
 	return &CurrentPortalStateV4{
 		UTXOs:                     utxos,
 		ShieldingExternalTx:       nil,
 		WaitingUnshieldRequests:   waitingUnshieldRequests,
 		ProcessedUnshieldRequests: processedUnshieldRequestsBatch,
+		VaultRotations:            vaultRotations,
 
 		DeletedUTXOKeyHashes:                 []common.Hash{},
 		DeletedWaitingUnshieldReqKeyHashes:   []common.Hash{},
@@ -122,6 +142,18 @@ func StorePortalV4StateToDB(
 		}
 	}
 
+	for _, tokenID := range portalParamV4.PortalV4TokenIDs {
+		vaultRotation := currentPortalState.VaultRotations[tokenID]
+		if vaultRotation == nil {
+			continue
+		}
+		vaultRotationBytes, _ := json.Marshal(vaultRotation)
+		err = statedb.StorePortalVaultRotationStatus(stateDB, tokenID, vaultRotationBytes)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = statedb.DeleteUTXOs(stateDB, currentPortalState.DeletedUTXOKeyHashes)
 	if err != nil {
 		return err
@@ -362,6 +394,9 @@ func UpdateNewStatusBatchUnshield(
 		NetworkFees:   updateExternalFees,
 		BeaconHeight:  batchUnshield.BeaconHeight,
 		Status:        newStatus,
+
+		MasterPubKeys:   batchUnshield.MasterPubKeys,
+		NumRequiredSigs: batchUnshield.NumRequiredSigs,
 	}
 	unshieldRequestNewStatusBytes, _ := json.Marshal(unshieldRequestNewStatus)
 	err = statedb.StorePortalBatchUnshieldRequestStatus(
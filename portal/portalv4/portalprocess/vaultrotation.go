@@ -0,0 +1,598 @@
+package portalprocess
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalcommonv4 "github.com/incognitochain/incognito-chain/portal/portalv4/common"
+	"github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
+)
+
+// VaultKeySet is the multisig key set holding the vault of a portal token
+type VaultKeySet struct {
+	MasterPubKeys          [][]byte
+	NumRequiredSigs        uint
+	GeneralMultiSigAddress string
+}
+
+// GetVaultKeySet returns the key set holding the vault of tokenID at beaconHeight
+func (s *CurrentPortalStateV4) GetVaultKeySet(tokenID string, beaconHeight uint64, portalParams portalv4.PortalParams) VaultKeySet {
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation == nil {
+		return VaultKeySet{
+			MasterPubKeys:          portalParams.MasterPubKeys[tokenID],
+			NumRequiredSigs:        portalParams.NumRequiredSigs,
+			GeneralMultiSigAddress: portalParams.GeneralMultiSigAddresses[tokenID],
+		}
+	}
+	if beaconHeight < vaultRotation.ActivationHeight {
+		return VaultKeySet{
+			MasterPubKeys:          vaultRotation.OldMasterPubKeys,
+			NumRequiredSigs:        vaultRotation.OldNumRequiredSigs,
+			GeneralMultiSigAddress: vaultRotation.OldGeneralMultiSigAddress,
+		}
+	}
+	return VaultKeySet{
+		MasterPubKeys:          vaultRotation.MasterPubKeys,
+		NumRequiredSigs:        vaultRotation.NumRequiredSigs,
+		GeneralMultiSigAddress: vaultRotation.GeneralMultiSigAddress,
+	}
+}
+
+// GetShieldingKeySets returns the key sets that shields of tokenID are accepted to at beaconHeight,
+// the old key set is still accepted during the grace period of the latest vault rotation
+func (s *CurrentPortalStateV4) GetShieldingKeySets(tokenID string, beaconHeight uint64, portalParams portalv4.PortalParams) []VaultKeySet {
+	keySets := []VaultKeySet{s.GetVaultKeySet(tokenID, beaconHeight, portalParams)}
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation != nil && beaconHeight >= vaultRotation.ActivationHeight && beaconHeight < vaultRotation.GraceEndHeight {
+		keySets = append(keySets, VaultKeySet{
+			MasterPubKeys:          vaultRotation.OldMasterPubKeys,
+			NumRequiredSigs:        vaultRotation.OldNumRequiredSigs,
+			GeneralMultiSigAddress: vaultRotation.OldGeneralMultiSigAddress,
+		})
+	}
+	return keySets
+}
+
+func (s *CurrentPortalStateV4) getVaultRotation(tokenID string) *metadata.PortalVaultRotationStatus {
+	if s.VaultRotations == nil {
+		return nil
+	}
+	return s.VaultRotations[tokenID]
+}
+
+// isActivatedVaultRotation returns true when the vault of tokenID is moving to the new key set at beaconHeight
+func (s *CurrentPortalStateV4) isActivatedVaultRotation(tokenID string, beaconHeight uint64) bool {
+	vaultRotation := s.getVaultRotation(tokenID)
+	return vaultRotation != nil && beaconHeight >= vaultRotation.ActivationHeight
+}
+
+// GetRetiringUTXOs returns the vault UTXOs of tokenID that are not held by the new key set of the latest vault rotation
+func (s *CurrentPortalStateV4) GetRetiringUTXOs(
+	tokenID string, portalTokenProcessor portaltokens.PortalTokenProcessor,
+) (map[string]*statedb.UTXO, error) {
+	retiringUTXOs := map[string]*statedb.UTXO{}
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation == nil {
+		return retiringUTXOs, nil
+	}
+
+	// cache the new multisig addresses by chain code seed
+	newAddresses := map[string]string{}
+	for key, utxo := range s.UTXOs[tokenID] {
+		chainCodeSeed := utxo.GetChainCodeSeed()
+		newAddress, ok := newAddresses[chainCodeSeed]
+		if !ok {
+			var err error
+			_, newAddress, err = portalTokenProcessor.GenerateOTMultisigAddress(
+				vaultRotation.MasterPubKeys, int(vaultRotation.NumRequiredSigs), chainCodeSeed)
+			if err != nil {
+				return nil, err
+			}
+			newAddresses[chainCodeSeed] = newAddress
+		}
+		if utxo.GetWalletAddress() != newAddress {
+			retiringUTXOs[key] = utxo
+		}
+	}
+	return retiringUTXOs, nil
+}
+
+// IsVaultRotationCompleted returns true when all funds of the vault of tokenID have been moved to the new key set
+func (s *CurrentPortalStateV4) IsVaultRotationCompleted(
+	tokenID string, beaconHeight uint64, portalParams portalv4.PortalParams,
+) (bool, error) {
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation == nil {
+		return true, nil
+	}
+	// shields to the old addresses are accepted until the end of the grace period
+	if beaconHeight < vaultRotation.GraceEndHeight || len(vaultRotation.PendingBatchIDs) > 0 {
+		return false, nil
+	}
+	// the change of batches created before the activation is paid to the old general multisig address
+	for _, batch := range s.ProcessedUnshieldRequests[tokenID] {
+		if getBatchCreatedHeight(batch) < vaultRotation.ActivationHeight {
+			return false, nil
+		}
+	}
+	portalTokenProcessor := portalParams.PortalTokens[tokenID]
+	if portalTokenProcessor == nil {
+		return false, fmt.Errorf("Portal token ID %v is not supported", tokenID)
+	}
+	retiringUTXOs, err := s.GetRetiringUTXOs(tokenID, portalTokenProcessor)
+	if err != nil {
+		return false, err
+	}
+	return len(retiringUTXOs) == 0, nil
+}
+
+// getBatchCreatedHeight returns the beacon height that the unshield batch has been created at
+func getBatchCreatedHeight(batch *statedb.ProcessedUnshieldRequestBatch) uint64 {
+	createdHeight := uint64(0)
+	for height := range batch.GetExternalFees() {
+		if createdHeight == 0 || height < createdHeight {
+			createdHeight = height
+		}
+	}
+	return createdHeight
+}
+
+func (s *CurrentPortalStateV4) isPendingVaultConsolidation(tokenID string, batchID string) bool {
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation == nil {
+		return false
+	}
+	isExisted, _ := common.SliceExists(vaultRotation.PendingBatchIDs, batchID)
+	return isExisted
+}
+
+func (s *CurrentPortalStateV4) addPendingVaultConsolidation(tokenID string, batchID string) {
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation == nil {
+		return
+	}
+	vaultRotation.PendingBatchIDs = append(vaultRotation.PendingBatchIDs, batchID)
+}
+
+// completeVaultConsolidation marks the consolidation batch as completed and counts its inputs as migrated
+func (s *CurrentPortalStateV4) completeVaultConsolidation(tokenID string, batchID string, utxos []*statedb.UTXO) {
+	vaultRotation := s.getVaultRotation(tokenID)
+	if vaultRotation == nil {
+		return
+	}
+	pendingBatchIDs := []string{}
+	for _, id := range vaultRotation.PendingBatchIDs {
+		if id != batchID {
+			pendingBatchIDs = append(pendingBatchIDs, id)
+		}
+	}
+	vaultRotation.PendingBatchIDs = pendingBatchIDs
+	vaultRotation.CompletedBatchIDs = append(vaultRotation.CompletedBatchIDs, batchID)
+	for _, utxo := range utxos {
+		vaultRotation.MigratedAmount += utxo.GetOutputAmount()
+	}
+}
+
+// getVaultConsolidationOutputs returns the only output of the consolidation tx spending utxos
+func getVaultConsolidationOutputs(
+	vaultRotation *metadata.PortalVaultRotationStatus, utxos []*statedb.UTXO,
+	portalTokenProcessor portaltokens.PortalTokenProcessor,
+) []*portaltokens.OutputTx {
+	totalAmount := uint64(0)
+	for _, utxo := range utxos {
+		totalAmount += utxo.GetOutputAmount()
+	}
+	return []*portaltokens.OutputTx{
+		{
+			ReceiverAddress: vaultRotation.GeneralMultiSigAddress,
+			Amount:          portalTokenProcessor.ConvertExternalToIncAmount(totalAmount),
+		},
+	}
+}
+
+// vaultChainRetriever pays the change of external txs to the general multisig address of the current vault key set
+type vaultChainRetriever struct {
+	metadata.ChainRetriever
+	generalMultiSigAddresses map[string]string
+}
+
+func newVaultChainRetriever(bc metadata.ChainRetriever, tokenID string, generalMultiSigAddress string) metadata.ChainRetriever {
+	return &vaultChainRetriever{
+		ChainRetriever:           bc,
+		generalMultiSigAddresses: map[string]string{tokenID: generalMultiSigAddress},
+	}
+}
+
+func (bc *vaultChainRetriever) GetPortalV4GeneralMultiSigAddress(tokenIDStr string, beaconHeight uint64) string {
+	if address, ok := bc.generalMultiSigAddresses[tokenIDStr]; ok && address != "" {
+		return address
+	}
+	return bc.ChainRetriever.GetPortalV4GeneralMultiSigAddress(tokenIDStr, beaconHeight)
+}
+
+/* =======
+Portal Vault Rotation Request Processor
+======= */
+
+type PortalVaultRotationRequestProcessor struct {
+	*PortalInstProcessorV4
+}
+
+func (p *PortalVaultRotationRequestProcessor) GetActions() map[byte][][]string {
+	return p.Actions
+}
+
+func (p *PortalVaultRotationRequestProcessor) PutAction(action []string, shardID byte) {
+	_, found := p.Actions[shardID]
+	if !found {
+		p.Actions[shardID] = [][]string{action}
+	} else {
+		p.Actions[shardID] = append(p.Actions[shardID], action)
+	}
+}
+
+func (p *PortalVaultRotationRequestProcessor) PrepareDataForBlockProducer(
+	stateDB *statedb.StateDB, contentStr string,
+	portalParams portalv4.PortalParams,
+) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+// beacon build new instruction from instruction received from ShardToBeaconBlock
+func buildVaultRotationRequestInst(
+	content metadata.PortalVaultRotationRequestContent,
+	metaType int,
+	shardID byte,
+	status string,
+	errorStr string,
+) []string {
+	contentBytes, _ := json.Marshal(content)
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(contentBytes),
+		errorStr,
+	}
+}
+
+func (p *PortalVaultRotationRequestProcessor) BuildNewInsts(
+	bc metadata.ChainRetriever,
+	contentStr string,
+	shardID byte,
+	currentPortalState *CurrentPortalStateV4,
+	beaconHeight uint64,
+	shardHeights map[byte]uint64,
+	portalParams portalv4.PortalParams,
+	optionalData map[string]interface{},
+) ([][]string, error) {
+	// parse instruction
+	actionContentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("[VaultRotationRequest]: an error occurred while decoding content string of vault rotation request action: %+v", err)
+		return [][]string{}, nil
+	}
+	var actionData metadata.PortalVaultRotationRequestAction
+	err = json.Unmarshal(actionContentBytes, &actionData)
+	if err != nil {
+		Logger.log.Errorf("[VaultRotationRequest]: an error occurred while unmarshal vault rotation request action: %+v", err)
+		return [][]string{}, nil
+	}
+	meta := actionData.Meta
+
+	content := metadata.PortalVaultRotationRequestContent{
+		TokenID:          meta.TokenID,
+		MasterPubKeys:    meta.MasterPubKeys,
+		NumRequiredSigs:  meta.NumRequiredSigs,
+		ActivationHeight: meta.ActivationHeight,
+		GraceEndHeight:   meta.ActivationHeight + meta.GracePeriod,
+		TxReqID:          actionData.TxReqID,
+		ShardID:          actionData.ShardID,
+	}
+	rejectInst := buildVaultRotationRequestInst(
+		content, meta.Type, shardID, portalcommonv4.PortalV4RequestRejectedChainStatus, "")
+
+	if currentPortalState == nil {
+		Logger.log.Warn("[VaultRotationRequest]: current Portal state V4 is null.")
+		rejectInst[4] = "NullPortalState"
+		return [][]string{rejectInst}, nil
+	}
+
+	portalTokenProcessor := portalParams.PortalTokens[meta.TokenID]
+	if portalTokenProcessor == nil {
+		Logger.log.Errorf("[VaultRotationRequest]: tokenID - %v is currently not supported on Portal", meta.TokenID)
+		rejectInst[4] = "InvalidTokenID"
+		return [][]string{rejectInst}, nil
+	}
+
+	if meta.ActivationHeight <= beaconHeight+1 {
+		Logger.log.Errorf("[VaultRotationRequest]: activation height %v has been passed", meta.ActivationHeight)
+		rejectInst[4] = "InvalidActivationHeight"
+		return [][]string{rejectInst}, nil
+	}
+
+	// only one vault rotation of a token is in progress at a time
+	isCompleted, err := currentPortalState.IsVaultRotationCompleted(meta.TokenID, beaconHeight+1, portalParams)
+	if err != nil || !isCompleted {
+		Logger.log.Errorf("[VaultRotationRequest]: the previous vault rotation of tokenID %v has not been completed - Error %v", meta.TokenID, err)
+		rejectInst[4] = "RotationInProgress"
+		return [][]string{rejectInst}, nil
+	}
+
+	// the rotation must be authorized by the key set holding the vault
+	oldKeySet := currentPortalState.GetVaultKeySet(meta.TokenID, beaconHeight+1, portalParams)
+	if err := meta.VerifyVaultSigs(oldKeySet.MasterPubKeys, oldKeySet.NumRequiredSigs); err != nil {
+		Logger.log.Errorf("[VaultRotationRequest]: the rotation is not authorized by the vault key set of tokenID %v - Error: %v", meta.TokenID, err)
+		rejectInst[4] = "InvalidVaultSigs"
+		return [][]string{rejectInst}, nil
+	}
+
+	_, generalMultiSigAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(
+		meta.MasterPubKeys, int(meta.NumRequiredSigs), "")
+	if err != nil {
+		Logger.log.Errorf("[VaultRotationRequest]: could not generate the general multisig address - Error: %v", err)
+		rejectInst[4] = "InvalidMasterPubKeys"
+		return [][]string{rejectInst}, nil
+	}
+
+	content.OldMasterPubKeys = oldKeySet.MasterPubKeys
+	content.OldNumRequiredSigs = oldKeySet.NumRequiredSigs
+	content.OldGeneralMultiSigAddress = oldKeySet.GeneralMultiSigAddress
+	content.GeneralMultiSigAddress = generalMultiSigAddress
+
+	// update current portal state
+	currentPortalState.setVaultRotation(content)
+
+	newInst := buildVaultRotationRequestInst(
+		content, meta.Type, shardID, portalcommonv4.PortalV4RequestAcceptedChainStatus, "None")
+	return [][]string{newInst}, nil
+}
+
+func (s *CurrentPortalStateV4) setVaultRotation(content metadata.PortalVaultRotationRequestContent) {
+	if s.VaultRotations == nil {
+		s.VaultRotations = map[string]*metadata.PortalVaultRotationStatus{}
+	}
+	s.VaultRotations[content.TokenID] = &metadata.PortalVaultRotationStatus{
+		TokenID:                   content.TokenID,
+		TxReqID:                   content.TxReqID,
+		OldMasterPubKeys:          content.OldMasterPubKeys,
+		OldNumRequiredSigs:        content.OldNumRequiredSigs,
+		OldGeneralMultiSigAddress: content.OldGeneralMultiSigAddress,
+		MasterPubKeys:             content.MasterPubKeys,
+		NumRequiredSigs:           content.NumRequiredSigs,
+		GeneralMultiSigAddress:    content.GeneralMultiSigAddress,
+		ActivationHeight:          content.ActivationHeight,
+		GraceEndHeight:            content.GraceEndHeight,
+		PendingBatchIDs:           []string{},
+		CompletedBatchIDs:         []string{},
+	}
+}
+
+func (p *PortalVaultRotationRequestProcessor) ProcessInsts(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	instructions []string,
+	currentPortalState *CurrentPortalStateV4,
+	portalParams portalv4.PortalParams,
+	updatingInfoByTokenID map[common.Hash]metadata.UpdatingInfo,
+) error {
+	if currentPortalState == nil {
+		Logger.log.Errorf("[VaultRotationRequest]: current portal state is nil")
+		return nil
+	}
+
+	if len(instructions) != 5 {
+		return nil // skip the instruction
+	}
+
+	// unmarshal instructions content
+	var actionData metadata.PortalVaultRotationRequestContent
+	err := json.Unmarshal([]byte(instructions[3]), &actionData)
+	if err != nil {
+		Logger.log.Errorf("[VaultRotationRequest]: can not unmarshal instruction content %v - Error %+v\n", instructions[3], err)
+		return nil
+	}
+
+	var reqStatus byte
+	if instructions[2] == portalcommonv4.PortalV4RequestAcceptedChainStatus {
+		reqStatus = portalcommonv4.PortalV4RequestAcceptedStatus
+		currentPortalState.setVaultRotation(actionData)
+	} else if instructions[2] == portalcommonv4.PortalV4RequestRejectedChainStatus {
+		reqStatus = portalcommonv4.PortalV4RequestRejectedStatus
+	}
+
+	// track status of vault rotation request by txReqID
+	reqStatusData := metadata.PortalVaultRotationRequestStatus{
+		Status:           reqStatus,
+		ErrorMsg:         instructions[4],
+		TokenID:          actionData.TokenID,
+		MasterPubKeys:    actionData.MasterPubKeys,
+		NumRequiredSigs:  actionData.NumRequiredSigs,
+		ActivationHeight: actionData.ActivationHeight,
+		GraceEndHeight:   actionData.GraceEndHeight,
+		TxReqID:          actionData.TxReqID,
+	}
+	reqStatusBytes, _ := json.Marshal(reqStatusData)
+	err = statedb.StorePortalVaultRotationRequestStatus(stateDB, actionData.TxReqID.String(), reqStatusBytes)
+	if err != nil {
+		Logger.log.Errorf("[VaultRotationRequest]: an error occurred while storing status of vault rotation request: %+v", err)
+	}
+
+	return nil
+}
+
+/* =======
+Portal Vault Consolidation Processor
+======= */
+
+type PortalVaultConsolidationProcessor struct {
+	*PortalInstProcessorV4
+}
+
+func (p *PortalVaultConsolidationProcessor) GetActions() map[byte][][]string {
+	return p.Actions
+}
+
+func (p *PortalVaultConsolidationProcessor) PutAction(action []string, shardID byte) {
+	_, found := p.Actions[shardID]
+	if !found {
+		p.Actions[shardID] = [][]string{action}
+	} else {
+		p.Actions[shardID] = append(p.Actions[shardID], action)
+	}
+}
+
+func (p *PortalVaultConsolidationProcessor) PrepareDataForBlockProducer(
+	stateDB *statedb.StateDB, contentStr string,
+	portalParams portalv4.PortalParams,
+) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+// BuildNewInsts batches the retiring UTXOs of activated vault rotations into txs paying the new general multisig address,
+// the txs are signed by the old key set through the pipeline of unshield batches
+func (p *PortalVaultConsolidationProcessor) BuildNewInsts(
+	bc metadata.ChainRetriever,
+	contentStr string,
+	shardID byte,
+	currentPortalStateV4 *CurrentPortalStateV4,
+	beaconHeight uint64,
+	shardHeights map[byte]uint64,
+	portalParams portalv4.PortalParams,
+	optionalData map[string]interface{},
+) ([][]string, error) {
+	if currentPortalStateV4 == nil {
+		Logger.log.Warn("[VaultConsolidation]: Current Portal state V4 is null.")
+		return [][]string{}, nil
+	}
+
+	newInsts := [][]string{}
+	tokenIDs := []string{}
+	for tokenID := range currentPortalStateV4.VaultRotations {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+	for _, tokenID := range tokenIDs {
+		vaultRotation := currentPortalStateV4.VaultRotations[tokenID]
+		if beaconHeight+1 < vaultRotation.ActivationHeight {
+			continue
+		}
+		portalTokenProcessor := portalParams.PortalTokens[tokenID]
+		if portalTokenProcessor == nil {
+			Logger.log.Errorf("[VaultConsolidation]: Portal token ID %v is null.", tokenID)
+			continue
+		}
+		retiringUTXOs, err := currentPortalStateV4.GetRetiringUTXOs(tokenID, portalTokenProcessor)
+		if err != nil {
+			Logger.log.Errorf("[VaultConsolidation]: Error when getting retiring UTXOs of token ID %v: %v", tokenID, err)
+			continue
+		}
+		if len(retiringUTXOs) == 0 {
+			continue
+		}
+
+		// spend the largest UTXOs first so that the dust ones are merged into bigger txs
+		utxoKeys := []string{}
+		for key := range retiringUTXOs {
+			utxoKeys = append(utxoKeys, key)
+		}
+		sort.SliceStable(utxoKeys, func(i, j int) bool {
+			amtI := retiringUTXOs[utxoKeys[i]].GetOutputAmount()
+			amtJ := retiringUTXOs[utxoKeys[j]].GetOutputAmount()
+			if amtI != amtJ {
+				return amtI > amtJ
+			}
+			return utxoKeys[i] < utxoKeys[j]
+		})
+
+		chunks := [][]string{}
+		chunk := []string{}
+		for _, key := range utxoKeys {
+			if len(chunk) > 0 && !portalTokenProcessor.IsAcceptableTxSize(len(chunk)+1, 1) {
+				chunks = append(chunks, chunk)
+				chunk = []string{}
+			}
+			chunk = append(chunk, key)
+		}
+		chunks = append(chunks, chunk)
+
+		feeUnshield := portalParams.DefaultFeeUnshields[tokenID]
+		oldKeySet := VaultKeySet{
+			MasterPubKeys:   vaultRotation.OldMasterPubKeys,
+			NumRequiredSigs: vaultRotation.OldNumRequiredSigs,
+		}
+		for _, keys := range chunks {
+			chosenUTXOs := []*statedb.UTXO{}
+			for _, key := range keys {
+				chosenUTXOs = append(chosenUTXOs, retiringUTXOs[key])
+			}
+			outputTxs := getVaultConsolidationOutputs(vaultRotation, chosenUTXOs, portalTokenProcessor)
+			hexRawExtTxStr, _, err := portalTokenProcessor.CreateRawExternalTx(
+				chosenUTXOs, outputTxs, feeUnshield, bc, beaconHeight)
+			if err != nil {
+				Logger.log.Errorf("[VaultConsolidation]: Error when creating raw external tx %v", err)
+				continue
+			}
+
+			// batchId: combine beacon height and list of consolidated UTXOs
+			batchID := GetBatchID(beaconHeight+1, keys)
+
+			// update current portal state
+			currentPortalStateV4.UpdatePortalStateAfterProcessBatchUnshieldRequest(
+				batchID, chosenUTXOs, beaconHeight+1, uint(feeUnshield), []string{}, tokenID)
+			currentPortalStateV4.addPendingVaultConsolidation(tokenID, batchID)
+
+			newInst := buildUnshieldBatchingInst(
+				batchID, hexRawExtTxStr, tokenID, []string{}, chosenUTXOs, uint(feeUnshield), beaconHeight+1,
+				&oldKeySet, metadataCommon.PortalV4VaultConsolidationMeta, portalcommonv4.PortalV4RequestAcceptedChainStatus)
+			newInsts = append(newInsts, newInst)
+		}
+	}
+	return newInsts, nil
+}
+
+func (p *PortalVaultConsolidationProcessor) ProcessInsts(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	instructions []string,
+	currentPortalStateV4 *CurrentPortalStateV4,
+	portalParams portalv4.PortalParams,
+	updatingInfoByTokenID map[common.Hash]metadata.UpdatingInfo,
+) error {
+	if currentPortalStateV4 == nil {
+		Logger.log.Errorf("[VaultConsolidation]: current portal state is nil")
+		return nil
+	}
+
+	if len(instructions) != 4 {
+		return nil // skip the instruction
+	}
+
+	// unmarshal instructions content
+	var actionData metadata.PortalUnshieldRequestBatchContent
+	err := json.Unmarshal([]byte(instructions[3]), &actionData)
+	if err != nil {
+		Logger.log.Errorf("[VaultConsolidation]: Can not unmarshal instruction content %v - Error %v\n", instructions[3], err)
+		return nil
+	}
+
+	if instructions[2] == portalcommonv4.PortalV4RequestAcceptedChainStatus {
+		err = processUnshieldBatchingContent(stateDB, beaconHeight, actionData, currentPortalStateV4)
+		if err != nil {
+			Logger.log.Errorf("[VaultConsolidation]: Error when processing consolidation batch: %v\n", err)
+			return nil
+		}
+		currentPortalStateV4.addPendingVaultConsolidation(actionData.TokenID, actionData.BatchID)
+	}
+
+	return nil
+}
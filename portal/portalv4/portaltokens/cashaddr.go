@@ -0,0 +1,140 @@
+package portaltokens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// cashaddr is the address format of Bitcoin Cash: a prefix, a colon and the base32 encoding
+// of a version byte, a hash and a 40-bit BCH checksum covering the prefix
+const cashAddrCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	cashAddrTypeP2PKH = byte(0)
+	cashAddrTypeP2SH  = byte(1)
+
+	cashAddrChecksumLen = 8
+	cashAddrHashLen     = 20
+)
+
+func cashAddrPolyMod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = ((c & 0x07ffffffff) << 5) ^ uint64(d)
+		if c0&0x01 != 0 {
+			c ^= 0x98f2bc8e61
+		}
+		if c0&0x02 != 0 {
+			c ^= 0x79b76d99e2
+		}
+		if c0&0x04 != 0 {
+			c ^= 0xf33e5fb3c4
+		}
+		if c0&0x08 != 0 {
+			c ^= 0xae2eabe2a8
+		}
+		if c0&0x10 != 0 {
+			c ^= 0x1e4f43e470
+		}
+	}
+	return c ^ 1
+}
+
+func cashAddrExpandPrefix(prefix string) []byte {
+	expanded := make([]byte, len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		expanded[i] = prefix[i] & 0x1f
+	}
+	return expanded
+}
+
+// convertBits regroups the bits of data from fromBits-bit to toBits-bit words
+func convertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	acc := uint(0)
+	bits := uint(0)
+	maxV := uint(1)<<toBits - 1
+	result := []byte{}
+	for _, value := range data {
+		if uint(value)>>fromBits != 0 {
+			return nil, errors.New("Invalid data range")
+		}
+		acc = acc<<fromBits | uint(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxV))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxV))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxV != 0 {
+		return nil, errors.New("Invalid padding")
+	}
+	return result, nil
+}
+
+// encodeCashAddress encodes a 160-bit hash of an address type with a prefix
+func encodeCashAddress(prefix string, addrType byte, hash []byte) (string, error) {
+	if len(hash) != cashAddrHashLen {
+		return "", fmt.Errorf("Invalid cashaddr hash length %v", len(hash))
+	}
+	payload, err := convertBits(append([]byte{addrType << 3}, hash...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	checksumInput := append(cashAddrExpandPrefix(prefix), payload...)
+	checksumInput = append(checksumInput, make([]byte, cashAddrChecksumLen)...)
+	polyMod := cashAddrPolyMod(checksumInput)
+	for i := 0; i < cashAddrChecksumLen; i++ {
+		payload = append(payload, byte(polyMod>>(5*(cashAddrChecksumLen-1-i))&0x1f))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteByte(':')
+	for _, d := range payload {
+		sb.WriteByte(cashAddrCharset[d])
+	}
+	return sb.String(), nil
+}
+
+// decodeCashAddress decodes an address with the expected prefix into its type and 160-bit hash
+func decodeCashAddress(address string, expectedPrefix string) (byte, []byte, error) {
+	lowerAddress := strings.ToLower(address)
+	if lowerAddress != address && strings.ToUpper(address) != address {
+		return 0, nil, errors.New("Cashaddr has mixed case")
+	}
+	sepIdx := strings.LastIndexByte(lowerAddress, ':')
+	if sepIdx < 0 || lowerAddress[:sepIdx] != expectedPrefix {
+		return 0, nil, fmt.Errorf("Cashaddr %v does not have the prefix %v", address, expectedPrefix)
+	}
+
+	payload := make([]byte, 0, len(lowerAddress)-sepIdx-1)
+	for _, c := range lowerAddress[sepIdx+1:] {
+		d := strings.IndexRune(cashAddrCharset, c)
+		if d < 0 {
+			return 0, nil, fmt.Errorf("Cashaddr has an invalid character %q", c)
+		}
+		payload = append(payload, byte(d))
+	}
+	if len(payload) <= cashAddrChecksumLen {
+		return 0, nil, errors.New("Cashaddr is too short")
+	}
+	if cashAddrPolyMod(append(cashAddrExpandPrefix(expectedPrefix), payload...)) != 0 {
+		return 0, nil, errors.New("Cashaddr has an invalid checksum")
+	}
+
+	data, err := convertBits(payload[:len(payload)-cashAddrChecksumLen], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	// only 160-bit hashes (size bits 0) are used by P2PKH and P2SH
+	if len(data) != cashAddrHashLen+1 || data[0]&0x87 != 0 {
+		return 0, nil, errors.New("Cashaddr has an invalid version byte or hash length")
+	}
+	return data[0] >> 3, data[1:], nil
+}
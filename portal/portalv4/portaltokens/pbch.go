@@ -0,0 +1,70 @@
+package portaltokens
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+// PortalBCHTokenProcessor processes pBCH, its vault uses P2SH multisig addresses in the
+// cashaddr format and its inputs are signed with the fork ID digest
+type PortalBCHTokenProcessor struct {
+	PortalBTCTokenProcessor
+}
+
+func (p PortalBCHTokenProcessor) codec() utxoChainCodec {
+	return bchCodec{params: p.ChainParam, prefix: cashAddrPrefix(p.ChainParam)}
+}
+
+func (p PortalBCHTokenProcessor) ParseAndVerifyShieldProof(
+	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64,
+) (bool, []*statedb.UTXO, error) {
+	return p.parseAndVerifyShieldProof(proof, bc.GetUTXOHeaderChain(p.ChainID), p.codec(), expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
+}
+
+func (p PortalBCHTokenProcessor) ParseAndVerifyUnshieldProof(
+	proof string,
+	bc metadata.ChainRetriever,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
+	return p.parseAndVerifyUnshieldProof(proof, bc.GetUTXOHeaderChain(p.ChainID), p.codec(), expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalBCHTokenProcessor) IsValidRemoteAddress(address string, bcr metadata.ChainRetriever) (bool, error) {
+	return p.isValidRemoteAddress(address, bcr.GetUTXOHeaderChain(p.ChainID), p.codec())
+}
+
+// Generate P2SH multisig cashaddr for each Incognito address
+// Return redeem script, OTMultisigAddress
+func (p PortalBCHTokenProcessor) GenerateOTMultisigAddress(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, string, error) {
+	return p.generateOTMultisigAddress(p.codec(), masterPubKeys, numSigsRequired, chainCodeSeed)
+}
+
+func (p PortalBCHTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, outputs []*OutputTx, feePerOutput uint64,
+	bc metadata.ChainRetriever, beaconHeight uint64) (string, string, error) {
+	return p.createRawExternalTx(p.codec(), inputs, outputs, feePerOutput, bc, beaconHeight)
+}
+
+func (p PortalBCHTokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, string, error) {
+	return p.partSignOnRawExternalTx(p.codec(), seedKey, masterPubKeys, numSigsRequired, rawTxBytes, inputs)
+}
+
+func (p PortalBCHTokenProcessor) AttachSigsToRawExternalTx(externalTx *wire.MsgTx, sigs [][][]byte, masterPubKeys [][]byte, numSigsRequired int, inputs []*statedb.UTXO) error {
+	return p.attachSigsToRawExternalTx(p.codec(), externalTx, sigs, masterPubKeys, numSigsRequired, inputs)
+}
+
+// cashAddrPrefix returns the cashaddr prefix of a Bitcoin Cash network
+func cashAddrPrefix(params *chaincfg.Params) string {
+	if params.Net == btcrelaying.BCHMainNetParams.Net {
+		return "bitcoincash"
+	}
+	if params.Net == btcrelaying.BCHTestNet3Params.Net {
+		return "bchtest"
+	}
+	return "bchreg"
+}
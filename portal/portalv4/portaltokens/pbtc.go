@@ -2,7 +2,6 @@ package portaltokens
 
 import (
 	"bytes"
-	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -15,7 +14,6 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	btcwire "github.com/btcsuite/btcd/wire"
-	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -53,11 +51,11 @@ func (p PortalBTCTokenProcessor) GetTxHashFromProof(proof string) (string, error
 	return btcTxProof.BTCTx.TxHash().String(), nil
 }
 
-func (p PortalBTCTokenProcessor) parseAndVerifyProofBTCChain(
-	proof string, btcChain *btcrelaying.BlockChain, expectedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64) (bool, []*statedb.UTXO, error) {
-	if btcChain == nil {
-		Logger.log.Error("BTC relaying chain should not be null")
-		return false, nil, errors.New("BTC relaying chain should not be null")
+func (p PortalBTCTokenProcessor) parseAndVerifyShieldProof(
+	proof string, headerChain *btcrelaying.BlockChain, codec utxoChainCodec, expectedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64) (bool, []*statedb.UTXO, error) {
+	if headerChain == nil {
+		Logger.log.Error("Relaying header chain should not be null")
+		return false, nil, errors.New("Relaying header chain should not be null")
 	}
 	// parse BTCProof in meta
 	btcTxProof, err := btcrelaying.ParseAndValidateSanityBTCProofFromB64EncodeStr(proof)
@@ -67,7 +65,7 @@ func (p PortalBTCTokenProcessor) parseAndVerifyProofBTCChain(
 	}
 
 	// verify tx with merkle proofs
-	isValid, err := btcChain.VerifyTxWithMerkleProofs(btcTxProof)
+	isValid, err := headerChain.VerifyTxWithMerkleProofs(btcTxProof)
 	if !isValid || err != nil {
 		Logger.log.Errorf("Verify btcTxProof failed %v", err)
 		return false, nil, fmt.Errorf("Verify btcTxProof failed %v", err)
@@ -81,7 +79,7 @@ func (p PortalBTCTokenProcessor) parseAndVerifyProofBTCChain(
 	listUTXO := []*statedb.UTXO{}
 
 	for idx, out := range outputs {
-		addrStr, err := btcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
+		addrStr, err := codec.extractAddress(out.PkScript)
 		if err != nil {
 			Logger.log.Errorf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
 			continue
@@ -118,7 +116,7 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyShieldProof(
 	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64,
 ) (bool, []*statedb.UTXO, error) {
 	btcChain := bc.GetBTCHeaderChain()
-	return p.parseAndVerifyProofBTCChain(proof, btcChain, expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
+	return p.parseAndVerifyShieldProof(proof, btcChain, segwitCodec{params: p.ChainParam}, expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
 }
 
 func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
@@ -130,9 +128,21 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
 	utxos []*statedb.UTXO,
 ) (bool, []*statedb.UTXO, string, uint64, error) {
 	btcChain := bc.GetBTCHeaderChain()
-	if btcChain == nil {
-		Logger.log.Error("BTC relaying chain should not be null")
-		return false, nil, "", 0, errors.New("BTC relaying chain should not be null")
+	return p.parseAndVerifyUnshieldProof(proof, btcChain, segwitCodec{params: p.ChainParam}, expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalBTCTokenProcessor) parseAndVerifyUnshieldProof(
+	proof string,
+	headerChain *btcrelaying.BlockChain,
+	codec utxoChainCodec,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
+	if headerChain == nil {
+		Logger.log.Error("Relaying header chain should not be null")
+		return false, nil, "", 0, errors.New("Relaying header chain should not be null")
 	}
 	// parse BTCProof in meta
 	btcTxProof, err := btcrelaying.ParseAndValidateSanityBTCProofFromB64EncodeStr(proof)
@@ -142,7 +152,7 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
 	}
 
 	// verify tx with merkle proofs
-	isValid, err := btcChain.VerifyTxWithMerkleProofs(btcTxProof)
+	isValid, err := headerChain.VerifyTxWithMerkleProofs(btcTxProof)
 	if !isValid || err != nil {
 		Logger.log.Errorf("Verify btcTxProof failed %v", err)
 		return false, nil, "", 0, fmt.Errorf("Verify btcTxProof failed %v", err)
@@ -176,7 +186,7 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
 			Logger.log.Error("BTC-TxProof is invalid")
 			return false, nil, "", 0, errors.New("BTC-TxProof is invalid")
 		}
-		addrStr, err := btcChain.ExtractPaymentAddrStrFromPkScript(outputs[idx].PkScript)
+		addrStr, err := codec.extractAddress(outputs[idx].PkScript)
 		if err != nil {
 			Logger.log.Errorf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
 			return false, nil, "", 0, errors.New("Could not extract address from proof")
@@ -199,7 +209,7 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
 	// check the change output coin
 	listUTXO := []*statedb.UTXO{}
 	for idx, out := range outputs {
-		addrStr, err := btcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
+		addrStr, err := codec.extractAddress(out.PkScript)
 		if err != nil {
 			Logger.log.Errorf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
 			continue
@@ -227,6 +237,13 @@ func (p PortalBTCTokenProcessor) IsValidRemoteAddress(address string, bcr metada
 	return btcHeaderChain.IsBTCAddressValid(address), nil
 }
 
+func (p PortalBTCTokenProcessor) isValidRemoteAddress(address string, headerChain *btcrelaying.BlockChain, codec utxoChainCodec) (bool, error) {
+	if headerChain == nil {
+		return false, nil
+	}
+	return codec.isValidAddress(address), nil
+}
+
 func (p PortalBTCTokenProcessor) GetChainID() string {
 	return p.ChainID
 }
@@ -276,6 +293,12 @@ func (p PortalBTCTokenProcessor) generateOTPrivateKey(seed []byte, chainCodeSeed
 // Generate Bech32 P2WSH multisig address for each Incognito address
 // Return redeem script, OTMultisigAddress
 func (p PortalBTCTokenProcessor) GenerateOTMultisigAddress(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, string, error) {
+	return p.generateOTMultisigAddress(segwitCodec{params: p.ChainParam}, masterPubKeys, numSigsRequired, chainCodeSeed)
+}
+
+// generateOTMultisigAddress builds the m of n multisig redeem script of an Incognito address
+// and returns it with its address in the format of codec
+func (p PortalBTCTokenProcessor) generateOTMultisigAddress(codec utxoChainCodec, masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, string, error) {
 	if len(masterPubKeys) < numSigsRequired || numSigsRequired < 0 {
 		return []byte{}, "", fmt.Errorf("Invalid signature requirement")
 	}
@@ -316,13 +339,10 @@ func (p PortalBTCTokenProcessor) GenerateOTMultisigAddress(masterPubKeys [][]byt
 		return []byte{}, "", fmt.Errorf("Could not build script - Error %v", err)
 	}
 
-	// generate P2WSH address
-	scriptHash := sha256.Sum256(redeemScript)
-	addr, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], p.ChainParam)
+	addrStr, err := codec.multisigAddress(redeemScript)
 	if err != nil {
 		return []byte{}, "", fmt.Errorf("Could not generate address from script - Error %v", err)
 	}
-	addrStr := addr.EncodeAddress()
 
 	return redeemScript, addrStr, nil
 }
@@ -332,6 +352,11 @@ func (p PortalBTCTokenProcessor) GenerateOTMultisigAddress(masterPubKeys [][]byt
 // outputs: unit of amount in pbtc ~ unshielding amount
 // feePerOutput: unit in pbtc
 func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, outputs []*OutputTx, feePerOutput uint64,
+	bc metadata.ChainRetriever, beaconHeight uint64) (string, string, error) {
+	return p.createRawExternalTx(segwitCodec{params: p.ChainParam}, inputs, outputs, feePerOutput, bc, beaconHeight)
+}
+
+func (p PortalBTCTokenProcessor) createRawExternalTx(codec utxoChainCodec, inputs []*statedb.UTXO, outputs []*OutputTx, feePerOutput uint64,
 	bc metadata.ChainRetriever, beaconHeight uint64) (string, string, error) {
 	msgTx := wire.NewMsgTx(wire.TxVersion)
 
@@ -344,7 +369,7 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 	for _, in := range inputs {
 		utxoHash, err := chainhash.NewHashFromStr(in.GetTxHash())
 		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when new TxIn for tx: %v", err)
+			Logger.log.Errorf("[CreateRawExternalTx] Error when new TxIn for tx: %v", err)
 			return "", "", err
 		}
 		outPoint := wire.NewOutPoint(utxoHash, in.GetOutputIndex())
@@ -359,22 +384,21 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 	totalOutputAmount := uint64(0)
 	for _, out := range outputs {
 		// adding the output to tx
-		decodedAddr, err := btcutil.DecodeAddress(out.ReceiverAddress, p.ChainParam)
-		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when decoding receiver address: %v", err)
-			return "", "", err
-		}
-		destinationAddrByte, err := txscript.PayToAddrScript(decodedAddr)
+		destinationAddrByte, err := codec.payToAddrScript(out.ReceiverAddress)
 		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when new Address Script: %v", err)
+			Logger.log.Errorf("[CreateRawExternalTx] Error when new Address Script: %v", err)
 			return "", "", err
 		}
 
 		// adding the destination address and the amount to the transaction
 		outAmountInExternal := p.ConvertIncToExternalAmount(out.Amount)
 		if outAmountInExternal <= feePerOutputInExternal {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Output amount %v must greater than fee %v", out.Amount, feePerOutputInExternal)
-			return "", "", fmt.Errorf("[CreateRawExternalTx-BTC] Output amount %v must greater than fee %v", out.Amount, feePerOutputInExternal)
+			Logger.log.Errorf("[CreateRawExternalTx] Output amount %v must greater than fee %v", out.Amount, feePerOutputInExternal)
+			return "", "", fmt.Errorf("[CreateRawExternalTx] Output amount %v must greater than fee %v", out.Amount, feePerOutputInExternal)
+		}
+		if outAmountInExternal-feePerOutputInExternal < p.ExternalDustLimit {
+			Logger.log.Errorf("[CreateRawExternalTx] Output amount %v after fee is below the dust limit %v", out.Amount, p.ExternalDustLimit)
+			return "", "", fmt.Errorf("[CreateRawExternalTx] Output amount %v after fee is below the dust limit %v", out.Amount, p.ExternalDustLimit)
 		}
 		redeemTxOut := wire.NewTxOut(int64(outAmountInExternal-feePerOutputInExternal), destinationAddrByte)
 		msgTx.AddTxOut(redeemTxOut)
@@ -383,22 +407,17 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 
 	// check amount of input coins and output coins
 	if totalInputAmount < totalOutputAmount {
-		Logger.log.Errorf("[CreateRawExternalTx-BTC] Total input amount %v is less than total output amount %v", totalInputAmount, totalOutputAmount)
-		return "", "", fmt.Errorf("[CreateRawExternalTx-BTC] Total input amount %v is less than total output amount %v", totalInputAmount, totalOutputAmount)
+		Logger.log.Errorf("[CreateRawExternalTx] Total input amount %v is less than total output amount %v", totalInputAmount, totalOutputAmount)
+		return "", "", fmt.Errorf("[CreateRawExternalTx] Total input amount %v is less than total output amount %v", totalInputAmount, totalOutputAmount)
 	}
 
-	// calculate the change output
-	if totalInputAmount > totalOutputAmount {
+	// calculate the change output, a change below the dust limit is left to the fee
+	if totalInputAmount > totalOutputAmount && totalInputAmount-totalOutputAmount >= p.ExternalDustLimit {
 		// adding the output to tx
 		multiSigAddress := bc.GetPortalV4GeneralMultiSigAddress(p.GetPortalTokenID(), beaconHeight)
-		decodedAddr, err := btcutil.DecodeAddress(multiSigAddress, p.ChainParam)
+		destinationAddrByte, err := codec.payToAddrScript(multiSigAddress)
 		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when decoding multisig address: %v", err)
-			return "", "", err
-		}
-		destinationAddrByte, err := txscript.PayToAddrScript(decodedAddr)
-		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when new multisig Address Script: %v", err)
+			Logger.log.Errorf("[CreateRawExternalTx] Error when new multisig Address Script: %v", err)
 			return "", "", err
 		}
 
@@ -410,7 +429,7 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 	var rawTxBytes bytes.Buffer
 	err := msgTx.Serialize(&rawTxBytes)
 	if err != nil {
-		Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when serializing raw tx: %v", err)
+		Logger.log.Errorf("[CreateRawExternalTx] Error when serializing raw tx: %v", err)
 		return "", "", err
 	}
 
@@ -419,6 +438,10 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 }
 
 func (p PortalBTCTokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, string, error) {
+	return p.partSignOnRawExternalTx(segwitCodec{params: p.ChainParam}, seedKey, masterPubKeys, numSigsRequired, rawTxBytes, inputs)
+}
+
+func (p PortalBTCTokenProcessor) partSignOnRawExternalTx(codec utxoChainCodec, seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, string, error) {
 	// new MsgTx from rawTxBytes
 	msgTx := new(btcwire.MsgTx)
 	rawTxBuffer := bytes.NewBuffer(rawTxBytes)
@@ -438,11 +461,11 @@ func (p PortalBTCTokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterP
 			return nil, "", fmt.Errorf("[PartSignOnRawExternalTx] Error when generate btc private key from seed: %v", err)
 		}
 		btcPrivateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), btcPrivateKeyBytes)
-		multiSigScript, _, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, inputs[i].GetChainCodeSeed())
+		multiSigScript, _, err := p.generateOTMultisigAddress(codec, masterPubKeys, numSigsRequired, inputs[i].GetChainCodeSeed())
 		if err != nil {
 			return nil, "", fmt.Errorf("[PartSignOnRawExternalTx] Error when generate multi sig address: %v", err)
 		}
-		sig, err := txscript.RawTxInWitnessSignature(msgTx, txscript.NewTxSigHashes(msgTx), i, int64(inputs[i].GetOutputAmount()), multiSigScript, txscript.SigHashAll, btcPrivateKey)
+		sig, err := codec.signInput(msgTx, i, int64(inputs[i].GetOutputAmount()), multiSigScript, btcPrivateKey)
 		if err != nil {
 			return nil, "", fmt.Errorf("[PartSignOnRawExternalTx] Error when signing on raw btc tx: %v", err)
 		}
@@ -452,6 +475,27 @@ func (p PortalBTCTokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterP
 	return sigs, msgTx.TxHash().String(), nil
 }
 
+func (p PortalBTCTokenProcessor) AttachSigsToRawExternalTx(externalTx *wire.MsgTx, sigs [][][]byte, masterPubKeys [][]byte, numSigsRequired int, inputs []*statedb.UTXO) error {
+	return p.attachSigsToRawExternalTx(segwitCodec{params: p.ChainParam}, externalTx, sigs, masterPubKeys, numSigsRequired, inputs)
+}
+
+func (p PortalBTCTokenProcessor) attachSigsToRawExternalTx(codec utxoChainCodec, externalTx *wire.MsgTx, sigs [][][]byte, masterPubKeys [][]byte, numSigsRequired int, inputs []*statedb.UTXO) error {
+	if len(sigs) != len(externalTx.TxIn) || len(inputs) != len(externalTx.TxIn) {
+		return fmt.Errorf("[AttachSigsToRawExternalTx] Len of sigs %v, len of inputs %v and len of TxIn %v are not matched", len(sigs), len(inputs), len(externalTx.TxIn))
+	}
+	for i := range externalTx.TxIn {
+		multiSigScript, _, err := p.generateOTMultisigAddress(codec, masterPubKeys, numSigsRequired, inputs[i].GetChainCodeSeed())
+		if err != nil {
+			return fmt.Errorf("[AttachSigsToRawExternalTx] Error when generate multi sig address: %v", err)
+		}
+		err = codec.attachSigs(externalTx, i, sigs[i], multiSigScript)
+		if err != nil {
+			return fmt.Errorf("[AttachSigsToRawExternalTx] Error when attaching sigs to raw tx: %v", err)
+		}
+	}
+	return nil
+}
+
 func (p PortalBTCTokenProcessor) IsAcceptableTxSize(numInputs int, numOutputs int) bool {
 	return p.ExternalInputSize*uint(numInputs)+p.ExternalOutputSize*uint(numOutputs) <= p.ExternalTxMaxSize
 }
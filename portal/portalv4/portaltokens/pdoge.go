@@ -0,0 +1,57 @@
+package portaltokens
+
+import (
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// PortalDOGETokenProcessor processes pDOGE, Dogecoin has no segwit so its vault uses
+// legacy P2SH multisig addresses
+type PortalDOGETokenProcessor struct {
+	PortalBTCTokenProcessor
+}
+
+func (p PortalDOGETokenProcessor) codec() utxoChainCodec {
+	return p2shCodec{params: p.ChainParam}
+}
+
+func (p PortalDOGETokenProcessor) ParseAndVerifyShieldProof(
+	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64,
+) (bool, []*statedb.UTXO, error) {
+	return p.parseAndVerifyShieldProof(proof, bc.GetUTXOHeaderChain(p.ChainID), p.codec(), expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
+}
+
+func (p PortalDOGETokenProcessor) ParseAndVerifyUnshieldProof(
+	proof string,
+	bc metadata.ChainRetriever,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
+	return p.parseAndVerifyUnshieldProof(proof, bc.GetUTXOHeaderChain(p.ChainID), p.codec(), expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalDOGETokenProcessor) IsValidRemoteAddress(address string, bcr metadata.ChainRetriever) (bool, error) {
+	return p.isValidRemoteAddress(address, bcr.GetUTXOHeaderChain(p.ChainID), p.codec())
+}
+
+// Generate P2SH multisig address for each Incognito address
+// Return redeem script, OTMultisigAddress
+func (p PortalDOGETokenProcessor) GenerateOTMultisigAddress(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, string, error) {
+	return p.generateOTMultisigAddress(p.codec(), masterPubKeys, numSigsRequired, chainCodeSeed)
+}
+
+func (p PortalDOGETokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, outputs []*OutputTx, feePerOutput uint64,
+	bc metadata.ChainRetriever, beaconHeight uint64) (string, string, error) {
+	return p.createRawExternalTx(p.codec(), inputs, outputs, feePerOutput, bc, beaconHeight)
+}
+
+func (p PortalDOGETokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, string, error) {
+	return p.partSignOnRawExternalTx(p.codec(), seedKey, masterPubKeys, numSigsRequired, rawTxBytes, inputs)
+}
+
+func (p PortalDOGETokenProcessor) AttachSigsToRawExternalTx(externalTx *wire.MsgTx, sigs [][][]byte, masterPubKeys [][]byte, numSigsRequired int, inputs []*statedb.UTXO) error {
+	return p.attachSigsToRawExternalTx(p.codec(), externalTx, sigs, masterPubKeys, numSigsRequired, inputs)
+}
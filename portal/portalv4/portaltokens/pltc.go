@@ -0,0 +1,37 @@
+package portaltokens
+
+import (
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// PortalLTCTokenProcessor processes pLTC, its vault uses P2WSH multisig addresses as pBTC
+// so only the relaying header chain differs
+type PortalLTCTokenProcessor struct {
+	PortalBTCTokenProcessor
+}
+
+func (p PortalLTCTokenProcessor) codec() utxoChainCodec {
+	return segwitCodec{params: p.ChainParam}
+}
+
+func (p PortalLTCTokenProcessor) ParseAndVerifyShieldProof(
+	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64,
+) (bool, []*statedb.UTXO, error) {
+	return p.parseAndVerifyShieldProof(proof, bc.GetUTXOHeaderChain(p.ChainID), p.codec(), expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
+}
+
+func (p PortalLTCTokenProcessor) ParseAndVerifyUnshieldProof(
+	proof string,
+	bc metadata.ChainRetriever,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
+	return p.parseAndVerifyUnshieldProof(proof, bc.GetUTXOHeaderChain(p.ChainID), p.codec(), expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalLTCTokenProcessor) IsValidRemoteAddress(address string, bcr metadata.ChainRetriever) (bool, error) {
+	return p.isValidRemoteAddress(address, bcr.GetUTXOHeaderChain(p.ChainID), p.codec())
+}
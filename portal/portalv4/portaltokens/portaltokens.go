@@ -1,6 +1,7 @@
 package portaltokens
 
 import (
+	"github.com/btcsuite/btcd/wire"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 )
@@ -29,8 +30,10 @@ type PortalTokenProcessor interface {
 	CreateRawExternalTx(inputs []*statedb.UTXO, outputs []*OutputTx, networkFee uint64,
 		bc metadata.ChainRetriever, beaconHeight uint64) (string, string, error)
 	PartSignOnRawExternalTx(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, string, error)
+	AttachSigsToRawExternalTx(externalTx *wire.MsgTx, sigs [][][]byte, masterPubKeys [][]byte, numSigsRequired int, inputs []*statedb.UTXO) error
 	GenerateOTMultisigAddress(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, string, error)
 	GetPortalTokenID() string
+	IsAcceptableTxSize(numInputs int, numOutputs int) bool
 }
 
 type PortalToken struct {
@@ -40,6 +43,7 @@ type PortalToken struct {
 	ExternalInputSize   uint   // they are used to estimate size of external txs (in byte)
 	ExternalOutputSize  uint
 	ExternalTxMaxSize   uint
+	ExternalDustLimit   uint64 // outputs below it are not relayed by external nodes, in external unit
 }
 
 type BroadcastTx struct {
@@ -0,0 +1,171 @@
+package portaltokens
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashAddress(t *testing.T) {
+	hash, _ := hex.DecodeString("76a04053bda0a88bda5177b86a15c3b29f559873")
+
+	addr, err := encodeCashAddress("bitcoincash", cashAddrTypeP2PKH, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", addr)
+	addr, err = encodeCashAddress("bitcoincash", cashAddrTypeP2SH, hash)
+	assert.Nil(t, err)
+	assert.Equal(t, "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq", addr)
+
+	addrType, decodedHash, err := decodeCashAddress("bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash")
+	assert.Nil(t, err)
+	assert.Equal(t, cashAddrTypeP2PKH, addrType)
+	assert.Equal(t, hash, decodedHash)
+
+	// upper case addresses are valid
+	_, _, err = decodeCashAddress(strings.ToUpper("bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"), "bitcoincash")
+	assert.Nil(t, err)
+
+	invalidAddrs := []string{
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b",  // bad checksum
+		"bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",      // other prefix
+		"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",              // no prefix
+		"bitcoincash:Qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",  // mixed case
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6ab", // bad length
+	}
+	for _, invalidAddr := range invalidAddrs {
+		_, _, err = decodeCashAddress(invalidAddr, "bitcoincash")
+		assert.NotNil(t, err, invalidAddr)
+	}
+}
+
+func TestUTXOChainMultisigAddresses(t *testing.T) {
+	seeds := [][]byte{[]byte("seed1"), []byte("seed2"), []byte("seed3"), []byte("seed4")}
+	btcProcessor := PortalBTCTokenProcessor{ChainParam: btcrelaying.LTCMainNetParams}
+	masterPubKeys := [][]byte{}
+	for _, seed := range seeds {
+		masterPubKeys = append(masterPubKeys, btcProcessor.generatePublicKeyFromSeed(seed))
+	}
+
+	ltcMainNet := PortalLTCTokenProcessor{PortalBTCTokenProcessor{ChainParam: btcrelaying.LTCMainNetParams}}
+	ltcTestNet := PortalLTCTokenProcessor{PortalBTCTokenProcessor{ChainParam: btcrelaying.LTCTestNet4Params}}
+	dogeMainNet := PortalDOGETokenProcessor{PortalBTCTokenProcessor{ChainParam: btcrelaying.DOGEMainNetParams}}
+	dogeTestNet := PortalDOGETokenProcessor{PortalBTCTokenProcessor{ChainParam: btcrelaying.DOGETestNetParams}}
+	bchMainNet := PortalBCHTokenProcessor{PortalBTCTokenProcessor{ChainParam: btcrelaying.BCHMainNetParams}}
+	bchTestNet := PortalBCHTokenProcessor{PortalBTCTokenProcessor{ChainParam: btcrelaying.BCHTestNet3Params}}
+	testCases := []struct {
+		processor PortalTokenProcessor
+		codec     utxoChainCodec
+		prefix    string
+	}{
+		{ltcMainNet, ltcMainNet.codec(), "ltc1q"},
+		{ltcTestNet, ltcTestNet.codec(), "tltc1q"},
+		{dogeMainNet, dogeMainNet.codec(), "A"},
+		{dogeTestNet, dogeTestNet.codec(), "2"},
+		{bchMainNet, bchMainNet.codec(), "bitcoincash:p"},
+		{bchTestNet, bchTestNet.codec(), "bchtest:p"},
+	}
+	for _, tc := range testCases {
+		for _, chainCodeSeed := range []string{"", "12svfkP6w5UDJDSCwqH978PvqiqBxKmUnA9em9yAYWYJVRv7wuXY1qhhYpPAm4BDz2mLbFrRmdK3yRhnTqJCZXKHUmoi7NV83HCH2YFpctHNaDdkSiQshsjw2UFUuwdEvcidgaKmF3VJpY5f8RdN"} {
+			_, address, err := tc.processor.GenerateOTMultisigAddress(masterPubKeys, 3, chainCodeSeed)
+			assert.Nil(t, err)
+			assert.True(t, strings.HasPrefix(address, tc.prefix), address)
+
+			// the multisig address is a valid remote address of its network only
+			for _, other := range testCases {
+				assert.Equal(t, other.codec == tc.codec, other.codec.isValidAddress(address), address)
+			}
+		}
+	}
+}
+
+// signAndAttach creates a raw tx spending one vault UTXO, signs it by the first numSigsRequired seeds
+// and attaches the sigs as the beacon does
+func signAndAttach(t *testing.T, p PortalTokenProcessor, seeds [][]byte, masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) (*wire.MsgTx, []byte, int64) {
+	redeemScript, address, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, chainCodeSeed)
+	assert.Nil(t, err)
+
+	inputAmount := uint64(100000000)
+	utxo := statedb.NewUTXOWithValue(address, "2e9a0e3e4a4ed5a2d4dd81c7a0b2cc4b1bf34e7c5a5e9b0b11e8c2dd6e62b0a1", 1, inputAmount, chainCodeSeed)
+	outputs := []*OutputTx{{ReceiverAddress: address, Amount: p.ConvertExternalToIncAmount(inputAmount)}}
+	hexRawTx, _, err := p.CreateRawExternalTx([]*statedb.UTXO{utxo}, outputs, 10000, nil, 0)
+	assert.Nil(t, err)
+	rawTxBytes, _ := hex.DecodeString(hexRawTx)
+
+	sigs := [][][]byte{{}}
+	for _, seed := range seeds[:numSigsRequired] {
+		partSigs, _, err := p.PartSignOnRawExternalTx(seed, masterPubKeys, numSigsRequired, rawTxBytes, []*statedb.UTXO{utxo})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(partSigs))
+		sigs[0] = append(sigs[0], partSigs[0])
+	}
+
+	externalTx := wire.NewMsgTx(wire.TxVersion)
+	assert.Nil(t, externalTx.Deserialize(bytes.NewReader(rawTxBytes)))
+	assert.Nil(t, p.AttachSigsToRawExternalTx(externalTx, sigs, masterPubKeys, numSigsRequired, []*statedb.UTXO{utxo}))
+	return externalTx, redeemScript, int64(inputAmount)
+}
+
+func TestUTXOChainSignatures(t *testing.T) {
+	seeds := [][]byte{[]byte("seed1"), []byte("seed2"), []byte("seed3"), []byte("seed4")}
+	btcProcessor := PortalBTCTokenProcessor{ChainParam: btcrelaying.LTCMainNetParams}
+	masterPubKeys := [][]byte{}
+	for _, seed := range seeds {
+		masterPubKeys = append(masterPubKeys, btcProcessor.generatePublicKeyFromSeed(seed))
+	}
+	token := &PortalToken{ExternalDustLimit: 546}
+	chainCodeSeed := "12svfkP6w5UDJDSCwqH978PvqiqBxKmUnA9em9yAYWYJVRv7wuXY1qhhYpPAm4BDz2mLbFrRmdK3yRhnTqJCZXKHUmoi7NV83HCH2YFpctHNaDdkSiQshsjw2UFUuwdEvcidgaKmF3VJpY5f8RdN"
+
+	// litecoin and dogecoin spend the vault with the script rules of bitcoin
+	ltcProcessor := PortalLTCTokenProcessor{PortalBTCTokenProcessor{PortalToken: token, ChainParam: btcrelaying.LTCTestNet4Params}}
+	dogeProcessor := PortalDOGETokenProcessor{PortalBTCTokenProcessor{PortalToken: token, ChainParam: btcrelaying.DOGETestNetParams}}
+	for _, tc := range []struct {
+		processor PortalTokenProcessor
+		codec     utxoChainCodec
+	}{
+		{ltcProcessor, ltcProcessor.codec()},
+		{dogeProcessor, dogeProcessor.codec()},
+	} {
+		externalTx, redeemScript, amount := signAndAttach(t, tc.processor, seeds, masterPubKeys, 3, chainCodeSeed)
+		address, err := tc.codec.multisigAddress(redeemScript)
+		assert.Nil(t, err)
+		pkScript, err := tc.codec.payToAddrScript(address)
+		assert.Nil(t, err)
+
+		vm, err := txscript.NewEngine(pkScript, externalTx, 0, txscript.StandardVerifyFlags, nil, txscript.NewTxSigHashes(externalTx), amount)
+		assert.Nil(t, err)
+		assert.Nil(t, vm.Execute())
+	}
+
+	// bitcoin cash sigs commit to the fork ID digest which btcd does not verify,
+	// so check each sig against the pub keys in order
+	p := PortalBCHTokenProcessor{PortalBTCTokenProcessor{PortalToken: token, ChainParam: btcrelaying.BCHTestNet3Params}}
+	externalTx, redeemScript, amount := signAndAttach(t, p, seeds, masterPubKeys, 3, chainCodeSeed)
+	assert.Equal(t, 0, len(externalTx.TxIn[0].Witness))
+	pushes, err := txscript.PushedData(externalTx.TxIn[0].SignatureScript)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(pushes)) // OP_0, 3 sigs and the redeem script
+	assert.Equal(t, redeemScript, pushes[4])
+
+	// the data pushed by the redeem script are the 4 pub keys
+	pubKeys, err := txscript.PushedData(redeemScript)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(pubKeys))
+	digest, err := txscript.CalcWitnessSigHash(redeemScript, txscript.NewTxSigHashes(externalTx), txscript.SigHashAll|sigHashForkID, externalTx, 0, amount)
+	assert.Nil(t, err)
+	for i, sigBytes := range pushes[1:4] {
+		assert.Equal(t, txscript.SigHashAll|sigHashForkID, txscript.SigHashType(sigBytes[len(sigBytes)-1]))
+		sig, err := btcec.ParseDERSignature(sigBytes[:len(sigBytes)-1], btcec.S256())
+		assert.Nil(t, err)
+		pubKey, err := btcec.ParsePubKey(pubKeys[i], btcec.S256())
+		assert.Nil(t, err)
+		assert.True(t, sig.Verify(digest, pubKey))
+	}
+}
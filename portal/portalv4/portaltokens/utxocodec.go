@@ -0,0 +1,210 @@
+package portaltokens
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// sigHashForkID is the flag of Bitcoin Cash signatures which commit to the input amounts
+// with the BIP143 digest (fork ID 0)
+const sigHashForkID txscript.SigHashType = 0x40
+
+// utxoChainCodec converts between addresses and scripts of a Bitcoin-derived chain,
+// and signs inputs of the vault multisig scripts the way the chain verifies them
+type utxoChainCodec interface {
+	payToAddrScript(address string) ([]byte, error)
+	extractAddress(pkScript []byte) (string, error)
+	isValidAddress(address string) bool
+	multisigAddress(redeemScript []byte) (string, error)
+	signInput(tx *wire.MsgTx, idx int, amount int64, redeemScript []byte, privKey *btcec.PrivateKey) ([]byte, error)
+	attachSigs(tx *wire.MsgTx, idx int, sigs [][]byte, redeemScript []byte) error
+}
+
+func decodeAddressForNet(address string, params *chaincfg.Params) (btcutil.Address, error) {
+	decodedAddr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return nil, err
+	}
+	if !decodedAddr.IsForNet(params) {
+		return nil, fmt.Errorf("Address %v is not for the network %v", address, params.Name)
+	}
+	return decodedAddr, nil
+}
+
+func extractFirstAddress(pkScript []byte, params *chaincfg.Params) (btcutil.Address, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	return addrs[0], nil
+}
+
+// buildP2SHMultisigSigScript builds the unlocking script of a P2SH multisig input,
+// OP_0 being the extra item consumed by OP_CHECKMULTISIG
+func buildP2SHMultisigSigScript(sigs [][]byte, redeemScript []byte) ([]byte, error) {
+	builder := txscript.NewScriptBuilder()
+	builder.AddOp(txscript.OP_0)
+	for _, sig := range sigs {
+		builder.AddData(sig)
+	}
+	builder.AddData(redeemScript)
+	return builder.Script()
+}
+
+// segwitCodec pays the vault with P2WSH multisig addresses (BTC, LTC)
+type segwitCodec struct {
+	params *chaincfg.Params
+}
+
+func (c segwitCodec) payToAddrScript(address string) ([]byte, error) {
+	decodedAddr, err := decodeAddressForNet(address, c.params)
+	if err != nil {
+		return nil, err
+	}
+	return txscript.PayToAddrScript(decodedAddr)
+}
+
+func (c segwitCodec) extractAddress(pkScript []byte) (string, error) {
+	addr, err := extractFirstAddress(pkScript, c.params)
+	if err != nil || addr == nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+func (c segwitCodec) isValidAddress(address string) bool {
+	_, err := decodeAddressForNet(address, c.params)
+	return err == nil
+}
+
+func (c segwitCodec) multisigAddress(redeemScript []byte) (string, error) {
+	scriptHash := sha256.Sum256(redeemScript)
+	addr, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], c.params)
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+func (c segwitCodec) signInput(tx *wire.MsgTx, idx int, amount int64, redeemScript []byte, privKey *btcec.PrivateKey) ([]byte, error) {
+	return txscript.RawTxInWitnessSignature(tx, txscript.NewTxSigHashes(tx), idx, amount, redeemScript, txscript.SigHashAll, privKey)
+}
+
+func (c segwitCodec) attachSigs(tx *wire.MsgTx, idx int, sigs [][]byte, redeemScript []byte) error {
+	// the empty first item is consumed by OP_CHECKMULTISIG
+	witness := wire.TxWitness{nil}
+	witness = append(witness, sigs...)
+	tx.TxIn[idx].Witness = append(witness, redeemScript)
+	return nil
+}
+
+// p2shCodec pays the vault with legacy P2SH multisig addresses (DOGE)
+type p2shCodec struct {
+	params *chaincfg.Params
+}
+
+func (c p2shCodec) payToAddrScript(address string) ([]byte, error) {
+	return segwitCodec(c).payToAddrScript(address)
+}
+
+func (c p2shCodec) extractAddress(pkScript []byte) (string, error) {
+	return segwitCodec(c).extractAddress(pkScript)
+}
+
+func (c p2shCodec) isValidAddress(address string) bool {
+	return segwitCodec(c).isValidAddress(address)
+}
+
+func (c p2shCodec) multisigAddress(redeemScript []byte) (string, error) {
+	addr, err := btcutil.NewAddressScriptHash(redeemScript, c.params)
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+func (c p2shCodec) signInput(tx *wire.MsgTx, idx int, amount int64, redeemScript []byte, privKey *btcec.PrivateKey) ([]byte, error) {
+	return txscript.RawTxInSignature(tx, idx, redeemScript, txscript.SigHashAll, privKey)
+}
+
+func (c p2shCodec) attachSigs(tx *wire.MsgTx, idx int, sigs [][]byte, redeemScript []byte) error {
+	sigScript, err := buildP2SHMultisigSigScript(sigs, redeemScript)
+	if err != nil {
+		return err
+	}
+	tx.TxIn[idx].SignatureScript = sigScript
+	return nil
+}
+
+// bchCodec pays the vault with P2SH multisig addresses in the cashaddr format (BCH),
+// inputs are signed with the fork ID digest
+type bchCodec struct {
+	params *chaincfg.Params
+	prefix string
+}
+
+func (c bchCodec) payToAddrScript(address string) ([]byte, error) {
+	addrType, hash, err := decodeCashAddress(address, c.prefix)
+	if err != nil {
+		return nil, err
+	}
+	var decodedAddr btcutil.Address
+	switch addrType {
+	case cashAddrTypeP2PKH:
+		decodedAddr, err = btcutil.NewAddressPubKeyHash(hash, c.params)
+	case cashAddrTypeP2SH:
+		decodedAddr, err = btcutil.NewAddressScriptHashFromHash(hash, c.params)
+	default:
+		err = fmt.Errorf("Unsupported cashaddr type %v", addrType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txscript.PayToAddrScript(decodedAddr)
+}
+
+func (c bchCodec) extractAddress(pkScript []byte) (string, error) {
+	addr, err := extractFirstAddress(pkScript, c.params)
+	if err != nil || addr == nil {
+		return "", err
+	}
+	switch a := addr.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return encodeCashAddress(c.prefix, cashAddrTypeP2PKH, a.Hash160()[:])
+	case *btcutil.AddressScriptHash:
+		return encodeCashAddress(c.prefix, cashAddrTypeP2SH, a.Hash160()[:])
+	}
+	return "", nil
+}
+
+func (c bchCodec) isValidAddress(address string) bool {
+	_, err := c.payToAddrScript(address)
+	return err == nil
+}
+
+func (c bchCodec) multisigAddress(redeemScript []byte) (string, error) {
+	return encodeCashAddress(c.prefix, cashAddrTypeP2SH, btcutil.Hash160(redeemScript))
+}
+
+func (c bchCodec) signInput(tx *wire.MsgTx, idx int, amount int64, redeemScript []byte, privKey *btcec.PrivateKey) ([]byte, error) {
+	// the fork ID digest is the BIP143 one with the fork ID in the sighash type
+	return txscript.RawTxInWitnessSignature(tx, txscript.NewTxSigHashes(tx), idx, amount, redeemScript, txscript.SigHashAll|sigHashForkID, privKey)
+}
+
+func (c bchCodec) attachSigs(tx *wire.MsgTx, idx int, sigs [][]byte, redeemScript []byte) error {
+	sigScript, err := buildP2SHMultisigSigScript(sigs, redeemScript)
+	if err != nil {
+		return err
+	}
+	tx.TxIn[idx].SignatureScript = sigScript
+	return nil
+}
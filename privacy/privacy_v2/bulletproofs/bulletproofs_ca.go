@@ -363,189 +363,145 @@ func (proof AggregatedRangeProof) VerifyFasterUsingBase(anAssetTag *operation.Po
 	return true, nil
 }
 
-// func VerifyBatchUsingBase(proofs []*AggregatedRangeProof) (bool, error, int) {
-// 	maxExp := privacy_util.MaxExp
-// 	baseG := CACommitmentScheme.G[operation.PedersenValueIndex]
-// 	baseH := CACommitmentScheme.G[operation.PedersenRandomnessIndex]
-
-// 	sum_tHat := new(operation.Scalar).FromUint64(0)
-// 	sum_tauX := new(operation.Scalar).FromUint64(0)
-// 	list_x_alpha := make([]*operation.Scalar, 0)
-// 	list_x_beta := make([]*operation.Scalar, 0)
-// 	list_xSquare := make([]*operation.Scalar, 0)
-// 	list_zSquare := make([]*operation.Scalar, 0)
-
-// 	list_t1 := make([]*operation.Point, 0)
-// 	list_t2 := make([]*operation.Point, 0)
-// 	list_V := make([]*operation.Point, 0)
-
-// 	sum_mu := new(operation.Scalar).FromUint64(0)
-// 	sum_absubthat := new(operation.Scalar).FromUint64(0)
-
-// 	list_S := make([]*operation.Point, 0)
-// 	list_A := make([]*operation.Point, 0)
-// 	list_beta := make([]*operation.Scalar, 0)
-// 	list_LR := make([]*operation.Point, 0)
-// 	list_lVector := make([]*operation.Scalar, 0)
-// 	list_rVector := make([]*operation.Scalar, 0)
-// 	list_gVector := make([]*operation.Point, 0)
-// 	list_hVector := make([]*operation.Point, 0)
-
-// 	twoNumber := new(operation.Scalar).FromUint64(2)
-// 	twoVectorN := powerVector(twoNumber, maxExp)
-
-// 	for k, proof := range proofs {
-// 		numValue := len(proof.cmsValue)
-// 		if numValue > privacy_util.MaxOutputCoin {
-// 			return false, errors.New("Must less than MaxOutputNumber"), k
-// 		}
-// 		numValuePad := roundUpPowTwo(numValue)
-// 		N := maxExp * numValuePad
-// 		aggParam := setAggregateParams(N)
-
-// 		cmsValue := proof.cmsValue
-// 		for i := numValue; i < numValuePad; i++ {
-// 			identity := new(operation.Point).Identity()
-// 			cmsValue = append(cmsValue, identity)
-// 		}
-
-// 		// recalculate challenge y, z, x
-// 		y := generateChallenge(aggParam.cs.ToBytesS(), []*operation.Point{proof.a, proof.s})
-// 		z := generateChallenge(y.ToBytesS(), []*operation.Point{proof.a, proof.s})
-// 		x := generateChallenge(z.ToBytesS(), []*operation.Point{proof.t1, proof.t2})
-// 		zSquare := new(operation.Scalar).Mul(z, z)
-// 		xSquare := new(operation.Scalar).Mul(x, x)
-
-// 		// Random alpha and beta for batch equations check
-// 		alpha := operation.RandomScalar()
-// 		beta := operation.RandomScalar()
-// 		list_beta = append(list_beta, beta)
-
-// 		// Compute first equation check
-// 		yVector := powerVector(y, N)
-// 		deltaYZ, err := computeDeltaYZ(z, zSquare, yVector, N)
-// 		if err != nil {
-// 			return false, err, k
-// 		}
-// 		sum_tHat.Add(sum_tHat, new(operation.Scalar).Mul(alpha, new(operation.Scalar).Sub(proof.tHat, deltaYZ)))
-// 		sum_tauX.Add(sum_tauX, new(operation.Scalar).Mul(alpha, proof.tauX))
-
-// 		list_x_alpha = append(list_x_alpha, new(operation.Scalar).Mul(x, alpha))
-// 		list_x_beta = append(list_x_beta, new(operation.Scalar).Mul(x, beta))
-// 		list_xSquare = append(list_xSquare, new(operation.Scalar).Mul(xSquare, alpha))
-// 		tmp := vectorMulScalar(powerVector(z, numValuePad), new(operation.Scalar).Mul(zSquare, alpha))
-// 		list_zSquare = append(list_zSquare, tmp...)
-
-// 		list_V = append(list_V, cmsValue...)
-// 		list_t1 = append(list_t1, proof.t1)
-// 		list_t2 = append(list_t2, proof.t2)
-
-// 		// Verify the second argument
-// 		hashCache := x.ToBytesS()
-// 		L := proof.innerProductProof.l
-// 		R := proof.innerProductProof.r
-// 		s := make([]*operation.Scalar, N)
-// 		sInverse := make([]*operation.Scalar, N)
-// 		logN := int(math.Log2(float64(N)))
-// 		vSquareList := make([]*operation.Scalar, logN)
-// 		vInverseSquareList := make([]*operation.Scalar, logN)
-
-// 		for i := 0; i < N; i++ {
-// 			s[i] = new(operation.Scalar).Set(proof.innerProductProof.a)
-// 			sInverse[i] = new(operation.Scalar).Set(proof.innerProductProof.b)
-// 		}
-
-// 		for i := range L {
-// 			v := generateChallenge(hashCache, []*operation.Point{L[i], R[i]})
-// 			hashCache = v.ToBytesS()
-// 			vInverse := new(operation.Scalar).Invert(v)
-// 			vSquareList[i] = new(operation.Scalar).Mul(v, v)
-// 			vInverseSquareList[i] = new(operation.Scalar).Mul(vInverse, vInverse)
-
-// 			for j := 0; j < N; j++ {
-// 				if j&int(math.Pow(2, float64(logN-i-1))) != 0 {
-// 					s[j] = new(operation.Scalar).Mul(s[j], v)
-// 					sInverse[j] = new(operation.Scalar).Mul(sInverse[j], vInverse)
-// 				} else {
-// 					s[j] = new(operation.Scalar).Mul(s[j], vInverse)
-// 					sInverse[j] = new(operation.Scalar).Mul(sInverse[j], v)
-// 				}
-// 			}
-// 		}
-
-// 		lVector := make([]*operation.Scalar, N)
-// 		rVector := make([]*operation.Scalar, N)
-
-// 		vectorSum := make([]*operation.Scalar, N)
-// 		zTmp := new(operation.Scalar).Set(z)
-// 		for j := 0; j < numValuePad; j++ {
-// 			zTmp.Mul(zTmp, z)
-// 			for i := 0; i < maxExp; i++ {
-// 				vectorSum[j*maxExp+i] = new(operation.Scalar).Mul(twoVectorN[i], zTmp)
-// 			}
-// 		}
-// 		yInverse := new(operation.Scalar).Invert(y)
-// 		yTmp := new(operation.Scalar).Set(y)
-// 		for j := 0; j < N; j++ {
-// 			yTmp.Mul(yTmp, yInverse)
-// 			lVector[j] = new(operation.Scalar).Add(s[j], z)
-// 			rVector[j] = new(operation.Scalar).Sub(sInverse[j], vectorSum[j])
-// 			rVector[j].Mul(rVector[j], yTmp)
-// 			rVector[j].Sub(rVector[j], z)
-
-// 			lVector[j].Mul(lVector[j], beta)
-// 			rVector[j].Mul(rVector[j], beta)
-// 		}
-
-// 		list_lVector = append(list_lVector, lVector...)
-// 		list_rVector = append(list_rVector, rVector...)
-
-// 		tmp1 := new(operation.Point).MultiScalarMult(vSquareList, L)
-// 		tmp2 := new(operation.Point).MultiScalarMult(vInverseSquareList, R)
-// 		list_LR = append(list_LR, new(operation.Point).Add(tmp1, tmp2))
-
-// 		list_gVector = append(list_gVector, aggParam.g...)
-// 		list_hVector = append(list_hVector, aggParam.h...)
-
-// 		sum_mu.Add(sum_mu, new(operation.Scalar).Mul(proof.mu, beta))
-// 		ab := new(operation.Scalar).Mul(proof.innerProductProof.a, proof.innerProductProof.b)
-// 		absubthat := new(operation.Scalar).Sub(ab, proof.tHat)
-// 		absubthat.Mul(absubthat, operation.HashToScalar(x.ToBytesS()))
-// 		sum_absubthat.Add(sum_absubthat, new(operation.Scalar).Mul(absubthat, beta))
-// 		list_A = append(list_A, proof.a)
-// 		list_S = append(list_S, proof.s)
-// 	}
-
-// 	tmp1 := new(operation.Point).MultiScalarMult(list_lVector, list_gVector)
-// 	tmp2 := new(operation.Point).MultiScalarMult(list_rVector, list_hVector)
-// 	tmp3 := new(operation.Point).ScalarMult(AggParam.u, sum_absubthat)
-// 	tmp4 := new(operation.Point).ScalarMult(baseH, sum_mu)
-// 	LHSPrime := new(operation.Point).Add(tmp1, tmp2)
-// 	LHSPrime.Add(LHSPrime, tmp3)
-// 	LHSPrime.Add(LHSPrime, tmp4)
-
-// 	LHS := new(operation.Point).AddPedersen(sum_tHat, baseG, sum_tauX, baseH)
-// 	LHSPrime.Add(LHSPrime, LHS)
-
-// 	tmp5 := new(operation.Point).MultiScalarMult(list_beta, list_A)
-// 	tmp6 := new(operation.Point).MultiScalarMult(list_x_beta, list_S)
-// 	RHSPrime := new(operation.Point).Add(tmp5, tmp6)
-// 	RHSPrime.Add(RHSPrime, new(operation.Point).MultiScalarMult(list_beta, list_LR))
-
-// 	part1 := new(operation.Point).MultiScalarMult(list_x_alpha, list_t1)
-// 	part2 := new(operation.Point).MultiScalarMult(list_xSquare, list_t2)
-// 	RHS := new(operation.Point).Add(part1, part2)
-// 	RHS.Add(RHS, new(operation.Point).MultiScalarMult(list_zSquare, list_V))
-// 	RHSPrime.Add(RHSPrime, RHS)
-// 	//fmt.Println("Batch Verification ", LHSPrime)
-// 	//fmt.Println("Batch Verification ", RHSPrime)
-
-// 	if !operation.IsPointEqual(LHSPrime, RHSPrime) {
-// 		Logger.Log.Errorf("batch verify aggregated range proof failed")
-// 		return false, errors.New("batch verify aggregated range proof failed"), -1
-// 	}
-// 	return true, nil, -1
-// }
+// VerifyBatchUsingBase verifies many proofs made with ProveUsingBase in one multi-exponent operation.
+// Each proof is checked against its own base (the first asset tag of its transaction), in the same way as VerifyUsingBase:
+// the first argument relates tHat, tauX, T1, T2 and the value commitments, while the second argument is the inner-product argument on the committed point p.
+// Both arguments of every proof are weighted by fresh random scalars and summed, so the batch passes only if (with overwhelming probability) every proof passes VerifyUsingBase.
+//
+// On failure, it returns the index of the first malformed proof if one is found, or -1 if only the combined equation failed;
+// callers are expected to fall back to VerifyUsingBase to isolate the offending proof.
+func VerifyBatchUsingBase(proofs []*AggregatedRangeProof, bases []*operation.Point) (bool, error, int) {
+	if len(proofs) != len(bases) {
+		return false, errors.New("number of proofs and bases mismatch"), -1
+	}
+	if len(proofs) == 0 {
+		return true, nil, -1
+	}
+	maxExp := privacy_util.MaxExp
+	baseH := operation.PedCom.G[operation.PedersenRandomnessIndex]
+
+	// LHS = sum alpha*(tHat-delta)*G_k + alpha*tauX*H + beta*(<s,g> + <s^-1 o y^-n,h> + ab*x'*u)
+	// RHS = sum alpha*(x*T1 + x^2*T2 + <z^2*z^n,V>) + beta*(<v^2,L> + <v^-2,R> + p)
+	sumTauX := new(operation.Scalar).FromUint64(0)
+	sumU := new(operation.Scalar).FromUint64(0)
+	gScalars := make([]*operation.Scalar, 0)
+	hScalars := make([]*operation.Scalar, 0)
+	lhsScalars := make([]*operation.Scalar, 0)
+	lhsPoints := make([]*operation.Point, 0)
+	rhsScalars := make([]*operation.Scalar, 0)
+	rhsPoints := make([]*operation.Point, 0)
+
+	for k, proof := range proofs {
+		if proof == nil || proof.innerProductProof == nil || bases[k] == nil {
+			return false, errors.New("batch verify aggregated range proof: proof or base is missing"), k
+		}
+		numValue := len(proof.cmsValue)
+		if numValue > privacy_util.MaxOutputCoin {
+			return false, errors.New("Must less than MaxOutputNumber"), k
+		}
+		numValuePad := roundUpPowTwo(numValue)
+		N := maxExp * numValuePad
+		logN := int(math.Log2(float64(N)))
+		L := proof.innerProductProof.l
+		R := proof.innerProductProof.r
+		if len(L) != logN || len(R) != logN {
+			return false, errors.New("batch verify aggregated range proof: invalid inner product proof length"), k
+		}
+		aggParam := setAggregateParams(N)
+
+		cmsValue := make([]*operation.Point, numValuePad)
+		copy(cmsValue, proof.cmsValue)
+		for i := numValue; i < numValuePad; i++ {
+			cmsValue[i] = new(operation.Point).Identity()
+		}
+
+		// recalculate challenge y, z, x
+		y := generateChallenge(aggParam.cs.ToBytesS(), []*operation.Point{proof.a, proof.s})
+		z := generateChallenge(y.ToBytesS(), []*operation.Point{proof.a, proof.s})
+		zSquare := new(operation.Scalar).Mul(z, z)
+		x := generateChallenge(z.ToBytesS(), []*operation.Point{proof.t1, proof.t2})
+		xSquare := new(operation.Scalar).Mul(x, x)
+
+		// random weights for the first and second arguments of this proof
+		alpha := operation.RandomScalar()
+		beta := operation.RandomScalar()
+
+		// first argument
+		yVector := powerVector(y, N)
+		deltaYZ, err := computeDeltaYZ(z, zSquare, yVector, N)
+		if err != nil {
+			return false, err, k
+		}
+		lhsScalars = append(lhsScalars, new(operation.Scalar).Mul(alpha, new(operation.Scalar).Sub(proof.tHat, deltaYZ)))
+		lhsPoints = append(lhsPoints, bases[k])
+		sumTauX.Add(sumTauX, new(operation.Scalar).Mul(alpha, proof.tauX))
+
+		rhsScalars = append(rhsScalars, new(operation.Scalar).Mul(alpha, x), new(operation.Scalar).Mul(alpha, xSquare))
+		rhsPoints = append(rhsPoints, proof.t1, proof.t2)
+		expVector := vectorMulScalar(powerVector(z, numValuePad), new(operation.Scalar).Mul(zSquare, alpha))
+		rhsScalars = append(rhsScalars, expVector...)
+		rhsPoints = append(rhsPoints, cmsValue...)
+
+		// second argument
+		hashCache := x.ToBytesS()
+		s := make([]*operation.Scalar, N)
+		sInverse := make([]*operation.Scalar, N)
+		for i := 0; i < N; i++ {
+			s[i] = new(operation.Scalar).Set(proof.innerProductProof.a)
+			sInverse[i] = new(operation.Scalar).Set(proof.innerProductProof.b)
+		}
+		for i := range L {
+			v := generateChallenge(hashCache, []*operation.Point{L[i], R[i]})
+			hashCache = v.ToBytesS()
+			vInverse := new(operation.Scalar).Invert(v)
+			rhsScalars = append(rhsScalars, new(operation.Scalar).Mul(beta, new(operation.Scalar).Mul(v, v)))
+			rhsScalars = append(rhsScalars, new(operation.Scalar).Mul(beta, new(operation.Scalar).Mul(vInverse, vInverse)))
+			rhsPoints = append(rhsPoints, L[i], R[i])
+
+			for j := 0; j < N; j++ {
+				if j&(1<<uint(logN-i-1)) != 0 {
+					s[j].Mul(s[j], v)
+					sInverse[j].Mul(sInverse[j], vInverse)
+				} else {
+					s[j].Mul(s[j], vInverse)
+					sInverse[j].Mul(sInverse[j], v)
+				}
+			}
+		}
+		rhsScalars = append(rhsScalars, beta)
+		rhsPoints = append(rhsPoints, proof.innerProductProof.p)
+
+		for len(gScalars) < N {
+			gScalars = append(gScalars, new(operation.Scalar).FromUint64(0))
+			hScalars = append(hScalars, new(operation.Scalar).FromUint64(0))
+		}
+		yInverse := new(operation.Scalar).Invert(y)
+		expyInverse := new(operation.Scalar).Set(beta)
+		for j := 0; j < N; j++ {
+			gScalars[j].Add(gScalars[j], new(operation.Scalar).Mul(s[j], beta))
+			hScalars[j].Add(hScalars[j], new(operation.Scalar).Mul(sInverse[j], expyInverse))
+			expyInverse.Mul(expyInverse, yInverse)
+		}
+		c := new(operation.Scalar).Mul(proof.innerProductProof.a, proof.innerProductProof.b)
+		c.Mul(c, operation.HashToScalar(x.ToBytesS()))
+		sumU.Add(sumU, new(operation.Scalar).Mul(c, beta))
+	}
+
+	lhsScalars = append(lhsScalars, sumTauX, sumU)
+	lhsPoints = append(lhsPoints, baseH, AggParam.u)
+	lhsScalars = append(lhsScalars, gScalars...)
+	lhsPoints = append(lhsPoints, AggParam.g[:len(gScalars)]...)
+	lhsScalars = append(lhsScalars, hScalars...)
+	lhsPoints = append(lhsPoints, AggParam.h[:len(hScalars)]...)
+
+	LHS := new(operation.Point).MultiScalarMult(lhsScalars, lhsPoints)
+	RHS := new(operation.Point).MultiScalarMult(rhsScalars, rhsPoints)
+	if !operation.IsPointEqual(LHS, RHS) {
+		Logger.Log.Errorf("batch verify aggregated range proof using base failed")
+		return false, errors.New("batch verify aggregated range proof using base failed"), -1
+	}
+	return true, nil, -1
+}
 
 // TransformWitnessToCAWitness does base transformation.
 // Our Bulletproof(G_r) scheme is parameterized by a base G_r.
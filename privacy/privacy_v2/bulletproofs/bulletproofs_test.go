@@ -492,3 +492,60 @@ func BenchmarkAggregatedRangeProof_Verify16(b *testing.B)  { benchmarkAggRangePr
 func BenchmarkAggregatedRangeProof_VerifyFaster16(b *testing.B) {
 	benchmarkAggRangeProof_VerifyFaster(16, b)
 }
+
+func TestAggregatedRangeProveVerifyBatchUsingBase(t *testing.T) {
+	count := 10
+	proofs := make([]*AggregatedRangeProof, 0)
+	bases := make([]*operation.Point, 0)
+
+	for i := 0; i < count; i++ {
+		wit := new(AggregatedRangeWitness)
+		numValue := rand.Intn(privacy_util.MaxOutputCoin) + 1
+		values := make([]uint64, numValue)
+		rands := make([]*operation.Scalar, numValue)
+
+		for i := range values {
+			values[i] = uint64(rand.Uint64())
+			rands[i] = operation.RandomScalar()
+		}
+		wit.Set(values, rands)
+
+		base := operation.RandomPoint()
+		proof, err := wit.ProveUsingBase(base)
+		assert.Equal(t, nil, err)
+		// ProveUsingBase leaves the commitments to the caller
+		cmsValue := make([]*operation.Point, numValue)
+		for i := range values {
+			cmsValue[i] = new(operation.Point).AddPedersen(new(operation.Scalar).FromUint64(values[i]), base, rands[i], operation.PedCom.G[operation.PedersenRandomnessIndex])
+		}
+		proof.SetCommitments(cmsValue)
+
+		res, err := proof.VerifyUsingBase(base)
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		proofs = append(proofs, proof)
+		bases = append(bases, base)
+	}
+	res, err, _ := VerifyBatchUsingBase(proofs, bases)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	// a proof checked against the wrong base must fail the whole batch
+	bases[3], bases[4] = bases[4], bases[3]
+	res, err, _ = VerifyBatchUsingBase(proofs, bases)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+	bases[3], bases[4] = bases[4], bases[3]
+
+	// so must a tampered inner product argument
+	proofs[5].innerProductProof.p = operation.RandomPoint()
+	res, _ = proofs[5].VerifyUsingBase(bases[5])
+	assert.Equal(t, false, res)
+	res, err, _ = VerifyBatchUsingBase(proofs, bases)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	_, err, _ = VerifyBatchUsingBase(proofs, bases[1:])
+	assert.NotEqual(t, nil, err)
+}
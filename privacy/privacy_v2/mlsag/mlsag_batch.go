@@ -0,0 +1,104 @@
+package mlsag
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BatchVerifier collects MLSAG signatures (with or without confidential assets) so that all signatures of a block can be verified in one call.
+// The challenges of a ring signature are chained through hashes, so unlike Bulletproofs they cannot be folded into a single multi-exponent check;
+// the collected signatures are instead verified concurrently, and the first invalid one is reported by its index.
+type BatchVerifier struct {
+	sigs     []*Sig
+	rings    []*Ring
+	messages [][]byte
+	isCA     []bool
+}
+
+// NewBatchVerifier returns an empty BatchVerifier.
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add queues a signature, its ring and the signed message. isCA selects VerifyConfidentialAsset instead of Verify.
+// It returns the index of the queued signature.
+func (b *BatchVerifier) Add(sig *Sig, K *Ring, message []byte, isCA bool) int {
+	b.sigs = append(b.sigs, sig)
+	b.rings = append(b.rings, K)
+	b.messages = append(b.messages, message)
+	b.isCA = append(b.isCA, isCA)
+	return len(b.sigs) - 1
+}
+
+// Len returns the number of queued signatures.
+func (b *BatchVerifier) Len() int {
+	return len(b.sigs)
+}
+
+func (b *BatchVerifier) verifyAt(i int) (bool, error) {
+	if b.sigs[i] == nil || b.rings[i] == nil {
+		return false, fmt.Errorf("MLSAG batch error: missing signature or ring at index %d", i)
+	}
+	if b.isCA[i] {
+		return VerifyConfidentialAsset(b.sigs[i], b.rings[i], b.messages[i])
+	}
+	return Verify(b.sigs[i], b.rings[i], b.messages[i])
+}
+
+// Verify checks every queued signature. It returns true and index -1 when all of them are valid;
+// otherwise it returns false, the error (if any) and the smallest index of an invalid signature.
+func (b *BatchVerifier) Verify() (bool, error, int) {
+	n := b.Len()
+	if n == 0 {
+		return true, nil, -1
+	}
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+
+	var mtx sync.Mutex
+	failedIndex := -1
+	var failedErr error
+	jobs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				mtx.Lock()
+				skip := failedIndex != -1 && failedIndex < i
+				mtx.Unlock()
+				if skip {
+					continue
+				}
+				valid, err := b.verifyAt(i)
+				if valid && err == nil {
+					continue
+				}
+				if err == nil {
+					err = fmt.Errorf("MLSAG batch error: signature at index %d is invalid", i)
+				}
+				mtx.Lock()
+				if failedIndex == -1 || i < failedIndex {
+					failedIndex = i
+					failedErr = err
+				}
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failedIndex != -1 {
+		return false, failedErr, failedIndex
+	}
+	return true, nil, -1
+}
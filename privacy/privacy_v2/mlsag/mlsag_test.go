@@ -81,4 +81,36 @@ func TestDumpSig(t *testing.T){
 		}
 		fmt.Printf("End Signature\n")
 	}
-}
\ No newline at end of file
+}
+
+func TestBatchVerifier(t *testing.T) {
+	batch := NewBatchVerifier()
+	ok, err, index := batch.Verify()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -1, index)
+
+	message := make([]byte, 32)
+	for i := 0; i < 8; i++ {
+		keyInputs := []*operation.Scalar{operation.RandomScalar(), operation.RandomScalar()}
+		ring := NewRandomRing(keyInputs, 4, i%4)
+		signer := NewMlsag(keyInputs, ring, i%4)
+		rand.Read(message)
+		s := common.HashH(message)
+		signature, err := signer.Sign(s[:])
+		assert.Equal(t, nil, err)
+		assert.Equal(t, i, batch.Add(signature, ring, s[:], false))
+	}
+	assert.Equal(t, 8, batch.Len())
+	ok, err, index = batch.Verify()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -1, index)
+
+	// signatures checked against the wrong messages are reported by the smallest index
+	batch.messages[5], batch.messages[2] = batch.messages[2], batch.messages[5]
+	ok, err, index = batch.Verify()
+	assert.Equal(t, false, ok)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 2, index)
+}
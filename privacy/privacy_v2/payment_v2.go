@@ -535,19 +535,26 @@ func (proof PaymentProofV2) verifyHasConfidentialAsset(isBatch bool) (bool, erro
 			return false, errors.New("Coin & Proof Commitments mismatch")
 		}
 	}
-	// for CA, batching is not supported
-	theBase, err := bulletproofs.GetFirstAssetTag(proof.outputCoins)
-	if err != nil {
-		return false, errhandler.NewPrivacyErr(errhandler.VerifyAggregatedProofFailedErr, err)
-	}
-	valid, err := proof.aggregatedRangeProof.VerifyUsingBase(theBase)
-	if !valid {
-		Logger.Log.Errorf("VERIFICATION PAYMENT PROOF V2: Multi-range failed")
-		return false, errhandler.NewPrivacyErr(errhandler.VerifyAggregatedProofFailedErr, err)
+	if !isBatch {
+		theBase, err := proof.GetRangeProofBase()
+		if err != nil {
+			return false, errhandler.NewPrivacyErr(errhandler.VerifyAggregatedProofFailedErr, err)
+		}
+		valid, err := proof.aggregatedRangeProof.VerifyUsingBase(theBase)
+		if !valid {
+			Logger.Log.Errorf("VERIFICATION PAYMENT PROOF V2: Multi-range failed")
+			return false, errhandler.NewPrivacyErr(errhandler.VerifyAggregatedProofFailedErr, err)
+		}
 	}
 	return true, nil
 }
 
+// GetRangeProofBase returns the base point that the Bulletproof of a Confidential Asset proof is verified against,
+// which is the asset tag of the first output coin.
+func (proof PaymentProofV2) GetRangeProofBase() (*operation.Point, error) {
+	return bulletproofs.GetFirstAssetTag(proof.outputCoins)
+}
+
 func (proof PaymentProofV2) verifyHasNoCA(isBatch bool) (bool, error) {
 	cmsValues := proof.aggregatedRangeProof.GetCommitments()
 	if len(proof.GetOutputCoins()) != len(cmsValues) {
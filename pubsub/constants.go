@@ -17,6 +17,7 @@ const (
 	RequestShardBlockByHeightTopic  = "requestshardblockbyheighttopic"
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
+	RequestEventTopic               = "requesteventtopic"
 	TestTopic                       = "testtopic"
 )
 
@@ -35,6 +36,7 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	RequestEventTopic,
 }
 
 type NodeRole struct {
@@ -0,0 +1,271 @@
+package btcrelaying
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// auxPowVersionBit marks the version of a merged mined block header
+	auxPowVersionBit = int32(1 << 8)
+	// auxPowChainIDShift is the position of the chain ID in a block version
+	auxPowChainIDShift = 16
+	// maxAuxPowChainBranchLen bounds the merkle tree of the chains merged
+	// mined in one parent block
+	maxAuxPowChainBranchLen = 30
+)
+
+// mergedMiningHeader is the magic preceding the chain merkle root in the
+// coinbase script of a parent block.
+var mergedMiningHeader = []byte{0xfa, 0xbe, 'm', 'm'}
+
+// AuxPowRelayingBlock is the relaying payload of a block of a merged mined
+// chain.  AuxPow is the hex encoded auxpow which follows the header of merged
+// mined blocks and is empty for the others.
+type AuxPowRelayingBlock struct {
+	wire.MsgBlock
+	AuxPow string `json:",omitempty"`
+}
+
+// AuxPow is the auxiliary proof of work of a merged mined block: a block of a
+// parent chain whose coinbase commits to the block through a merkle tree of
+// the merged mined chains.
+type AuxPow struct {
+	CoinbaseTx      *wire.MsgTx
+	ParentBlockHash chainhash.Hash
+	CoinbaseBranch  []chainhash.Hash
+	CoinbaseIndex   int32
+	ChainBranch     []chainhash.Hash
+	ChainIndex      int32
+	ParentHeader    wire.BlockHeader
+}
+
+// ParseAuxPow decodes a hex encoded auxpow.
+func ParseAuxPow(auxPowHex string) (*AuxPow, error) {
+	auxPowBytes, err := hex.DecodeString(auxPowHex)
+	if err != nil {
+		return nil, err
+	}
+	r := bytes.NewReader(auxPowBytes)
+	auxPow := new(AuxPow)
+	err = auxPow.Deserialize(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("auxpow has %d trailing bytes", r.Len())
+	}
+	return auxPow, nil
+}
+
+// Deserialize decodes an auxpow with the serialization of merged mining nodes.
+func (a *AuxPow) Deserialize(r io.Reader) error {
+	a.CoinbaseTx = new(wire.MsgTx)
+	err := a.CoinbaseTx.DeserializeNoWitness(r)
+	if err != nil {
+		return err
+	}
+	_, err = io.ReadFull(r, a.ParentBlockHash[:])
+	if err != nil {
+		return err
+	}
+	a.CoinbaseBranch, err = readMerkleBranch(r)
+	if err != nil {
+		return err
+	}
+	err = binary.Read(r, binary.LittleEndian, &a.CoinbaseIndex)
+	if err != nil {
+		return err
+	}
+	a.ChainBranch, err = readMerkleBranch(r)
+	if err != nil {
+		return err
+	}
+	err = binary.Read(r, binary.LittleEndian, &a.ChainIndex)
+	if err != nil {
+		return err
+	}
+	return a.ParentHeader.Deserialize(r)
+}
+
+// Serialize encodes an auxpow with the serialization of merged mining nodes.
+func (a *AuxPow) Serialize(w io.Writer) error {
+	err := a.CoinbaseTx.SerializeNoWitness(w)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(a.ParentBlockHash[:])
+	if err != nil {
+		return err
+	}
+	err = writeMerkleBranch(w, a.CoinbaseBranch)
+	if err != nil {
+		return err
+	}
+	err = binary.Write(w, binary.LittleEndian, a.CoinbaseIndex)
+	if err != nil {
+		return err
+	}
+	err = writeMerkleBranch(w, a.ChainBranch)
+	if err != nil {
+		return err
+	}
+	err = binary.Write(w, binary.LittleEndian, a.ChainIndex)
+	if err != nil {
+		return err
+	}
+	return a.ParentHeader.Serialize(w)
+}
+
+func readMerkleBranch(r io.Reader) ([]chainhash.Hash, error) {
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if count > maxAuxPowChainBranchLen {
+		return nil, fmt.Errorf("auxpow merkle branch of %d hashes is too long", count)
+	}
+	branch := make([]chainhash.Hash, count)
+	for i := range branch {
+		_, err = io.ReadFull(r, branch[i][:])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return branch, nil
+}
+
+func writeMerkleBranch(w io.Writer, branch []chainhash.Hash) error {
+	err := wire.WriteVarInt(w, 0, uint64(len(branch)))
+	if err != nil {
+		return err
+	}
+	for i := range branch {
+		_, err = w.Write(branch[i][:])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check verifies that the auxpow commits to the header of a block of the
+// chain chainID and that its parent block meets the target of the header.
+func (a *AuxPow) Check(header *wire.BlockHeader, chainID int32) error {
+	if a.CoinbaseTx == nil || len(a.CoinbaseTx.TxIn) == 0 {
+		return ruleError(ErrBadAuxPow, "auxpow coinbase tx has no input")
+	}
+	if a.CoinbaseIndex != 0 {
+		return ruleError(ErrBadAuxPow, "auxpow is not a generate")
+	}
+	if a.ParentHeader.Version>>auxPowChainIDShift == chainID {
+		return ruleError(ErrBadAuxPow, "auxpow parent has our chain ID")
+	}
+	if len(a.ChainBranch) > maxAuxPowChainBranchLen {
+		return ruleError(ErrBadAuxPow, "auxpow chain merkle branch is too long")
+	}
+
+	coinbaseHash := a.CoinbaseTx.TxHash()
+	if calcMerkleBranchRoot(coinbaseHash, a.CoinbaseBranch, a.CoinbaseIndex) != a.ParentHeader.MerkleRoot {
+		return ruleError(ErrBadAuxPow, "auxpow merkle root is incorrect")
+	}
+
+	// the chain merkle root is written in big endian in the coinbase script
+	chainRoot := calcMerkleBranchRoot(header.BlockHash(), a.ChainBranch, a.ChainIndex)
+	rootBytes := make([]byte, chainhash.HashSize)
+	for i := range rootBytes {
+		rootBytes[i] = chainRoot[chainhash.HashSize-1-i]
+	}
+	script := a.CoinbaseTx.TxIn[0].SignatureScript
+	pcHead := bytes.Index(script, mergedMiningHeader)
+	pc := bytes.Index(script, rootBytes)
+	if pc == -1 {
+		return ruleError(ErrBadAuxPow, "auxpow chain merkle root is missing in the parent coinbase")
+	}
+	if pcHead != -1 {
+		if bytes.Index(script[pcHead+1:], mergedMiningHeader) != -1 {
+			return ruleError(ErrBadAuxPow, "multiple merged mining headers in the parent coinbase")
+		}
+		if pcHead+len(mergedMiningHeader) != pc {
+			return ruleError(ErrBadAuxPow, "merged mining header is not just before the chain merkle root")
+		}
+	} else if pc > 20 {
+		return ruleError(ErrBadAuxPow, "auxpow chain merkle root must start in the first 20 bytes of the parent coinbase")
+	}
+
+	pc += len(rootBytes)
+	if len(script)-pc < 8 {
+		return ruleError(ErrBadAuxPow, "auxpow chain merkle tree size and nonce are missing in the parent coinbase")
+	}
+	size := binary.LittleEndian.Uint32(script[pc:])
+	merkleHeight := uint(len(a.ChainBranch))
+	if size != uint32(1)<<merkleHeight {
+		return ruleError(ErrBadAuxPow, "auxpow chain merkle branch size does not match the parent coinbase")
+	}
+	nonce := binary.LittleEndian.Uint32(script[pc+4:])
+	if a.ChainIndex != expectedAuxPowChainIndex(nonce, chainID, merkleHeight) {
+		return ruleError(ErrBadAuxPow, "auxpow chain index is not the expected one")
+	}
+
+	parentPowHash, err := ScryptPowHash(&a.ParentHeader)
+	if err != nil {
+		return err
+	}
+	return checkProofOfWorkHash(&parentPowHash, header.Bits)
+}
+
+// calcMerkleBranchRoot folds a merkle branch from a leaf at index.
+func calcMerkleBranchRoot(hash chainhash.Hash, branch []chainhash.Hash, index int32) chainhash.Hash {
+	if index == -1 {
+		return chainhash.Hash{}
+	}
+	var buf [chainhash.HashSize * 2]byte
+	for _, sibling := range branch {
+		if index&1 == 1 {
+			copy(buf[:chainhash.HashSize], sibling[:])
+			copy(buf[chainhash.HashSize:], hash[:])
+		} else {
+			copy(buf[:chainhash.HashSize], hash[:])
+			copy(buf[chainhash.HashSize:], sibling[:])
+		}
+		hash = chainhash.DoubleHashH(buf[:])
+		index >>= 1
+	}
+	return hash
+}
+
+// expectedAuxPowChainIndex is the slot of a chain in the chain merkle tree,
+// derived from the nonce in the parent coinbase so a parent block cannot
+// commit to two blocks of the same chain.
+func expectedAuxPowChainIndex(nonce uint32, chainID int32, merkleHeight uint) int32 {
+	rand := nonce
+	rand = rand*1103515245 + 12345
+	rand += uint32(chainID)
+	rand = rand*1103515245 + 12345
+	return int32(rand % (uint32(1) << merkleHeight))
+}
+
+// checkAuxPowVersion ensures a header of a merged mined chain carries the
+// chain ID and comes with an auxpow exactly when its version says so.
+func checkAuxPowVersion(header *wire.BlockHeader, chainID int32, hasAuxPow bool) error {
+	blockChainID := header.Version >> auxPowChainIDShift
+	isLegacy := header.Version == 1 || (header.Version == 2 && blockChainID == 0)
+	if !isLegacy && blockChainID != chainID {
+		str := fmt.Sprintf("block chain ID %d is not the expected %d", blockChainID, chainID)
+		return ruleError(ErrBadAuxPow, str)
+	}
+	isAuxPow := header.Version&auxPowVersionBit != 0
+	if isAuxPow && !hasAuxPow {
+		return ruleError(ErrBadAuxPow, "merged mined block must come with its auxpow")
+	}
+	if !isAuxPow && hasAuxPow {
+		return ruleError(ErrBadAuxPow, "auxpow is attached to a block which is not merged mined")
+	}
+	return nil
+}
@@ -104,6 +104,7 @@ type BlockChain struct {
 	sigCache            *txscript.SigCache
 	indexManager        IndexManager
 	hashCache           *txscript.HashCache
+	rules               ChainRules
 
 	// The following fields are calculated based upon the provided chain
 	// parameters.  They are also set when the instance is created and
@@ -1845,6 +1846,13 @@ type Config struct {
 	// This field can be nil if the caller is not interested in using a
 	// signature cache.
 	HashCache *txscript.HashCache
+
+	// Rules describes the header validation rules of Bitcoin-derived chains
+	// which differ from Bitcoin, such as their proof of work hash and
+	// difficulty adjustment algorithm.
+	//
+	// This field can be nil for the Bitcoin networks.
+	Rules *ChainRules
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1883,6 +1891,10 @@ func New(config *Config, genesisBlkHeight int32) (*BlockChain, error) {
 	targetTimePerBlock := int64(params.TargetTimePerBlock / time.Second)
 
 	adjustmentFactor := params.RetargetAdjustmentFactor
+	var rules ChainRules
+	if config.Rules != nil {
+		rules = *config.Rules
+	}
 	b := BlockChain{
 		genesisBlkHeight:    genesisBlkHeight,
 		checkpoints:         config.Checkpoints,
@@ -1898,6 +1910,7 @@ func New(config *Config, genesisBlkHeight int32) (*BlockChain, error) {
 		blocksPerRetarget:   int32(targetTimespan / targetTimePerBlock),
 		index:               newBlockIndex(config.DB, params),
 		hashCache:           config.HashCache,
+		rules:               rules,
 		bestChain:           newChainView(nil),
 		orphans:             make(map[chainhash.Hash]*orphanBlock),
 		prevOrphans:         make(map[chainhash.Hash][]*orphanBlock),
@@ -296,7 +296,7 @@ func TestReorganizeChainV2(t *testing.T) {
 
 	fmt.Println(genBlk)
 
-	chain, err := GetChainV2("haveblock",
+	chain, err := GetChainV2(t.TempDir(),
 		&chaincfg.MainNetParams, 0)
 	if err != nil {
 		t.Errorf("Failed to setup chain instance: %v", err)
@@ -610,7 +610,7 @@ func TestGetChainInfo(t *testing.T) {
 	}
 	fmt.Println(genBlk)
 
-	chain, err := GetChainV2("haveblock",
+	chain, err := GetChainV2(t.TempDir(),
 		&chaincfg.MainNetParams, 0)
 	if err != nil {
 		t.Errorf("Failed to get chain instance: %v", err)
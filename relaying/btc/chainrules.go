@@ -0,0 +1,223 @@
+package btcrelaying
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"golang.org/x/crypto/scrypt"
+)
+
+// RetargetRule identifies the difficulty adjustment algorithm of a relaying chain.
+type RetargetRule int
+
+const (
+	// BitcoinRetarget adjusts the difficulty every TargetTimespan based on
+	// the time spent by the last blocksPerRetarget-1 blocks.
+	BitcoinRetarget RetargetRule = iota
+
+	// LitecoinRetarget is BitcoinRetarget looking back the full retarget
+	// period, with the one bit shift litecoind applies to avoid overflowing
+	// its 256-bit intermediate target.
+	LitecoinRetarget
+
+	// DigiShieldRetarget adjusts the difficulty every block with a dampened
+	// and bounded timespan, as Dogecoin does since its DigiShield fork.
+	DigiShieldRetarget
+
+	// ASERTRetarget is the aserti3-2d algorithm used by Bitcoin Cash since
+	// its November 2020 upgrade.
+	ASERTRetarget
+)
+
+// ASERTAnchor is the reference block of the aserti3-2d algorithm.
+type ASERTAnchor struct {
+	Height        int32
+	Bits          uint32
+	PrevBlockTime int64 // timestamp of the parent of the anchor block
+}
+
+// ChainRules describes how a Bitcoin-derived relaying chain validates block
+// headers where it differs from Bitcoin.  The zero value is Bitcoin's rules.
+type ChainRules struct {
+	// ScryptPoW indicates the proof of work hash of a header is its scrypt
+	// hash instead of its block hash.
+	ScryptPoW bool
+
+	// Retarget is the difficulty adjustment algorithm of the chain.
+	Retarget RetargetRule
+
+	// ASERTAnchor and ASERTHalfLife (in seconds) parameterize ASERTRetarget.
+	ASERTAnchor   *ASERTAnchor
+	ASERTHalfLife int64
+
+	// AuxPowChainID is the chain ID of a merged mined chain.  Merged mined
+	// headers must come with an auxiliary proof of work committing to them.
+	// Zero disables merged mining.
+	AuxPowChainID int32
+}
+
+// ScryptPowHash returns the scrypt proof of work hash of a block header, as
+// used by Litecoin and Dogecoin.
+func ScryptPowHash(header *wire.BlockHeader) (chainhash.Hash, error) {
+	var buf bytes.Buffer
+	err := header.Serialize(&buf)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	key, err := scrypt.Key(buf.Bytes(), buf.Bytes(), 1024, 1, 1, chainhash.HashSize)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	var powHash chainhash.Hash
+	copy(powHash[:], key)
+	return powHash, nil
+}
+
+// checkProofOfWorkHash ensures a proof of work hash is not above the target
+// claimed by the bits of a header.
+func checkProofOfWorkHash(powHash *chainhash.Hash, bits uint32) error {
+	target := CompactToBig(bits)
+	hashNum := HashToBig(powHash)
+	if hashNum.Cmp(target) > 0 {
+		str := fmt.Sprintf("block proof of work hash of %064x is higher "+
+			"than expected max of %064x", hashNum, target)
+		return ruleError(ErrHighHash, str)
+	}
+	return nil
+}
+
+// checkBlockSanityWithRules performs checkBlockSanityV2 with the proof of
+// work rules of the relaying chain.  auxPow must be set for, and only for,
+// merged mined headers.
+func (b *BlockChain) checkBlockSanityWithRules(block *btcutil.Block, auxPow *AuxPow, flags BehaviorFlags) error {
+	header := &block.MsgBlock().Header
+	if b.rules.AuxPowChainID != 0 {
+		err := checkAuxPowVersion(header, b.rules.AuxPowChainID, auxPow != nil)
+		if err != nil {
+			return err
+		}
+	} else if auxPow != nil {
+		return ruleError(ErrBadAuxPow, "the relaying chain is not merged mined")
+	}
+
+	if !b.rules.ScryptPoW && auxPow == nil {
+		return checkBlockSanityV2(block, b.chainParams.PowLimit, b.timeSource, flags)
+	}
+
+	// the block hash is not the proof of work hash here, so only the range
+	// of the claimed target is checked with the header
+	err := checkBlockSanityV2(block, b.chainParams.PowLimit, b.timeSource, flags|BFNoPoWCheck)
+	if err != nil || flags&BFNoPoWCheck == BFNoPoWCheck {
+		return err
+	}
+	if auxPow != nil {
+		return auxPow.Check(header, b.rules.AuxPowChainID)
+	}
+	powHash, err := ScryptPowHash(header)
+	if err != nil {
+		return err
+	}
+	return checkProofOfWorkHash(&powHash, header.Bits)
+}
+
+// calcDigiShieldRequiredDifficulty calculates the required difficulty of the
+// block after lastNode with the DigiShield rules: the timespan of the last
+// block is dampened by 8 and bounded to [-25%, +50%] of the target timespan.
+func (b *BlockChain) calcDigiShieldRequiredDifficulty(lastNode *blockNode, header *wire.BlockHeader) (uint32, error) {
+	// testnets allow a minimum difficulty block once no block was mined
+	// for twice the target spacing
+	targetSpacing := int64(b.chainParams.TargetTimePerBlock / time.Second)
+	if b.chainParams.ReduceMinDifficulty && header.Timestamp.Unix() > lastNode.timestamp+2*targetSpacing {
+		return b.chainParams.PowLimitBits, nil
+	}
+
+	firstNode := lastNode.parent
+	if firstNode == nil {
+		// the parent of the relaying genesis block is not known
+		if lastNode.height == b.genesisBlkHeight {
+			return header.Bits, nil
+		}
+		return 0, AssertError("unable to obtain previous retarget block")
+	}
+
+	retargetTimespan := int64(b.chainParams.TargetTimespan / time.Second)
+	actualTimespan := lastNode.timestamp - firstNode.timestamp
+	modulatedTimespan := retargetTimespan + (actualTimespan-retargetTimespan)/8
+	minTimespan := retargetTimespan - retargetTimespan/4
+	maxTimespan := retargetTimespan + retargetTimespan/2
+	if modulatedTimespan < minTimespan {
+		modulatedTimespan = minTimespan
+	} else if modulatedTimespan > maxTimespan {
+		modulatedTimespan = maxTimespan
+	}
+
+	newTarget := CompactToBig(lastNode.bits)
+	newTarget.Mul(newTarget, big.NewInt(modulatedTimespan))
+	newTarget.Div(newTarget, big.NewInt(retargetTimespan))
+	if newTarget.Cmp(b.chainParams.PowLimit) > 0 {
+		newTarget.Set(b.chainParams.PowLimit)
+	}
+	return BigToCompact(newTarget), nil
+}
+
+// calcASERTRequiredDifficulty calculates the required difficulty of the
+// block after lastNode with the aserti3-2d algorithm: the target of the anchor
+// block is multiplied by 2^((timeDiff - spacing*(heightDiff+1)) / halfLife),
+// the fractional power being approximated by a cubic polynomial in fixed point.
+func (b *BlockChain) calcASERTRequiredDifficulty(lastNode *blockNode, header *wire.BlockHeader) (uint32, error) {
+	anchor := b.rules.ASERTAnchor
+	if anchor == nil || b.rules.ASERTHalfLife <= 0 {
+		return 0, AssertError("ASERT anchor of the relaying chain is not configured")
+	}
+
+	targetSpacing := int64(b.chainParams.TargetTimePerBlock / time.Second)
+	if b.chainParams.ReduceMinDifficulty && header.Timestamp.Unix() > lastNode.timestamp+2*targetSpacing {
+		return b.chainParams.PowLimitBits, nil
+	}
+
+	if lastNode.height < anchor.Height {
+		str := fmt.Sprintf("block at height %d precedes the ASERT anchor "+
+			"block at height %d", lastNode.height+1, anchor.Height)
+		return 0, ruleError(ErrUnexpectedDifficulty, str)
+	}
+	timeDiff := lastNode.timestamp - anchor.PrevBlockTime
+	heightDiff := int64(lastNode.height - anchor.Height)
+	nextTarget := calcASERTTarget(CompactToBig(anchor.Bits), targetSpacing, timeDiff, heightDiff,
+		b.chainParams.PowLimit, b.rules.ASERTHalfLife)
+	return BigToCompact(nextTarget), nil
+}
+
+// calcASERTTarget is the fixed point aserti3-2d target computation of
+// Bitcoin Cash nodes.
+func calcASERTTarget(refTarget *big.Int, targetSpacing, timeDiff, heightDiff int64, powLimit *big.Int, halfLife int64) *big.Int {
+	// the division truncates toward zero while the shift below floors,
+	// both as in the reference implementation
+	exponent := ((timeDiff - targetSpacing*(heightDiff+1)) * 65536) / halfLife
+	shifts := exponent >> 16
+	frac := uint64(uint16(exponent))
+	factor := 65536 + ((195766423245049*frac +
+		971821376*frac*frac +
+		5127*frac*frac*frac +
+		(1 << 47)) >> 48)
+
+	nextTarget := new(big.Int).Mul(refTarget, new(big.Int).SetUint64(factor))
+	shifts -= 16
+	if shifts <= 0 {
+		nextTarget.Rsh(nextTarget, uint(-shifts))
+	} else {
+		nextTarget.Lsh(nextTarget, uint(shifts))
+	}
+
+	if nextTarget.Sign() == 0 {
+		return big.NewInt(1)
+	}
+	if nextTarget.Cmp(powLimit) > 0 {
+		return new(big.Int).Set(powLimit)
+	}
+	return nextTarget
+}
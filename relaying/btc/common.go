@@ -186,6 +186,12 @@ func chainSetup(dbName string, params *chaincfg.Params) (*BlockChain, func(), er
 
 // GetChainV2 returns btcrelaying chain
 func GetChainV2(dbPath string, params *chaincfg.Params, genesisBlkHeight int32) (*BlockChain, error) {
+	return GetChainV2WithRules(dbPath, params, genesisBlkHeight, nil)
+}
+
+// GetChainV2WithRules opens or creates a relaying header chain of a Bitcoin-derived network
+// whose header validation rules differ from Bitcoin
+func GetChainV2WithRules(dbPath string, params *chaincfg.Params, genesisBlkHeight int32, rules *ChainRules) (*BlockChain, error) {
 	if !isSupportedDbType(testDbType) {
 		return nil, fmt.Errorf("unsupported db type %v", testDbType)
 	}
@@ -221,6 +227,7 @@ func GetChainV2(dbPath string, params *chaincfg.Params, genesisBlkHeight int32)
 		Checkpoints: nil,
 		TimeSource:  NewMedianTime(),
 		SigCache:    txscript.NewSigCache(1000),
+		Rules:       rules,
 	}, genesisBlkHeight)
 	if err != nil {
 		err := fmt.Errorf("failed to create chain instance: %v", err)
@@ -229,6 +229,14 @@ func (b *BlockChain) calcNextRequiredDifficulty(lastNode *blockNode, header *wir
 		return b.chainParams.PowLimitBits, nil
 	}
 
+	// Chains which retarget every block have their own algorithms.
+	switch b.rules.Retarget {
+	case DigiShieldRetarget:
+		return b.calcDigiShieldRequiredDifficulty(lastNode, header)
+	case ASERTRetarget:
+		return b.calcASERTRequiredDifficulty(lastNode, header)
+	}
+
 	// Return the previous block's difficulty requirements if this block
 	// is not at a difficulty retarget interval.
 	if (lastNode.height+1)%b.blocksPerRetarget != 0 {
@@ -256,10 +264,15 @@ func (b *BlockChain) calcNextRequiredDifficulty(lastNode *blockNode, header *wir
 	}
 
 	// Get the block node at the previous retarget (targetTimespan days
-	// worth of blocks).
-	firstNode := lastNode.RelativeAncestor(b.blocksPerRetarget - 1)
+	// worth of blocks).  Litecoin goes back the full period unless it is
+	// the first retarget after the real genesis block.
+	lookback := b.blocksPerRetarget - 1
+	if b.rules.Retarget == LitecoinRetarget && lastNode.height+1 != b.blocksPerRetarget {
+		lookback = b.blocksPerRetarget
+	}
+	firstNode := lastNode.RelativeAncestor(lookback)
 	if firstNode == nil {
-		if b.genesisBlkHeight > lastNode.height+1-b.blocksPerRetarget {
+		if b.genesisBlkHeight > lastNode.height-lookback {
 			return header.Bits, nil
 		}
 		return 0, AssertError("unable to obtain previous retarget block")
@@ -281,9 +294,19 @@ func (b *BlockChain) calcNextRequiredDifficulty(lastNode *blockNode, header *wir
 	// rounded down.  Bitcoind also uses integer division to calculate this
 	// result.
 	oldTarget := CompactToBig(lastNode.bits)
-	newTarget := new(big.Int).Mul(oldTarget, big.NewInt(adjustedTimespan))
+	// litecoind halves large targets before the multiplication so they fit
+	// in 256 bits, which changes the rounding of the result
+	shift := b.rules.Retarget == LitecoinRetarget && oldTarget.BitLen() > b.chainParams.PowLimit.BitLen()-1
+	newTarget := new(big.Int).Set(oldTarget)
+	if shift {
+		newTarget.Rsh(newTarget, 1)
+	}
+	newTarget.Mul(newTarget, big.NewInt(adjustedTimespan))
 	targetTimeSpan := int64(b.chainParams.TargetTimespan / time.Second)
 	newTarget.Div(newTarget, big.NewInt(targetTimeSpan))
+	if shift {
+		newTarget.Lsh(newTarget, 1)
+	}
 
 	// Limit new value to the proof of work limit.
 	if newTarget.Cmp(b.chainParams.PowLimit) > 0 {
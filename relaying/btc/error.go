@@ -216,6 +216,10 @@ const (
 	// current chain tip. This is not a block validation rule, but is required
 	// for block proposals submitted via getblocktemplate RPC.
 	ErrPrevBlockNotBest
+
+	// ErrBadAuxPow indicates the auxiliary proof of work of a merged mined
+	// block is missing, malformed or does not commit to the block.
+	ErrBadAuxPow
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -263,6 +267,7 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrPreviousBlockUnknown:      "ErrPreviousBlockUnknown",
 	ErrInvalidAncestorBlock:      "ErrInvalidAncestorBlock",
 	ErrPrevBlockNotBest:          "ErrPrevBlockNotBest",
+	ErrBadAuxPow:                 "ErrBadAuxPow",
 }
 
 // String returns the ErrorCode as a human-readable name.
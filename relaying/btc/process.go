@@ -126,6 +126,19 @@ func (b *BlockChain) processOrphans(hash *chainhash.Hash, flags BehaviorFlags) e
 }
 
 func (b *BlockChain) ProcessBlockV2(block *btcutil.Block, flags BehaviorFlags) (bool, bool, error) {
+	return b.processBlockV2(block, nil, flags)
+}
+
+// ProcessAuxPowBlockV2 is ProcessBlockV2 for a merged mined block, whose proof
+// of work is the auxiliary proof of work of a parent chain block.
+func (b *BlockChain) ProcessAuxPowBlockV2(block *btcutil.Block, auxPow *AuxPow, flags BehaviorFlags) (bool, bool, error) {
+	if auxPow == nil {
+		return false, false, ruleError(ErrBadAuxPow, "auxpow of merged mined block is missing")
+	}
+	return b.processBlockV2(block, auxPow, flags)
+}
+
+func (b *BlockChain) processBlockV2(block *btcutil.Block, auxPow *AuxPow, flags BehaviorFlags) (bool, bool, error) {
 	b.chainLock.Lock()
 	defer b.chainLock.Unlock()
 
@@ -151,7 +164,7 @@ func (b *BlockChain) ProcessBlockV2(block *btcutil.Block, flags BehaviorFlags) (
 	// }
 
 	// Perform preliminary sanity checks on the block and its transactions.
-	err = checkBlockSanityV2(block, b.chainParams.PowLimit, b.timeSource, flags)
+	err = b.checkBlockSanityWithRules(block, auxPow, flags)
 	if err != nil {
 		return false, false, err
 	}
@@ -0,0 +1,244 @@
+package btcrelaying
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Header chains of the Bitcoin-derived networks relayed besides Bitcoin.
+// Their relaying genesis blocks are the genesis blocks of the networks.
+// @@Note: need to update before deploying
+// The Dogecoin and Bitcoin Cash relaying chains must start from a block which
+// already follows the difficulty adjustment algorithm of ChainRules, that is
+// after DigiShield for Dogecoin and at the ASERT anchor for Bitcoin Cash.
+
+// scryptPowLimit is the highest proof of work value a Litecoin or Dogecoin
+// block can have, 2^236 - 1.
+var scryptPowLimit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 236), big.NewInt(1))
+
+var (
+	// LTCMainNetParams defines the network parameters of the Litecoin main network.
+	LTCMainNetParams = newLTCMainNetParams()
+
+	// LTCTestNet4Params defines the network parameters of the Litecoin test network (version 4).
+	LTCTestNet4Params = newLTCTestNet4Params()
+
+	// DOGEMainNetParams defines the network parameters of the Dogecoin main network.
+	DOGEMainNetParams = newDOGEMainNetParams()
+
+	// DOGETestNetParams defines the network parameters of the Dogecoin test network.
+	DOGETestNetParams = newDOGETestNetParams()
+
+	// BCHMainNetParams defines the network parameters of the Bitcoin Cash main network.
+	BCHMainNetParams = newBCHMainNetParams()
+
+	// BCHTestNet3Params defines the network parameters of the Bitcoin Cash test network (version 3).
+	BCHTestNet3Params = newBCHTestNet3Params()
+)
+
+var (
+	// LTCChainRules are the header validation rules of the Litecoin networks.
+	LTCChainRules = ChainRules{
+		ScryptPoW: true,
+		Retarget:  LitecoinRetarget,
+	}
+
+	// DOGEChainRules are the header validation rules of the Dogecoin networks.
+	DOGEChainRules = ChainRules{
+		ScryptPoW:     true,
+		Retarget:      DigiShieldRetarget,
+		AuxPowChainID: 0x62,
+	}
+
+	// BCHMainNetChainRules are the header validation rules of the Bitcoin Cash main network.
+	BCHMainNetChainRules = ChainRules{
+		Retarget: ASERTRetarget,
+		ASERTAnchor: &ASERTAnchor{
+			Height:        661647,
+			Bits:          0x1804dafe,
+			PrevBlockTime: 1605447844,
+		},
+		ASERTHalfLife: 2 * 24 * 60 * 60,
+	}
+
+	// BCHTestNet3ChainRules are the header validation rules of the Bitcoin Cash test network (version 3).
+	BCHTestNet3ChainRules = ChainRules{
+		Retarget: ASERTRetarget,
+		ASERTAnchor: &ASERTAnchor{
+			Height:        1421481,
+			Bits:          0x1d00ffff,
+			PrevBlockTime: 1605445400,
+		},
+		ASERTHalfLife: 60 * 60,
+	}
+)
+
+func init() {
+	// Litecoin addresses are only decoded by btcutil for registered networks
+	for _, params := range []*chaincfg.Params{LTCMainNetParams, LTCTestNet4Params} {
+		err := chaincfg.Register(params)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func newGenesisBlock(genesisHashStr string, merkleRootStr string, timestamp int64, bits uint32, nonce uint32) (*wire.MsgBlock, *chainhash.Hash) {
+	genesisHash, _ := chainhash.NewHashFromStr(genesisHashStr)
+	merkleRoot, _ := chainhash.NewHashFromStr(merkleRootStr)
+	var genesisBlock = wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:    int32(1),
+			PrevBlock:  chainhash.Hash{},
+			MerkleRoot: *merkleRoot,
+			Timestamp:  time.Unix(timestamp, 0),
+			Bits:       bits,
+			Nonce:      nonce,
+		},
+		Transactions: []*wire.MsgTx{},
+	}
+	return &genesisBlock, genesisHash
+}
+
+func newLTCMainNetParams() *chaincfg.Params {
+	params := chaincfg.MainNetParams
+	params.Name = "ltcmainnet"
+	params.Net = wire.BitcoinNet(0xdbb6c0fb)
+	params.DefaultPort = "9333"
+	params.DNSSeeds = nil
+	params.GenesisBlock, params.GenesisHash = newGenesisBlock(
+		"12a765e31ffd4059bada1e25190f6e98c99d9714d334efa41a195a7e7e04bfe2",
+		"97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9",
+		1317972665, 0x1e0ffff0, 2084524493,
+	)
+	params.PowLimit = scryptPowLimit
+	params.PowLimitBits = 0x1e0fffff
+	params.BIP0034Height = 710000
+	params.BIP0065Height = 918684
+	params.BIP0066Height = 811879
+	params.SubsidyReductionInterval = 840000
+	params.TargetTimespan = time.Hour * 24 * 7 / 2  // 3.5 days
+	params.TargetTimePerBlock = time.Minute * 5 / 2 // 2.5 minutes
+	params.Checkpoints = nil
+	params.Bech32HRPSegwit = "ltc"
+	params.PubKeyHashAddrID = 0x30
+	params.ScriptHashAddrID = 0x32
+	params.PrivateKeyID = 0xb0
+	params.HDCoinType = 2
+	return &params
+}
+
+func newLTCTestNet4Params() *chaincfg.Params {
+	params := chaincfg.TestNet3Params
+	params.Name = "ltctestnet4"
+	params.Net = wire.BitcoinNet(0xf1c8d2fd)
+	params.DefaultPort = "19335"
+	params.DNSSeeds = nil
+	params.GenesisBlock, params.GenesisHash = newGenesisBlock(
+		"4966625a4b2851d9fdee139e56211a0d88575f59ed816ff5e6a63deb4e3e29a0",
+		"97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9",
+		1486949366, 0x1e0ffff0, 293345,
+	)
+	params.PowLimit = scryptPowLimit
+	params.PowLimitBits = 0x1e0fffff
+	params.BIP0034Height = 76
+	params.BIP0065Height = 76
+	params.BIP0066Height = 76
+	params.SubsidyReductionInterval = 840000
+	params.TargetTimespan = time.Hour * 24 * 7 / 2  // 3.5 days
+	params.TargetTimePerBlock = time.Minute * 5 / 2 // 2.5 minutes
+	params.ReduceMinDifficulty = true
+	params.MinDiffReductionTime = time.Minute * 5 // TargetTimePerBlock * 2
+	params.Checkpoints = nil
+	params.Bech32HRPSegwit = "tltc"
+	params.PubKeyHashAddrID = 0x6f
+	params.ScriptHashAddrID = 0x3a
+	params.PrivateKeyID = 0xef
+	params.HDCoinType = 1
+	return &params
+}
+
+func newDOGEMainNetParams() *chaincfg.Params {
+	params := chaincfg.MainNetParams
+	params.Name = "dogemainnet"
+	params.Net = wire.BitcoinNet(0xc0c0c0c0)
+	params.DefaultPort = "22556"
+	params.DNSSeeds = nil
+	params.GenesisBlock, params.GenesisHash = newGenesisBlock(
+		"1a91e3dace36e2be3bf030a65679fe821aa1d6ef92e7c9902eb318182c355691",
+		"5b2a3f53f605d62c53e62932dac6925e3d74afa5a4b459745c36d42d0ed26a69",
+		1386325540, 0x1e0ffff0, 99943,
+	)
+	params.PowLimit = scryptPowLimit
+	params.PowLimitBits = 0x1e0fffff
+	params.BIP0034Height = 1034383
+	params.BIP0065Height = 3464751
+	params.BIP0066Height = 1034383
+	params.CoinbaseMaturity = 240
+	params.TargetTimespan = time.Minute // DigiShield retargets every block
+	params.TargetTimePerBlock = time.Minute
+	params.Checkpoints = nil
+	params.Bech32HRPSegwit = ""
+	params.PubKeyHashAddrID = 0x1e
+	params.ScriptHashAddrID = 0x16
+	params.PrivateKeyID = 0x9e
+	params.HDPrivateKeyID = [4]byte{0x02, 0xfa, 0xc3, 0x98}
+	params.HDPublicKeyID = [4]byte{0x02, 0xfa, 0xca, 0xfd}
+	params.HDCoinType = 3
+	return &params
+}
+
+func newDOGETestNetParams() *chaincfg.Params {
+	params := chaincfg.TestNet3Params
+	params.Name = "dogetestnet"
+	params.Net = wire.BitcoinNet(0xdcb7c1fc)
+	params.DefaultPort = "44556"
+	params.DNSSeeds = nil
+	params.GenesisBlock, params.GenesisHash = newGenesisBlock(
+		"bb0a78264637406b6360aad926284d544d7049f45189db5664f3c4d07350559e",
+		"5b2a3f53f605d62c53e62932dac6925e3d74afa5a4b459745c36d42d0ed26a69",
+		1391503289, 0x1e0ffff0, 997879,
+	)
+	params.PowLimit = scryptPowLimit
+	params.PowLimitBits = 0x1e0fffff
+	params.BIP0034Height = 708658
+	params.BIP0065Height = 1854705
+	params.BIP0066Height = 708658
+	params.CoinbaseMaturity = 240
+	params.TargetTimespan = time.Minute // DigiShield retargets every block
+	params.TargetTimePerBlock = time.Minute
+	params.ReduceMinDifficulty = true
+	params.MinDiffReductionTime = time.Minute * 2 // TargetTimePerBlock * 2
+	params.Checkpoints = nil
+	params.Bech32HRPSegwit = ""
+	params.PubKeyHashAddrID = 0x71
+	params.ScriptHashAddrID = 0xc4
+	params.PrivateKeyID = 0xf1
+	params.HDCoinType = 1
+	return &params
+}
+
+func newBCHMainNetParams() *chaincfg.Params {
+	params := chaincfg.MainNetParams
+	params.Name = "bchmainnet"
+	params.Net = wire.BitcoinNet(0xe8f3e1e3)
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.Bech32HRPSegwit = ""
+	params.HDCoinType = 145
+	return &params
+}
+
+func newBCHTestNet3Params() *chaincfg.Params {
+	params := chaincfg.TestNet3Params
+	params.Name = "bchtestnet3"
+	params.Net = wire.BitcoinNet(0xf4f3e5f4)
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.Bech32HRPSegwit = ""
+	return &params
+}
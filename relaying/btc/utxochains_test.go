@@ -0,0 +1,179 @@
+package btcrelaying
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUTXOChainGenesisBlocks(t *testing.T) {
+	for _, params := range []*chaincfg.Params{
+		LTCMainNetParams, LTCTestNet4Params,
+		DOGEMainNetParams, DOGETestNetParams,
+		BCHMainNetParams, BCHTestNet3Params,
+	} {
+		assert.Equal(t, *params.GenesisHash, params.GenesisBlock.Header.BlockHash(), params.Name)
+	}
+
+	// the litecoin genesis block meets its target with its scrypt hash only
+	header := LTCMainNetParams.GenesisBlock.Header
+	powHash, err := ScryptPowHash(&header)
+	assert.Nil(t, err)
+	assert.Nil(t, checkProofOfWorkHash(&powHash, header.Bits))
+	blockHash := header.BlockHash()
+	assert.NotNil(t, checkProofOfWorkHash(&blockHash, header.Bits))
+}
+
+func TestLTCAddresses(t *testing.T) {
+	addr, err := btcutil.DecodeAddress("ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9", LTCMainNetParams)
+	assert.Nil(t, err)
+	assert.True(t, addr.IsForNet(LTCMainNetParams))
+	assert.False(t, addr.IsForNet(&chaincfg.MainNetParams))
+
+	addr, err = btcutil.DecodeAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", LTCMainNetParams)
+	assert.Nil(t, err)
+	assert.False(t, addr.IsForNet(LTCMainNetParams))
+}
+
+func TestDigiShieldRetarget(t *testing.T) {
+	b := &BlockChain{chainParams: DOGEMainNetParams, rules: DOGEChainRules}
+	bits := uint32(0x1b01c45a)
+	first := &blockNode{height: 100, timestamp: 1600000000, bits: bits}
+	header := &wire.BlockHeader{Timestamp: time.Unix(1600000120, 0)}
+
+	testCases := []struct {
+		spacing  int64
+		timespan int64
+	}{
+		{60, 60},
+		{68, 61},    // dampened by 8
+		{0, 53},     // 60 - 60/8
+		{1000, 90},  // bounded to +50%
+		{-1000, 45}, // bounded to -25%
+	}
+	for _, tc := range testCases {
+		last := &blockNode{height: 101, timestamp: first.timestamp + tc.spacing, bits: bits, parent: first}
+		nextBits, err := b.calcDigiShieldRequiredDifficulty(last, header)
+		assert.Nil(t, err)
+		expected := new(big.Int).Mul(CompactToBig(bits), big.NewInt(tc.timespan))
+		expected.Div(expected, big.NewInt(60))
+		assert.Equal(t, BigToCompact(expected), nextBits)
+	}
+
+	// testnet allows a minimum difficulty block after twice the spacing
+	b.chainParams = DOGETestNetParams
+	last := &blockNode{height: 101, timestamp: 1600000060, bits: bits, parent: first}
+	nextBits, err := b.calcDigiShieldRequiredDifficulty(last, &wire.BlockHeader{Timestamp: time.Unix(1600000181, 0)})
+	assert.Nil(t, err)
+	assert.Equal(t, DOGETestNetParams.PowLimitBits, nextBits)
+}
+
+func TestASERTRetarget(t *testing.T) {
+	anchor := BCHMainNetChainRules.ASERTAnchor
+	b := &BlockChain{chainParams: BCHMainNetParams, rules: BCHMainNetChainRules}
+	header := &wire.BlockHeader{}
+
+	// the block after the anchor keeps its target when it is on schedule
+	last := &blockNode{height: anchor.Height, timestamp: anchor.PrevBlockTime + 600}
+	nextBits, err := b.calcASERTRequiredDifficulty(last, header)
+	assert.Nil(t, err)
+	assert.Equal(t, anchor.Bits, nextBits)
+
+	// the target doubles every half life behind schedule
+	last = &blockNode{height: anchor.Height + 10, timestamp: anchor.PrevBlockTime + 11*600 + 2*BCHMainNetChainRules.ASERTHalfLife}
+	nextBits, err = b.calcASERTRequiredDifficulty(last, header)
+	assert.Nil(t, err)
+	assert.Equal(t, BigToCompact(new(big.Int).Lsh(CompactToBig(anchor.Bits), 2)), nextBits)
+
+	// and halves every half life ahead of schedule
+	last = &blockNode{height: anchor.Height + 300, timestamp: anchor.PrevBlockTime + 301*600 - BCHMainNetChainRules.ASERTHalfLife}
+	nextBits, err = b.calcASERTRequiredDifficulty(last, header)
+	assert.Nil(t, err)
+	assert.Equal(t, BigToCompact(new(big.Int).Rsh(CompactToBig(anchor.Bits), 1)), nextBits)
+
+	// blocks before the anchor are not supported
+	last = &blockNode{height: anchor.Height - 1, timestamp: anchor.PrevBlockTime}
+	_, err = b.calcASERTRequiredDifficulty(last, header)
+	assert.NotNil(t, err)
+}
+
+func newTestAuxPow(t *testing.T, header *wire.BlockHeader, chainID int32) *AuxPow {
+	chainRoot := header.BlockHash()
+	script := append([]byte{}, mergedMiningHeader...)
+	for i := chainhash.HashSize - 1; i >= 0; i-- {
+		script = append(script, chainRoot[i])
+	}
+	var sizeAndNonce [8]byte
+	binary.LittleEndian.PutUint32(sizeAndNonce[:4], 1)
+	script = append(script, sizeAndNonce[:]...)
+
+	coinbaseTx := wire.NewMsgTx(1)
+	coinbaseTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), script, nil))
+	coinbaseTx.AddTxOut(wire.NewTxOut(0, []byte{0x51}))
+
+	auxPow := &AuxPow{
+		CoinbaseTx: coinbaseTx,
+		ParentHeader: wire.BlockHeader{
+			Version:    0x20000000,
+			MerkleRoot: coinbaseTx.TxHash(),
+			Timestamp:  header.Timestamp,
+			Bits:       header.Bits,
+		},
+	}
+	// mine the parent block
+	for ; ; auxPow.ParentHeader.Nonce++ {
+		powHash, err := ScryptPowHash(&auxPow.ParentHeader)
+		assert.Nil(t, err)
+		if checkProofOfWorkHash(&powHash, header.Bits) == nil {
+			break
+		}
+	}
+	return auxPow
+}
+
+func TestAuxPow(t *testing.T) {
+	chainID := DOGEChainRules.AuxPowChainID
+	header := &wire.BlockHeader{
+		Version:   chainID<<auxPowChainIDShift | auxPowVersionBit | 4,
+		Timestamp: time.Unix(1600000000, 0),
+		Bits:      0x207fffff,
+	}
+	auxPow := newTestAuxPow(t, header, chainID)
+	assert.Nil(t, auxPow.Check(header, chainID))
+	assert.Nil(t, checkAuxPowVersion(header, chainID, true))
+	assert.NotNil(t, checkAuxPowVersion(header, chainID, false))
+	assert.NotNil(t, checkAuxPowVersion(&wire.BlockHeader{Version: 4}, chainID, false))
+	assert.Nil(t, checkAuxPowVersion(&wire.BlockHeader{Version: 1}, chainID, false))
+
+	// round trip of the serialization
+	var buf bytes.Buffer
+	assert.Nil(t, auxPow.Serialize(&buf))
+	parsed, err := ParseAuxPow(hex.EncodeToString(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, auxPow.ParentHeader, parsed.ParentHeader)
+	assert.Nil(t, parsed.Check(header, chainID))
+
+	// the auxpow does not commit to another block
+	otherHeader := *header
+	otherHeader.Nonce++
+	assert.NotNil(t, auxPow.Check(&otherHeader, chainID))
+
+	// the parent block can not be of the same chain
+	sameChainAuxPow := *auxPow
+	sameChainAuxPow.ParentHeader.Version = header.Version
+	assert.NotNil(t, sameChainAuxPow.Check(header, chainID))
+
+	// the chain merkle tree size must match the chain merkle branch
+	longBranchAuxPow := *auxPow
+	longBranchAuxPow.ChainBranch = []chainhash.Hash{{}}
+	assert.NotNil(t, longBranchAuxPow.Check(header, chainID))
+}
@@ -0,0 +1,14 @@
+package btcrelayer
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type BTCRelayerLogger struct {
+	log common.Logger
+}
+
+func (l *BTCRelayerLogger) Init(inst common.Logger) {
+	l.log = inst
+}
+
+// Global instant to use
+var Logger = BTCRelayerLogger{}
@@ -0,0 +1,263 @@
+// Package btcrelayer relays the headers of a bitcoind compatible node to the BTC relaying chain of Incognito.
+// The relayer compares the best chain of bitcoind with the relaying chain the beacon built from the relayed
+// headers (getbtcrelayingbeststate) and submits the missing headers in order with RelayingBTCHeaderMeta txs.
+package btcrelayer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+const (
+	DefaultPollInterval    = 30 * time.Second
+	DefaultBatchSize       = 5
+	DefaultResubmitTimeout = 10 * time.Minute
+	DefaultMinBackoff      = time.Minute
+	DefaultMaxBackoff      = 30 * time.Minute
+	// maxReorgDepth bounds the search of the last block shared by bitcoind and the relaying chain
+	maxReorgDepth = 144
+)
+
+// BTCClient reads the best chain of a bitcoind compatible node
+type BTCClient interface {
+	GetBlockCount() (int32, error)
+	GetBlockHash(height int32) (*chainhash.Hash, error)
+	GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader, error)
+}
+
+// RelayingChain is the BTC relaying chain of the node, *btcrelaying.BlockChain implements it
+type RelayingChain interface {
+	BestSnapshot() *btcrelaying.BestState
+	BlockHashByHeight(blockHeight int32) (*chainhash.Hash, error)
+}
+
+// HeaderSubmitter sends a RelayingBTCHeaderMeta tx for an encoded header and returns its tx id
+type HeaderSubmitter interface {
+	SubmitBTCHeader(header string, blockHeight uint64) (string, error)
+}
+
+type Config struct {
+	PollInterval time.Duration
+	// BatchSize is the number of headers submitted in a round, every tx spends a different output coin
+	// of the relayer key so it should not exceed the number of its unspent coins
+	BatchSize int
+	// ResubmitTimeout is the delay before a submitted header not yet in the relaying chain is submitted again
+	ResubmitTimeout time.Duration
+	// The relayer waits from MinBackoff, doubled up to MaxBackoff for each new reorg, before relaying the new branch
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultConfig returns the config used for the unset fields of a relayer config
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:    DefaultPollInterval,
+		BatchSize:       DefaultBatchSize,
+		ResubmitTimeout: DefaultResubmitTimeout,
+		MinBackoff:      DefaultMinBackoff,
+		MaxBackoff:      DefaultMaxBackoff,
+	}
+}
+
+type submission struct {
+	hash chainhash.Hash
+	txID string
+	at   time.Time
+}
+
+// reorg is a fork of the relaying chain from the best chain of bitcoind after height ancestor
+type reorg struct {
+	ancestor int32
+	until    time.Time
+}
+
+// Relayer polls bitcoind and submits the headers the relaying chain misses
+type Relayer struct {
+	client    BTCClient
+	chain     RelayingChain
+	submitter HeaderSubmitter
+	config    Config
+
+	mu        sync.Mutex
+	submitted map[int32]*submission
+	reorg     *reorg
+	backoff   time.Duration
+	now       func() time.Time
+
+	quit    chan struct{}
+	stopped sync.WaitGroup
+}
+
+func NewRelayer(client BTCClient, chain RelayingChain, submitter HeaderSubmitter, config Config) *Relayer {
+	defaultConfig := DefaultConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultConfig.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultConfig.BatchSize
+	}
+	if config.ResubmitTimeout <= 0 {
+		config.ResubmitTimeout = defaultConfig.ResubmitTimeout
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = defaultConfig.MinBackoff
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = config.MinBackoff
+	}
+	return &Relayer{
+		client:    client,
+		chain:     chain,
+		submitter: submitter,
+		config:    config,
+		submitted: make(map[int32]*submission),
+		backoff:   config.MinBackoff,
+		now:       time.Now,
+	}
+}
+
+// Start polls bitcoind every PollInterval until Stop is called
+func (r *Relayer) Start() {
+	r.quit = make(chan struct{})
+	r.stopped.Add(1)
+	go func() {
+		defer r.stopped.Done()
+		ticker := time.NewTicker(r.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			if err := r.Step(); err != nil {
+				Logger.log.Errorf("[BTC relayer] %v", err)
+			}
+			select {
+			case <-r.quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *Relayer) Stop() {
+	if r.quit == nil {
+		return
+	}
+	close(r.quit)
+	r.stopped.Wait()
+	r.quit = nil
+}
+
+// Step runs one polling round: it submits in order the headers of bitcoind following the best block of the
+// relaying chain, or the last block both chains share when bitcoind reorganized once the backoff has elapsed
+func (r *Relayer) Step() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	best := r.chain.BestSnapshot()
+	if best == nil {
+		return errors.New("BTC relaying chain has no best state")
+	}
+	tip, err := r.client.GetBlockCount()
+	if err != nil {
+		return err
+	}
+	if tip < best.Height {
+		// bitcoind is still syncing, it does not know the blocks of the relaying chain yet
+		Logger.log.Infof("[BTC relayer] bitcoind height %v is behind the relaying chain height %v", tip, best.Height)
+		return nil
+	}
+
+	ancestor, err := r.findCommonAncestor(best.Height)
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	from := best.Height + 1
+	if ancestor < best.Height {
+		if r.reorg == nil || r.reorg.ancestor != ancestor {
+			r.reorg = &reorg{ancestor: ancestor, until: now.Add(r.backoff)}
+			Logger.log.Infof("[BTC relayer] bitcoind reorganized after height %v, relaying chain height %v, wait %v",
+				ancestor, best.Height, r.backoff)
+			r.backoff *= 2
+			if r.backoff > r.config.MaxBackoff {
+				r.backoff = r.config.MaxBackoff
+			}
+			return nil
+		}
+		if now.Before(r.reorg.until) {
+			return nil
+		}
+		// the relaying chain switches to the branch of bitcoind once it has more work
+		from = ancestor + 1
+	} else if r.reorg != nil {
+		r.reorg = nil
+		r.backoff = r.config.MinBackoff
+	}
+
+	for height := range r.submitted {
+		if height <= ancestor {
+			delete(r.submitted, height)
+		}
+	}
+
+	for height := from; height <= tip && height < from+int32(r.config.BatchSize); height++ {
+		hash, err := r.client.GetBlockHash(height)
+		if err != nil {
+			return err
+		}
+		if s, ok := r.submitted[height]; ok && s.hash == *hash && now.Sub(s.at) < r.config.ResubmitTimeout {
+			continue
+		}
+		header, err := r.client.GetBlockHeader(hash)
+		if err != nil {
+			return err
+		}
+		encoded, err := EncodeHeader(header)
+		if err != nil {
+			return err
+		}
+		txID, err := r.submitter.SubmitBTCHeader(encoded, uint64(height))
+		if err != nil {
+			// the next headers must not be relayed before this one
+			return err
+		}
+		Logger.log.Infof("[BTC relayer] submitted header %v at height %v with tx %v", hash.String(), height, txID)
+		r.submitted[height] = &submission{hash: *hash, txID: txID, at: now}
+	}
+	return nil
+}
+
+// findCommonAncestor returns the height of the last block of the relaying chain from height down that is in the
+// best chain of bitcoind
+func (r *Relayer) findCommonAncestor(height int32) (int32, error) {
+	for depth := int32(0); depth <= maxReorgDepth; depth++ {
+		localHash, err := r.chain.BlockHashByHeight(height - depth)
+		if err != nil {
+			return 0, err
+		}
+		remoteHash, err := r.client.GetBlockHash(height - depth)
+		if err != nil {
+			return 0, err
+		}
+		if localHash.IsEqual(remoteHash) {
+			return height - depth, nil
+		}
+	}
+	return 0, errors.New("no block of the relaying chain is in the best chain of bitcoind")
+}
+
+// EncodeHeader encodes a header as the Header of RelayingBTCHeaderMeta, a json block without transactions in base64
+func EncodeHeader(header *wire.BlockHeader) (string, error) {
+	msgBlock := wire.NewMsgBlock(header)
+	data, err := json.Marshal(msgBlock)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,268 @@
+package btcrelayer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/common"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+// fakeBitcoind serves the best chain headers over JSON-RPC as bitcoind does
+type fakeBitcoind struct {
+	mu      sync.Mutex
+	headers []*wire.BlockHeader // headers[i] is at height i
+}
+
+func newHeader(prev *wire.BlockHeader, nonce uint32) *wire.BlockHeader {
+	header := &wire.BlockHeader{Version: 1, Timestamp: time.Unix(1600000000+int64(nonce), 0), Nonce: nonce}
+	if prev != nil {
+		header.PrevBlock = prev.BlockHash()
+		header.Timestamp = prev.Timestamp.Add(10 * time.Minute)
+	}
+	return header
+}
+
+// extend appends count headers after height, dropping the headers above it, the salt makes a different branch
+func (b *fakeBitcoind) extend(height int, count int, salt uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.headers = b.headers[:height+1]
+	for i := 0; i < count; i++ {
+		b.headers = append(b.headers, newHeader(b.headers[len(b.headers)-1], salt+uint32(len(b.headers))))
+	}
+}
+
+func (b *fakeBitcoind) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	req := struct {
+		Method string        `json:"method"`
+		Params []interface{} `json:"params"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var result interface{}
+	var rpcErr interface{}
+	switch req.Method {
+	case "getblockcount":
+		result = len(b.headers) - 1
+	case "getblockhash":
+		height := int(req.Params[0].(float64))
+		if height >= len(b.headers) {
+			rpcErr = map[string]interface{}{"code": -8, "message": "Block height out of range"}
+			break
+		}
+		result = b.headers[height].BlockHash().String()
+	case "getblockheader":
+		for _, header := range b.headers {
+			if header.BlockHash().String() == req.Params[0].(string) {
+				buf := new(bytes.Buffer)
+				_ = header.Serialize(buf)
+				result = hex.EncodeToString(buf.Bytes())
+			}
+		}
+		if result == nil {
+			rpcErr = map[string]interface{}{"code": -5, "message": "Block not found"}
+		}
+	default:
+		rpcErr = map[string]interface{}{"code": -32601, "message": "Method not found"}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "result": result, "error": rpcErr})
+}
+
+// fakeRelayingChain applies the submitted headers as the beacon does, following the longest branch
+type fakeRelayingChain struct {
+	blocks    map[chainhash.Hash]*wire.BlockHeader
+	heights   map[chainhash.Hash]int32
+	best      chainhash.Hash
+	submitted []int32
+	fail      bool
+}
+
+func newFakeRelayingChain(genesis *wire.BlockHeader) *fakeRelayingChain {
+	hash := genesis.BlockHash()
+	return &fakeRelayingChain{
+		blocks:  map[chainhash.Hash]*wire.BlockHeader{hash: genesis},
+		heights: map[chainhash.Hash]int32{hash: 0},
+		best:    hash,
+	}
+}
+
+func (c *fakeRelayingChain) BestSnapshot() *btcrelaying.BestState {
+	return &btcrelaying.BestState{Hash: c.best, Height: c.heights[c.best]}
+}
+
+func (c *fakeRelayingChain) BlockHashByHeight(height int32) (*chainhash.Hash, error) {
+	hash := c.best
+	for c.heights[hash] > height {
+		hash = c.blocks[hash].PrevBlock
+	}
+	if c.heights[hash] != height {
+		return nil, fmt.Errorf("no block at height %v", height)
+	}
+	return &hash, nil
+}
+
+func (c *fakeRelayingChain) SubmitBTCHeader(header string, blockHeight uint64) (string, error) {
+	if c.fail {
+		return "", errors.New("not enough coins")
+	}
+	data, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return "", err
+	}
+	var msgBlock *wire.MsgBlock
+	if err := json.Unmarshal(data, &msgBlock); err != nil {
+		return "", err
+	}
+	prevHeight, ok := c.heights[msgBlock.Header.PrevBlock]
+	if !ok || uint64(prevHeight+1) != blockHeight {
+		return "", fmt.Errorf("orphan header at height %v", blockHeight)
+	}
+	hash := msgBlock.Header.BlockHash()
+	c.blocks[hash] = &msgBlock.Header
+	c.heights[hash] = prevHeight + 1
+	if c.heights[hash] > c.heights[c.best] {
+		c.best = hash
+	}
+	c.submitted = append(c.submitted, int32(blockHeight))
+	return hash.String(), nil
+}
+
+// newTestRelayer returns a relayer on a virtual clock, between a fake bitcoind server and a fake relaying chain
+func newTestRelayer() (*Relayer, *fakeBitcoind, *fakeRelayingChain, *time.Time, *httptest.Server) {
+	bitcoind := &fakeBitcoind{headers: []*wire.BlockHeader{newHeader(nil, 0)}}
+	server := httptest.NewServer(bitcoind)
+	chain := newFakeRelayingChain(bitcoind.headers[0])
+	relayer := NewRelayer(NewBitcoindClient(server.URL), chain, chain, Config{
+		BatchSize:       3,
+		ResubmitTimeout: time.Minute,
+		MinBackoff:      2 * time.Minute,
+		MaxBackoff:      5 * time.Minute,
+	})
+	now := time.Unix(1600000000, 0)
+	relayer.now = func() time.Time { return now }
+	return relayer, bitcoind, chain, &now, server
+}
+
+func TestRelayer_CatchUpInOrder(t *testing.T) {
+	relayer, bitcoind, chain, _, server := newTestRelayer()
+	defer server.Close()
+	bitcoind.extend(0, 7, 0)
+
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, []int32{1, 2, 3}, chain.submitted)
+	assert.NoError(t, relayer.Step())
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, []int32{1, 2, 3, 4, 5, 6, 7}, chain.submitted)
+	assert.Equal(t, bitcoind.headers[7].BlockHash(), chain.best)
+
+	// nothing to relay at the tip of bitcoind
+	assert.NoError(t, relayer.Step())
+	assert.Len(t, chain.submitted, 7)
+}
+
+func TestRelayer_ResubmitPendingHeader(t *testing.T) {
+	relayer, bitcoind, chain, now, server := newTestRelayer()
+	defer server.Close()
+	bitcoind.extend(0, 2, 0)
+	chain.fail = true
+	assert.Error(t, relayer.Step())
+	assert.Empty(t, chain.submitted)
+
+	// the tx of the first header is sent but not yet in a beacon block
+	chain.fail = false
+	submitter := &pendingSubmitter{}
+	relayer.submitter = submitter
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, []uint64{1, 2}, submitter.heights)
+	*now = now.Add(30 * time.Second)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, []uint64{1, 2}, submitter.heights)
+
+	*now = now.Add(time.Minute)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, []uint64{1, 2, 1, 2}, submitter.heights)
+}
+
+type pendingSubmitter struct {
+	heights []uint64
+}
+
+func (s *pendingSubmitter) SubmitBTCHeader(header string, blockHeight uint64) (string, error) {
+	s.heights = append(s.heights, blockHeight)
+	return "pending", nil
+}
+
+func TestRelayer_BackOffOnReorg(t *testing.T) {
+	relayer, bitcoind, chain, now, server := newTestRelayer()
+	defer server.Close()
+	bitcoind.extend(0, 3, 0)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, bitcoind.headers[3].BlockHash(), chain.best)
+
+	// bitcoind replaces the blocks after height 1 by a longer branch
+	bitcoind.extend(1, 4, 1000)
+	assert.NoError(t, relayer.Step())
+	assert.Len(t, chain.submitted, 3, "the relayer must wait after a reorg")
+	*now = now.Add(time.Minute)
+	assert.NoError(t, relayer.Step())
+	assert.Len(t, chain.submitted, 3)
+
+	*now = now.Add(time.Minute)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, []int32{1, 2, 3, 2, 3, 4}, chain.submitted)
+	assert.Equal(t, bitcoind.headers[4].BlockHash(), chain.best)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, bitcoind.headers[5].BlockHash(), chain.best)
+	assert.Nil(t, relayer.reorg)
+	assert.Equal(t, 2*time.Minute, relayer.backoff)
+
+	// the backoff doubles for a reorg seen while waiting for the previous one, up to MaxBackoff
+	bitcoind.extend(3, 3, 2000)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, 4*time.Minute, relayer.backoff)
+	bitcoind.extend(2, 5, 3000)
+	assert.NoError(t, relayer.Step())
+	assert.Equal(t, 5*time.Minute, relayer.backoff)
+	assert.Equal(t, int32(2), relayer.reorg.ancestor)
+}
+
+func TestBitcoindClient_GetBlockHeader(t *testing.T) {
+	bitcoind := &fakeBitcoind{headers: []*wire.BlockHeader{newHeader(nil, 0)}}
+	bitcoind.extend(0, 2, 0)
+	server := httptest.NewServer(bitcoind)
+	defer server.Close()
+	client := NewBitcoindClient(server.URL)
+
+	count, err := client.GetBlockCount()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), count)
+	hash, err := client.GetBlockHash(2)
+	assert.NoError(t, err)
+	header, err := client.GetBlockHeader(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, bitcoind.headers[2].PrevBlock, header.PrevBlock)
+
+	_, err = client.GetBlockHash(3)
+	assert.EqualError(t, err, "getblockhash failed with error -8: Block height out of range")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// See loadConfig for details on the configuration load process.
+type config struct {
+	Listen     string `long:"listen" short:"l" description:"Listen address, unix:///path/to/socket or tcp://host:port"`
+	TLSCert    string `long:"tlscert" description:"TLS certificate (PEM) of the signer, required to listen on tcp"`
+	TLSKey     string `long:"tlskey" description:"TLS key (PEM) of the signer, required to listen on tcp"`
+	TLSCA      string `long:"tlsca" description:"CA certificate (PEM) signing the certificates of the validators allowed to connect over tcp"`
+	Network    string `long:"network" description:"Network of the validators (mainnet, testnet-1, testnet-2 or local), selects the portal params of the portal external txs"`
+	MiningKeys string `long:"miningkeys" description:"Mining keys (private seeds) to sign with, separated by comma"`
+	PrivateKey string `long:"privatekey" description:"Wallet private key to derive the mining key from"`
+	GuardFile  string `long:"guardfile" description:"File persisting the last signed blocks, refuse to double sign after a restart"`
+}
+
+// newConfigParser returns a new command line flags parser.
+func newConfigParser(cfg *config, options flags.Options) *flags.Parser {
+	parser := flags.NewParser(cfg, options)
+	return parser
+}
+
+// loadConfig
+// - set default config
+// - read config from cmd line params
+// - return config object
+func loadConfig() (*config, error) {
+	// create config object from default values
+	cfg := config{
+		Listen:    defaultListenAddress,
+		Network:   defaultNetwork,
+		GuardFile: defaultGuardFile,
+	}
+
+	preParser := newConfigParser(&cfg, flags.HelpFlag)
+	_, err := preParser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+	}
+	if cfg.MiningKeys == "" && cfg.PrivateKey == "" {
+		return nil, errors.New("miningkeys or privatekey is required")
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,8 @@
+package main
+
+const (
+	version              = "1.0.0"
+	defaultListenAddress = "unix:///tmp/incognito-signer.sock"
+	defaultNetwork       = "mainnet"
+	defaultGuardFile     = "signer-guard.json"
+)
@@ -0,0 +1,79 @@
+//+build !test
+
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
+	"github.com/incognitochain/incognito-chain/portal"
+)
+
+// Remote signer holds the mining keys of validators off the validator host,
+// the node started with --remotesigner asks it for the signatures of its votes and proposals
+func main() {
+	// Show Version at startup.
+	log.Printf("Version %s\n", version)
+
+	// Load config
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Println("Parse config error", err.Error())
+		return
+	}
+
+	privateSeeds := []string{}
+	if cfg.PrivateKey != "" {
+		privateSeed, err := consensus.LoadUserKeyFromIncPrivateKey(cfg.PrivateKey)
+		if err != nil {
+			log.Fatal("load private key error:", err)
+		}
+		privateSeeds = append(privateSeeds, privateSeed)
+	} else {
+		privateSeeds = strings.Split(cfg.MiningKeys, ",")
+	}
+	miningKeys := []*signatureschemes.MiningKey{}
+	for _, privateSeed := range privateSeeds {
+		miningKey, err := consensus.GetMiningKeyFromPrivateSeed(strings.TrimSpace(privateSeed))
+		if err != nil {
+			log.Fatal("load mining key error:", err)
+		}
+		log.Printf("Load mining key %v\n", miningKey.GetPublicKey().GetMiningKeyBase58("bls"))
+		miningKeys = append(miningKeys, miningKey)
+	}
+
+	guard, err := signer.NewDoubleSignGuard(cfg.GuardFile)
+	if err != nil {
+		log.Fatal("load guard file error:", err)
+	}
+	portalParams, err := portal.GetNetworkPortalParams(cfg.Network)
+	if err != nil {
+		log.Fatal("load portal params error:", err)
+	}
+	// the validators sign the portal external txs with the params of beacon height 0 too
+	server, err := signer.NewServer(miningKeys, guard, portalParams.GetPortalParamsV4(0))
+	if err != nil {
+		log.Fatal("init signer error:", err)
+	}
+	listener, err := signer.Listen(cfg.Listen, signer.TLSFiles{Cert: cfg.TLSCert, Key: cfg.TLSKey, CA: cfg.TLSCA})
+	if err != nil {
+		log.Fatal("listen error:", err)
+	}
+
+	// close the listener on exit, it also removes the unix socket
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		listener.Close()
+	}()
+
+	log.Printf("Start signer on %v\n", cfg.Listen)
+	server.Serve(listener)
+}
@@ -46,6 +46,8 @@ const (
 	//getFeeEstimator             = "getfeeestimator"
 	setBackup                   = "setbackup"
 	getLatestBackup             = "getlatestbackup"
+	getBackupManifest           = "getbackupmanifest"
+	downloadBackupChunk         = "downloadbackupchunk"
 	getBestBlock                = "getbestblock"
 	getBestBlockHash            = "getbestblockhash"
 	getBlocks                   = "getblocks"
@@ -145,6 +147,12 @@ const (
 	removeByzantineDetector    = "removebyzantinedetector"
 	getConsensusData           = "getconsensusdata"
 	getProposerIndex           = "getproposerindex"
+
+	// equivocation evidence
+	getEquivocationEvidence                 = "getequivocationevidence"
+	getLocalEquivocationEvidences           = "getlocalequivocationevidences"
+	createRawTxWithEquivocationEvidence     = "createrawtxwithequivocationevidence"
+	createAndSendTxWithEquivocationEvidence = "createandsendtxwithequivocationevidence"
 	//==================================================
 
 	getShardBestState        = "getshardbeststate"
@@ -214,6 +222,11 @@ const (
 	createAndSendTxWithIssuingSOLReq      = "createandsendtxwithissuingsolreq"
 	createAndSendBurningSOLRequest        = "createandsendburningsolrequest"
 
+	// generic evm networks
+	createAndSendTxWithIssuingEVMNetworkReq = "createandsendtxwithissuingevmnetworkreq"
+	createAndSendBurningEVMNetworkRequest   = "createandsendburningevmnetworkrequest"
+	getEVMNetworkBurnProof                  = "getevmnetworkburnproof"
+
 	// Incognito -> Ethereum bridge
 	getBeaconSwapProof       = "getbeaconswapproof"
 	getLatestBeaconSwapProof = "getlatestbeaconswapproof"
@@ -235,6 +248,8 @@ const (
 	revertbeaconchain = "revertbeaconchain"
 	revertshardchain  = "revertshardchain"
 
+	pinStateSnapshot = "pinstatesnapshot"
+
 	enableMining                = "enablemining"
 	getChainMiningStatus        = "getchainminingstatus"
 	getPublickeyMining          = "getpublickeymining"
@@ -298,6 +313,9 @@ const (
 	getPdexv3EstimatedStakingPoolReward            = "pdexv3_getEstimatedStakingPoolReward"
 	createAndSendTxWithPdexv3WithdrawStakingReward = "pdexv3_txWithdrawStakingReward"
 	getPdexv3WithdrawalStakingRewardStatus         = "pdexv3_getWithdrawalStakingRewardStatus"
+	getPdexv3PoolPairCandles                       = "pdexv3_getPoolPairCandles"
+	getPdexv3TradeHistory                          = "pdexv3_getTradeHistory"
+	getPdexv3PoolPairStateAtHeight                 = "pdexv3_getPoolPairStateAtHeight"
 
 	// get burning address
 	getBurningAddress = "getburningaddress"
@@ -310,6 +328,7 @@ const (
 	createAndSendRegisterPortingPublicTokens      = "createandsendregisterportingpublictokens"
 	createAndSendPortalExchangeRates              = "createandsendportalexchangerates"
 	getPortalFinalExchangeRates                   = "getportalfinalexchangerates"
+	getPortalExchangeRateOracleHealth             = "getportalexchangerateoraclehealth"
 	getPortalPortingRequestByKey                  = "getportalportingrequestbykey"
 	getPortalPortingRequestByPortingId            = "getportalportingrequestbyportingid"
 	convertExchangeRates                          = "convertexchangerates"
@@ -352,13 +371,19 @@ const (
 	getPortalUnlockOverRateCollateralsStatus      = "getportalunlockoverratecollateralsbytxidstatus"
 
 	// relaying
-	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
-	createAndSendTxWithRelayingBTCHeader = "createandsendtxwithrelayingbtcheader"
-	getRelayingBNBHeaderState            = "getrelayingbnbheaderstate"
-	getRelayingBNBHeaderByBlockHeight    = "getrelayingbnbheaderbyblockheight"
-	getBTCRelayingBestState              = "getbtcrelayingbeststate"
-	getBTCBlockByHash                    = "getbtcblockbyhash"
-	getLatestBNBHeaderBlockHeight        = "getlatestbnbheaderblockheight"
+	createAndSendTxWithRelayingBNBHeader  = "createandsendtxwithrelayingbnbheader"
+	createAndSendTxWithRelayingBTCHeader  = "createandsendtxwithrelayingbtcheader"
+	getRelayingBNBHeaderState             = "getrelayingbnbheaderstate"
+	getRelayingBNBHeaderByBlockHeight     = "getrelayingbnbheaderbyblockheight"
+	getBTCRelayingBestState               = "getbtcrelayingbeststate"
+	createAndSendTxWithRelayingLTCHeader  = "createandsendtxwithrelayingltcheader"
+	createAndSendTxWithRelayingDOGEHeader = "createandsendtxwithrelayingdogeheader"
+	createAndSendTxWithRelayingBCHHeader  = "createandsendtxwithrelayingbchheader"
+	getLTCRelayingBestState               = "getltcrelayingbeststate"
+	getDOGERelayingBestState              = "getdogerelayingbeststate"
+	getBCHRelayingBestState               = "getbchrelayingbeststate"
+	getBTCBlockByHash                     = "getbtcblockbyhash"
+	getLatestBNBHeaderBlockHeight         = "getlatestbnbheaderblockheight"
 
 	// incognito mode for sc
 	getBurnProofForDepositToSC                    = "getburnprooffordeposittosc"
@@ -404,10 +429,16 @@ const (
 	createAndSendTxPortalConvertVaultRequest   = "createandsendtxportalconvertvault"
 	getPortalConvertVaultTxStatus              = "getportalconvertvaultstatus"
 	generatePortalShieldMultisigAddress        = "generateportalshieldmultisigaddress"
+	createAndSendTxWithPortalVaultRotation     = "createandsendtxwithportalv4vaultrotation"
+	getPortalVaultRotationRequestStatus        = "getportalvaultrotationrequeststatus"
+	getPortalVaultRotationStatus               = "getportalvaultrotationstatus"
 
 	// stake
 	unstake = "createunstaketransaction"
 
+	// state proof
+	getStateProof = "getstateproof"
+
 	connectionStatus = "getconnectionstatus"
 )
 
@@ -425,6 +456,9 @@ const (
 	subcribeCrossOutputCoinByPrivateKey         = "subcribecrossoutputcoinbyprivatekey"
 	subcribeCrossCustomTokenByPrivateKey        = "subcribecrosscustomtokenbyprivatekey"
 	subcribeCrossCustomTokenPrivacyByPrivateKey = "subcribecrosscustomtokenprivacybyprivatekey"
+	subcribeOutputCoinByOTAKey                  = "subcribeoutputcoinbyotakey"
+	subcribeSpentKeyImages                      = "subcribespentkeyimages"
+	subcribeRequestEvents                       = "subcriberequestevents"
 	subcribeMempoolInfo                         = "subcribemempoolinfo"
 	subcribeShardBestState                      = "subcribeshardbeststate"
 	subcribeBeaconBestState                     = "subcribebeaconbeststate"
@@ -441,6 +475,12 @@ var FeatureFlagWithMethodNames = map[string][]string{
 		getRelayingBNBHeaderState,
 		getRelayingBNBHeaderByBlockHeight,
 		getBTCRelayingBestState,
+		createAndSendTxWithRelayingLTCHeader,
+		createAndSendTxWithRelayingDOGEHeader,
+		createAndSendTxWithRelayingBCHHeader,
+		getLTCRelayingBestState,
+		getDOGERelayingBestState,
+		getBCHRelayingBestState,
 		getBTCBlockByHash,
 		getLatestBNBHeaderBlockHeight,
 	},
@@ -452,6 +492,7 @@ var FeatureFlagWithMethodNames = map[string][]string{
 		createAndSendRegisterPortingPublicTokens,
 		createAndSendPortalExchangeRates,
 		getPortalFinalExchangeRates,
+		getPortalExchangeRateOracleHealth,
 		getPortalPortingRequestByKey,
 		getPortalPortingRequestByPortingId,
 		convertExchangeRates,
@@ -510,5 +551,8 @@ var FeatureFlagWithMethodNames = map[string][]string{
 		getPortalConvertVaultTxStatus,
 		getPortalV4Params,
 		generatePortalShieldMultisigAddress,
+		createAndSendTxWithPortalVaultRotation,
+		getPortalVaultRotationRequestStatus,
+		getPortalVaultRotationStatus,
 	},
 }
@@ -85,3 +85,45 @@ func (httpServer *HttpServer) handleDownloadBackup(conn net.Conn, params interfa
 	}
 	return
 }
+
+// handleGetBackupManifest - params: chainName (beacon, shard<ID>)
+func (httpServer *HttpServer) handleGetBackupManifest(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	paramArray, ok := params.([]interface{})
+	if !ok || len(paramArray) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("expect chainName"))
+	}
+	chainName, ok := paramArray[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("chainName is invalid"))
+	}
+	manifest, err := httpServer.config.BlockChain.GetLatestBackupManifest(chainName)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return manifest, nil
+}
+
+// handleDownloadBackupChunk - params: epoch, fileName (beacon, shard<ID>, btc), chunkIndex
+func (httpServer *HttpServer) handleDownloadBackupChunk(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	paramArray, ok := params.([]interface{})
+	if !ok || len(paramArray) != 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("expect epoch, fileName and chunkIndex"))
+	}
+	epoch, ok := paramArray[0].(float64)
+	if !ok || epoch < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("epoch is invalid"))
+	}
+	fileName, ok := paramArray[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("fileName is invalid"))
+	}
+	index, ok := paramArray[2].(float64)
+	if !ok || index < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("chunkIndex is invalid"))
+	}
+	chunk, err := httpServer.config.BlockChain.GetBackupChunk(uint64(epoch), fileName, int(index))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return chunk, nil
+}
@@ -190,3 +190,23 @@ func (httpServer *HttpServer) handleGetTotalStaker(params interface{}, closeChan
 func (httpServer *HttpServer) handleGetConnectionStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	return httpServer.blockService.BlockChain.GetConfig().Highway.GetConnectionStatus(), nil
 }
+
+/*
+handlePinStateSnapshot - RPC pin the state snapshot of the final view of a chain (-1 for beacon),
+returns the checkpoint fast syncing nodes trusting this node are configured with
+*/
+func (httpServer *HttpServer) handlePinStateSnapshot(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Chain ID empty"))
+	}
+	chainIDParam, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Chain ID component invalid"))
+	}
+	checkpoint, err := httpServer.blockService.BlockChain.PinStateSnapshot(int(chainIDParam))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.PinStateSnapshotError, err)
+	}
+	return checkpoint.String(), nil
+}
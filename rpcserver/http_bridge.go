@@ -345,7 +345,18 @@ func (httpServer *HttpServer) handleGetBridgeReqWithStatus(params interface{}, c
 	if err != nil {
 		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
-	return status, nil
+	// the reject reason is only returned on demand to keep the plain status response for old clients
+	if withRejectReason, ok := data["WithRejectReason"].(bool); !ok || !withRejectReason {
+		return status, nil
+	}
+	result := jsonresult.BridgeReqStatus{Status: status}
+	if status == common.BridgeRequestRejectedStatus {
+		result.RejectReason, err = httpServer.blockService.GetBridgeReqRejectReason(data["TxReqID"].(string))
+		if err != nil {
+			return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+		}
+	}
+	return result, nil
 }
 
 func processBurningReq(
@@ -426,6 +437,13 @@ func processBurningReq(
 	if err != nil {
 		return nil, err
 	}
+	if burningMetaType == metadata.BurningEVMNetworkRequestMeta {
+		networkID, ok := tokenParamsRaw["NetworkID"].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("network ID is invalid"))
+		}
+		meta.NetworkID = uint8(networkID)
+	}
 	var byteArrays []byte
 	var err2 error
 	var txHash string
@@ -926,3 +944,48 @@ func (httpServer *HttpServer) handleCreateAndSendBurningSOLForDepositToSCRequest
 	}
 	return sendResult, nil
 }
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithIssuingEVMNetworkReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithIssuingEVMReq(params, closeChan, metadata.IssuingEVMNetworkRequestMeta)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithBurningEVMNetworkReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return processBurningReq(
+		metadata.BurningEVMNetworkRequestMeta,
+		params,
+		closeChan,
+		httpServer,
+		false,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendBurningEVMNetworkRequest(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithBurningEVMNetworkReq(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err1 := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	return sendResult, nil
+}
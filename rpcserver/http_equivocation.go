@@ -0,0 +1,118 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetEquivocationEvidence returns the last equivocation proven on chain for a committee public key, nil if there is none
+func (httpServer *HttpServer) handleGetEquivocationEvidence(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid Number Of Params"))
+	}
+	committeePublicKey, ok := arrayParams[0].(string)
+	if !ok || committeePublicKey == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("committee public key is invalid"))
+	}
+	beaconBestState := httpServer.blockService.BlockChain.GetBeaconBestState()
+	evidence, has, err := statedb.GetEquivocationEvidence(beaconBestState.GetBeaconSlashStateDB(), committeePublicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	if !has {
+		return nil, nil
+	}
+	return evidence, nil
+}
+
+// handleGetLocalEquivocationEvidences returns the evidences detected by the byzantine detector of this node, by validator
+func (httpServer *HttpServer) handleGetLocalEquivocationEvidences(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return blsbft.ByzantineDetectorObject.GetEquivocationEvidences(), nil
+}
+
+// handleCreateRawTxWithEquivocationEvidence builds a tx submitting two conflicting votes,
+// the votes are given as {"VoteA": ..., "VoteB": ...} or taken from the byzantine detector with {"Validator": blsKey}
+func (httpServer *HttpServer) handleCreateRawTxWithEquivocationEvidence(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
+	}
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
+	}
+
+	var meta *metadata.EquivocationEvidence
+	if validator, ok := data["Validator"].(string); ok {
+		meta, ok = blsbft.ByzantineDetectorObject.GetEquivocationEvidences()[validator]
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("no equivocation evidence of validator %v", validator))
+		}
+	} else {
+		temp := struct {
+			VoteA metadata.EquivocationVote
+			VoteB metadata.EquivocationVote
+		}{}
+		dataBytes, err := json.Marshal(data)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		if err := json.Unmarshal(dataBytes, &temp); err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		meta = metadata.NewEquivocationEvidence(temp.VoteA, temp.VoteB)
+	}
+	if err := meta.CheckConflict(); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithEquivocationEvidence(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithEquivocationEvidence(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
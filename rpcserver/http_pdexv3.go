@@ -1444,6 +1444,7 @@ func createPdexv3AddOrderRequestTransaction(
 		SellAmount          Uint64Reader
 		MinAcceptableAmount Uint64Reader
 		NftID               common.Hash
+		ExpiryHeight        Uint64Reader
 	}{}
 
 	// parse params & metadata
@@ -1461,6 +1462,7 @@ func createPdexv3AddOrderRequestTransaction(
 		uint64(mdReader.MinAcceptableAmount), nil,
 		mdReader.NftID, metadataCommon.Pdexv3AddOrderRequestMeta,
 	)
+	md.ExpiryHeight = uint64(mdReader.ExpiryHeight)
 
 	// set token ID & metadata to paramSelect struct. Generate new OTAReceivers from private key
 	paramSelect.SetTokenID(md.TokenToSell)
@@ -0,0 +1,140 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// the pDEX history RPCs read the index built by nodes started with --pdexindexer
+
+func getPdexv3HistoryParams(params interface{}) (map[string]interface{}, string, *rpcservice.RPCError) {
+	if !config.Config().PdexIndexer {
+		return nil, "", rpcservice.NewRPCError(rpcservice.GetPdexv3HistoryError, errors.New("pDEX indexer is not enabled on this node"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	poolPairID, ok := data["PoolPairID"].(string)
+	if !ok || poolPairID == "" {
+		return nil, "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PoolPairID is invalid"))
+	}
+	return data, poolPairID, nil
+}
+
+func getPdexv3HistoryTimeRange(data map[string]interface{}) (int64, int64, *rpcservice.RPCError) {
+	fromTime, ok := data["FromTime"].(float64)
+	if !ok || fromTime < 0 {
+		return 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromTime is invalid"))
+	}
+	toTime, ok := data["ToTime"].(float64)
+	if !ok || toTime < fromTime {
+		return 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ToTime is invalid"))
+	}
+	return int64(fromTime), int64(toTime), nil
+}
+
+func (httpServer *HttpServer) handleGetPdexv3PoolPairCandles(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, poolPairID, rpcErr := getPdexv3HistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromTime, toTime, rpcErr := getPdexv3HistoryTimeRange(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	interval, ok := data["Interval"].(float64)
+	if !ok || interval < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Interval is invalid"))
+	}
+	trades, err := rawdbv2.GetPdexv3TradeRecords(httpServer.GetBeaconChainDatabase(), poolPairID, fromTime, toTime, 0)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPdexv3HistoryError, err)
+	}
+	return jsonresult.Pdexv3PoolPairCandles{
+		PoolPairID: poolPairID,
+		Interval:   int64(interval),
+		Candles:    buildPdexv3Candles(trades, int64(interval)),
+	}, nil
+}
+
+func (httpServer *HttpServer) handleGetPdexv3TradeHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, poolPairID, rpcErr := getPdexv3HistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromTime, toTime, rpcErr := getPdexv3HistoryTimeRange(data)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	limit := uint(0)
+	if temp, ok := data["Limit"].(float64); ok && temp > 0 {
+		limit = uint(temp)
+	}
+	trades, err := rawdbv2.GetPdexv3TradeRecords(httpServer.GetBeaconChainDatabase(), poolPairID, fromTime, toTime, limit)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPdexv3HistoryError, err)
+	}
+	return trades, nil
+}
+
+func (httpServer *HttpServer) handleGetPdexv3PoolPairStateAtHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, poolPairID, rpcErr := getPdexv3HistoryParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	beaconHeight, ok := data["BeaconHeight"].(float64)
+	if !ok || beaconHeight == 0 {
+		beaconHeight = float64(httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight)
+	}
+	snapshot, err := rawdbv2.GetPdexv3PoolPairSnapshot(httpServer.GetBeaconChainDatabase(), poolPairID, uint64(beaconHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPdexv3HistoryError, err)
+	}
+	if snapshot == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPdexv3HistoryError, fmt.Errorf("Pool pair %v is not indexed at beacon height %v", poolPairID, uint64(beaconHeight)))
+	}
+	return snapshot, nil
+}
+
+// buildPdexv3Candles groups time ordered trades into candles of interval seconds, intervals without
+// trades are left out
+func buildPdexv3Candles(trades []*rawdbv2.Pdexv3TradeRecord, interval int64) []*jsonresult.Pdexv3Candle {
+	candles := []*jsonresult.Pdexv3Candle{}
+	var candle *jsonresult.Pdexv3Candle
+	for _, trade := range trades {
+		token0Change, token1Change := trade.TotalChanges()
+		amount0, amount1 := token0Change.Abs(token0Change), token1Change.Abs(token1Change)
+		if amount0.Sign() == 0 {
+			continue
+		}
+		price, _ := new(big.Float).Quo(new(big.Float).SetInt(amount1), new(big.Float).SetInt(amount0)).Float64()
+		startTime := trade.Timestamp - trade.Timestamp%interval
+		if candle == nil || candle.StartTime != startTime {
+			candle = &jsonresult.Pdexv3Candle{StartTime: startTime, Open: price, High: price, Low: price}
+			candles = append(candles, candle)
+		}
+		if price > candle.High {
+			candle.High = price
+		}
+		if price < candle.Low {
+			candle.Low = price
+		}
+		candle.Close = price
+		candle.Volume0 += amount0.Uint64()
+		candle.Volume1 += amount1.Uint64()
+		candle.TradeCount++
+	}
+	return candles
+}
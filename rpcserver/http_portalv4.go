@@ -362,7 +362,8 @@ func (httpServer *HttpServer) handleGetPortalSignedExtTxWithBatchID(
 	}
 
 	// get signed transaction
-	return getRawSignedTxByHeight(httpServer, unshieldBatch.BeaconHeight, unshieldBatch.RawExternalTx, unshieldBatch.UTXOs)
+	return getRawSignedTxByHeight(httpServer, unshieldBatch.BeaconHeight, unshieldBatch.RawExternalTx, unshieldBatch.UTXOs,
+		unshieldBatch.MasterPubKeys, unshieldBatch.NumRequiredSigs)
 }
 
 type getSignedTxResult struct {
@@ -376,9 +377,15 @@ func getRawSignedTxByHeight(
 	height uint64,
 	rawTx string,
 	utxos []*statedb.UTXO,
+	masterPubKeys [][]byte,
+	numRequiredSigs uint,
 ) (interface{}, *rpcservice.RPCError) {
 	// get portal params v4
 	portalParamsv4 := httpServer.config.BlockChain.GetPortalParamsV4(height)
+	// the batches created before any vault rotation do not record their key set
+	if len(masterPubKeys) == 0 {
+		numRequiredSigs = portalParamsv4.NumRequiredSigs
+	}
 
 	// get beacon block
 	beaconBlockQueried, err := getSingleBeaconBlockByHeight(httpServer.GetBlockchain(), height)
@@ -410,9 +417,6 @@ func getRawSignedTxByHeight(
 	var tokenID string
 	numSig := uint(0)
 	sigs := make([][][]byte, len(externalTx.TxIn))
-	for i := range sigs {
-		sigs[i] = make([][]byte, 1)
-	}
 
 	for _, v := range portalV4Sig {
 		if v.RawTxHash != externalTxHash {
@@ -429,7 +433,7 @@ func getRawSignedTxByHeight(
 			sigs[i] = append(sigs[i], v2)
 		}
 		numSig++
-		if numSig == portalParamsv4.NumRequiredSigs {
+		if numSig == numRequiredSigs {
 			break
 		}
 	}
@@ -437,16 +441,14 @@ func getRawSignedTxByHeight(
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Not found portal sigs for batchID"))
 	}
 
-	// append multisig script hex into each sigs
-	// then, attach sig into TxIn in externalTx
-	portalParams := httpServer.blockService.BlockChain.GetPortalParamsV4(height)
-	for i, v := range sigs {
-		multisigScriptHex, _, err := portalParams.PortalTokens[tokenID].GenerateOTMultisigAddress(portalParams.MasterPubKeys[tokenID], int(portalParams.NumRequiredSigs), utxos[i].GetChainCodeSeed())
-		if err != nil {
-			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
-		}
-		v = append(v, multisigScriptHex)
-		externalTx.TxIn[i].Witness = v
+	if len(masterPubKeys) == 0 {
+		masterPubKeys = portalParamsv4.MasterPubKeys[tokenID]
+	}
+
+	// attach sigs and multisig scripts into TxIn in externalTx in the way of the external chain
+	err = portalParamsv4.PortalTokens[tokenID].AttachSigsToRawExternalTx(externalTx, sigs, masterPubKeys, int(numRequiredSigs), utxos)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
 
 	// hex-encoding signed external tx
@@ -677,7 +679,8 @@ func (httpServer *HttpServer) handleGetPortalTransactionSignedWithFeeReplacement
 	}
 
 	// get signed transaction
-	return getRawSignedTxByHeight(httpServer, replaceFeeStatus.BeaconHeight, replaceFeeStatus.ExternalRawTx, unshieldBatch.UTXOs)
+	return getRawSignedTxByHeight(httpServer, replaceFeeStatus.BeaconHeight, replaceFeeStatus.ExternalRawTx, unshieldBatch.UTXOs,
+		unshieldBatch.MasterPubKeys, unshieldBatch.NumRequiredSigs)
 }
 
 /*
@@ -812,9 +815,15 @@ func (httpServer *HttpServer) handleGenerateShieldingMultisigAddress(
 			errors.New("TokenID is not a portal token"))
 	}
 
-	// generate shielding multisig address
+	// generate shielding multisig address from the key set holding the vault at the next beacon height
+	portalStateDB := httpServer.config.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	portalState, err := portalprocessv4.InitCurrentPortalStateV4FromDB(portalStateDB, nil, portalParamV4)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4StateError, err)
+	}
+	keySet := portalState.GetVaultKeySet(tokenID, latestBeaconHeight+1, portalParamV4)
 	_, shieldingAddress, err := portalParamV4.PortalTokens[tokenID].GenerateOTMultisigAddress(
-		portalParamV4.MasterPubKeys[tokenID], int(portalParamV4.NumRequiredSigs), incAddressStr)
+		keySet.MasterPubKeys, int(keySet.NumRequiredSigs), incAddressStr)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError,
 			fmt.Errorf("Error when generating multisig address %v\n", err))
@@ -822,3 +831,209 @@ func (httpServer *HttpServer) handleGenerateShieldingMultisigAddress(
 
 	return shieldingAddress, nil
 }
+
+/*
+===== Vault rotation request
+*/
+
+func (httpServer *HttpServer) handleCreateRawTxWithPortalVaultRotation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 5"))
+	}
+
+	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param metadata is invalid"))
+	}
+
+	tokenID, ok := tokenParamsRaw["TokenID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenID is invalid"))
+	}
+
+	masterPubKeysParam, ok := tokenParamsRaw["MasterPubKeys"].([]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("MasterPubKeys is invalid"))
+	}
+	masterPubKeys := [][]byte{}
+	for _, pubKeyParam := range masterPubKeysParam {
+		pubKeyStr, ok := pubKeyParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("MasterPubKeys must be hex strings"))
+		}
+		pubKey, err := hex.DecodeString(pubKeyStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("MasterPubKey %v is invalid: %v", pubKeyStr, err))
+		}
+		masterPubKeys = append(masterPubKeys, pubKey)
+	}
+
+	numRequiredSigs, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["NumRequiredSigs"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	activationHeight, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["ActivationHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	gracePeriod, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["GracePeriod"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	vaultSigsParam, ok := tokenParamsRaw["VaultSigs"].([]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("VaultSigs is invalid"))
+	}
+	vaultSigs := [][]byte{}
+	for _, sigParam := range vaultSigsParam {
+		sigStr, ok := sigParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("VaultSigs must be hex strings"))
+		}
+		sig, err := hex.DecodeString(sigStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("VaultSig %v is invalid: %v", sigStr, err))
+		}
+		vaultSigs = append(vaultSigs, sig)
+	}
+
+	meta, err := metadata.NewPortalVaultRotationRequest(
+		metadataCommon.PortalV4VaultRotationRequestMeta, tokenID, masterPubKeys, uint(numRequiredSigs), activationHeight, gracePeriod, vaultSigs)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPortalVaultRotation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPortalVaultRotation(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err1 := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	return sendResult, nil
+}
+
+func (httpServer *HttpServer) handleGetPortalVaultRotationRequestStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	reqTxID, ok := data["ReqTxID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param ReqTxID is invalid"))
+	}
+	status, err := httpServer.blockService.GetPortalVaultRotationRequestStatus(reqTxID)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4VaultRotationReqStatusError, err)
+	}
+	return status, nil
+}
+
+func (httpServer *HttpServer) handleGetPortalVaultRotationStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	tokenID, ok := data["TokenID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param TokenID is invalid"))
+	}
+
+	beaconBestState := httpServer.config.BlockChain.GetBeaconBestState()
+	portalParamV4 := httpServer.config.BlockChain.GetPortalParamsV4(beaconBestState.BeaconHeight)
+	portalTokenProcessor := portalParamV4.PortalTokens[tokenID]
+	if portalTokenProcessor == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenID is not a portal token"))
+	}
+	portalState, err := portalprocessv4.InitCurrentPortalStateV4FromDB(beaconBestState.GetBeaconFeatureStateDB(), nil, portalParamV4)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4VaultRotationStatusError, err)
+	}
+	vaultRotation := portalState.VaultRotations[tokenID]
+	if vaultRotation == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4VaultRotationStatusError,
+			fmt.Errorf("Vault of tokenID %v has not been rotated", tokenID))
+	}
+
+	// derive the progress of the rotation from the current vault
+	nextBeaconHeight := beaconBestState.BeaconHeight + 1
+	retiringUTXOs, err := portalState.GetRetiringUTXOs(tokenID, portalTokenProcessor)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4VaultRotationStatusError, err)
+	}
+	retiringAmount := uint64(0)
+	for _, utxo := range retiringUTXOs {
+		retiringAmount += utxo.GetOutputAmount()
+	}
+	isCompleted, err := portalState.IsVaultRotationCompleted(tokenID, nextBeaconHeight, portalParamV4)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4VaultRotationStatusError, err)
+	}
+	status := "Scheduled"
+	if isCompleted {
+		status = "Completed"
+	} else if nextBeaconHeight >= vaultRotation.ActivationHeight {
+		status = "Migrating"
+	}
+
+	type vaultRotationResult struct {
+		*metadata.PortalVaultRotationStatus
+		Status              string
+		NumRetiringUTXOs    int
+		RetiringAmount      uint64 // in external unit
+		IsInGracePeriod     bool
+		CurrentBeaconHeight uint64
+	}
+	return vaultRotationResult{
+		PortalVaultRotationStatus: vaultRotation,
+		Status:                    status,
+		NumRetiringUTXOs:          len(retiringUTXOs),
+		RetiringAmount:            retiringAmount,
+		IsInGracePeriod:           nextBeaconHeight >= vaultRotation.ActivationHeight && nextBeaconHeight < vaultRotation.GraceEndHeight,
+		CurrentBeaconHeight:       beaconBestState.BeaconHeight,
+	}, nil
+}
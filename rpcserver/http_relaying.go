@@ -3,6 +3,8 @@ package rpcserver
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/incognitochain/incognito-chain/common"
@@ -14,6 +16,7 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -33,6 +36,30 @@ func (httpServer *HttpServer) handleCreateRawTxWithRelayingBNBHeader(params inte
 	)
 }
 
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingLTCHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingDOGEHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingDOGEHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingBCHHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingBCHHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
 func (httpServer *HttpServer) handleCreateRawTxWithRelayingHeader(
 	metaType int,
 	params interface{},
@@ -130,9 +157,48 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingBTCHeader(params
 	return result, nil
 }
 
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateAndSendTxWithRelayingHeader(metadata.RelayingLTCHeaderMeta, params, closeChan)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingDOGEHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateAndSendTxWithRelayingHeader(metadata.RelayingDOGEHeaderMeta, params, closeChan)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingBCHHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateAndSendTxWithRelayingHeader(metadata.RelayingBCHHeaderMeta, params, closeChan)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingHeader(
+	metaType int,
+	params interface{},
+	closeChan <-chan struct{},
+) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingHeader(metaType, params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetRelayingBNBHeaderState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
-	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(bc.GetBNBHeaderChain(), bc.GetBTCHeaderChain())
+	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(
+		bc.GetBNBHeaderChain(),
+		bc.GetBTCHeaderChain(),
+		bc.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID),
+		bc.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.DOGERelayingHeaderChainID),
+		bc.GetUTXOHeaderChain(portal.GetPortalParams().RelayingParam.BCHRelayingHeaderChainID),
+	)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBHeaderError, err)
 	}
@@ -182,6 +248,26 @@ func (httpServer *HttpServer) handleGetBTCRelayingBestState(params interface{},
 	return bestState, nil
 }
 
+func (httpServer *HttpServer) handleGetLTCRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getUTXORelayingBestState(portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID)
+}
+
+func (httpServer *HttpServer) handleGetDOGERelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getUTXORelayingBestState(portal.GetPortalParams().RelayingParam.DOGERelayingHeaderChainID)
+}
+
+func (httpServer *HttpServer) handleGetBCHRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getUTXORelayingBestState(portal.GetPortalParams().RelayingParam.BCHRelayingHeaderChainID)
+}
+
+func (httpServer *HttpServer) getUTXORelayingBestState(chainID string) (interface{}, *rpcservice.RPCError) {
+	utxoChain := httpServer.config.BlockChain.GetUTXOHeaderChain(chainID)
+	if utxoChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetBTCRelayingBestState, fmt.Errorf("%v relaying chain should not be null", chainID))
+	}
+	return utxoChain.BestSnapshot(), nil
+}
+
 func (httpServer *HttpServer) handleGetLatestBNBHeaderBlockHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
 	result, err := portalrelaying.GetLatestBNBBlockHeight(bc.GetBNBHeaderChain())
@@ -219,3 +305,44 @@ func (httpServer *HttpServer) handleGetBTCBlockByHash(params interface{}, closeC
 	}
 	return btcBlock.MsgBlock(), nil
 }
+
+// BTCHeaderSubmitter sends the RelayingBTCHeaderMeta txs of the built-in BTC header relayer,
+// the fees are paid by its private key
+type BTCHeaderSubmitter struct {
+	httpServer    *HttpServer
+	privateKey    string
+	senderAddress string
+}
+
+func NewBTCHeaderSubmitter(httpServer *HttpServer, privateKey string) (*BTCHeaderSubmitter, error) {
+	keySet, _, err := bean.GetKeySetFromPrivateKeyParams(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	keyWallet := wallet.KeyWallet{KeySet: *keySet}
+	return &BTCHeaderSubmitter{
+		httpServer:    httpServer,
+		privateKey:    privateKey,
+		senderAddress: keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
+	}, nil
+}
+
+// SubmitBTCHeader creates and sends a tx relaying header, it returns the tx id
+func (s *BTCHeaderSubmitter) SubmitBTCHeader(header string, blockHeight uint64) (string, error) {
+	params := []interface{}{
+		s.privateKey,
+		nil,
+		float64(-1),
+		float64(-1),
+		map[string]interface{}{
+			"SenderAddress": s.senderAddress,
+			"Header":        header,
+			"BlockHeight":   strconv.FormatUint(blockHeight, 10),
+		},
+	}
+	result, rpcErr := s.httpServer.handleCreateAndSendTxWithRelayingBTCHeader(params, nil)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	return result.(jsonresult.CreateTransactionResult).TxID, nil
+}
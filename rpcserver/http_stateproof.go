@@ -0,0 +1,126 @@
+package rpcserver
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/pkg/errors"
+)
+
+// handleGetStateProof returns the value of a state object with a Merkle proof against the state root
+// of a block. Block headers do not commit to that root, so the proof is node trust only: a client verifies
+// it against a root taken from a node it trusts (see the stateproof package).
+// The object is either given by its raw "Key" or derived from an "Object" description, e.g.
+// {"Type": "SerialNumber", "TokenID": "...", "SerialNumber": "..."}
+func (httpServer *HttpServer) handleGetStateProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an object"))
+	}
+	chainID, ok := data["ChainID"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ChainID is invalid"))
+	}
+	blockHashStr, ok := data["BlockHash"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("BlockHash is invalid"))
+	}
+	blockHash, err := common.Hash{}.NewHashFromStr(blockHashStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	stateDBName, _ := data["StateDB"].(string)
+	var key common.Hash
+	if keyStr, ok := data["Key"].(string); ok {
+		if stateDBName == "" {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("StateDB is required with a raw Key"))
+		}
+		temp, err := common.Hash{}.NewHashFromStr(keyStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		key = *temp
+	} else if object, ok := data["Object"].(map[string]interface{}); ok {
+		var objectStateDB string
+		key, objectStateDB, err = getStateObjectKey(int(chainID), object)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		if stateDBName == "" {
+			stateDBName = objectStateDB
+		}
+	} else {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("either Key or Object is required"))
+	}
+
+	result, err := httpServer.blockService.GetStateProof(int(chainID), *blockHash, stateDBName, key)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return result, nil
+}
+
+// getStateObjectKey derives the key of well-known state objects and the state db holding them
+func getStateObjectKey(chainID int, object map[string]interface{}) (common.Hash, string, error) {
+	objectType, _ := object["Type"].(string)
+	switch objectType {
+	case "SerialNumber":
+		if chainID == common.BeaconChainID {
+			return common.Hash{}, "", errors.New("serial numbers are stored in shard chains")
+		}
+		tokenIDStr, ok := object["TokenID"].(string)
+		if !ok {
+			return common.Hash{}, "", errors.New("TokenID is invalid")
+		}
+		tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return common.Hash{}, "", err
+		}
+		serialNumberStr, ok := object["SerialNumber"].(string)
+		if !ok {
+			return common.Hash{}, "", errors.New("SerialNumber is invalid")
+		}
+		serialNumber, _, err := base58.Base58Check{}.Decode(serialNumberStr)
+		if err != nil {
+			return common.Hash{}, "", err
+		}
+		return statedb.GenerateSerialNumberObjectKey(*tokenID, byte(chainID), serialNumber), rpcservice.TransactionStateDB, nil
+	case "Pdexv3PoolPair":
+		poolPairID, ok := object["PoolPairID"].(string)
+		if !ok || poolPairID == "" {
+			return common.Hash{}, "", errors.New("PoolPairID is invalid")
+		}
+		return statedb.GeneratePdexv3PoolPairObjectKey(poolPairID), rpcservice.FeatureStateDB, nil
+	case "BridgeStatus":
+		txReqIDStr, ok := object["TxReqID"].(string)
+		if !ok {
+			return common.Hash{}, "", errors.New("TxReqID is invalid")
+		}
+		txReqID, err := common.Hash{}.NewHashFromStr(txReqIDStr)
+		if err != nil {
+			return common.Hash{}, "", err
+		}
+		return statedb.GenerateBridgeStatusObjectKey(*txReqID), rpcservice.FeatureStateDB, nil
+	case "BridgeEthTx":
+		uniqueTxStr, ok := object["UniqueTx"].(string)
+		if !ok {
+			return common.Hash{}, "", errors.New("UniqueTx is invalid")
+		}
+		uniqueTx, err := hex.DecodeString(uniqueTxStr)
+		if err != nil {
+			return common.Hash{}, "", err
+		}
+		return statedb.GenerateBridgeEthTxObjectKey(uniqueTx), rpcservice.FeatureStateDB, nil
+	default:
+		return common.Hash{}, "", fmt.Errorf("unsupported object type %v", objectType)
+	}
+}
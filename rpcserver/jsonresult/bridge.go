@@ -19,3 +19,8 @@ type GetInstructionProof struct {
 	BridgeSigs           []string
 	BridgeSigIdxs        []int
 }
+
+type BridgeReqStatus struct {
+	Status       byte   `json:"Status"`
+	RejectReason string `json:"RejectReason,omitempty"`
+}
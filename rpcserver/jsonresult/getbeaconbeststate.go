@@ -34,6 +34,7 @@ type GetBeaconBestState struct {
 	CurrentRandomNumber                    int64                                        `json:"CurrentRandomNumber"`
 	CurrentRandomTimeStamp                 int64                                        `json:"CurrentRandomTimeStamp"` // random timestamp for this epoch
 	IsGetRandomNumber                      bool                                         `json:"IsGetRandomNumber"`
+	RandomBeaconValue                      common.Hash                                  `json:"RandomBeaconValue"`
 	MaxBeaconCommitteeSize                 int                                          `json:"MaxBeaconCommitteeSize"`
 	MinBeaconCommitteeSize                 int                                          `json:"MinBeaconCommitteeSize"`
 	MaxShardCommitteeSize                  int                                          `json:"MaxShardCommitteeSize"`
@@ -59,6 +60,7 @@ func NewGetBeaconBestState(data *blockchain.BeaconBestState) *GetBeaconBestState
 		CurrentRandomNumber:    data.CurrentRandomNumber,
 		CurrentRandomTimeStamp: data.CurrentRandomTimeStamp,
 		IsGetRandomNumber:      data.IsGetRandomNumber,
+		RandomBeaconValue:      data.RandomBeaconValue,
 		MaxShardCommitteeSize:  data.MaxShardCommitteeSize,
 		MinShardCommitteeSize:  data.MinShardCommitteeSize,
 		MaxBeaconCommitteeSize: data.MaxBeaconCommitteeSize,
@@ -37,6 +37,7 @@ type GetBeaconBestStateDetail struct {
 	CurrentRandomNumber                    int64                                        `json:"CurrentRandomNumber"`
 	CurrentRandomTimeStamp                 int64                                        `json:"CurrentRandomTimeStamp"` // random timestamp for this epoch
 	IsGetRandomNumber                      bool                                         `json:"IsGetRandomNumber"`
+	RandomBeaconValue                      common.Hash                                  `json:"RandomBeaconValue"`
 	MaxBeaconCommitteeSize                 int                                          `json:"MaxBeaconCommitteeSize"`
 	MinBeaconCommitteeSize                 int                                          `json:"MinBeaconCommitteeSize"`
 	MaxShardCommitteeSize                  int                                          `json:"MaxShardCommitteeSize"`
@@ -58,6 +59,7 @@ func NewGetBeaconBestStateDetail(data *blockchain.BeaconBestState) *GetBeaconBes
 		CurrentRandomNumber:    data.CurrentRandomNumber,
 		CurrentRandomTimeStamp: data.CurrentRandomTimeStamp,
 		IsGetRandomNumber:      data.IsGetRandomNumber,
+		RandomBeaconValue:      data.RandomBeaconValue,
 		MaxShardCommitteeSize:  data.MaxShardCommitteeSize,
 		MinShardCommitteeSize:  data.MinShardCommitteeSize,
 		MaxBeaconCommitteeSize: data.MaxBeaconCommitteeSize,
@@ -83,24 +83,27 @@ func NewGetMempoolInfo(txMempool interface {
 }
 
 type GetMempoolInfoTx struct {
-	TxID     string `json:"TxID"`
-	LockTime int64  `json:"LockTime"`
-	TpKey    string `json:"TpKey"`
+	TxID         string `json:"TxID"`
+	LockTime     int64  `json:"LockTime"`
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
+	TpKey        string `json:"TpKey"`
 }
 
 func NewGetMempoolInfoTxV2(infoTx txpool.MempoolInfoTx) *GetMempoolInfoTx {
 	result := &GetMempoolInfoTx{
-		LockTime: infoTx.GetLockTime(),
-		TxID:     infoTx.GetTxID(),
+		LockTime:     infoTx.GetLockTime(),
+		ExpiryHeight: infoTx.GetExpiryHeight(),
+		TxID:         infoTx.GetTxID(),
 	}
 	return result
 }
 
 func NewGetMempoolInfoTx(tpKey common.Hash, tx metadata.Transaction) *GetMempoolInfoTx {
 	result := &GetMempoolInfoTx{
-		LockTime: tx.GetLockTime(),
-		TxID:     tx.Hash().String(),
-		TpKey:    tpKey.String(),
+		LockTime:     tx.GetLockTime(),
+		ExpiryHeight: tx.GetExpiryHeight(),
+		TxID:         tx.Hash().String(),
+		TpKey:        tpKey.String(),
 	}
 	return result
 }
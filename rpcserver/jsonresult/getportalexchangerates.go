@@ -12,3 +12,20 @@ type FinalExchangeRatesResult struct {
 type ExchangeRatesResult struct {
 	Rates map[string]uint64 `json:"Rates"`
 }
+
+type ExchangeRateOracleTokenHealth struct {
+	Value            uint64 `json:"Value"`
+	IsStale          bool   `json:"IsStale"`
+	NumOfFeeders     int    `json:"NumOfFeeders"`
+	LastReportHeight uint64 `json:"LastReportHeight"`
+}
+
+type ExchangeRateOracleHealthResult struct {
+	BeaconHeight        uint64                                   `json:"BeaconHeight"`
+	IsEnabled           bool                                     `json:"IsEnabled"`
+	FeederAddresses     []string                                 `json:"FeederAddresses"`
+	WindowBlocks        uint64                                   `json:"WindowBlocks"`
+	MinFeeders          int                                      `json:"MinFeeders"`
+	MaxDeviationPercent uint64                                   `json:"MaxDeviationPercent"`
+	Rates               map[string]ExchangeRateOracleTokenHealth `json:"Rates"`
+}
@@ -50,6 +50,10 @@ type TransactionDetail struct {
 	IsInMempool bool `json:"IsInMempool"`
 	IsInBlock   bool `json:"IsInBlock"`
 
+	// ExpiryHeight is the last shard height the tx can be included at, IsExpired is set once the shard passed it
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
+	IsExpired    bool   `json:"IsExpired"`
+
 	Info string `json:"Info"`
 }
 
@@ -178,6 +182,7 @@ func NewTransactionDetail(tx metadata.Transaction, blockHash *common.Hash, block
 			return nil, errors.New("Tx type is invalid")
 		}
 	}
+	result.ExpiryHeight = tx.GetExpiryHeight()
 	return result, nil
 }
 
@@ -0,0 +1,20 @@
+package jsonresult
+
+type OTAOutputCoinResult struct {
+	ShardID       byte    `json:"ShardID"`
+	SenderShardID byte    `json:"SenderShardID"`
+	BlockHeight   uint64  `json:"BlockHeight"`
+	BlockHash     string  `json:"BlockHash"`
+	TxHash        string  `json:"TxHash"` // empty for a cross-shard output coin
+	TokenID       string  `json:"TokenID"`
+	OutputCoin    OutCoin `json:"OutputCoin"`
+}
+
+type SpentKeyImageResult struct {
+	ShardID     byte   `json:"ShardID"`
+	BlockHeight uint64 `json:"BlockHeight"`
+	BlockHash   string `json:"BlockHash"`
+	TxHash      string `json:"TxHash"`
+	TokenID     string `json:"TokenID"`
+	KeyImage    string `json:"KeyImage"`
+}
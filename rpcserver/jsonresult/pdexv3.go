@@ -20,3 +20,21 @@ type Pdexv3LPValue struct {
 	OrderReward map[string]uint64 `json:"OrderReward"`
 	PoolReward  map[string]uint64 `json:"PoolReward"`
 }
+
+// Pdexv3Candle is the OHLCV of a pool pair over one interval, prices are amounts of token1 per token0
+type Pdexv3Candle struct {
+	StartTime  int64   `json:"StartTime"`
+	Open       float64 `json:"Open"`
+	High       float64 `json:"High"`
+	Low        float64 `json:"Low"`
+	Close      float64 `json:"Close"`
+	Volume0    uint64  `json:"Volume0"`
+	Volume1    uint64  `json:"Volume1"`
+	TradeCount uint64  `json:"TradeCount"`
+}
+
+type Pdexv3PoolPairCandles struct {
+	PoolPairID string          `json:"PoolPairID"`
+	Interval   int64           `json:"Interval"`
+	Candles    []*Pdexv3Candle `json:"Candles"`
+}
@@ -0,0 +1,12 @@
+package jsonresult
+
+type StateProof struct {
+	ChainID     int      `json:"ChainID"`
+	BlockHash   string   `json:"BlockHash"`
+	BlockHeight uint64   `json:"BlockHeight"`
+	StateDB     string   `json:"StateDB"`
+	Root        string   `json:"Root"` // Computed by the node, no block header commits to it
+	Key         string   `json:"Key"`
+	Value       string   `json:"Value"` // Hex encoded raw object, empty if the key is absent
+	Proof       []string `json:"Proof"` // Hex encoded trie nodes on the path to the key
+}
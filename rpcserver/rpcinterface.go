@@ -47,8 +47,10 @@ var HttpHandler = map[string]httpHandler{
 	// getNextCrossShard: (*HttpServer).handleGetNextCrossShard,
 
 	//backup and preload
-	setBackup:       (*HttpServer).handleSetBackup,
-	getLatestBackup: (*HttpServer).handleGetLatestBackup,
+	setBackup:           (*HttpServer).handleSetBackup,
+	getLatestBackup:     (*HttpServer).handleGetLatestBackup,
+	getBackupManifest:   (*HttpServer).handleGetBackupManifest,
+	downloadBackupChunk: (*HttpServer).handleDownloadBackupChunk,
 	// block
 	getBestBlock:                (*HttpServer).handleGetBestBlock,
 	getBestBlockHash:            (*HttpServer).handleGetBestBlockHash,
@@ -119,6 +121,12 @@ var HttpHandler = map[string]httpHandler{
 	removeByzantineDetector:    (*HttpServer).handleRemoveByzantineDetector,
 	getConsensusData:           (*HttpServer).handleGetConsensusData,
 	getProposerIndex:           (*HttpServer).handleGetProposerIndex,
+
+	// equivocation evidence
+	getEquivocationEvidence:                 (*HttpServer).handleGetEquivocationEvidence,
+	getLocalEquivocationEvidences:           (*HttpServer).handleGetLocalEquivocationEvidences,
+	createRawTxWithEquivocationEvidence:     (*HttpServer).handleCreateRawTxWithEquivocationEvidence,
+	createAndSendTxWithEquivocationEvidence: (*HttpServer).handleCreateAndSendTxWithEquivocationEvidence,
 	//=================================
 
 	// Beststate
@@ -191,6 +199,11 @@ var HttpHandler = map[string]httpHandler{
 	createAndSendTxWithIssuingSOLReq:      (*HttpServer).handleCreateAndSendTxWithIssuingSOLReq,
 	createAndSendBurningSOLRequest:        (*HttpServer).handleCreateAndSendBurningSOLRequest,
 
+	// generic evm networks
+	createAndSendTxWithIssuingEVMNetworkReq: (*HttpServer).handleCreateAndSendTxWithIssuingEVMNetworkReq,
+	createAndSendBurningEVMNetworkRequest:   (*HttpServer).handleCreateAndSendBurningEVMNetworkRequest,
+	getEVMNetworkBurnProof:                  (*HttpServer).handleGetEVMNetworkBurnProof,
+
 	// Incognito -> Ethereum bridge
 	getBeaconSwapProof:       (*HttpServer).handleGetBeaconSwapProof,
 	getLatestBeaconSwapProof: (*HttpServer).handleGetLatestBeaconSwapProof,
@@ -271,6 +284,9 @@ var HttpHandler = map[string]httpHandler{
 	getPdexv3EstimatedStakingPoolReward:            (*HttpServer).handleGetPdexv3EstimatedStakingPoolReward,
 	createAndSendTxWithPdexv3WithdrawStakingReward: (*HttpServer).handleCreateAndSendTxWithPdexv3WithdrawStakingReward,
 	getPdexv3WithdrawalStakingRewardStatus:         (*HttpServer).handleGetPdexv3WithdrawalStakingRewardStatus,
+	getPdexv3PoolPairCandles:                       (*HttpServer).handleGetPdexv3PoolPairCandles,
+	getPdexv3TradeHistory:                          (*HttpServer).handleGetPdexv3TradeHistory,
+	getPdexv3PoolPairStateAtHeight:                 (*HttpServer).handleGetPdexv3PoolPairStateAtHeight,
 
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 
@@ -282,6 +298,7 @@ var HttpHandler = map[string]httpHandler{
 	createAndSendTxWithReqPToken:                  (*HttpServer).handleCreateAndSendTxWithReqPToken,
 	createAndSendPortalExchangeRates:              (*HttpServer).handleCreateAndSendTxWithPortalExchangeRate,
 	getPortalFinalExchangeRates:                   (*HttpServer).handleGetPortalFinalExchangeRates,
+	getPortalExchangeRateOracleHealth:             (*HttpServer).handleGetPortalExchangeRateOracleHealth,
 	getPortalPortingRequestByKey:                  (*HttpServer).handleGetPortingRequestStatusByTxID,
 	getPortalPortingRequestByPortingId:            (*HttpServer).handleGetPortingRequestStatusByPortingId,
 	convertExchangeRates:                          (*HttpServer).handleConvertExchangeRates,
@@ -324,13 +341,19 @@ var HttpHandler = map[string]httpHandler{
 	getPortalUnlockOverRateCollateralsStatus:      (*HttpServer).handleGetPortalReqUnlockOverRateCollateralStatus,
 
 	// relaying
-	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
-	createAndSendTxWithRelayingBTCHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBTCHeader,
-	getRelayingBNBHeaderState:            (*HttpServer).handleGetRelayingBNBHeaderState,
-	getRelayingBNBHeaderByBlockHeight:    (*HttpServer).handleGetRelayingBNBHeaderByBlockHeight,
-	getBTCRelayingBestState:              (*HttpServer).handleGetBTCRelayingBestState,
-	getBTCBlockByHash:                    (*HttpServer).handleGetBTCBlockByHash,
-	getLatestBNBHeaderBlockHeight:        (*HttpServer).handleGetLatestBNBHeaderBlockHeight,
+	createAndSendTxWithRelayingBNBHeader:  (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
+	createAndSendTxWithRelayingBTCHeader:  (*HttpServer).handleCreateAndSendTxWithRelayingBTCHeader,
+	getRelayingBNBHeaderState:             (*HttpServer).handleGetRelayingBNBHeaderState,
+	getRelayingBNBHeaderByBlockHeight:     (*HttpServer).handleGetRelayingBNBHeaderByBlockHeight,
+	getBTCRelayingBestState:               (*HttpServer).handleGetBTCRelayingBestState,
+	createAndSendTxWithRelayingLTCHeader:  (*HttpServer).handleCreateAndSendTxWithRelayingLTCHeader,
+	createAndSendTxWithRelayingDOGEHeader: (*HttpServer).handleCreateAndSendTxWithRelayingDOGEHeader,
+	createAndSendTxWithRelayingBCHHeader:  (*HttpServer).handleCreateAndSendTxWithRelayingBCHHeader,
+	getLTCRelayingBestState:               (*HttpServer).handleGetLTCRelayingBestState,
+	getDOGERelayingBestState:              (*HttpServer).handleGetDOGERelayingBestState,
+	getBCHRelayingBestState:               (*HttpServer).handleGetBCHRelayingBestState,
+	getBTCBlockByHash:                     (*HttpServer).handleGetBTCBlockByHash,
+	getLatestBNBHeaderBlockHeight:         (*HttpServer).handleGetLatestBNBHeaderBlockHeight,
 
 	// incognnito mode for sc
 	getBurnProofForDepositToSC:                    (*HttpServer).handleGetBurnProofForDepositToSC,
@@ -378,10 +401,16 @@ var HttpHandler = map[string]httpHandler{
 	getPortalConvertVaultTxStatus:              (*HttpServer).handleGetPortalConvertVaultTxStatus,
 	getPortalV4Params:                          (*HttpServer).handleGetPortalV4Params,
 	generatePortalShieldMultisigAddress:        (*HttpServer).handleGenerateShieldingMultisigAddress,
+	createAndSendTxWithPortalVaultRotation:     (*HttpServer).handleCreateAndSendTxWithPortalVaultRotation,
+	getPortalVaultRotationRequestStatus:        (*HttpServer).handleGetPortalVaultRotationRequestStatus,
+	getPortalVaultRotationStatus:               (*HttpServer).handleGetPortalVaultRotationStatus,
 
 	// unstake
 	unstake: (*HttpServer).handleCreateUnstakeTransaction,
 
+	// state proof
+	getStateProof: (*HttpServer).handleGetStateProof,
+
 	connectionStatus: (*HttpServer).handleGetConnectionStatus,
 }
 
@@ -407,6 +436,7 @@ var LimitedHttpHandler = map[string]httpHandler{
 	submitKey:                        (*HttpServer).handleSubmitKey,
 	authorizedSubmitKey:              (*HttpServer).handleAuthorizedSubmitKey,
 	getKeySubmissionInfo:             (*HttpServer).handleGetKeySubmissionInfo,
+	pinStateSnapshot:                 (*HttpServer).handlePinStateSnapshot,
 }
 
 var WsHandler = map[string]wsHandler{
@@ -423,6 +453,9 @@ var WsHandler = map[string]wsHandler{
 	subcribeMempoolInfo:                         (*WsServer).handleSubcribeMempoolInfo,
 	subcribeCrossOutputCoinByPrivateKey:         (*WsServer).handleSubcribeCrossOutputCoinByPrivateKey,
 	subcribeCrossCustomTokenPrivacyByPrivateKey: (*WsServer).handleSubcribeCrossCustomTokenPrivacyByPrivateKey,
+	subcribeOutputCoinByOTAKey:                  (*WsServer).handleSubcribeOutputCoinByOTAKey,
+	subcribeSpentKeyImages:                      (*WsServer).handleSubcribeSpentKeyImages,
+	subcribeRequestEvents:                       (*WsServer).handleSubscribeRequestEvents,
 	subcribeShardBestState:                      (*WsServer).handleSubscribeShardBestState,
 	subcribeBeaconBestState:                     (*WsServer).handleSubscribeBeaconBestState,
 	subcribeBeaconBestStateFromMem:              (*WsServer).handleSubscribeBeaconBestStateFromMem,
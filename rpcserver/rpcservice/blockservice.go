@@ -940,6 +940,16 @@ func (blockService BlockService) GetBridgeReqWithStatus(txID string) (byte, erro
 	return status, nil
 }
 
+// GetBridgeReqRejectReason returns the reason recorded by the beacon for a rejected bridge request
+func (blockService BlockService) GetBridgeReqRejectReason(txID string) (string, error) {
+	txIDHash, err := common.Hash{}.NewHashFromStr(txID)
+	if err != nil {
+		return "", err
+	}
+	bridgeStateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	return statedb.GetBridgeReqRejectReason(bridgeStateDB, *txIDHash)
+}
+
 func (blockService BlockService) GetAllBridgeTokens() ([]*rawdbv2.BridgeTokenInfo, error) {
 	_, bridgeTokenInfos, err := blockService.BlockChain.GetAllBridgeTokens()
 	return bridgeTokenInfos, err
@@ -1394,3 +1404,90 @@ func (blockService BlockService) CheckPortalExternalTxSubmitted(data map[string]
 	submitted, err := statedb.IsPortalExternalTxHashSubmitted(featureStateDB, uniqExternalTx)
 	return submitted, err
 }
+
+// GetStateProof returns the value of a state object key and its Merkle proof
+// against the root of a state db right after the given beacon or shard block.
+// The root is the one stored by this node, block headers do not commit to it
+func (blockService BlockService) GetStateProof(chainID int, blockHash common.Hash, stateDBName string, key common.Hash) (*jsonresult.StateProof, error) {
+	var db incdb.Database
+	var root common.Hash
+	var height uint64
+	if chainID == common.BeaconChainID {
+		db = blockService.BlockChain.GetBeaconChainDatabase()
+		block, _, err := blockService.BlockChain.GetBeaconBlockByHash(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		height = block.GetHeight()
+		roots, err := blockchain.GetBeaconRootsHashByBlockHash(db, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		switch stateDBName {
+		case ConsensusStateDB:
+			root = roots.ConsensusStateDBRootHash
+		case FeatureStateDB:
+			root = roots.FeatureStateDBRootHash
+		case RewardStateDB:
+			root = roots.RewardStateDBRootHash
+		case SlashStateDB:
+			root = roots.SlashStateDBRootHash
+		default:
+			return nil, fmt.Errorf("invalid beacon state db %v", stateDBName)
+		}
+	} else {
+		if chainID < 0 || chainID >= blockService.BlockChain.GetActiveShardNumber() {
+			return nil, fmt.Errorf("invalid chain id %v", chainID)
+		}
+		shardID := byte(chainID)
+		db = blockService.BlockChain.GetShardChainDatabase(shardID)
+		block, _, err := blockService.BlockChain.GetShardBlockByHash(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		if block.Header.ShardID != shardID {
+			return nil, fmt.Errorf("block %v is not in shard %v", blockHash.String(), shardID)
+		}
+		height = block.GetHeight()
+		roots, err := blockchain.GetShardRootsHashByBlockHash(db, shardID, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		switch stateDBName {
+		case ConsensusStateDB:
+			root = roots.ConsensusStateDBRootHash
+		case TransactionStateDB:
+			root = roots.TransactionStateDBRootHash
+		case FeatureStateDB:
+			root = roots.FeatureStateDBRootHash
+		case RewardStateDB:
+			root = roots.RewardStateDBRootHash
+		case SlashStateDB:
+			root = roots.SlashStateDBRootHash
+		default:
+			return nil, fmt.Errorf("invalid shard state db %v", stateDBName)
+		}
+	}
+
+	stateDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return nil, err
+	}
+	value, proof, err := stateDB.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	result := &jsonresult.StateProof{
+		ChainID:     chainID,
+		BlockHash:   blockHash.String(),
+		BlockHeight: height,
+		StateDB:     stateDBName,
+		Root:        root.String(),
+		Key:         key.String(),
+		Value:       hex.EncodeToString(value),
+	}
+	for _, node := range proof {
+		result.Proof = append(result.Proof, hex.EncodeToString(node))
+	}
+	return result, nil
+}
@@ -16,3 +16,12 @@ const (
 	IntermidateVerbosity = 2
 	FullVerbosity        = 3
 )
+
+// state dbs a state proof can be requested from
+const (
+	ConsensusStateDB   = "consensus"
+	TransactionStateDB = "transaction"
+	FeatureStateDB     = "feature"
+	RewardStateDB      = "reward"
+	SlashStateDB       = "slash"
+)
@@ -3,6 +3,7 @@ package rpcservice
 import (
 	"fmt"
 
+	"github.com/incognitochain/incognito-chain/trie"
 	"github.com/pkg/errors"
 )
 
@@ -105,6 +106,7 @@ const (
 	GetCustodianTopupWaitingPortingStatusError
 	GetAmountTopUpWaitingPortingError
 	GetCustodianDepositV3Error
+	GetExchangeRateOracleHealthError
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError
@@ -142,8 +144,12 @@ const (
 	GetPortalV4FeeReplacementReqStatusError
 	GetPortalV4SubmitConfirmedTxStatusError
 	GetPortalV4ConvertVaultTxStatusError
+	GetPortalV4VaultRotationReqStatusError
+	GetPortalV4VaultRotationStatusError
 
 	CacheQueueError
+	StatePrunedError
+	PinStateSnapshotError
 
 	// pdex v3
 	GetPdexv3StateError
@@ -154,6 +160,7 @@ const (
 	GetPdexv3WithdrawalLPFeeStatusError
 	GetPdexv3WithdrawalProtocolFeeStatusError
 	GetPdexv3WithdrawalStakingRewardStatusError
+	GetPdexv3HistoryError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -260,6 +267,7 @@ var ErrCodeMessage = map[int]struct {
 	GetAmountTopUpWaitingPortingError:                  {-9017, "Get amount top up for waiting porting error"},
 	GetReqRedeemFromLiquidationPoolStatusError:         {-9018, "Get redeem request from liquidation pool status error"},
 	GetCustodianDepositV3Error:                         {-9019, "Get custodian deposit v3 status error"},
+	GetExchangeRateOracleHealthError:                   {-9020, "Get exchange rate oracle health error"},
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError: {-10001, "Get relaying bnb header by block height error"},
@@ -286,12 +294,15 @@ var ErrCodeMessage = map[int]struct {
 	GetAllBeaconViews:                             {-12009, "Get all beacon views"},
 	GetTotalStakerError:                           {-12010, "Get total staker return error"},
 
-	CacheQueueError: {-13001, "Full node cache error"},
+	CacheQueueError:       {-13001, "Full node cache error"},
+	StatePrunedError:      {-13002, "State has been pruned, query an archive node"},
+	PinStateSnapshotError: {-13003, "Pin state snapshot error"},
 
 	// pDex v3
 	GetPdexv3StateError:                {-14001, "Get pDex V3 state error"},
 	GenerateOTAFailError:               {-14002, "Generate ota fail"},
 	GetPdexv3ParamsModyfingStatusError: {-14003, "Get pDex v3 params modyfing status error"},
+	GetPdexv3HistoryError:              {-14004, "Get pDex v3 history error"},
 	// Portal v4
 	GetPortalV4ShieldReqStatusError:         {-12501, "Get portal v4 shielding request status error"},
 	GetPortalV4UnshieldReqStatusError:       {-12502, "Get portal v4 unshielding request status error"},
@@ -299,6 +310,8 @@ var ErrCodeMessage = map[int]struct {
 	GetPortalV4FeeReplacementReqStatusError: {-12504, "Get portal v4 fee replacement request status error"},
 	GetPortalV4SubmitConfirmedTxStatusError: {-12505, "Get portal v4 submit external confirmed tx request status error"},
 	GetPortalV4ConvertVaultTxStatusError:    {-12506, "Get portal v4 convert vault tx request status error"},
+	GetPortalV4VaultRotationReqStatusError:  {-12507, "Get portal v4 vault rotation request status error"},
+	GetPortalV4VaultRotationStatusError:     {-12508, "Get portal v4 vault rotation status error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
@@ -331,6 +344,10 @@ func (e RPCError) GetErr() error {
 // NewRPCError constructs and returns a new JSON-RPC error that is suitable
 // for use in a JSON-RPC JsonResponse object.
 func NewRPCError(key int, err error, param ...interface{}) *RPCError {
+	// reading a state removed by pruning fails the same way whatever the request
+	if trie.IsPrunedError(err) {
+		key = StatePrunedError
+	}
 	e := &RPCError{
 		Code: ErrCodeMessage[key].Code,
 		err:  errors.Wrap(err, ErrCodeMessage[key].Message),
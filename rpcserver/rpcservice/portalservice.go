@@ -104,6 +104,42 @@ func (s *PortalService) GetFinalExchangeRates(stateDB *statedb.StateDB, beaconHe
 	return result, nil
 }
 
+func (s *PortalService) GetExchangeRateOracleHealth(
+	stateDB *statedb.StateDB, beaconHeight uint64, portalParams portalv3.PortalParams) (jsonresult.ExchangeRateOracleHealthResult, error) {
+	finalExchangeRates, err := statedb.GetFinalExchangeRatesState(stateDB)
+	if err != nil {
+		return jsonresult.ExchangeRateOracleHealthResult{}, err
+	}
+
+	item := make(map[string]jsonresult.ExchangeRateOracleTokenHealth)
+	for pTokenID, rates := range finalExchangeRates.Rates() {
+		lastReportHeight := uint64(0)
+		for _, submission := range rates.Submissions {
+			if submission.BeaconHeight > lastReportHeight {
+				lastReportHeight = submission.BeaconHeight
+			}
+		}
+		item[pTokenID] = jsonresult.ExchangeRateOracleTokenHealth{
+			Value:            rates.Amount,
+			IsStale:          rates.IsStale,
+			NumOfFeeders:     len(rates.Submissions),
+			LastReportHeight: lastReportHeight,
+		}
+	}
+
+	oracleParams := portalParams.ExchangeRateOracle
+	result := jsonresult.ExchangeRateOracleHealthResult{
+		BeaconHeight:        beaconHeight,
+		IsEnabled:           portalParams.IsExchangeRateOracleEnabled(),
+		FeederAddresses:     portalParams.GetFeederAddresses(),
+		WindowBlocks:        oracleParams.WindowBlocks,
+		MinFeeders:          oracleParams.MinFeeders,
+		MaxDeviationPercent: oracleParams.MaxDeviationPercent,
+		Rates:               item,
+	}
+	return result, nil
+}
+
 func (s *PortalService) ConvertExchangeRates(
 	finalExchangeRates *statedb.FinalExchangeRatesState, portalParams portalv3.PortalParams,
 	amount uint64, tokenIDFrom string, tokenIDTo string) (uint64, error) {
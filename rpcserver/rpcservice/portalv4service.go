@@ -103,3 +103,19 @@ func (blockService BlockService) GetPortalConvertVaultTxStatus(reqTxID string) (
 
 	return &status, nil
 }
+
+func (blockService BlockService) GetPortalVaultRotationRequestStatus(reqTxID string) (*metadata.PortalVaultRotationRequestStatus, error) {
+	stateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	data, err := statedb.GetPortalVaultRotationRequestStatus(stateDB, reqTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status metadata.PortalVaultRotationRequestStatus
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
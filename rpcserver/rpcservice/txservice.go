@@ -26,6 +26,7 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -1472,10 +1473,17 @@ func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.T
 	shardID, blockHash, blockHeight, index, tx, err := txService.BlockChain.GetTransactionByHash(*txHash)
 	if err != nil {
 		// maybe tx is still in tx mempool -> check mempool
+		isInMempool := true
 		if txService.BlockChain.UsingNewPool() {
 			pM := txService.BlockChain.GetPoolManager()
 			if pM != nil {
 				tx, err = pM.GetTransactionByHash(txHashStr)
+				if err != nil {
+					// or it was evicted from the mempool because of its expiry height
+					if expiredTx, errExpired := pM.GetExpiredTransactionByHash(txHashStr); errExpired == nil {
+						tx, err, isInMempool = expiredTx, nil, false
+					}
+				}
 			} else {
 				err = errors.New("PoolManager is nil")
 			}
@@ -1490,7 +1498,10 @@ func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.T
 		if errM != nil {
 			return nil, NewRPCError(UnexpectedError, errM)
 		}
-		result.IsInMempool = true
+		result.IsInMempool = isInMempool
+		if bestState := txService.BlockChain.GetBestStateShard(shardIDTemp); bestState != nil {
+			result.IsExpired = tx_generic.IsTxExpired(tx, bestState.ShardHeight+1)
+		}
 		return result, nil
 	}
 
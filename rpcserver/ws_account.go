@@ -7,9 +7,14 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/transaction"
+	coinIndexer "github.com/incognitochain/incognito-chain/transaction/coin_indexer"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -195,3 +200,296 @@ func (wsServer *WsServer) handleSubcribeCrossCustomTokenPrivacyByPrivateKey(para
 		}
 	}
 }
+
+// shardBlockCoins are output coins of a tx (or of a cross-shard tx if txHash is empty) in a shard block
+type shardBlockCoins struct {
+	txHash        string
+	tokenID       common.Hash
+	senderShardID byte
+	coins         []privacy.Coin
+}
+
+// getShardBlockOutputCoins returns the output coins of the txs and of the cross-shard txs of a shard block
+func getShardBlockOutputCoins(shardBlock *types.ShardBlock) []shardBlockCoins {
+	res := []shardBlockCoins{}
+	shardID := shardBlock.Header.ShardID
+	for _, tx := range shardBlock.Body.Transactions {
+		txHash := tx.Hash().String()
+		if txToken, ok := tx.(transaction.TransactionToken); ok {
+			if txToken.GetTxBase().GetProof() != nil {
+				res = append(res, shardBlockCoins{txHash, common.PRVCoinID, shardID, txToken.GetTxBase().GetProof().GetOutputCoins()})
+			}
+			if txToken.GetTxNormal().GetProof() != nil {
+				res = append(res, shardBlockCoins{txHash, *tx.GetTokenID(), shardID, txToken.GetTxNormal().GetProof().GetOutputCoins()})
+			}
+			continue
+		}
+		if tx.GetProof() != nil {
+			res = append(res, shardBlockCoins{txHash, common.PRVCoinID, shardID, tx.GetProof().GetOutputCoins()})
+		}
+	}
+	for senderShardID, crossTransactions := range shardBlock.Body.CrossTransactions {
+		for _, crossTransaction := range crossTransactions {
+			res = append(res, shardBlockCoins{"", common.PRVCoinID, senderShardID, crossTransaction.OutputCoin})
+			for _, crossTokenPrivacyData := range crossTransaction.TokenPrivacyData {
+				res = append(res, shardBlockCoins{"", crossTokenPrivacyData.PropertyID, senderShardID, crossTokenPrivacyData.OutputCoin})
+			}
+		}
+	}
+	return res
+}
+
+// shardBlockKeyImages are key images of the input coins of a tx in a shard block
+type shardBlockKeyImages struct {
+	txHash    string
+	tokenID   common.Hash
+	keyImages []*privacy.Point
+}
+
+// getShardBlockKeyImages returns the key images of the input coins of the txs of a shard block
+func getShardBlockKeyImages(shardBlock *types.ShardBlock) []shardBlockKeyImages {
+	res := []shardBlockKeyImages{}
+	appendInputCoins := func(txHash string, tokenID common.Hash, inputCoins []privacy.PlainCoin) {
+		keyImages := make([]*privacy.Point, 0, len(inputCoins))
+		for _, inputCoin := range inputCoins {
+			if inputCoin.GetKeyImage() != nil {
+				keyImages = append(keyImages, inputCoin.GetKeyImage())
+			}
+		}
+		res = append(res, shardBlockKeyImages{txHash, tokenID, keyImages})
+	}
+	for _, tx := range shardBlock.Body.Transactions {
+		txHash := tx.Hash().String()
+		if txToken, ok := tx.(transaction.TransactionToken); ok {
+			if txToken.GetTxBase().GetProof() != nil {
+				appendInputCoins(txHash, common.PRVCoinID, txToken.GetTxBase().GetProof().GetInputCoins())
+			}
+			if txToken.GetTxNormal().GetProof() != nil {
+				appendInputCoins(txHash, *tx.GetTokenID(), txToken.GetTxNormal().GetProof().GetInputCoins())
+			}
+			continue
+		}
+		if tx.GetProof() != nil {
+			appendInputCoins(txHash, common.PRVCoinID, tx.GetProof().GetInputCoins())
+		}
+	}
+	return res
+}
+
+// decryptOutputCoinV2 decrypts a copy of an output coin if the key set has a read-only key,
+// the coin itself belongs to the shard block and must not be updated
+func decryptOutputCoinV2(outputCoin *privacy.CoinV2, keySet *incognitokey.KeySet) *privacy.CoinV2 {
+	if len(keySet.ReadonlyKey.Rk) == 0 {
+		return outputCoin
+	}
+	copiedCoin := &privacy.CoinV2{}
+	err := copiedCoin.SetBytes(outputCoin.Bytes())
+	if err != nil {
+		Logger.log.Errorf("Err %v", err)
+		return outputCoin
+	}
+	_, err = copiedCoin.Decrypt(keySet)
+	if err != nil {
+		Logger.log.Errorf("Err %v", err)
+		return outputCoin
+	}
+	return copiedCoin
+}
+
+// handleSubcribeOutputCoinByOTAKey streams the new v2 output coins of an OTA key as shard blocks are inserted.
+// Params: OTA key, token ID (optional, all tokens if empty), read-only key (optional, to decrypt the coin values).
+func (wsServer *WsServer) handleSubcribeOutputCoinByOTAKey(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 || len(arrayParams) > 3 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should contain an OTA key, an optional token ID and an optional read-only key"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	otaKeyStr, ok := arrayParams[0].(string)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("OTA key is invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	otaKeyWallet, err := wallet.Base58CheckDeserialize(otaKeyStr)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	otaKey := otaKeyWallet.KeySet.OTAKey
+	if otaKey.GetOTASecretKey() == nil || otaKey.GetPublicSpend() == nil {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("OTA key is invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	keySet := &incognitokey.KeySet{OTAKey: otaKey}
+
+	var tokenID *common.Hash
+	filter := coinIndexer.GetCoinFilterByOTAKey()
+	if len(arrayParams) > 1 {
+		tokenIDStr, ok := arrayParams[1].(string)
+		if !ok {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		if tokenIDStr != "" {
+			tokenID, err = common.Hash{}.NewHashFromStr(tokenIDStr)
+			if err != nil {
+				err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+				cResult <- RpcSubResult{Error: err}
+				return
+			}
+			filter = coinIndexer.GetCoinFilterByOTAKeyAndToken()
+		}
+	}
+	if len(arrayParams) > 2 {
+		readonlyKeyStr, ok := arrayParams[2].(string)
+		if !ok {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Read-only key is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		if readonlyKeyStr != "" {
+			readonlyKeyWallet, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+			if err != nil {
+				err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+				cResult <- RpcSubResult{Error: err}
+				return
+			}
+			keySet.ReadonlyKey = readonlyKeyWallet.KeySet.ReadonlyKey
+		}
+	}
+	publicSpend := otaKey.GetPublicSpend().ToBytesS()
+	shardID := common.GetShardIDFromLastByte(publicSpend[len(publicSpend)-1])
+
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Output Coin By OTA Key")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewShardblockTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				shardBlock, ok := msg.Value.(*types.ShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *types.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if shardBlock.Header.ShardID != shardID {
+					continue
+				}
+				for _, blockCoins := range getShardBlockOutputCoins(shardBlock) {
+					for _, outputCoin := range coinIndexer.MatchCoinsVer2(blockCoins.coins, otaKey, tokenID, filter) {
+						coinTokenID := blockCoins.tokenID.String()
+						if tokenID != nil {
+							coinTokenID = tokenID.String()
+						} else if outputCoin.GetAssetTag() == nil {
+							coinTokenID = common.PRVIDStr
+						}
+						cResult <- RpcSubResult{Result: jsonresult.OTAOutputCoinResult{
+							ShardID:       shardID,
+							SenderShardID: blockCoins.senderShardID,
+							BlockHeight:   shardBlock.Header.Height,
+							BlockHash:     shardBlock.Header.Hash().String(),
+							TxHash:        blockCoins.txHash,
+							TokenID:       coinTokenID,
+							OutputCoin:    jsonresult.NewOutCoin(decryptOutputCoinV2(outputCoin, keySet)),
+						}, Error: nil}
+					}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Output Coin By OTA Key"}}
+				return
+			}
+		}
+	}
+}
+
+// handleSubcribeSpentKeyImages notifies when the given key images (serial numbers) are spent in new shard blocks.
+// Params: list of base58 encoded key images.
+func (wsServer *WsServer) handleSubcribeSpentKeyImages(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain ONE params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	keyImageList := common.InterfaceSlice(arrayParams[0])
+	if len(keyImageList) == 0 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Key image list is empty"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	keyImages := make(map[string]string)
+	for _, item := range keyImageList {
+		keyImageStr, ok := item.(string)
+		if !ok {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Key image is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		keyImageBytes, _, err := base58.Base58Check{}.Decode(keyImageStr)
+		if err != nil {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		keyImages[string(keyImageBytes)] = keyImageStr
+	}
+
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Spent Key Images")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewShardblockTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				shardBlock, ok := msg.Value.(*types.ShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *types.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				for _, blockKeyImages := range getShardBlockKeyImages(shardBlock) {
+					for _, keyImage := range blockKeyImages.keyImages {
+						keyImageStr, ok := keyImages[string(keyImage.ToBytesS())]
+						if !ok {
+							continue
+						}
+						cResult <- RpcSubResult{Result: jsonresult.SpentKeyImageResult{
+							ShardID:     shardBlock.Header.ShardID,
+							BlockHeight: shardBlock.Header.Height,
+							BlockHash:   shardBlock.Header.Hash().String(),
+							TxHash:      blockKeyImages.txHash,
+							TokenID:     blockKeyImages.tokenID.String(),
+							KeyImage:    keyImageStr,
+						}, Error: nil}
+					}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Spent Key Images"}}
+				return
+			}
+		}
+	}
+}
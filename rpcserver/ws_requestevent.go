@@ -0,0 +1,124 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// requestEventFilter selects the request events sent to a subscriber, an empty field matches any event
+type requestEventFilter struct {
+	types      map[string]bool
+	poolPairID string
+	nftID      string
+	txID       string
+}
+
+func newRequestEventFilter(params map[string]interface{}) (*requestEventFilter, error) {
+	filter := &requestEventFilter{types: map[string]bool{}}
+	for _, item := range common.InterfaceSlice(params["Types"]) {
+		eventType, ok := item.(string)
+		if !ok {
+			return nil, errors.New("Types is invalid")
+		}
+		filter.types[eventType] = true
+	}
+	for key, value := range map[string]*string{"PoolPairID": &filter.poolPairID, "NftID": &filter.nftID, "TxID": &filter.txID} {
+		if params[key] == nil {
+			continue
+		}
+		str, ok := params[key].(string)
+		if !ok {
+			return nil, errors.New(key + " is invalid")
+		}
+		*value = str
+	}
+	return filter, nil
+}
+
+func (filter *requestEventFilter) match(event *blockchain.RequestEvent) bool {
+	if len(filter.types) > 0 && !filter.types[event.Type] {
+		return false
+	}
+	if filter.nftID != "" && filter.nftID != event.NftID {
+		return false
+	}
+	if filter.txID != "" && filter.txID != event.TxReqID && filter.txID != event.OrderID {
+		return false
+	}
+	if filter.poolPairID != "" {
+		for _, poolPairID := range event.PoolPairIDs {
+			if poolPairID == filter.poolPairID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// handleSubscribeRequestEvents pushes the status transitions of pDEX v3 and bridge requests as blocks are inserted.
+// Params: an optional filter {"Types": [...], "PoolPairID": "", "NftID": "", "TxID": ""}.
+func (wsServer *WsServer) handleSubscribeRequestEvents(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Request Events", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) > 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should contain at most 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	filterParams := map[string]interface{}{}
+	if len(arrayParams) == 1 && arrayParams[0] != nil {
+		var ok bool
+		filterParams, ok = arrayParams[0].(map[string]interface{})
+		if !ok {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Filter param is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+	}
+	filter, err := newRequestEventFilter(filterParams)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.RequestEventTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Request Events")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.RequestEventTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				events, ok := msg.Value.([]*blockchain.RequestEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []*blockchain.RequestEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				for _, event := range events {
+					if filter.match(event) {
+						cResult <- RpcSubResult{Result: event, Error: nil}
+					}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Request Events"}}
+				return
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signer"
 
 	p2ppubsub "github.com/incognitochain/go-libp2p-pubsub"
 	pb "github.com/incognitochain/go-libp2p-pubsub/pb"
@@ -36,7 +38,9 @@ import (
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
+	"github.com/incognitochain/incognito-chain/metrics/prometheus"
 	"github.com/incognitochain/incognito-chain/netsync"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/peerv2"
@@ -44,6 +48,7 @@ import (
 	"github.com/incognitochain/incognito-chain/pubsub"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/incognitochain/incognito-chain/relaying/btcrelayer"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/syncker"
 	"github.com/incognitochain/incognito-chain/transaction"
@@ -66,6 +71,8 @@ type Server struct {
 	syncker         *syncker.SynckerManager
 	memCache        *memcache.MemoryCache
 	rpcServer       *rpcserver.RpcServer
+	metricsServer   *http.Server
+	btcRelayer      *btcrelayer.Relayer
 	memPool         *mempool.TxPool
 	tempMemPool     *mempool.TxPool
 	waitGroup       sync.WaitGroup
@@ -74,6 +81,8 @@ type Server struct {
 	// userKeySet        *incognitokey.KeySet
 	miningKeys      string
 	privateKey      string
+	remoteSigner    string
+	remoteSignerTLS signer.TLSFiles
 	wallet          *wallet.Wallet
 	consensusEngine *consensus.Engine
 	blockgen        *blockchain.BlockGenerator
@@ -199,6 +208,7 @@ func (serverObj *Server) NewServer(
 	indexerToken string,
 	protocolVer string,
 	btcChain *btcrelaying.BlockChain,
+	utxoChains map[string]*btcrelaying.BlockChain,
 	bnbChainState *bnbrelaying.BNBChainState,
 	interrupt <-chan struct{},
 ) error {
@@ -223,6 +233,8 @@ func (serverObj *Server) NewServer(
 	serverObj.miningKeys = cfg.MiningKeys
 	serverObj.privateKey = cfg.PrivateKey
 	serverObj.miningKeys = cfg.MiningKeys
+	serverObj.remoteSigner = cfg.RemoteSigner
+	serverObj.remoteSignerTLS = signer.TLSFiles{Cert: cfg.RemoteSignerCert, Key: cfg.RemoteSignerKey, CA: cfg.RemoteSignerCA}
 
 	// if serverObj.miningKeys == "" && serverObj.privateKey == "" {
 	// 	if cfg.NodeMode == common.NodeModeAuto || cfg.NodeMode == common.NodeModeBeacon || cfg.NodeMode == common.NodeModeShard {
@@ -303,6 +315,10 @@ func (serverObj *Server) NewServer(
 		"",
 		relayShards,
 	)
+	if cfg.P2PTransport != "" && !peerv2.IsValidTransport(cfg.P2PTransport) {
+		return fmt.Errorf("invalid p2p transport %v", cfg.P2PTransport)
+	}
+	serverObj.highway.SetTransport(cfg.P2PTransport, strings.Split(cfg.BootstrapPeers, ";"))
 	poolManager, _ := txpool.NewPoolManager(
 		common.MaxShardNumber,
 		serverObj.pusubManager,
@@ -310,6 +326,7 @@ func (serverObj *Server) NewServer(
 	)
 	err = serverObj.blockChain.Init(&blockchain.Config{
 		BTCChain:      btcChain,
+		UTXOChains:    utxoChains,
 		BNBChainState: bnbChainState,
 		DataBase:      serverObj.dataBase,
 		MemCache:      serverObj.memCache,
@@ -552,6 +569,35 @@ func (serverObj *Server) NewServer(
 		}()
 	}
 
+	// Relay the headers of a bitcoind node, the txs are built and sent as the RPC server does
+	if cfg.BTCRelayerEndpoint != "" {
+		if serverObj.rpcServer == nil || serverObj.rpcServer.HttpServer == nil {
+			return errors.New("the BTC relayer requires the RPC server")
+		}
+		if btcChain == nil {
+			return errors.New("the BTC relayer requires the BTC relaying chain")
+		}
+		submitter, err := rpcserver.NewBTCHeaderSubmitter(serverObj.rpcServer.HttpServer, cfg.BTCRelayerPrivateKey)
+		if err != nil {
+			return fmt.Errorf("invalid BTC relayer private key, %v", err)
+		}
+		serverObj.btcRelayer = btcrelayer.NewRelayer(
+			btcrelayer.NewBitcoindClient(cfg.BTCRelayerEndpoint),
+			btcChain,
+			submitter,
+			btcrelayer.Config{BatchSize: cfg.BTCRelayerBatchSize},
+		)
+	}
+
+	// Serve the metrics registry and the grafana measurements for Prometheus
+	if cfg.MetricsListener != "" {
+		metricTool := prometheus.NewMetricTool(cfg.ExternalAddress)
+		grafana.InitMetricTool(metricTool)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", prometheus.Handler(metrics.DefaultRegistry, metricTool))
+		serverObj.metricsServer = &http.Server{Addr: cfg.MetricsListener, Handler: mux}
+	}
+
 	//Publish node state to other peer
 	go func() {
 		t := time.NewTicker(time.Second * 3)
@@ -608,6 +654,14 @@ func (serverObj *Server) Stop() error {
 		serverObj.rpcServer.Stop()
 	}
 
+	if serverObj.metricsServer != nil {
+		serverObj.metricsServer.Close()
+	}
+
+	if serverObj.btcRelayer != nil {
+		serverObj.btcRelayer.Stop()
+	}
+
 	// Save fee estimator in the db
 	for shardID, feeEstimator := range serverObj.feeEstimator {
 		Logger.log.Debugf("Fee estimator data when saving #%d", feeEstimator)
@@ -725,7 +779,21 @@ func (serverObj Server) Start() {
 		serverObj.rpcServer.Start()
 	}
 
-	if cfg.MiningKeys != "" || cfg.PrivateKey != "" {
+	if serverObj.metricsServer != nil {
+		go func() {
+			Logger.log.Infof("Metrics server listening on %s", serverObj.metricsServer.Addr)
+			if err := serverObj.metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				Logger.log.Error(err)
+			}
+		}()
+		go grafana.StartSystemMetrics()
+	}
+
+	if serverObj.btcRelayer != nil {
+		serverObj.btcRelayer.Start()
+	}
+
+	if cfg.MiningKeys != "" || cfg.PrivateKey != "" || cfg.RemoteSigner != "" {
 		serverObj.memPool.IsBlockGenStarted = true
 		serverObj.blockChain.SetIsBlockGenStarted(true)
 	}
@@ -1229,7 +1297,7 @@ func (serverObj *Server) GetPeerIDsFromPublicKey(pubKey string) []libp2p.ID {
 }
 
 func (serverObj *Server) GetNodeRole() string {
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		return "RELAY"
 	}
 	role, shardID := serverObj.GetUserMiningState()
@@ -1644,7 +1712,7 @@ func (serverObj *Server) GetChainMiningStatus(chain int) string {
 	if chain >= common.MaxShardNumber || chain < -1 {
 		return notmining
 	}
-	if config.Config().MiningKeys != "" || config.Config().PrivateKey != "" {
+	if config.Config().MiningKeys != "" || config.Config().PrivateKey != "" || config.Config().RemoteSigner != "" {
 		//Beacon: chain = -1
 		role, chainID := serverObj.GetUserMiningState()
 		layer := ""
@@ -1708,6 +1776,14 @@ func (serverObj *Server) GetPrivateKey() string {
 	return serverObj.privateKey
 }
 
+func (serverObj *Server) GetRemoteSigner() string {
+	return serverObj.remoteSigner
+}
+
+func (serverObj *Server) GetRemoteSignerTLS() signer.TLSFiles {
+	return serverObj.remoteSignerTLS
+}
+
 func (serverObj *Server) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
 	chainID := chain.GetShardID()
 	if chainID == -1 {
@@ -0,0 +1,120 @@
+package syncker
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	configpkg "github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/syncker/statesync"
+	"github.com/pkg/errors"
+)
+
+const (
+	fastSyncTimeout        = 6 * time.Hour
+	fastSyncSnapshotRetry  = 5
+	fastSyncSnapshotPeriod = 10 * time.Second
+)
+
+// fastSyncCheckpoint returns the trusted checkpoint configured for a chain, false if there is none
+func fastSyncCheckpoint(chainID int) (common.Hash, bool) {
+	for _, entry := range configpkg.Config().FastSyncCheckpoints {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			Logger.Errorf("Fast sync checkpoint %v is not <chainID>:<checkpoint>", entry)
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || id != chainID {
+			continue
+		}
+		checkpoint, err := common.Hash{}.NewHashFromStr(strings.TrimSpace(parts[1]))
+		if err != nil {
+			Logger.Errorf("Fast sync checkpoint %v of chain %v is invalid: %v", parts[1], chainID, err)
+			return common.Hash{}, false
+		}
+		return *checkpoint, true
+	}
+	return common.Hash{}, false
+}
+
+// shouldFastSync is true when fast sync is enabled, the chain has a trusted checkpoint and still has only its genesis block
+func shouldFastSync(chainID int, chain Chain) bool {
+	if !configpkg.Config().FastSync || configpkg.Config().PreloadAddress != "" || chain.GetBestViewHeight() > 1 {
+		return false
+	}
+	_, ok := fastSyncCheckpoint(chainID)
+	return ok
+}
+
+// isFastSyncing starts the fast sync of a chain in its own goroutine the first time it should fast sync,
+// and returns true until it is done so that the block sync of the chain is not started meanwhile
+func (synckerManager *SynckerManager) isFastSyncing(chainID int, chain Chain) bool {
+	synckerManager.fastSyncLock.Lock()
+	defer synckerManager.fastSyncLock.Unlock()
+	if running, ok := synckerManager.fastSyncing[chainID]; ok {
+		return running
+	}
+	if !shouldFastSync(chainID, chain) {
+		return false
+	}
+	synckerManager.fastSyncing[chainID] = true
+	go func() {
+		if err := synckerManager.fastSync(chainID); err != nil {
+			Logger.Infof("Fast sync chain %v fail! %v", chainID, err)
+		}
+		synckerManager.fastSyncLock.Lock()
+		synckerManager.fastSyncing[chainID] = false
+		synckerManager.fastSyncLock.Unlock()
+	}()
+	return true
+}
+
+// fastSync downloads the state snapshot of the trusted checkpoint of a chain from peers, verifies every
+// trie node against the roots of the snapshot, then restarts the chain from its view. Normal block sync
+// continues from the height of the view.
+func (synckerManager *SynckerManager) fastSync(chainID int) error {
+	checkpoint, ok := fastSyncCheckpoint(chainID)
+	if !ok {
+		return errors.Errorf("no trusted checkpoint for chain %v", chainID)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fastSyncTimeout)
+	defer cancel()
+
+	var snapshot *blockchain.StateSnapshot
+	var err error
+	for i := 0; i < fastSyncSnapshotRetry; i++ {
+		snapshot, err = synckerManager.config.Network.RequestStateSnapshot(ctx, chainID, checkpoint)
+		if err == nil {
+			err = blockchain.VerifyStateSnapshot(snapshot, checkpoint)
+		}
+		if err == nil {
+			break
+		}
+		Logger.Infof("Fast sync chain %v: request snapshot error %v", chainID, err)
+		time.Sleep(fastSyncSnapshotPeriod)
+	}
+	if err != nil {
+		return err
+	}
+	roots, err := snapshot.StateRoots()
+	if err != nil {
+		return err
+	}
+	for _, root := range roots {
+		var db incdb.Database
+		if root.ChainID == common.BeaconChainID {
+			db = synckerManager.config.Blockchain.GetBeaconChainDatabase()
+		} else {
+			db = synckerManager.config.Blockchain.GetShardChainDatabase(byte(root.ChainID))
+		}
+		if err := statesync.SyncTrie(ctx, root.ChainID, root.Root, db, synckerManager.config.Network.RequestStateNodes); err != nil {
+			return errors.Wrapf(err, "sync state root %v", root.Root.String())
+		}
+	}
+	return synckerManager.config.Blockchain.ApplyStateSnapshot(snapshot, checkpoint)
+}
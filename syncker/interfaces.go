@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/incognitochain/incognito-chain/multiview"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/wire"
 
@@ -22,6 +23,8 @@ type Network interface {
 	RequestBeaconBlocksByHashViaStream(ctx context.Context, peerID string, hashes [][]byte) (blockCh chan types.BlockInterface, err error)
 	RequestShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (blockCh chan types.BlockInterface, err error)
 	PublishMessageToShard(msg wire.Message, shardID byte) error
+	RequestStateSnapshot(ctx context.Context, chainID int, checkpoint common.Hash) (*blockchain.StateSnapshot, error)
+	RequestStateNodes(ctx context.Context, chainID int, hashes []common.Hash) ([][]byte, error)
 }
 
 type BeaconChainInterface interface {
@@ -2,17 +2,31 @@ package syncker
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	configpkg "github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/incdb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
+const (
+	preloadFolder       = "./data/preload"
+	preloadRetry        = 5
+	preloadRetryPeriod  = 10 * time.Second
+	preloadBlockTimeout = time.Minute
+)
+
 //JsonRequest ...
 type JsonRequest struct {
 	Jsonrpc string      `json:"Jsonrpc"`
@@ -38,32 +52,6 @@ type JsonResponse struct {
 	Jsonrpc string          `json:"Jsonrpc"`
 }
 
-func makeRPCDownloadRequest(address string, method string, w io.Writer, params ...interface{}) error {
-	request := JsonRequest{
-		Jsonrpc: "1.0",
-		Method:  method,
-		Params:  params,
-		Id:      "1",
-	}
-	requestBytes, err := json.Marshal(&request)
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(requestBytes))
-	resp, err := http.Post(address, "application/json", bytes.NewBuffer(requestBytes))
-	if err != nil {
-		fmt.Println(err)
-		return err
-	}
-
-	n, err := io.Copy(w, resp.Body)
-	fmt.Println(n, err)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func makeRPCRequest(address string, method string, params ...interface{}) (*JsonResponse, error) {
 	request := JsonRequest{
 		Jsonrpc: "1.0",
@@ -94,69 +82,177 @@ func makeRPCRequest(address string, method string, params ...interface{}) (*Json
 }
 
 //preloadDatabase call to backuped database node ...
-func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Database, btcChain *btcrelaying.BlockChain) error {
-	chainName := "beacon"
-	if chainID > -1 {
-		chainName = fmt.Sprintf("shard%v", chainID)
-	}
-	response, err := makeRPCRequest(url, "getlatestbackup", chainName)
+// The backup is trusted only if its manifest is signed by the configured preload manifest key, the block of
+// the manifest is found at peers, committee signed, and stored in the restored database with the state roots
+// of the manifest. A shard block must be signed by its committee in the beacon chain of the node.
+func (synckerManager *SynckerManager) preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Database, btcChain *btcrelaying.BlockChain) error {
+	chainName := blockchain.BackupChainName(chainID)
+	response, err := makeRPCRequest(url, "getbackupmanifest", chainName)
 	if err != nil {
 		return err
 	}
-	type LatestEpochResult struct {
-		LatestEpoch int
+	if response.Error != nil {
+		return errors.New(response.Error.Message)
 	}
-	result := LatestEpochResult{}
-	err = json.Unmarshal(response.Result, &result)
+	manifest := &blockchain.BackupManifest{}
+	err = json.Unmarshal(response.Result, manifest)
 	if err != nil {
 		return err
 	}
+	if err := manifest.ValidateSanity(chainID); err != nil {
+		return err
+	}
+	if configpkg.Config().PreloadManifestPubKey == "" {
+		return errors.New("no trusted key for the backup manifests, set preload_manifest_pubkey")
+	}
+	if err := manifest.VerifySig(configpkg.Config().PreloadManifestPubKey); err != nil {
+		return err
+	}
 
-	if currentEpoch < result.LatestEpoch-2 {
-		backupFile := "./data/preload/" + chainName
+	if uint64(currentEpoch)+2 >= manifest.Epoch {
+		return nil
+	}
 
-		fd, err := os.OpenFile(backupFile, os.O_CREATE|os.O_WRONLY, 0666)
-		if err != nil {
+	block, err := synckerManager.requestPreloadBlock(chainID, manifest.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	fileNames := []string{chainName}
+	if chainID == common.BeaconChainID {
+		fileNames = append(fileNames, "btc")
+	}
+	if err := os.MkdirAll(preloadFolder, 0700); err != nil {
+		return err
+	}
+	for _, name := range fileNames {
+		if err := downloadBackupFile(url, manifest, name); err != nil {
 			return err
 		}
-		fd.Truncate(0)
-		err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName)
+	}
+	Logger.Infof("Download %v backup of epoch %v finish", chainName, manifest.Epoch)
+
+	db.Close()
+	defer db.ReOpen()
+
+	//restore beacon|shard
+	err = db.PreloadBackup(filepath.Join(preloadFolder, chainName), func(restored incdb.Database) error {
+		return synckerManager.config.Blockchain.VerifyBackupDatabase(manifest, block, restored)
+	})
+	if err != nil {
+		return err
+	}
+
+	//restore btc if we restore beacon
+	if chainID == common.BeaconChainID {
+		err = btcChain.RestoreDBFromBackup(filepath.Join(preloadFolder, "btc"))
 		if err != nil {
-			return err
+			panic(err)
 		}
-		fd.Close()
+	}
+	for _, name := range fileNames {
+		os.Remove(filepath.Join(preloadFolder, name))
+		os.Remove(filepath.Join(preloadFolder, name+".manifest"))
+	}
+	return nil
+}
 
-		if chainName == "beacon" {
-			fd, err = os.OpenFile("./data/preload/btc", os.O_CREATE|os.O_WRONLY, 0666)
-			if err != nil {
-				return err
-			}
-			fd.Truncate(0)
-			err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName, "btc")
-			if err != nil {
-				return err
+// requestPreloadBlock fetches the block of a backup manifest from peers, a shard block is only accepted
+// once it is verified against the beacon chain of the node
+func (synckerManager *SynckerManager) requestPreloadBlock(chainID int, hash common.Hash) (types.BlockInterface, error) {
+	for i := 0; i < preloadRetry; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), preloadBlockTimeout)
+		var ch chan types.BlockInterface
+		var err error
+		if chainID == common.BeaconChainID {
+			ch, err = synckerManager.config.Network.RequestBeaconBlocksByHashViaStream(ctx, "", [][]byte{hash.Bytes()})
+		} else {
+			ch, err = synckerManager.config.Network.RequestShardBlocksByHashViaStream(ctx, "", chainID, [][]byte{hash.Bytes()})
+		}
+		if err == nil {
+			select {
+			case blk := <-ch:
+				if !isNil(blk) && *blk.Hash() == hash {
+					if chainID != common.BeaconChainID {
+						err = synckerManager.config.Blockchain.VerifyPreloadShardBlock(blk)
+					}
+					if err == nil {
+						cancel()
+						return blk, nil
+					}
+				}
+			case <-ctx.Done():
 			}
-			fd.Close()
 		}
+		cancel()
+		Logger.Infof("Preload chain %v: block %v not found at peers, err %v", chainID, hash.String(), err)
+		time.Sleep(preloadRetryPeriod)
+	}
+	return nil, fmt.Errorf("block %v of backup manifest is not found at peers", hash.String())
+}
 
-		fmt.Println("Download finish", chainName)
-
-		db.Close()
-		defer db.ReOpen()
+// downloadBackupFile downloads a backup file chunk by chunk into the preload folder.
+// Chunks already downloaded for the same manifest are kept, so an interrupted download resumes.
+func downloadBackupFile(url string, manifest *blockchain.BackupManifest, name string) error {
+	file, err := manifest.GetFile(name)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(preloadFolder, name)
+	manifestHash := manifest.Hash().String()
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	if downloading, err := ioutil.ReadFile(path + ".manifest"); err != nil || string(downloading) != manifestHash {
+		if err := fd.Truncate(0); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path+".manifest", []byte(manifestHash), 0600); err != nil {
+			return err
+		}
+	}
 
-		//restore beacon|shard
-		err = db.PreloadBackup(backupFile)
+	buf := make([]byte, manifest.ChunkSize)
+	for index := range file.ChunkHashes {
+		offset := int64(index) * manifest.ChunkSize
+		size := manifest.ChunkSize
+		if offset+size > file.Size {
+			size = file.Size - offset
+		}
+		if n, _ := fd.ReadAt(buf[:size], offset); int64(n) == size && file.VerifyChunk(index, buf[:size]) == nil {
+			continue
+		}
+		chunk, err := downloadBackupChunk(url, manifest.Epoch, file, index)
 		if err != nil {
 			return err
 		}
+		if _, err := fd.WriteAt(chunk, offset); err != nil {
+			return err
+		}
+	}
+	return fd.Truncate(file.Size)
+}
 
-		//restore btc if we restore beacon
-		if chainName == "beacon" {
-			err = btcChain.RestoreDBFromBackup("./data/preload/btc")
-			if err != nil {
-				panic(err)
+func downloadBackupChunk(url string, epoch uint64, file *blockchain.BackupFile, index int) ([]byte, error) {
+	var err error
+	for i := 0; i < preloadRetry; i++ {
+		var response *JsonResponse
+		response, err = makeRPCRequest(url, "downloadbackupchunk", epoch, file.Name, index)
+		if err == nil && response.Error != nil {
+			err = errors.New(response.Error.Message)
+		}
+		if err == nil {
+			chunk := []byte{}
+			if err = json.Unmarshal(response.Result, &chunk); err == nil {
+				if err = file.VerifyChunk(index, chunk); err == nil {
+					return chunk, nil
+				}
 			}
 		}
+		Logger.Infof("Download chunk %v of backup %v fail, err %v", index, file.Name, err)
+		time.Sleep(preloadRetryPeriod)
 	}
-	return nil
+	return nil, err
 }
@@ -237,7 +237,7 @@ func (s *ShardSyncProcess) trySendFinishSyncMessage() {
 	finishedSyncValidators := []string{}
 	finishedSyncSignatures := [][]byte{}
 	for i, v := range validatorFromUserKeys {
-		signature, err := v.MiningKey.BriSignData([]byte(wire.CmdMsgFinishSync))
+		signature, err := v.Signer.BriSignData([]byte(wire.CmdMsgFinishSync))
 		if err != nil {
 			continue
 		}
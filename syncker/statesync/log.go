@@ -0,0 +1,14 @@
+package statesync
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type StateSyncLogger struct {
+	common.Logger
+}
+
+func (self *StateSyncLogger) Init(inst common.Logger) {
+	self.Logger = inst
+}
+
+// Global instant to use
+var Logger = StateSyncLogger{}
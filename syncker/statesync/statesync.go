@@ -0,0 +1,95 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+const (
+	MaxNodesPerRequest = 384
+	MaxFailedRequests  = 10
+	syncBloomSize      = 64 // MB
+)
+
+// NodeFetcher requests trie nodes of a chain by hash from the network,
+// result[i] is the node of hashes[i] and is empty if the peer does not have it
+type NodeFetcher func(ctx context.Context, chainID int, hashes []common.Hash) ([][]byte, error)
+
+// SyncTrie downloads every node of the trie rooted at root which is missing in db.
+// Each node is checked against the hash it was requested by before being scheduled,
+// so the resulting trie is exactly the one committed by root.
+func SyncTrie(ctx context.Context, chainID int, root common.Hash, db incdb.Database, fetch NodeFetcher) error {
+	bloom := trie.NewSyncBloom(syncBloomSize, db)
+	defer bloom.Close()
+	sched := trie.NewSync(root, db, nil, bloom)
+
+	queue := []common.Hash{}
+	failed := 0
+	total := 0
+	for sched.Pending() > 0 {
+		if len(queue) < MaxNodesPerRequest {
+			queue = append(queue, sched.Missing(MaxNodesPerRequest-len(queue))...)
+		}
+		if len(queue) == 0 {
+			return fmt.Errorf("trie %v of chain %v: %v nodes pending but none missing", root.String(), chainID, sched.Pending())
+		}
+		if failed >= MaxFailedRequests {
+			return fmt.Errorf("trie %v of chain %v: too many failed requests", root.String(), chainID)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		data, err := fetch(ctx, chainID, queue)
+		if err != nil {
+			Logger.Errorf("[statesync] Request %v nodes of chain %v error %v", len(queue), chainID, err)
+			failed++
+			continue
+		}
+		results, missing := verifyNodes(queue, data)
+		if len(results) == 0 {
+			failed++
+			continue
+		}
+		failed = 0
+		if _, index, err := sched.Process(results); err != nil {
+			return fmt.Errorf("trie %v of chain %v: process node %v error %v", root.String(), chainID, results[index].Hash.String(), err)
+		}
+		batch := db.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		total += len(results)
+		queue = missing
+	}
+	Logger.Infof("[statesync] Synced trie %v of chain %v, downloaded %v nodes", root.String(), chainID, total)
+	return nil
+}
+
+// verifyNodes splits the response into nodes matching their requested hash and hashes to request again
+func verifyNodes(hashes []common.Hash, data [][]byte) ([]trie.SyncResult, []common.Hash) {
+	results := []trie.SyncResult{}
+	missing := []common.Hash{}
+	for i, hash := range hashes {
+		if i >= len(data) || len(data[i]) == 0 {
+			missing = append(missing, hash)
+			continue
+		}
+		if common.Keccak256Hash(data[i]) != hash {
+			Logger.Warnf("[statesync] Receive invalid data for node %v", hash.String())
+			missing = append(missing, hash)
+			continue
+		}
+		results = append(results, trie.SyncResult{Hash: hash, Data: data[i]})
+	}
+	return results, missing
+}
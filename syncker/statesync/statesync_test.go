@@ -0,0 +1,133 @@
+package statesync
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func newTestDB(t *testing.T) incdb.Database {
+	dir, err := ioutil.TempDir("", "statesync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll(dir)
+	})
+	return db
+}
+
+// newSourceState commits a state with n bridge requests and returns its root
+func newSourceState(t *testing.T, db incdb.Database, n int) (common.Hash, []common.Hash) {
+	sDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	txReqIDs := []common.Hash{}
+	for i := 0; i < n; i++ {
+		txReqID := common.HashH([]byte{byte(i), byte(i >> 8)})
+		if err := statedb.TrackBridgeReqWithStatus(sDB, txReqID, common.BridgeRequestAcceptedStatus); err != nil {
+			t.Fatal(err)
+		}
+		txReqIDs = append(txReqIDs, txReqID)
+	}
+	root, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sDB.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root, txReqIDs
+}
+
+func newFetcher(source incdb.Database, corrupt func(i int, data []byte) []byte) NodeFetcher {
+	calls := 0
+	return func(ctx context.Context, chainID int, hashes []common.Hash) ([][]byte, error) {
+		calls++
+		res := make([][]byte, len(hashes))
+		for i, hash := range hashes {
+			data, err := source.Get(hash[:])
+			if err != nil {
+				continue
+			}
+			if corrupt != nil {
+				data = corrupt(calls, common.CopyBytes(data))
+			}
+			res[i] = data
+		}
+		return res, nil
+	}
+}
+
+func TestSyncTrie(t *testing.T) {
+	source := newTestDB(t)
+	root, txReqIDs := newSourceState(t, source, 500)
+
+	target := newTestDB(t)
+	err := SyncTrie(context.Background(), 0, root, target, newFetcher(source, nil))
+	assert.Nil(t, err)
+
+	sDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(target))
+	assert.Nil(t, err)
+	for _, txReqID := range txReqIDs {
+		status, err := statedb.GetBridgeReqWithStatus(sDB, txReqID)
+		assert.Nil(t, err)
+		assert.Equal(t, byte(common.BridgeRequestAcceptedStatus), status)
+	}
+
+	// syncing again is a no-op
+	err = SyncTrie(context.Background(), 0, root, target, func(ctx context.Context, chainID int, hashes []common.Hash) ([][]byte, error) {
+		t.Fatal("unexpected request")
+		return nil, nil
+	})
+	assert.Nil(t, err)
+}
+
+func TestSyncTrie_InvalidNodes(t *testing.T) {
+	source := newTestDB(t)
+	root, txReqIDs := newSourceState(t, source, 200)
+
+	// a peer answering garbage on every other request is tolerated
+	target := newTestDB(t)
+	err := SyncTrie(context.Background(), 0, root, target, newFetcher(source, func(call int, data []byte) []byte {
+		if call%2 == 1 {
+			data[len(data)-1] ^= 0xff
+		}
+		return data
+	}))
+	assert.Nil(t, err)
+	sDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(target))
+	assert.Nil(t, err)
+	status, err := statedb.GetBridgeReqWithStatus(sDB, txReqIDs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, byte(common.BridgeRequestAcceptedStatus), status)
+
+	// a peer which never answers valid data can not make it store anything
+	target = newTestDB(t)
+	err = SyncTrie(context.Background(), 0, root, target, newFetcher(source, func(call int, data []byte) []byte {
+		data[0] ^= 0xff
+		return data
+	}))
+	assert.NotNil(t, err)
+	has, _ := target.Has(root[:])
+	assert.False(t, has)
+}
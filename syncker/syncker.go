@@ -44,6 +44,8 @@ type SynckerManager struct {
 	beaconPool            *BlkPool
 	shardPool             map[int]*BlkPool
 	crossShardPool        map[int]*BlkPool
+	fastSyncLock          sync.Mutex
+	fastSyncing           map[int]bool // chains whose fast sync was started, true until it is done
 }
 
 func NewSynckerManager() *SynckerManager {
@@ -52,6 +54,7 @@ func NewSynckerManager() *SynckerManager {
 		shardPool:             make(map[int]*BlkPool),
 		CrossShardSyncProcess: make(map[int]*CrossShardSyncProcess),
 		crossShardPool:        make(map[int]*BlkPool),
+		fastSyncing:           make(map[int]bool),
 	}
 	return s
 }
@@ -62,7 +65,7 @@ func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
 	//check preload beacon
 	preloadAddr := configpkg.Config().PreloadAddress
 	if preloadAddr != "" {
-		if err := preloadDatabase(-1, int(config.Blockchain.BeaconChain.GetEpoch()), preloadAddr, config.Blockchain.GetBeaconChainDatabase(), config.Blockchain.GetBTCHeaderChain()); err != nil {
+		if err := synckerManager.preloadDatabase(-1, int(config.Blockchain.BeaconChain.GetEpoch()), preloadAddr, config.Blockchain.GetBeaconChainDatabase(), config.Blockchain.GetBTCHeaderChain()); err != nil {
 			fmt.Println(err)
 			Logger.Infof("Preload beacon fail!")
 		} else {
@@ -128,7 +131,9 @@ func (synckerManager *SynckerManager) manageSyncProcess() {
 	}
 
 	preloadAddr := configpkg.Config().PreloadAddress
-	synckerManager.BeaconSyncProcess.start()
+	if synckerManager.BeaconSyncProcess.status == RUNNING_SYNC || !synckerManager.isFastSyncing(common.BeaconChainID, synckerManager.Blockchain.BeaconChain) {
+		synckerManager.BeaconSyncProcess.start()
+	}
 
 	if time.Now().Unix()-synckerManager.Blockchain.GetBeaconBestState().BestBlock.GetProduceTime() > 4*60*60 {
 		lastInsertTime := synckerManager.BeaconSyncProcess.lastInsert
@@ -165,7 +170,7 @@ func (synckerManager *SynckerManager) manageSyncProcess() {
 				//check preload shard
 				if preloadAddr != "" {
 					if syncProc.status != RUNNING_SYNC { //run only when start
-						if err := preloadDatabase(sid, int(syncProc.Chain.GetEpoch()), preloadAddr, synckerManager.config.Blockchain.GetShardChainDatabase(byte(sid)), nil); err != nil {
+						if err := synckerManager.preloadDatabase(sid, int(syncProc.Chain.GetEpoch()), preloadAddr, synckerManager.config.Blockchain.GetShardChainDatabase(byte(sid)), nil); err != nil {
 							fmt.Println(err)
 							Logger.Infof("Preload shard %v fail!", sid)
 						} else {
@@ -173,7 +178,9 @@ func (synckerManager *SynckerManager) manageSyncProcess() {
 						}
 					}
 				}
-				syncProc.start()
+				if syncProc.status == RUNNING_SYNC || !synckerManager.isFastSyncing(sid, syncProc.Chain) {
+					syncProc.start()
+				}
 			} else {
 				syncProc.stop()
 			}
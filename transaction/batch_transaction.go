@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy/privacy_v1/zeroknowledge/aggregatedrange"
 	"github.com/incognitochain/incognito-chain/privacy/privacy_v2/bulletproofs"
+	"github.com/incognitochain/incognito-chain/privacy/privacy_v2/mlsag"
 	"github.com/incognitochain/incognito-chain/transaction/utils"
 )
 
@@ -18,14 +19,20 @@ type batchTransaction struct {
 	txs []metadata.Transaction
 }
 
+// mlsagBatchable is implemented by transactions whose ring signatures can be moved out of ValidateTransaction into an MLSAG batch.
+type mlsagBatchable interface {
+	AddSigToBatch(batch *mlsag.BatchVerifier, transactionStateDB *statedb.StateDB) error
+}
+
 // NewBatchTransaction creates a batchTransaction object from the given TX array.
 // Batched transactions save verification time by batching many Bulletproof verifications together in one multi-exponent operation.
 //
 // One can then call ".Validate(" to validate all TXs in this batch. This does not cover sanity checks & double-spend checks, those are handled separately.
 // The batch can have transactions from both versions.
 //
-// Outside of Bulletproofs, other verification steps are done normally.
-// Batching is applicable to PRV transfers, not pToken transfers.
+// Batching is applicable to PRV & pToken transfers: Bulletproofs of confidential asset proofs are batched against their own asset tag bases,
+// and MLSAG signatures of ver2 transactions are collected & verified together.
+// Outside of these, other verification steps are done normally.
 func NewBatchTransaction(txs []metadata.Transaction) *batchTransaction {
 	return &batchTransaction{txs: txs}
 }
@@ -35,6 +42,10 @@ func (b *batchTransaction) AddTxs(txs []metadata.Transaction) {
 	b.txs = append(b.txs, txs...)
 }
 
+// Validate verifies all TXs in this batch. It returns the index of the first invalid TX (or -1).
+//
+// When a batched check fails, only the range proofs of the TXs are verified again one by one to find the offending one
+// (MLSAG signatures are reported by index already), so the result is always the same as verifying each TX separately.
 func (b *batchTransaction) Validate(transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, boolParams map[string]bool) (bool, error, int) {
 	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB, boolParams)
 }
@@ -45,73 +56,180 @@ func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transactio
 	if err != nil {
 		return false, err, -1
 	}
-	var bulletProofListVer1 []*privacy.AggregatedRangeProofV1
-	var bulletProofListVer2 []*privacy.AggregatedRangeProofV2
+	isNewZKP, ok := boolParams["isNewZKP"]
+	if !ok {
+		isNewZKP = true
+	}
+	rangeProofs := &rangeProofBatch{}
+	var txRangeProofs [][]privacy.Proof
+	mlsagBatch := mlsag.NewBatchVerifier()
+	var mlsagTxIndexes []int
 
+	// the first TX failing the checks which are not batched, the batched ones of the TXs before it are still verified
+	failedIndex := -1
+	var failedErr error
+	params := copyBoolParams(boolParams)
+	params["isBatch"] = true
+	params["isBatchMlsag"] = true
 	for i, tx := range txList {
 		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
-		boolParams["hasPrivacy"] = tx.IsPrivacy()
+		params["hasPrivacy"] = tx.IsPrivacy()
 
-		ok, batchableProofs, err := tx.ValidateTransaction(boolParams, transactionStateDB, bridgeStateDB, shardID, prvCoinID)
+		ok, batchableProofs, err := validateTxWithoutBatchedProofs(tx, params, transactionStateDB, bridgeStateDB, shardID, prvCoinID)
 		if !ok {
-			return false, err, i
+			failedIndex, failedErr = i, err
+			break
 		}
-		if tx.GetMetadata() != nil {
-			// if hasPrivacy {
-			// 	return false, errors.New("Metadata can not exist in privacy tx"), i
-			// }
-			validateMetadata := tx.GetMetadata().ValidateMetadataByItself()
-			if !validateMetadata {
-				return validateMetadata, utils.NewTransactionErr(utils.UnexpectedError, errors.New("Metadata is invalid")), i
+		if batchableTx, ok := tx.(mlsagBatchable); ok {
+			if err := batchableTx.AddSigToBatch(mlsagBatch, transactionStateDB); err != nil {
+				failedIndex, failedErr = i, err
+				break
+			}
+			for len(mlsagTxIndexes) < mlsagBatch.Len() {
+				mlsagTxIndexes = append(mlsagTxIndexes, i)
 			}
 		}
+		if err := rangeProofs.add(batchableProofs, i); err != nil {
+			failedIndex, failedErr = i, err
+			break
+		}
+		txRangeProofs = append(txRangeProofs, batchableProofs)
+	}
 
-		for _, batchableProof := range batchableProofs {
-			bulletproof := batchableProof.GetAggregatedRangeProof()
-			if bulletproof == nil {
-				return false, utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("Privacy TX Proof missing at index %d", i)), -1
-			}
-			switch proof_specific := bulletproof.(type) {
-			case *privacy.AggregatedRangeProofV1:
-				bulletProofListVer1 = append(bulletProofListVer1, proof_specific)
-			case *privacy.AggregatedRangeProofV2:
-				bulletProofListVer2 = append(bulletProofListVer2, proof_specific)
+	if ok, err := rangeProofs.verify(isNewZKP); !ok {
+		Logger.Log.Warnf("Batch verification of the range proofs of %d txs failed: %v, verifying them one by one", len(txRangeProofs), err)
+		found := false
+		for i, proofs := range txRangeProofs {
+			txProofs := &rangeProofBatch{}
+			txProofs.add(proofs, i) // cannot fail, the proofs were added to the batch already
+			if ok, err := txProofs.verify(isNewZKP); !ok {
+				failedIndex, failedErr = i, err
+				found = true
+				break
 			}
 		}
+		if !found && failedIndex == -1 {
+			return false, err, -1
+		}
 	}
-	isNewZKP, ok := boolParams["isNewZKP"]
-	if !ok {
-		isNewZKP = true
+
+	if ok, err, i := mlsagBatch.Verify(); !ok {
+		txIndex := mlsagTxIndexes[i]
+		Logger.Log.Errorf("FAILED VERIFICATION BATCH MLSAG SIGNATURE of tx %d", txIndex)
+		if failedIndex == -1 || txIndex < failedIndex {
+			failedIndex, failedErr = txIndex, utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
+		}
 	}
+	if failedIndex != -1 {
+		if failedErr == nil {
+			failedErr = utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("tx %s is invalid", txList[failedIndex].Hash().String()))
+		}
+		return false, failedErr, failedIndex
+	}
+	Logger.Log.Debugf("Batch verified %d txs: %d ver1 & %d ver2 (%d CA) range proofs, %d MLSAG signatures", len(txList), len(rangeProofs.ver1), len(rangeProofs.ver2)+len(rangeProofs.ca), len(rangeProofs.ca), mlsagBatch.Len())
+	return true, nil, -1
+}
+
+// rangeProofBatch collects the range proofs of TXs to verify them in one multi-exponent operation per kind
+type rangeProofBatch struct {
+	ver1    []*privacy.AggregatedRangeProofV1
+	ver2    []*privacy.AggregatedRangeProofV2
+	ca      []*privacy.AggregatedRangeProofV2
+	basesCA []*privacy.Point
+}
 
+// add queues the range proofs of the batchable proofs of the TX at index i
+func (batch *rangeProofBatch) add(batchableProofs []privacy.Proof, i int) error {
+	for _, batchableProof := range batchableProofs {
+		bulletproof := batchableProof.GetAggregatedRangeProof()
+		if bulletproof == nil {
+			return utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("Privacy TX Proof missing at index %d", i))
+		}
+		switch proof_specific := bulletproof.(type) {
+		case *privacy.AggregatedRangeProofV1:
+			batch.ver1 = append(batch.ver1, proof_specific)
+		case *privacy.AggregatedRangeProofV2:
+			proofAsV2, ok := batchableProof.(*privacy.ProofV2)
+			if !ok {
+				return utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("Privacy TX Proof at index %d is not of version 2", i))
+			}
+			isConfAsset, err := proofAsV2.IsConfidentialAsset()
+			if err != nil {
+				return utils.NewTransactionErr(utils.TxProofVerifyFailError, err)
+			}
+			if !isConfAsset {
+				batch.ver2 = append(batch.ver2, proof_specific)
+				continue
+			}
+			base, err := proofAsV2.GetRangeProofBase()
+			if err != nil {
+				return utils.NewTransactionErr(utils.TxProofVerifyFailError, err)
+			}
+			batch.ca = append(batch.ca, proof_specific)
+			batch.basesCA = append(batch.basesCA, base)
+		}
+	}
+	return nil
+}
+
+func (batch *rangeProofBatch) verify(isNewZKP bool) (bool, error) {
 	if isNewZKP {
-		ok, err, index := aggregatedrange.VerifyBatch(bulletProofListVer1)
+		ok, err, index := aggregatedrange.VerifyBatch(batch.ver1)
 		if err != nil {
-			return false, NewTransactionErr(TxProofVerifyFailError, err), -1
+			return false, NewTransactionErr(TxProofVerifyFailError, err)
 		}
 		if !ok {
 			Logger.Log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 1 %d", index)
-			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", index)), index
+			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", index))
 		}
 	} else {
-		ok, err, index := aggregatedrange.VerifyBatchOld(bulletProofListVer1)
+		ok, err, index := aggregatedrange.VerifyBatchOld(batch.ver1)
 		if err != nil {
-			return false, NewTransactionErr(TxProofVerifyFailError, err), -1
+			return false, NewTransactionErr(TxProofVerifyFailError, err)
 		}
 		if !ok {
 			Logger.Log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 1 OLD %d", index)
-			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 1 OLD %d", index)), index
+			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 1 OLD %d", index))
 		}
 	}
 
-	ok, err, i := bulletproofs.VerifyBatch(bulletProofListVer2)
+	ok, err, i := bulletproofs.VerifyBatch(batch.ver2)
 	if err != nil {
-		return false, utils.NewTransactionErr(utils.TxProofVerifyFailError, err), -1
+		return false, utils.NewTransactionErr(utils.TxProofVerifyFailError, err)
 	}
 	if !ok {
 		Logger.Log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 2 %d", i)
-		return false, utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH VER 2 PAYMENT PROOF %d", i)), -1
+		return false, utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH VER 2 PAYMENT PROOF %d", i))
 	}
-	fmt.Println("[BUGLOG] Number of tx in batch", len(bulletProofListVer1), len(bulletProofListVer2))
-	return true, nil, -1
+
+	ok, err, i = bulletproofs.VerifyBatchUsingBase(batch.ca, batch.basesCA)
+	if !ok {
+		Logger.Log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 2 CA %d", i)
+		return false, utils.NewTransactionErr(utils.TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH VER 2 CA PAYMENT PROOF %d: %v", i, err))
+	}
+	return true, nil
+}
+
+// validateTxWithoutBatchedProofs runs ValidateTransaction & the metadata check of a TX.
+// Depending on boolParams, range proofs & MLSAG signatures are left for the caller to verify.
+func validateTxWithoutBatchedProofs(tx metadata.Transaction, boolParams map[string]bool, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash) (bool, []privacy.Proof, error) {
+	ok, batchableProofs, err := tx.ValidateTransaction(boolParams, transactionStateDB, bridgeStateDB, shardID, tokenID)
+	if !ok {
+		return false, nil, err
+	}
+	if tx.GetMetadata() != nil {
+		validateMetadata := tx.GetMetadata().ValidateMetadataByItself()
+		if !validateMetadata {
+			return validateMetadata, nil, utils.NewTransactionErr(utils.UnexpectedError, errors.New("Metadata is invalid"))
+		}
+	}
+	return true, batchableProofs, nil
+}
+
+func copyBoolParams(boolParams map[string]bool) map[string]bool {
+	result := make(map[string]bool, len(boolParams))
+	for k, v := range boolParams {
+		result[k] = v
+	}
+	return result
 }
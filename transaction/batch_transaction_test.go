@@ -0,0 +1,58 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/coin"
+	"github.com/incognitochain/incognito-chain/privacy/operation"
+	"github.com/incognitochain/incognito-chain/privacy/privacy_v2/bulletproofs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRangeProofV2(t *testing.T, value uint64) *privacy.ProofV2 {
+	wit := new(bulletproofs.AggregatedRangeWitness)
+	wit.Set([]uint64{value}, []*operation.Scalar{operation.RandomScalar()})
+	bulletproof, err := wit.Prove()
+	assert.Nil(t, err)
+
+	proof := new(privacy.ProofV2)
+	proof.Init()
+	assert.Nil(t, proof.SetOutputCoinsV2([]*coin.CoinV2{new(coin.CoinV2).Init()}))
+	proof.SetAggregatedRangeProof(bulletproof)
+	return proof
+}
+
+func TestRangeProofBatchLocatesInvalidTx(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	bulletproofs.Logger.Init(common.NewBackend(nil).Logger("test", true))
+
+	var txRangeProofs [][]privacy.Proof
+	for i := 0; i < 3; i++ {
+		txRangeProofs = append(txRangeProofs, []privacy.Proof{newTestRangeProofV2(t, uint64(1000+i))})
+	}
+
+	batch := &rangeProofBatch{}
+	for i, proofs := range txRangeProofs {
+		assert.Nil(t, batch.add(proofs, i))
+	}
+	ok, err := batch.verify(true)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	// tamper with the value commitment of the second TX
+	tampered := txRangeProofs[1][0].GetAggregatedRangeProof().(*privacy.AggregatedRangeProofV2)
+	tampered.SetCommitments([]*operation.Point{operation.RandomPoint()})
+
+	ok, err = batch.verify(true)
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+
+	for i, proofs := range txRangeProofs {
+		txProofs := &rangeProofBatch{}
+		assert.Nil(t, txProofs.add(proofs, i))
+		ok, _ := txProofs.verify(true)
+		assert.Equal(t, i != 1, ok, "tx %d", i)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+// Benchmarks for block-level batch verification, comparing one-by-one and batched verification of
+// the pieces that batchTransaction.Validate batches: PRV range proofs, confidential asset range proofs and MLSAG signatures.
+//
+// Run with: go test -run=^$ -bench=. ./transaction/benchmark/
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy/operation"
+	"github.com/incognitochain/incognito-chain/privacy/privacy_v2/bulletproofs"
+	"github.com/incognitochain/incognito-chain/privacy/privacy_v2/mlsag"
+)
+
+var (
+	benchBlockSizes  = []int{1, 8, 32}
+	benchNumOutputs  = 2
+	benchRingSize    = 8
+	benchNumOfInputs = 2
+)
+
+func prepareRangeProofs(count int, withBase bool) ([]*bulletproofs.AggregatedRangeProof, []*operation.Point) {
+	proofs := make([]*bulletproofs.AggregatedRangeProof, count)
+	bases := make([]*operation.Point, count)
+	for k := 0; k < count; k++ {
+		values := make([]uint64, benchNumOutputs)
+		rands := make([]*operation.Scalar, benchNumOutputs)
+		for i := range values {
+			values[i] = rand.Uint64()
+			rands[i] = operation.RandomScalar()
+		}
+		wit := new(bulletproofs.AggregatedRangeWitness)
+		wit.Set(values, rands)
+		if !withBase {
+			proofs[k], _ = wit.Prove()
+			continue
+		}
+		bases[k] = operation.RandomPoint()
+		proofs[k], _ = wit.ProveUsingBase(bases[k])
+		cmsValue := make([]*operation.Point, len(values))
+		for i := range values {
+			cmsValue[i] = new(operation.Point).AddPedersen(new(operation.Scalar).FromUint64(values[i]), bases[k], rands[i], operation.PedCom.G[operation.PedersenRandomnessIndex])
+		}
+		proofs[k].SetCommitments(cmsValue)
+	}
+	return proofs, bases
+}
+
+func prepareMlsagSigs(count int) ([]*mlsag.Sig, []*mlsag.Ring, [][]byte) {
+	sigs := make([]*mlsag.Sig, count)
+	rings := make([]*mlsag.Ring, count)
+	messages := make([][]byte, count)
+	for k := 0; k < count; k++ {
+		privateKeys := make([]*operation.Scalar, benchNumOfInputs)
+		for i := range privateKeys {
+			privateKeys[i] = operation.RandomScalar()
+		}
+		pi := common.RandInt() % benchRingSize
+		rings[k] = mlsag.NewRandomRing(privateKeys, benchRingSize, pi)
+		message := common.HashH(common.RandBytes(32))
+		messages[k] = message[:]
+		sigs[k], _ = mlsag.NewMlsag(privateKeys, rings[k], pi).Sign(messages[k])
+	}
+	return sigs, rings, messages
+}
+
+func BenchmarkRangeProofVerify(b *testing.B) {
+	for _, n := range benchBlockSizes {
+		proofs, _ := prepareRangeProofs(n, false)
+		b.Run(fmt.Sprintf("OneByOne/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, proof := range proofs {
+					if ok, _ := proof.Verify(); !ok {
+						b.Fatal("invalid range proof")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if ok, _, _ := bulletproofs.VerifyBatch(proofs); !ok {
+					b.Fatal("invalid range proof batch")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRangeProofVerifyUsingBase(b *testing.B) {
+	for _, n := range benchBlockSizes {
+		proofs, bases := prepareRangeProofs(n, true)
+		b.Run(fmt.Sprintf("OneByOne/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for k, proof := range proofs {
+					if ok, _ := proof.VerifyUsingBase(bases[k]); !ok {
+						b.Fatal("invalid range proof")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if ok, _, _ := bulletproofs.VerifyBatchUsingBase(proofs, bases); !ok {
+					b.Fatal("invalid range proof batch")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMlsagVerify(b *testing.B) {
+	for _, n := range benchBlockSizes {
+		sigs, rings, messages := prepareMlsagSigs(n)
+		b.Run(fmt.Sprintf("OneByOne/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for k := range sigs {
+					if ok, _ := mlsag.Verify(sigs[k], rings[k], messages[k]); !ok {
+						b.Fatal("invalid signature")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				batch := mlsag.NewBatchVerifier()
+				for k := range sigs {
+					batch.Add(sigs[k], rings[k], messages[k], false)
+				}
+				if ok, _, _ := batch.Verify(); !ok {
+					b.Fatal("invalid signature batch")
+				}
+			}
+		})
+	}
+}
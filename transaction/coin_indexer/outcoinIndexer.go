@@ -294,7 +294,7 @@ func (ci *CoinIndexer) ReIndexOutCoin(idxParams *IndexParam) {
 		nextHeight := height + utils.MaxOutcoinQueryInterval
 
 		// query token output coins
-		currentOutputCoinsToken, err := QueryDbCoinVer2(idxParams.OTAKey, &common.ConfidentialAssetID, height, nextHeight-1, idxParams.TxDb, getCoinFilterByOTAKey())
+		currentOutputCoinsToken, err := QueryDbCoinVer2(idxParams.OTAKey, &common.ConfidentialAssetID, height, nextHeight-1, idxParams.TxDb, GetCoinFilterByOTAKey())
 		if err != nil {
 			utils.Logger.Log.Errorf("[CoinIndexer] Error while querying token coins from db - %v\n", err)
 
@@ -304,7 +304,7 @@ func (ci *CoinIndexer) ReIndexOutCoin(idxParams *IndexParam) {
 		}
 
 		// query PRV output coins
-		currentOutputCoinsPRV, err := QueryDbCoinVer2(idxParams.OTAKey, &common.PRVCoinID, height, nextHeight-1, idxParams.TxDb, getCoinFilterByOTAKey())
+		currentOutputCoinsPRV, err := QueryDbCoinVer2(idxParams.OTAKey, &common.PRVCoinID, height, nextHeight-1, idxParams.TxDb, GetCoinFilterByOTAKey())
 		if err != nil {
 			utils.Logger.Log.Errorf("[CoinIndexer] Error while querying PRV coins from db - %v\n", err)
 
@@ -429,7 +429,7 @@ func (ci *CoinIndexer) ReIndexOutCoinBatch(idxParams []*IndexParam, txDb *stated
 		nextHeight := height + utils.MaxOutcoinQueryInterval
 
 		// query token output coins
-		currentOutputCoinsToken, err := QueryBatchDbCoinVer2(mapIdxParams, shardID, &common.ConfidentialAssetID, height, nextHeight-1, txDb, ci.cachedCoinPubKeys, getCoinFilterByOTAKey())
+		currentOutputCoinsToken, err := QueryBatchDbCoinVer2(mapIdxParams, shardID, &common.ConfidentialAssetID, height, nextHeight-1, txDb, ci.cachedCoinPubKeys, GetCoinFilterByOTAKey())
 		if err != nil {
 			utils.Logger.Log.Errorf("[CoinIndexer] Error while querying token coins from db - %v\n", err)
 
@@ -445,7 +445,7 @@ func (ci *CoinIndexer) ReIndexOutCoinBatch(idxParams []*IndexParam, txDb *stated
 		}
 
 		// query PRV output coins
-		currentOutputCoinsPRV, err := QueryBatchDbCoinVer2(mapIdxParams, shardID, &common.PRVCoinID, height, nextHeight-1, txDb, ci.cachedCoinPubKeys, getCoinFilterByOTAKey())
+		currentOutputCoinsPRV, err := QueryBatchDbCoinVer2(mapIdxParams, shardID, &common.PRVCoinID, height, nextHeight-1, txDb, ci.cachedCoinPubKeys, GetCoinFilterByOTAKey())
 		if err != nil {
 			utils.Logger.Log.Errorf("[CoinIndexer] Error while querying PRV coins from db - %v\n", err)
 
@@ -604,7 +604,7 @@ func (ci *CoinIndexer) ReIndexOutCoinBatchByIndices(idxParams []*IndexParam, txD
 		}
 
 		// query token output coins
-		currentOutputCoinsPRV, err := QueryBatchDbCoinVer2ByIndices(mapIdxParams, shardID, &common.PRVCoinID, idx, nextIdx-1, txDb, ci.cachedCoinPubKeys, getCoinFilterByOTAKey())
+		currentOutputCoinsPRV, err := QueryBatchDbCoinVer2ByIndices(mapIdxParams, shardID, &common.PRVCoinID, idx, nextIdx-1, txDb, ci.cachedCoinPubKeys, GetCoinFilterByOTAKey())
 		if err != nil {
 			utils.Logger.Log.Errorf("[CoinIndexer] Error while querying PRV coins from db - %v\n", err)
 
@@ -637,7 +637,7 @@ func (ci *CoinIndexer) ReIndexOutCoinBatchByIndices(idxParams []*IndexParam, txD
 		}
 
 		// query token output coins
-		currentOutputCoinsToken, err := QueryBatchDbCoinVer2ByIndices(mapIdxParams, shardID, &common.ConfidentialAssetID, idx, nextIdx-1, txDb, ci.cachedCoinPubKeys, getCoinFilterByOTAKey())
+		currentOutputCoinsToken, err := QueryBatchDbCoinVer2ByIndices(mapIdxParams, shardID, &common.ConfidentialAssetID, idx, nextIdx-1, txDb, ci.cachedCoinPubKeys, GetCoinFilterByOTAKey())
 		if err != nil {
 			utils.Logger.Log.Errorf("[CoinIndexer] Error while querying Token coins from db - %v\n", err)
 
@@ -43,8 +43,8 @@ type IndexParam struct {
 // CoinMatcher is an interface for matching a v2 coin and given parameter(s).
 type CoinMatcher func(*privacy.CoinV2, map[string]interface{}) bool
 
-// getCoinFilterByOTAKey returns a functions that filters if an output coin belongs to an OTAKey.
-func getCoinFilterByOTAKey() CoinMatcher {
+// GetCoinFilterByOTAKey returns a functions that filters if an output coin belongs to an OTAKey.
+func GetCoinFilterByOTAKey() CoinMatcher {
 	return func(c *privacy.CoinV2, kvargs map[string]interface{}) bool {
 		entry, exists := kvargs["otaKey"]
 		if !exists {
@@ -195,6 +195,30 @@ func QueryDbCoinVer2(otaKey privacy.OTAKey, tokenID *common.Hash, startHeight, d
 	return outCoins, nil
 }
 
+// MatchCoinsVer2 returns the v2 coins among the given coins that pass any of the given filters for an OTAKey and a tokenID.
+// It is used to match the output coins of a new block without querying the db.
+func MatchCoinsVer2(coins []privacy.Coin, otaKey privacy.OTAKey, tokenID *common.Hash, filters ...CoinMatcher) []*privacy.CoinV2 {
+	// create parameter(s) for CoinMatcher filters.
+	params := make(map[string]interface{})
+	params["otaKey"] = otaKey
+	params["tokenID"] = tokenID
+
+	var res []*privacy.CoinV2
+	for _, c := range coins {
+		cv2, ok := c.(*privacy.CoinV2)
+		if !ok {
+			continue
+		}
+		for _, f := range filters {
+			if f(cv2, params) {
+				res = append(res, cv2)
+				break
+			}
+		}
+	}
+	return res
+}
+
 // QueryBatchDbCoinVer2 queries the db to get v2 coins for `shardHeight` to `destHeight` and checks if the coins belong
 // to any of the given IndexParam's using the given filters.
 //
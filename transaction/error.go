@@ -70,6 +70,8 @@ const (
 	RejectTxType
 	RejectTxInfoSize
 	RejectTxMedataWithBlockChain
+	RejectTxExpiryHeight
+	RejectTxExpired
 
 	GetCommitmentsInDatabaseError
 	InvalidPaymentAddressError
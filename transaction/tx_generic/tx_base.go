@@ -35,6 +35,8 @@ type TxBase struct {
 	PubKeyLastByteSender byte
 	// Metadata, optional
 	Metadata metadata.Metadata
+	// Last shard height the tx can be included at, optional (ver 2 only)
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
 	// private field, not use for json parser, only use as temp variable
 	sigPrivKey       []byte       // is ALWAYS private property of struct, if privacy: 64 bytes, and otherwise, 32 bytes
 	cachedHash       *common.Hash // cached hash data of tx
@@ -54,6 +56,8 @@ type TxPrivacyInitParams struct {
 	MetaData    metadata.Metadata
 	Info        []byte // 512 bytes
 	Kvargs      map[string]interface{}
+	// ExpiryHeight is the last shard height the tx can be included at, 0 means no expiry
+	ExpiryHeight uint64
 }
 
 func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
@@ -163,6 +167,7 @@ func (tx *TxBase) InitializeTxAndParams(params *TxPrivacyInitParams) error {
 		tx.LockTime = time.Now().Unix()
 	}
 	tx.Fee = params.Fee
+	tx.ExpiryHeight = params.ExpiryHeight
 	tx.Type = common.TxNormalType
 	tx.Metadata = params.MetaData
 	tx.PubKeyLastByteSender = common.GetShardIDFromLastByte(senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1])
@@ -246,6 +251,10 @@ func (tx TxBase) GetLockTime() int64 { return tx.LockTime }
 
 func (tx *TxBase) SetLockTime(locktime int64) { tx.LockTime = locktime }
 
+func (tx TxBase) GetExpiryHeight() uint64 { return tx.ExpiryHeight }
+
+func (tx *TxBase) SetExpiryHeight(height uint64) { tx.ExpiryHeight = height }
+
 func (tx TxBase) GetSenderAddrLastByte() byte { return tx.PubKeyLastByteSender }
 
 func (tx *TxBase) SetGetSenderAddrLastByte(b byte) { tx.PubKeyLastByteSender = b }
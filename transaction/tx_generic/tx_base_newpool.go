@@ -125,6 +125,10 @@ func (tx *TxBase) ValidateSanityDataByItSelf() (bool, error) {
 	if int64(tx.LockTime) > time.Now().Unix() {
 		return false, utils.NewTransactionErr(utils.RejectInvalidLockTime, fmt.Errorf("wrong tx locktime %d", tx.LockTime))
 	}
+	// only ver 2 hashes the expiry height
+	if tx.ExpiryHeight != 0 && tx.Version != utils.TxVersion2Number {
+		return false, utils.NewTransactionErr(utils.RejectTxExpiryHeight, fmt.Errorf("tx version %d cannot have an expiry height", tx.Version))
+	}
 
 	proof := tx.GetProof()
 	if proof != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/privacy/operation"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
@@ -152,6 +153,10 @@ func ValidateSanity(tx metadata.Transaction, chainRetriever metadata.ChainRetrie
 	if tx.GetLockTime() > time.Now().Unix() {
 		return false, utils.NewTransactionErr(utils.RejectInvalidLockTime, fmt.Errorf("wrong tx locktime %d", tx.GetLockTime()))
 	}
+	// only ver 2 hashes the expiry height
+	if tx.GetExpiryHeight() != 0 && tx.GetVersion() != utils.TxVersion2Number {
+		return false, utils.NewTransactionErr(utils.RejectTxExpiryHeight, fmt.Errorf("tx version %d cannot have an expiry height", tx.GetVersion()))
+	}
 
 	// check tx size
 	actualTxSize := tx.GetTxActualSize()
@@ -252,3 +257,28 @@ func GetTxActualSizeInBytes(tx metadata.Transaction) uint64 {
 
 	return sizeTx
 }
+
+// ValidateExpiryHeight checks that the expiry height of tx is enabled at beaconHeight and that tx can
+// still be included in the shard block at shardHeight
+func ValidateExpiryHeight(tx metadata.Transaction, shardHeight uint64, beaconHeight uint64) error {
+	expiryHeight := tx.GetExpiryHeight()
+	if expiryHeight == 0 {
+		return nil
+	}
+	if tx.GetVersion() != utils.TxVersion2Number {
+		return utils.NewTransactionErr(utils.RejectTxExpiryHeight, fmt.Errorf("tx version %d cannot have an expiry height", tx.GetVersion()))
+	}
+	if beaconHeight < config.Param().BCHeightBreakPointTxExpiry {
+		return utils.NewTransactionErr(utils.RejectTxExpiryHeight, fmt.Errorf("tx expiry height is only enabled from beacon height %d", config.Param().BCHeightBreakPointTxExpiry))
+	}
+	if IsTxExpired(tx, shardHeight) {
+		return utils.NewTransactionErr(utils.RejectTxExpired, fmt.Errorf("tx %s expired after shard height %d", tx.Hash().String(), expiryHeight))
+	}
+	return nil
+}
+
+// IsTxExpired returns true if tx cannot be included in the shard block at shardHeight anymore
+func IsTxExpired(tx metadata.Transaction, shardHeight uint64) bool {
+	expiryHeight := tx.GetExpiryHeight()
+	return expiryHeight != 0 && shardHeight > expiryHeight
+}
@@ -0,0 +1,53 @@
+package tx_generic //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/metadata/common/mocks"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockTxWithExpiry(version int8, expiryHeight uint64) *mocks.Transaction {
+	tx := &mocks.Transaction{}
+	tx.On("GetVersion").Return(version)
+	tx.On("GetExpiryHeight").Return(expiryHeight)
+	tx.On("Hash").Return(&common.Hash{})
+	return tx
+}
+
+func TestValidateExpiryHeight(t *testing.T) {
+	config.AbortParam()
+	config.Param().BCHeightBreakPointTxExpiry = 100
+
+	tests := []struct {
+		name         string
+		version      int8
+		expiryHeight uint64
+		shardHeight  uint64
+		beaconHeight uint64
+		wantCode     int
+	}{
+		{"no expiry", 1, 0, 50, 10, 0},
+		{"not expired", 2, 50, 50, 100, 0},
+		{"expired", 2, 50, 51, 100, utils.ErrCodeMessage[utils.RejectTxExpired].Code},
+		{"before break point", 2, 50, 10, 99, utils.ErrCodeMessage[utils.RejectTxExpiryHeight].Code},
+		{"ver 1", 1, 50, 10, 100, utils.ErrCodeMessage[utils.RejectTxExpiryHeight].Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := mockTxWithExpiry(tt.version, tt.expiryHeight)
+			err := ValidateExpiryHeight(tx, tt.shardHeight, tt.beaconHeight)
+			if tt.wantCode == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			txErr, ok := err.(*utils.TransactionError)
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantCode, txErr.Code)
+			assert.Equal(t, tt.wantCode == utils.ErrCodeMessage[utils.RejectTxExpired].Code, IsTxExpired(tx, tt.shardHeight))
+		})
+	}
+}
@@ -36,6 +36,8 @@ type TxTokenParams struct {
 	HasPrivacyToken    bool
 	ShardID            byte
 	Info               []byte
+	// ExpiryHeight is the last shard height the tx can be included at, 0 means no expiry
+	ExpiryHeight uint64
 }
 
 // CustomTokenParamTx - use for rpc request json body
@@ -20,6 +20,8 @@ type TransactionToken interface {
 	SetType(string)
 	GetLockTime() int64
 	SetLockTime(int64)
+	GetExpiryHeight() uint64
+	SetExpiryHeight(uint64)
 	GetSenderAddrLastByte() byte
 	SetGetSenderAddrLastByte(byte)
 	GetTxFee() uint64
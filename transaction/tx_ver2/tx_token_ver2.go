@@ -414,6 +414,7 @@ func (txToken *TxToken) Init(paramsInterface interface{}) error {
 		params.MetaData,
 		params.Info,
 	)
+	txPrivacyParams.ExpiryHeight = params.ExpiryHeight
 	jsb, _ := json.Marshal(params.TokenParams)
 	utils.Logger.Log.Infof("Create TX token v2 with token params %s", string(jsb))
 	if err := tx_generic.ValidateTxParams(txPrivacyParams); err != nil {
@@ -584,31 +585,46 @@ func (txToken *TxToken) verifySig(transactionStateDB *statedb.StateDB, shardID b
 	if txFee.GetSig() == nil || txFee.GetSigPubKey() == nil {
 		return false, utils.NewTransactionErr(utils.UnexpectedError, errors.New("input transaction must be a signed one"))
 	}
-	var err error
 
 	// Verify TxToken Salary: NonPrivacyNonInput
 	if txFee.GetProof() == nil {
-		hashedTokenMessage, err := txToken.TokenData.Hash()
+		return txToken.verifySigNoProof()
+	}
+
+	mlsagSignature, ring, message, err := txToken.reconstructMLSAGSig(transactionStateDB)
+	if err != nil {
+		return false, err
+	}
+	return mlsag.Verify(mlsagSignature, ring, message[:])
+}
+
+func (txToken *TxToken) verifySigNoProof() (bool, error) {
+	txFee := &txToken.Tx
+	hashedTokenMessage, err := txToken.TokenData.Hash()
+	if err != nil {
+		return false, err
+	}
+	message := common.HashH(append(txFee.Hash()[:], hashedTokenMessage[:]...))
+	if valid, err := tx_generic.VerifySigNoPrivacy(txFee.GetSig(), txFee.GetSigPubKey(), message[:]); !valid {
 		if err != nil {
-			return false, err
+			utils.Logger.Log.Debugf("Error verifying signature of tx: %+v", err)
+			return false, utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
 		}
-		message := common.HashH(append(txFee.Hash()[:], hashedTokenMessage[:]...))
-		if valid, err := tx_generic.VerifySigNoPrivacy(txFee.GetSig(), txFee.GetSigPubKey(), message[:]); !valid {
-			if err != nil {
-				utils.Logger.Log.Debugf("Error verifying signature of tx: %+v", err)
-				return false, utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
-			}
-			return false, nil
-		}
-		return true, nil
+		return false, nil
 	}
+	return true, nil
+}
+
+// reconstructMLSAGSig rebuilds the MLSAG signature & ring of the fee-paying sub-transaction, and the message it signs, without verifying them.
+func (txToken *TxToken) reconstructMLSAGSig(transactionStateDB *statedb.StateDB) (*mlsag.Sig, *mlsag.Ring, *common.Hash, error) {
+	txFee := &txToken.Tx
 
 	// Reform Ring
 	sumOutputCoinsWithFee := tx_generic.CalculateSumOutputsWithFee(txFee.GetProof().GetOutputCoins(), txFee.GetTxFee())
 	ring, err := getRingFromSigPubKeyAndLastColumnCommitmentV2(txFee.GetValidationEnv(), sumOutputCoinsWithFee, transactionStateDB)
 	if err != nil {
 		utils.Logger.Log.Errorf("Error when querying database to construct mlsag ring: %v ", err)
-		return false, err
+		return nil, nil, nil, err
 	}
 
 	// Reform MLSAG Signature
@@ -617,7 +633,7 @@ func (txToken *TxToken) verifySig(transactionStateDB *statedb.StateDB, shardID b
 	for i := 0; i < len(inputCoins); i++ {
 		if inputCoins[i].GetKeyImage() == nil {
 			utils.Logger.Log.Errorf("Error when reconstructing mlsagSignature: missing keyImage")
-			return false, err
+			return nil, nil, nil, errors.New("missing keyImage")
 		}
 		keyImages[i] = inputCoins[i].GetKeyImage()
 	}
@@ -626,17 +642,49 @@ func (txToken *TxToken) verifySig(transactionStateDB *statedb.StateDB, shardID b
 	mlsagSignature, err := getMLSAGSigFromTxSigAndKeyImages(txFee.GetSig(), keyImages)
 	if err != nil {
 		utils.Logger.Log.Errorf("Error when reconstructing mlsagSignature: %v ", err)
-		return false, err
+		return nil, nil, nil, err
 	}
 
 	txTokenDataHash, err := txToken.TokenData.Hash()
 	if err != nil {
 		utils.Logger.Log.Errorf("Error when getting txTokenData Hash: %v ", err)
-		return false, err
-
+		return nil, nil, nil, err
 	}
 	message := common.HashH(append(txFee.Hash()[:], txTokenDataHash[:]...))
-	return mlsag.Verify(mlsagSignature, ring, message[:])
+	return mlsagSignature, ring, &message, nil
+}
+
+// AddSigToBatch rebuilds the ring signatures of both sub-transactions and adds them to an MLSAG batch, instead of verifying them.
+// It covers exactly the signatures that ValidateTransaction skips when boolParams["isBatchMlsag"] is set.
+func (txToken TxToken) AddSigToBatch(batch *mlsag.BatchVerifier, transactionStateDB *statedb.StateDB) error {
+	txFee := &txToken.Tx
+	if txFee.GetSig() == nil || txFee.GetSigPubKey() == nil {
+		return utils.NewTransactionErr(utils.UnexpectedError, errors.New("input transaction must be a signed one"))
+	}
+	if txFee.GetProof() == nil {
+		// a salary-like signature is not a ring signature, so it is verified right away
+		if valid, err := txToken.verifySigNoProof(); !valid {
+			if err == nil {
+				err = utils.NewTransactionErr(utils.VerifyTxSigFailError, fmt.Errorf("FAILED VERIFICATION SIGNATURE ver2 (token) with tx hash %s", txToken.Hash().String()))
+			}
+			return err
+		}
+	} else {
+		mlsagSignature, ring, message, err := txToken.reconstructMLSAGSig(transactionStateDB)
+		if err != nil {
+			return utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
+		}
+		batch.Add(mlsagSignature, ring, message[:], false)
+	}
+
+	if txToken.TokenData.Type != utils.CustomTokenTransfer || txToken.GetType() == common.TxTokenConversionType {
+		return nil
+	}
+	txn, ok := txToken.GetTxNormal().(*Tx)
+	if !ok || txn == nil {
+		return utils.NewTransactionErr(utils.UnexpectedError, errors.New("TX token must have token component"))
+	}
+	return txn.AddSigToBatch(batch, transactionStateDB)
 }
 
 // ValidateTxByItself does most of the verification for TxToken, including bulletproofs, signatures & metadata.
@@ -671,10 +719,17 @@ func (txToken TxToken) ValidateTransaction(boolParams map[string]bool, transacti
 	if tokenID, err = tx_generic.ParseTokenID(tokenID); err != nil {
 		return false, nil, err
 	}
-	ok, err := txToken.verifySig(transactionStateDB, shardID, tokenID)
+	isBatchMlsag, ok := boolParams["isBatchMlsag"]
 	if !ok {
-		utils.Logger.Log.Errorf("FAILED VERIFICATION SIGNATURE ver2 (token) with tx hash %s: %+v \n", txToken.Hash().String(), err)
-		return false, nil, utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
+		isBatchMlsag = false
+	}
+	// when batch-verifying, the signatures are skipped here & verified with the whole batch (see AddSigToBatch)
+	if !isBatchMlsag {
+		ok, err := txToken.verifySig(transactionStateDB, shardID, tokenID)
+		if !ok {
+			utils.Logger.Log.Errorf("FAILED VERIFICATION SIGNATURE ver2 (token) with tx hash %s: %+v \n", txToken.Hash().String(), err)
+			return false, nil, utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
+		}
 	}
 
 	// validate for pToken
@@ -685,6 +740,7 @@ func (txToken TxToken) ValidateTransaction(boolParams map[string]bool, transacti
 		if !ok {
 			isBatch = false
 		}
+		resultProofs := make([]privacy.Proof, 0)
 
 		// validate the token sub-transaction
 		var resToken bool
@@ -705,13 +761,14 @@ func (txToken TxToken) ValidateTransaction(boolParams map[string]bool, transacti
 				}
 			}
 
-			// for CA, bulletproof batching is not supported
-			boolParams["isBatch"] = false
+			// when batch-verifying, the token proof is returned & verified with the whole batch
 			boolParams["hasPrivacy"] = true
-			resToken, _, err = txn.ValidateTransaction(boolParams, transactionStateDB, bridgeStateDB, shardID, &tokenIdOnTx)
+			var tokenProofs []privacy.Proof
+			resToken, tokenProofs, err = txn.ValidateTransaction(boolParams, transactionStateDB, bridgeStateDB, shardID, &tokenIdOnTx)
 			if err != nil {
 				return resToken, nil, err
 			}
+			resultProofs = append(resultProofs, tokenProofs...)
 		}
 
 		// validate the fee-paying sub-transaction. The signature part has been verified above, so we skip it here.
@@ -721,11 +778,20 @@ func (txToken TxToken) ValidateTransaction(boolParams map[string]bool, transacti
 		}
 		boolParams["isBatch"] = isBatch
 		boolParams["hasConfidentialAsset"] = false // we are validating the PRV part, so `hasConfidentialAsset` must be false.
+		if isBatch {
+			// the batch picks the Bulletproof variant from the proof itself, so the PRV part must not carry asset tags
+			feeProofAsV2, ok := txFeeProof.(*privacy.ProofV2)
+			if !ok {
+				return false, nil, errors.New("PRV proof of a ver2 token transaction must be of version 2")
+			}
+			if isCA, err := feeProofAsV2.IsConfidentialAsset(); err != nil || isCA {
+				return false, nil, fmt.Errorf("PRV proof of tx %s cannot be batched with asset tags", txToken.Hash().String())
+			}
+		}
 		// when batch-verifying for PRV, bulletproof will be skipped here & verified with the whole batch
 		resTxFee, err := txFeeProof.Verify(boolParams, txToken.Tx.GetSigPubKey(), 0, shardID, &common.PRVCoinID, nil)
-		resultProofs := make([]privacy.Proof, 0)
 		if isBatch {
-			resultProofs = append(resultProofs, txFeeProof)
+			resultProofs = append([]privacy.Proof{txFeeProof}, resultProofs...)
 		}
 		return resTxFee && resToken, resultProofs, err
 
@@ -798,6 +864,11 @@ func (txToken TxToken) GetLockTime() int64 { return txToken.Tx.LockTime }
 
 func (txToken *TxToken) SetLockTime(locktime int64) { txToken.Tx.LockTime = locktime }
 
+// GetExpiryHeight returns the last shard height the transaction can be included at. A pToken transaction only has one ExpiryHeight.
+func (txToken TxToken) GetExpiryHeight() uint64 { return txToken.Tx.ExpiryHeight }
+
+func (txToken *TxToken) SetExpiryHeight(height uint64) { txToken.Tx.ExpiryHeight = height }
+
 // GetSenderAddrLastByte returns the SHARD ID of this transaction sender.
 // It uses this legacy function name for compatibility purposes.
 func (txToken TxToken) GetSenderAddrLastByte() byte { return txToken.Tx.PubKeyLastByteSender }
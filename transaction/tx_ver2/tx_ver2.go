@@ -387,18 +387,26 @@ func getMLSAGSigFromTxSigAndKeyImages(txSig []byte, keyImages []*privacy.Point)
 }
 
 func (tx *Tx) verifySig(transactionStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isNewTransaction bool) (bool, error) {
+	mlsagSignature, ring, err := tx.reconstructMLSAGSig(transactionStateDB)
+	if err != nil {
+		return false, err
+	}
+	return mlsag.Verify(mlsagSignature, ring, tx.Hash()[:])
+}
+
+// reconstructMLSAGSig rebuilds the MLSAG signature & ring of a non-CA transaction, without verifying them.
+func (tx *Tx) reconstructMLSAGSig(transactionStateDB *statedb.StateDB) (*mlsag.Sig, *mlsag.Ring, error) {
 	// check input transaction
 	if tx.Sig == nil || tx.SigPubKey == nil {
-		return false, utils.NewTransactionErr(utils.UnexpectedError, errors.New("input transaction must be a signed one"))
+		return nil, nil, utils.NewTransactionErr(utils.UnexpectedError, errors.New("input transaction must be a signed one"))
 	}
-	var err error
 
 	// Reform Ring
 	sumOutputsWithFee := tx_generic.CalculateSumOutputsWithFee(tx.Proof.GetOutputCoins(), tx.Fee)
 	ring, err := getRingFromSigPubKeyAndLastColumnCommitmentV2(tx.GetValidationEnv(), sumOutputsWithFee, transactionStateDB)
 	if err != nil {
 		utils.Logger.Log.Errorf("Error when querying database to construct mlsag ring: %v ", err)
-		return false, err
+		return nil, nil, err
 	}
 
 	// Reform MLSAG Signature
@@ -407,7 +415,7 @@ func (tx *Tx) verifySig(transactionStateDB *statedb.StateDB, shardID byte, token
 	for i := 0; i < len(inputCoins); i++ {
 		if inputCoins[i].GetKeyImage() == nil {
 			utils.Logger.Log.Errorf("Error when reconstructing mlsagSignature: missing keyImage")
-			return false, err
+			return nil, nil, errors.New("missing keyImage")
 		}
 		keyImages[i] = inputCoins[i].GetKeyImage()
 	}
@@ -415,10 +423,38 @@ func (tx *Tx) verifySig(transactionStateDB *statedb.StateDB, shardID byte, token
 	keyImages[len(inputCoins)] = privacy.RandomPoint()
 	mlsagSignature, err := getMLSAGSigFromTxSigAndKeyImages(tx.Sig, keyImages)
 	if err != nil {
-		return false, err
+		return nil, nil, err
 	}
+	return mlsagSignature, ring, nil
+}
 
-	return mlsag.Verify(mlsagSignature, ring, tx.Hash()[:])
+// AddSigToBatch rebuilds the ring signature of this transaction and adds it to an MLSAG batch, instead of verifying it.
+// It covers exactly the signature that ValidateTransaction skips when boolParams["isBatchMlsag"] is set.
+func (tx Tx) AddSigToBatch(batch *mlsag.BatchVerifier, transactionStateDB *statedb.StateDB) error {
+	switch tx.GetType() {
+	case common.TxRewardType, common.TxReturnStakingType, common.TxConversionType:
+		return nil
+	}
+	proofAsV2, ok := tx.GetProof().(*privacy.ProofV2)
+	if !ok {
+		return utils.NewTransactionErr(utils.UnexpectedError, errors.New("ver2 transaction cannot have proofs of any other version"))
+	}
+	isConfAsset, err := proofAsV2.IsConfidentialAsset()
+	if err != nil {
+		return utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
+	}
+	var mlsagSignature *mlsag.Sig
+	var ring *mlsag.Ring
+	if isConfAsset {
+		mlsagSignature, ring, err = tx.reconstructMLSAGSigCA(transactionStateDB)
+	} else {
+		mlsagSignature, ring, err = tx.reconstructMLSAGSig(transactionStateDB)
+	}
+	if err != nil {
+		return utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
+	}
+	batch.Add(mlsagSignature, ring, tx.Hash()[:], isConfAsset)
+	return nil
 }
 
 // Verify is the sub-function for ValidateTransaction.
@@ -441,12 +477,20 @@ func (tx *Tx) Verify(boolParams map[string]bool, transactionStateDB *statedb.Sta
 		isNewTransaction = false
 	}
 
+	isBatchMlsag, ok := boolParams["isBatchMlsag"]
+	if !ok {
+		isBatchMlsag = false
+	}
+
 	isConfAsset, err := proofAsV2.IsConfidentialAsset()
 	if err != nil {
 		utils.Logger.Log.Errorf("Error in tx %s : proof is invalid due to inconsistent asset tags - %v", tx.Hash().String(), err)
 		return false, utils.NewTransactionErr(utils.VerifyTxSigFailError, err)
 	}
-	if isConfAsset {
+	// when batch-verifying, the signature is skipped here & verified with the whole batch (see AddSigToBatch)
+	if isBatchMlsag {
+		valid = true
+	} else if isConfAsset {
 		utils.Logger.Log.Infof("Verifying transaction with assetTag\n")
 		valid, err = tx.verifySigCA(transactionStateDB, shardID, tokenID, isNewTransaction)
 	} else {
@@ -380,16 +380,22 @@ func reconstructRingCA(sigPubKey []byte, sumOutputsWithFee, sumOutputAssetTags *
 	return mlsag.NewRing(ring), nil
 }
 
-//nolint:staticcheck // overwrite tokenID to fit existing prototype
 func (tx *Tx) verifySigCA(transactionStateDB *statedb.StateDB, shardID byte, tokenID *common.Hash, isNewTransaction bool) (bool, error) {
+	mlsagSignature, ring, err := tx.reconstructMLSAGSigCA(transactionStateDB)
+	if err != nil {
+		return false, err
+	}
+	return mlsag.VerifyConfidentialAsset(mlsagSignature, ring, tx.Hash()[:])
+}
+
+// reconstructMLSAGSigCA rebuilds the MLSAG signature & ring of a confidential asset transaction, without verifying them.
+func (tx *Tx) reconstructMLSAGSigCA(transactionStateDB *statedb.StateDB) (*mlsag.Sig, *mlsag.Ring, error) {
 	// check input transaction
 	if tx.Sig == nil || tx.SigPubKey == nil {
-		return false, utils.NewTransactionErr(utils.UnexpectedError, errors.New("input transaction must be a signed one"))
+		return nil, nil, utils.NewTransactionErr(utils.UnexpectedError, errors.New("input transaction must be a signed one"))
 	}
-	var err error
 
 	// confidential asset TX always use umbrella ID to verify
-	tokenID = &common.ConfidentialAssetID
 	// Reform Ring
 	sumOutputsWithFee := tx_generic.CalculateSumOutputsWithFee(tx.Proof.GetOutputCoins(), tx.Fee)
 	sumOutputAssetTags := new(privacy.Point).Identity()
@@ -397,7 +403,7 @@ func (tx *Tx) verifySigCA(transactionStateDB *statedb.StateDB, shardID byte, tok
 		output_specific, ok := oc.(*privacy.CoinV2)
 		if !ok {
 			utils.Logger.Log.Errorf("Error when casting coin as v2")
-			return false, errors.New("Error when casting coin as v2")
+			return nil, nil, errors.New("Error when casting coin as v2")
 		}
 		sumOutputAssetTags.Add(sumOutputAssetTags, output_specific.GetAssetTag())
 	}
@@ -405,11 +411,10 @@ func (tx *Tx) verifySigCA(transactionStateDB *statedb.StateDB, shardID byte, tok
 	outCount := new(privacy.Scalar).FromUint64(uint64(len(tx.GetProof().GetOutputCoins())))
 	sumOutputAssetTags.ScalarMult(sumOutputAssetTags, inCount)
 
-	// fmt.Printf("Token id is %v\n",tokenID)
 	ring, err := reconstructRingCAV2(tx.GetValidationEnv(), sumOutputsWithFee, sumOutputAssetTags, outCount, transactionStateDB)
 	if err != nil {
 		utils.Logger.Log.Errorf("Error when querying database to construct mlsag ring: %v ", err)
-		return false, err
+		return nil, nil, err
 	}
 
 	// Reform MLSAG Signature
@@ -418,7 +423,7 @@ func (tx *Tx) verifySigCA(transactionStateDB *statedb.StateDB, shardID byte, tok
 	for i := 0; i < len(inputCoins); i++ {
 		if inputCoins[i].GetKeyImage() == nil {
 			utils.Logger.Log.Errorf("Error when reconstructing mlsagSignature: missing keyImage")
-			return false, err
+			return nil, nil, errors.New("missing keyImage")
 		}
 		keyImages[i] = inputCoins[i].GetKeyImage()
 	}
@@ -427,10 +432,9 @@ func (tx *Tx) verifySigCA(transactionStateDB *statedb.StateDB, shardID byte, tok
 	keyImages[len(inputCoins)+1] = privacy.RandomPoint()
 	mlsagSignature, err := getMLSAGSigFromTxSigAndKeyImages(tx.Sig, keyImages)
 	if err != nil {
-		return false, err
+		return nil, nil, err
 	}
-
-	return mlsag.VerifyConfidentialAsset(mlsagSignature, ring, tx.Hash()[:])
+	return mlsagSignature, ring, nil
 }
 
 func createUniqueOTACoinCA(paymentInfo *privacy.PaymentInfo, tokenID *common.Hash, stateDB *statedb.StateDB) (*privacy.CoinV2, *privacy.Point, error) {
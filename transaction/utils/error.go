@@ -69,6 +69,8 @@ const (
 	RejectTxType
 	RejectTxInfoSize
 	RejectTxMedataWithBlockChain
+	RejectTxExpiryHeight
+	RejectTxExpired
 
 	GetCommitmentsInDatabaseError
 	InvalidPaymentAddressError
@@ -123,6 +125,8 @@ var ErrCodeMessage = map[int]struct {
 	BatchTxProofVerifyFailError:                   {-1040, "Can not verify proof of batch txs %s"},
 	VerifyOneOutOfManyProofFailedErr:              {-1041, "Verify one out of many proof failed"},
 	GetShardIDByPublicKeyError:                    {-1042, "Cannot get shard id from public key of input coin"},
+	RejectTxExpiryHeight:                          {-1043, "Invalid tx expiry height"},
+	RejectTxExpired:                               {-1044, "Tx expired"},
 
 	// for PRV
 	InvalidSanityDataPRVError:  {-2000, "Invalid sanity data for PRV"},
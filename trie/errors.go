@@ -12,8 +12,12 @@ import (
 type MissingNodeError struct {
 	NodeHash common.Hash // hash of the missing node
 	Path     []byte      // hex-encoded path to the missing node
+	Pruned   bool        // the database is pruned, the node was most likely deleted with an old state
 }
 
 func (err *MissingNodeError) Error() string {
+	if err.Pruned {
+		return fmt.Sprintf("trie node %x (path %x) %s", err.NodeHash, err.Path, prunedNodeError)
+	}
 	return fmt.Sprintf("missing trie node %x (path %x)", err.NodeHash, err.Path)
 }
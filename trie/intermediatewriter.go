@@ -692,6 +692,25 @@ func (intermediateWriter *IntermediateWriter) Commit(node common.Hash, report bo
 	// Move the trie itself into the batch, flushing if enough data is accumulated
 	//nodes, storage := len(intermediateWriter.dirties), intermediateWriter.dirtiesSize
 
+	// On a pruned database, count the references of the new nodes before writing them
+	if IsPruningEnabled(intermediateWriter.diskdb) {
+		lock := pruneLock(intermediateWriter.diskdb)
+		lock.Lock()
+		defer lock.Unlock()
+
+		refs := newRefCounter(intermediateWriter.diskdb)
+		if err := intermediateWriter.countReferences(node, refs, make(map[common.Hash]struct{})); err != nil {
+			return err
+		}
+		if err := refs.write(batch); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+
 	uncacher := &cleaner{intermediateWriter}
 	if err := intermediateWriter.commit(node, batch, uncacher); err != nil {
 		Logger.log.Error("Failed to commit trie from trie database", "err", err)
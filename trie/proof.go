@@ -16,7 +16,7 @@ import (
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	// Collect all nodes on the path to key.
 	key = keybytesToHex(key)
 	var nodes []node
@@ -79,7 +79,7 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
@@ -90,14 +90,14 @@ func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) e
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *PrefixTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *PrefixTrie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
 // VerifyProof checks merkle proofs. The given proof must contain the value for
 // key in a trie with the given root hash. VerifyProof returns an error if the
 // proof contains invalid trie nodes or the wrong value.
-func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.Database) (value []byte, nodes int, err error) {
+func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.KeyValueReader) (value []byte, nodes int, err error) {
 	key = keybytesToHex(key)
 	wantHash := rootHash
 	for i := 0; ; i++ {
@@ -146,3 +146,39 @@ func get(tn node, key []byte) ([]byte, node) {
 		}
 	}
 }
+
+// ProofList collects the nodes written by Prove so they can be sent over the wire
+type ProofList [][]byte
+
+func (n *ProofList) Put(key []byte, value []byte) error {
+	*n = append(*n, common.CopyBytes(value))
+	return nil
+}
+
+func (n *ProofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// ProofSet serves the nodes of a received proof to VerifyProof, indexed by their hash
+type ProofSet map[common.Hash][]byte
+
+func NewProofSet(nodes [][]byte) ProofSet {
+	set := make(ProofSet)
+	for _, node := range nodes {
+		set[common.Keccak256Hash(node)] = node
+	}
+	return set
+}
+
+func (set ProofSet) Has(key []byte) (bool, error) {
+	_, ok := set[common.BytesToHash(key)]
+	return ok, nil
+}
+
+func (set ProofSet) Get(key []byte) ([]byte, error) {
+	node, ok := set[common.BytesToHash(key)]
+	if !ok {
+		return nil, fmt.Errorf("proof node %x not found", key)
+	}
+	return node, nil
+}
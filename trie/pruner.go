@@ -0,0 +1,454 @@
+package trie
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// Trie nodes are stored under their own hash and shared by every state root reaching them, so a node
+// can only be deleted once nothing needs it anymore. When pruning is enabled the database keeps a
+// reference count per node: one for each parent node written to disk plus one for each block that
+// retained it as a state root. Releasing a block decrements the counts and deletes the nodes dropping
+// to zero, together with the children they were the last parent of.
+//
+// Counts may be too high (a fork that is never finalized), this only leaks nodes until the next Prune.
+// They are never too low: increments are always written before the nodes they protect and decrements
+// together with the deletions causing them.
+var (
+	// pruneHeightKey is present when reference counts are maintained, it holds the height of the last
+	// released block
+	pruneHeightKey = []byte("trie-prune-height")
+	// refCountPrefix + node hash => reference count of the node
+	refCountPrefix = []byte("trie-ref-")
+	// retainedRootsPrefix + block hash => state roots retained by the block
+	retainedRootsPrefix = []byte("trie-retained-")
+)
+
+// prunedNodeError is the message of a MissingNodeError returned on a pruned database
+const prunedNodeError = "has been pruned, historical state is only kept by archive nodes"
+
+// pruneLocks serializes the reference count updates of a database
+var pruneLocks sync.Map
+
+func pruneLock(db incdb.Database) *sync.Mutex {
+	lock, _ := pruneLocks.LoadOrStore(db, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// PruneHeight returns the height of the last released block, and false when pruning is not enabled on db.
+func PruneHeight(db incdb.KeyValueReader) (uint64, bool) {
+	value, err := db.Get(pruneHeightKey)
+	if err != nil || len(value) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(value), true
+}
+
+// IsPruningEnabled reports whether db maintains the reference counts of its trie nodes.
+func IsPruningEnabled(db incdb.KeyValueReader) bool {
+	_, ok := PruneHeight(db)
+	return ok
+}
+
+// IsPrunedError reports whether err was caused by reading a trie node removed by pruning. Most callers
+// wrap errors as text, so the message is checked when the error chain does not hold the node error.
+func IsPrunedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var missing *MissingNodeError
+	if errors.As(err, &missing) {
+		return missing.Pruned
+	}
+	return strings.Contains(err.Error(), prunedNodeError)
+}
+
+func encodeUint64(v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return buf
+}
+
+func prefixedKey(prefix []byte, hash common.Hash) []byte {
+	return append(append(make([]byte, 0, len(prefix)+common.HashSize), prefix...), hash[:]...)
+}
+
+func refCountKey(hash common.Hash) []byte {
+	return prefixedKey(refCountPrefix, hash)
+}
+
+func retainedRootsKey(block common.Hash) []byte {
+	return prefixedKey(retainedRootsPrefix, block)
+}
+
+func hasPrefixedHash(key, prefix []byte) bool {
+	return len(key) == len(prefix)+common.HashSize && string(key[:len(prefix)]) == string(prefix)
+}
+
+func isEmptyRoot(root common.Hash) bool {
+	return root == (common.Hash{}) || root == emptyRoot
+}
+
+// refCounter caches the reference counts read and updated during one operation
+type refCounter struct {
+	db     incdb.KeyValueReader
+	counts map[common.Hash]uint32
+}
+
+func newRefCounter(db incdb.KeyValueReader) *refCounter {
+	return &refCounter{db: db, counts: make(map[common.Hash]uint32)}
+}
+
+func (r *refCounter) get(hash common.Hash) uint32 {
+	if count, ok := r.counts[hash]; ok {
+		return count
+	}
+	count := uint32(0)
+	if value, err := r.db.Get(refCountKey(hash)); err == nil && len(value) == 4 {
+		count = binary.BigEndian.Uint32(value)
+	}
+	r.counts[hash] = count
+	return count
+}
+
+func (r *refCounter) inc(hash common.Hash) {
+	r.counts[hash] = r.get(hash) + 1
+}
+
+// dec decrements the count of hash and reports whether it reached zero, a count already at zero is
+// left alone
+func (r *refCounter) dec(hash common.Hash) bool {
+	count := r.get(hash)
+	if count == 0 {
+		return false
+	}
+	r.counts[hash] = count - 1
+	return count == 1
+}
+
+// write puts the updated counts into batch, a zero count deletes its key
+func (r *refCounter) write(batch incdb.KeyValueWriter) error {
+	buf := make([]byte, 4)
+	for hash, count := range r.counts {
+		var err error
+		if count == 0 {
+			err = batch.Delete(refCountKey(hash))
+		} else {
+			binary.BigEndian.PutUint32(buf, count)
+			err = batch.Put(refCountKey(hash), buf)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeChildren returns the hashes referenced by a node decoded from disk, through its embedded nodes.
+// It matches gatherChildren on the collapsed form of the same node.
+func nodeChildren(n node, children *[]common.Hash) {
+	switch n := n.(type) {
+	case *shortNode:
+		nodeChildren(n.Val, children)
+	case *fullNode:
+		for i := 0; i < 16; i++ {
+			nodeChildren(n.Children[i], children)
+		}
+	case hashNode:
+		*children = append(*children, common.BytesToHash(n))
+	}
+}
+
+// countReferences increments the counts of the children of every dirty node under hash that is not on
+// disk yet. Nodes already on disk had their children counted when they were first written.
+func (intermediateWriter *IntermediateWriter) countReferences(hash common.Hash, refs *refCounter, seen map[common.Hash]struct{}) error {
+	node, ok := intermediateWriter.dirties[hash]
+	if !ok {
+		return nil
+	}
+	if _, ok := seen[hash]; ok {
+		return nil
+	}
+	seen[hash] = struct{}{}
+	if has, err := intermediateWriter.diskdb.Has(hash[:]); err != nil {
+		return err
+	} else if has {
+		return nil
+	}
+	for _, child := range node.childs() {
+		refs.inc(child)
+		if err := intermediateWriter.countReferences(child, refs, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RetainRoots adds a reference to the state roots of a block, keeping their tries until the block is
+// released. The roots are recorded with the block so that releasing it drops exactly these references;
+// retaining a block twice, when it is processed again after a crash, is a no-op. It does nothing when
+// pruning is not enabled on db.
+func RetainRoots(db incdb.Database, block common.Hash, roots ...common.Hash) error {
+	if !IsPruningEnabled(db) {
+		return nil
+	}
+	lock := pruneLock(db)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if has, err := db.Has(retainedRootsKey(block)); err != nil || has {
+		return err
+	}
+	refs := newRefCounter(db)
+	retained := make([]byte, 0, len(roots)*common.HashSize)
+	for _, root := range roots {
+		if !isEmptyRoot(root) {
+			refs.inc(root)
+			retained = append(retained, root[:]...)
+		}
+	}
+	batch := db.NewBatch()
+	if err := refs.write(batch); err != nil {
+		return err
+	}
+	if err := batch.Put(retainedRootsKey(block), retained); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// ReleaseRoots drops the references retained by a block, deletes the trie nodes no longer referenced
+// and records height as the last released block, all in one write. A block that retained nothing, like
+// the ones stored before pruning was enabled, only moves the height. It returns the number of deleted
+// nodes.
+func ReleaseRoots(db incdb.Database, height uint64, block common.Hash) (int, error) {
+	if !IsPruningEnabled(db) {
+		return 0, errors.New("state pruning is not enabled")
+	}
+	lock := pruneLock(db)
+	lock.Lock()
+	defer lock.Unlock()
+
+	refs := newRefCounter(db)
+	batch := db.NewBatch()
+	var unreferenced []common.Hash
+	if retained, err := db.Get(retainedRootsKey(block)); err == nil {
+		for i := 0; i+common.HashSize <= len(retained); i += common.HashSize {
+			root := common.BytesToHash(retained[i : i+common.HashSize])
+			if refs.dec(root) {
+				unreferenced = append(unreferenced, root)
+			}
+		}
+		if err := batch.Delete(retainedRootsKey(block)); err != nil {
+			return 0, err
+		}
+	}
+	deleted := 0
+	for len(unreferenced) > 0 {
+		hash := unreferenced[len(unreferenced)-1]
+		unreferenced = unreferenced[:len(unreferenced)-1]
+		enc, err := db.Get(hash[:])
+		if err != nil || len(enc) == 0 {
+			continue
+		}
+		n, err := decodeNode(hash[:], enc)
+		if err != nil {
+			return deleted, fmt.Errorf("decode trie node %x: %v", hash, err)
+		}
+		children := make([]common.Hash, 0, 16)
+		nodeChildren(n, &children)
+		for _, child := range children {
+			if refs.dec(child) {
+				unreferenced = append(unreferenced, child)
+			}
+		}
+		if err := batch.Delete(hash[:]); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	if err := refs.write(batch); err != nil {
+		return deleted, err
+	}
+	if err := batch.Put(pruneHeightKey, encodeUint64(height)); err != nil {
+		return deleted, err
+	}
+	return deleted, batch.Write()
+}
+
+// PruneStats reports the outcome of Prune
+type PruneStats struct {
+	Roots        int                // state roots kept
+	Nodes        int                // trie nodes kept
+	DeletedNodes int                // trie nodes deleted
+	DeletedSize  common.StorageSize // size of the deleted nodes
+}
+
+func (stats *PruneStats) String() string {
+	return fmt.Sprintf("kept %v roots and %v trie nodes, deleted %v trie nodes (%v)", stats.Roots, stats.Nodes, stats.DeletedNodes, stats.DeletedSize)
+}
+
+// markReachable counts the references of every node under root that was not reached yet
+func markReachable(db incdb.KeyValueReader, root common.Hash, refs map[common.Hash]uint32, reachable map[common.Hash]struct{}) error {
+	pending := []common.Hash{root}
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if _, ok := reachable[hash]; ok {
+			continue
+		}
+		enc, err := db.Get(hash[:])
+		if err != nil || len(enc) == 0 {
+			return &MissingNodeError{NodeHash: hash, Pruned: IsPruningEnabled(db)}
+		}
+		n, err := decodeNode(hash[:], enc)
+		if err != nil {
+			return fmt.Errorf("decode trie node %x: %v", hash, err)
+		}
+		reachable[hash] = struct{}{}
+		children := make([]common.Hash, 0, 16)
+		nodeChildren(n, &children)
+		for _, child := range children {
+			refs[child]++
+			pending = append(pending, child)
+		}
+	}
+	return nil
+}
+
+// Prune deletes every trie node of db that is not reachable from the state roots of the keep blocks,
+// rebuilds the reference counts as if each of these blocks had been retained and enables pruning with
+// height as the last released block. Prune must not run while the chain writes into db; it can be
+// interrupted and run again, pruning is only enabled once it completes.
+func Prune(db incdb.Database, height uint64, keep map[common.Hash][]common.Hash) (*PruneStats, error) {
+	lock := pruneLock(db)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// mark: count the references of every node reachable from the kept roots
+	stats := &PruneStats{}
+	refs := make(map[common.Hash]uint32)
+	reachable := make(map[common.Hash]struct{})
+	retained := make(map[common.Hash][]byte)
+	for block, roots := range keep {
+		retained[block] = []byte{}
+		for _, root := range roots {
+			if isEmptyRoot(root) {
+				continue
+			}
+			if err := markReachable(db, root, refs, reachable); err != nil {
+				return nil, err
+			}
+			retained[block] = append(retained[block], root[:]...)
+			refs[root]++
+			stats.Roots++
+		}
+	}
+	stats.Nodes = len(reachable)
+	if err := db.Delete(pruneHeightKey); err != nil {
+		return nil, err
+	}
+
+	// sweep: delete the unreachable nodes and the previous pruning records
+	batch := db.NewBatch()
+	iter := db.NewIterator()
+	for iter.Next() {
+		key := iter.Key()
+		switch {
+		case len(key) == common.HashSize:
+			hash := common.BytesToHash(key)
+			if _, ok := reachable[hash]; ok || common.Keccak256Hash(iter.Value()) != hash {
+				continue
+			}
+			stats.DeletedNodes++
+			stats.DeletedSize += common.StorageSize(len(key) + len(iter.Value()))
+		case hasPrefixedHash(key, refCountPrefix), hasPrefixedHash(key, retainedRootsPrefix):
+		default:
+			continue
+		}
+		if err := batch.Delete(common.CopyBytes(key)); err != nil {
+			iter.Release()
+			return nil, err
+		}
+		if batch.ValueSize() >= incdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				iter.Release()
+				return nil, err
+			}
+			batch.Reset()
+		}
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	batch.Reset()
+
+	buf := make([]byte, 4)
+	for hash, count := range refs {
+		binary.BigEndian.PutUint32(buf, count)
+		if err := batch.Put(refCountKey(hash), buf); err != nil {
+			return nil, err
+		}
+		if batch.ValueSize() >= incdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return nil, err
+			}
+			batch.Reset()
+		}
+	}
+	for block, roots := range retained {
+		if err := batch.Put(retainedRootsKey(block), roots); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Put(pruneHeightKey, encodeUint64(height)); err != nil {
+		return nil, err
+	}
+	return stats, batch.Write()
+}
+
+// DisablePruning stops maintaining reference counts in db and deletes the pruning records. The nodes
+// already pruned are not restored, the database only keeps every state written from now on.
+func DisablePruning(db incdb.Database) error {
+	lock := pruneLock(db)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := db.Delete(pruneHeightKey); err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	for _, prefix := range [][]byte{refCountPrefix, retainedRootsPrefix} {
+		iter := db.NewIteratorWithPrefix(prefix)
+		for iter.Next() {
+			if err := batch.Delete(common.CopyBytes(iter.Key())); err != nil {
+				iter.Release()
+				return err
+			}
+			if batch.ValueSize() >= incdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					iter.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,136 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incdb/memdb"
+	"github.com/stretchr/testify/assert"
+)
+
+// commitState writes the updates on top of root and returns the new root
+func commitState(t *testing.T, db incdb.Database, root common.Hash, from, to int, version string) common.Hash {
+	iw := NewIntermediateWriter(db)
+	tr, err := New(root, iw)
+	assert.Nil(t, err)
+	for i := from; i < to; i++ {
+		assert.Nil(t, tr.TryUpdate(common.Keccak256Hash([]byte(fmt.Sprint(i))).Bytes(), []byte(version+fmt.Sprint(i))))
+	}
+	newRoot, err := tr.Commit(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, iw.Commit(newRoot, false))
+	return newRoot
+}
+
+func assertState(t *testing.T, db incdb.Database, root common.Hash, n int) {
+	tr, err := New(root, NewIntermediateWriter(db))
+	assert.Nil(t, err)
+	count := 0
+	it := NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		count++
+	}
+	assert.Nil(t, it.Err)
+	assert.Equal(t, n, count)
+}
+
+func trieContent(db incdb.Database) (nodes int, refs map[string]string) {
+	refs = make(map[string]string)
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		if len(iter.Key()) == common.HashSize {
+			nodes++
+		} else if hasPrefixedHash(iter.Key(), refCountPrefix) || hasPrefixedHash(iter.Key(), retainedRootsPrefix) {
+			refs[string(iter.Key())] = string(iter.Value())
+		}
+	}
+	return nodes, refs
+}
+
+func TestPruner_RetainRelease(t *testing.T) {
+	db := memdb.New("")
+	_, err := Prune(db, 0, nil)
+	assert.Nil(t, err)
+	block1, block2, block3 := common.HashH([]byte{1}), common.HashH([]byte{2}), common.HashH([]byte{3})
+
+	root1 := commitState(t, db, common.Hash{}, 0, 500, "a")
+	assert.Nil(t, RetainRoots(db, block1, root1))
+	root2 := commitState(t, db, root1, 0, 20, "b")
+	assert.Nil(t, RetainRoots(db, block2, root2, emptyRoot))
+	assert.Nil(t, RetainRoots(db, block2, root2), "a block is retained once")
+	root3 := commitState(t, db, root2, 500, 510, "c")
+	assert.Nil(t, RetainRoots(db, block3, root3, root3))
+
+	// the counts maintained online are the ones a full mark and sweep rebuilds
+	nodes, online := trieContent(db)
+	_, err = Prune(db, 0, map[common.Hash][]common.Hash{
+		block1: {root1},
+		block2: {root2},
+		block3: {root3, root3},
+	})
+	assert.Nil(t, err)
+	rebuiltNodes, rebuilt := trieContent(db)
+	assert.Equal(t, nodes, rebuiltNodes)
+	assert.Equal(t, online, rebuilt)
+
+	deleted, err := ReleaseRoots(db, 1, block1)
+	assert.Nil(t, err)
+	assert.True(t, deleted > 0)
+	height, ok := PruneHeight(db)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), height)
+	assertState(t, db, root2, 500)
+
+	_, err = New(root1, NewIntermediateWriter(db))
+	assert.NotNil(t, err)
+	assert.True(t, IsPrunedError(err))
+	assert.True(t, IsPrunedError(fmt.Errorf("restore view: %+v", err)))
+
+	_, err = ReleaseRoots(db, 2, block2)
+	assert.Nil(t, err)
+	assertState(t, db, root3, 510)
+	deleted, err = ReleaseRoots(db, 2, block2)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, deleted, "a block is released once")
+	_, err = ReleaseRoots(db, 3, block3)
+	assert.Nil(t, err)
+	nodes, refs := trieContent(db)
+	assert.Equal(t, 0, nodes)
+	assert.Equal(t, 0, len(refs))
+}
+
+func TestPruner_Prune(t *testing.T) {
+	db := memdb.New("")
+	root1 := commitState(t, db, common.Hash{}, 0, 300, "a")
+	root2 := commitState(t, db, root1, 0, 300, "b")
+	assert.Nil(t, db.Put([]byte("other-key"), []byte{1}))
+	assert.False(t, IsPruningEnabled(db))
+	assert.Nil(t, RetainRoots(db, common.HashH([]byte{1}), root1), "retaining on an archive database is a no-op")
+
+	stats, err := Prune(db, 7, map[common.Hash][]common.Hash{common.HashH([]byte{2}): {root2}})
+	assert.Nil(t, err)
+	assert.True(t, stats.DeletedNodes > 0)
+	assert.Equal(t, 1, stats.Roots)
+	height, ok := PruneHeight(db)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(7), height)
+	assertState(t, db, root2, 300)
+	_, err = New(root1, NewIntermediateWriter(db))
+	assert.True(t, IsPrunedError(err))
+	has, _ := db.Has([]byte("other-key"))
+	assert.True(t, has)
+
+	_, err = Prune(db, 8, map[common.Hash][]common.Hash{common.HashH([]byte{1}): {root1}})
+	assert.NotNil(t, err, "a pruned root cannot be kept")
+	height, _ = PruneHeight(db)
+	assert.Equal(t, uint64(7), height)
+
+	assert.Nil(t, DisablePruning(db))
+	assert.False(t, IsPruningEnabled(db))
+	_, refs := trieContent(db)
+	assert.Equal(t, 0, len(refs))
+	assertState(t, db, root2, 300)
+}
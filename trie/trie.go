@@ -379,7 +379,7 @@ func (t *Trie) resolveHash(n hashNode, prefix []byte) (node, error) {
 	if node := t.iw.node(hash); node != nil {
 		return node, nil
 	}
-	return nil, &MissingNodeError{NodeHash: hash, Path: prefix}
+	return nil, &MissingNodeError{NodeHash: hash, Path: prefix, Pruned: IsPruningEnabled(t.iw.diskdb)}
 }
 
 // Hash returns the root hash of the trie. It does not write to the
@@ -71,6 +71,7 @@ type TxPool interface {
 	snapshotPool() TxsData
 	snapshotPoolOutCoin() map[common.Hash]interface{}
 	getTxByHash(txID string) metadata.Transaction
+	getExpiredTxByHash(txID string) metadata.Transaction
 	RemoveTx(txHash string)
 }
 
@@ -3,11 +3,13 @@ package txpool
 import (
 	"fmt"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics/grafana"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
@@ -63,6 +65,8 @@ type TxsPool struct {
 	better    func(txA, txB metadata.Transaction) bool
 	ttl       time.Duration
 	CData     CoinsData
+	// Expired keeps the txs evicted because of their expiry height for ttl
+	Expired *cache.Cache
 }
 
 func NewTxsPool(
@@ -95,6 +99,7 @@ func NewTxsPool(
 			TxHashByCoin:  map[string]string{},
 			CoinsByTxHash: map[string][]string{},
 		},
+		Expired: cache.New(ttl, ttl),
 	}
 	removeTx := func(txHash string, arg interface{}) {
 		go func(txPool *TxsPool, target string) {
@@ -278,6 +283,19 @@ func (tp *TxsPool) addTx(validTx txInfoTemp, listCoinKey []string) {
 	for _, v := range listCoinKey {
 		tp.CData.TxHashByCoin[v] = validTx.tx.Hash().String()
 	}
+	shardID := strconv.Itoa(int(common.GetShardIDFromLastByte(validTx.tx.GetSenderAddrLastByte())))
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.TxPoolAddedAfterValidation,
+		grafana.MeasurementValue: validTx.vt.Seconds(),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         shardID,
+	})
+	grafana.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		grafana.Measurement:      grafana.PoolSize,
+		grafana.MeasurementValue: float64(len(tp.Data.TxInfos)),
+		grafana.Tag:              grafana.ShardIDTag,
+		grafana.TagValue:         shardID,
+	})
 }
 
 func (tp *TxsPool) removeDoubleSpendTx(txH string) {
@@ -392,6 +410,13 @@ func (tp *TxsPool) FilterWithNewView(
 		Logger.Infof("SHARD %v | Filter mempool with bview %v, sview %v; del %v txs, remaining %v \n", sView.GetShardID(), bcView.GetHeight(), sView.GetHeight(), len(txsToRemove), len(txsValid))
 	}()
 	for txHash, tx := range txsData.TxByHash {
+		// the next block of sView is past the expiry height
+		if tx_generic.IsTxExpired(tx, sView.GetHeight()+1) {
+			Logger.Infof("[txTracing] Tx %v expired at shard height %v with sView %v\n", txHash, tx.GetExpiryHeight(), sView.GetHeight())
+			tp.Expired.SetDefault(txHash, tx)
+			txsToRemove = append(txsToRemove, txHash)
+			continue
+		}
 		if tp.isDoubleStake(mapForChkDbStake, tx) {
 			Logger.Errorf("[txTracing] Tx %v is stake/unstake/stop auto stake twice with sView %v\n", txHash, sView.GetHeight())
 			continue
@@ -825,6 +850,13 @@ func (tp *TxsPool) getTxByHash(txID string) metadata.Transaction {
 	return <-cData
 }
 
+func (tp *TxsPool) getExpiredTxByHash(txID string) metadata.Transaction {
+	if tx, ok := tp.Expired.Get(txID); ok {
+		return tx.(metadata.Transaction)
+	}
+	return nil
+}
+
 func (tp *TxsPool) getTxsFromPool(
 	txCh chan *TxInfoDetail,
 	stopC <-chan interface{},
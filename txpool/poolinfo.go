@@ -1,8 +1,9 @@
 package txpool
 
 type GetMempoolInfoTx struct {
-	TxID     string
-	LockTime int64
+	TxID         string
+	LockTime     int64
+	ExpiryHeight uint64
 }
 
 func (infoTx *GetMempoolInfoTx) GetTxID() string {
@@ -11,10 +12,14 @@ func (infoTx *GetMempoolInfoTx) GetTxID() string {
 func (infoTx *GetMempoolInfoTx) GetLockTime() int64 {
 	return infoTx.LockTime
 }
+func (infoTx *GetMempoolInfoTx) GetExpiryHeight() uint64 {
+	return infoTx.ExpiryHeight
+}
 
 type MempoolInfoTx interface {
 	GetTxID() string
 	GetLockTime() int64
+	GetExpiryHeight() uint64
 }
 
 type GetMempoolInfo struct {
@@ -124,8 +124,9 @@ func (pm *PoolManager) GetMempoolInfo() MempoolInfo {
 		res.Size += len(txsData.TxByHash)
 		for txHash, tx := range txsData.TxByHash {
 			res.ListTxs = append(res.ListTxs, &GetMempoolInfoTx{
-				TxID:     txHash,
-				LockTime: tx.GetLockTime(),
+				TxID:         txHash,
+				LockTime:     tx.GetLockTime(),
+				ExpiryHeight: tx.GetExpiryHeight(),
 			})
 			if txInfo, ok := txsData.TxInfos[txHash]; ok {
 				res.Bytes += txInfo.Size
@@ -154,6 +155,16 @@ func (pm *PoolManager) GetTransactionByHash(txHash string) (metadata.Transaction
 	return nil, errors.Errorf("Transaction %v not found in mempool", txHash)
 }
 
+// GetExpiredTransactionByHash returns a tx recently evicted from the pool because of its expiry height
+func (pm *PoolManager) GetExpiredTransactionByHash(txHash string) (metadata.Transaction, error) {
+	for _, txPool := range pm.ShardTxsPool {
+		if tx := txPool.getExpiredTxByHash(txHash); tx != nil {
+			return tx, nil
+		}
+	}
+	return nil, errors.Errorf("Transaction %v not found in expired txs", txHash)
+}
+
 func (pm *PoolManager) RemoveTransactionInPool(txHash string) {
 	for _, txPool := range pm.ShardTxsPool {
 		if txPool.IsRunning() {
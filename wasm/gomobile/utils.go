@@ -6,6 +6,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/stateproof"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/privacy/privacy_v1/hybridencryption"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -199,3 +200,9 @@ func HybridDecryptionASM(dataB64Encode string) (string, error) {
 	res := base64.StdEncoding.EncodeToString(plaintextBytes)
 	return res, nil
 }
+
+// VerifyStateProof takes a trusted state root and a getstateproof RPC result, it returns the hex encoded
+// proven value, empty if the proof shows the absence of the object. The root must come from a trusted node.
+func VerifyStateProof(trustedRoot string, stateProof string) (string, error) {
+	return stateproof.VerifyJSON(trustedRoot, stateProof)
+}
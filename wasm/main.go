@@ -3,6 +3,7 @@
 package main
 
 import (
+	"github.com/incognitochain/incognito-chain/dataaccessobject/stateproof"
 	"github.com/incognitochain/incognito-chain/wasm/gomobile"
 	"syscall/js"
 )
@@ -211,6 +212,16 @@ func verifySign(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// verifyStateProof takes a trusted state root and a getstateproof RPC result
+func verifyStateProof(_ js.Value, args []js.Value) interface{} {
+	result, err := stateproof.VerifyJSON(args[0].String(), args[1].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
 func main() {
 	c := make(chan struct{}, 0)
 	println("Hello WASM")
@@ -243,5 +254,7 @@ func main() {
 	js.Global().Set("signPoolWithdraw", js.FuncOf(signPoolWithdraw))
 	js.Global().Set("verifySign", js.FuncOf(verifySign))
 
+	js.Global().Set("verifyStateProof", js.FuncOf(verifyStateProof))
+
 	<-c
 }